@@ -29,6 +29,7 @@ var (
 			subcmdRegenerate,
 			subcmdAuth,
 			subcmdSendMail,
+			subcmdPackages,
 		},
 	}
 
@@ -59,6 +60,8 @@ var (
 			microcmdAuthUpdateLdapSimpleAuth(),
 			microcmdAuthAddSMTP(),
 			microcmdAuthUpdateSMTP(),
+			microcmdAuthAddSAML(),
+			microcmdAuthUpdateSAML(),
 			microcmdAuthList,
 			microcmdAuthDelete,
 		},
@@ -0,0 +1,282 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/auth/source/saml"
+
+	"github.com/urfave/cli/v3"
+)
+
+func samlCLIFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "name",
+			Value: "",
+			Usage: "Authentication name",
+		},
+		&cli.StringFlag{
+			Name:  "idp-sso-url",
+			Value: "",
+			Usage: "Single sign-on URL of the identity provider",
+		},
+		&cli.StringFlag{
+			Name:  "idp-entity-id",
+			Value: "",
+			Usage: "Entity ID of the identity provider, the issuer of responses is checked against it",
+		},
+		&cli.StringFlag{
+			Name:  "idp-certificate",
+			Value: "",
+			Usage: "PEM encoded signing certificate of the identity provider",
+		},
+		&cli.StringFlag{
+			Name:  "idp-certificate-file",
+			Value: "",
+			Usage: "Path to the PEM encoded signing certificate of the identity provider",
+		},
+		&cli.StringFlag{
+			Name:  "name-id-format",
+			Value: "",
+			Usage: "NameID format to request from the identity provider",
+		},
+		&cli.BoolFlag{
+			Name:  "sign-requests",
+			Usage: "Sign authentication requests, a key pair is generated if none is given",
+		},
+		&cli.StringFlag{
+			Name:  "sp-certificate-file",
+			Value: "",
+			Usage: "Path to the PEM encoded certificate of the service provider",
+		},
+		&cli.StringFlag{
+			Name:  "sp-private-key-file",
+			Value: "",
+			Usage: "Path to the PEM encoded private key of the service provider",
+		},
+		&cli.BoolFlag{
+			Name:  "allow-idp-initiated",
+			Usage: "Allow sign in initiated by the identity provider",
+		},
+		&cli.StringFlag{
+			Name:  "icon-url",
+			Value: "",
+			Usage: "Custom icon URL for SAML login source",
+		},
+		&cli.BoolFlag{
+			Name:  "skip-local-2fa",
+			Usage: "Set to true to skip local 2fa for users authenticated by this source",
+		},
+		&cli.StringFlag{
+			Name:  "username-attribute",
+			Value: "",
+			Usage: "Attribute providing the username, the NameID is used if empty",
+		},
+		&cli.StringFlag{
+			Name:  "email-attribute",
+			Value: "",
+			Usage: "Attribute providing the email address",
+		},
+		&cli.StringFlag{
+			Name:  "full-name-attribute",
+			Value: "",
+			Usage: "Attribute providing the full name",
+		},
+		&cli.StringFlag{
+			Name:  "group-attribute",
+			Value: "",
+			Usage: "Attribute providing group names for this source",
+		},
+		&cli.StringFlag{
+			Name:  "admin-group",
+			Value: "",
+			Usage: "Group value for administrator users",
+		},
+		&cli.StringFlag{
+			Name:  "restricted-group",
+			Value: "",
+			Usage: "Group value for restricted users",
+		},
+		&cli.StringFlag{
+			Name:  "group-team-map",
+			Value: "",
+			Usage: "JSON mapping between groups and org teams",
+		},
+		&cli.BoolFlag{
+			Name:  "group-team-map-removal",
+			Usage: "Activate automatic team membership removal depending on groups",
+		},
+	}
+}
+
+func microcmdAuthAddSAML() *cli.Command {
+	return &cli.Command{
+		Name:  "add-saml",
+		Usage: "Add new SAML authentication source",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return newAuthService().runAddSAML(ctx, cmd)
+		},
+		Flags: samlCLIFlags(),
+	}
+}
+
+func microcmdAuthUpdateSAML() *cli.Command {
+	return &cli.Command{
+		Name:  "update-saml",
+		Usage: "Update existing SAML authentication source",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return newAuthService().runUpdateSAML(ctx, cmd)
+		},
+		Flags: append(samlCLIFlags()[:1], append([]cli.Flag{&cli.Int64Flag{
+			Name:  "id",
+			Usage: "ID of authentication source",
+		}}, samlCLIFlags()[1:]...)...),
+	}
+}
+
+func readFileFlag(c *cli.Command, name string) (string, error) {
+	bs, err := os.ReadFile(c.String(name))
+	if err != nil {
+		return "", fmt.Errorf("unable to read --%s: %w", name, err)
+	}
+	return string(bs), nil
+}
+
+func parseSAMLConfig(c *cli.Command, conf *saml.Source) error {
+	if c.IsSet("idp-sso-url") {
+		conf.IdentityProviderSSOURL = c.String("idp-sso-url")
+	}
+	if c.IsSet("idp-entity-id") {
+		conf.IdentityProviderEntityID = c.String("idp-entity-id")
+	}
+	if c.IsSet("idp-certificate") {
+		conf.IdentityProviderCertificate = c.String("idp-certificate")
+	}
+	if c.IsSet("idp-certificate-file") {
+		cert, err := readFileFlag(c, "idp-certificate-file")
+		if err != nil {
+			return err
+		}
+		conf.IdentityProviderCertificate = cert
+	}
+	if c.IsSet("name-id-format") {
+		conf.NameIDFormat = c.String("name-id-format")
+	}
+	if c.IsSet("sign-requests") {
+		conf.SignRequests = c.Bool("sign-requests")
+	}
+	if c.IsSet("sp-certificate-file") {
+		cert, err := readFileFlag(c, "sp-certificate-file")
+		if err != nil {
+			return err
+		}
+		conf.ServiceProviderCertificate = cert
+	}
+	if c.IsSet("sp-private-key-file") {
+		key, err := readFileFlag(c, "sp-private-key-file")
+		if err != nil {
+			return err
+		}
+		conf.ServiceProviderPrivateKey = key
+	}
+	if c.IsSet("allow-idp-initiated") {
+		conf.AllowIdPInitiated = c.Bool("allow-idp-initiated")
+	}
+	if c.IsSet("icon-url") {
+		conf.IconURL = c.String("icon-url")
+	}
+	if c.IsSet("username-attribute") {
+		conf.UsernameAttribute = c.String("username-attribute")
+	}
+	if c.IsSet("email-attribute") {
+		conf.EmailAttribute = c.String("email-attribute")
+	}
+	if c.IsSet("full-name-attribute") {
+		conf.FullNameAttribute = c.String("full-name-attribute")
+	}
+	if c.IsSet("group-attribute") {
+		conf.GroupAttribute = c.String("group-attribute")
+	}
+	if c.IsSet("admin-group") {
+		conf.AdminGroup = c.String("admin-group")
+	}
+	if c.IsSet("restricted-group") {
+		conf.RestrictedGroup = c.String("restricted-group")
+	}
+	if c.IsSet("group-team-map") {
+		conf.GroupTeamMap = c.String("group-team-map")
+	}
+	if c.IsSet("group-team-map-removal") {
+		conf.GroupTeamMapRemoval = c.Bool("group-team-map-removal")
+	}
+
+	if conf.SignRequests {
+		if err := conf.GenerateServiceProviderKey(setting.Domain); err != nil {
+			return err
+		}
+	}
+	return conf.Validate()
+}
+
+func (a *authService) runAddSAML(ctx context.Context, c *cli.Command) error {
+	if err := a.initDB(ctx); err != nil {
+		return err
+	}
+
+	if !c.IsSet("name") || len(c.String("name")) == 0 {
+		return errors.New("name must be set")
+	}
+
+	var samlConfig saml.Source
+	if err := parseSAMLConfig(c, &samlConfig); err != nil {
+		return err
+	}
+
+	return a.createAuthSource(ctx, &auth_model.Source{
+		Type:            auth_model.SAML,
+		Name:            c.String("name"),
+		IsActive:        true,
+		Cfg:             &samlConfig,
+		TwoFactorPolicy: util.Iif(c.Bool("skip-local-2fa"), "skip", ""),
+	})
+}
+
+func (a *authService) runUpdateSAML(ctx context.Context, c *cli.Command) error {
+	if !c.IsSet("id") {
+		return errors.New("--id flag is missing")
+	}
+
+	if err := a.initDB(ctx); err != nil {
+		return err
+	}
+
+	source, err := a.getAuthSourceByID(ctx, c.Int64("id"))
+	if err != nil {
+		return err
+	}
+
+	samlConfig, ok := source.Cfg.(*saml.Source)
+	if !ok {
+		return fmt.Errorf("authentication source %d is not a SAML source", source.ID)
+	}
+	if err := parseSAMLConfig(c, samlConfig); err != nil {
+		return err
+	}
+
+	if c.IsSet("name") {
+		source.Name = c.String("name")
+	}
+
+	source.Cfg = samlConfig
+	source.TwoFactorPolicy = util.Iif(c.Bool("skip-local-2fa"), "skip", "")
+	return a.updateAuthSource(ctx, source)
+}
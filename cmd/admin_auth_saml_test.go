@@ -0,0 +1,252 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/services/auth/source/saml"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func testSAMLCertificate(t *testing.T) string {
+	idp := &saml.Source{}
+	require.NoError(t, idp.GenerateServiceProviderKey("idp.example.com"))
+	return idp.ServiceProviderCertificate
+}
+
+func TestAddSAML(t *testing.T) {
+	cert := testSAMLCertificate(t)
+
+	testCases := []struct {
+		name   string
+		args   []string
+		source *auth_model.Source
+		errMsg string
+	}{
+		{
+			name: "missing name",
+			args: []string{
+				"--idp-sso-url", "https://idp.example.com/sso",
+				"--idp-certificate", cert,
+			},
+			errMsg: "name must be set",
+		},
+		{
+			name: "missing certificate",
+			args: []string{
+				"--name", "test",
+				"--idp-sso-url", "https://idp.example.com/sso",
+			},
+			errMsg: "the certificate of the identity provider is required",
+		},
+		{
+			name: "valid config",
+			args: []string{
+				"--name", "test",
+				"--idp-sso-url", "https://idp.example.com/sso",
+				"--idp-certificate", cert,
+			},
+			source: &auth_model.Source{
+				Type:     auth_model.SAML,
+				Name:     "test",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderSSOURL:      "https://idp.example.com/sso",
+					IdentityProviderCertificate: cert,
+				},
+			},
+		},
+		{
+			name: "valid config with options",
+			args: []string{
+				"--name", "test",
+				"--idp-sso-url", "https://idp.example.com/sso",
+				"--idp-entity-id", "https://idp.example.com",
+				"--idp-certificate", cert,
+				"--name-id-format", "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent",
+				"--allow-idp-initiated",
+				"--icon-url", "https://idp.example.com/icon.png",
+				"--username-attribute", "uid",
+				"--email-attribute", "mail",
+				"--full-name-attribute", "displayName",
+				"--group-attribute", "groups",
+				"--admin-group", "admins",
+				"--restricted-group", "guests",
+				"--group-team-map", `{"developers": {"org": ["team"]}}`,
+				"--group-team-map-removal",
+				"--skip-local-2fa",
+			},
+			source: &auth_model.Source{
+				Type:     auth_model.SAML,
+				Name:     "test",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderSSOURL:      "https://idp.example.com/sso",
+					IdentityProviderEntityID:    "https://idp.example.com",
+					IdentityProviderCertificate: cert,
+					NameIDFormat:                "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent",
+					AllowIdPInitiated:           true,
+					IconURL:                     "https://idp.example.com/icon.png",
+					UsernameAttribute:           "uid",
+					EmailAttribute:              "mail",
+					FullNameAttribute:           "displayName",
+					GroupAttribute:              "groups",
+					AdminGroup:                  "admins",
+					RestrictedGroup:             "guests",
+					GroupTeamMap:                `{"developers": {"org": ["team"]}}`,
+					GroupTeamMapRemoval:         true,
+				},
+				TwoFactorPolicy: "skip",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &authService{
+				initDB: func(ctx context.Context) error {
+					return nil
+				},
+				createAuthSource: func(ctx context.Context, source *auth_model.Source) error {
+					assert.Equal(t, tc.source, source)
+					return nil
+				},
+			}
+
+			cmd := &cli.Command{
+				Flags:  microcmdAuthAddSAML().Flags,
+				Action: a.runAddSAML,
+			}
+
+			args := []string{"saml-test"}
+			args = append(args, tc.args...)
+
+			err := cmd.Run(t.Context(), args)
+
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddSAMLSignRequests(t *testing.T) {
+	var created *auth_model.Source
+	a := &authService{
+		initDB: func(ctx context.Context) error {
+			return nil
+		},
+		createAuthSource: func(ctx context.Context, source *auth_model.Source) error {
+			created = source
+			return nil
+		},
+	}
+	cmd := &cli.Command{
+		Flags:  microcmdAuthAddSAML().Flags,
+		Action: a.runAddSAML,
+	}
+	err := cmd.Run(t.Context(), []string{
+		"saml-test",
+		"--name", "test",
+		"--idp-sso-url", "https://idp.example.com/sso",
+		"--idp-certificate", testSAMLCertificate(t),
+		"--sign-requests",
+	})
+	require.NoError(t, err)
+
+	cfg := created.Cfg.(*saml.Source)
+	assert.True(t, cfg.SignRequests)
+	assert.Contains(t, cfg.ServiceProviderCertificate, "BEGIN CERTIFICATE")
+	assert.Contains(t, cfg.ServiceProviderPrivateKey, "BEGIN PRIVATE KEY")
+}
+
+func TestUpdateSAML(t *testing.T) {
+	cert := testSAMLCertificate(t)
+
+	testCases := []struct {
+		name       string
+		args       []string
+		authSource *auth_model.Source
+		errMsg     string
+	}{
+		{
+			name: "missing id",
+			args: []string{
+				"--name", "test",
+			},
+			errMsg: "--id flag is missing",
+		},
+		{
+			name: "valid config",
+			args: []string{
+				"--id", "1",
+				"--name", "test",
+				"--idp-sso-url", "https://idp.example.com/new",
+				"--email-attribute", "mail",
+			},
+			authSource: &auth_model.Source{
+				ID:       1,
+				Type:     auth_model.SAML,
+				Name:     "test",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderSSOURL:      "https://idp.example.com/new",
+					IdentityProviderCertificate: cert,
+					UsernameAttribute:           "uid",
+					EmailAttribute:              "mail",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &authService{
+				initDB: func(ctx context.Context) error {
+					return nil
+				},
+				getAuthSourceByID: func(ctx context.Context, id int64) (*auth_model.Source, error) {
+					return &auth_model.Source{
+						ID:       1,
+						Type:     auth_model.SAML,
+						Name:     "old name",
+						IsActive: true,
+						Cfg: &saml.Source{
+							IdentityProviderSSOURL:      "https://idp.example.com/sso",
+							IdentityProviderCertificate: cert,
+							UsernameAttribute:           "uid",
+						},
+					}, nil
+				},
+				updateAuthSource: func(ctx context.Context, source *auth_model.Source) error {
+					assert.Equal(t, tc.authSource, source)
+					return nil
+				},
+			}
+
+			app := &cli.Command{
+				Flags:  microcmdAuthUpdateSAML().Flags,
+				Action: a.runUpdateSAML,
+			}
+			args := []string{"saml-tests"}
+			args = append(args, tc.args...)
+
+			err := app.Run(t.Context(), args)
+
+			if tc.errMsg != "" {
+				assert.EqualError(t, err, tc.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	packages_scanner_service "code.gitea.io/gitea/services/packages/scanner"
+
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	subcmdPackages = &cli.Command{
+		Name:  "packages",
+		Usage: "Manage the package registry",
+		Commands: []*cli.Command{
+			microcmdPackagesImportAdvisories,
+		},
+	}
+
+	microcmdPackagesImportAdvisories = &cli.Command{
+		Name:      "import-advisories",
+		Usage:     "Import vulnerability advisories in the OSV format used by the package scanner",
+		ArgsUsage: "<file.json | archive.zip | directory>...",
+		Description: "Imports OSV advisories from JSON files, zip archives like the ecosystem exports of osv.dev, or directories containing them.\n" +
+			"Withdrawn advisories are removed. The packages are matched against the imported advisories by the scan_packages cron task.",
+		Action: runPackagesImportAdvisories,
+	}
+)
+
+func runPackagesImportAdvisories(ctx context.Context, c *cli.Command) error {
+	if c.Args().Len() == 0 {
+		return errors.New("at least one file or directory is required")
+	}
+
+	if err := initDB(ctx); err != nil {
+		return err
+	}
+
+	total := 0
+	for _, p := range c.Args().Slice() {
+		if err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			count, err := importAdvisoryFile(ctx, path)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			total += count
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Imported %d advisories\n", total)
+	return nil
+}
+
+func importAdvisoryFile(ctx context.Context, path string) (int, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, err
+		}
+		if err := packages_scanner_service.ImportAdvisory(ctx, data); err != nil {
+			return 0, err
+		}
+		return 1, nil
+	case ".zip":
+		f, err := os.Open(path)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+
+		fi, err := f.Stat()
+		if err != nil {
+			return 0, err
+		}
+		return packages_scanner_service.ImportAdvisoryArchive(ctx, f, fi.Size())
+	}
+	return 0, nil
+}
@@ -15,6 +15,7 @@ import (
 	pwd "code.gitea.io/gitea/modules/auth/password"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
+	user_service "code.gitea.io/gitea/services/user"
 
 	"github.com/urfave/cli/v3"
 )
@@ -224,7 +225,7 @@ func runCreateUser(ctx context.Context, c *cli.Command) error {
 	// arguments should be prepared before creating the user & access token, in case there is anything wrong
 
 	// create the user
-	if err := user_model.CreateUser(ctx, u, &user_model.Meta{}, overwriteDefault); err != nil {
+	if err := user_service.CreateUser(ctx, nil, u, &user_model.Meta{}, overwriteDefault); err != nil {
 		return fmt.Errorf("CreateUser: %w", err)
 	}
 	fmt.Printf("New user '%s' has been successfully created!\n", username)
@@ -15,6 +15,7 @@ import (
 	git_model "code.gitea.io/gitea/models/git"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
+	terraform_model "code.gitea.io/gitea/models/terraform"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	packages_module "code.gitea.io/gitea/modules/packages"
@@ -36,7 +37,7 @@ var CmdMigrateStorage = &cli.Command{
 			Name:    "type",
 			Aliases: []string{"t"},
 			Value:   "",
-			Usage:   "Type of stored files to copy.  Allowed types: 'attachments', 'lfs', 'avatars', 'repo-avatars', 'repo-archivers', 'packages', 'actions-log', 'actions-artifacts",
+			Usage:   "Type of stored files to copy.  Allowed types: 'attachments', 'lfs', 'avatars', 'repo-avatars', 'repo-archivers', 'packages', 'actions-log', 'actions-artifacts', 'terraform-states'",
 		},
 		&cli.StringFlag{
 			Name:    "storage",
@@ -213,6 +214,14 @@ func migrateActionsArtifacts(ctx context.Context, dstStorage storage.ObjectStora
 	})
 }
 
+func migrateTerraformStates(ctx context.Context, dstStorage storage.ObjectStorage) error {
+	return db.Iterate(ctx, nil, func(ctx context.Context, version *terraform_model.StateVersion) error {
+		p := version.RelativePath()
+		_, err := storage.Copy(dstStorage, p, storage.TerraformStates, p)
+		return err
+	})
+}
+
 func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 	if err := initDB(ctx); err != nil {
 		return err
@@ -294,6 +303,7 @@ func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 		"packages":          migratePackages,
 		"actions-log":       migrateActionsLog,
 		"actions-artifacts": migrateActionsArtifacts,
+		"terraform-states":  migrateTerraformStates,
 	}
 
 	tp := strings.ToLower(cmd.String("type"))
@@ -0,0 +1,118 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionAttestation is a signed in-toto statement about artifacts produced by a run,
+// e.g. the SLSA build provenance or the SBOM of a release artifact or a container image
+type ActionAttestation struct {
+	ID            int64
+	RepoID        int64  `xorm:"index"`
+	OwnerID       int64  `xorm:"index"`
+	RunID         int64  `xorm:"index"`
+	JobID         int64  // the job which uploaded the statement
+	CommitSHA     string `xorm:"VARCHAR(64)"`
+	WorkflowID    string `xorm:"VARCHAR(255)"`
+	PredicateType string `xorm:"VARCHAR(255)"`
+	Envelope      string `xorm:"LONGTEXT"` // the DSSE envelope of the signed statement
+
+	Created timeutil.TimeStamp `xorm:"created"`
+}
+
+// ActionAttestationSubject is an artifact described by an attestation, it is identified by its digest
+type ActionAttestationSubject struct {
+	ID            int64
+	AttestationID int64  `xorm:"index"`
+	RepoID        int64  `xorm:"index"`
+	OwnerID       int64  `xorm:"index(owner_digest)"`
+	Digest        string `xorm:"VARCHAR(255) index(owner_digest)"` // e.g. "sha256:<hex>"
+	Name          string `xorm:"VARCHAR(255)"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionAttestation))
+	db.RegisterModel(new(ActionAttestationSubject))
+}
+
+type ErrAttestationNotExist struct {
+	ID int64
+}
+
+func (err ErrAttestationNotExist) Error() string {
+	return fmt.Sprintf("attestation [id: %d] does not exist", err.ID)
+}
+
+func (err ErrAttestationNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// CreateAttestation stores an attestation with the subjects of its statement
+func CreateAttestation(ctx context.Context, attestation *ActionAttestation, subjects []*ActionAttestationSubject) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.Insert(ctx, attestation); err != nil {
+			return err
+		}
+		for _, subject := range subjects {
+			subject.AttestationID = attestation.ID
+			subject.RepoID = attestation.RepoID
+			subject.OwnerID = attestation.OwnerID
+		}
+		return db.Insert(ctx, subjects)
+	})
+}
+
+// GetAttestationByID returns the attestation of a repository by its id
+func GetAttestationByID(ctx context.Context, repoID, id int64) (*ActionAttestation, error) {
+	var attestation ActionAttestation
+	has, err := db.GetEngine(ctx).Where("id=? AND repo_id=?", id, repoID).Get(&attestation)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAttestationNotExist{ID: id}
+	}
+	return &attestation, nil
+}
+
+type FindAttestationsOptions struct {
+	db.ListOptions
+	RepoID  int64
+	OwnerID int64
+	RunID   int64
+	Digests []string // the attestations describing any of these subjects
+}
+
+func (opts FindAttestationsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RunID > 0 {
+		cond = cond.And(builder.Eq{"run_id": opts.RunID})
+	}
+	if len(opts.Digests) > 0 {
+		subjectCond := builder.In("digest", opts.Digests)
+		if opts.OwnerID > 0 {
+			subjectCond = subjectCond.And(builder.Eq{"owner_id": opts.OwnerID})
+		}
+		cond = cond.And(builder.In("id", builder.Select("attestation_id").From("action_attestation_subject").Where(subjectCond)))
+	}
+	return cond
+}
+
+func (opts FindAttestationsOptions) ToOrders() string {
+	return "`id` DESC"
+}
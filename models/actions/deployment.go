@@ -0,0 +1,273 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// DeploymentStatus represents the review state of a deployment
+type DeploymentStatus int
+
+const (
+	DeploymentStatusWaiting  DeploymentStatus = iota // waiting for a review or for the wait timer of the environment
+	DeploymentStatusApproved                         // the job is allowed to run once the wait timer elapsed
+	DeploymentStatusRejected                         // the job will not run
+)
+
+var deploymentStatusNames = map[DeploymentStatus]string{
+	DeploymentStatusWaiting:  "waiting",
+	DeploymentStatusApproved: "approved",
+	DeploymentStatusRejected: "rejected",
+}
+
+// String returns the string name of the DeploymentStatus
+func (s DeploymentStatus) String() string {
+	return deploymentStatusNames[s]
+}
+
+// ActionDeployment records a job deploying a commit to an environment, one for each attempt of the job
+type ActionDeployment struct {
+	ID            int64              `xorm:"pk autoincr"`
+	RepoID        int64              `xorm:"INDEX NOT NULL"`
+	RunID         int64              `xorm:"INDEX NOT NULL"`
+	Run           *ActionRun         `xorm:"-"`
+	RunJobID      int64              `xorm:"UNIQUE(job_attempt) NOT NULL"`
+	Job           *ActionRunJob      `xorm:"-"`
+	Attempt       int64              `xorm:"UNIQUE(job_attempt) NOT NULL"`
+	EnvironmentID int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Environment   string             `xorm:"VARCHAR(255) NOT NULL"`
+	Ref           string             `xorm:"VARCHAR(255)"`
+	CommitSHA     string             `xorm:"VARCHAR(64) INDEX"`
+	TriggerUserID int64              `xorm:"NOT NULL DEFAULT 0"`
+	Status        DeploymentStatus   `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ReviewerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	Reviewer      *user_model.User   `xorm:"-"`
+	ReviewComment string             `xorm:"TEXT"`
+	ReviewedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionDeployment))
+}
+
+// LoadAttributes loads the run, the job and the reviewer of the deployment
+func (d *ActionDeployment) LoadAttributes(ctx context.Context) error {
+	if d.Job == nil {
+		job, err := GetRunJobByID(ctx, d.RunJobID)
+		if err != nil {
+			return err
+		}
+		d.Job = job
+	}
+	if d.Run == nil {
+		run, err := GetRunByRepoAndID(ctx, d.RepoID, d.RunID)
+		if err != nil {
+			return err
+		}
+		d.Run = run
+		d.Job.Run = run
+	}
+	if d.Reviewer == nil && d.ReviewerID != 0 {
+		reviewer, err := user_model.GetPossibleUserByID(ctx, d.ReviewerID)
+		if err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return err
+			}
+			reviewer = user_model.NewGhostUser()
+		}
+		d.Reviewer = reviewer
+	}
+	return nil
+}
+
+// IsPending returns whether the deployment waits for a review and its loaded job has not been started or cancelled
+func (d *ActionDeployment) IsPending() bool {
+	return d.Status == DeploymentStatusWaiting && d.Job != nil &&
+		d.Job.Status == StatusBlocked && d.Job.Attempt+1 == d.Attempt
+}
+
+// ErrDeploymentNotExist represents an error when a deployment cannot be found
+type ErrDeploymentNotExist struct {
+	ID int64
+}
+
+// IsErrDeploymentNotExist checks if an error is a ErrDeploymentNotExist
+func IsErrDeploymentNotExist(err error) bool {
+	_, ok := err.(ErrDeploymentNotExist)
+	return ok
+}
+
+func (err ErrDeploymentNotExist) Error() string {
+	return fmt.Sprintf("deployment does not exist [id: %d]", err.ID)
+}
+
+func (err ErrDeploymentNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// GetDeploymentByID returns a deployment of the repository
+func GetDeploymentByID(ctx context.Context, repoID, id int64) (*ActionDeployment, error) {
+	d := &ActionDeployment{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrDeploymentNotExist{ID: id}
+	}
+	return d, nil
+}
+
+// GetDeploymentByJobAttempt returns the deployment of an attempt of a job, it returns nil if there is none
+func GetDeploymentByJobAttempt(ctx context.Context, jobID, attempt int64) (*ActionDeployment, error) {
+	d := &ActionDeployment{}
+	has, err := db.GetEngine(ctx).Where("run_job_id = ? AND attempt = ?", jobID, attempt).Get(d)
+	if err != nil || !has {
+		return nil, err
+	}
+	return d, nil
+}
+
+// GetDeploymentEnvironmentIDOfJob returns the ID of the environment the current attempt of the job deploys to,
+// it returns 0 if the attempt has no approved deployment to a configured environment
+func GetDeploymentEnvironmentIDOfJob(ctx context.Context, job *ActionRunJob) (int64, error) {
+	if job.Environment == "" {
+		return 0, nil
+	}
+	d, err := GetDeploymentByJobAttempt(ctx, job.ID, job.Attempt)
+	if err != nil || d == nil || d.Status != DeploymentStatusApproved {
+		return 0, err
+	}
+	return d.EnvironmentID, nil
+}
+
+// prepareToPickJobWithEnvironment returns whether a waiting job deploying to an environment can be picked by a runner.
+// The environment may have been created or may require a review since the deployment of the job has been approved,
+// then the deployment has to be reviewed again and the job is blocked until it is.
+func prepareToPickJobWithEnvironment(ctx context.Context, job *ActionRunJob) (bool, error) {
+	// a runner increases the attempt of the job when it picks it
+	d, err := GetDeploymentByJobAttempt(ctx, job.ID, job.Attempt+1)
+	if err != nil || d == nil || d.Status != DeploymentStatusApproved {
+		return false, err
+	}
+
+	env, err := GetEnvironmentByName(ctx, job.RepoID, job.Environment)
+	if err != nil {
+		if IsErrEnvironmentNotExist(err) {
+			// the environment has been deleted, there is nothing to review anymore
+			return true, nil
+		}
+		return false, err
+	}
+	if env.ID == d.EnvironmentID && (d.ReviewerID != 0 || !env.RequiresReview()) {
+		return true, nil
+	}
+
+	d.EnvironmentID = env.ID
+	d.Environment = env.Name
+	if !env.RequiresReview() {
+		_, err := db.GetEngine(ctx).ID(d.ID).Cols("environment_id", "environment").Update(d)
+		return err == nil, err
+	}
+
+	d.Status = DeploymentStatusWaiting
+	d.ReviewerID = 0
+	d.ReviewComment = ""
+	d.ReviewedUnix = 0
+	if _, err := db.GetEngine(ctx).ID(d.ID).
+		Cols("environment_id", "environment", "status", "reviewer_id", "review_comment", "reviewed_unix").
+		Update(d); err != nil {
+		return false, err
+	}
+	job.Status = StatusBlocked
+	_, err = UpdateRunJob(ctx, job, builder.Eq{"status": StatusWaiting}, "status")
+	return false, err
+}
+
+// CreateDeployment inserts a new deployment
+func CreateDeployment(ctx context.Context, d *ActionDeployment) error {
+	_, err := db.GetEngine(ctx).Insert(d)
+	return err
+}
+
+// UpdateDeploymentReview stores the review of a waiting deployment, it returns false if the deployment has been reviewed already
+func UpdateDeploymentReview(ctx context.Context, d *ActionDeployment) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(d.ID).
+		Where(builder.Eq{"status": DeploymentStatusWaiting}).
+		Cols("status", "reviewer_id", "review_comment", "reviewed_unix").
+		Update(d)
+	return n == 1, err
+}
+
+type FindDeploymentsOptions struct {
+	db.ListOptions
+	RepoID        int64
+	EnvironmentID int64
+	Environment   string
+	CommitSHA     string
+	Statuses      []DeploymentStatus
+}
+
+func (opts FindDeploymentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.EnvironmentID > 0 {
+		cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
+	}
+	if opts.Environment != "" {
+		cond = cond.And(builder.Eq{"environment": opts.Environment})
+	}
+	if opts.CommitSHA != "" {
+		cond = cond.And(builder.Eq{"commit_sha": opts.CommitSHA})
+	}
+	if len(opts.Statuses) > 0 {
+		cond = cond.And(builder.In("status", opts.Statuses))
+	}
+	return cond
+}
+
+func (opts FindDeploymentsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+// HasSuccessfulDeployment returns whether a job successfully deployed the commit to the environment of the repository
+func HasSuccessfulDeployment(ctx context.Context, repoID int64, environment, commitSHA string) (bool, error) {
+	return db.GetEngine(ctx).Table("action_deployment").
+		Join("INNER", "action_run_job", "action_run_job.id = action_deployment.run_job_id AND action_run_job.attempt = action_deployment.attempt").
+		Where(builder.Eq{
+			"action_deployment.repo_id":    repoID,
+			"action_deployment.commit_sha": commitSHA,
+			"action_deployment.status":     DeploymentStatusApproved,
+			"action_run_job.status":        StatusSuccess,
+		}).
+		And(builder.Expr("LOWER(action_deployment.environment) = ?", strings.ToLower(environment))).
+		Exist()
+}
+
+// GetRunIDsWithApprovedBlockedDeployments returns the runs having an approved deployment whose job is still blocked,
+// these jobs wait for the wait timer of their environment
+func GetRunIDsWithApprovedBlockedDeployments(ctx context.Context) ([]int64, error) {
+	runIDs := make([]int64, 0, 10)
+	return runIDs, db.GetEngine(ctx).Table("action_deployment").
+		Join("INNER", "action_run_job", "action_run_job.id = action_deployment.run_job_id AND action_run_job.attempt + 1 = action_deployment.attempt").
+		Where(builder.Eq{
+			"action_deployment.status": DeploymentStatusApproved,
+			"action_run_job.status":    StatusBlocked,
+		}).
+		Distinct("action_deployment.run_id").
+		Find(&runIDs)
+}
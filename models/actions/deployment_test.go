@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareToPickJobWithEnvironment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	job := unittest.AssertExistsAndLoadBean(t, &ActionRunJob{ID: 196})
+	job.Environment = "production"
+	_, err := db.GetEngine(t.Context()).ID(job.ID).Cols("environment").Update(job)
+	require.NoError(t, err)
+
+	// the environment was not configured when the deployment has been recorded
+	d := &ActionDeployment{
+		RepoID:      job.RepoID,
+		RunID:       job.RunID,
+		RunJobID:    job.ID,
+		Attempt:     job.Attempt + 1,
+		Environment: job.Environment,
+		Status:      DeploymentStatusApproved,
+	}
+	require.NoError(t, CreateDeployment(t.Context(), d))
+
+	ok, err := prepareToPickJobWithEnvironment(t.Context(), job)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// an environment without protection rules is only recorded
+	env := &ActionEnvironment{RepoID: job.RepoID, Name: "Production"}
+	require.NoError(t, CreateEnvironment(t.Context(), env))
+	ok, err = prepareToPickJobWithEnvironment(t.Context(), job)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	d = unittest.AssertExistsAndLoadBean(t, &ActionDeployment{ID: d.ID})
+	assert.Equal(t, env.ID, d.EnvironmentID)
+	assert.Equal(t, DeploymentStatusApproved, d.Status)
+
+	// an environment which requires a review now blocks the job until the deployment is reviewed
+	env.ReviewerUserIDs = []int64{2}
+	require.NoError(t, UpdateEnvironment(t.Context(), env))
+	ok, err = prepareToPickJobWithEnvironment(t.Context(), job)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	d = unittest.AssertExistsAndLoadBean(t, &ActionDeployment{ID: d.ID})
+	assert.Equal(t, DeploymentStatusWaiting, d.Status)
+	job = unittest.AssertExistsAndLoadBean(t, &ActionRunJob{ID: job.ID})
+	assert.Equal(t, StatusBlocked, job.Status)
+
+	// the secrets and variables of the environment are resolved through the approved deployment of the picked attempt
+	job.Attempt = d.Attempt
+	environmentID, err := GetDeploymentEnvironmentIDOfJob(t.Context(), job)
+	require.NoError(t, err)
+	assert.Zero(t, environmentID)
+
+	d.Status = DeploymentStatusApproved
+	d.ReviewerID = 2
+	updated, err := UpdateDeploymentReview(t.Context(), d)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	environmentID, err = GetDeploymentEnvironmentIDOfJob(t.Context(), job)
+	require.NoError(t, err)
+	assert.Equal(t, env.ID, environmentID)
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionEnvironment represents a deployment environment of a repository.
+// Jobs declaring `environment: <name>` have to pass its protection rules before they start,
+// and only these jobs get the secrets and variables of the environment.
+type ActionEnvironment struct {
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"UNIQUE(repo_name) NOT NULL"`
+	Name      string `xorm:"NOT NULL"`
+	LowerName string `xorm:"UNIQUE(repo_name) NOT NULL"`
+
+	ReviewerUserIDs   []int64 `xorm:"JSON TEXT"`
+	ReviewerTeamIDs   []int64 `xorm:"JSON TEXT"`
+	PreventSelfReview bool    `xorm:"NOT NULL DEFAULT false"`
+	WaitTimer         int64   `xorm:"NOT NULL DEFAULT 0"` // in minutes
+	// BranchPatterns is a semicolon separated list of branch or tag name globs allowed to deploy, empty means all refs
+	BranchPatterns string `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionEnvironment))
+}
+
+// MaxEnvironmentWaitTimer is the maximum wait timer of an environment in minutes (30 days)
+const MaxEnvironmentWaitTimer = 43200
+
+// RequiresReview returns whether deployments to the environment must be approved by a reviewer
+func (env *ActionEnvironment) RequiresReview() bool {
+	return len(env.ReviewerUserIDs) > 0 || len(env.ReviewerTeamIDs) > 0
+}
+
+// GetWaitTimer returns how long a deployment has to wait before its job starts
+func (env *ActionEnvironment) GetWaitTimer() time.Duration {
+	return time.Duration(env.WaitTimer) * time.Minute
+}
+
+// MatchRef returns whether a run of the given ref ("refs/heads/main", "refs/tags/v1.0") may deploy to the environment
+func (env *ActionEnvironment) MatchRef(ref string) bool {
+	if strings.TrimSpace(env.BranchPatterns) == "" {
+		return true
+	}
+	name := git.RefName(ref).ShortName()
+	for pattern := range strings.SplitSeq(env.BranchPatterns, ";") {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			log.Info("Invalid environment branch pattern '%s' (skipped): %v", pattern, err)
+			continue
+		}
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReviewer returns whether the user is a required reviewer of the environment, directly or through one of its teams
+func (env *ActionEnvironment) IsReviewer(ctx context.Context, userID int64) bool {
+	if slices.Contains(env.ReviewerUserIDs, userID) {
+		return true
+	}
+	if len(env.ReviewerTeamIDs) == 0 {
+		return false
+	}
+	in, err := organization.IsUserInTeams(ctx, userID, env.ReviewerTeamIDs)
+	if err != nil {
+		log.Error("IsUserInTeams: %v", err)
+		return false
+	}
+	return in
+}
+
+// ErrEnvironmentNotExist represents an error when an environment cannot be found
+type ErrEnvironmentNotExist struct {
+	RepoID int64
+	Name   string
+}
+
+// IsErrEnvironmentNotExist checks if an error is a ErrEnvironmentNotExist
+func IsErrEnvironmentNotExist(err error) bool {
+	_, ok := err.(ErrEnvironmentNotExist)
+	return ok
+}
+
+func (err ErrEnvironmentNotExist) Error() string {
+	return fmt.Sprintf("environment does not exist [repo_id: %d, name: %s]", err.RepoID, err.Name)
+}
+
+func (err ErrEnvironmentNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrEnvironmentAlreadyExist represents an error when an environment with the same name exists
+type ErrEnvironmentAlreadyExist struct {
+	Name string
+}
+
+// IsErrEnvironmentAlreadyExist checks if an error is a ErrEnvironmentAlreadyExist
+func IsErrEnvironmentAlreadyExist(err error) bool {
+	_, ok := err.(ErrEnvironmentAlreadyExist)
+	return ok
+}
+
+func (err ErrEnvironmentAlreadyExist) Error() string {
+	return fmt.Sprintf("environment already exists [name: %s]", err.Name)
+}
+
+func (err ErrEnvironmentAlreadyExist) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// GetEnvironmentByID returns an environment of the repository
+func GetEnvironmentByID(ctx context.Context, repoID, id int64) (*ActionEnvironment, error) {
+	env := &ActionEnvironment{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Get(env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrEnvironmentNotExist{RepoID: repoID, Name: strconv.FormatInt(id, 10)}
+	}
+	return env, nil
+}
+
+// GetEnvironmentByName returns an environment of the repository by its case-insensitive name
+func GetEnvironmentByName(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	env := &ActionEnvironment{}
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND lower_name = ?", repoID, strings.ToLower(name)).Get(env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrEnvironmentNotExist{RepoID: repoID, Name: name}
+	}
+	return env, nil
+}
+
+// GetEnvironments returns all environments of the repository ordered by name
+func GetEnvironments(ctx context.Context, repoID int64) ([]*ActionEnvironment, error) {
+	envs := make([]*ActionEnvironment, 0, 5)
+	return envs, db.GetEngine(ctx).Where("repo_id = ?", repoID).Asc("lower_name").Find(&envs)
+}
+
+// CreateEnvironment inserts a new environment, the name must be unique in the repository
+func CreateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	env.LowerName = strings.ToLower(env.Name)
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.GetEngine(ctx).Where("repo_id = ? AND lower_name = ?", env.RepoID, env.LowerName).Exist(new(ActionEnvironment))
+		if err != nil {
+			return err
+		} else if has {
+			return ErrEnvironmentAlreadyExist{Name: env.Name}
+		}
+		_, err = db.GetEngine(ctx).Insert(env)
+		return err
+	})
+}
+
+// UpdateEnvironment updates the protection rules of an environment, its name cannot be changed
+func UpdateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	_, err := db.GetEngine(ctx).ID(env.ID).
+		Cols("reviewer_user_i_ds", "reviewer_team_i_ds", "prevent_self_review", "wait_timer", "branch_patterns").
+		Update(env)
+	return err
+}
+
+// DeleteEnvironment deletes an environment together with its variables,
+// the deployment records are kept for the history
+func DeleteEnvironment(ctx context.Context, repoID, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		deleted, err := db.GetEngine(ctx).Where("id = ? AND repo_id = ?", id, repoID).Delete(&ActionEnvironment{})
+		if err != nil {
+			return err
+		} else if deleted == 0 {
+			return ErrEnvironmentNotExist{RepoID: repoID, Name: strconv.FormatInt(id, 10)}
+		}
+		_, err = db.GetEngine(ctx).Where(builder.Eq{"environment_id": id}).Delete(&ActionVariable{})
+		return err
+	})
+}
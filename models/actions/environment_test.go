@@ -0,0 +1,41 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentMatchRef(t *testing.T) {
+	env := &ActionEnvironment{}
+	assert.True(t, env.MatchRef("refs/heads/feature"))
+
+	env.BranchPatterns = "main; release/*"
+	assert.True(t, env.MatchRef("refs/heads/main"))
+	assert.True(t, env.MatchRef("refs/heads/release/v1"))
+	assert.True(t, env.MatchRef("refs/tags/main"))
+	assert.False(t, env.MatchRef("refs/heads/release/v1/fix"))
+	assert.False(t, env.MatchRef("refs/heads/feature"))
+}
+
+func TestCreateEnvironment(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	env := &ActionEnvironment{RepoID: 1, Name: "Production", WaitTimer: 5}
+	assert.NoError(t, CreateEnvironment(t.Context(), env))
+	assert.True(t, IsErrEnvironmentAlreadyExist(CreateEnvironment(t.Context(), &ActionEnvironment{RepoID: 1, Name: "production"})))
+
+	got, err := GetEnvironmentByName(t.Context(), 1, "PRODUCTION")
+	assert.NoError(t, err)
+	assert.Equal(t, env.ID, got.ID)
+	assert.Equal(t, int64(5), got.WaitTimer)
+
+	assert.NoError(t, DeleteEnvironment(t.Context(), 1, env.ID))
+	_, err = GetEnvironmentByName(t.Context(), 1, "production")
+	assert.True(t, IsErrEnvironmentNotExist(err))
+}
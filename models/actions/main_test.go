@@ -14,6 +14,7 @@ func TestMain(m *testing.M) {
 		FixtureFiles: []string{
 			"action_runner_token.yml",
 			"action_run.yml",
+			"action_run_job.yml",
 			"repository.yml",
 		},
 	})
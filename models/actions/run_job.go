@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"code.gitea.io/gitea/models/db"
@@ -41,6 +42,19 @@ type ActionRunJob struct {
 	TaskID int64    // the latest task of the job
 	Status Status   `xorm:"index"`
 
+	Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"` // the name of the deployment environment from job YAML's "environment" section
+
+	// Uses is the reusable workflow called by the job from job YAML's "uses" section.
+	// Such a job is never picked by a runner, the job emitter adds the jobs of the called workflow to the run instead.
+	// The JobID of a called job is prefixed with the JobID of its caller, e.g. "caller/build".
+	Uses        string            `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	CallerJobID int64             `xorm:"index NOT NULL DEFAULT 0"` // the job calling the reusable workflow which defines this job
+	CallOutputs map[string]string `xorm:"JSON TEXT"`                // the outputs of the called workflow, they are the unevaluated expressions until the called jobs are done
+
+	// TokenPermissions are the permissions of the GITEA_TOKEN from job YAML's or workflow YAML's "permissions" section,
+	// nil if neither of them declares permissions.
+	TokenPermissions TokenPermissions `xorm:"JSON TEXT"`
+
 	RawConcurrency string // raw concurrency from job YAML's "concurrency" section
 
 	// IsConcurrencyEvaluated is only valid/needed when this job's RawConcurrency is not empty.
@@ -100,6 +114,27 @@ func (job *ActionRunJob) LoadAttributes(ctx context.Context) error {
 	return job.Run.LoadAttributes(ctx)
 }
 
+// WorkflowJobID returns the id of the job in the workflow defining it, which is the JobID without the prefix of its callers
+func (job *ActionRunJob) WorkflowJobID() string {
+	if i := strings.LastIndex(job.JobID, "/"); i >= 0 {
+		return job.JobID[i+1:]
+	}
+	return job.JobID
+}
+
+// WorkflowNeeds returns the needs of the job by the ids of the jobs in the workflow defining it
+func (job *ActionRunJob) WorkflowNeeds() []string {
+	prefix := strings.TrimSuffix(job.JobID, job.WorkflowJobID())
+	if prefix == "" {
+		return job.Needs
+	}
+	needs := make([]string, 0, len(job.Needs))
+	for _, need := range job.Needs {
+		needs = append(needs, strings.TrimPrefix(need, prefix))
+	}
+	return needs
+}
+
 // ParseJob parses the job structure from the ActionRunJob.WorkflowPayload
 func (job *ActionRunJob) ParseJob() (*jobparser.Job, error) {
 	// job.WorkflowPayload is a SingleWorkflow created from an ActionRun's workflow, which exactly contains this job's YAML definition.
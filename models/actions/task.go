@@ -244,13 +244,22 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 	var job *ActionRunJob
 	log.Trace("runner labels: %v", runner.AgentLabels)
 	for _, v := range jobs {
-		if runner.CanMatchLabels(v.RunsOn) {
-			job = v
-			break
+		if !runner.CanMatchLabels(v.RunsOn) {
+			continue
 		}
+		if v.Environment != "" {
+			if ok, err := prepareToPickJobWithEnvironment(ctx, v); err != nil {
+				return nil, false, err
+			} else if !ok {
+				continue
+			}
+		}
+		job = v
+		break
 	}
 	if job == nil {
-		return nil, false, nil
+		// keep the deployments which have to be reviewed again
+		return nil, false, committer.Commit()
 	}
 	if err := job.LoadAttributes(ctx); err != nil {
 		return nil, false, err
@@ -0,0 +1,211 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"maps"
+
+	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+)
+
+// The permission scopes of the GITEA_TOKEN of a job
+const (
+	TokenScopeActions      = "actions"
+	TokenScopeAttestations = "attestations"
+	TokenScopeContents     = "contents"
+	TokenScopeIssues       = "issues"
+	TokenScopePackages     = "packages"
+	TokenScopePullRequests = "pull-requests"
+	TokenScopeStatuses     = "statuses"
+
+	// TokenScopeIDToken allows a job to request an OIDC ID token, it doesn't grant any access to the GITEA_TOKEN
+	TokenScopeIDToken = "id-token"
+)
+
+// TokenPermissionScopes are the permission scopes of a workflow which limit the GITEA_TOKEN of a job
+var TokenPermissionScopes = []string{
+	TokenScopeActions,
+	TokenScopeAttestations,
+	TokenScopeContents,
+	TokenScopeIssues,
+	TokenScopePackages,
+	TokenScopePullRequests,
+	TokenScopeStatuses,
+}
+
+// tokenScopeUnits maps the repository units to the permission scopes covering them
+var tokenScopeUnits = map[unit.Type]string{
+	unit.TypeCode:         TokenScopeContents,
+	unit.TypeReleases:     TokenScopeContents,
+	unit.TypeWiki:         TokenScopeContents,
+	unit.TypeIssues:       TokenScopeIssues,
+	unit.TypePullRequests: TokenScopePullRequests,
+	unit.TypePackages:     TokenScopePackages,
+	unit.TypeActions:      TokenScopeActions,
+}
+
+// TokenPermissions are the access modes of the GITEA_TOKEN of a job by permission scope.
+// A nil value means the job didn't declare any permissions and grants write access to every scope,
+// otherwise a missing scope grants no access.
+type TokenPermissions map[string]perm.AccessMode
+
+// AccessMode returns the access mode granted to a permission scope, it is at most write
+func (perms TokenPermissions) AccessMode(scope string) perm.AccessMode {
+	if perms == nil {
+		return perm.AccessModeWrite
+	}
+	return min(perms[scope], perm.AccessModeWrite)
+}
+
+// UnitAccessMode returns the access mode granted to a repository unit.
+// The units which aren't covered by a scope can only be read once permissions are declared.
+func (perms TokenPermissions) UnitAccessMode(unitType unit.Type) perm.AccessMode {
+	if scope, ok := tokenScopeUnits[unitType]; ok {
+		return perms.AccessMode(scope)
+	}
+	if perms == nil {
+		return perm.AccessModeWrite
+	}
+	return perm.AccessModeRead
+}
+
+// IDTokenAccessMode returns the access mode granted to request an OIDC ID token.
+// Unlike the other scopes, it is never granted if the job didn't declare any permissions.
+func (perms TokenPermissions) IDTokenAccessMode() perm.AccessMode {
+	return min(perms[TokenScopeIDToken], perm.AccessModeWrite)
+}
+
+// Clamp returns the permissions limited by the maximum permissions, a nil maximum doesn't limit anything.
+// The maximum permissions don't cover the ID token, it is kept as it is.
+func (perms TokenPermissions) Clamp(maxPerms TokenPermissions) TokenPermissions {
+	if maxPerms == nil {
+		return perms
+	}
+	clamped := make(TokenPermissions, len(TokenPermissionScopes)+1)
+	for _, scope := range TokenPermissionScopes {
+		clamped[scope] = min(perms.AccessMode(scope), maxPerms.AccessMode(scope))
+	}
+	if mode := perms.IDTokenAccessMode(); mode > perm.AccessModeNone {
+		clamped[TokenScopeIDToken] = mode
+	}
+	return clamped
+}
+
+// ClampByCaller returns the permissions of a job of a called workflow limited by the permissions of the caller job,
+// the ID token can only be requested if the caller granted it as well.
+func (perms TokenPermissions) ClampByCaller(caller TokenPermissions) TokenPermissions {
+	clamped := perms.Clamp(caller)
+	if mode := min(clamped.IDTokenAccessMode(), caller.IDTokenAccessMode()); mode != clamped.IDTokenAccessMode() {
+		clamped = maps.Clone(clamped)
+		if mode == perm.AccessModeNone {
+			delete(clamped, TokenScopeIDToken)
+		} else {
+			clamped[TokenScopeIDToken] = mode
+		}
+	}
+	return clamped
+}
+
+// ReadOnlyTokenPermissions grants read access to every scope
+func ReadOnlyTokenPermissions() TokenPermissions {
+	perms := make(TokenPermissions, len(TokenPermissionScopes))
+	for _, scope := range TokenPermissionScopes {
+		perms[scope] = perm.AccessModeRead
+	}
+	return perms
+}
+
+// GetOwnerMaxTokenPermissions returns the maximum permissions of the tokens of the jobs of the repositories of an owner, nil if they are unlimited
+func GetOwnerMaxTokenPermissions(ctx context.Context, ownerID int64) (TokenPermissions, error) {
+	value, err := user_model.GetUserSetting(ctx, ownerID, user_model.SettingsKeyActionsMaxTokenPermissions)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var perms TokenPermissions
+	if err := json.Unmarshal([]byte(value), &perms); err != nil {
+		return nil, err
+	}
+	return perms, nil
+}
+
+// SetOwnerMaxTokenPermissions sets the maximum permissions of the tokens of the jobs of the repositories of an owner, nil removes the limit
+func SetOwnerMaxTokenPermissions(ctx context.Context, ownerID int64, perms TokenPermissions) error {
+	if perms == nil {
+		return user_model.DeleteUserSetting(ctx, ownerID, user_model.SettingsKeyActionsMaxTokenPermissions)
+	}
+	value, err := json.Marshal(perms)
+	if err != nil {
+		return err
+	}
+	return user_model.SetUserSetting(ctx, ownerID, user_model.SettingsKeyActionsMaxTokenPermissions, string(value))
+}
+
+// GetMaxTokenPermissions returns the maximum permissions of the tokens of the jobs of a repository,
+// they are limited by the defaults of both the repository and its owner
+func GetMaxTokenPermissions(ctx context.Context, repo *repo_model.Repository) (TokenPermissions, error) {
+	ownerPerms, err := GetOwnerMaxTokenPermissions(ctx, repo.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	actionsUnit, err := repo.GetUnit(ctx, unit.TypeActions)
+	if err != nil {
+		if repo_model.IsErrUnitTypeNotExist(err) {
+			return ownerPerms, nil
+		}
+		return nil, err
+	}
+	repoPerms := TokenPermissions(actionsUnit.ActionsConfig().MaxTokenPermissions)
+	if repoPerms == nil {
+		return ownerPerms, nil
+	}
+	return repoPerms.Clamp(ownerPerms), nil
+}
+
+// GetTaskTokenPermissions returns the effective permissions of the token of a task,
+// they are the permissions of its job limited by the maximum permissions of the repository and its owner.
+// The token of a task triggered by a pull request from a fork can never write.
+func GetTaskTokenPermissions(ctx context.Context, task *ActionTask) (TokenPermissions, error) {
+	job, err := GetRunJobByID(ctx, task.JobID)
+	if err != nil {
+		return nil, err
+	}
+	return getJobTokenPermissions(ctx, job, task.IsForkPullRequest)
+}
+
+// GetTaskPackagesAccessMode returns the access of the token of a task to the packages of the owner of its repository.
+// The packages are shared by all repositories of the owner, so a job can only write them if it declares the packages permission.
+func GetTaskPackagesAccessMode(ctx context.Context, task *ActionTask) (perm.AccessMode, error) {
+	job, err := GetRunJobByID(ctx, task.JobID)
+	if err != nil {
+		return perm.AccessModeNone, err
+	}
+	perms, err := getJobTokenPermissions(ctx, job, task.IsForkPullRequest)
+	if err != nil {
+		return perm.AccessModeNone, err
+	}
+	if job.TokenPermissions == nil {
+		return min(perms.AccessMode(TokenScopePackages), perm.AccessModeRead), nil
+	}
+	return perms.AccessMode(TokenScopePackages), nil
+}
+
+func getJobTokenPermissions(ctx context.Context, job *ActionRunJob, isForkPullRequest bool) (TokenPermissions, error) {
+	if err := job.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	maxPerms, err := GetMaxTokenPermissions(ctx, job.Repo)
+	if err != nil {
+		return nil, err
+	}
+	perms := job.TokenPermissions.Clamp(maxPerms)
+	if isForkPullRequest {
+		perms = perms.Clamp(ReadOnlyTokenPermissions())
+	}
+	return perms, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/models/unit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenPermissions(t *testing.T) {
+	var undeclared TokenPermissions
+	assert.Equal(t, perm.AccessModeWrite, undeclared.AccessMode(TokenScopeContents))
+	assert.Equal(t, perm.AccessModeWrite, undeclared.UnitAccessMode(unit.TypeProjects))
+
+	declared := TokenPermissions{TokenScopeContents: perm.AccessModeRead, TokenScopeIssues: perm.AccessModeWrite}
+	assert.Equal(t, perm.AccessModeRead, declared.UnitAccessMode(unit.TypeCode))
+	assert.Equal(t, perm.AccessModeRead, declared.UnitAccessMode(unit.TypeReleases))
+	assert.Equal(t, perm.AccessModeWrite, declared.UnitAccessMode(unit.TypeIssues))
+	assert.Equal(t, perm.AccessModeNone, declared.UnitAccessMode(unit.TypePullRequests))
+	assert.Equal(t, perm.AccessModeRead, declared.UnitAccessMode(unit.TypeProjects))
+
+	assert.Equal(t, declared, declared.Clamp(nil))
+	assert.Equal(t, ReadOnlyTokenPermissions(), undeclared.Clamp(ReadOnlyTokenPermissions()))
+
+	clamped := declared.Clamp(TokenPermissions{TokenScopeContents: perm.AccessModeWrite, TokenScopeIssues: perm.AccessModeRead})
+	assert.Equal(t, perm.AccessModeRead, clamped.AccessMode(TokenScopeContents))
+	assert.Equal(t, perm.AccessModeRead, clamped.AccessMode(TokenScopeIssues))
+	assert.Equal(t, perm.AccessModeNone, clamped.AccessMode(TokenScopePackages))
+
+	// the maximum permissions don't cover the ID token, but a caller has to grant it
+	idToken := TokenPermissions{TokenScopeContents: perm.AccessModeRead, TokenScopeIDToken: perm.AccessModeWrite}
+	assert.Equal(t, perm.AccessModeNone, undeclared.IDTokenAccessMode())
+	assert.Equal(t, perm.AccessModeWrite, idToken.Clamp(ReadOnlyTokenPermissions()).IDTokenAccessMode())
+	assert.Equal(t, perm.AccessModeNone, idToken.ClampByCaller(nil).IDTokenAccessMode())
+	assert.Equal(t, perm.AccessModeNone, idToken.ClampByCaller(declared).IDTokenAccessMode())
+	assert.Equal(t, perm.AccessModeWrite, idToken.ClampByCaller(idToken).IDTokenAccessMode())
+	assert.Equal(t, perm.AccessModeWrite, idToken.IDTokenAccessMode(), "the permissions of the called job must not be changed")
+}
@@ -22,6 +22,7 @@ import (
 //  1. global variable, OwnerID is 0 and RepoID is 0
 //  2. org/user level variable, OwnerID is org/user ID and RepoID is 0
 //  3. repo level variable, OwnerID is 0 and RepoID is repo ID
+//  4. environment level variable, OwnerID is 0, RepoID is repo ID and EnvironmentID is the ID of one of its environments
 //
 // Please note that it's not acceptable to have both OwnerID and RepoID to be non-zero,
 // or it will be complicated to find variables belonging to a specific owner.
@@ -29,14 +30,15 @@ import (
 // but it's a repo level variable, not an org/user level variable.
 // To avoid this, make it clear with {OwnerID: 0, RepoID: 1} for repo level variables.
 type ActionVariable struct {
-	ID          int64              `xorm:"pk autoincr"`
-	OwnerID     int64              `xorm:"UNIQUE(owner_repo_name)"`
-	RepoID      int64              `xorm:"INDEX UNIQUE(owner_repo_name)"`
-	Name        string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
-	Data        string             `xorm:"LONGTEXT NOT NULL"`
-	Description string             `xorm:"TEXT"`
-	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
-	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	ID            int64              `xorm:"pk autoincr"`
+	OwnerID       int64              `xorm:"UNIQUE(owner_repo_name)"`
+	RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name)"`
+	EnvironmentID int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	Data          string             `xorm:"LONGTEXT NOT NULL"`
+	Description   string             `xorm:"TEXT"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
 }
 
 const (
@@ -55,28 +57,47 @@ func InsertVariable(ctx context.Context, ownerID, repoID int64, name, data, desc
 		ownerID = 0
 	}
 
-	if utf8.RuneCountInString(data) > VariableDataMaxLength {
-		return nil, util.NewInvalidArgumentErrorf("data too long")
-	}
-
-	description = util.TruncateRunes(description, VariableDescriptionMaxLength)
-
-	variable := &ActionVariable{
+	return insertVariable(ctx, &ActionVariable{
 		OwnerID:     ownerID,
 		RepoID:      repoID,
 		Name:        strings.ToUpper(name),
 		Data:        data,
 		Description: description,
+	})
+}
+
+// InsertEnvironmentVariable inserts a variable of a deployment environment of a repository
+func InsertEnvironmentVariable(ctx context.Context, repoID, environmentID int64, name, data, description string) (*ActionVariable, error) {
+	if repoID == 0 || environmentID == 0 {
+		return nil, util.NewInvalidArgumentErrorf("repoID and environmentID are required for environment variables")
 	}
+
+	return insertVariable(ctx, &ActionVariable{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          strings.ToUpper(name),
+		Data:          data,
+		Description:   description,
+	})
+}
+
+func insertVariable(ctx context.Context, variable *ActionVariable) (*ActionVariable, error) {
+	if utf8.RuneCountInString(variable.Data) > VariableDataMaxLength {
+		return nil, util.NewInvalidArgumentErrorf("data too long")
+	}
+
+	variable.Description = util.TruncateRunes(variable.Description, VariableDescriptionMaxLength)
+
 	return variable, db.Insert(ctx, variable)
 }
 
 type FindVariablesOpts struct {
 	db.ListOptions
-	IDs     []int64
-	RepoID  int64
-	OwnerID int64 // it will be ignored if RepoID is set
-	Name    string
+	IDs           []int64
+	RepoID        int64
+	OwnerID       int64 // it will be ignored if RepoID is set
+	EnvironmentID int64 // the variables of an environment are only found if it is set
+	Name          string
 }
 
 func (opts FindVariablesOpts) ToConds() builder.Cond {
@@ -99,6 +120,7 @@ func (opts FindVariablesOpts) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.Name != "" {
 		cond = cond.And(builder.Eq{"name": strings.ToUpper(opts.Name)})
@@ -169,6 +191,31 @@ func GetVariablesOfRun(ctx context.Context, run *ActionRun) (map[string]string,
 	return variables, nil
 }
 
+// GetVariablesOfJob returns the variables of the run of the job, overridden by the variables of the environment its deployment has been approved for
+func GetVariablesOfJob(ctx context.Context, job *ActionRunJob) (map[string]string, error) {
+	if err := job.LoadRun(ctx); err != nil {
+		return nil, err
+	}
+	variables, err := GetVariablesOfRun(ctx, job.Run)
+	if err != nil {
+		return nil, err
+	}
+
+	environmentID, err := GetDeploymentEnvironmentIDOfJob(ctx, job)
+	if err != nil || environmentID == 0 {
+		return variables, err
+	}
+	envVariables, err := db.Find[ActionVariable](ctx, FindVariablesOpts{RepoID: job.RepoID, EnvironmentID: environmentID})
+	if err != nil {
+		log.Error("find variables of environment: %d, error: %v", environmentID, err)
+		return nil, err
+	}
+	for _, v := range envVariables {
+		variables[v.Name] = v.Data
+	}
+	return variables, nil
+}
+
 func CountWrongRepoLevelVariables(ctx context.Context) (int64, error) {
 	var result int64
 	_, err := db.GetEngine(ctx).SQL("SELECT count(`id`) FROM `action_variable` WHERE `repo_id` > 0 AND `owner_id` > 0").Get(&result)
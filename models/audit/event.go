@@ -0,0 +1,182 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// Action is the kind of a security relevant change recorded in the audit log
+type Action string
+
+const (
+	ActionUserImpersonation Action = "user_impersonation"
+
+	ActionAccessTokenCreate Action = "access_token_create"
+	ActionAccessTokenDelete Action = "access_token_delete"
+
+	ActionRepositoryVisibility Action = "repository_visibility"
+	ActionRepositoryTransfer   Action = "repository_transfer"
+	ActionRepositoryDelete     Action = "repository_delete"
+
+	ActionCollaboratorAdd        Action = "collaborator_add"
+	ActionCollaboratorAccessMode Action = "collaborator_access_mode"
+	ActionCollaboratorRemove     Action = "collaborator_remove"
+
+	ActionBranchProtectionCreate Action = "branch_protection_create"
+	ActionBranchProtectionUpdate Action = "branch_protection_update"
+	ActionBranchProtectionDelete Action = "branch_protection_delete"
+
+	ActionDeployKeyAdd    Action = "deploy_key_add"
+	ActionDeployKeyDelete Action = "deploy_key_delete"
+
+	ActionSecretCreate Action = "secret_create"
+	ActionSecretUpdate Action = "secret_update"
+	ActionSecretDelete Action = "secret_delete"
+
+	ActionTeamCreate           Action = "team_create"
+	ActionTeamUpdate           Action = "team_update"
+	ActionTeamDelete           Action = "team_delete"
+	ActionTeamMemberAdd        Action = "team_member_add"
+	ActionTeamMemberRemove     Action = "team_member_remove"
+	ActionTeamRepositoryAdd    Action = "team_repository_add"
+	ActionTeamRepositoryRemove Action = "team_repository_remove"
+)
+
+// Actions are all actions recorded in the audit log
+var Actions = []Action{
+	ActionUserImpersonation,
+	ActionAccessTokenCreate,
+	ActionAccessTokenDelete,
+	ActionRepositoryVisibility,
+	ActionRepositoryTransfer,
+	ActionRepositoryDelete,
+	ActionCollaboratorAdd,
+	ActionCollaboratorAccessMode,
+	ActionCollaboratorRemove,
+	ActionBranchProtectionCreate,
+	ActionBranchProtectionUpdate,
+	ActionBranchProtectionDelete,
+	ActionDeployKeyAdd,
+	ActionDeployKeyDelete,
+	ActionSecretCreate,
+	ActionSecretUpdate,
+	ActionSecretDelete,
+	ActionTeamCreate,
+	ActionTeamUpdate,
+	ActionTeamDelete,
+	ActionTeamMemberAdd,
+	ActionTeamMemberRemove,
+	ActionTeamRepositoryAdd,
+	ActionTeamRepositoryRemove,
+}
+
+// TargetType is the type of the object changed by an event
+type TargetType string
+
+const (
+	TargetUser             TargetType = "user"
+	TargetRepository       TargetType = "repository"
+	TargetTeam             TargetType = "team"
+	TargetBranchProtection TargetType = "branch_protection"
+	TargetDeployKey        TargetType = "deploy_key"
+	TargetSecret           TargetType = "secret"
+	TargetAccessToken      TargetType = "access_token"
+)
+
+// Event is a security relevant change recorded in the audit log.
+// The names of the actor, repository and target are kept because they may be deleted later.
+type Event struct {
+	ID          int64
+	Action      Action     `xorm:"VARCHAR(64) INDEX"`
+	ActorID     int64      `xorm:"INDEX"`
+	ActorName   string     `xorm:"VARCHAR(255)"`
+	OwnerID     int64      `xorm:"INDEX"` // the user or organization owning the target, 0 for instance wide events
+	RepoID      int64      `xorm:"INDEX"`
+	RepoName    string     `xorm:"VARCHAR(255)"` // the full name of the repository
+	TargetType  TargetType `xorm:"VARCHAR(64)"`
+	TargetID    int64
+	TargetName  string             `xorm:"VARCHAR(255)"`
+	Before      string             `xorm:"TEXT"` // JSON encoded value of the target before the change
+	After       string             `xorm:"TEXT"` // JSON encoded value of the target after the change
+	IPAddress   string             `xorm:"VARCHAR(64)"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func init() {
+	db.RegisterModel(new(Event))
+}
+
+// TableName sets the table name of the audit events
+func (*Event) TableName() string {
+	return "audit_event"
+}
+
+// TrKey returns the locale key of the action of the event
+func (e *Event) TrKey() string {
+	return "audit.action." + string(e.Action)
+}
+
+// InsertEvent stores an event in the audit log
+func InsertEvent(ctx context.Context, e *Event) error {
+	return db.Insert(ctx, e)
+}
+
+// FindEventsOptions filters the events of the audit log
+type FindEventsOptions struct {
+	db.ListOptions
+	OwnerID    int64
+	RepoID     int64
+	ActorID    int64
+	Action     Action
+	TargetType TargetType
+	Since      timeutil.TimeStamp
+	Until      timeutil.TimeStamp
+}
+
+func (opts FindEventsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.ActorID != 0 {
+		cond = cond.And(builder.Eq{"actor_id": opts.ActorID})
+	}
+	if opts.Action != "" {
+		cond = cond.And(builder.Eq{"action": opts.Action})
+	}
+	if opts.TargetType != "" {
+		cond = cond.And(builder.Eq{"target_type": opts.TargetType})
+	}
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"created_unix": opts.Since})
+	}
+	if opts.Until > 0 {
+		cond = cond.And(builder.Lt{"created_unix": opts.Until})
+	}
+	return cond
+}
+
+func (opts FindEventsOptions) ToOrders() string {
+	return "`id` DESC"
+}
+
+// DeleteOldEvents deletes the events older than the given duration
+func DeleteOldEvents(ctx context.Context, olderThan time.Duration) error {
+	if olderThan <= 0 {
+		return nil
+	}
+
+	_, err := db.GetEngine(ctx).Where("created_unix < ?", time.Now().Add(-olderThan).Unix()).Delete(&Event{})
+	return err
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	events := []*Event{
+		{Action: ActionTeamCreate, ActorID: 2, OwnerID: 3, TargetType: TargetTeam, TargetID: 1, TargetName: "Owners"},
+		{Action: ActionCollaboratorAdd, ActorID: 2, OwnerID: 2, RepoID: 1, RepoName: "user2/repo1", TargetType: TargetUser, TargetID: 4, TargetName: "user4"},
+		{Action: ActionUserImpersonation, ActorID: 1, TargetType: TargetUser, TargetID: 2, TargetName: "user2"},
+	}
+	for _, e := range events {
+		require.NoError(t, InsertEvent(t.Context(), e))
+	}
+
+	found, err := db.Find[Event](t.Context(), FindEventsOptions{OwnerID: 3})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, ActionTeamCreate, found[0].Action)
+
+	found, err = db.Find[Event](t.Context(), FindEventsOptions{ActorID: 2})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, ActionCollaboratorAdd, found[0].Action, "the latest event is listed first")
+
+	found, err = db.Find[Event](t.Context(), FindEventsOptions{Action: ActionUserImpersonation})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "audit.action.user_impersonation", found[0].TrKey())
+
+	_, err = db.Exec(t.Context(), "UPDATE audit_event SET created_unix = 1 WHERE id = ?", events[0].ID)
+	require.NoError(t, err)
+	require.NoError(t, DeleteOldEvents(t.Context(), 24*time.Hour))
+	unittest.AssertNotExistsBean(t, &Event{ID: events[0].ID})
+	unittest.AssertExistsAndLoadBean(t, &Event{ID: events[1].ID})
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// SCIMToken is the bearer token an identity provider provisions the users of an authentication source with.
+// Every source has at most one token, it is only shown once when it is generated.
+type SCIMToken struct {
+	ID             int64  `xorm:"pk autoincr"`
+	SourceID       int64  `xorm:"UNIQUE"`
+	TokenHash      string `xorm:"UNIQUE"` // sha256 of token
+	TokenSalt      string
+	TokenLastEight string             `xorm:"INDEX token_last_eight"`
+	TeamMap        string             `xorm:"TEXT"` // JSON object of organization names to the names of the teams which can be provisioned
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SCIMToken))
+}
+
+// GenerateSCIMToken replaces the SCIM token of a source with a new one and returns its value
+func GenerateSCIMToken(ctx context.Context, sourceID int64) (string, error) {
+	salt, err := util.CryptoRandomString(10)
+	if err != nil {
+		return "", err
+	}
+	data, err := util.CryptoRandomBytes(20)
+	if err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(data)
+
+	t := &SCIMToken{
+		SourceID:       sourceID,
+		TokenHash:      HashToken(token, salt),
+		TokenSalt:      salt,
+		TokenLastEight: token[len(token)-8:],
+		CreatedUnix:    timeutil.TimeStampNow(),
+	}
+	// the mapped teams are kept when the token is regenerated
+	updated, err := db.GetEngine(ctx).Where("source_id = ?", sourceID).Cols("token_hash", "token_salt", "token_last_eight", "created_unix").Update(t)
+	if err != nil || updated > 0 {
+		return token, err
+	}
+	return token, db.Insert(ctx, t)
+}
+
+// TeamMapping returns the names of the teams which can be provisioned through the token by organization name
+func (t *SCIMToken) TeamMapping() (map[string][]string, error) {
+	mapping := make(map[string][]string)
+	if t.TeamMap == "" {
+		return mapping, nil
+	}
+	return mapping, json.Unmarshal([]byte(t.TeamMap), &mapping)
+}
+
+// UpdateSCIMTeamMap changes the teams which can be provisioned through the SCIM token of a source
+func UpdateSCIMTeamMap(ctx context.Context, sourceID int64, teamMap string) error {
+	if teamMap != "" {
+		var mapping map[string][]string
+		if err := json.Unmarshal([]byte(teamMap), &mapping); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid team mapping: %v", err)
+		}
+	}
+	updated, err := db.GetEngine(ctx).Where("source_id = ?", sourceID).Cols("team_map").Update(&SCIMToken{TeamMap: teamMap})
+	if err != nil {
+		return err
+	} else if updated == 0 {
+		return util.NewNotExistErrorf("source %d has no SCIM token", sourceID)
+	}
+	return nil
+}
+
+// GetSCIMToken returns the SCIM token of a source
+func GetSCIMToken(ctx context.Context, sourceID int64) (*SCIMToken, error) {
+	t := &SCIMToken{}
+	has, err := db.GetEngine(ctx).Where("source_id = ?", sourceID).Get(t)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("source %d has no SCIM token", sourceID)
+	}
+	return t, nil
+}
+
+// DeleteSCIMToken deletes the SCIM token of a source, which disables provisioning through it
+func DeleteSCIMToken(ctx context.Context, sourceID int64) error {
+	_, err := db.GetEngine(ctx).Where("source_id = ?", sourceID).Delete(new(SCIMToken))
+	return err
+}
+
+// GetSourceBySCIMToken returns the source a SCIM token belongs to
+func GetSourceBySCIMToken(ctx context.Context, token string) (*Source, error) {
+	if len(token) != 40 {
+		return nil, util.NewNotExistErrorf("invalid SCIM token")
+	}
+
+	var tokens []*SCIMToken
+	if err := db.GetEngine(ctx).Where("token_last_eight = ?", token[len(token)-8:]).Find(&tokens); err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(HashToken(token, t.TokenSalt))) == 1 {
+			return GetSourceByID(ctx, t.SourceID)
+		}
+	}
+	return nil, util.NewNotExistErrorf("invalid SCIM token")
+}
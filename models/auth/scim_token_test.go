@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth_test
+
+import (
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCIMToken(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	auth_model.RegisterTypeConfig(auth_model.OAuth2, new(TestSource))
+	source := &auth_model.Source{Type: auth_model.OAuth2, Name: "scim-source", IsActive: true, Cfg: &TestSource{Provider: "openidConnect"}}
+	require.NoError(t, auth_model.CreateSource(t.Context(), source))
+
+	token, err := auth_model.GenerateSCIMToken(t.Context(), source.ID)
+	require.NoError(t, err)
+
+	found, err := auth_model.GetSourceBySCIMToken(t.Context(), token)
+	require.NoError(t, err)
+	assert.Equal(t, source.ID, found.ID)
+
+	assert.ErrorIs(t, auth_model.UpdateSCIMTeamMap(t.Context(), source.ID, `{"org3": `), util.ErrInvalidArgument)
+	require.NoError(t, auth_model.UpdateSCIMTeamMap(t.Context(), source.ID, `{"org3": ["team1"]}`))
+
+	// generating a new token revokes the previous one and keeps the mapped teams
+	newToken, err := auth_model.GenerateSCIMToken(t.Context(), source.ID)
+	require.NoError(t, err)
+	_, err = auth_model.GetSourceBySCIMToken(t.Context(), token)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	_, err = auth_model.GetSourceBySCIMToken(t.Context(), newToken)
+	assert.NoError(t, err)
+	scimToken, err := auth_model.GetSCIMToken(t.Context(), source.ID)
+	require.NoError(t, err)
+	mapping, err := scimToken.TeamMapping()
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"org3": {"team1"}}, mapping)
+
+	require.NoError(t, auth_model.DeleteSCIMToken(t.Context(), source.ID))
+	_, err = auth_model.GetSourceBySCIMToken(t.Context(), newToken)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
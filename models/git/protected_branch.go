@@ -57,12 +57,15 @@ type ProtectedBranch struct {
 	BlockOnRejectedReviews        bool     `xorm:"NOT NULL DEFAULT false"`
 	BlockOnOfficialReviewRequests bool     `xorm:"NOT NULL DEFAULT false"`
 	BlockOnOutdatedBranch         bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockOnCodeOwnerReviews       bool     `xorm:"NOT NULL DEFAULT false"`
 	DismissStaleApprovals         bool     `xorm:"NOT NULL DEFAULT false"`
 	IgnoreStaleApprovals          bool     `xorm:"NOT NULL DEFAULT false"`
 	RequireSignedCommits          bool     `xorm:"NOT NULL DEFAULT false"`
 	ProtectedFilePatterns         string   `xorm:"TEXT"`
 	UnprotectedFilePatterns       string   `xorm:"TEXT"`
 	BlockAdminMergeOverride       bool     `xorm:"NOT NULL DEFAULT false"`
+	EnableMergeQueue              bool     `xorm:"NOT NULL DEFAULT false"`
+	MergeQueueMaxBatchSize        int64    `xorm:"NOT NULL DEFAULT 0"`
 
 	CreatedUnix timeutil.TimeStamp `xorm:"created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
@@ -72,6 +75,17 @@ func init() {
 	db.RegisterModel(new(ProtectedBranch))
 }
 
+// DefaultMergeQueueMaxBatchSize is the number of pull requests tested together when no batch size is configured
+const DefaultMergeQueueMaxBatchSize = 5
+
+// GetMergeQueueMaxBatchSize returns the maximum number of pull requests the merge queue tests together
+func (protectBranch *ProtectedBranch) GetMergeQueueMaxBatchSize() int {
+	if protectBranch.MergeQueueMaxBatchSize <= 0 {
+		return DefaultMergeQueueMaxBatchSize
+	}
+	return int(protectBranch.MergeQueueMaxBatchSize)
+}
+
 // IsRuleNameSpecial return true if it contains special character
 func IsRuleNameSpecial(ruleName string) bool {
 	for i := 0; i < len(ruleName); i++ {
@@ -0,0 +1,264 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// RulesetTarget represents the kind of ref updates a ruleset applies to
+type RulesetTarget string
+
+const (
+	RulesetTargetBranch RulesetTarget = "branch"
+	RulesetTargetTag    RulesetTarget = "tag"
+	RulesetTargetPush   RulesetTarget = "push" // every pushed ref, the ref patterns are ignored
+)
+
+// IsValid returns whether the target is known
+func (t RulesetTarget) IsValid() bool {
+	return t == RulesetTargetBranch || t == RulesetTargetTag || t == RulesetTargetPush
+}
+
+// RulesetEnforcement represents how the violations of a ruleset are handled
+type RulesetEnforcement int
+
+const (
+	RulesetEnforcementDisabled RulesetEnforcement = iota // the ruleset is ignored
+	RulesetEnforcementActive                             // violating pushes are rejected
+	RulesetEnforcementEvaluate                           // violations are only logged, pushes are accepted
+)
+
+// Special patterns of Ruleset.IncludeRefs, Ruleset.ExcludeRefs and Ruleset.RepoPatterns
+const (
+	RulesetPatternAll           = "~ALL"
+	RulesetPatternDefaultBranch = "~DEFAULT_BRANCH"
+)
+
+// Ruleset is a set of push rules which applies to the matching refs of a repository,
+// or of all matching repositories of an organization
+type Ruleset struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"` // set for organization rulesets
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"` // set for repository rulesets
+	Name        string             `xorm:"NOT NULL"`
+	Target      RulesetTarget      `xorm:"VARCHAR(20) NOT NULL"`
+	Enforcement RulesetEnforcement `xorm:"NOT NULL DEFAULT 0"`
+
+	// IncludeRefs and ExcludeRefs are semicolon separated lists of branch or tag name globs
+	IncludeRefs string `xorm:"TEXT"`
+	ExcludeRefs string `xorm:"TEXT"`
+	// RepoPatterns is a semicolon separated list of repository name globs, only used by organization rulesets
+	RepoPatterns string `xorm:"TEXT"`
+
+	BypassUserIDs    []int64 `xorm:"JSON TEXT"`
+	BypassTeamIDs    []int64 `xorm:"JSON TEXT"`
+	BypassDeployKeys bool    `xorm:"NOT NULL DEFAULT false"`
+	BypassActions    bool    `xorm:"NOT NULL DEFAULT false"`
+	BypassRepoAdmins bool    `xorm:"NOT NULL DEFAULT false"`
+
+	BlockCreation        bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockDeletion        bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockForcePush       bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireLinearHistory bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits bool     `xorm:"NOT NULL DEFAULT false"`
+	RequiredDeployments  []string `xorm:"JSON TEXT"`
+	CommitMessagePattern string   `xorm:"TEXT"`
+	RestrictedFilePaths  string   `xorm:"TEXT"`               // semicolon separated list of file path globs
+	MaxFileSize          int64    `xorm:"NOT NULL DEFAULT 0"` // in bytes, 0 means unlimited
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(Ruleset))
+}
+
+// IsOrgRuleset returns whether the ruleset is defined by an organization
+func (rs *Ruleset) IsOrgRuleset() bool {
+	return rs.RepoID == 0
+}
+
+// IsEnabled returns whether the ruleset is evaluated on push
+func (rs *Ruleset) IsEnabled() bool {
+	return rs.Enforcement == RulesetEnforcementActive || rs.Enforcement == RulesetEnforcementEvaluate
+}
+
+func splitRulesetPatterns(patterns string) []string {
+	var res []string
+	for pattern := range strings.SplitSeq(patterns, ";") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			res = append(res, pattern)
+		}
+	}
+	return res
+}
+
+func matchRulesetPatterns(patterns []string, name, defaultBranch string) bool {
+	for _, pattern := range patterns {
+		switch pattern {
+		case RulesetPatternAll:
+			return true
+		case RulesetPatternDefaultBranch:
+			if defaultBranch != "" && name == defaultBranch {
+				return true
+			}
+			continue
+		}
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			log.Info("Invalid ruleset pattern '%s' (skipped): %v", pattern, err)
+			continue
+		}
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchRepo returns whether an organization ruleset applies to the repository, repository rulesets always match
+func (rs *Ruleset) MatchRepo(repo *repo_model.Repository) bool {
+	if !rs.IsOrgRuleset() {
+		return rs.RepoID == repo.ID
+	}
+	patterns := splitRulesetPatterns(rs.RepoPatterns)
+	return len(patterns) == 0 || matchRulesetPatterns(patterns, repo.LowerName, "")
+}
+
+// MatchRef returns whether the ruleset applies to the update of a branch or a tag with the given short name
+func (rs *Ruleset) MatchRef(target RulesetTarget, name, defaultBranch string) bool {
+	if rs.Target == RulesetTargetPush {
+		return true
+	}
+	if rs.Target != target {
+		return false
+	}
+	if target != RulesetTargetBranch {
+		defaultBranch = ""
+	}
+	return matchRulesetPatterns(splitRulesetPatterns(rs.IncludeRefs), name, defaultBranch) &&
+		!matchRulesetPatterns(splitRulesetPatterns(rs.ExcludeRefs), name, defaultBranch)
+}
+
+// GetRestrictedFilePatterns returns the globs of the file paths which must not be changed
+func (rs *Ruleset) GetRestrictedFilePatterns() []glob.Glob {
+	return getFilePatterns(rs.RestrictedFilePaths)
+}
+
+// GetCommitMessageRegexp compiles the pattern all commit messages must match, it is nil if there is no such rule
+func (rs *Ruleset) GetCommitMessageRegexp() (*regexp.Regexp, error) {
+	if rs.CommitMessagePattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(rs.CommitMessagePattern)
+}
+
+// CanUserBypass returns whether the user is a bypass actor of the ruleset, directly or through one of its teams
+func (rs *Ruleset) CanUserBypass(ctx context.Context, userID int64) bool {
+	if slices.Contains(rs.BypassUserIDs, userID) {
+		return true
+	}
+	if len(rs.BypassTeamIDs) == 0 {
+		return false
+	}
+	in, err := organization.IsUserInTeams(ctx, userID, rs.BypassTeamIDs)
+	if err != nil {
+		log.Error("IsUserInTeams: %v", err)
+		return false
+	}
+	return in
+}
+
+// ErrRulesetNotExist represents an error when a ruleset cannot be found
+type ErrRulesetNotExist struct {
+	ID int64
+}
+
+// IsErrRulesetNotExist checks if an error is a ErrRulesetNotExist
+func IsErrRulesetNotExist(err error) bool {
+	_, ok := err.(ErrRulesetNotExist)
+	return ok
+}
+
+func (err ErrRulesetNotExist) Error() string {
+	return fmt.Sprintf("ruleset does not exist [id: %d]", err.ID)
+}
+
+func (err ErrRulesetNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// GetRulesetByID returns the ruleset of an organization (repoID is 0) or of a repository (ownerID is 0)
+func GetRulesetByID(ctx context.Context, ownerID, repoID, id int64) (*Ruleset, error) {
+	rs := &Ruleset{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND owner_id = ? AND repo_id = ?", id, ownerID, repoID).Get(rs)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrRulesetNotExist{ID: id}
+	}
+	return rs, nil
+}
+
+// GetRulesets returns the rulesets defined by an organization (repoID is 0) or by a repository (ownerID is 0)
+func GetRulesets(ctx context.Context, ownerID, repoID int64) ([]*Ruleset, error) {
+	rulesets := make([]*Ruleset, 0, 5)
+	return rulesets, db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).Asc("id").Find(&rulesets)
+}
+
+// GetEnabledRulesetsForRepo returns the enabled rulesets of the repository and of its owner which apply to the repository
+func GetEnabledRulesetsForRepo(ctx context.Context, repo *repo_model.Repository) ([]*Ruleset, error) {
+	rulesets := make([]*Ruleset, 0, 5)
+	err := db.GetEngine(ctx).
+		Where(builder.Or(
+			builder.Eq{"repo_id": repo.ID},
+			builder.Eq{"owner_id": repo.OwnerID, "repo_id": 0},
+		)).
+		And(builder.Neq{"enforcement": RulesetEnforcementDisabled}).
+		Asc("id").
+		Find(&rulesets)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(rulesets, func(rs *Ruleset) bool { return !rs.MatchRepo(repo) }), nil
+}
+
+// CreateRuleset inserts a new ruleset
+func CreateRuleset(ctx context.Context, rs *Ruleset) error {
+	_, err := db.GetEngine(ctx).Insert(rs)
+	return err
+}
+
+// UpdateRuleset updates all columns of a ruleset
+func UpdateRuleset(ctx context.Context, rs *Ruleset) error {
+	_, err := db.GetEngine(ctx).ID(rs.ID).AllCols().Update(rs)
+	return err
+}
+
+// DeleteRuleset deletes a ruleset of an organization (repoID is 0) or of a repository (ownerID is 0)
+func DeleteRuleset(ctx context.Context, ownerID, repoID, id int64) error {
+	deleted, err := db.GetEngine(ctx).Where("id = ? AND owner_id = ? AND repo_id = ?", id, ownerID, repoID).Delete(&Ruleset{})
+	if err != nil {
+		return err
+	} else if deleted == 0 {
+		return ErrRulesetNotExist{ID: id}
+	}
+	return nil
+}
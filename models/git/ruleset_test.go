@@ -0,0 +1,77 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git_test
+
+import (
+	"testing"
+
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRulesetMatchRef(t *testing.T) {
+	rs := &git_model.Ruleset{
+		Target:      git_model.RulesetTargetBranch,
+		IncludeRefs: "~DEFAULT_BRANCH; release/*",
+		ExcludeRefs: "release/old-*",
+	}
+	assert.True(t, rs.MatchRef(git_model.RulesetTargetBranch, "main", "main"))
+	assert.True(t, rs.MatchRef(git_model.RulesetTargetBranch, "release/v1", "main"))
+	assert.False(t, rs.MatchRef(git_model.RulesetTargetBranch, "release/old-v0", "main"))
+	assert.False(t, rs.MatchRef(git_model.RulesetTargetBranch, "release/v1/fix", "main"))
+	assert.False(t, rs.MatchRef(git_model.RulesetTargetBranch, "feature", "main"))
+	assert.False(t, rs.MatchRef(git_model.RulesetTargetTag, "main", "main"))
+
+	rs = &git_model.Ruleset{Target: git_model.RulesetTargetTag, IncludeRefs: "~ALL"}
+	assert.True(t, rs.MatchRef(git_model.RulesetTargetTag, "v1.0.0", "main"))
+	assert.False(t, rs.MatchRef(git_model.RulesetTargetBranch, "main", "main"))
+
+	rs = &git_model.Ruleset{Target: git_model.RulesetTargetPush}
+	assert.True(t, rs.MatchRef(git_model.RulesetTargetBranch, "feature", "main"))
+	assert.True(t, rs.MatchRef(git_model.RulesetTargetTag, "v1.0.0", "main"))
+}
+
+func TestGetEnabledRulesetsForRepo(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo3 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+	repo5 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 5})
+
+	orgRuleset := &git_model.Ruleset{OwnerID: 3, Name: "org", Target: git_model.RulesetTargetBranch, IncludeRefs: "~ALL", RepoPatterns: "repo3", Enforcement: git_model.RulesetEnforcementActive}
+	repoRuleset := &git_model.Ruleset{RepoID: 5, Name: "repo", Target: git_model.RulesetTargetTag, IncludeRefs: "v*", Enforcement: git_model.RulesetEnforcementEvaluate}
+	disabledRuleset := &git_model.Ruleset{OwnerID: 3, Name: "disabled", Target: git_model.RulesetTargetPush, Enforcement: git_model.RulesetEnforcementDisabled}
+	for _, rs := range []*git_model.Ruleset{orgRuleset, repoRuleset, disabledRuleset} {
+		assert.NoError(t, git_model.CreateRuleset(t.Context(), rs))
+	}
+
+	rulesets, err := git_model.GetEnabledRulesetsForRepo(t.Context(), repo3)
+	assert.NoError(t, err)
+	if assert.Len(t, rulesets, 1) {
+		assert.Equal(t, orgRuleset.ID, rulesets[0].ID)
+	}
+
+	rulesets, err = git_model.GetEnabledRulesetsForRepo(t.Context(), repo5)
+	assert.NoError(t, err)
+	if assert.Len(t, rulesets, 1) {
+		assert.Equal(t, repoRuleset.ID, rulesets[0].ID)
+	}
+
+	_, err = git_model.GetRulesetByID(t.Context(), 0, 5, orgRuleset.ID)
+	assert.True(t, git_model.IsErrRulesetNotExist(err))
+
+	assert.NoError(t, git_model.DeleteRuleset(t.Context(), 3, 0, orgRuleset.ID))
+	assert.True(t, git_model.IsErrRulesetNotExist(git_model.DeleteRuleset(t.Context(), 3, 0, orgRuleset.ID)))
+}
+
+func TestRulesetCanUserBypass(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	rs := &git_model.Ruleset{BypassUserIDs: []int64{4}, BypassTeamIDs: []int64{1}}
+	assert.True(t, rs.CanUserBypass(t.Context(), 4))
+	assert.True(t, rs.CanUserBypass(t.Context(), 2)) // member of team 1
+	assert.False(t, rs.CanUserBypass(t.Context(), 5))
+}
@@ -854,15 +854,22 @@ func GetCodeOwnersFromContent(ctx context.Context, data string) ([]*CodeOwnerRul
 }
 
 type CodeOwnerRule struct {
+	Pattern  string // the path pattern as written in the CODEOWNERS file
 	Rule     *regexp.Regexp
 	Negative bool
 	Users    []*user_model.User
 	Teams    []*org_model.Team
 }
 
+// MatchFile returns whether the file path is owned by the rule
+func (rule *CodeOwnerRule) MatchFile(f string) bool {
+	return rule.Rule.MatchString(f) != rule.Negative
+}
+
 func ParseCodeOwnersLine(ctx context.Context, tokens []string) (*CodeOwnerRule, []string) {
 	var err error
 	rule := &CodeOwnerRule{
+		Pattern:  tokens[0],
 		Users:    make([]*user_model.User, 0),
 		Teams:    make([]*org_model.Team, 0),
 		Negative: strings.HasPrefix(tokens[0], "!"),
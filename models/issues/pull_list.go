@@ -6,6 +6,8 @@ package issues
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"code.gitea.io/gitea/models/db"
 	access_model "code.gitea.io/gitea/models/perm/access"
@@ -69,6 +71,23 @@ func GetUnmergedPullRequestsByHeadInfo(ctx context.Context, repoID int64, branch
 	return prs, sess.Find(&prs)
 }
 
+// GetUnmergedPullRequestsByHeadBranchPrefix returns all pull requests within the repository that are open,
+// have not been merged and whose head branches start with the prefix
+func GetUnmergedPullRequestsByHeadBranchPrefix(ctx context.Context, repoID int64, prefix string) (PullRequestList, error) {
+	prs := make([]*PullRequest, 0, 5)
+	sess := db.GetEngine(ctx).
+		Join("INNER", "issue", "issue.id = pull_request.issue_id").
+		Where("head_repo_id = ? AND base_repo_id = ? AND has_merged = ? AND issue.is_closed = ? AND flow = ?", repoID, repoID, false, false, PullRequestFlowGithub).
+		And(builder.Like{"head_branch", prefix + "%"})
+	if err := sess.Find(&prs); err != nil {
+		return nil, err
+	}
+	// "_" is a wildcard of LIKE
+	return slices.DeleteFunc(prs, func(pr *PullRequest) bool {
+		return !strings.HasPrefix(pr.HeadBranch, prefix)
+	}), nil
+}
+
 // CanMaintainerWriteToBranch check whether user is a maintainer and could write to the branch
 func CanMaintainerWriteToBranch(ctx context.Context, p access_model.Permission, branch string, user *user_model.User) bool {
 	if p.CanWrite(unit.TypeCode) {
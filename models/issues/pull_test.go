@@ -339,6 +339,23 @@ func TestParseCodeOwnersLine(t *testing.T) {
 	}
 }
 
+func TestCodeOwnerRuleMatchFile(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	rule, _ := issues_model.ParseCodeOwnersLine(t.Context(), []string{`docs/.*\.md`, "@user2"})
+	if assert.NotNil(t, rule) {
+		assert.Equal(t, `docs/.*\.md`, rule.Pattern)
+		assert.True(t, rule.MatchFile("docs/README.md"))
+		assert.False(t, rule.MatchFile("README.md"))
+	}
+
+	rule, _ = issues_model.ParseCodeOwnersLine(t.Context(), []string{`!docs/.*`, "@user2"})
+	if assert.NotNil(t, rule) {
+		assert.False(t, rule.MatchFile("docs/README.md"))
+		assert.True(t, rule.MatchFile("main.go"))
+	}
+}
+
 func TestGetApprovers(t *testing.T) {
 	assert.NoError(t, unittest.PrepareTestDatabase())
 	pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: 5})
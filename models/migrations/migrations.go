@@ -399,6 +399,24 @@ func prepareMigrationTasks() []*migration {
 
 		newMigration(323, "Add support for actions concurrency", v1_26.AddActionsConcurrency),
 		newMigration(324, "add org billing table", v1_26.AddOrgBillingTable),
+		newMigration(325, "Add merge queue for protected branches", v1_26.AddMergeQueue),
+		newMigration(326, "Add organization and repository rulesets", v1_26.AddRulesets),
+		newMigration(327, "Add block on code owner reviews to protected branch", v1_26.AddBlockOnCodeOwnerReviews),
+		newMigration(328, "Add actions deployment environments", v1_26.AddActionsEnvironments),
+		newMigration(329, "Add actions reusable workflows", v1_26.AddActionsReusableWorkflows),
+		newMigration(330, "Add actions token permissions", v1_26.AddActionsTokenPermissions),
+		newMigration(331, "Add actions attestations", v1_26.AddActionsAttestations),
+		newMigration(332, "Add audit events", v1_26.AddAuditEvents),
+		newMigration(333, "Add SCIM tokens", v1_26.AddSCIMTokens),
+		newMigration(334, "Add subscription status to org billing", v1_26.AddOrgBillingSubscriptionStatus),
+		newMigration(335, "Create package remote tables", v1_26.CreatePackageRemoteTables),
+		newMigration(336, "Create package virtual source table", v1_26.CreatePackageVirtualSourceTable),
+		newMigration(337, "Create terraform state tables", v1_26.CreateTerraformStateTables),
+		newMigration(338, "Create package scanner tables", v1_26.CreatePackageScannerTables),
+		newMigration(339, "Create repo dependency table", v1_26.CreateRepoDependencyTable),
+		newMigration(340, "Create quota group tables", v1_26.CreateQuotaGroupTables),
+		newMigration(341, "Add webhook delivery retries", v1_26.AddWebhookDeliveryRetries),
+		newMigration(342, "Add team map to SCIM tokens", v1_26.AddTeamMapToSCIMTokens),
 	}
 	return preparedMigrations
 }
@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type pullMergeQueue struct {
+	ID                     int64  `xorm:"pk autoincr"`
+	RepoID                 int64  `xorm:"INDEX(s) NOT NULL"`
+	BaseBranch             string `xorm:"INDEX(s) NOT NULL"`
+	PullID                 int64  `xorm:"UNIQUE NOT NULL"`
+	DoerID                 int64  `xorm:"INDEX NOT NULL"`
+	MergeStyle             string `xorm:"varchar(30)"`
+	Message                string `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool
+	Status                 int                `xorm:"NOT NULL DEFAULT 0"`
+	BatchBaseCommitID      string             `xorm:"VARCHAR(64)"`
+	BatchHeadCommitID      string             `xorm:"VARCHAR(64) INDEX"`
+	MergeCommitID          string             `xorm:"VARCHAR(64)"`
+	CreatedUnix            timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix            timeutil.TimeStamp `xorm:"updated"`
+}
+
+func AddMergeQueue(x *xorm.Engine) error {
+	type ProtectedBranch struct {
+		EnableMergeQueue       bool  `xorm:"NOT NULL DEFAULT false"`
+		MergeQueueMaxBatchSize int64 `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	if _, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ProtectedBranch)); err != nil {
+		return err
+	}
+
+	return x.Sync(new(pullMergeQueue))
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type ruleset struct {
+	ID          int64  `xorm:"pk autoincr"`
+	OwnerID     int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string `xorm:"NOT NULL"`
+	Target      string `xorm:"VARCHAR(20) NOT NULL"`
+	Enforcement int    `xorm:"NOT NULL DEFAULT 0"`
+
+	IncludeRefs  string `xorm:"TEXT"`
+	ExcludeRefs  string `xorm:"TEXT"`
+	RepoPatterns string `xorm:"TEXT"`
+
+	BypassUserIDs    []int64 `xorm:"JSON TEXT"`
+	BypassTeamIDs    []int64 `xorm:"JSON TEXT"`
+	BypassDeployKeys bool    `xorm:"NOT NULL DEFAULT false"`
+	BypassActions    bool    `xorm:"NOT NULL DEFAULT false"`
+	BypassRepoAdmins bool    `xorm:"NOT NULL DEFAULT false"`
+
+	BlockCreation        bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockDeletion        bool     `xorm:"NOT NULL DEFAULT false"`
+	BlockForcePush       bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireLinearHistory bool     `xorm:"NOT NULL DEFAULT false"`
+	RequireSignedCommits bool     `xorm:"NOT NULL DEFAULT false"`
+	RequiredDeployments  []string `xorm:"JSON TEXT"`
+	CommitMessagePattern string   `xorm:"TEXT"`
+	RestrictedFilePaths  string   `xorm:"TEXT"`
+	MaxFileSize          int64    `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// AddRulesets creates the table of the organization and repository rulesets
+func AddRulesets(x *xorm.Engine) error {
+	return x.Sync(new(ruleset))
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type actionEnvironment struct {
+	ID                int64   `xorm:"pk autoincr"`
+	RepoID            int64   `xorm:"UNIQUE(repo_name) NOT NULL"`
+	Name              string  `xorm:"NOT NULL"`
+	LowerName         string  `xorm:"UNIQUE(repo_name) NOT NULL"`
+	ReviewerUserIDs   []int64 `xorm:"JSON TEXT"`
+	ReviewerTeamIDs   []int64 `xorm:"JSON TEXT"`
+	PreventSelfReview bool    `xorm:"NOT NULL DEFAULT false"`
+	WaitTimer         int64   `xorm:"NOT NULL DEFAULT 0"`
+	BranchPatterns    string  `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+type actionDeployment struct {
+	ID            int64              `xorm:"pk autoincr"`
+	RepoID        int64              `xorm:"INDEX NOT NULL"`
+	RunID         int64              `xorm:"INDEX NOT NULL"`
+	RunJobID      int64              `xorm:"UNIQUE(job_attempt) NOT NULL"`
+	Attempt       int64              `xorm:"UNIQUE(job_attempt) NOT NULL"`
+	EnvironmentID int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Environment   string             `xorm:"VARCHAR(255) NOT NULL"`
+	Ref           string             `xorm:"VARCHAR(255)"`
+	CommitSHA     string             `xorm:"VARCHAR(64) INDEX"`
+	TriggerUserID int64              `xorm:"NOT NULL DEFAULT 0"`
+	Status        int                `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ReviewerID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	ReviewComment string             `xorm:"TEXT"`
+	ReviewedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+func AddActionsEnvironments(x *xorm.Engine) error {
+	if err := x.Sync(new(actionEnvironment), new(actionDeployment)); err != nil {
+		return err
+	}
+
+	type ActionRunJob struct {
+		Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+
+	// the unique indexes of secrets and variables are recreated with the environment ID
+	type Secret struct {
+		OwnerID       int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+
+	type ActionVariable struct {
+		OwnerID       int64  `xorm:"UNIQUE(owner_repo_name)"`
+		RepoID        int64  `xorm:"INDEX UNIQUE(owner_repo_name)"`
+		EnvironmentID int64  `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionRunJob), new(Secret), new(ActionVariable))
+	return err
+}
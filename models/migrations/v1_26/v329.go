@@ -0,0 +1,21 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddActionsReusableWorkflows(x *xorm.Engine) error {
+	type ActionRunJob struct {
+		Uses        string            `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+		CallerJobID int64             `xorm:"INDEX NOT NULL DEFAULT 0"`
+		CallOutputs map[string]string `xorm:"JSON TEXT"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(ActionRunJob))
+	return err
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionsAttestations(x *xorm.Engine) error {
+	type ActionAttestation struct {
+		ID            int64
+		RepoID        int64 `xorm:"index"`
+		OwnerID       int64 `xorm:"index"`
+		RunID         int64 `xorm:"index"`
+		JobID         int64
+		CommitSHA     string `xorm:"VARCHAR(64)"`
+		WorkflowID    string `xorm:"VARCHAR(255)"`
+		PredicateType string `xorm:"VARCHAR(255)"`
+		Envelope      string `xorm:"LONGTEXT"`
+
+		Created timeutil.TimeStamp `xorm:"created"`
+	}
+
+	type ActionAttestationSubject struct {
+		ID            int64
+		AttestationID int64  `xorm:"index"`
+		RepoID        int64  `xorm:"index"`
+		OwnerID       int64  `xorm:"index(owner_digest)"`
+		Digest        string `xorm:"VARCHAR(255) index(owner_digest)"`
+		Name          string `xorm:"VARCHAR(255)"`
+	}
+
+	return x.Sync(new(ActionAttestation), new(ActionAttestationSubject))
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type auditEvent struct {
+	ID          int64
+	Action      string `xorm:"VARCHAR(64) INDEX"`
+	ActorID     int64  `xorm:"INDEX"`
+	ActorName   string `xorm:"VARCHAR(255)"`
+	OwnerID     int64  `xorm:"INDEX"`
+	RepoID      int64  `xorm:"INDEX"`
+	RepoName    string `xorm:"VARCHAR(255)"`
+	TargetType  string `xorm:"VARCHAR(64)"`
+	TargetID    int64
+	TargetName  string             `xorm:"VARCHAR(255)"`
+	Before      string             `xorm:"TEXT"`
+	After       string             `xorm:"TEXT"`
+	IPAddress   string             `xorm:"VARCHAR(64)"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func (*auditEvent) TableName() string {
+	return "audit_event"
+}
+
+func AddAuditEvents(x *xorm.Engine) error {
+	return x.Sync(new(auditEvent))
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddSCIMTokens(x *xorm.Engine) error {
+	type SCIMToken struct {
+		ID             int64  `xorm:"pk autoincr"`
+		SourceID       int64  `xorm:"UNIQUE"`
+		TokenHash      string `xorm:"UNIQUE"`
+		TokenSalt      string
+		TokenLastEight string             `xorm:"INDEX token_last_eight"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	}
+	return x.Sync(new(SCIMToken))
+}
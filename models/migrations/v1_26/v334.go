@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddOrgBillingSubscriptionStatus(x *xorm.Engine) error {
+	type OrgBilling struct {
+		OrgID              int64  `xorm:"pk"`
+		SubscriptionID     string `xorm:"INDEX"`
+		CustomerID         string
+		CheckoutSessionID  string
+		SubscriptionStatus string
+		IsReadOnly         bool `xorm:"NOT NULL DEFAULT false"`
+		LastSeatCount      int
+		LastSync           timeutil.TimeStamp `xorm:"INDEX"`
+		CreatedUnix        timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix        timeutil.TimeStamp `xorm:"updated"`
+	}
+	return x.Sync(new(OrgBilling))
+}
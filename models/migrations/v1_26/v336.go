@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePackageVirtualSourceTable(x *xorm.Engine) error {
+	type PackageVirtualSource struct {
+		ID            int64              `xorm:"pk autoincr"`
+		OwnerID       int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		Type          string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		SourceOwnerID int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+		Position      int                `xorm:"NOT NULL DEFAULT 0"`
+		Pattern       string             `xorm:"NOT NULL DEFAULT ''"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageVirtualSource))
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateTerraformStateTables(x *xorm.Engine) error {
+	type TerraformState struct {
+		ID        int64  `xorm:"pk autoincr"`
+		RepoID    int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Name      string `xorm:"NOT NULL"`
+		LowerName string `xorm:"UNIQUE(s) NOT NULL"`
+		Serial    int64  `xorm:"NOT NULL DEFAULT 0"`
+		Lineage   string
+
+		LockID     string
+		LockInfo   string `xorm:"TEXT"`
+		LockerID   int64
+		LockedUnix timeutil.TimeStamp
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated INDEX NOT NULL"`
+	}
+
+	type TerraformStateVersion struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX NOT NULL"`
+		StateID     int64              `xorm:"INDEX NOT NULL"`
+		Serial      int64              `xorm:"NOT NULL DEFAULT 0"`
+		Lineage     string             `xorm:"NOT NULL DEFAULT ''"`
+		Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+		HashSHA256  string             `xorm:"hash_sha256 char(64)"`
+		CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+	}
+
+	return x.Sync(new(TerraformState), new(TerraformStateVersion))
+}
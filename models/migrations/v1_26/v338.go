@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreatePackageScannerTables(x *xorm.Engine) error {
+	type PackageAdvisory struct {
+		ID            int64              `xorm:"pk autoincr"`
+		Identifier    string             `xorm:"UNIQUE NOT NULL"`
+		Aliases       []string           `xorm:"TEXT JSON"`
+		Summary       string             `xorm:"TEXT"`
+		Details       string             `xorm:"LONGTEXT"`
+		Severity      string             `xorm:"NOT NULL DEFAULT ''"`
+		References    []string           `xorm:"TEXT JSON"`
+		PublishedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		ModifiedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageAdvisoryAffected struct {
+		ID         int64  `xorm:"pk autoincr"`
+		AdvisoryID int64  `xorm:"INDEX NOT NULL"`
+		Ecosystem  string `xorm:"INDEX(s) NOT NULL"`
+		LowerName  string `xorm:"INDEX(s) NOT NULL"`
+		Affected   string `xorm:"LONGTEXT JSON"`
+	}
+
+	type PackageComponent struct {
+		ID         int64  `xorm:"pk autoincr"`
+		VersionID  int64  `xorm:"INDEX NOT NULL"`
+		Kind       string `xorm:"NOT NULL"`
+		Ecosystem  string `xorm:"NOT NULL"`
+		Name       string `xorm:"NOT NULL"`
+		SourceName string `xorm:"NOT NULL DEFAULT ''"`
+		LowerName  string `xorm:"INDEX NOT NULL"`
+		Version    string `xorm:"NOT NULL"`
+		License    string `xorm:"TEXT"`
+	}
+
+	type PackageScan struct {
+		ID          int64              `xorm:"pk autoincr"`
+		VersionID   int64              `xorm:"UNIQUE NOT NULL"`
+		ScannedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageVulnerability struct {
+		ID           int64              `xorm:"pk autoincr"`
+		VersionID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		ComponentID  int64              `xorm:"UNIQUE(s) NOT NULL"`
+		AdvisoryID   int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		FixedVersion string             `xorm:"NOT NULL DEFAULT ''"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageAdvisory), new(PackageAdvisoryAffected), new(PackageComponent), new(PackageScan), new(PackageVulnerability))
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func CreateRepoDependencyTable(x *xorm.Engine) error {
+	type RepoDependency struct {
+		ID        int64  `xorm:"pk autoincr"`
+		RepoID    int64  `xorm:"INDEX NOT NULL"`
+		CommitID  string `xorm:"VARCHAR(64)"`
+		Manifest  string `xorm:"TEXT NOT NULL"`
+		Ecosystem string `xorm:"INDEX(s) NOT NULL"`
+		Name      string `xorm:"NOT NULL"`
+		LowerName string `xorm:"INDEX(s) NOT NULL"`
+		Version   string
+		IsDirect  bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync(new(RepoDependency))
+}
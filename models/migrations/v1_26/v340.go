@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func CreateQuotaGroupTables(x *xorm.Engine) error {
+	type QuotaGroup struct {
+		ID               int64              `xorm:"pk autoincr"`
+		Name             string             `xorm:"UNIQUE NOT NULL"`
+		LimitTotal       int64              `xorm:"NOT NULL DEFAULT -1"`
+		LimitGit         int64              `xorm:"NOT NULL DEFAULT -1"`
+		LimitLFS         int64              `xorm:"NOT NULL DEFAULT -1"`
+		LimitPackages    int64              `xorm:"NOT NULL DEFAULT -1"`
+		LimitArtifacts   int64              `xorm:"NOT NULL DEFAULT -1"`
+		LimitAttachments int64              `xorm:"NOT NULL DEFAULT -1"`
+		CreatedUnix      timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix      timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	type QuotaGroupUser struct {
+		ID      int64 `xorm:"pk autoincr"`
+		GroupID int64 `xorm:"INDEX NOT NULL"`
+		UserID  int64 `xorm:"UNIQUE NOT NULL"`
+	}
+
+	return x.Sync(new(QuotaGroup), new(QuotaGroupUser))
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddWebhookDeliveryRetries(x *xorm.Engine) error {
+	type Webhook struct {
+		MaxAttempts         int `xorm:"NOT NULL DEFAULT 0"`
+		ConsecutiveFailures int `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type HookTask struct {
+		Attempt       int                `xorm:"NOT NULL DEFAULT 1"`
+		ScheduledUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+		IsExhausted   bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	}
+
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(Webhook), new(HookTask))
+	return err
+}
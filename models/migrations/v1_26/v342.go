@@ -0,0 +1,18 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddTeamMapToSCIMTokens(x *xorm.Engine) error {
+	type SCIMToken struct {
+		TeamMap string `xorm:"TEXT"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{
+		IgnoreDropIndices: true,
+	}, new(SCIMToken))
+	return err
+}
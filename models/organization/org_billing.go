@@ -8,6 +8,8 @@ import (
 
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
 )
 
 // OrgBilling stores Stripe billing identifiers per org.
@@ -16,16 +18,36 @@ type OrgBilling struct {
 	SubscriptionID    string `xorm:"INDEX"`
 	CustomerID        string
 	CheckoutSessionID string
-	LastSeatCount     int
-	LastSync          timeutil.TimeStamp `xorm:"INDEX"`
-	CreatedUnix       timeutil.TimeStamp `xorm:"created"`
-	UpdatedUnix       timeutil.TimeStamp `xorm:"updated"`
+	// SubscriptionStatus is the last status reported by the payments sidecar, e.g. "active" or "past_due"
+	SubscriptionStatus string
+	// IsReadOnly is set while the subscription is not paid, members can still read but not write
+	IsReadOnly    bool `xorm:"NOT NULL DEFAULT false"`
+	LastSeatCount int
+	LastSync      timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
 }
 
 func init() {
 	db.RegisterModel(new(OrgBilling))
 }
 
+// Subscription statuses reported by the payments sidecar which restrict the org
+const (
+	SubscriptionStatusPastDue  = "past_due"
+	SubscriptionStatusUnpaid   = "unpaid"
+	SubscriptionStatusCanceled = "canceled"
+)
+
+// IsSubscriptionStatusReadOnly returns whether an org with a subscription in the status must be read-only.
+func IsSubscriptionStatusReadOnly(status string) bool {
+	switch status {
+	case SubscriptionStatusPastDue, SubscriptionStatusUnpaid, SubscriptionStatusCanceled:
+		return true
+	}
+	return false
+}
+
 // GetOrgBilling fetches billing info for an org, or nil if none exists.
 func GetOrgBilling(ctx context.Context, orgID int64) (*OrgBilling, error) {
 	ob := new(OrgBilling)
@@ -62,3 +84,51 @@ func UpsertOrgBilling(ctx context.Context, ob *OrgBilling) error {
 	_, err = e.Insert(ob)
 	return err
 }
+
+// GetOrgBillingBySubscriptionID fetches the billing info of the org with the subscription, or nil if none exists.
+func GetOrgBillingBySubscriptionID(ctx context.Context, subscriptionID string) (*OrgBilling, error) {
+	if subscriptionID == "" {
+		return nil, nil
+	}
+	ob := new(OrgBilling)
+	has, err := db.GetEngine(ctx).Where("subscription_id = ?", subscriptionID).Get(ob)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, nil
+	}
+	return ob, nil
+}
+
+// FindSubscribedOrgBillings returns the billing info of all orgs with a subscription which has not been canceled.
+func FindSubscribedOrgBillings(ctx context.Context) ([]*OrgBilling, error) {
+	obs := make([]*OrgBilling, 0, 10)
+	return obs, db.GetEngine(ctx).
+		Where(builder.Neq{"subscription_id": ""}.And(builder.Neq{"subscription_status": SubscriptionStatusCanceled})).
+		OrderBy("org_id").
+		Find(&obs)
+}
+
+// UpdateOrgBillingSeats stores the seat count last pushed to the payments sidecar.
+func UpdateOrgBillingSeats(ctx context.Context, orgID int64, seatCount int) error {
+	_, err := db.GetEngine(ctx).ID(orgID).Cols("last_seat_count", "last_sync").Update(&OrgBilling{
+		LastSeatCount: seatCount,
+		LastSync:      timeutil.TimeStampNow(),
+	})
+	return err
+}
+
+// UpdateOrgBillingStatus stores the subscription status and marks the org read-only if it is not paid.
+func UpdateOrgBillingStatus(ctx context.Context, orgID int64, status string) error {
+	_, err := db.GetEngine(ctx).ID(orgID).Cols("subscription_status", "is_read_only").Update(&OrgBilling{
+		SubscriptionStatus: status,
+		IsReadOnly:         IsSubscriptionStatusReadOnly(status),
+	})
+	return err
+}
+
+// IsOrgReadOnly returns whether the org has been made read-only because of its subscription.
+func IsOrgReadOnly(ctx context.Context, orgID int64) (bool, error) {
+	return db.GetEngine(ctx).Where("org_id = ? AND is_read_only = ?", orgID, true).Exist(new(OrgBilling))
+}
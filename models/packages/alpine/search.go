@@ -11,11 +11,11 @@ import (
 )
 
 // GetBranches gets all available branches
-func GetBranches(ctx context.Context, ownerID int64) ([]string, error) {
+func GetBranches(ctx context.Context, ownerIDs []int64) ([]string, error) {
 	return packages_model.GetDistinctPropertyValues(
 		ctx,
 		packages_model.TypeAlpine,
-		ownerID,
+		ownerIDs,
 		packages_model.PropertyTypeFile,
 		alpine_module.PropertyBranch,
 		nil,
@@ -23,11 +23,11 @@ func GetBranches(ctx context.Context, ownerID int64) ([]string, error) {
 }
 
 // GetRepositories gets all available repositories for the given branch
-func GetRepositories(ctx context.Context, ownerID int64, branch string) ([]string, error) {
+func GetRepositories(ctx context.Context, ownerIDs []int64, branch string) ([]string, error) {
 	return packages_model.GetDistinctPropertyValues(
 		ctx,
 		packages_model.TypeAlpine,
-		ownerID,
+		ownerIDs,
 		packages_model.PropertyTypeFile,
 		alpine_module.PropertyRepository,
 		&packages_model.DistinctPropertyDependency{
@@ -38,11 +38,11 @@ func GetRepositories(ctx context.Context, ownerID int64, branch string) ([]strin
 }
 
 // GetArchitectures gets all available architectures for the given repository
-func GetArchitectures(ctx context.Context, ownerID int64, repository string) ([]string, error) {
+func GetArchitectures(ctx context.Context, ownerIDs []int64, repository string) ([]string, error) {
 	return packages_model.GetDistinctPropertyValues(
 		ctx,
 		packages_model.TypeAlpine,
-		ownerID,
+		ownerIDs,
 		packages_model.PropertyTypeFile,
 		alpine_module.PropertyArchitecture,
 		&packages_model.DistinctPropertyDependency{
@@ -11,11 +11,11 @@ import (
 )
 
 // GetRepositories gets all available repositories
-func GetRepositories(ctx context.Context, ownerID int64) ([]string, error) {
+func GetRepositories(ctx context.Context, ownerIDs []int64) ([]string, error) {
 	return packages_model.GetDistinctPropertyValues(
 		ctx,
 		packages_model.TypeArch,
-		ownerID,
+		ownerIDs,
 		packages_model.PropertyTypeFile,
 		arch_module.PropertyRepository,
 		nil,
@@ -23,11 +23,11 @@ func GetRepositories(ctx context.Context, ownerID int64) ([]string, error) {
 }
 
 // GetArchitectures gets all available architectures for the given repository
-func GetArchitectures(ctx context.Context, ownerID int64, repository string) ([]string, error) {
+func GetArchitectures(ctx context.Context, ownerIDs []int64, repository string) ([]string, error) {
 	return packages_model.GetDistinctPropertyValues(
 		ctx,
 		packages_model.TypeArch,
-		ownerID,
+		ownerIDs,
 		packages_model.PropertyTypeFile,
 		arch_module.PropertyArchitecture,
 		&packages_model.DistinctPropertyDependency{
@@ -15,7 +15,7 @@ import (
 )
 
 type PackageSearchOptions struct {
-	OwnerID      int64
+	OwnerIDs     []int64
 	Distribution string
 	Component    string
 	Architecture string
@@ -25,10 +25,9 @@ func (opts *PackageSearchOptions) toCond() builder.Cond {
 	var cond builder.Cond = builder.Eq{
 		"package_file.is_lead":        true,
 		"package.type":                packages.TypeDebian,
-		"package.owner_id":            opts.OwnerID,
 		"package.is_internal":         false,
 		"package_version.is_internal": false,
-	}
+	}.And(builder.In("package.owner_id", opts.OwnerIDs))
 
 	props := make(map[string]string)
 	if opts.Distribution != "" {
@@ -99,11 +98,11 @@ func SearchPackages(ctx context.Context, opts *PackageSearchOptions) ([]*package
 }
 
 // GetDistributions gets all available distributions
-func GetDistributions(ctx context.Context, ownerID int64) ([]string, error) {
+func GetDistributions(ctx context.Context, ownerIDs []int64) ([]string, error) {
 	return packages.GetDistinctPropertyValues(
 		ctx,
 		packages.TypeDebian,
-		ownerID,
+		ownerIDs,
 		packages.PropertyTypeFile,
 		debian_module.PropertyDistribution,
 		nil,
@@ -111,11 +110,11 @@ func GetDistributions(ctx context.Context, ownerID int64) ([]string, error) {
 }
 
 // GetComponents gets all available components for the given distribution
-func GetComponents(ctx context.Context, ownerID int64, distribution string) ([]string, error) {
+func GetComponents(ctx context.Context, ownerIDs []int64, distribution string) ([]string, error) {
 	return packages.GetDistinctPropertyValues(
 		ctx,
 		packages.TypeDebian,
-		ownerID,
+		ownerIDs,
 		packages.PropertyTypeFile,
 		debian_module.PropertyComponent,
 		&packages.DistinctPropertyDependency{
@@ -126,11 +125,11 @@ func GetComponents(ctx context.Context, ownerID int64, distribution string) ([]s
 }
 
 // GetArchitectures gets all available architectures for the given distribution
-func GetArchitectures(ctx context.Context, ownerID int64, distribution string) ([]string, error) {
+func GetArchitectures(ctx context.Context, ownerIDs []int64, distribution string) ([]string, error) {
 	return packages.GetDistinctPropertyValues(
 		ctx,
 		packages.TypeDebian,
-		ownerID,
+		ownerIDs,
 		packages.PropertyTypeFile,
 		debian_module.PropertyArchitecture,
 		&packages.DistinctPropertyDependency{
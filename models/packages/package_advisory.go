@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/packages/scanner"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrPackageAdvisoryNotExist = util.NewNotExistErrorf("package advisory does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageAdvisory))
+	db.RegisterModel(new(PackageAdvisoryAffected))
+}
+
+// PackageAdvisory represents a vulnerability imported from an OSV database
+type PackageAdvisory struct {
+	ID            int64              `xorm:"pk autoincr"`
+	Identifier    string             `xorm:"UNIQUE NOT NULL"` // the OSV id, e.g. GHSA-xxxx-xxxx-xxxx
+	Aliases       []string           `xorm:"TEXT JSON"`
+	Summary       string             `xorm:"TEXT"`
+	Details       string             `xorm:"LONGTEXT"`
+	Severity      string             `xorm:"NOT NULL DEFAULT ''"`
+	References    []string           `xorm:"TEXT JSON"`
+	PublishedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	ModifiedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// PackageAdvisoryAffected represents a package affected by an advisory
+type PackageAdvisoryAffected struct {
+	ID         int64             `xorm:"pk autoincr"`
+	AdvisoryID int64             `xorm:"INDEX NOT NULL"`
+	Ecosystem  string            `xorm:"INDEX(s) NOT NULL"` // the ecosystem without the release, e.g. Debian
+	LowerName  string            `xorm:"INDEX(s) NOT NULL"`
+	Affected   *scanner.Affected `xorm:"LONGTEXT JSON"`
+}
+
+// Link returns the link to the advisory in the OSV database
+func (pa *PackageAdvisory) Link() string {
+	return "https://osv.dev/vulnerability/" + pa.Identifier
+}
+
+// AliasesString returns the aliases of the advisory, usually CVE ids
+func (pa *PackageAdvisory) AliasesString() string {
+	return strings.Join(pa.Aliases, ", ")
+}
+
+// GetAdvisoryByIdentifier gets an advisory by its OSV id
+func GetAdvisoryByIdentifier(ctx context.Context, identifier string) (*PackageAdvisory, error) {
+	pa := &PackageAdvisory{}
+	has, err := db.GetEngine(ctx).Where("identifier = ?", identifier).Get(pa)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageAdvisoryNotExist
+	}
+	return pa, nil
+}
+
+// GetAdvisoriesByIDs gets the advisories mapped by their id
+func GetAdvisoriesByIDs(ctx context.Context, ids []int64) (map[int64]*PackageAdvisory, error) {
+	advisories := make(map[int64]*PackageAdvisory, len(ids))
+	if len(ids) == 0 {
+		return advisories, nil
+	}
+	return advisories, db.GetEngine(ctx).In("id", ids).Find(&advisories)
+}
+
+// CountAdvisories counts all imported advisories
+func CountAdvisories(ctx context.Context) (int64, error) {
+	return db.GetEngine(ctx).Count(&PackageAdvisory{})
+}
+
+// SaveAdvisory inserts or replaces an advisory together with its affected packages
+func SaveAdvisory(ctx context.Context, pa *PackageAdvisory, affected []*PackageAdvisoryAffected) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetAdvisoryByIdentifier(ctx, pa.Identifier)
+		if err != nil && err != ErrPackageAdvisoryNotExist {
+			return err
+		}
+		if existing != nil {
+			pa.ID = existing.ID
+			if _, err := db.GetEngine(ctx).ID(pa.ID).AllCols().Update(pa); err != nil {
+				return err
+			}
+			if _, err := db.GetEngine(ctx).Where("advisory_id = ?", pa.ID).Delete(&PackageAdvisoryAffected{}); err != nil {
+				return err
+			}
+		} else if err := db.Insert(ctx, pa); err != nil {
+			return err
+		}
+
+		for _, paa := range affected {
+			paa.AdvisoryID = pa.ID
+		}
+		if len(affected) == 0 {
+			return nil
+		}
+		return db.Insert(ctx, affected)
+	})
+}
+
+// DeleteAdvisoryByIdentifier deletes an advisory and the vulnerabilities reported for it
+func DeleteAdvisoryByIdentifier(ctx context.Context, identifier string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		pa, err := GetAdvisoryByIdentifier(ctx, identifier)
+		if err != nil {
+			if err == ErrPackageAdvisoryNotExist {
+				return nil
+			}
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("advisory_id = ?", pa.ID).Delete(&PackageAdvisoryAffected{}); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where("advisory_id = ?", pa.ID).Delete(&PackageVulnerability{}); err != nil {
+			return err
+		}
+		_, err = db.GetEngine(ctx).ID(pa.ID).Delete(&PackageAdvisory{})
+		return err
+	})
+}
+
+// FindAffectedByNames finds the affected packages of an ecosystem with the names
+func FindAffectedByNames(ctx context.Context, ecosystem string, lowerNames []string) ([]*PackageAdvisoryAffected, error) {
+	affected := make([]*PackageAdvisoryAffected, 0, 10)
+	if len(lowerNames) == 0 {
+		return affected, nil
+	}
+	return affected, db.GetEngine(ctx).
+		Where(builder.Eq{"ecosystem": ecosystem}.And(builder.In("lower_name", lowerNames))).
+		Find(&affected)
+}
@@ -89,7 +89,8 @@ func FindExpiredUnreferencedBlobs(ctx context.Context, olderThan time.Duration)
 	return pbs, db.GetEngine(ctx).
 		Table("package_blob").
 		Join("LEFT", "package_file", "package_file.blob_id = package_blob.id").
-		Where("package_file.id IS NULL AND package_blob.created_unix < ?", time.Now().Add(-olderThan).Unix()).
+		Join("LEFT", "package_remote_file", "package_remote_file.blob_id = package_blob.id").
+		Where("package_file.id IS NULL AND package_remote_file.id IS NULL AND package_blob.created_unix < ?", time.Now().Add(-olderThan).Unix()).
 		Find(&pbs)
 }
 
@@ -110,7 +111,8 @@ func GetTotalUnreferencedBlobSize(ctx context.Context) (int64, error) {
 	return db.GetEngine(ctx).
 		Table("package_blob").
 		Join("LEFT", "package_file", "package_file.blob_id = package_blob.id").
-		Where("package_file.id IS NULL").
+		Join("LEFT", "package_remote_file", "package_remote_file.blob_id = package_blob.id").
+		Where("package_file.id IS NULL AND package_remote_file.id IS NULL").
 		SumInt(&PackageBlob{}, "size")
 }
 
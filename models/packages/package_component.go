@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/packages/scanner"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageComponent))
+	db.RegisterModel(new(PackageScan))
+}
+
+// PackageComponent represents a piece of software contained in or required by a package version
+type PackageComponent struct {
+	ID         int64        `xorm:"pk autoincr"`
+	VersionID  int64        `xorm:"INDEX NOT NULL"`
+	Kind       scanner.Kind `xorm:"NOT NULL"`
+	Ecosystem  string       `xorm:"NOT NULL"`
+	Name       string       `xorm:"NOT NULL"`
+	SourceName string       `xorm:"NOT NULL DEFAULT ''"`
+	LowerName  string       `xorm:"INDEX NOT NULL"` // the normalized name used to match advisories
+	Version    string       `xorm:"NOT NULL"`
+	License    string       `xorm:"TEXT"`
+}
+
+// PackageScan records when the components of a package version were extracted
+type PackageScan struct {
+	ID          int64              `xorm:"pk autoincr"`
+	VersionID   int64              `xorm:"UNIQUE NOT NULL"`
+	ScannedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// ReplaceComponents replaces the components of a package version and its vulnerabilities
+func ReplaceComponents(ctx context.Context, versionID int64, components []*PackageComponent) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := DeleteScanResultsByVersionID(ctx, versionID); err != nil {
+			return err
+		}
+		if len(components) > 0 {
+			for _, pc := range components {
+				pc.VersionID = versionID
+			}
+			if err := db.Insert(ctx, components); err != nil {
+				return err
+			}
+		}
+		return db.Insert(ctx, &PackageScan{VersionID: versionID, ScannedUnix: timeutil.TimeStampNow()})
+	})
+}
+
+// GetComponentsByVersionID gets the components of a package version
+func GetComponentsByVersionID(ctx context.Context, versionID int64) ([]*PackageComponent, error) {
+	components := make([]*PackageComponent, 0, 10)
+	return components, db.GetEngine(ctx).Where("version_id = ?", versionID).OrderBy("kind, lower_name, id").Find(&components)
+}
+
+// GetScanByVersionID gets the scan of a package version, nil is returned if the version was not scanned yet
+func GetScanByVersionID(ctx context.Context, versionID int64) (*PackageScan, error) {
+	ps := &PackageScan{}
+	has, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Get(ps)
+	if err != nil || !has {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// IterateScannedVersionIDs calls the function for every scanned package version
+func IterateScannedVersionIDs(ctx context.Context, f func(ctx context.Context, versionID int64) error) error {
+	return db.Iterate(ctx, nil, func(ctx context.Context, ps *PackageScan) error {
+		return f(ctx, ps.VersionID)
+	})
+}
+
+// GetUnscannedVersionIDs gets package versions of the types whose components were not extracted yet
+func GetUnscannedVersionIDs(ctx context.Context, types []Type, limit int) ([]int64, error) {
+	ids := make([]int64, 0, limit)
+	return ids, db.GetEngine(ctx).
+		Table("package_version").
+		Join("INNER", "package", "package.id = package_version.package_id").
+		Where(builder.In("package.type", types).
+			And(builder.Eq{"package_version.is_internal": false}).
+			And(builder.NotIn("package_version.id", builder.Select("version_id").From("package_scan")))).
+		OrderBy("package_version.id").
+		Limit(limit).
+		Cols("package_version.id").
+		Find(&ids)
+}
+
+// DeleteScanResultsByVersionID deletes the components, the vulnerabilities and the scan of a package version
+func DeleteScanResultsByVersionID(ctx context.Context, versionID int64) error {
+	if _, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageVulnerability{}); err != nil {
+		return err
+	}
+	if _, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageComponent{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageScan{})
+	return err
+}
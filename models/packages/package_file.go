@@ -123,6 +123,7 @@ func UpdateFile(ctx context.Context, pf *PackageFile, cols []string) error {
 // PackageFileSearchOptions are options for SearchXXX methods
 type PackageFileSearchOptions struct {
 	OwnerID       int64
+	OwnerIDs      []int64 // searches the files of any of the owners, used instead of OwnerID if set
 	PackageType   Type
 	VersionID     int64
 	Query         string
@@ -139,11 +140,13 @@ func (opts *PackageFileSearchOptions) toConds() builder.Cond {
 
 	if opts.VersionID != 0 {
 		cond = cond.And(builder.Eq{"package_file.version_id": opts.VersionID})
-	} else if opts.OwnerID != 0 || (opts.PackageType != "" && opts.PackageType != "all") {
+	} else if opts.OwnerID != 0 || len(opts.OwnerIDs) != 0 || (opts.PackageType != "" && opts.PackageType != "all") {
 		var versionCond builder.Cond = builder.Eq{
 			"package_version.is_internal": false,
 		}
-		if opts.OwnerID != 0 {
+		if len(opts.OwnerIDs) != 0 {
+			versionCond = versionCond.And(builder.In("package.owner_id", opts.OwnerIDs))
+		} else if opts.OwnerID != 0 {
 			versionCond = versionCond.And(builder.Eq{"package.owner_id": opts.OwnerID})
 		}
 		if opts.PackageType != "" && opts.PackageType != "all" {
@@ -105,13 +105,12 @@ type DistinctPropertyDependency struct {
 
 // GetDistinctPropertyValues returns all distinct property values for a given type.
 // Optional: Search only in dependence of another property.
-func GetDistinctPropertyValues(ctx context.Context, packageType Type, ownerID int64, refType PropertyType, propertyName string, dep *DistinctPropertyDependency) ([]string, error) {
+func GetDistinctPropertyValues(ctx context.Context, packageType Type, ownerIDs []int64, refType PropertyType, propertyName string, dep *DistinctPropertyDependency) ([]string, error) {
 	var cond builder.Cond = builder.Eq{
 		"package_property.ref_type": refType,
 		"package_property.name":     propertyName,
 		"package.type":              packageType,
-		"package.owner_id":          ownerID,
-	}
+	}.And(builder.In("package.owner_id", ownerIDs))
 	if dep != nil {
 		innerCond := builder.
 			Expr("pp.ref_id = package_property.ref_id").
@@ -0,0 +1,231 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/secret"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrPackageRemoteNotExist     = util.NewNotExistErrorf("package remote does not exist")
+	ErrPackageRemoteFileNotExist = util.NewNotExistErrorf("package remote file does not exist")
+)
+
+// RemoteTypes are the package types which can be backed by a remote repository
+var RemoteTypes = []Type{
+	TypeCargo,
+	TypeContainer,
+	TypeGo,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// IsRemoteType tests if the package type can be backed by a remote repository
+func IsRemoteType(t Type) bool {
+	for _, rt := range RemoteTypes {
+		if rt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRemoteMetadataTTL is the default time cached metadata of a remote repository is served without asking the upstream
+const DefaultRemoteMetadataTTL = 30 * time.Minute
+
+func init() {
+	db.RegisterModel(new(PackageRemote))
+	db.RegisterModel(new(PackageRemoteFile))
+}
+
+// PackageRemote represents an upstream registry whose packages are served through an owner's registry of a type.
+// Requests the owner's registry can not answer are fetched from the upstream and cached.
+type PackageRemote struct {
+	ID                int64              `xorm:"pk autoincr"`
+	Enabled           bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	OwnerID           int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type              Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	URL               string             `xorm:"TEXT NOT NULL"`
+	Username          string             `xorm:"NOT NULL DEFAULT ''"`
+	PasswordEncrypted string             `xorm:"TEXT"`               // should be accessed using Password() and SetPassword()
+	MetadataTTL       int64              `xorm:"NOT NULL DEFAULT 0"` // seconds, 0 means DefaultRemoteMetadataTTL
+	CreatedUnix       timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix       timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// Password returns the decrypted password of the upstream
+func (pr *PackageRemote) Password() (string, error) {
+	if pr.PasswordEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, pr.PasswordEncrypted)
+}
+
+// SetPassword encrypts and sets the password of the upstream
+func (pr *PackageRemote) SetPassword(password string) error {
+	if password == "" {
+		pr.PasswordEncrypted = ""
+		return nil
+	}
+	encrypted, err := secret.EncryptSecret(setting.SecretKey, password)
+	if err != nil {
+		return err
+	}
+	pr.PasswordEncrypted = encrypted
+	return nil
+}
+
+// GetMetadataTTL returns how long cached metadata is served without asking the upstream
+func (pr *PackageRemote) GetMetadataTTL() time.Duration {
+	if pr.MetadataTTL <= 0 {
+		return DefaultRemoteMetadataTTL
+	}
+	return time.Duration(pr.MetadataTTL) * time.Second
+}
+
+func InsertRemote(ctx context.Context, pr *PackageRemote) (*PackageRemote, error) {
+	return pr, db.Insert(ctx, pr)
+}
+
+func GetRemoteByID(ctx context.Context, id int64) (*PackageRemote, error) {
+	pr := &PackageRemote{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return pr, nil
+}
+
+// GetEnabledRemoteByOwnerAndType returns the enabled remote of the owner's registry of the type
+func GetEnabledRemoteByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageRemote, error) {
+	pr := &PackageRemote{}
+
+	has, err := db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ? AND enabled = ?", ownerID, packageType, true).
+		Get(pr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return pr, nil
+}
+
+func UpdateRemote(ctx context.Context, pr *PackageRemote) error {
+	_, err := db.GetEngine(ctx).ID(pr.ID).AllCols().Update(pr)
+	return err
+}
+
+func GetRemotesByOwner(ctx context.Context, ownerID int64) ([]*PackageRemote, error) {
+	prs := make([]*PackageRemote, 0, 10)
+	return prs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&prs)
+}
+
+// DeleteRemoteByID deletes the remote and its cached files, the blobs are removed by the cleanup task
+func DeleteRemoteByID(ctx context.Context, remoteID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := DeleteRemoteFilesByRemoteID(ctx, remoteID); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(remoteID).Delete(&PackageRemote{})
+		return err
+	})
+}
+
+// DeleteRemotesByOwner deletes all remotes of the owner and their cached files
+func DeleteRemotesByOwner(ctx context.Context, ownerID int64) error {
+	prs, err := GetRemotesByOwner(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	for _, pr := range prs {
+		if err := DeleteRemoteByID(ctx, pr.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func HasOwnerRemoteForPackageType(ctx context.Context, ownerID int64, packageType Type) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		Exist(&PackageRemote{})
+}
+
+// PackageRemoteFile represents a file fetched from the upstream of a remote, identified by its path
+type PackageRemoteFile struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RemoteID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	PathHash    string             `xorm:"UNIQUE(s) NOT NULL"`
+	Path        string             `xorm:"TEXT NOT NULL"`
+	BlobID      int64              `xorm:"INDEX NOT NULL"`
+	ContentType string             `xorm:"NOT NULL DEFAULT ''"`
+	IsMetadata  bool               `xorm:"NOT NULL DEFAULT false"`
+	FetchedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// RemoteFilePathHash returns the hash the path of a remote file is looked up by, paths can be longer than an index allows
+func RemoteFilePathHash(path string) string {
+	h := sha256.Sum256([]byte(path))
+	return hex.EncodeToString(h[:])
+}
+
+func GetRemoteFileByPath(ctx context.Context, remoteID int64, path string) (*PackageRemoteFile, error) {
+	prf := &PackageRemoteFile{}
+
+	has, err := db.GetEngine(ctx).
+		Where("remote_id = ? AND path_hash = ?", remoteID, RemoteFilePathHash(path)).
+		Get(prf)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteFileNotExist
+	}
+	return prf, nil
+}
+
+// SaveRemoteFile inserts the remote file or replaces the blob of an existing file with the same path
+func SaveRemoteFile(ctx context.Context, prf *PackageRemoteFile) error {
+	prf.PathHash = RemoteFilePathHash(prf.Path)
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := GetRemoteFileByPath(ctx, prf.RemoteID, prf.Path)
+		if err != nil && err != ErrPackageRemoteFileNotExist {
+			return err
+		}
+		if existing == nil {
+			return db.Insert(ctx, prf)
+		}
+		prf.ID = existing.ID
+		_, err = db.GetEngine(ctx).ID(prf.ID).AllCols().Update(prf)
+		return err
+	})
+}
+
+// CountRemoteFiles returns the number of cached files of the remote
+func CountRemoteFiles(ctx context.Context, remoteID int64) (int64, error) {
+	return db.GetEngine(ctx).Where("remote_id = ?", remoteID).Count(&PackageRemoteFile{})
+}
+
+// DeleteRemoteFilesByRemoteID removes the cached files of the remote, the blobs are removed by the cleanup task
+func DeleteRemoteFilesByRemoteID(ctx context.Context, remoteID int64) error {
+	_, err := db.GetEngine(ctx).Where("remote_id = ?", remoteID).Delete(&PackageRemoteFile{})
+	return err
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRemote(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pr, err := packages_model.InsertRemote(t.Context(), &packages_model.PackageRemote{
+		Enabled:  true,
+		OwnerID:  2,
+		Type:     packages_model.TypeNpm,
+		URL:      "https://registry.npmjs.org",
+		Username: "user",
+	})
+	require.NoError(t, err)
+
+	t.Run("Password", func(t *testing.T) {
+		require.NoError(t, pr.SetPassword("secret"))
+		assert.NotEmpty(t, pr.PasswordEncrypted)
+		assert.NotEqual(t, "secret", pr.PasswordEncrypted)
+
+		password, err := pr.Password()
+		require.NoError(t, err)
+		assert.Equal(t, "secret", password)
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		has, err := packages_model.HasOwnerRemoteForPackageType(t.Context(), 2, packages_model.TypeNpm)
+		require.NoError(t, err)
+		assert.True(t, has)
+
+		_, err = packages_model.GetEnabledRemoteByOwnerAndType(t.Context(), 2, packages_model.TypePyPI)
+		assert.ErrorIs(t, err, packages_model.ErrPackageRemoteNotExist)
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		pb, _, err := packages_model.GetOrInsertBlob(t.Context(), &packages_model.PackageBlob{HashSHA256: "remote"})
+		require.NoError(t, err)
+
+		prf := &packages_model.PackageRemoteFile{RemoteID: pr.ID, Path: "test", BlobID: pb.ID, IsMetadata: true}
+		require.NoError(t, packages_model.SaveRemoteFile(t.Context(), prf))
+		prf = &packages_model.PackageRemoteFile{RemoteID: pr.ID, Path: "test", BlobID: pb.ID, ContentType: "application/json"}
+		require.NoError(t, packages_model.SaveRemoteFile(t.Context(), prf))
+
+		prf, err = packages_model.GetRemoteFileByPath(t.Context(), pr.ID, "test")
+		require.NoError(t, err)
+		assert.False(t, prf.IsMetadata)
+		assert.Equal(t, "application/json", prf.ContentType)
+
+		count, err := packages_model.CountRemoteFiles(t.Context(), pr.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, count)
+
+		// cached blobs are not removed by the cleanup
+		pbs, err := packages_model.FindExpiredUnreferencedBlobs(t.Context(), -time.Hour)
+		require.NoError(t, err)
+		for _, expired := range pbs {
+			assert.NotEqual(t, pb.ID, expired.ID)
+		}
+
+		require.NoError(t, packages_model.DeleteRemoteByID(t.Context(), pr.ID))
+
+		_, err = packages_model.GetRemoteFileByPath(t.Context(), pr.ID, "test")
+		assert.ErrorIs(t, err, packages_model.ErrPackageRemoteFileNotExist)
+
+		pbs, err = packages_model.FindExpiredUnreferencedBlobs(t.Context(), -time.Hour)
+		require.NoError(t, err)
+		found := false
+		for _, expired := range pbs {
+			found = found || expired.ID == pb.ID
+		}
+		assert.True(t, found)
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrPackageVirtualSourceNotExist = util.NewNotExistErrorf("package virtual source does not exist")
+
+// VirtualTypes are the package types whose registries can resolve packages through the registries of other owners
+var VirtualTypes = []Type{
+	TypeAlpine,
+	TypeArch,
+	TypeCargo,
+	TypeContainer,
+	TypeDebian,
+	TypeGo,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+	TypeRpm,
+}
+
+// IsVirtualType tests if the registries of the package type can have virtual sources
+func IsVirtualType(t Type) bool {
+	for _, vt := range VirtualTypes {
+		if vt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRepositoryIndexType tests if the registries of the package type serve a repository index.
+// The index of a registry with virtual sources includes the packages of the sources.
+func IsRepositoryIndexType(t Type) bool {
+	switch t {
+	case TypeAlpine, TypeArch, TypeDebian, TypeRpm:
+		return true
+	}
+	return false
+}
+
+func init() {
+	db.RegisterModel(new(PackageVirtualSource))
+}
+
+// PackageVirtualSource represents a registry of another owner which the owner's registry of a type resolves packages through.
+// Packages which do not exist in the owner's registry are looked up in the sources ordered by position,
+// a source resolves a package through its local packages and its remote.
+type PackageVirtualSource struct {
+	ID             int64              `xorm:"pk autoincr"`
+	OwnerID        int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Type           Type               `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	SourceOwnerID  int64              `xorm:"UNIQUE(s) INDEX NOT NULL DEFAULT 0"`
+	Position       int                `xorm:"NOT NULL DEFAULT 0"`
+	Pattern        string             `xorm:"NOT NULL DEFAULT ''"` // glob of the package names resolved through the source, empty matches all
+	PatternMatcher glob.Glob          `xorm:"-"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix    timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// CompiledPattern compiles the pattern of the source
+func (pvs *PackageVirtualSource) CompiledPattern() error {
+	if pvs.PatternMatcher != nil || pvs.Pattern == "" {
+		return nil
+	}
+
+	var err error
+	pvs.PatternMatcher, err = glob.Compile(strings.ToLower(pvs.Pattern))
+	return err
+}
+
+// Matches tests if the package name is resolved through the source
+func (pvs *PackageVirtualSource) Matches(packageName string) bool {
+	if pvs.Pattern == "" {
+		return true
+	}
+	if err := pvs.CompiledPattern(); err != nil {
+		return false
+	}
+	return pvs.PatternMatcher.Match(strings.ToLower(packageName))
+}
+
+func InsertVirtualSource(ctx context.Context, pvs *PackageVirtualSource) (*PackageVirtualSource, error) {
+	return pvs, db.Insert(ctx, pvs)
+}
+
+func GetVirtualSourceByID(ctx context.Context, id int64) (*PackageVirtualSource, error) {
+	pvs := &PackageVirtualSource{}
+
+	has, err := db.GetEngine(ctx).ID(id).Get(pvs)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrPackageVirtualSourceNotExist
+	}
+	return pvs, nil
+}
+
+func UpdateVirtualSource(ctx context.Context, pvs *PackageVirtualSource) error {
+	_, err := db.GetEngine(ctx).ID(pvs.ID).AllCols().Update(pvs)
+	return err
+}
+
+func DeleteVirtualSourceByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(&PackageVirtualSource{})
+	return err
+}
+
+// DeleteVirtualSourcesByOwner deletes the sources of the owner's registries and the sources which point to the owner
+func DeleteVirtualSourcesByOwner(ctx context.Context, ownerID int64) error {
+	_, err := db.GetEngine(ctx).Where("owner_id = ? OR source_owner_id = ?", ownerID, ownerID).Delete(&PackageVirtualSource{})
+	return err
+}
+
+// GetVirtualSourcesByOwner returns the sources of all registries of the owner ordered by type and position
+func GetVirtualSourcesByOwner(ctx context.Context, ownerID int64) ([]*PackageVirtualSource, error) {
+	sources := make([]*PackageVirtualSource, 0, 10)
+	return sources, db.GetEngine(ctx).
+		Where("owner_id = ?", ownerID).
+		OrderBy("type ASC, position ASC, id ASC").
+		Find(&sources)
+}
+
+// GetVirtualSourcesByOwnerAndType returns the sources of the owner's registry of the type in the order they are resolved
+func GetVirtualSourcesByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) ([]*PackageVirtualSource, error) {
+	sources := make([]*PackageVirtualSource, 0, 5)
+	return sources, db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ?", ownerID, packageType).
+		OrderBy("position ASC, id ASC").
+		Find(&sources)
+}
+
+// GetVirtualOwnerIDsBySource returns the owners whose registry of the type has a source pointing to the source owner
+func GetVirtualOwnerIDsBySource(ctx context.Context, sourceOwnerID int64, packageType Type) ([]int64, error) {
+	ownerIDs := make([]int64, 0, 5)
+	return ownerIDs, db.GetEngine(ctx).
+		Table("package_virtual_source").
+		Where("source_owner_id = ? AND type = ?", sourceOwnerID, packageType).
+		Cols("owner_id").
+		Find(&ownerIDs)
+}
+
+func HasVirtualSource(ctx context.Context, ownerID int64, packageType Type, sourceOwnerID int64) (bool, error) {
+	return db.GetEngine(ctx).
+		Where("owner_id = ? AND type = ? AND source_owner_id = ?", ownerID, packageType, sourceOwnerID).
+		Exist(&PackageVirtualSource{})
+}
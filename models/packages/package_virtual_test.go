@@ -0,0 +1,82 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageVirtualSource(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for _, pvs := range []*packages_model.PackageVirtualSource{
+		{OwnerID: 3, Type: packages_model.TypeNpm, SourceOwnerID: 2, Position: 2},
+		{OwnerID: 3, Type: packages_model.TypeNpm, SourceOwnerID: 5, Position: 1, Pattern: "@corp/*"},
+		{OwnerID: 3, Type: packages_model.TypeDebian, SourceOwnerID: 2},
+	} {
+		_, err := packages_model.InsertVirtualSource(t.Context(), pvs)
+		require.NoError(t, err)
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		pvss, err := packages_model.GetVirtualSourcesByOwnerAndType(t.Context(), 3, packages_model.TypeNpm)
+		require.NoError(t, err)
+		require.Len(t, pvss, 2)
+		assert.EqualValues(t, 5, pvss[0].SourceOwnerID)
+		assert.EqualValues(t, 2, pvss[1].SourceOwnerID)
+
+		pvss, err = packages_model.GetVirtualSourcesByOwner(t.Context(), 3)
+		require.NoError(t, err)
+		assert.Len(t, pvss, 3)
+	})
+
+	t.Run("Matches", func(t *testing.T) {
+		pvs := &packages_model.PackageVirtualSource{}
+		assert.True(t, pvs.Matches("anything"))
+
+		pvs = &packages_model.PackageVirtualSource{Pattern: "@Corp/*"}
+		assert.True(t, pvs.Matches("@corp/lib"))
+		assert.True(t, pvs.Matches("@CORP/lib"))
+		assert.False(t, pvs.Matches("@other/lib"))
+		assert.False(t, pvs.Matches("lib"))
+
+		pvs = &packages_model.PackageVirtualSource{Pattern: "["}
+		assert.Error(t, pvs.CompiledPattern())
+		assert.False(t, pvs.Matches("["))
+	})
+
+	t.Run("Source", func(t *testing.T) {
+		has, err := packages_model.HasVirtualSource(t.Context(), 3, packages_model.TypeNpm, 2)
+		require.NoError(t, err)
+		assert.True(t, has)
+
+		has, err = packages_model.HasVirtualSource(t.Context(), 3, packages_model.TypePyPI, 2)
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		ownerIDs, err := packages_model.GetVirtualOwnerIDsBySource(t.Context(), 2, packages_model.TypeDebian)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3}, ownerIDs)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, packages_model.DeleteVirtualSourcesByOwner(t.Context(), 2))
+
+		pvss, err := packages_model.GetVirtualSourcesByOwner(t.Context(), 3)
+		require.NoError(t, err)
+		require.Len(t, pvss, 1)
+		assert.EqualValues(t, 5, pvss[0].SourceOwnerID)
+
+		require.NoError(t, packages_model.DeleteVirtualSourceByID(t.Context(), pvss[0].ID))
+
+		_, err = packages_model.GetVirtualSourceByID(t.Context(), pvss[0].ID)
+		assert.ErrorIs(t, err, packages_model.ErrPackageVirtualSourceNotExist)
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+func init() {
+	db.RegisterModel(new(PackageVulnerability))
+}
+
+// PackageVulnerability represents an advisory affecting a component of a package version
+type PackageVulnerability struct {
+	ID           int64              `xorm:"pk autoincr"`
+	VersionID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	ComponentID  int64              `xorm:"UNIQUE(s) NOT NULL"`
+	AdvisoryID   int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	FixedVersion string             `xorm:"NOT NULL DEFAULT ''"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+
+	Component *PackageComponent `xorm:"-"`
+	Advisory  *PackageAdvisory  `xorm:"-"`
+}
+
+// GetVulnerabilitiesByVersionID gets the vulnerabilities of a package version
+func GetVulnerabilitiesByVersionID(ctx context.Context, versionID int64) ([]*PackageVulnerability, error) {
+	vulnerabilities := make([]*PackageVulnerability, 0, 10)
+	return vulnerabilities, db.GetEngine(ctx).Where("version_id = ?", versionID).OrderBy("id").Find(&vulnerabilities)
+}
+
+// InsertVulnerabilities inserts vulnerabilities
+func InsertVulnerabilities(ctx context.Context, vulnerabilities []*PackageVulnerability) error {
+	if len(vulnerabilities) == 0 {
+		return nil
+	}
+	return db.Insert(ctx, vulnerabilities)
+}
+
+// DeleteVulnerabilitiesByIDs deletes vulnerabilities
+func DeleteVulnerabilitiesByIDs(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).In("id", ids).Delete(&PackageVulnerability{})
+	return err
+}
+
+// LoadVulnerabilityAttributes loads the components and advisories of the vulnerabilities
+func LoadVulnerabilityAttributes(ctx context.Context, vulnerabilities []*PackageVulnerability) error {
+	if len(vulnerabilities) == 0 {
+		return nil
+	}
+
+	componentIDs := make([]int64, 0, len(vulnerabilities))
+	advisoryIDs := make([]int64, 0, len(vulnerabilities))
+	for _, pv := range vulnerabilities {
+		componentIDs = append(componentIDs, pv.ComponentID)
+		advisoryIDs = append(advisoryIDs, pv.AdvisoryID)
+	}
+
+	components := make(map[int64]*PackageComponent, len(componentIDs))
+	if err := db.GetEngine(ctx).In("id", componentIDs).Find(&components); err != nil {
+		return err
+	}
+	advisories, err := GetAdvisoriesByIDs(ctx, advisoryIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, pv := range vulnerabilities {
+		pv.Component = components[pv.ComponentID]
+		pv.Advisory = advisories[pv.AdvisoryID]
+	}
+	return nil
+}
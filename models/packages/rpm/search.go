@@ -11,11 +11,11 @@ import (
 )
 
 // GetGroups gets all available groups
-func GetGroups(ctx context.Context, ownerID int64) ([]string, error) {
+func GetGroups(ctx context.Context, ownerIDs []int64) ([]string, error) {
 	return packages_model.GetDistinctPropertyValues(
 		ctx,
 		packages_model.TypeRpm,
-		ownerID,
+		ownerIDs,
 		packages_model.PropertyTypeFile,
 		rpm_module.PropertyGroup,
 		nil,
@@ -291,7 +291,43 @@ func GetActionsUserRepoPermission(ctx context.Context, repo *repo_model.Reposito
 		return perm, err
 	}
 	perm.SetUnitsWithDefaultAccessMode(repo.Units, accessMode)
-	return perm, nil
+
+	// the token can't exceed the permissions granted to the job by the "permissions" of its workflow
+	tokenPerms, err := actions_model.GetTaskTokenPermissions(ctx, task)
+	if err != nil {
+		return perm, err
+	}
+	for _, u := range repo.Units {
+		perm.unitsMode[u.Type] = min(accessMode, tokenPerms.UnitAccessMode(u.Type))
+	}
+	return perm, restrictReadOnlyOwner(ctx, repo, &perm)
+}
+
+// restrictReadOnlyOwner limits the permission to read access if the owner of the repository is an organization
+// which has been made read-only, e.g. because its subscription has not been paid
+func restrictReadOnlyOwner(ctx context.Context, repo *repo_model.Repository, perm *Permission) error {
+	if !setting.Payments.Enabled {
+		// only the payments make organizations read-only
+		return nil
+	}
+
+	canWrite := perm.AccessMode > perm_model.AccessModeRead
+	for _, mode := range perm.unitsMode {
+		canWrite = canWrite || mode > perm_model.AccessModeRead
+	}
+	if !canWrite || (repo.Owner != nil && !repo.Owner.IsOrganization()) {
+		return nil
+	}
+
+	readOnly, err := organization.IsOrgReadOnly(ctx, repo.OwnerID)
+	if err != nil || !readOnly {
+		return err
+	}
+	perm.AccessMode = min(perm.AccessMode, perm_model.AccessModeRead)
+	for t, mode := range perm.unitsMode {
+		perm.unitsMode[t] = min(mode, perm_model.AccessModeRead)
+	}
+	return nil
 }
 
 // GetUserRepoPermission returns the user permissions to the repository
@@ -300,6 +336,9 @@ func GetUserRepoPermission(ctx context.Context, repo *repo_model.Repository, use
 		if err == nil {
 			finalProcessRepoUnitPermission(user, &perm)
 		}
+		if err == nil && (user == nil || !user.IsAdmin) {
+			err = restrictReadOnlyOwner(ctx, repo, &perm)
+		}
 		log.Trace("Permission Loaded for user %-v in repo %-v, permissions: %-+v", user, repo, perm)
 	}()
 
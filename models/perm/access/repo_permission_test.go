@@ -13,6 +13,8 @@ import (
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/models/unittest"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -244,4 +246,24 @@ func TestGetUserRepoPermission(t *testing.T) {
 		require.Len(t, users, 1)
 		assert.Equal(t, user.ID, users[0].ID)
 	})
+	require.NoError(t, db.Insert(ctx, &organization.OrgBilling{OrgID: org.ID, SubscriptionID: "sub_1", SubscriptionStatus: "past_due", IsReadOnly: true}))
+	t.Run("DoerWithWriteCollaboratorOnReadOnlyOrg", func(t *testing.T) {
+		// organizations are only read-only while the payments are enabled
+		perm, err := GetUserRepoPermission(ctx, repo3, user)
+		require.NoError(t, err)
+		assert.True(t, perm.CanWrite(unit.TypeCode))
+
+		defer test.MockVariableValue(&setting.Payments.Enabled, true)()
+		perm, err = GetUserRepoPermission(ctx, repo3, user)
+		require.NoError(t, err)
+		assert.Equal(t, perm_model.AccessModeRead, perm.AccessMode)
+		assert.True(t, perm.CanRead(unit.TypeCode))
+		assert.False(t, perm.CanWrite(unit.TypeCode))
+		assert.False(t, perm.CanWrite(unit.TypeIssues))
+
+		admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+		perm, err = GetUserRepoPermission(ctx, repo3, admin)
+		require.NoError(t, err)
+		assert.True(t, perm.IsOwner())
+	})
 }
@@ -0,0 +1,188 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// MergeQueueStatus represents the state of a pull request in the merge queue
+type MergeQueueStatus int
+
+const (
+	MergeQueueStatusWaiting MergeQueueStatus = iota // waiting to be picked up by the next batch
+	MergeQueueStatusTesting                         // part of the batch currently tested on the queue branch
+)
+
+func (s MergeQueueStatus) String() string {
+	switch s {
+	case MergeQueueStatusWaiting:
+		return "waiting"
+	case MergeQueueStatusTesting:
+		return "testing"
+	}
+	return "unknown"
+}
+
+// MergeQueueBranchPrefix is the prefix of the temporary branches the merge queue tests its batches on
+const MergeQueueBranchPrefix = "gitea-mq/"
+
+// MergeQueueBranchName returns the name of the temporary branch used to test batches targeting baseBranch
+func MergeQueueBranchName(baseBranch string) string {
+	return MergeQueueBranchPrefix + baseBranch
+}
+
+// MergeQueueEntry represents a pull request waiting in the merge queue of its base branch
+type MergeQueueEntry struct {
+	ID                     int64                 `xorm:"pk autoincr"`
+	RepoID                 int64                 `xorm:"INDEX(s) NOT NULL"`
+	BaseBranch             string                `xorm:"INDEX(s) NOT NULL"`
+	PullID                 int64                 `xorm:"UNIQUE NOT NULL"`
+	DoerID                 int64                 `xorm:"INDEX NOT NULL"`
+	Doer                   *user_model.User      `xorm:"-"`
+	MergeStyle             repo_model.MergeStyle `xorm:"varchar(30)"`
+	Message                string                `xorm:"LONGTEXT"`
+	DeleteBranchAfterMerge bool
+	Status                 MergeQueueStatus `xorm:"NOT NULL DEFAULT 0"`
+	// BatchBaseCommitID is the commit of the base branch the current batch was built on
+	BatchBaseCommitID string `xorm:"VARCHAR(64)"`
+	// BatchHeadCommitID is the head of the queue branch, CI has to pass on it before the batch is merged
+	BatchHeadCommitID string `xorm:"VARCHAR(64) INDEX"`
+	// MergeCommitID is the commit on the queue branch which contains this pull request
+	MergeCommitID string             `xorm:"VARCHAR(64)"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return database table name for xorm
+func (MergeQueueEntry) TableName() string {
+	return "pull_merge_queue"
+}
+
+func init() {
+	db.RegisterModel(new(MergeQueueEntry))
+}
+
+// ErrAlreadyInMergeQueue represents an error when a pull request is already queued for merging
+var ErrAlreadyInMergeQueue = util.NewAlreadyExistErrorf("pull request is already in the merge queue")
+
+// AddToMergeQueue appends a pull request to the merge queue of its base branch
+func AddToMergeQueue(ctx context.Context, doer *user_model.User, repoID int64, baseBranch string, pullID int64, style repo_model.MergeStyle, message string, deleteBranchAfterMerge bool) (*MergeQueueEntry, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (*MergeQueueEntry, error) {
+		exist, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Exist(&MergeQueueEntry{})
+		if err != nil {
+			return nil, err
+		} else if exist {
+			return nil, ErrAlreadyInMergeQueue
+		}
+
+		entry := &MergeQueueEntry{
+			RepoID:                 repoID,
+			BaseBranch:             baseBranch,
+			PullID:                 pullID,
+			DoerID:                 doer.ID,
+			Doer:                   doer,
+			MergeStyle:             style,
+			Message:                message,
+			DeleteBranchAfterMerge: deleteBranchAfterMerge,
+			Status:                 MergeQueueStatusWaiting,
+		}
+		if _, err := db.GetEngine(ctx).Insert(entry); err != nil {
+			return nil, err
+		}
+		return entry, nil
+	})
+}
+
+// LoadDoer loads the user who added the pull request to the merge queue
+func (entry *MergeQueueEntry) LoadDoer(ctx context.Context) (err error) {
+	if entry.Doer != nil {
+		return nil
+	}
+	entry.Doer, err = user_model.GetPossibleUserByID(ctx, entry.DoerID)
+	if errors.Is(err, util.ErrNotExist) {
+		entry.Doer, err = user_model.NewGhostUser(), nil
+	}
+	return err
+}
+
+// GetMergeQueueEntryByPullID returns the merge queue entry of a pull request, if any
+func GetMergeQueueEntryByPullID(ctx context.Context, pullID int64) (*MergeQueueEntry, bool, error) {
+	entry := &MergeQueueEntry{}
+	has, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Get(entry)
+	if err != nil || !has {
+		return nil, false, err
+	}
+	return entry, true, nil
+}
+
+// GetMergeQueueEntries returns all entries queued for a base branch in queue order
+func GetMergeQueueEntries(ctx context.Context, repoID int64, baseBranch string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 10)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND base_branch = ?", repoID, baseBranch).
+		Asc("id").
+		Find(&entries)
+}
+
+// GetMergeQueueEntriesByBatchHead returns the entries of the batch whose queue branch head is the given commit
+func GetMergeQueueEntriesByBatchHead(ctx context.Context, repoID int64, commitID string) ([]*MergeQueueEntry, error) {
+	entries := make([]*MergeQueueEntry, 0, 5)
+	return entries, db.GetEngine(ctx).
+		Where("repo_id = ? AND batch_head_commit_id = ? AND status = ?", repoID, commitID, MergeQueueStatusTesting).
+		Asc("id").
+		Find(&entries)
+}
+
+// GetMergeQueuePosition returns the 1-based position of an entry in the merge queue of its base branch
+func GetMergeQueuePosition(ctx context.Context, entry *MergeQueueEntry) (int64, error) {
+	count, err := db.GetEngine(ctx).
+		Where("repo_id = ? AND base_branch = ? AND id < ?", entry.RepoID, entry.BaseBranch, entry.ID).
+		Count(&MergeQueueEntry{})
+	if err != nil {
+		return 0, err
+	}
+	return count + 1, nil
+}
+
+// UpdateMergeQueueEntryBatch persists the batch state of an entry
+func UpdateMergeQueueEntryBatch(ctx context.Context, entry *MergeQueueEntry) error {
+	_, err := db.GetEngine(ctx).ID(entry.ID).
+		Cols("status", "batch_base_commit_id", "batch_head_commit_id", "merge_commit_id").
+		Update(entry)
+	return err
+}
+
+// ResetMergeQueueBatch moves all tested entries of a base branch back to the waiting state
+func ResetMergeQueueBatch(ctx context.Context, repoID int64, baseBranch string) error {
+	_, err := db.GetEngine(ctx).
+		Where("repo_id = ? AND base_branch = ? AND status = ?", repoID, baseBranch, MergeQueueStatusTesting).
+		Cols("status", "batch_base_commit_id", "batch_head_commit_id", "merge_commit_id").
+		Update(&MergeQueueEntry{Status: MergeQueueStatusWaiting})
+	return err
+}
+
+// RemoveFromMergeQueue removes a pull request from the merge queue
+func RemoveFromMergeQueue(ctx context.Context, pullID int64) error {
+	deleted, err := db.GetEngine(ctx).Where("pull_id = ?", pullID).Delete(&MergeQueueEntry{})
+	if err != nil {
+		return err
+	} else if deleted == 0 {
+		return db.ErrNotExist{Resource: "merge_queue", ID: pullID}
+	}
+	return nil
+}
+
+// String implements fmt.Stringer for logging
+func (entry *MergeQueueEntry) String() string {
+	return fmt.Sprintf("<MergeQueueEntry %d pull:%d %d:%s %s>", entry.ID, entry.PullID, entry.RepoID, entry.BaseBranch, entry.Status)
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Category is a kind of storage which is limited by a quota group
+type Category string
+
+const (
+	CategoryGit         Category = "git"
+	CategoryLFS         Category = "lfs"
+	CategoryPackages    Category = "packages"
+	CategoryArtifacts   Category = "artifacts"
+	CategoryAttachments Category = "attachments"
+	// CategoryTotal is the storage of all categories together
+	CategoryTotal Category = "total"
+)
+
+// Categories are all categories in the order they are displayed
+var Categories = []Category{
+	CategoryGit,
+	CategoryLFS,
+	CategoryPackages,
+	CategoryArtifacts,
+	CategoryAttachments,
+	CategoryTotal,
+}
+
+// TrKey returns the locale key of the category
+func (c Category) TrKey() string {
+	return "quota.category." + string(c)
+}
+
+// Group is a set of storage limits in bytes assigned to users and organizations, a limit of -1 means unlimited
+type Group struct {
+	ID               int64              `xorm:"pk autoincr"`
+	Name             string             `xorm:"UNIQUE NOT NULL"`
+	LimitTotal       int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitGit         int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitLFS         int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitPackages    int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitArtifacts   int64              `xorm:"NOT NULL DEFAULT -1"`
+	LimitAttachments int64              `xorm:"NOT NULL DEFAULT -1"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix      timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// GroupUser assigns a user or an organization to a quota group, an owner belongs to one group at most
+type GroupUser struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"INDEX NOT NULL"`
+	UserID  int64 `xorm:"UNIQUE NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(Group))
+	db.RegisterModel(new(GroupUser))
+}
+
+// TableName sets the table name of the quota groups
+func (*Group) TableName() string {
+	return "quota_group"
+}
+
+// TableName sets the table name of the quota group assignments
+func (*GroupUser) TableName() string {
+	return "quota_group_user"
+}
+
+// Limit returns the limit of the category
+func (g *Group) Limit(category Category) int64 {
+	switch category {
+	case CategoryGit:
+		return g.LimitGit
+	case CategoryLFS:
+		return g.LimitLFS
+	case CategoryPackages:
+		return g.LimitPackages
+	case CategoryArtifacts:
+		return g.LimitArtifacts
+	case CategoryAttachments:
+		return g.LimitAttachments
+	case CategoryTotal:
+		return g.LimitTotal
+	}
+	return -1
+}
+
+// ErrQuotaExceeded represents an upload which does not fit in the quota of the owner
+type ErrQuotaExceeded struct {
+	Category Category
+	Limit    int64
+}
+
+// Error implements error
+func (err ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("the %s storage quota of %d bytes is exceeded", err.Category, err.Limit)
+}
+
+// Unwrap unwraps the error
+func (err ErrQuotaExceeded) Unwrap() error {
+	return util.ErrContentTooLarge
+}
+
+// GetGroupByID returns the quota group with the id
+func GetGroupByID(ctx context.Context, id int64) (*Group, error) {
+	g := &Group{}
+	if has, err := db.GetEngine(ctx).ID(id).Get(g); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("quota group %d does not exist", id)
+	}
+	return g, nil
+}
+
+// GetGroupByName returns the quota group with the name
+func GetGroupByName(ctx context.Context, name string) (*Group, error) {
+	g := &Group{}
+	if has, err := db.GetEngine(ctx).Where("name = ?", name).Get(g); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("quota group %s does not exist", name)
+	}
+	return g, nil
+}
+
+// GetGroups returns all quota groups ordered by name
+func GetGroups(ctx context.Context) ([]*Group, error) {
+	groups := make([]*Group, 0, 5)
+	return groups, db.GetEngine(ctx).OrderBy("name ASC").Find(&groups)
+}
+
+// CreateGroup inserts a quota group, the name has to be unique
+func CreateGroup(ctx context.Context, g *Group) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if exist, err := db.GetEngine(ctx).Exist(&Group{Name: g.Name}); err != nil {
+			return err
+		} else if exist {
+			return util.NewAlreadyExistErrorf("quota group %s already exists", g.Name)
+		}
+		return db.Insert(ctx, g)
+	})
+}
+
+// UpdateGroup updates the limits of a quota group
+func UpdateGroup(ctx context.Context, g *Group) error {
+	_, err := db.GetEngine(ctx).ID(g.ID).Cols("limit_total", "limit_git", "limit_lfs", "limit_packages", "limit_artifacts", "limit_attachments").Update(g)
+	return err
+}
+
+// DeleteGroup deletes a quota group together with its assignments
+func DeleteGroup(ctx context.Context, id int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("group_id = ?", id).Delete(&GroupUser{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(id).Delete(&Group{})
+		return err
+	})
+}
+
+// AddGroupUser assigns the user to the quota group, a previous assignment of the user is replaced
+func AddGroupUser(ctx context.Context, groupID, userID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("user_id = ?", userID).Delete(&GroupUser{}); err != nil {
+			return err
+		}
+		return db.Insert(ctx, &GroupUser{GroupID: groupID, UserID: userID})
+	})
+}
+
+// RemoveGroupUser removes the user from the quota group
+func RemoveGroupUser(ctx context.Context, groupID, userID int64) error {
+	_, err := db.GetEngine(ctx).Where("group_id = ? AND user_id = ?", groupID, userID).Delete(&GroupUser{})
+	return err
+}
+
+// GetGroupUserIDs returns the ids of the users and organizations assigned to the quota group
+func GetGroupUserIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	userIDs := make([]int64, 0, 10)
+	return userIDs, db.GetEngine(ctx).
+		Table("quota_group_user").
+		Where("group_id = ?", groupID).
+		Cols("user_id").
+		Asc("user_id").
+		Find(&userIDs)
+}
+
+// CountGroupUsers returns the number of users and organizations assigned to each quota group
+func CountGroupUsers(ctx context.Context) (map[int64]int64, error) {
+	var rows []struct {
+		GroupID int64
+		Count   int64
+	}
+	if err := db.GetEngine(ctx).
+		Table("quota_group_user").
+		Select("group_id, COUNT(*) AS count").
+		GroupBy("group_id").
+		Find(&rows); err != nil {
+		return nil, err
+	}
+	counts := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		counts[row.GroupID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetGroupForUser returns the quota group assigned to the user or the default group, nil if the user has no limits
+func GetGroupForUser(ctx context.Context, userID int64) (*Group, error) {
+	gu := &GroupUser{}
+	has, err := db.GetEngine(ctx).Where("user_id = ?", userID).Get(gu)
+	if err != nil {
+		return nil, err
+	} else if has {
+		return GetGroupByID(ctx, gu.GroupID)
+	}
+
+	if setting.Quota.DefaultGroup == "" {
+		return nil, nil
+	}
+	g, err := GetGroupByName(ctx, setting.Quota.DefaultGroup)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return g, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"testing"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupForUser(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Quota.DefaultGroup, "default")()
+
+	g, err := GetGroupForUser(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Nil(t, g)
+
+	def := &Group{Name: "default", LimitTotal: 100, LimitGit: -1, LimitLFS: -1, LimitPackages: -1, LimitArtifacts: -1, LimitAttachments: -1}
+	require.NoError(t, CreateGroup(t.Context(), def))
+	assert.ErrorIs(t, CreateGroup(t.Context(), &Group{Name: "default"}), util.ErrAlreadyExist)
+
+	g, err = GetGroupForUser(t.Context(), 2)
+	require.NoError(t, err)
+	require.NotNil(t, g)
+	assert.Equal(t, def.ID, g.ID)
+
+	large := &Group{Name: "large", LimitTotal: -1, LimitGit: -1, LimitLFS: 1000, LimitPackages: -1, LimitArtifacts: -1, LimitAttachments: -1}
+	require.NoError(t, CreateGroup(t.Context(), large))
+	require.NoError(t, AddGroupUser(t.Context(), def.ID, 2))
+	require.NoError(t, AddGroupUser(t.Context(), large.ID, 2))
+
+	g, err = GetGroupForUser(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, large.ID, g.ID)
+
+	counts, err := CountGroupUsers(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{large.ID: 1}, counts)
+
+	require.NoError(t, DeleteGroup(t.Context(), large.ID))
+	g, err = GetGroupForUser(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, def.ID, g.ID)
+}
+
+func TestCheckQuota(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Quota.Enabled, true)()
+
+	u, err := GetUsage(t.Context(), 2)
+	require.NoError(t, err)
+
+	// without a group there are no limits
+	require.NoError(t, CheckQuota(t.Context(), 2, CategoryLFS, 1<<40))
+
+	g := &Group{
+		Name:             "limited",
+		LimitTotal:       u.Size(CategoryTotal) + 100,
+		LimitGit:         -1,
+		LimitLFS:         u.LFS + 10,
+		LimitPackages:    -1,
+		LimitArtifacts:   -1,
+		LimitAttachments: -1,
+	}
+	require.NoError(t, CreateGroup(t.Context(), g))
+	require.NoError(t, AddGroupUser(t.Context(), g.ID, 2))
+
+	assert.NoError(t, CheckQuota(t.Context(), 2, CategoryLFS, 10))
+	assert.NoError(t, CheckQuota(t.Context(), 2, CategoryPackages, 100))
+
+	err = CheckQuota(t.Context(), 2, CategoryLFS, 11)
+	assert.ErrorIs(t, err, util.ErrContentTooLarge)
+	assert.Equal(t, ErrQuotaExceeded{Category: CategoryLFS, Limit: g.LimitLFS}, err)
+
+	err = CheckQuota(t.Context(), 2, CategoryPackages, 101)
+	assert.Equal(t, ErrQuotaExceeded{Category: CategoryTotal, Limit: g.LimitTotal}, err)
+
+	// uploaded lfs objects count before the size of the repository is updated
+	_, err = git_model.NewLFSMetaObject(t.Context(), 1, lfs.Pointer{Oid: "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", Size: 6})
+	require.NoError(t, err)
+	assert.NoError(t, CheckQuota(t.Context(), 2, CategoryLFS, 4))
+	assert.Equal(t, ErrQuotaExceeded{Category: CategoryLFS, Limit: g.LimitLFS}, CheckQuota(t.Context(), 2, CategoryLFS, 5))
+
+	// other owners are not affected
+	assert.NoError(t, CheckQuota(t.Context(), 3, CategoryLFS, 1<<40))
+
+	defer test.MockVariableValue(&setting.Quota.Enabled, false)()
+	assert.NoError(t, CheckQuota(t.Context(), 2, CategoryLFS, 11))
+}
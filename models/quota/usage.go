@@ -0,0 +1,111 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package quota
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// Usage is the storage in bytes used by a user or an organization
+type Usage struct {
+	Git         int64
+	LFS         int64
+	Packages    int64
+	Artifacts   int64
+	Attachments int64
+}
+
+// Size returns the used storage of the category
+func (u *Usage) Size(category Category) int64 {
+	switch category {
+	case CategoryGit:
+		return u.Git
+	case CategoryLFS:
+		return u.LFS
+	case CategoryPackages:
+		return u.Packages
+	case CategoryArtifacts:
+		return u.Artifacts
+	case CategoryAttachments:
+		return u.Attachments
+	case CategoryTotal:
+		return u.Git + u.LFS + u.Packages + u.Artifacts + u.Attachments
+	}
+	return 0
+}
+
+// GetUsage calculates the storage used by the repositories, packages and artifacts of the owner
+func GetUsage(ctx context.Context, ownerID int64) (*Usage, error) {
+	u := &Usage{}
+
+	var err error
+	if u.Git, err = db.GetEngine(ctx).
+		Where("owner_id = ?", ownerID).
+		SumInt(new(repo_model.Repository), "git_size"); err != nil {
+		return nil, err
+	}
+
+	// the lfs size of a repository is only updated after a push, the uploaded objects are counted right away
+	if u.LFS, err = db.GetEngine(ctx).
+		Join("INNER", "repository", "repository.id = lfs_meta_object.repository_id").
+		Where("repository.owner_id = ?", ownerID).
+		SumInt(new(git_model.LFSMetaObject), "lfs_meta_object.size"); err != nil {
+		return nil, err
+	}
+
+	if u.Packages, err = packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{OwnerID: ownerID}); err != nil {
+		return nil, err
+	}
+
+	// expired artifacts are deleted by a cron task and do not count anymore
+	if u.Artifacts, err = db.GetEngine(ctx).
+		Where("owner_id = ?", ownerID).
+		In("status", actions_model.ArtifactStatusUploadPending, actions_model.ArtifactStatusUploadConfirmed).
+		SumInt(new(actions_model.ActionArtifact), "file_compressed_size"); err != nil {
+		return nil, err
+	}
+
+	if u.Attachments, err = db.GetEngine(ctx).
+		Join("INNER", "repository", "repository.id = attachment.repo_id").
+		Where("repository.owner_id = ?", ownerID).
+		SumInt(new(repo_model.Attachment), "attachment.size"); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// CheckQuota returns an ErrQuotaExceeded error if storing additional bytes of the category exceeds a limit of the owner's quota group.
+// Pushes don't know their size in advance, they are rejected once the limit is exceeded.
+func CheckQuota(ctx context.Context, ownerID int64, category Category, size int64) error {
+	if !setting.Quota.Enabled {
+		return nil
+	}
+
+	g, err := GetGroupForUser(ctx, ownerID)
+	if err != nil || g == nil {
+		return err
+	}
+	if g.Limit(category) < 0 && g.LimitTotal < 0 {
+		return nil
+	}
+
+	u, err := GetUsage(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	for _, c := range []Category{category, CategoryTotal} {
+		if limit := g.Limit(c); limit >= 0 && u.Size(c)+size > limit {
+			return ErrQuotaExceeded{Category: c, Limit: limit}
+		}
+	}
+	return nil
+}
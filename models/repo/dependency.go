@@ -0,0 +1,153 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/dependency"
+
+	"xorm.io/builder"
+)
+
+// RepoDependency is a package the default branch of a repository depends on
+type RepoDependency struct { //revive:disable-line:exported
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"INDEX NOT NULL"`
+	CommitID  string `xorm:"VARCHAR(64)"`
+	Manifest  string `xorm:"TEXT NOT NULL"`
+	Ecosystem string `xorm:"INDEX(s) NOT NULL"`
+	Name      string `xorm:"NOT NULL"`
+	LowerName string `xorm:"INDEX(s) NOT NULL"`
+	Version   string
+	IsDirect  bool `xorm:"NOT NULL DEFAULT false"`
+
+	Repo *Repository `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(RepoDependency))
+}
+
+// PackageURL returns the package URL (purl) of the dependency
+func (d *RepoDependency) PackageURL() string {
+	return (&dependency.Dependency{Ecosystem: d.Ecosystem, Name: d.Name, Version: d.Version}).PackageURL()
+}
+
+// FindDependenciesOptions are the options to find dependencies
+type FindDependenciesOptions struct {
+	db.ListOptions
+	RepoID int64
+	// RepoCond limits the repositories, e.g. to the ones a user has access to
+	RepoCond  builder.Cond
+	Ecosystem string
+	// Name is matched exactly and normalized like the names of the ecosystem
+	Name    string
+	Version string
+	Keyword string
+}
+
+func (opts FindDependenciesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID != 0 {
+		cond = cond.And(builder.Eq{"repo_dependency.repo_id": opts.RepoID})
+	}
+	if opts.RepoCond != nil {
+		cond = cond.And(builder.In("repo_dependency.repo_id", builder.Select("id").From("repository").Where(opts.RepoCond)))
+	}
+	if opts.Ecosystem != "" {
+		cond = cond.And(builder.Eq{"repo_dependency.ecosystem": opts.Ecosystem})
+	}
+	if opts.Name != "" {
+		d := &dependency.Dependency{Ecosystem: opts.Ecosystem, Name: opts.Name}
+		// without ecosystem the name may be stored as it is, e.g. Go module paths are case-sensitive
+		cond = cond.And(builder.In("repo_dependency.lower_name", d.LowerName(), opts.Name))
+	}
+	if opts.Version != "" {
+		cond = cond.And(builder.Eq{"repo_dependency.version": opts.Version})
+	}
+	if opts.Keyword != "" {
+		cond = cond.And(builder.Like{"repo_dependency.lower_name", strings.ToLower(opts.Keyword)})
+	}
+	return cond
+}
+
+func (opts FindDependenciesOptions) ToOrders() string {
+	return "repo_dependency.ecosystem, repo_dependency.lower_name, repo_dependency.version, repo_dependency.repo_id"
+}
+
+// GetDependencyEcosystems returns the ecosystems of the dependencies of a repository
+func GetDependencyEcosystems(ctx context.Context, repoID int64) ([]string, error) {
+	ecosystems := make([]string, 0, 5)
+	return ecosystems, db.GetEngine(ctx).
+		Table("repo_dependency").
+		Where("repo_id = ?", repoID).
+		Distinct("ecosystem").
+		Asc("ecosystem").
+		Find(&ecosystems)
+}
+
+// GetRepoIDsWithDependencies returns the ids of the repositories which have dependencies
+func GetRepoIDsWithDependencies(ctx context.Context) ([]int64, error) {
+	repoIDs := make([]int64, 0, 10)
+	return repoIDs, db.GetEngine(ctx).
+		Table("repo_dependency").
+		Distinct("repo_id").
+		Asc("repo_id").
+		Find(&repoIDs)
+}
+
+// UpdateRepoDependencies replaces the dependencies of a repository with the ones of the commit
+func UpdateRepoDependencies(ctx context.Context, repo *Repository, commitID string, deps []*dependency.Dependency) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("repo_id = ?", repo.ID).Delete(&RepoDependency{}); err != nil {
+			return err
+		}
+
+		beans := make([]*RepoDependency, 0, len(deps))
+		for _, d := range deps {
+			beans = append(beans, &RepoDependency{
+				RepoID:    repo.ID,
+				CommitID:  commitID,
+				Manifest:  d.Manifest,
+				Ecosystem: d.Ecosystem,
+				Name:      d.Name,
+				LowerName: d.LowerName(),
+				Version:   d.Version,
+				IsDirect:  d.IsDirect,
+			})
+		}
+		// insert in chunks, some databases limit the number of parameters of a statement
+		for len(beans) > 0 {
+			n := min(len(beans), 100)
+			if _, err := db.GetEngine(ctx).Insert(beans[:n]); err != nil {
+				return err
+			}
+			beans = beans[n:]
+		}
+
+		return UpdateIndexerStatus(ctx, repo, RepoIndexerTypeDependencies, commitID)
+	})
+}
+
+// RepoDependencyList is a list of dependencies
+type RepoDependencyList []*RepoDependency //revive:disable-line:exported
+
+// LoadRepositories loads the repositories of the dependencies
+func (list RepoDependencyList) LoadRepositories(ctx context.Context) error {
+	repoIDs := make([]int64, 0, len(list))
+	for _, d := range list {
+		repoIDs = append(repoIDs, d.RepoID)
+	}
+	repos := make(map[int64]*Repository, len(repoIDs))
+	if err := db.GetEngine(ctx).In("id", repoIDs).Find(&repos); err != nil {
+		return err
+	}
+	for _, d := range list {
+		d.Repo = repos[d.RepoID]
+	}
+	return nil
+}
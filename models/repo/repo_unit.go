@@ -173,6 +173,8 @@ type ActionsConfig struct {
 	// CollaborativeOwnerIDs is a list of owner IDs used to share actions from private repos.
 	// Only workflows from the private repos whose owners are in CollaborativeOwnerIDs can access the current repo's actions.
 	CollaborativeOwnerIDs []int64
+	// MaxTokenPermissions limits the access of the GITEA_TOKEN of the jobs by permission scope, nil means unlimited.
+	MaxTokenPermissions map[string]perm.AccessMode `json:",omitempty"`
 }
 
 func (cfg *ActionsConfig) EnableWorkflow(file string) {
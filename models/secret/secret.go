@@ -17,6 +17,9 @@ import (
 	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 
+	"github.com/nektos/act/pkg/exprparser"
+	"github.com/nektos/act/pkg/jobparser"
+	"gopkg.in/yaml.v3"
 	"xorm.io/builder"
 )
 
@@ -25,6 +28,7 @@ import (
 // It can be:
 //  1. org/user level secret, OwnerID is org/user ID and RepoID is 0
 //  2. repo level secret, OwnerID is 0 and RepoID is repo ID
+//  3. environment level secret, OwnerID is 0, RepoID is repo ID and EnvironmentID is the ID of one of its environments
 //
 // Please note that it's not acceptable to have both OwnerID and RepoID to be non-zero,
 // or it will be complicated to find secrets belonging to a specific owner.
@@ -35,13 +39,14 @@ import (
 // Please note that it's not acceptable to have both OwnerID and RepoID to zero, global secrets are not supported.
 // It's for security reasons, admin may be not aware of that the secrets could be stolen by any user when setting them as global.
 type Secret struct {
-	ID          int64
-	OwnerID     int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
-	RepoID      int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
-	Name        string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
-	Data        string             `xorm:"LONGTEXT"` // encrypted data
-	Description string             `xorm:"TEXT"`
-	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	ID            int64
+	OwnerID       int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+	RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	EnvironmentID int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+	Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+	Data          string             `xorm:"LONGTEXT"` // encrypted data
+	Description   string             `xorm:"TEXT"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
 }
 
 const (
@@ -73,24 +78,41 @@ func InsertEncryptedSecret(ctx context.Context, ownerID, repoID int64, name, dat
 		return nil, fmt.Errorf("%w: ownerID and repoID cannot be both zero, global secrets are not supported", util.ErrInvalidArgument)
 	}
 
+	return insertEncryptedSecret(ctx, &Secret{
+		OwnerID:     ownerID,
+		RepoID:      repoID,
+		Name:        strings.ToUpper(name),
+		Description: description,
+	}, data)
+}
+
+// InsertEncryptedEnvironmentSecret creates, encrypts, and validates a new secret of a deployment environment of a repository
+func InsertEncryptedEnvironmentSecret(ctx context.Context, repoID, environmentID int64, name, data, description string) (*Secret, error) {
+	if repoID == 0 || environmentID == 0 {
+		return nil, fmt.Errorf("%w: repoID and environmentID are required for environment secrets", util.ErrInvalidArgument)
+	}
+
+	return insertEncryptedSecret(ctx, &Secret{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          strings.ToUpper(name),
+		Description:   description,
+	}, data)
+}
+
+func insertEncryptedSecret(ctx context.Context, secret *Secret, data string) (*Secret, error) {
 	if len(data) > SecretDataMaxLength {
 		return nil, util.NewInvalidArgumentErrorf("data too long")
 	}
 
-	description = util.TruncateRunes(description, SecretDescriptionMaxLength)
+	secret.Description = util.TruncateRunes(secret.Description, SecretDescriptionMaxLength)
 
 	encrypted, err := secret_module.EncryptSecret(setting.SecretKey, data)
 	if err != nil {
 		return nil, err
 	}
+	secret.Data = encrypted
 
-	secret := &Secret{
-		OwnerID:     ownerID,
-		RepoID:      repoID,
-		Name:        strings.ToUpper(name),
-		Data:        encrypted,
-		Description: description,
-	}
 	return secret, db.Insert(ctx, secret)
 }
 
@@ -100,10 +122,11 @@ func init() {
 
 type FindSecretsOptions struct {
 	db.ListOptions
-	RepoID   int64
-	OwnerID  int64 // it will be ignored if RepoID is set
-	SecretID int64
-	Name     string
+	RepoID        int64
+	OwnerID       int64 // it will be ignored if RepoID is set
+	EnvironmentID int64 // the secrets of an environment are only found if it is set
+	SecretID      int64
+	Name          string
 }
 
 func (opts FindSecretsOptions) ToConds() builder.Cond {
@@ -116,6 +139,7 @@ func (opts FindSecretsOptions) ToConds() builder.Cond {
 	} else {
 		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
 	}
+	cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
 
 	if opts.SecretID != 0 {
 		cond = cond.And(builder.Eq{"id": opts.SecretID})
@@ -175,7 +199,35 @@ func GetSecretsOfTask(ctx context.Context, task *actions_model.ActionTask) (map[
 		return nil, err
 	}
 
-	for _, secret := range append(ownerSecrets, repoSecrets...) {
+	// Level precedence: Environment > Repo > Org / User
+	decryptSecrets(secrets, append(ownerSecrets, repoSecrets...))
+	if task.Job.CallerJobID != 0 {
+		if secrets, err = getSecretsOfCalledJob(ctx, task.Job, secrets); err != nil {
+			return nil, err
+		}
+	}
+
+	var environmentSecrets []*Secret
+	// the deployment of the job has passed the protection rules of its environment before it could be picked
+	environmentID, err := actions_model.GetDeploymentEnvironmentIDOfJob(ctx, task.Job)
+	if err != nil {
+		return nil, err
+	}
+	if environmentID != 0 {
+		environmentSecrets, err = db.Find[Secret](ctx, FindSecretsOptions{RepoID: task.Job.RepoID, EnvironmentID: environmentID})
+		if err != nil {
+			log.Error("find secrets of environment %v: %v", environmentID, err)
+			return nil, err
+		}
+	}
+
+	decryptSecrets(secrets, environmentSecrets)
+
+	return secrets, nil
+}
+
+func decryptSecrets(secrets map[string]string, list []*Secret) {
+	for _, secret := range list {
 		v, err := secret_module.DecryptSecret(setting.SecretKey, secret.Data)
 		if err != nil {
 			log.Error("Unable to decrypt Actions secret %v %q, maybe SECRET_KEY is wrong: %v", secret.ID, secret.Name, err)
@@ -183,7 +235,48 @@ func GetSecretsOfTask(ctx context.Context, task *actions_model.ActionTask) (map[
 		}
 		secrets[secret.Name] = v
 	}
+}
+
+// getSecretsOfCalledJob returns the secrets passed to a job of a reusable workflow by the "secrets" of its callers:
+// "inherit" passes all secrets of the caller, a mapping passes the mapped values, otherwise only the tokens are passed.
+func getSecretsOfCalledJob(ctx context.Context, job *actions_model.ActionRunJob, secrets map[string]string) (map[string]string, error) {
+	var callers []*actions_model.ActionRunJob
+	for id := job.CallerJobID; id != 0; {
+		caller, err := actions_model.GetRunJobByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		callers = append(callers, caller)
+		id = caller.CallerJobID
+	}
 
+	// the secrets are passed down from the top level caller
+	for i := len(callers) - 1; i >= 0; i-- {
+		workflowJob, err := callers[i].ParseJob()
+		if err != nil {
+			return nil, err
+		}
+		passed := map[string]string{
+			"GITHUB_TOKEN": secrets["GITHUB_TOKEN"],
+			"GITEA_TOKEN":  secrets["GITEA_TOKEN"],
+		}
+		switch workflowJob.RawSecrets.Kind {
+		case yaml.ScalarNode:
+			if workflowJob.RawSecrets.Value == "inherit" {
+				passed = secrets
+			}
+		case yaml.MappingNode:
+			var mapping map[string]string
+			if err := workflowJob.RawSecrets.Decode(&mapping); err != nil {
+				return nil, fmt.Errorf("decode secrets of job %d: %w", callers[i].ID, err)
+			}
+			evaluator := jobparser.NewExpressionEvaluator(exprparser.NewInterpeter(&exprparser.EvaluationEnvironment{Secrets: secrets}, exprparser.Config{}))
+			for name, value := range mapping {
+				passed[name] = evaluator.Interpolate(value)
+			}
+		}
+		secrets = passed
+	}
 	return secrets, nil
 }
 
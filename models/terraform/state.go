@@ -0,0 +1,137 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrStateNotExist = util.NewNotExistErrorf("terraform state does not exist")
+
+func init() {
+	db.RegisterModel(new(State))
+}
+
+// State is a named Terraform state of a repository.
+// The content of the state is stored in its versions.
+type State struct {
+	ID        int64  `xorm:"pk autoincr"`
+	RepoID    int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Name      string `xorm:"NOT NULL"`
+	LowerName string `xorm:"UNIQUE(s) NOT NULL"`
+	Serial    int64  `xorm:"NOT NULL DEFAULT 0"`
+	Lineage   string
+
+	LockID     string
+	LockInfo   string `xorm:"TEXT"`
+	LockerID   int64
+	LockedUnix timeutil.TimeStamp
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated INDEX NOT NULL"`
+}
+
+// TableName sets the table name of the state
+func (*State) TableName() string {
+	return "terraform_state"
+}
+
+// LockInfo is the lock information sent by Terraform
+// https://github.com/hashicorp/terraform/blob/main/internal/states/statemgr/locker.go
+type LockInfo struct {
+	ID        string `json:"ID"`
+	Operation string `json:"Operation"`
+	Info      string `json:"Info"`
+	Who       string `json:"Who"`
+	Version   string `json:"Version"`
+	Created   string `json:"Created"`
+	Path      string `json:"Path"`
+}
+
+// IsLocked tests if the state is locked by a Terraform operation
+func (s *State) IsLocked() bool {
+	return s.LockID != ""
+}
+
+// GetLockInfo parses the lock information of the state
+func (s *State) GetLockInfo() *LockInfo {
+	if !s.IsLocked() {
+		return nil
+	}
+	info := &LockInfo{}
+	if err := json.Unmarshal([]byte(s.LockInfo), info); err != nil {
+		return &LockInfo{ID: s.LockID}
+	}
+	return info
+}
+
+// HasVersions tests if content was stored for the state
+func (s *State) HasVersions() bool {
+	return s.Serial > 0 || s.Lineage != ""
+}
+
+// GetStateByName gets the state of the repository with the name
+func GetStateByName(ctx context.Context, repoID int64, name string) (*State, error) {
+	s := &State{}
+	has, err := db.GetEngine(ctx).Where("repo_id=? AND lower_name=?", repoID, strings.ToLower(name)).Get(s)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateNotExist
+	}
+	return s, nil
+}
+
+// GetOrInsertState gets the state of the repository with the name or inserts an empty one
+func GetOrInsertState(ctx context.Context, repoID int64, name string) (*State, error) {
+	s, err := GetStateByName(ctx, repoID, name)
+	if err == nil {
+		return s, nil
+	}
+	if err != ErrStateNotExist {
+		return nil, err
+	}
+
+	s = &State{
+		RepoID:    repoID,
+		Name:      name,
+		LowerName: strings.ToLower(name),
+	}
+	if err := db.Insert(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetStatesByRepoID gets all states of the repository sorted by name
+func GetStatesByRepoID(ctx context.Context, repoID int64) ([]*State, error) {
+	states := make([]*State, 0, 5)
+	return states, db.GetEngine(ctx).Where("repo_id=?", repoID).OrderBy("lower_name ASC").Find(&states)
+}
+
+// UpdateStateCols updates the columns of the state
+func UpdateStateCols(ctx context.Context, s *State, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols(cols...).Update(s)
+	return err
+}
+
+// DeleteStateByID deletes the state and all its versions
+func DeleteStateByID(ctx context.Context, stateID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"state_id": stateID}).Delete(&StateVersion{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(stateID).Delete(&State{})
+		return err
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	s, err := GetOrInsertState(t.Context(), 1, "Production")
+	require.NoError(t, err)
+	assert.Equal(t, "production", s.LowerName)
+	assert.False(t, s.IsLocked())
+	assert.False(t, s.HasVersions())
+
+	s2, err := GetOrInsertState(t.Context(), 1, "production")
+	require.NoError(t, err)
+	assert.Equal(t, s.ID, s2.ID)
+
+	_, err = GetStateByName(t.Context(), 2, "production")
+	assert.ErrorIs(t, err, ErrStateNotExist)
+
+	s.LockID = "lock"
+	s.LockInfo = `{"ID":"lock","Operation":"OperationTypeApply","Who":"user@host"}`
+	require.NoError(t, UpdateStateCols(t.Context(), s, "lock_id", "lock_info"))
+
+	s, err = GetStateByName(t.Context(), 1, "PRODUCTION")
+	require.NoError(t, err)
+	assert.True(t, s.IsLocked())
+	info := s.GetLockInfo()
+	assert.Equal(t, "OperationTypeApply", info.Operation)
+	assert.Equal(t, "user@host", info.Who)
+
+	states, err := GetStatesByRepoID(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Len(t, states, 1)
+}
+
+func TestStateVersions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	s, err := GetOrInsertState(t.Context(), 1, "default")
+	require.NoError(t, err)
+
+	_, err = GetLatestStateVersion(t.Context(), s.ID)
+	assert.ErrorIs(t, err, ErrStateVersionNotExist)
+
+	for serial := int64(1); serial <= 3; serial++ {
+		require.NoError(t, InsertStateVersion(t.Context(), &StateVersion{RepoID: 1, StateID: s.ID, Serial: serial, Lineage: "lineage"}))
+	}
+
+	latest, err := GetLatestStateVersion(t.Context(), s.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, latest.Serial)
+
+	prev, err := GetPreviousStateVersion(t.Context(), latest)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, prev.Serial)
+
+	versions, total, err := FindStateVersions(t.Context(), s.ID, db.ListOptions{Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, total)
+	assert.Len(t, versions, 2)
+	assert.EqualValues(t, 3, versions[0].Serial)
+
+	expired, err := GetExpiredStateVersions(t.Context(), s.ID, 2)
+	require.NoError(t, err)
+	assert.Len(t, expired, 1)
+	assert.EqualValues(t, 1, expired[0].Serial)
+
+	require.NoError(t, DeleteStateByID(t.Context(), s.ID))
+	versions, err = GetStateVersionsByRepoID(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+var ErrStateVersionNotExist = util.NewNotExistErrorf("terraform state version does not exist")
+
+func init() {
+	db.RegisterModel(new(StateVersion))
+}
+
+// StateVersion is a stored content of a state.
+// Every write of Terraform creates a new version, the content is kept in the storage.
+type StateVersion struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RepoID      int64              `xorm:"INDEX NOT NULL"`
+	StateID     int64              `xorm:"INDEX NOT NULL"`
+	Serial      int64              `xorm:"NOT NULL DEFAULT 0"`
+	Lineage     string             `xorm:"NOT NULL DEFAULT ''"`
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+	HashSHA256  string             `xorm:"hash_sha256 char(64)"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	Creator     *user_model.User   `xorm:"-"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// TableName sets the table name of the state version
+func (*StateVersion) TableName() string {
+	return "terraform_state_version"
+}
+
+// RelativePath returns the path of the content in the storage
+func (v *StateVersion) RelativePath() string {
+	return fmt.Sprintf("%d/%d/%d.tfstate", v.RepoID, v.StateID, v.ID)
+}
+
+// LoadCreator loads the user who stored the version
+func (v *StateVersion) LoadCreator(ctx context.Context) (err error) {
+	if v.Creator != nil {
+		return nil
+	}
+	v.Creator, err = user_model.GetPossibleUserByID(ctx, v.CreatorID)
+	if user_model.IsErrUserNotExist(err) {
+		v.Creator = user_model.NewGhostUser()
+		err = nil
+	}
+	return err
+}
+
+// InsertStateVersion inserts a new version
+func InsertStateVersion(ctx context.Context, v *StateVersion) error {
+	return db.Insert(ctx, v)
+}
+
+// GetStateVersionByID gets a version of the state
+func GetStateVersionByID(ctx context.Context, stateID, versionID int64) (*StateVersion, error) {
+	v := &StateVersion{}
+	has, err := db.GetEngine(ctx).Where("id=? AND state_id=?", versionID, stateID).Get(v)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateVersionNotExist
+	}
+	return v, nil
+}
+
+// GetLatestStateVersion gets the current version of the state
+func GetLatestStateVersion(ctx context.Context, stateID int64) (*StateVersion, error) {
+	v := &StateVersion{}
+	has, err := db.GetEngine(ctx).Where("state_id=?", stateID).Desc("id").Get(v)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateVersionNotExist
+	}
+	return v, nil
+}
+
+// GetPreviousStateVersion gets the version stored before the version
+func GetPreviousStateVersion(ctx context.Context, v *StateVersion) (*StateVersion, error) {
+	prev := &StateVersion{}
+	has, err := db.GetEngine(ctx).Where("state_id=? AND id<?", v.StateID, v.ID).Desc("id").Get(prev)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrStateVersionNotExist
+	}
+	return prev, nil
+}
+
+// FindStateVersions gets the versions of the state, newest first
+func FindStateVersions(ctx context.Context, stateID int64, opts db.ListOptions) ([]*StateVersion, int64, error) {
+	sess := db.GetEngine(ctx).Where("state_id=?", stateID).Desc("id")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	versions := make([]*StateVersion, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&versions)
+	return versions, count, err
+}
+
+// GetStateVersionsByStateID gets all versions of the state
+func GetStateVersionsByStateID(ctx context.Context, stateID int64) ([]*StateVersion, error) {
+	versions := make([]*StateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).Where("state_id=?", stateID).Find(&versions)
+}
+
+// GetStateVersionsByRepoID gets all versions of all states of the repository
+func GetStateVersionsByRepoID(ctx context.Context, repoID int64) ([]*StateVersion, error) {
+	versions := make([]*StateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).Where("repo_id=?", repoID).Find(&versions)
+}
+
+// GetExpiredStateVersions gets the versions of the state beyond the newest keep versions
+func GetExpiredStateVersions(ctx context.Context, stateID int64, keep int) ([]*StateVersion, error) {
+	versions := make([]*StateVersion, 0, 10)
+	return versions, db.GetEngine(ctx).
+		Where("state_id=?", stateID).
+		Desc("id").
+		Limit(-1, keep).
+		Find(&versions)
+}
+
+// DeleteStateVersionsByIDs deletes the versions
+func DeleteStateVersionsByIDs(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).In("id", ids).Delete(&StateVersion{})
+	return err
+}
+
+// DeleteStatesByRepoID deletes all states and versions of the repository
+func DeleteStatesByRepoID(ctx context.Context, repoID int64) error {
+	if _, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID}).Delete(&StateVersion{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID}).Delete(&State{})
+	return err
+}
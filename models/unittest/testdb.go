@@ -125,6 +125,8 @@ func MainTest(m *testing.M, testOptsArg ...*TestOptions) {
 
 	setting.Actions.LogStorage.Path = filepath.Join(setting.AppDataPath, "actions_log")
 
+	setting.Terraform.Storage.Path = filepath.Join(setting.AppDataPath, "terraform")
+
 	setting.Git.HomePath = filepath.Join(setting.AppDataPath, "home")
 
 	setting.IncomingEmail.ReplyToAddress = "incoming+%{token}@localhost"
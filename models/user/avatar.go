@@ -51,7 +51,8 @@ func GenerateRandomAvatar(ctx context.Context, u *User) error {
 		}
 	}
 
-	if _, err := db.GetEngine(ctx).ID(u.ID).Cols("avatar").Update(u); err != nil {
+	// update a new bean, the hooks of the user would change other fields of the caller's user (e.g. lowercase the email)
+	if _, err := db.GetEngine(ctx).ID(u.ID).Cols("avatar").Update(&User{Avatar: u.Avatar}); err != nil {
 		return err
 	}
 
@@ -26,4 +26,7 @@ const (
 	SettingEmailNotificationGiteaActionsAll         = "all"
 	SettingEmailNotificationGiteaActionsFailureOnly = "failure-only" // Default for actions email preference
 	SettingEmailNotificationGiteaActionsDisabled    = "disabled"
+
+	// SettingsKeyActionsMaxTokenPermissions is the setting key for the maximum permissions of the GITEA_TOKEN of the jobs of an owner's repositories
+	SettingsKeyActionsMaxTokenPermissions = "actions.max_token_permissions"
 )
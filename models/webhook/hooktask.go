@@ -56,6 +56,11 @@ type HookTask struct {
 	IsDelivered bool
 	Delivered   timeutil.TimeStampNano
 
+	// Retry info. Every attempt to deliver an event is stored as its own task.
+	Attempt       int                `xorm:"NOT NULL DEFAULT 1"`
+	ScheduledUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`           // the task must not be delivered before this time
+	IsExhausted   bool               `xorm:"INDEX NOT NULL DEFAULT false"` // the delivery failed and is not retried anymore
+
 	// History info.
 	IsSucceed       bool
 	RequestContent  string        `xorm:"LONGTEXT"`
@@ -116,6 +121,16 @@ func HookTasks(ctx context.Context, hookID int64, page int) ([]*HookTask, error)
 		Find(&tasks)
 }
 
+// ExhaustedHookTasks returns a list of hook tasks which failed after all delivery attempts.
+func ExhaustedHookTasks(ctx context.Context, hookID int64, page int) ([]*HookTask, error) {
+	tasks := make([]*HookTask, 0, setting.Webhook.PagingNum)
+	return tasks, db.GetEngine(ctx).
+		Limit(setting.Webhook.PagingNum, (page-1)*setting.Webhook.PagingNum).
+		Where("hook_id=? AND is_exhausted=?", hookID, true).
+		Desc("id").
+		Find(&tasks)
+}
+
 // CreateHookTask creates a new hook task,
 // it handles conversion from Payload to PayloadContent.
 func CreateHookTask(ctx context.Context, t *HookTask) (*HookTask, error) {
@@ -123,6 +138,9 @@ func CreateHookTask(ctx context.Context, t *HookTask) (*HookTask, error) {
 	if t.Delivered == 0 {
 		t.Delivered = timeutil.TimeStampNanoNow()
 	}
+	if t.Attempt == 0 {
+		t.Attempt = 1
+	}
 	if t.PayloadVersion == 0 {
 		return nil, errors.New("missing HookTask.PayloadVersion")
 	}
@@ -150,6 +168,18 @@ func UpdateHookTask(ctx context.Context, t *HookTask) error {
 	return err
 }
 
+// CreateRetryHookTask copies a failed hook task to get delivered again at the scheduled time
+func CreateRetryHookTask(ctx context.Context, t *HookTask, scheduled timeutil.TimeStamp) (*HookTask, error) {
+	return CreateHookTask(ctx, &HookTask{
+		HookID:         t.HookID,
+		PayloadContent: t.PayloadContent,
+		EventType:      t.EventType,
+		PayloadVersion: t.PayloadVersion,
+		Attempt:        t.Attempt + 1,
+		ScheduledUnix:  scheduled,
+	})
+}
+
 // ReplayHookTask copies a hook task to get re-delivered
 func ReplayHookTask(ctx context.Context, hookID int64, uuid string) (*HookTask, error) {
 	task, exist, err := db.Get[HookTask](ctx, builder.Eq{"hook_id": hookID, "uuid": uuid})
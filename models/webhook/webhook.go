@@ -136,6 +136,11 @@ type Webhook struct {
 	Meta                      string                    `xorm:"TEXT"` // store hook-specific attributes
 	LastStatus                webhook_module.HookStatus // Last delivery status
 
+	// MaxAttempts is the number of delivery attempts before a task is given up, 0 uses the default of the instance
+	MaxAttempts int `xorm:"NOT NULL DEFAULT 0"`
+	// ConsecutiveFailures counts the deliveries which failed after all attempts since the last successful delivery
+	ConsecutiveFailures int `xorm:"NOT NULL DEFAULT 0"`
+
 	// HeaderAuthorizationEncrypted should be accessed using HeaderAuthorization() and SetHeaderAuthorization()
 	HeaderAuthorizationEncrypted string `xorm:"TEXT"`
 
@@ -160,6 +165,19 @@ func (w *Webhook) History(ctx context.Context, page int) ([]*HookTask, error) {
 	return HookTasks(ctx, w.ID, page)
 }
 
+// FailedDeliveries returns the tasks of the webhook which failed after all delivery attempts.
+func (w *Webhook) FailedDeliveries(ctx context.Context, page int) ([]*HookTask, error) {
+	return ExhaustedHookTasks(ctx, w.ID, page)
+}
+
+// DeliveryAttempts returns the number of attempts to deliver a task to the webhook
+func (w *Webhook) DeliveryAttempts() int {
+	if w.MaxAttempts > 0 {
+		return w.MaxAttempts
+	}
+	return max(setting.Webhook.MaxAttempts, 1)
+}
+
 // UpdateEvent handles conversion from HookEvent to Events.
 func (w *Webhook) UpdateEvent() error {
 	data, err := json.Marshal(w.HookEvent)
@@ -316,6 +334,34 @@ func UpdateWebhookLastStatus(ctx context.Context, w *Webhook) error {
 	return err
 }
 
+// IncreaseWebhookConsecutiveFailures increases the number of consecutive failed deliveries and returns the new value.
+func IncreaseWebhookConsecutiveFailures(ctx context.Context, id int64) (int, error) {
+	if _, err := db.GetEngine(ctx).ID(id).Incr("consecutive_failures").NoAutoTime().Update(new(Webhook)); err != nil {
+		return 0, err
+	}
+
+	w := &Webhook{}
+	has, err := db.GetEngine(ctx).ID(id).Cols("consecutive_failures").Get(w)
+	if err != nil {
+		return 0, err
+	} else if !has {
+		return 0, ErrWebhookNotExist{ID: id}
+	}
+	return w.ConsecutiveFailures, nil
+}
+
+// ResetWebhookConsecutiveFailures resets the number of consecutive failed deliveries after a successful delivery.
+func ResetWebhookConsecutiveFailures(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Where("consecutive_failures > 0").Cols("consecutive_failures").NoAutoTime().Update(&Webhook{})
+	return err
+}
+
+// DisableWebhook deactivates an active webhook. It returns false if the webhook was already inactive.
+func DisableWebhook(ctx context.Context, id int64) (bool, error) {
+	count, err := db.GetEngine(ctx).ID(id).Where("is_active = ?", true).Cols("is_active").Update(&Webhook{IsActive: false})
+	return count != 0, err
+}
+
 // DeleteWebhookByID uses argument bean as query condition,
 // ID must be specified and do not assign unnecessary fields.
 func DeleteWebhookByID(ctx context.Context, id int64) (err error) {
@@ -0,0 +1,121 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	// InTotoPayloadType is the payload type of a DSSE envelope signing an in-toto statement
+	InTotoPayloadType = "application/vnd.in-toto+json"
+
+	inTotoStatementV1  = "https://in-toto.io/Statement/v1"
+	inTotoStatementV01 = "https://in-toto.io/Statement/v0.1"
+)
+
+// InTotoSubject is an artifact described by an in-toto statement
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// InTotoStatement is an in-toto statement, its predicate is e.g. a SLSA build provenance or an SBOM
+// See https://github.com/in-toto/attestation/blob/main/spec/v1/statement.md
+type InTotoStatement struct {
+	Type          string               `json:"_type"`
+	Subject       []InTotoSubject      `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     any                  `json:"predicate,omitempty"`
+	Identity      *AttestationIdentity `json:"identity,omitempty"`
+}
+
+// AttestationIdentity identifies the actions job which attested the subjects of a statement.
+// It is added to the statement before it is signed, so the signature covers it.
+type AttestationIdentity struct {
+	Repository  string `json:"repository"`
+	WorkflowRef string `json:"workflowRef"`
+	RunID       int64  `json:"runId"`
+	CommitSHA   string `json:"sha"`
+}
+
+// AddAttestationIdentity adds the identity of the attesting job to a statement, it replaces any identity claimed by the statement itself
+func AddAttestationIdentity(content []byte, identity *AttestationIdentity) ([]byte, error) {
+	var statement map[string]json.RawMessage
+	if err := json.Unmarshal(content, &statement); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid statement: %v", err)
+	}
+	data, err := json.Marshal(identity)
+	if err != nil {
+		return nil, err
+	}
+	statement["identity"] = data
+	return json.Marshal(statement)
+}
+
+// Digests returns the digests of the subjects of the statement in the "<algorithm>:<hex>" form
+func (s *InTotoStatement) Digests() []string {
+	digests := make([]string, 0, len(s.Subject))
+	for _, subject := range s.Subject {
+		for algorithm, value := range subject.Digest {
+			digests = append(digests, NormalizeDigest(algorithm+":"+value))
+		}
+	}
+	return digests
+}
+
+// NormalizeDigest returns the lower case "<algorithm>:<hex>" form of a digest, a digest without algorithm is a sha256 digest
+func NormalizeDigest(digest string) string {
+	digest = strings.ToLower(strings.TrimSpace(digest))
+	if !strings.Contains(digest, ":") {
+		digest = "sha256:" + digest
+	}
+	return digest
+}
+
+// ParseInTotoStatement parses an in-toto statement, it must describe at least one subject by its digest
+func ParseInTotoStatement(data []byte) (*InTotoStatement, error) {
+	var statement InTotoStatement
+	if err := json.Unmarshal(data, &statement); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid statement: %v", err)
+	}
+	if statement.Type != inTotoStatementV1 && statement.Type != inTotoStatementV01 {
+		return nil, util.NewInvalidArgumentErrorf("unsupported statement type %q", statement.Type)
+	}
+	if statement.PredicateType == "" {
+		return nil, util.NewInvalidArgumentErrorf("statement has no predicate type")
+	}
+	if len(statement.Subject) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("statement has no subject")
+	}
+	for _, subject := range statement.Subject {
+		if len(subject.Digest) == 0 {
+			return nil, util.NewInvalidArgumentErrorf("subject %q has no digest", subject.Name)
+		}
+	}
+	return &statement, nil
+}
+
+// DSSESignature is a signature of a DSSE envelope
+type DSSESignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"` // base64 encoded
+}
+
+// DSSEEnvelope is a Dead Simple Signing Envelope carrying a signed payload
+// See https://github.com/secure-systems-lab/dsse/blob/master/envelope.md
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"` // base64 encoded
+	Signatures  []DSSESignature `json:"signatures"`
+}
+
+// DSSEPreAuthEncoding returns the pre-authentication encoding of a payload, which is what the signatures of a DSSE envelope sign
+func DSSEPreAuthEncoding(payloadType string, payload []byte) string {
+	return fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload)
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInTotoStatement(t *testing.T) {
+	statement, err := ParseInTotoStatement([]byte(`{
+  "_type": "https://in-toto.io/Statement/v1",
+  "subject": [{"name": "app.tar.gz", "digest": {"sha256": "ABC123"}}],
+  "predicateType": "https://slsa.dev/provenance/v1",
+  "predicate": {"buildDefinition": {}}
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://slsa.dev/provenance/v1", statement.PredicateType)
+	assert.Equal(t, []string{"sha256:abc123"}, statement.Digests())
+
+	for _, content := range []string{
+		`not json`,
+		`{"_type": "https://example.com/Statement", "subject": [{"digest": {"sha256": "abc"}}], "predicateType": "x"}`,
+		`{"_type": "https://in-toto.io/Statement/v1", "subject": [], "predicateType": "x"}`,
+		`{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "a"}], "predicateType": "x"}`,
+		`{"_type": "https://in-toto.io/Statement/v1", "subject": [{"digest": {"sha256": "abc"}}]}`,
+	} {
+		_, err = ParseInTotoStatement([]byte(content))
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, content)
+	}
+}
+
+func TestAddAttestationIdentity(t *testing.T) {
+	identity := &AttestationIdentity{
+		Repository:  "user2/repo1",
+		WorkflowRef: "user2/repo1/build.yml@refs/heads/main",
+		RunID:       5,
+		CommitSHA:   "c2d72f548424103f01ee1dc02889c1e2bff816b0",
+	}
+	content, err := AddAttestationIdentity([]byte(`{
+  "_type": "https://in-toto.io/Statement/v1",
+  "subject": [{"name": "app.tar.gz", "digest": {"sha256": "abc123"}}],
+  "predicateType": "https://slsa.dev/provenance/v1",
+  "predicate": {"buildDefinition": {}},
+  "identity": {"repository": "user2/other", "runId": 1}
+}`), identity)
+	assert.NoError(t, err)
+
+	statement, err := ParseInTotoStatement(content)
+	assert.NoError(t, err)
+	assert.Equal(t, identity, statement.Identity)
+	assert.Equal(t, []string{"sha256:abc123"}, statement.Digests())
+	assert.Equal(t, map[string]any{"buildDefinition": map[string]any{}}, statement.Predicate)
+
+	_, err = AddAttestationIdentity([]byte(`not json`), identity)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestDSSEPreAuthEncoding(t *testing.T) {
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world", DSSEPreAuthEncoding("http://example.com/HelloWorld", []byte("hello world")))
+	assert.Equal(t, "sha256:abc", NormalizeDigest(" ABC "))
+	assert.Equal(t, "sha512:abc", NormalizeDigest("sha512:abc"))
+}
@@ -23,6 +23,7 @@ const (
 	GithubEventPullRequestComment       = "pull_request_comment"
 	GithubEventGollum                   = "gollum"
 	GithubEventSchedule                 = "schedule"
+	GithubEventWorkflowCall             = "workflow_call"
 )
 
 // IsDefaultBranchWorkflow returns true if the event only triggers workflows on the default branch
@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/jobparser"
+	"gopkg.in/yaml.v3"
+)
+
+// PermissionLevel is the access of the token of a job to a permission scope
+type PermissionLevel int
+
+const (
+	PermissionNone PermissionLevel = iota
+	PermissionRead
+	PermissionWrite
+)
+
+// PermissionIDToken is the scope allowing a job to request an OIDC ID token, it cannot be read
+const PermissionIDToken = "id-token"
+
+// PermissionScopes are the scopes of the `permissions` of workflows and jobs
+var PermissionScopes = []string{
+	"actions",
+	"attestations",
+	"checks",
+	"contents",
+	"deployments",
+	"discussions",
+	PermissionIDToken,
+	"issues",
+	"packages",
+	"pages",
+	"pull-requests",
+	"repository-projects",
+	"security-events",
+	"statuses",
+}
+
+// Permissions are the access levels of the token of a job by scope
+type Permissions map[string]PermissionLevel
+
+// ParsePermissions parses the `permissions` of a workflow or a job, which is either `read-all`, `write-all` or a mapping of scopes.
+// It returns nil if there are no permissions.
+// See https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#permissions
+func ParsePermissions(node *yaml.Node) (Permissions, error) {
+	switch node.Kind {
+	case 0:
+		return nil, nil
+	case yaml.ScalarNode:
+		var level PermissionLevel
+		switch node.Value {
+		case "read-all":
+			level = PermissionRead
+		case "write-all":
+			level = PermissionWrite
+		case "":
+			return Permissions{}, nil
+		default:
+			return nil, util.NewInvalidArgumentErrorf("unknown permissions %q", node.Value)
+		}
+		perms := make(Permissions, len(PermissionScopes))
+		for _, scope := range PermissionScopes {
+			perms[scope] = level
+		}
+		if level == PermissionRead {
+			delete(perms, PermissionIDToken)
+		}
+		return perms, nil
+	case yaml.MappingNode:
+		var values map[string]string
+		if err := node.Decode(&values); err != nil {
+			return nil, err
+		}
+		perms := make(Permissions, len(values))
+		for scope, value := range values {
+			switch value {
+			case "read":
+				perms[scope] = PermissionRead
+			case "write":
+				perms[scope] = PermissionWrite
+			case "none":
+				perms[scope] = PermissionNone
+			default:
+				return nil, util.NewInvalidArgumentErrorf("unknown permission %q of %q", value, scope)
+			}
+		}
+		return perms, nil
+	default:
+		return nil, util.NewInvalidArgumentErrorf("invalid permissions")
+	}
+}
+
+// GetJobPermissions returns the permissions of the job of a single workflow, the permissions of the job replace the ones of the workflow.
+// It returns nil if neither the job nor the workflow declares permissions.
+func GetJobPermissions(workflow *jobparser.SingleWorkflow) (Permissions, error) {
+	_, job := workflow.Job()
+	if job != nil && job.RawPermissions.Kind != 0 {
+		return ParsePermissions(&job.RawPermissions)
+	}
+	return ParsePermissions(&workflow.RawPermissions)
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/jobparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobPermissions(t *testing.T) {
+	parse := func(content string) *jobparser.SingleWorkflow {
+		workflows, err := jobparser.Parse([]byte(content))
+		require.NoError(t, err)
+		require.Len(t, workflows, 1)
+		return workflows[0]
+	}
+
+	perms, err := GetJobPermissions(parse(`
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`))
+	assert.NoError(t, err)
+	assert.Nil(t, perms)
+
+	perms, err = GetJobPermissions(parse(`
+on: push
+permissions: read-all
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, PermissionRead, perms["contents"])
+	assert.Equal(t, PermissionNone, perms[PermissionIDToken])
+
+	perms, err = GetJobPermissions(parse(`
+on: push
+permissions: write-all
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+      id-token: write
+    steps:
+      - run: make deploy
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, Permissions{"contents": PermissionRead, PermissionIDToken: PermissionWrite}, perms)
+
+	_, err = GetJobPermissions(parse(`
+on: push
+permissions:
+  contents: admin
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
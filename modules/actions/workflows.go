@@ -5,7 +5,11 @@ package actions
 
 import (
 	"bytes"
+	"fmt"
+	"path"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
 	"code.gitea.io/gitea/modules/git"
@@ -98,6 +102,219 @@ func GetEventsFromContent(content []byte) ([]*jobparser.Event, error) {
 	return events, nil
 }
 
+// GetJobEnvironmentsFromContent returns the deployment environments declared by the jobs of a workflow, keyed by job ID.
+// The environment can be given as a name or as a mapping with a name, expressions in the name are not evaluated.
+func GetJobEnvironmentsFromContent(content []byte) (map[string]string, error) {
+	var workflow struct {
+		Jobs map[string]struct {
+			Environment yaml.Node `yaml:"environment"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(content, &workflow); err != nil {
+		return nil, err
+	}
+
+	environments := make(map[string]string, len(workflow.Jobs))
+	for id, job := range workflow.Jobs {
+		var name string
+		switch job.Environment.Kind {
+		case yaml.ScalarNode:
+			name = job.Environment.Value
+		case yaml.MappingNode:
+			var env struct {
+				Name string `yaml:"name"`
+			}
+			if err := job.Environment.Decode(&env); err != nil {
+				return nil, err
+			}
+			name = env.Name
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			environments[id] = name
+		}
+	}
+	return environments, nil
+}
+
+// ReusableWorkflow is a workflow called by a job with `jobs.<job_id>.uses`
+type ReusableWorkflow struct {
+	OwnerName string // empty if the workflow is in the repository of the caller
+	RepoName  string
+	Path      string
+	Ref       string // empty if the workflow is in the repository of the caller, which reads it at the commit of its run
+}
+
+// IsLocal returns whether the workflow is in the repository of the caller
+func (w *ReusableWorkflow) IsLocal() bool {
+	return w.OwnerName == ""
+}
+
+// ParseReusableWorkflowUses parses the `uses` of a job calling a reusable workflow, which is either
+// `./.gitea/workflows/build.yml` for a workflow of the same repository or `owner/repo/.gitea/workflows/build.yml@ref`.
+func ParseReusableWorkflowUses(uses string) (*ReusableWorkflow, error) {
+	wf := &ReusableWorkflow{}
+	if p, ok := strings.CutPrefix(uses, "./"); ok {
+		wf.Path = p
+	} else {
+		i := strings.LastIndex(uses, "@")
+		if i < 0 {
+			return nil, util.NewInvalidArgumentErrorf("reusable workflow %q has no ref", uses)
+		}
+		parts := strings.SplitN(uses[:i], "/", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, util.NewInvalidArgumentErrorf("reusable workflow %q has no repository", uses)
+		}
+		wf.OwnerName, wf.RepoName, wf.Path, wf.Ref = parts[0], parts[1], parts[2], uses[i+1:]
+		if wf.Ref == "" {
+			return nil, util.NewInvalidArgumentErrorf("reusable workflow %q has no ref", uses)
+		}
+	}
+	if !IsWorkflow(wf.Path) || path.Clean(wf.Path) != wf.Path {
+		return nil, util.NewInvalidArgumentErrorf("%q is not a workflow file", uses)
+	}
+	return wf, nil
+}
+
+// ReadWorkflowCallConfig reads the inputs and outputs declared by `on.workflow_call` of a reusable workflow,
+// it returns an invalid argument error if the workflow cannot be called by other workflows.
+func ReadWorkflowCallConfig(content []byte) (*model.WorkflowCall, error) {
+	workflow, err := model.ReadWorkflow(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	// jobparser.ParseRawOn can't be used here, it rejects the inputs of workflow_call
+	var events []string
+	switch workflow.RawOn.Kind {
+	case yaml.ScalarNode:
+		var event string
+		err = workflow.RawOn.Decode(&event)
+		events = []string{event}
+	case yaml.SequenceNode:
+		err = workflow.RawOn.Decode(&events)
+	case yaml.MappingNode:
+		var val map[string]yaml.Node
+		err = workflow.RawOn.Decode(&val)
+		for event := range val {
+			events = append(events, event)
+		}
+	}
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid on: %v", err)
+	}
+	if !slices.Contains(events, GithubEventWorkflowCall) {
+		return nil, util.NewInvalidArgumentErrorf("workflow is not triggered by %s", GithubEventWorkflowCall)
+	}
+	return workflow.WorkflowCallConfig(), nil
+}
+
+// GetWorkflowCallInputs returns the inputs of a reusable workflow from the `with` of its caller.
+// The inputs which are not given get their defaults and all values are converted to the declared types.
+func GetWorkflowCallInputs(config *model.WorkflowCall, with map[string]any) (map[string]any, error) {
+	inputs := make(map[string]any, len(config.Inputs))
+	for name, input := range config.Inputs {
+		value, ok := with[name]
+		if !ok {
+			if input.Required {
+				return nil, util.NewInvalidArgumentErrorf("required input %q is not provided", name)
+			}
+			value = input.Default
+		}
+		switch input.Type {
+		case "boolean":
+			if s, ok := value.(string); ok {
+				b, err := strconv.ParseBool(util.IfZero(s, "false"))
+				if err != nil {
+					return nil, util.NewInvalidArgumentErrorf("input %q is not a boolean: %q", name, s)
+				}
+				value = b
+			}
+		case "number":
+			if s, ok := value.(string); ok {
+				n, err := strconv.ParseFloat(util.IfZero(s, "0"), 64)
+				if err != nil {
+					return nil, util.NewInvalidArgumentErrorf("input %q is not a number: %q", name, s)
+				}
+				value = n
+			}
+		default:
+			if value == nil {
+				value = ""
+			} else if _, ok := value.(string); !ok {
+				value = fmt.Sprint(value)
+			}
+		}
+		inputs[name] = value
+	}
+	return inputs, nil
+}
+
+var (
+	workflowExpressionRegexp = regexp.MustCompile(`(?s)\$\{\{(.*?)\}\}`)
+	workflowInputsRegexp     = regexp.MustCompile(`(^|[^\w.])inputs\.([A-Za-z_][\w-]*)`)
+)
+
+// SubstituteWorkflowCallInputs replaces the references to the `inputs` context in the expressions of a reusable workflow
+// with the literals of the inputs given by its caller, so the called jobs can be parsed and run like any other job.
+// The conditions in `if` are expressions even without `${{ }}`.
+func SubstituteWorkflowCallInputs(content []byte, inputs map[string]any) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, err
+	}
+	substituteInputsInNode(&node, false, inputs)
+	return yaml.Marshal(&node)
+}
+
+func substituteInputsInNode(node *yaml.Node, isCondition bool, inputs map[string]any) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			substituteInputsInNode(n, false, inputs)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			substituteInputsInNode(node.Content[i+1], node.Content[i].Value == "if", inputs)
+		}
+	case yaml.ScalarNode:
+		if isCondition && !strings.Contains(node.Value, "${{") {
+			node.Value = substituteInputsInExpression(node.Value, inputs)
+			return
+		}
+		node.Value = workflowExpressionRegexp.ReplaceAllStringFunc(node.Value, func(expr string) string {
+			return substituteInputsInExpression(expr, inputs)
+		})
+	}
+}
+
+func substituteInputsInExpression(expr string, inputs map[string]any) string {
+	// the odd parts are string literals, which must not be changed
+	parts := strings.Split(expr, "'")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = workflowInputsRegexp.ReplaceAllStringFunc(parts[i], func(s string) string {
+			m := workflowInputsRegexp.FindStringSubmatch(s)
+			return m[1] + workflowInputLiteral(inputs[m[2]])
+		})
+	}
+	return strings.Join(parts, "'")
+}
+
+func workflowInputLiteral(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int, int64, uint64:
+		return fmt.Sprint(v)
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	default:
+		return workflowInputLiteral(fmt.Sprint(v))
+	}
+}
+
 func DetectWorkflows(
 	gitRepo *git.Repository,
 	commit *git.Commit,
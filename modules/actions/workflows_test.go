@@ -8,6 +8,7 @@ import (
 
 	"code.gitea.io/gitea/modules/git"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 
 	"github.com/stretchr/testify/assert"
@@ -335,3 +336,107 @@ func TestMatchIssuesEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestGetJobEnvironmentsFromContent(t *testing.T) {
+	content := []byte(`
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+  staging:
+    runs-on: ubuntu-latest
+    environment: staging
+    steps:
+      - run: make deploy
+  production:
+    runs-on: ubuntu-latest
+    environment:
+      name: production
+      url: https://example.com
+    steps:
+      - run: make deploy
+`)
+	environments, err := GetJobEnvironmentsFromContent(content)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"staging": "staging", "production": "production"}, environments)
+}
+
+func TestParseReusableWorkflowUses(t *testing.T) {
+	wf, err := ParseReusableWorkflowUses("./.gitea/workflows/build.yml")
+	assert.NoError(t, err)
+	assert.True(t, wf.IsLocal())
+	assert.Equal(t, ".gitea/workflows/build.yml", wf.Path)
+
+	wf, err = ParseReusableWorkflowUses("org/templates/.github/workflows/deploy.yaml@v1")
+	assert.NoError(t, err)
+	assert.False(t, wf.IsLocal())
+	assert.Equal(t, &ReusableWorkflow{OwnerName: "org", RepoName: "templates", Path: ".github/workflows/deploy.yaml", Ref: "v1"}, wf)
+
+	for _, uses := range []string{
+		"actions/checkout@v4",
+		"org/templates/.gitea/workflows/deploy.yml",
+		"org/templates/.gitea/workflows/deploy.yml@",
+		"./.gitea/workflows/../../secret.yml",
+		"./scripts/build.yml",
+	} {
+		_, err = ParseReusableWorkflowUses(uses)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, uses)
+	}
+}
+
+func TestGetWorkflowCallInputs(t *testing.T) {
+	content := []byte(`
+on:
+  workflow_call:
+    inputs:
+      env:
+        type: string
+        required: true
+      debug:
+        type: boolean
+        default: false
+      replicas:
+        type: number
+        default: 2
+    outputs:
+      url:
+        value: ${{ jobs.deploy.outputs.url }}
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make deploy
+`)
+	config, err := ReadWorkflowCallConfig(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "${{ jobs.deploy.outputs.url }}", config.Outputs["url"].Value)
+
+	inputs, err := GetWorkflowCallInputs(config, map[string]any{"env": "staging", "debug": "true"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"env": "staging", "debug": true, "replicas": float64(2)}, inputs)
+
+	_, err = GetWorkflowCallInputs(config, map[string]any{"debug": true})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	_, err = ReadWorkflowCallConfig([]byte("on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"))
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+}
+
+func TestSubstituteWorkflowCallInputs(t *testing.T) {
+	content := []byte(`
+on: workflow_call
+jobs:
+  deploy:
+    if: inputs.debug && github.event.inputs.debug
+    runs-on: ${{ inputs.runner }}
+    steps:
+      - run: echo "${{ inputs.env }} ${{ 'inputs.env' }} ${{ inputs.missing }}"
+`)
+	got, err := SubstituteWorkflowCallInputs(content, map[string]any{"debug": true, "runner": "ubuntu-latest", "env": "it's"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), "if: true && github.event.inputs.debug")
+	assert.Contains(t, string(got), "runs-on: ${{ 'ubuntu-latest' }}")
+	assert.Contains(t, string(got), `run: echo "${{ 'it''s' }} ${{ 'inputs.env' }} ${{ null }}"`)
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+)
+
+// parseCargoLock parses the packages of a Cargo.lock file
+func parseCargoLock(content []byte) ([]*Dependency, error) {
+	packages, err := parseTOMLArrayTables(content, "package")
+	if err != nil {
+		return nil, err
+	}
+
+	// the packages without source are the members of the workspace, their dependencies are the direct ones
+	direct := make(map[string]bool)
+	for _, p := range packages {
+		if p.String("source") != "" {
+			continue
+		}
+		for _, dep := range p.Strings("dependencies") {
+			// "name", "name version" or "name version (source)"
+			name, _, _ := strings.Cut(dep, " ")
+			direct[name] = true
+		}
+	}
+
+	deps := make([]*Dependency, 0, len(packages))
+	for _, p := range packages {
+		if p.String("source") == "" {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: scanner.EcosystemCrates,
+			Name:      p.String("name"),
+			Version:   p.String("version"),
+			IsDirect:  direct[p.String("name")],
+		})
+	}
+	return deps, nil
+}
+
+var (
+	cargoTablePattern      = regexp.MustCompile(`^\s*\[([^\[\]]+)\]\s*(?:#.*)?$`)
+	cargoEntryPattern      = regexp.MustCompile(`^\s*"?([A-Za-z0-9_-]+)"?\s*=\s*`)
+	cargoVersionKeyPattern = regexp.MustCompile(`\bversion\s*=\s*"([^"]*)"`)
+	cargoPackageKeyPattern = regexp.MustCompile(`\bpackage\s*=`)
+	// only exact versions and the common ranges of a version are updated, e.g. "1.2", "^1.2.3" or "=1.2.3"
+	cargoSpecPattern = regexp.MustCompile(`^([~^=]|>=)?(\d+(?:\.\d+){0,2}(?:-[0-9A-Za-z.-]+)?)$`)
+)
+
+func isCargoDependencyTable(name string) bool {
+	_, last, _ := cutLast(name, ".")
+	return last == "dependencies" || last == "dev-dependencies" || last == "build-dependencies"
+}
+
+func cutLast(s, sep string) (string, string, bool) {
+	if idx := strings.LastIndex(s, sep); idx != -1 {
+		return s[:idx], s[idx+len(sep):], true
+	}
+	return "", s, false
+}
+
+// rewriteCargoDependencies calls fn with the version specs of the dependencies of a Cargo.toml file and replaces them with the results.
+// Dependencies are declared as `name = "spec"`, `name = { version = "spec" }` or as table "[dependencies.name]",
+// renamed dependencies are skipped because their key is not the name of the crate.
+func rewriteCargoDependencies(content []byte, fn func(name, spec string) string) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+
+	var inDependencies bool
+	var tableDependency string
+	for i, line := range lines {
+		if m := cargoTablePattern.FindStringSubmatch(line); m != nil {
+			name := strings.TrimSpace(m[1])
+			inDependencies = isCargoDependencyTable(name)
+			tableDependency = ""
+			if parent, last, ok := cutLast(name, "."); ok && isCargoDependencyTable(parent) {
+				tableDependency = last
+			}
+			continue
+		}
+
+		var name string
+		var loc []int
+		switch {
+		case tableDependency != "":
+			if cargoPackageKeyPattern.MatchString(line) {
+				tableDependency = ""
+				continue
+			}
+			if !strings.HasPrefix(strings.TrimSpace(line), "version") {
+				continue
+			}
+			name, loc = tableDependency, cargoVersionKeyPattern.FindStringSubmatchIndex(line)
+		case inDependencies:
+			m := cargoEntryPattern.FindStringSubmatchIndex(line)
+			if m == nil {
+				continue
+			}
+			name = line[m[2]:m[3]]
+			value := line[m[1]:]
+			if strings.HasPrefix(value, `"`) {
+				if end := strings.IndexByte(value[1:], '"'); end != -1 {
+					loc = []int{m[1], m[1] + end + 2, m[1] + 1, m[1] + end + 1}
+				}
+			} else if strings.HasPrefix(value, "{") && !cargoPackageKeyPattern.MatchString(value) {
+				if v := cargoVersionKeyPattern.FindStringSubmatchIndex(value); v != nil {
+					loc = []int{m[1] + v[0], m[1] + v[1], m[1] + v[2], m[1] + v[3]}
+				}
+			}
+		}
+		if loc == nil {
+			continue
+		}
+		spec := line[loc[2]:loc[3]]
+		lines[i] = line[:loc[2]] + fn(name, spec) + line[loc[3]:]
+	}
+	return []byte(strings.Join(lines, ""))
+}
+
+// readCargoRequirements returns the dependencies of a Cargo.toml file
+func readCargoRequirements(content []byte) ([]*Requirement, error) {
+	var reqs []*Requirement
+	rewriteCargoDependencies(content, func(name, spec string) string {
+		if m := cargoSpecPattern.FindStringSubmatch(spec); m != nil {
+			reqs = append(reqs, &Requirement{Name: name, Version: m[2]})
+		}
+		return spec
+	})
+	return uniqueRequirements(reqs), nil
+}
+
+func updateCargoRequirement(content []byte, name, from, to string) []byte {
+	return rewriteCargoDependencies(content, func(depName, spec string) string {
+		m := cargoSpecPattern.FindStringSubmatch(spec)
+		if depName != name || m == nil || m[2] != from {
+			return spec
+		}
+		return m[1] + to
+	})
+}
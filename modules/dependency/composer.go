@@ -0,0 +1,66 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/packages/scanner"
+)
+
+type composerLock struct {
+	Packages    []composerLockPackage `json:"packages"`
+	PackagesDev []composerLockPackage `json:"packages-dev"`
+}
+
+type composerLockPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// parseComposerLock parses the packages of a composer.lock file, the lockfile does not tell which ones are direct dependencies
+func parseComposerLock(content []byte) ([]*Dependency, error) {
+	var lock composerLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	deps := make([]*Dependency, 0, len(lock.Packages)+len(lock.PackagesDev))
+	for _, p := range append(lock.Packages, lock.PackagesDev...) {
+		deps = append(deps, &Dependency{
+			Ecosystem: scanner.EcosystemPackagist,
+			Name:      p.Name,
+			Version:   p.Version,
+		})
+	}
+	return deps, nil
+}
+
+// only exact versions and the common ranges of a version are updated, e.g. "1.2.3", "^1.2" or "~1.2.3"
+var composerSpecPattern = regexp.MustCompile(`^([~^]|>=)?(v?\d+(?:\.\d+){0,3}(?:-[0-9A-Za-z.]+)?)$`)
+
+type composerJSON struct {
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// readComposerRequirements returns the package dependencies of a composer.json file,
+// platform requirements like "php" or "ext-json" have no vendor and are skipped
+func readComposerRequirements(content []byte) ([]*Requirement, error) {
+	var pkg composerJSON
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+	reqs := readJSONRequirements(composerSpecPattern, pkg.Require, pkg.RequireDev)
+	return slices.DeleteFunc(reqs, func(r *Requirement) bool {
+		return !strings.Contains(r.Name, "/")
+	}), nil
+}
+
+func updateComposerRequirement(content []byte, name, from, to string) []byte {
+	return updateJSONRequirement(content, composerSpecPattern, name, from, to)
+}
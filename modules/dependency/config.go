@@ -0,0 +1,229 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UpdatesConfigPath is the path of the config of the dependency updater in the default branch
+const UpdatesConfigPath = ".gitea/dependency-updates.yml"
+
+// DefaultOpenPullRequestsLimit is the number of pull requests an update entry opens if the config does not limit them
+const DefaultOpenPullRequestsLimit = 5
+
+// Schedule intervals of an update entry
+const (
+	IntervalDaily   = "daily"
+	IntervalWeekly  = "weekly"
+	IntervalMonthly = "monthly"
+)
+
+// Update types which can be ignored or grouped
+const (
+	UpdateTypeMajor = "major"
+	UpdateTypeMinor = "minor"
+	UpdateTypePatch = "patch"
+)
+
+// UpdatesConfig is the config of the dependency updater, the format follows the one of Dependabot:
+//
+//	updates:
+//	  - package-ecosystem: npm
+//	    directory: /web
+//	    schedule:
+//	      interval: weekly
+//	    groups:
+//	      eslint:
+//	        patterns: ["eslint*", "@eslint/*"]
+//	    ignore:
+//	      - dependency-name: "react*"
+//	        update-types: [major]
+type UpdatesConfig struct {
+	Updates []*UpdateConfig `yaml:"updates"`
+}
+
+// UpdateConfig describes which manifest of a package ecosystem gets updated and how
+type UpdateConfig struct {
+	PackageEcosystem string `yaml:"package-ecosystem"`
+	// Directory is the directory of the manifest relative to the root of the repository
+	Directory string `yaml:"directory"`
+	Schedule  struct {
+		Interval string `yaml:"interval"`
+		// Day is the weekday weekly updates run at
+		Day string `yaml:"day"`
+	} `yaml:"schedule"`
+	// TargetBranch is the branch the pull requests are opened against, the default branch if empty
+	TargetBranch          string                  `yaml:"target-branch"`
+	OpenPullRequestsLimit *int                    `yaml:"open-pull-requests-limit"`
+	Labels                []string                `yaml:"labels"`
+	Ignore                []*UpdateIgnore         `yaml:"ignore"`
+	Groups                map[string]*UpdateGroup `yaml:"groups"`
+}
+
+// UpdateIgnore excludes updates of dependencies, all updates are excluded if no update type is listed
+type UpdateIgnore struct {
+	DependencyName string   `yaml:"dependency-name"`
+	UpdateTypes    []string `yaml:"update-types"`
+}
+
+// UpdateGroup combines the updates of the matching dependencies in one pull request
+type UpdateGroup struct {
+	Patterns        []string `yaml:"patterns"`
+	ExcludePatterns []string `yaml:"exclude-patterns"`
+	UpdateTypes     []string `yaml:"update-types"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// ParseUpdatesConfig parses and validates the config of the dependency updater
+func ParseUpdatesConfig(content []byte) (*UpdatesConfig, error) {
+	var cfg UpdatesConfig
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid %s: %v", UpdatesConfigPath, err)
+	}
+
+	for i, u := range cfg.Updates {
+		if u == nil {
+			return nil, util.NewInvalidArgumentErrorf("update entry %d is empty", i)
+		}
+		if GetUpdateEcosystem(u.PackageEcosystem) == nil {
+			return nil, util.NewInvalidArgumentErrorf("update entry %d has the unsupported package-ecosystem %q", i, u.PackageEcosystem)
+		}
+		u.Directory = path.Join("/", u.Directory)
+
+		u.Schedule.Interval = strings.ToLower(util.IfZero(u.Schedule.Interval, IntervalDaily))
+		switch u.Schedule.Interval {
+		case IntervalDaily, IntervalWeekly, IntervalMonthly:
+		default:
+			return nil, util.NewInvalidArgumentErrorf("update entry %d has the invalid schedule interval %q", i, u.Schedule.Interval)
+		}
+		u.Schedule.Day = strings.ToLower(util.IfZero(u.Schedule.Day, "monday"))
+		if _, ok := weekdays[u.Schedule.Day]; !ok {
+			return nil, util.NewInvalidArgumentErrorf("update entry %d has the invalid schedule day %q", i, u.Schedule.Day)
+		}
+
+		for _, ignore := range u.Ignore {
+			if err := validatePatterns(ignore.DependencyName); err != nil {
+				return nil, util.NewInvalidArgumentErrorf("update entry %d has an invalid ignore: %v", i, err)
+			}
+			if err := validateUpdateTypes(ignore.UpdateTypes); err != nil {
+				return nil, util.NewInvalidArgumentErrorf("update entry %d has an invalid ignore: %v", i, err)
+			}
+		}
+		for name, group := range u.Groups {
+			if group == nil || len(group.Patterns) == 0 && len(group.UpdateTypes) == 0 {
+				return nil, util.NewInvalidArgumentErrorf("group %q of update entry %d matches nothing", name, i)
+			}
+			if err := validatePatterns(append(group.Patterns, group.ExcludePatterns...)...); err != nil {
+				return nil, util.NewInvalidArgumentErrorf("group %q of update entry %d is invalid: %v", name, i, err)
+			}
+			if err := validateUpdateTypes(group.UpdateTypes); err != nil {
+				return nil, util.NewInvalidArgumentErrorf("group %q of update entry %d is invalid: %v", name, i, err)
+			}
+		}
+	}
+	return &cfg, nil
+}
+
+func validatePatterns(patterns ...string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+func validateUpdateTypes(updateTypes []string) error {
+	for _, t := range updateTypes {
+		switch t {
+		case UpdateTypeMajor, UpdateTypeMinor, UpdateTypePatch:
+		default:
+			return fmt.Errorf("invalid update type %q", t)
+		}
+	}
+	return nil
+}
+
+// matchesPattern tests if the name matches the glob pattern, "*" matches "/" too because the names of most ecosystems contain it
+func matchesPattern(pattern, name string) bool {
+	matched, _ := path.Match(strings.ReplaceAll(pattern, "/", "\x00"), strings.ReplaceAll(name, "/", "\x00"))
+	return matched
+}
+
+// IsDue tests if the updates of the entry are scheduled at the day
+func (u *UpdateConfig) IsDue(t time.Time) bool {
+	switch u.Schedule.Interval {
+	case IntervalWeekly:
+		return t.Weekday() == weekdays[u.Schedule.Day]
+	case IntervalMonthly:
+		return t.Day() == 1
+	}
+	return true
+}
+
+// GetOpenPullRequestsLimit returns the maximum number of open pull requests of the entry
+func (u *UpdateConfig) GetOpenPullRequestsLimit() int {
+	if u.OpenPullRequestsLimit == nil {
+		return DefaultOpenPullRequestsLimit
+	}
+	return *u.OpenPullRequestsLimit
+}
+
+// IsIgnored tests if the update of the dependency is excluded
+func (u *UpdateConfig) IsIgnored(name, updateType string) bool {
+	for _, ignore := range u.Ignore {
+		if matchesPattern(ignore.DependencyName, name) && (len(ignore.UpdateTypes) == 0 || util.SliceContainsString(ignore.UpdateTypes, updateType)) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGroup returns the name of the group the update of the dependency belongs to, groups are matched in the order of their names
+func (u *UpdateConfig) GetGroup(name, updateType string) string {
+	for _, groupName := range util.Sorted(util.KeysOfMap(u.Groups)) {
+		if u.Groups[groupName].Matches(name, updateType) {
+			return groupName
+		}
+	}
+	return ""
+}
+
+// Matches tests if the update of the dependency belongs to the group
+func (g *UpdateGroup) Matches(name, updateType string) bool {
+	if len(g.UpdateTypes) > 0 && !util.SliceContainsString(g.UpdateTypes, updateType) {
+		return false
+	}
+	for _, pattern := range g.ExcludePatterns {
+		if matchesPattern(pattern, name) {
+			return false
+		}
+	}
+	if len(g.Patterns) == 0 {
+		return true
+	}
+	for _, pattern := range g.Patterns {
+		if matchesPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/packages/scanner"
+)
+
+// MaxManifestSize is the maximum size of a manifest file which gets parsed
+const MaxManifestSize = 10 * 1024 * 1024
+
+// Dependency is a package a repository depends on
+type Dependency struct {
+	// Manifest is the path of the manifest or lockfile which declares the dependency
+	Manifest string
+	// Ecosystem is the OSV ecosystem of the package, see modules/packages/scanner
+	Ecosystem string
+	Name      string
+	// Version is the resolved version or, if the manifest only declares a requirement, the requirement
+	Version string
+	// IsDirect is true if the repository declares the dependency, false if it is pulled in by another dependency or unknown
+	IsDirect bool
+}
+
+type parserFunc func(content []byte) ([]*Dependency, error)
+
+var parsers = map[string]parserFunc{
+	"go.mod":              parseGoMod,
+	"package-lock.json":   parsePackageLock,
+	"npm-shrinkwrap.json": parsePackageLock,
+	"pnpm-lock.yaml":      parsePnpmLock,
+	"Cargo.lock":          parseCargoLock,
+	"poetry.lock":         parsePoetryLock,
+	"pom.xml":             parsePom,
+	"composer.lock":       parseComposerLock,
+}
+
+// directories which contain checked in dependencies and not manifests of the repository
+var ignoredDirectories = []string{"node_modules", "vendor", "bower_components"}
+
+func getParser(treePath string) parserFunc {
+	for dir := range strings.SplitSeq(path.Dir(treePath), "/") {
+		for _, ignored := range ignoredDirectories {
+			if dir == ignored {
+				return nil
+			}
+		}
+	}
+
+	name := path.Base(treePath)
+	if p, ok := parsers[name]; ok {
+		return p
+	}
+	if strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt") {
+		return parseRequirements
+	}
+	return nil
+}
+
+// IsManifest tests if the file is a manifest or lockfile which can be parsed
+func IsManifest(treePath string) bool {
+	return getParser(treePath) != nil
+}
+
+// Parse parses the dependencies declared in a manifest or lockfile
+func Parse(treePath string, content []byte) ([]*Dependency, error) {
+	p := getParser(treePath)
+	if p == nil {
+		return nil, nil
+	}
+
+	deps, err := p(content)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(deps))
+	result := make([]*Dependency, 0, len(deps))
+	for _, d := range deps {
+		if d.Name == "" {
+			continue
+		}
+		key := d.Name + "@" + d.Version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		d.Manifest = treePath
+		result = append(result, d)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Version < result[j].Version
+	})
+	return result, nil
+}
+
+// GetCommitDependencies parses all manifests and lockfiles of the commit.
+// Manifests which are too large or invalid are skipped.
+func GetCommitDependencies(commit *git.Commit) ([]*Dependency, error) {
+	entries, err := commit.Tree.ListEntriesRecursiveWithSize()
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []*Dependency
+	for _, entry := range entries {
+		// the name of a recursively listed entry is the full path
+		treePath := entry.Name()
+		if !entry.IsRegular() || !IsManifest(treePath) {
+			continue
+		}
+		if entry.Size() > MaxManifestSize {
+			log.Debug("Skipping manifest %s of commit %s: too large", treePath, commit.ID)
+			continue
+		}
+
+		content, err := entry.Blob().GetBlobBytes(MaxManifestSize)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := Parse(treePath, content)
+		if err != nil {
+			log.Debug("Skipping manifest %s of commit %s: %v", treePath, commit.ID, err)
+			continue
+		}
+		deps = append(deps, parsed...)
+	}
+	return deps, nil
+}
+
+// LowerName returns the name used to search the dependency, it is normalized like the names of advisories
+func (d *Dependency) LowerName() string {
+	return scanner.NormalizeName(d.Ecosystem, d.Name)
+}
+
+// IsExactVersion tests if the version is a single version and not a requirement
+func (d *Dependency) IsExactVersion() bool {
+	return d.Version != "" && !strings.ContainsAny(d.Version, "<>=!^~*, |[]()")
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsManifest(t *testing.T) {
+	assert.True(t, IsManifest("go.mod"))
+	assert.True(t, IsManifest("web/package-lock.json"))
+	assert.True(t, IsManifest("requirements-dev.txt"))
+	assert.True(t, IsManifest("service/pom.xml"))
+	assert.False(t, IsManifest("package.json"))
+	assert.False(t, IsManifest("go.sum"))
+	assert.False(t, IsManifest("node_modules/lib/package-lock.json"))
+	assert.False(t, IsManifest("vendor/github.com/lib/go.mod"))
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		Path     string
+		Content  string
+		Expected []*Dependency
+	}{
+		{
+			Path: "go.mod",
+			Content: `module example.com/app
+
+go 1.22
+
+require (
+	github.com/google/uuid v1.6.0
+	golang.org/x/text v0.14.0 // indirect
+	example.com/old v1.0.0
+)
+
+replace example.com/old => example.com/new v1.1.0
+`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemGo, Name: "example.com/new", Version: "v1.1.0", IsDirect: true},
+				{Ecosystem: scanner.EcosystemGo, Name: "github.com/google/uuid", Version: "v1.6.0", IsDirect: true},
+				{Ecosystem: scanner.EcosystemGo, Name: "golang.org/x/text", Version: "v0.14.0"},
+			},
+		},
+		{
+			Path: "package-lock.json",
+			Content: `{
+	"lockfileVersion": 3,
+	"packages": {
+		"": {"name": "app", "dependencies": {"lodash": "^4.17.0"}, "devDependencies": {"@types/node": "^20.0.0"}},
+		"node_modules/lodash": {"version": "4.17.20"},
+		"node_modules/@types/node": {"version": "20.1.0", "dev": true},
+		"node_modules/@types/node/node_modules/undici-types": {"version": "5.26.5", "dev": true},
+		"node_modules/local": {"resolved": "packages/local", "link": true}
+	}
+}`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemNpm, Name: "@types/node", Version: "20.1.0", IsDirect: true},
+				{Ecosystem: scanner.EcosystemNpm, Name: "lodash", Version: "4.17.20", IsDirect: true},
+				{Ecosystem: scanner.EcosystemNpm, Name: "undici-types", Version: "5.26.5"},
+			},
+		},
+		{
+			Path: "package-lock.json",
+			Content: `{
+	"lockfileVersion": 1,
+	"dependencies": {
+		"express": {"version": "4.18.2", "requires": {"debug": "2.6.9"}, "dependencies": {"ms": {"version": "2.0.0"}}},
+		"debug": {"version": "2.6.9"}
+	}
+}`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemNpm, Name: "debug", Version: "2.6.9"},
+				{Ecosystem: scanner.EcosystemNpm, Name: "express", Version: "4.18.2", IsDirect: true},
+				{Ecosystem: scanner.EcosystemNpm, Name: "ms", Version: "2.0.0"},
+			},
+		},
+		{
+			Path: "pnpm-lock.yaml",
+			Content: `lockfileVersion: '9.0'
+importers:
+  .:
+    dependencies:
+      react:
+        specifier: ^18.0.0
+        version: 18.2.0
+packages:
+  react@18.2.0:
+    resolution: {integrity: sha512-abc}
+  loose-envify@1.4.0:
+    resolution: {integrity: sha512-def}
+  '@babel/core@7.23.0(supports-color@5.5.0)':
+    resolution: {integrity: sha512-ghi}
+`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemNpm, Name: "@babel/core", Version: "7.23.0"},
+				{Ecosystem: scanner.EcosystemNpm, Name: "loose-envify", Version: "1.4.0"},
+				{Ecosystem: scanner.EcosystemNpm, Name: "react", Version: "18.2.0", IsDirect: true},
+			},
+		},
+		{
+			Path: "pnpm-lock.yaml",
+			Content: `lockfileVersion: 5.4
+dependencies:
+  react: 17.0.2
+packages:
+  /react/17.0.2:
+    resolution: {integrity: sha512-abc}
+  /@emotion/react/11.10.0_react@17.0.2:
+    resolution: {integrity: sha512-def}
+`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemNpm, Name: "@emotion/react", Version: "11.10.0"},
+				{Ecosystem: scanner.EcosystemNpm, Name: "react", Version: "17.0.2", IsDirect: true},
+			},
+		},
+		{
+			Path: "Cargo.lock",
+			Content: `# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.190"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "91d3c334ca1ee894a2c6f6ad698fe8c435b76d504b13d436f0685d648d6d96f7"
+dependencies = [
+ "serde_derive 1.0.190",
+]
+
+[[package]]
+name = "serde_derive"
+version = "1.0.190"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemCrates, Name: "serde", Version: "1.0.190", IsDirect: true},
+				{Ecosystem: scanner.EcosystemCrates, Name: "serde_derive", Version: "1.0.190"},
+			},
+		},
+		{
+			Path: "requirements.txt",
+			Content: `# comment
+-r base.txt
+Django==4.2.7 \
+    --hash=sha256:abc
+requests[socks] >= 2.31, <3 ; python_version >= "3.8"
+pkg @ https://example.com/pkg-1.0.tar.gz
+-e .
+`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemPyPI, Name: "Django", Version: "4.2.7", IsDirect: true},
+				{Ecosystem: scanner.EcosystemPyPI, Name: "pkg", IsDirect: true},
+				{Ecosystem: scanner.EcosystemPyPI, Name: "requests", Version: ">=2.31,<3", IsDirect: true},
+			},
+		},
+		{
+			Path: "poetry.lock",
+			Content: `[[package]]
+name = "certifi"
+version = "2023.7.22"
+description = "Python package for providing Mozilla's CA Bundle."
+optional = false
+python-versions = ">=3.6"
+files = [
+    {file = "certifi-2023.7.22-py3-none-any.whl", hash = "sha256:abc"},
+]
+
+[package.dependencies]
+idna = ">=2.5"
+
+[metadata]
+lock-version = "2.0"
+`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemPyPI, Name: "certifi", Version: "2023.7.22"},
+			},
+		},
+		{
+			Path: "pom.xml",
+			Content: `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+	<groupId>com.example</groupId>
+	<artifactId>app</artifactId>
+	<version>1.0.0</version>
+	<properties>
+		<jackson.version>2.15.3</jackson.version>
+	</properties>
+	<dependencyManagement>
+		<dependencies>
+			<dependency>
+				<groupId>org.slf4j</groupId>
+				<artifactId>slf4j-api</artifactId>
+				<version>2.0.9</version>
+			</dependency>
+		</dependencies>
+	</dependencyManagement>
+	<dependencies>
+		<dependency>
+			<groupId>com.fasterxml.jackson.core</groupId>
+			<artifactId>jackson-databind</artifactId>
+			<version>${jackson.version}</version>
+		</dependency>
+		<dependency>
+			<groupId>org.slf4j</groupId>
+			<artifactId>slf4j-api</artifactId>
+		</dependency>
+		<dependency>
+			<groupId>${project.groupId}</groupId>
+			<artifactId>lib</artifactId>
+			<version>${unknown.version}</version>
+		</dependency>
+	</dependencies>
+</project>`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemMaven, Name: "com.example:lib", IsDirect: true},
+				{Ecosystem: scanner.EcosystemMaven, Name: "com.fasterxml.jackson.core:jackson-databind", Version: "2.15.3", IsDirect: true},
+				{Ecosystem: scanner.EcosystemMaven, Name: "org.slf4j:slf4j-api", Version: "2.0.9", IsDirect: true},
+			},
+		},
+		{
+			Path: "composer.lock",
+			Content: `{
+	"packages": [{"name": "monolog/monolog", "version": "3.5.0"}],
+	"packages-dev": [{"name": "phpunit/phpunit", "version": "10.4.2"}]
+}`,
+			Expected: []*Dependency{
+				{Ecosystem: scanner.EcosystemPackagist, Name: "monolog/monolog", Version: "3.5.0"},
+				{Ecosystem: scanner.EcosystemPackagist, Name: "phpunit/phpunit", Version: "10.4.2"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Path, func(t *testing.T) {
+			deps, err := Parse(c.Path, []byte(c.Content))
+			require.NoError(t, err)
+			for _, d := range c.Expected {
+				d.Manifest = c.Path
+			}
+			assert.Equal(t, c.Expected, deps)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Parse("package-lock.json", []byte("{"))
+		assert.Error(t, err)
+
+		_, err = Parse("pom.xml", []byte("<project>"))
+		assert.Error(t, err)
+	})
+}
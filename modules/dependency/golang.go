@@ -0,0 +1,83 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"bytes"
+	"slices"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+
+	"golang.org/x/mod/modfile"
+)
+
+// parseGoMod parses the requirements of a go.mod file, replacements by other module versions are applied
+func parseGoMod(content []byte) ([]*Dependency, error) {
+	f, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		// the lax parser accepts directives of newer Go versions but ignores the replacements
+		if f, err = modfile.ParseLax("go.mod", content, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	deps := make([]*Dependency, 0, len(f.Require))
+	for _, r := range f.Require {
+		name, version := r.Mod.Path, r.Mod.Version
+		for _, rep := range f.Replace {
+			// a replacement by a local directory has no version
+			if rep.Old.Path == name && (rep.Old.Version == "" || rep.Old.Version == version) && rep.New.Version != "" {
+				name, version = rep.New.Path, rep.New.Version
+				break
+			}
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: scanner.EcosystemGo,
+			Name:      name,
+			Version:   version,
+			IsDirect:  !r.Indirect,
+		})
+	}
+	return deps, nil
+}
+
+// readGoModRequirements returns the direct requirements of a go.mod file which are not replaced
+func readGoModRequirements(content []byte) ([]*Requirement, error) {
+	f, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := make(map[string]bool, len(f.Replace))
+	for _, rep := range f.Replace {
+		replaced[rep.Old.Path] = true
+	}
+
+	reqs := make([]*Requirement, 0, len(f.Require))
+	for _, r := range f.Require {
+		if r.Indirect || replaced[r.Mod.Path] {
+			continue
+		}
+		reqs = append(reqs, &Requirement{Name: r.Mod.Path, Version: r.Mod.Version})
+	}
+	return uniqueRequirements(reqs), nil
+}
+
+// updateGoModRequirement changes the version of the require directive in place to keep the formatting of the file
+func updateGoModRequirement(content []byte, name, from, to string) []byte {
+	f, err := modfile.Parse("go.mod", content, nil)
+	if err != nil {
+		return content
+	}
+	for i := len(f.Require) - 1; i >= 0; i-- {
+		r := f.Require[i]
+		if r.Mod.Path != name || r.Mod.Version != from {
+			continue
+		}
+		start, end := r.Syntax.Start.Byte, r.Syntax.End.Byte
+		line := bytes.Replace(content[start:end], []byte(" "+from), []byte(" "+to), 1)
+		content = slices.Concat(content[:start], line, content[end:])
+	}
+	return content
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+
+	"golang.org/x/net/html/charset"
+)
+
+var pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+type pomProject struct {
+	XMLName xml.Name `xml:"project"`
+
+	Parent struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+
+	GroupID string `xml:"groupId"`
+	Version string `xml:"version"`
+
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+}
+
+// parsePom parses the dependencies of a Maven pom.xml file.
+// Properties defined in the file are resolved, versions inherited from a parent pom stay unknown.
+func parsePom(content []byte) ([]*Dependency, error) {
+	var pom pomProject
+
+	dec := xml.NewDecoder(bytes.NewReader(content))
+	dec.CharsetReader = charset.NewReaderLabel
+	if err := dec.Decode(&pom); err != nil {
+		return nil, err
+	}
+
+	properties := map[string]string{
+		"project.groupId":        pom.GroupID,
+		"project.version":        pom.Version,
+		"project.parent.groupId": pom.Parent.GroupID,
+		"project.parent.version": pom.Parent.Version,
+	}
+	if pom.GroupID == "" {
+		properties["project.groupId"] = pom.Parent.GroupID
+	}
+	if pom.Version == "" {
+		properties["project.version"] = pom.Parent.Version
+	}
+	for _, p := range pom.Properties.Entries {
+		properties[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+	resolve := func(s string) string {
+		s = pomPropertyPattern.ReplaceAllStringFunc(strings.TrimSpace(s), func(m string) string {
+			if v, ok := properties[m[2:len(m)-1]]; ok {
+				return v
+			}
+			return m
+		})
+		if strings.Contains(s, "${") {
+			return ""
+		}
+		return s
+	}
+
+	managed := make(map[string]string, len(pom.DependencyManagement))
+	for _, d := range pom.DependencyManagement {
+		managed[resolve(d.GroupID)+":"+resolve(d.ArtifactID)] = resolve(d.Version)
+	}
+
+	deps := make([]*Dependency, 0, len(pom.Dependencies))
+	for _, d := range pom.Dependencies {
+		groupID, artifactID := resolve(d.GroupID), resolve(d.ArtifactID)
+		if groupID == "" || artifactID == "" {
+			continue
+		}
+		name := groupID + ":" + artifactID
+		version := resolve(d.Version)
+		if version == "" {
+			version = managed[name]
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: scanner.EcosystemMaven,
+			Name:      name,
+			Version:   version,
+			IsDirect:  true,
+		})
+	}
+	return deps, nil
+}
+
+var (
+	pomDependencyPattern = regexp.MustCompile(`(?s)<dependency>.*?</dependency>`)
+	pomGroupIDPattern    = regexp.MustCompile(`<groupId>\s*([^<]*?)\s*</groupId>`)
+	pomArtifactIDPattern = regexp.MustCompile(`<artifactId>\s*([^<]*?)\s*</artifactId>`)
+	pomVersionPattern    = regexp.MustCompile(`<version>\s*([^<]*?)\s*</version>`)
+)
+
+// rewritePomDependencies calls fn with the literal versions of the dependencies of a pom.xml file and replaces them with the results,
+// versions defined by properties or as ranges are skipped
+func rewritePomDependencies(content []byte, fn func(name, version string) string) []byte {
+	return pomDependencyPattern.ReplaceAllFunc(content, func(dep []byte) []byte {
+		groupID := pomGroupIDPattern.FindSubmatch(dep)
+		artifactID := pomArtifactIDPattern.FindSubmatch(dep)
+		version := pomVersionPattern.FindSubmatchIndex(dep)
+		if groupID == nil || artifactID == nil || version == nil {
+			return dep
+		}
+		v := string(dep[version[2]:version[3]])
+		if strings.ContainsAny(v, "${[(,") {
+			return dep
+		}
+		return slices.Concat(dep[:version[2]], []byte(fn(string(groupID[1])+":"+string(artifactID[1]), v)), dep[version[3]:])
+	})
+}
+
+// readPomRequirements returns the dependencies of a pom.xml file which declare a literal version
+func readPomRequirements(content []byte) ([]*Requirement, error) {
+	var reqs []*Requirement
+	rewritePomDependencies(content, func(name, version string) string {
+		reqs = append(reqs, &Requirement{Name: name, Version: version})
+		return version
+	})
+	return uniqueRequirements(reqs), nil
+}
+
+func updatePomRequirement(content []byte, name, from, to string) []byte {
+	return rewritePomDependencies(content, func(depName, version string) string {
+		if depName != name || version != from {
+			return version
+		}
+		return to
+	})
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/packages/scanner"
+
+	"gopkg.in/yaml.v3"
+)
+
+type packageLock struct {
+	// lockfileVersion 2 and 3
+	Packages map[string]*packageLockPackage `json:"packages"`
+	// lockfileVersion 1
+	Dependencies map[string]*packageLockDependency `json:"dependencies"`
+}
+
+type packageLockPackage struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+type packageLockDependency struct {
+	Version      string                            `json:"version"`
+	Requires     map[string]string                 `json:"requires"`
+	Dependencies map[string]*packageLockDependency `json:"dependencies"`
+}
+
+// parsePackageLock parses the installed packages of a package-lock.json or npm-shrinkwrap.json file
+func parsePackageLock(content []byte) ([]*Dependency, error) {
+	var lock packageLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	var deps []*Dependency
+	if len(lock.Packages) > 0 {
+		direct := make(map[string]bool)
+		if root, ok := lock.Packages[""]; ok {
+			for _, m := range []map[string]string{root.Dependencies, root.DevDependencies, root.OptionalDependencies} {
+				for name := range m {
+					direct[name] = true
+				}
+			}
+		}
+
+		for key, p := range lock.Packages {
+			idx := strings.LastIndex(key, "node_modules/")
+			if idx == -1 || p.Link || p.Version == "" {
+				// the root package and workspace members
+				continue
+			}
+			name := key[idx+len("node_modules/"):]
+			if p.Name != "" {
+				// an aliased package is installed under another name
+				name = p.Name
+			}
+			deps = append(deps, &Dependency{
+				Ecosystem: scanner.EcosystemNpm,
+				Name:      name,
+				Version:   p.Version,
+				IsDirect:  idx == 0 && direct[key[len("node_modules/"):]],
+			})
+		}
+		return deps, nil
+	}
+
+	var walk func(m map[string]*packageLockDependency, isTopLevel bool)
+	walk = func(m map[string]*packageLockDependency, isTopLevel bool) {
+		for name, d := range m {
+			// local packages are referenced with "file:"
+			if d.Version != "" && !strings.HasPrefix(d.Version, "file:") {
+				deps = append(deps, &Dependency{
+					Ecosystem: scanner.EcosystemNpm,
+					Name:      name,
+					Version:   d.Version,
+					IsDirect:  isTopLevel && !isRequiredByOther(lock.Dependencies, name),
+				})
+			}
+			walk(d.Dependencies, false)
+		}
+	}
+	walk(lock.Dependencies, true)
+	return deps, nil
+}
+
+// isRequiredByOther tests if a top level package of a lockfileVersion 1 file is required by another package.
+// The old format does not store the dependencies of the root package, a package nobody requires must be one of them.
+func isRequiredByOther(m map[string]*packageLockDependency, name string) bool {
+	for other, d := range m {
+		if other == name {
+			continue
+		}
+		if _, ok := d.Requires[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+type pnpmLock struct {
+	LockfileVersion any `yaml:"lockfileVersion"`
+	// lockfileVersion 5
+	Dependencies map[string]any `yaml:"dependencies"`
+	// lockfileVersion 6 and 9
+	Importers map[string]struct {
+		Dependencies         map[string]any `yaml:"dependencies"`
+		DevDependencies      map[string]any `yaml:"devDependencies"`
+		OptionalDependencies map[string]any `yaml:"optionalDependencies"`
+	} `yaml:"importers"`
+	Packages map[string]struct {
+		Name    string `yaml:"name"`
+		Version string `yaml:"version"`
+	} `yaml:"packages"`
+}
+
+// parsePnpmLock parses the installed packages of a pnpm-lock.yaml file
+func parsePnpmLock(content []byte) ([]*Dependency, error) {
+	var lock pnpmLock
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	direct := make(map[string]bool)
+	for name := range lock.Dependencies {
+		direct[name] = true
+	}
+	for _, importer := range lock.Importers {
+		for _, m := range []map[string]any{importer.Dependencies, importer.DevDependencies, importer.OptionalDependencies} {
+			for name := range m {
+				direct[name] = true
+			}
+		}
+	}
+
+	isV5 := strings.HasPrefix(fmt.Sprint(lock.LockfileVersion), "5")
+
+	deps := make([]*Dependency, 0, len(lock.Packages))
+	for key, p := range lock.Packages {
+		name, version := splitPnpmPackageKey(key, isV5)
+		if p.Name != "" {
+			name, version = p.Name, p.Version
+		}
+		if name == "" || version == "" {
+			continue
+		}
+		deps = append(deps, &Dependency{
+			Ecosystem: scanner.EcosystemNpm,
+			Name:      name,
+			Version:   version,
+			IsDirect:  direct[name],
+		})
+	}
+	return deps, nil
+}
+
+// splitPnpmPackageKey splits the key of a package into name and version.
+// The keys look like "/name/1.0.0_peer@1.0.0" (v5), "/name@1.0.0(peer@1.0.0)" (v6) or "name@1.0.0(peer@1.0.0)" (v9).
+func splitPnpmPackageKey(key string, isV5 bool) (name, version string) {
+	key = strings.TrimPrefix(key, "/")
+
+	if isV5 {
+		// the "/" of scoped peers in the suffix is encoded as "+"
+		if idx := strings.LastIndexByte(key, '/'); idx > 0 {
+			name, version = key[:idx], key[idx+1:]
+		}
+		if idx := strings.IndexByte(version, '_'); idx != -1 {
+			version = version[:idx]
+		}
+	} else {
+		if idx := strings.IndexByte(key, '('); idx != -1 {
+			key = key[:idx]
+		}
+		if idx := strings.LastIndexByte(key, '@'); idx > 0 {
+			name, version = key[:idx], key[idx+1:]
+		}
+	}
+	// packages not from the registry have no plain version, e.g. "name@https://codeload.github.com/..."
+	if strings.Contains(version, ":") || strings.Contains(version, "/") {
+		return name, ""
+	}
+	return name, version
+}
+
+// only exact versions and the common ranges of a version are updated, e.g. "1.2.3", "^1.2.3" or "~1.2.3"
+var npmSpecPattern = regexp.MustCompile(`^([~^]|>=|=)?(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?)$`)
+
+type packageJSON struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+}
+
+// readPackageJSONRequirements returns the dependencies of a package.json file
+func readPackageJSONRequirements(content []byte) ([]*Requirement, error) {
+	var pkg packageJSON
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+	return readJSONRequirements(npmSpecPattern, pkg.Dependencies, pkg.DevDependencies, pkg.OptionalDependencies), nil
+}
+
+func updatePackageJSONRequirement(content []byte, name, from, to string) []byte {
+	return updateJSONRequirement(content, npmSpecPattern, name, from, to)
+}
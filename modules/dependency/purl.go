@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"net/url"
+	"strings"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+)
+
+// purl types, see https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst
+var purlTypes = map[string]string{
+	scanner.EcosystemCrates:    "cargo",
+	scanner.EcosystemGo:        "golang",
+	scanner.EcosystemMaven:     "maven",
+	scanner.EcosystemNpm:       "npm",
+	scanner.EcosystemPackagist: "composer",
+	scanner.EcosystemPyPI:      "pypi",
+}
+
+// PackageURL returns the package URL (purl) of the dependency, the version is omitted if it is a requirement
+func (d *Dependency) PackageURL() string {
+	typ, ok := purlTypes[d.Ecosystem]
+	if !ok {
+		return ""
+	}
+
+	var segments []string
+	switch d.Ecosystem {
+	case scanner.EcosystemMaven:
+		segments = strings.SplitN(d.Name, ":", 2)
+	case scanner.EcosystemPyPI:
+		segments = []string{strings.ReplaceAll(strings.ToLower(d.Name), "_", "-")}
+	default:
+		segments = strings.Split(d.Name, "/")
+	}
+	for i, s := range segments {
+		// the "@" of npm scopes has to be encoded
+		segments[i] = strings.ReplaceAll(url.PathEscape(s), "@", "%40")
+	}
+
+	purl := "pkg:" + typ + "/" + strings.Join(segments, "/")
+	if d.IsExactVersion() {
+		purl += "@" + url.PathEscape(d.Version)
+	}
+	return purl
+}
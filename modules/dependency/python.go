@@ -0,0 +1,133 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+)
+
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+
+// parseRequirements parses a pip requirements file, references to other files and local paths are ignored
+func parseRequirements(content []byte) ([]*Dependency, error) {
+	var deps []*Dependency
+
+	var line strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxManifestSize)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if idx := strings.Index(text, "#"); idx != -1 && (idx == 0 || text[idx-1] == ' ' || text[idx-1] == '\t') {
+			text = text[:idx]
+		}
+		if s, ok := strings.CutSuffix(strings.TrimRight(text, " \t"), `\`); ok {
+			line.WriteString(s)
+			line.WriteString(" ")
+			continue
+		}
+		line.WriteString(text)
+
+		if dep := parseRequirement(line.String()); dep != nil {
+			deps = append(deps, dep)
+		}
+		line.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+func parseRequirement(line string) *Dependency {
+	line = strings.TrimSpace(line)
+	// options like "-r other.txt" or "-e ." and per requirement options like "--hash"
+	if line == "" || line[0] == '-' || line[0] == '.' || line[0] == '/' {
+		return nil
+	}
+	if strings.Contains(line, "://") {
+		// only a direct reference like "name @ https://..." has a name
+		name, _, ok := strings.Cut(line, "@")
+		if !ok || strings.Contains(name, ":") {
+			return nil
+		}
+		line = name
+	}
+	if idx := strings.Index(line, " --"); idx != -1 {
+		line = line[:idx]
+	}
+	// environment markers
+	if idx := strings.IndexByte(line, ';'); idx != -1 {
+		line = line[:idx]
+	}
+
+	m := requirementPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return nil
+	}
+
+	version := strings.ReplaceAll(m[2], " ", "")
+	if v, ok := strings.CutPrefix(version, "=="); ok && !strings.Contains(v, ",") && !strings.Contains(v, "*") {
+		version = strings.TrimPrefix(v, "=")
+	}
+
+	return &Dependency{
+		Ecosystem: scanner.EcosystemPyPI,
+		Name:      m[1],
+		Version:   version,
+		IsDirect:  true,
+	}
+}
+
+// parsePoetryLock parses the packages of a poetry.lock file, the lockfile does not tell which ones are direct dependencies
+func parsePoetryLock(content []byte) ([]*Dependency, error) {
+	packages, err := parseTOMLArrayTables(content, "package")
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]*Dependency, 0, len(packages))
+	for _, p := range packages {
+		deps = append(deps, &Dependency{
+			Ecosystem: scanner.EcosystemPyPI,
+			Name:      p.String("name"),
+			Version:   p.String("version"),
+		})
+	}
+	return deps, nil
+}
+
+// readPipRequirements returns the requirements of a pip requirements file which are pinned to a version
+func readPipRequirements(content []byte) ([]*Requirement, error) {
+	deps, err := parseRequirements(content)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]*Requirement, 0, len(deps))
+	for _, d := range deps {
+		if d.IsExactVersion() {
+			reqs = append(reqs, &Requirement{Name: d.Name, Version: d.Version})
+		}
+	}
+	return uniqueRequirements(reqs), nil
+}
+
+// updatePipRequirement changes the pinned version of the requirement, the name is matched like pip normalizes it
+func updatePipRequirement(content []byte, name, from, to string) []byte {
+	parts := strings.Split(scanner.NormalizeName(scanner.EcosystemPyPI, name), "-")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	pattern := regexp.MustCompile(`(?im)^(\s*` + strings.Join(parts, `[-_.]+`) + `\s*(?:\[[^\]]*\])?\s*===?\s*)` + regexp.QuoteMeta(from) + `([\s;#,\\]|$)`)
+	return pattern.ReplaceAllFunc(content, func(line []byte) []byte {
+		m := pattern.FindSubmatch(line)
+		return slices.Concat(m[1], []byte(to), m[2])
+	})
+}
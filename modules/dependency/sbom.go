@@ -0,0 +1,252 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SBOM formats
+const (
+	SBOMFormatCycloneDX = "cyclonedx"
+	SBOMFormatSPDX      = "spdx"
+)
+
+// SBOMSubject is the repository commit a software bill of materials describes
+type SBOMSubject struct {
+	Name string
+	// Version is the commit ID
+	Version string
+	// URL is the link to the commit
+	URL         string
+	Created     time.Time
+	ToolName    string
+	ToolVersion string
+}
+
+type sbomPackage struct {
+	*Dependency
+	PackageURL string
+	Manifests  []string
+}
+
+// uniquePackages merges the dependencies declared in multiple manifests
+func uniquePackages(deps []*Dependency) []*sbomPackage {
+	index := make(map[string]*sbomPackage, len(deps))
+	packages := make([]*sbomPackage, 0, len(deps))
+	for _, d := range deps {
+		key := d.Ecosystem + "/" + d.Name + "@" + d.Version
+		if p, ok := index[key]; ok {
+			p.Manifests = append(p.Manifests, d.Manifest)
+			continue
+		}
+		p := &sbomPackage{Dependency: d, PackageURL: d.PackageURL(), Manifests: []string{d.Manifest}}
+		index[key] = p
+		packages = append(packages, p)
+	}
+	return packages
+}
+
+// CycloneDX 1.5, see https://cyclonedx.org/docs/1.5/json/
+type (
+	CycloneDXBOM struct {
+		BOMFormat    string                 `json:"bomFormat"`
+		SpecVersion  string                 `json:"specVersion"`
+		SerialNumber string                 `json:"serialNumber"`
+		Version      int                    `json:"version"`
+		Metadata     *CycloneDXMetadata     `json:"metadata"`
+		Components   []*CycloneDXComponent  `json:"components"`
+		Dependencies []*CycloneDXDependency `json:"dependencies"`
+	}
+
+	CycloneDXMetadata struct {
+		Timestamp string              `json:"timestamp"`
+		Tools     *CycloneDXTools     `json:"tools"`
+		Component *CycloneDXComponent `json:"component"`
+	}
+
+	CycloneDXTools struct {
+		Components []*CycloneDXComponent `json:"components"`
+	}
+
+	CycloneDXComponent struct {
+		Type               string                        `json:"type"`
+		BOMRef             string                        `json:"bom-ref,omitempty"`
+		Group              string                        `json:"group,omitempty"`
+		Name               string                        `json:"name"`
+		Version            string                        `json:"version,omitempty"`
+		PackageURL         string                        `json:"purl,omitempty"`
+		ExternalReferences []*CycloneDXExternalReference `json:"externalReferences,omitempty"`
+		Properties         []*CycloneDXProperty          `json:"properties,omitempty"`
+	}
+
+	CycloneDXExternalReference struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}
+
+	CycloneDXProperty struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	CycloneDXDependency struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn"`
+	}
+)
+
+// NewCycloneDX creates a CycloneDX document of the dependencies
+func NewCycloneDX(subject *SBOMSubject, deps []*Dependency) *CycloneDXBOM {
+	root := &CycloneDXComponent{
+		Type:    "application",
+		BOMRef:  subject.Name + "@" + subject.Version,
+		Name:    subject.Name,
+		Version: subject.Version,
+		ExternalReferences: []*CycloneDXExternalReference{
+			{Type: "vcs", URL: subject.URL},
+		},
+	}
+
+	packages := uniquePackages(deps)
+	components := make([]*CycloneDXComponent, 0, len(packages))
+	dependsOn := make([]string, 0, len(packages))
+	for i, p := range packages {
+		c := &CycloneDXComponent{
+			Type:       "library",
+			BOMRef:     fmt.Sprintf("%s#%d", p.PackageURL, i),
+			Name:       p.Name,
+			Version:    p.Version,
+			PackageURL: p.PackageURL,
+		}
+		for _, m := range p.Manifests {
+			c.Properties = append(c.Properties, &CycloneDXProperty{Name: "gitea:manifest", Value: m})
+		}
+		components = append(components, c)
+		dependsOn = append(dependsOn, c.BOMRef)
+	}
+
+	return &CycloneDXBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: &CycloneDXMetadata{
+			Timestamp: subject.Created.UTC().Format(time.RFC3339),
+			Tools: &CycloneDXTools{
+				Components: []*CycloneDXComponent{
+					{Type: "application", Name: subject.ToolName, Version: subject.ToolVersion},
+				},
+			},
+			Component: root,
+		},
+		Components: components,
+		Dependencies: []*CycloneDXDependency{
+			{Ref: root.BOMRef, DependsOn: dependsOn},
+		},
+	}
+}
+
+// SPDX 2.3, see https://spdx.github.io/spdx-spec/v2.3/
+type (
+	SPDXDocument struct {
+		SPDXVersion       string              `json:"spdxVersion"`
+		DataLicense       string              `json:"dataLicense"`
+		SPDXID            string              `json:"SPDXID"`
+		Name              string              `json:"name"`
+		DocumentNamespace string              `json:"documentNamespace"`
+		CreationInfo      *SPDXCreationInfo   `json:"creationInfo"`
+		Packages          []*SPDXPackage      `json:"packages"`
+		Relationships     []*SPDXRelationship `json:"relationships"`
+	}
+
+	SPDXCreationInfo struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	}
+
+	SPDXPackage struct {
+		SPDXID           string             `json:"SPDXID"`
+		Name             string             `json:"name"`
+		VersionInfo      string             `json:"versionInfo,omitempty"`
+		DownloadLocation string             `json:"downloadLocation"`
+		FilesAnalyzed    bool               `json:"filesAnalyzed"`
+		ExternalRefs     []*SPDXExternalRef `json:"externalRefs,omitempty"`
+		Comment          string             `json:"comment,omitempty"`
+	}
+
+	SPDXExternalRef struct {
+		ReferenceCategory string `json:"referenceCategory"`
+		ReferenceType     string `json:"referenceType"`
+		ReferenceLocator  string `json:"referenceLocator"`
+	}
+
+	SPDXRelationship struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+	}
+)
+
+// NewSPDX creates a SPDX document of the dependencies
+func NewSPDX(subject *SBOMSubject, deps []*Dependency) *SPDXDocument {
+	const rootID = "SPDXRef-Repository"
+
+	packages := uniquePackages(deps)
+	spdxPackages := make([]*SPDXPackage, 0, len(packages)+1)
+	spdxPackages = append(spdxPackages, &SPDXPackage{
+		SPDXID:           rootID,
+		Name:             subject.Name,
+		VersionInfo:      subject.Version,
+		DownloadLocation: subject.URL,
+		FilesAnalyzed:    false,
+	})
+	relationships := make([]*SPDXRelationship, 0, len(packages)+1)
+	relationships = append(relationships, &SPDXRelationship{
+		SPDXElementID:      "SPDXRef-DOCUMENT",
+		RelationshipType:   "DESCRIBES",
+		RelatedSPDXElement: rootID,
+	})
+
+	for i, p := range packages {
+		sp := &SPDXPackage{
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d", i+1),
+			Name:             p.Name,
+			VersionInfo:      p.Version,
+			DownloadLocation: "NOASSERTION",
+			FilesAnalyzed:    false,
+		}
+		if p.PackageURL != "" {
+			sp.ExternalRefs = []*SPDXExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: p.PackageURL},
+			}
+		}
+		if len(p.Manifests) > 0 {
+			sp.Comment = "Declared in " + p.Manifests[0]
+		}
+		spdxPackages = append(spdxPackages, sp)
+		relationships = append(relationships, &SPDXRelationship{
+			SPDXElementID:      rootID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: sp.SPDXID,
+		})
+	}
+
+	return &SPDXDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              subject.Name + "@" + subject.Version,
+		DocumentNamespace: subject.URL + "/sbom/spdx/" + uuid.NewString(),
+		CreationInfo: &SPDXCreationInfo{
+			Created:  subject.Created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + subject.ToolName + "-" + subject.ToolVersion},
+		},
+		Packages:      spdxPackages,
+		Relationships: relationships,
+	}
+}
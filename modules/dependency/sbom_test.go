@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageURL(t *testing.T) {
+	cases := []struct {
+		Dependency *Dependency
+		Expected   string
+	}{
+		{&Dependency{Ecosystem: scanner.EcosystemNpm, Name: "@types/node", Version: "20.1.0"}, "pkg:npm/%40types/node@20.1.0"},
+		{&Dependency{Ecosystem: scanner.EcosystemNpm, Name: "lodash", Version: "^4.17.0"}, "pkg:npm/lodash"},
+		{&Dependency{Ecosystem: scanner.EcosystemGo, Name: "github.com/google/uuid", Version: "v1.6.0"}, "pkg:golang/github.com/google/uuid@v1.6.0"},
+		{&Dependency{Ecosystem: scanner.EcosystemMaven, Name: "org.slf4j:slf4j-api", Version: "2.0.9"}, "pkg:maven/org.slf4j/slf4j-api@2.0.9"},
+		{&Dependency{Ecosystem: scanner.EcosystemPyPI, Name: "Django_Filter", Version: "23.3"}, "pkg:pypi/django-filter@23.3"},
+		{&Dependency{Ecosystem: scanner.EcosystemCrates, Name: "serde", Version: "1.0.190"}, "pkg:cargo/serde@1.0.190"},
+		{&Dependency{Ecosystem: scanner.EcosystemPackagist, Name: "monolog/monolog", Version: "3.5.0"}, "pkg:composer/monolog/monolog@3.5.0"},
+		{&Dependency{Ecosystem: "unknown", Name: "pkg", Version: "1.0"}, ""},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, c.Dependency.PackageURL())
+	}
+}
+
+func TestSBOM(t *testing.T) {
+	subject := &SBOMSubject{
+		Name:        "user2/repo1",
+		Version:     "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		URL:         "https://gitea.example.com/user2/repo1/commit/65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		Created:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		ToolName:    "Gitea",
+		ToolVersion: "1.26.0",
+	}
+	deps := []*Dependency{
+		{Manifest: "go.mod", Ecosystem: scanner.EcosystemGo, Name: "github.com/google/uuid", Version: "v1.6.0", IsDirect: true},
+		{Manifest: "tools/go.mod", Ecosystem: scanner.EcosystemGo, Name: "github.com/google/uuid", Version: "v1.6.0", IsDirect: true},
+		{Manifest: "web/package-lock.json", Ecosystem: scanner.EcosystemNpm, Name: "lodash", Version: "4.17.20"},
+	}
+
+	t.Run("CycloneDX", func(t *testing.T) {
+		bom := NewCycloneDX(subject, deps)
+		assert.Equal(t, "CycloneDX", bom.BOMFormat)
+		assert.Equal(t, "1.5", bom.SpecVersion)
+		assert.Equal(t, "2026-01-02T03:04:05Z", bom.Metadata.Timestamp)
+		assert.Equal(t, subject.Name, bom.Metadata.Component.Name)
+		require.Len(t, bom.Components, 2)
+		assert.Equal(t, "pkg:golang/github.com/google/uuid@v1.6.0", bom.Components[0].PackageURL)
+		assert.Len(t, bom.Components[0].Properties, 2)
+		assert.Equal(t, "pkg:npm/lodash@4.17.20", bom.Components[1].PackageURL)
+		require.Len(t, bom.Dependencies, 1)
+		assert.Equal(t, bom.Metadata.Component.BOMRef, bom.Dependencies[0].Ref)
+		assert.Equal(t, []string{bom.Components[0].BOMRef, bom.Components[1].BOMRef}, bom.Dependencies[0].DependsOn)
+	})
+
+	t.Run("SPDX", func(t *testing.T) {
+		doc := NewSPDX(subject, deps)
+		assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+		assert.Equal(t, []string{"Tool: Gitea-1.26.0"}, doc.CreationInfo.Creators)
+		require.Len(t, doc.Packages, 3)
+		assert.Equal(t, "SPDXRef-Repository", doc.Packages[0].SPDXID)
+		assert.Equal(t, "lodash", doc.Packages[2].Name)
+		assert.Equal(t, "pkg:npm/lodash@4.17.20", doc.Packages[2].ExternalRefs[0].ReferenceLocator)
+		require.Len(t, doc.Relationships, 3)
+		assert.Equal(t, "DESCRIBES", doc.Relationships[0].RelationshipType)
+		assert.Equal(t, "DEPENDS_ON", doc.Relationships[2].RelationshipType)
+		assert.Equal(t, "SPDXRef-Package-2", doc.Relationships[2].RelatedSPDXElement)
+	})
+}
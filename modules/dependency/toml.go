@@ -0,0 +1,120 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var tomlStringPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+// tomlTable contains the string and string array values of a table, other values are kept as raw text
+type tomlTable struct {
+	values map[string]string
+	arrays map[string][]string
+}
+
+func (t *tomlTable) String(key string) string {
+	return t.values[key]
+}
+
+func (t *tomlTable) Strings(key string) []string {
+	return t.arrays[key]
+}
+
+// parseTOMLArrayTables reads the entries of an array of tables like "[[package]]" from the generated lockfiles.
+// It is no full TOML parser, the lockfiles written by tools only use a small subset.
+func parseTOMLArrayTables(content []byte, name string) ([]*tomlTable, error) {
+	header := "[[" + name + "]]"
+
+	var tables []*tomlTable
+	var current *tomlTable
+	var arrayKey string
+	var arrayValue strings.Builder
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxManifestSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if arrayKey != "" {
+			arrayValue.WriteString(line)
+			if strings.HasSuffix(stripTOMLComment(line), "]") {
+				current.arrays[arrayKey] = parseTOMLStrings(arrayValue.String())
+				arrayKey = ""
+			}
+			continue
+		}
+
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			current = nil
+			if line == header {
+				current = &tomlTable{values: map[string]string{}, arrays: map[string][]string{}}
+				tables = append(tables, current)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, errors.New("invalid key value pair")
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, "["):
+			if strings.HasSuffix(stripTOMLComment(value), "]") {
+				current.arrays[key] = parseTOMLStrings(value)
+			} else {
+				arrayKey = key
+				arrayValue.Reset()
+				arrayValue.WriteString(value)
+			}
+		case strings.HasPrefix(value, `"`):
+			s, err := strconv.Unquote(stripTOMLComment(value))
+			if err != nil {
+				return nil, err
+			}
+			current.values[key] = s
+		default:
+			current.values[key] = stripTOMLComment(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func stripTOMLComment(value string) string {
+	if idx := strings.LastIndex(value, "#"); idx != -1 && !strings.Contains(value[idx:], `"`) {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value)
+}
+
+func parseTOMLStrings(value string) []string {
+	matches := tomlStringPattern.FindAllStringSubmatch(value, -1)
+	result := make([]string, 0, len(matches))
+	for _, m := range matches {
+		s, err := strconv.Unquote(`"` + m[1] + `"`)
+		if err != nil {
+			s = m[1]
+		}
+		result = append(result, s)
+	}
+	return result
+}
@@ -0,0 +1,227 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+)
+
+// Requirement is a dependency whose version is declared in a manifest and can be updated
+type Requirement struct {
+	Name string
+	// Version is the declared version without the range operator
+	Version string
+}
+
+// UpdateEcosystem describes the manifest of a package-ecosystem of the dependency updater config
+type UpdateEcosystem struct {
+	// Name is the package-ecosystem of the config
+	Name string
+	// Ecosystem is the OSV ecosystem of the dependencies
+	Ecosystem string
+	// Manifest is the filename of the manifest which gets updated
+	Manifest string
+	// Lockfile is the filename of the lockfile which needs to be updated together with the manifest, if any
+	Lockfile string
+	// LockfileCommand updates the lockfile
+	LockfileCommand string
+
+	readRequirements  func(content []byte) ([]*Requirement, error)
+	updateRequirement func(content []byte, name, from, to string) []byte
+}
+
+var updateEcosystems = []*UpdateEcosystem{
+	{
+		Name:              "cargo",
+		Ecosystem:         scanner.EcosystemCrates,
+		Manifest:          "Cargo.toml",
+		Lockfile:          "Cargo.lock",
+		LockfileCommand:   "cargo update --workspace",
+		readRequirements:  readCargoRequirements,
+		updateRequirement: updateCargoRequirement,
+	},
+	{
+		Name:              "composer",
+		Ecosystem:         scanner.EcosystemPackagist,
+		Manifest:          "composer.json",
+		Lockfile:          "composer.lock",
+		LockfileCommand:   "composer update --lock",
+		readRequirements:  readComposerRequirements,
+		updateRequirement: updateComposerRequirement,
+	},
+	{
+		Name:              "gomod",
+		Ecosystem:         scanner.EcosystemGo,
+		Manifest:          "go.mod",
+		Lockfile:          "go.sum",
+		LockfileCommand:   "go mod tidy",
+		readRequirements:  readGoModRequirements,
+		updateRequirement: updateGoModRequirement,
+	},
+	{
+		Name:              "maven",
+		Ecosystem:         scanner.EcosystemMaven,
+		Manifest:          "pom.xml",
+		readRequirements:  readPomRequirements,
+		updateRequirement: updatePomRequirement,
+	},
+	{
+		Name:              "npm",
+		Ecosystem:         scanner.EcosystemNpm,
+		Manifest:          "package.json",
+		Lockfile:          "package-lock.json",
+		LockfileCommand:   "npm install --package-lock-only",
+		readRequirements:  readPackageJSONRequirements,
+		updateRequirement: updatePackageJSONRequirement,
+	},
+	{
+		Name:              "pip",
+		Ecosystem:         scanner.EcosystemPyPI,
+		Manifest:          "requirements.txt",
+		readRequirements:  readPipRequirements,
+		updateRequirement: updatePipRequirement,
+	},
+}
+
+// GetUpdateEcosystem returns the package-ecosystem of the dependency updater config with the name or nil if it is not supported
+func GetUpdateEcosystem(name string) *UpdateEcosystem {
+	for _, e := range updateEcosystems {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// ManifestPath returns the path of the manifest in the directory
+func (e *UpdateEcosystem) ManifestPath(directory string) string {
+	return strings.TrimPrefix(path.Join(directory, e.Manifest), "/")
+}
+
+// ReadRequirements returns the dependencies of the manifest whose versions can be updated
+func (e *UpdateEcosystem) ReadRequirements(content []byte) ([]*Requirement, error) {
+	return e.readRequirements(content)
+}
+
+// UpdateRequirement changes the declared version of the dependency, the range operator is kept
+func (e *UpdateEcosystem) UpdateRequirement(content []byte, name, from, to string) []byte {
+	return e.updateRequirement(content, name, from, to)
+}
+
+var (
+	versionNumberPattern = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?`)
+	mavenPrerelease      = regexp.MustCompile(`(?i)(alpha|beta|rc|cr|snapshot|preview|[.-]m\d+$)`)
+	pythonPrerelease     = regexp.MustCompile(`(?i)\d[._-]?(a|b|c|rc|alpha|beta|pre|preview|dev)\d*`)
+)
+
+// IsPrerelease tests if the version is a pre-release in the ecosystem
+func IsPrerelease(ecosystem, version string) bool {
+	switch ecosystem {
+	case scanner.EcosystemMaven:
+		return mavenPrerelease.MatchString(version)
+	case scanner.EcosystemPyPI:
+		return pythonPrerelease.MatchString(version)
+	}
+	version, _, _ = strings.Cut(version, "+")
+	return strings.Contains(version, "-")
+}
+
+// GetUpdateType returns if the update from one version to another is a major, minor or patch update
+func GetUpdateType(from, to string) string {
+	a := versionNumberPattern.FindStringSubmatch(from)
+	b := versionNumberPattern.FindStringSubmatch(to)
+	if a == nil || b == nil {
+		return UpdateTypeMajor
+	}
+	if parseVersionNumber(a[1]) != parseVersionNumber(b[1]) {
+		return UpdateTypeMajor
+	}
+	if parseVersionNumber(a[2]) != parseVersionNumber(b[2]) {
+		return UpdateTypeMinor
+	}
+	return UpdateTypePatch
+}
+
+func parseVersionNumber(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// LatestVersion returns the newest of the versions which is newer than the current version or "" if there is none.
+// Pre-releases are only considered if the current version is one, versions are skipped if accept returns false.
+func LatestVersion(ecosystem, current string, versions []string, accept func(version string) bool) string {
+	allowPrerelease := IsPrerelease(ecosystem, current)
+
+	latest := current
+	for _, v := range versions {
+		if !allowPrerelease && IsPrerelease(ecosystem, v) {
+			continue
+		}
+		if scanner.CompareVersions(ecosystem, v, latest) <= 0 {
+			continue
+		}
+		if accept != nil && !accept(v) {
+			continue
+		}
+		latest = v
+	}
+	if latest == current {
+		return ""
+	}
+	return latest
+}
+
+// uniqueRequirements removes duplicate requirements and sorts them by name
+func uniqueRequirements(reqs []*Requirement) []*Requirement {
+	seen := make(map[Requirement]bool, len(reqs))
+	unique := make([]*Requirement, 0, len(reqs))
+	for _, r := range reqs {
+		if seen[*r] {
+			continue
+		}
+		seen[*r] = true
+		unique = append(unique, r)
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		if unique[i].Name != unique[j].Name {
+			return unique[i].Name < unique[j].Name
+		}
+		return unique[i].Version < unique[j].Version
+	})
+	return unique
+}
+
+// readJSONRequirements returns the requirements of the dependency maps whose specs match the pattern,
+// the pattern has to capture the range operator and the version
+func readJSONRequirements(specPattern *regexp.Regexp, sections ...map[string]string) []*Requirement {
+	var reqs []*Requirement
+	for _, section := range sections {
+		for name, spec := range section {
+			if m := specPattern.FindStringSubmatch(spec); m != nil {
+				reqs = append(reqs, &Requirement{Name: name, Version: m[2]})
+			}
+		}
+	}
+	return uniqueRequirements(reqs)
+}
+
+// updateJSONRequirement changes the version of the specs of the dependency which declare the old version,
+// the text is changed in place to keep the formatting of the file
+func updateJSONRequirement(content []byte, specPattern *regexp.Regexp, name, from, to string) []byte {
+	entryPattern := regexp.MustCompile(`("` + regexp.QuoteMeta(name) + `"\s*:\s*")([^"]*)"`)
+	return entryPattern.ReplaceAllFunc(content, func(entry []byte) []byte {
+		m := entryPattern.FindSubmatch(entry)
+		spec := specPattern.FindStringSubmatch(string(m[2]))
+		if spec == nil || spec[2] != from {
+			return entry
+		}
+		return []byte(string(m[1]) + spec[1] + to + `"`)
+	})
+}
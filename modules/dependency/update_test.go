@@ -0,0 +1,278 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependency
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/packages/scanner"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpdatesConfig(t *testing.T) {
+	cfg, err := ParseUpdatesConfig([]byte(`
+updates:
+  - package-ecosystem: npm
+    directory: web
+    schedule:
+      interval: weekly
+      day: Friday
+    open-pull-requests-limit: 0
+    ignore:
+      - dependency-name: "react*"
+        update-types: [major]
+      - dependency-name: left-pad
+    groups:
+      eslint:
+        patterns: ["eslint*", "@eslint/*"]
+        exclude-patterns: [eslint-plugin-legacy]
+      patches:
+        update-types: [patch]
+  - package-ecosystem: gomod
+`))
+	require.NoError(t, err)
+	require.Len(t, cfg.Updates, 2)
+
+	u := cfg.Updates[0]
+	assert.Equal(t, "/web", u.Directory)
+	assert.Equal(t, 0, u.GetOpenPullRequestsLimit())
+	assert.True(t, u.IsDue(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, u.IsDue(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)))
+
+	assert.True(t, u.IsIgnored("react-dom", UpdateTypeMajor))
+	assert.False(t, u.IsIgnored("react-dom", UpdateTypeMinor))
+	assert.True(t, u.IsIgnored("left-pad", UpdateTypePatch))
+
+	assert.Equal(t, "eslint", u.GetGroup("eslint-plugin-import", UpdateTypeMajor))
+	assert.Equal(t, "eslint", u.GetGroup("@eslint/js", UpdateTypeMinor))
+	assert.Empty(t, u.GetGroup("eslint-plugin-legacy", UpdateTypeMinor))
+	assert.Equal(t, "patches", u.GetGroup("eslint-plugin-legacy", UpdateTypePatch))
+	assert.Empty(t, u.GetGroup("lodash", UpdateTypeMinor))
+
+	u = cfg.Updates[1]
+	assert.Equal(t, "/", u.Directory)
+	assert.Equal(t, IntervalDaily, u.Schedule.Interval)
+	assert.Equal(t, DefaultOpenPullRequestsLimit, u.GetOpenPullRequestsLimit())
+
+	for _, content := range []string{
+		"updates: [{package-ecosystem: bundler}]",
+		"updates: [{package-ecosystem: npm, schedule: {interval: hourly}}]",
+		"updates: [{package-ecosystem: npm, schedule: {interval: weekly, day: someday}}]",
+		"updates: [{package-ecosystem: npm, ignore: [{dependency-name: a, update-types: [build]}]}]",
+		"updates: [{package-ecosystem: npm, groups: {all: {}}}]",
+		"updates: [{package-ecosystem: npm, groups: {all: {patterns: ['[']}}}]",
+		"updates: {}",
+	} {
+		_, err := ParseUpdatesConfig([]byte(content))
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, content)
+	}
+}
+
+func TestUpdateEcosystems(t *testing.T) {
+	cases := []struct {
+		Ecosystem    string
+		Content      string
+		Requirements []*Requirement
+		Name         string
+		From, To     string
+		Expected     string
+	}{
+		{
+			Ecosystem: "npm",
+			Content: `{
+  "name": "web",
+  "dependencies": {
+    "lodash": "^4.17.20",
+    "react": "18.x"
+  },
+  "devDependencies": {"@types/node": "~20.1.0", "local": "file:../local"}
+}`,
+			Requirements: []*Requirement{{Name: "@types/node", Version: "20.1.0"}, {Name: "lodash", Version: "4.17.20"}},
+			Name:         "lodash",
+			From:         "4.17.20",
+			To:           "4.17.21",
+			Expected: `{
+  "name": "web",
+  "dependencies": {
+    "lodash": "^4.17.21",
+    "react": "18.x"
+  },
+  "devDependencies": {"@types/node": "~20.1.0", "local": "file:../local"}
+}`,
+		},
+		{
+			Ecosystem:    "composer",
+			Content:      `{"require": {"php": ">=8.1", "ext-json": "*", "monolog/monolog": "^3.5"}, "require-dev": {"phpunit/phpunit": "10.5.1"}}`,
+			Requirements: []*Requirement{{Name: "monolog/monolog", Version: "3.5"}, {Name: "phpunit/phpunit", Version: "10.5.1"}},
+			Name:         "monolog/monolog",
+			From:         "3.5",
+			To:           "3.6.0",
+			Expected:     `{"require": {"php": ">=8.1", "ext-json": "*", "monolog/monolog": "^3.6.0"}, "require-dev": {"phpunit/phpunit": "10.5.1"}}`,
+		},
+		{
+			Ecosystem: "gomod",
+			Content: `module example.com/app
+
+go 1.22
+
+require github.com/google/uuid v1.5.0
+
+require (
+	golang.org/x/text v0.14.0 // indirect
+	github.com/replaced/mod v1.0.0
+)
+
+replace github.com/replaced/mod => ../mod
+`,
+			Requirements: []*Requirement{{Name: "github.com/google/uuid", Version: "v1.5.0"}},
+			Name:         "github.com/google/uuid",
+			From:         "v1.5.0",
+			To:           "v1.6.0",
+			Expected: `module example.com/app
+
+go 1.22
+
+require github.com/google/uuid v1.6.0
+
+require (
+	golang.org/x/text v0.14.0 // indirect
+	github.com/replaced/mod v1.0.0
+)
+
+replace github.com/replaced/mod => ../mod
+`,
+		},
+		{
+			Ecosystem: "pip",
+			Content: `# comment
+Django==4.2.7 ; python_version >= "3.8"
+django_filter[extras] == 23.3
+requests>=2.31
+`,
+			Requirements: []*Requirement{{Name: "Django", Version: "4.2.7"}, {Name: "django_filter", Version: "23.3"}},
+			Name:         "django-filter",
+			From:         "23.3",
+			To:           "23.5",
+			Expected: `# comment
+Django==4.2.7 ; python_version >= "3.8"
+django_filter[extras] == 23.5
+requests>=2.31
+`,
+		},
+		{
+			Ecosystem: "cargo",
+			Content: `[package]
+name = "app"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+rand = "0.8.5"
+log = { git = "https://github.com/rust-lang/log" }
+renamed = { package = "other", version = "1.0" }
+
+[dev-dependencies.tokio]
+version = "^1.35.0"
+features = ["full"]
+`,
+			Requirements: []*Requirement{{Name: "rand", Version: "0.8.5"}, {Name: "serde", Version: "1.0"}, {Name: "tokio", Version: "1.35.0"}},
+			Name:         "tokio",
+			From:         "1.35.0",
+			To:           "1.36.0",
+			Expected: `[package]
+name = "app"
+version = "0.1.0"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+rand = "0.8.5"
+log = { git = "https://github.com/rust-lang/log" }
+renamed = { package = "other", version = "1.0" }
+
+[dev-dependencies.tokio]
+version = "^1.36.0"
+features = ["full"]
+`,
+		},
+		{
+			Ecosystem: "maven",
+			Content: `<project>
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+      <version>2.0.9</version>
+    </dependency>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>${junit.version}</version>
+    </dependency>
+  </dependencies>
+</project>`,
+			Requirements: []*Requirement{{Name: "org.slf4j:slf4j-api", Version: "2.0.9"}},
+			Name:         "org.slf4j:slf4j-api",
+			From:         "2.0.9",
+			To:           "2.0.12",
+			Expected: `<project>
+  <version>1.0.0</version>
+  <dependencies>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+      <version>2.0.12</version>
+    </dependency>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <version>${junit.version}</version>
+    </dependency>
+  </dependencies>
+</project>`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Ecosystem, func(t *testing.T) {
+			e := GetUpdateEcosystem(c.Ecosystem)
+			require.NotNil(t, e)
+
+			reqs, err := e.ReadRequirements([]byte(c.Content))
+			require.NoError(t, err)
+			assert.Equal(t, c.Requirements, reqs)
+
+			assert.Equal(t, c.Expected, string(e.UpdateRequirement([]byte(c.Content), c.Name, c.From, c.To)))
+			// other versions are not changed
+			assert.Equal(t, c.Content, string(e.UpdateRequirement([]byte(c.Content), c.Name, "0.0.1", c.To)))
+		})
+	}
+
+	assert.Equal(t, "web/package.json", GetUpdateEcosystem("npm").ManifestPath("/web"))
+	assert.Equal(t, "go.mod", GetUpdateEcosystem("gomod").ManifestPath("/"))
+}
+
+func TestLatestVersion(t *testing.T) {
+	versions := []string{"1.2.3", "1.2.10", "1.3.0-rc.1", "1.3.0", "2.0.0", "0.9.0"}
+
+	assert.Equal(t, "2.0.0", LatestVersion(scanner.EcosystemNpm, "1.2.3", versions, nil))
+	assert.Equal(t, "1.3.0", LatestVersion(scanner.EcosystemNpm, "1.2.3", versions, func(v string) bool {
+		return GetUpdateType("1.2.3", v) != UpdateTypeMajor
+	}))
+	assert.Empty(t, LatestVersion(scanner.EcosystemNpm, "2.0.0", versions, nil))
+	assert.Equal(t, "1.3.0-rc.1", LatestVersion(scanner.EcosystemNpm, "1.3.0-beta.1", []string{"1.3.0-rc.1"}, nil))
+
+	assert.Equal(t, "4.2.8", LatestVersion(scanner.EcosystemPyPI, "4.2.7", []string{"4.2.8", "5.0a1", "5.0.dev1"}, nil))
+	assert.Equal(t, "2.0.12", LatestVersion(scanner.EcosystemMaven, "2.0.9", []string{"2.0.12", "2.1.0-alpha1", "3.0.0-SNAPSHOT"}, nil))
+	assert.Equal(t, "v1.6.0", LatestVersion(scanner.EcosystemGo, "v1.5.0", []string{"v1.6.0", "v1.7.0-pre"}, nil))
+
+	assert.Equal(t, UpdateTypeMajor, GetUpdateType("1.2.3", "2.0.0"))
+	assert.Equal(t, UpdateTypeMinor, GetUpdateType("v1.2.3", "v1.3.0"))
+	assert.Equal(t, UpdateTypeMinor, GetUpdateType("1.2", "1.3.0"))
+	assert.Equal(t, UpdateTypePatch, GetUpdateType("1.2", "1.2.1"))
+}
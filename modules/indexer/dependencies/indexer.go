@@ -0,0 +1,131 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependencies
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// Init initializes the repo dependencies indexer
+func Init() error {
+	if err := initDependenciesQueue(); err != nil {
+		return err
+	}
+
+	go populateRepoIndexer(graceful.GetManager().ShutdownContext())
+
+	return nil
+}
+
+// index parses the dependencies of the default branch of a repository
+func index(id int64) error {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().ShutdownContext(), fmt.Sprintf("Dependencies Index Repo[%d]", id))
+	defer finished()
+
+	repo, err := repo_model.GetRepositoryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if repo.IsEmpty {
+		return nil
+	}
+
+	status, err := repo_model.GetIndexerStatus(ctx, repo, repo_model.RepoIndexerTypeDependencies)
+	if err != nil {
+		return err
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		if err.Error() == "no such file or directory" {
+			return nil
+		}
+		return err
+	}
+	defer gitRepo.Close()
+
+	commitID, err := gitRepo.GetBranchCommitID(repo.DefaultBranch)
+	if err != nil {
+		if git.IsErrBranchNotExist(err) || git.IsErrNotExist(err) || setting.IsInTesting {
+			log.Debug("Unable to get commit ID for default branch %s in %s ... skipping this repository", repo.DefaultBranch, repo.FullName())
+			return nil
+		}
+		return err
+	}
+
+	// Do not parse the dependencies again if already done for this commit
+	if status.CommitSha == commitID {
+		return nil
+	}
+
+	commit, err := gitRepo.GetCommit(commitID)
+	if err != nil {
+		return err
+	}
+	deps, err := dependency.GetCommitDependencies(commit)
+	if err != nil {
+		return err
+	}
+	if err := repo_model.UpdateRepoDependencies(ctx, repo, commitID, deps); err != nil {
+		return err
+	}
+
+	log.Debug("Dependencies indexer completed for ID %s for default branch %s in %s. dependency count: %d", commitID, repo.DefaultBranch, repo.FullName(), len(deps))
+	return nil
+}
+
+// populateRepoIndexer populates the indexer with the existing repositories
+func populateRepoIndexer(ctx context.Context) {
+	exist, err := db.IsTableNotEmpty("repository")
+	if err != nil {
+		log.Error("System error: %v", err)
+		return
+	} else if !exist {
+		return
+	}
+
+	var maxRepoID int64
+	if maxRepoID, err = db.GetMaxID("repository"); err != nil {
+		log.Error("System error: %v", err)
+		return
+	}
+
+	log.Info("Populating the repo dependencies indexer with existing repositories")
+
+	// start with the maximum existing repo ID and work backwards, the repositories
+	// created after gitea starts are added by the notifier
+	for maxRepoID > 0 {
+		ids, err := repo_model.GetUnindexedRepos(ctx, repo_model.RepoIndexerTypeDependencies, maxRepoID, 0, 50)
+		if err != nil {
+			log.Error("populateRepoIndexer: %v", err)
+			return
+		} else if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				log.Info("Repository Dependencies Indexer population shutdown before completion")
+				return
+			default:
+			}
+			if err := dependenciesQueue.Push(id); err != nil {
+				log.Error("dependenciesQueue.Push: %v", err)
+			}
+			maxRepoID = id - 1
+		}
+	}
+	log.Info("Done (re)populating the repo dependencies indexer with existing repositories")
+}
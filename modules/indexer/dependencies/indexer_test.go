@@ -0,0 +1,48 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependencies
+
+import (
+	"testing"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+
+	_ "code.gitea.io/gitea/models"
+	_ "code.gitea.io/gitea/models/actions"
+	_ "code.gitea.io/gitea/models/activities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
+
+func TestRepoDependenciesIndex(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	setting.CfgProvider, _ = setting.NewConfigProviderFromData("")
+
+	setting.LoadQueueSettings()
+
+	assert.NoError(t, Init())
+
+	repo, err := repo_model.GetRepositoryByID(t.Context(), 1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, UpdateRepoIndexer(repo))
+
+	assert.NoError(t, queue.GetManager().FlushAll(t.Context(), 5*time.Second))
+
+	status, err := repo_model.GetIndexerStatus(t.Context(), repo, repo_model.RepoIndexerTypeDependencies)
+	assert.NoError(t, err)
+	assert.Equal(t, "65f1bf27bc3bf70f64657658635e66094edbcb4d", status.CommitSha)
+
+	deps, err := repo_model.GetDependencyEcosystems(t.Context(), repo.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, deps)
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependencies
+
+import (
+	"errors"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// dependenciesQueue represents a queue to handle repository dependencies updates
+var dependenciesQueue *queue.WorkerPoolQueue[int64]
+
+func handler(items ...int64) []int64 {
+	for _, id := range items {
+		if err := index(id); err != nil {
+			if !setting.IsInTesting {
+				log.Error("dependencies queue index(%d) failed: %v", id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func initDependenciesQueue() error {
+	dependenciesQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "repo_dependencies_update", handler)
+	if dependenciesQueue == nil {
+		return errors.New("unable to create repo_dependencies_update queue")
+	}
+	go graceful.GetManager().RunWithCancel(dependenciesQueue)
+	return nil
+}
+
+// UpdateRepoIndexer queues the update of the dependencies of a repository
+func UpdateRepoIndexer(repo *repo_model.Repository) error {
+	if err := dependenciesQueue.Push(repo.ID); err != nil {
+		if err != queue.ErrAlreadyInQueue {
+			return err
+		}
+		log.Debug("Repo ID: %d already queued", repo.ID)
+	}
+	return nil
+}
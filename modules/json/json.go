@@ -31,6 +31,9 @@ type Interface interface {
 
 var DefaultJSONHandler = getDefaultJSONHandler()
 
+// RawMessage is a raw encoded JSON value, it can be used to delay decoding
+type RawMessage = json.RawMessage
+
 // Marshal converts object as bytes
 func Marshal(v any) ([]byte, error) {
 	return DefaultJSONHandler.Marshal(v)
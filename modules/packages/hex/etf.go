@@ -0,0 +1,126 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ContentTypeErlang is the media type of the Hex API for values in the Erlang external term format
+const ContentTypeErlang = "application/vnd.hex+erlang"
+
+// External term format tags
+// https://www.erlang.org/doc/apps/erts/erl_ext_dist.html
+const (
+	etfVersion         = 131
+	etfSmallIntegerExt = 97
+	etfIntegerExt      = 98
+	etfNilExt          = 106
+	etfListExt         = 108
+	etfBinaryExt       = 109
+	etfSmallBigExt     = 110
+	etfMapExt          = 116
+	etfSmallAtomUTF8   = 119
+)
+
+// EncodeTerm encodes a value in the Erlang external term format like term_to_binary/1.
+// Strings are encoded as binaries, bool and nil as atoms.
+func EncodeTerm(v any) ([]byte, error) {
+	return appendTerm([]byte{etfVersion}, v)
+}
+
+func appendTerm(b []byte, v any) ([]byte, error) {
+	var err error
+	switch t := v.(type) {
+	case nil:
+		b = appendAtom(b, "nil")
+	case bool:
+		if t {
+			b = appendAtom(b, "true")
+		} else {
+			b = appendAtom(b, "false")
+		}
+	case Atom:
+		b = appendAtom(b, string(t))
+	case string:
+		b = append(b, etfBinaryExt)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		b = append(b, t...)
+	case int:
+		b = appendInteger(b, int64(t))
+	case int64:
+		b = appendInteger(b, t)
+	case []string:
+		items := make([]any, 0, len(t))
+		for _, s := range t {
+			items = append(items, s)
+		}
+		return appendTerm(b, items)
+	case []any:
+		if len(t) == 0 {
+			return append(b, etfNilExt), nil
+		}
+		b = append(b, etfListExt)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		for _, item := range t {
+			if b, err = appendTerm(b, item); err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, etfNilExt)
+	case map[string]string:
+		m := make(map[string]any, len(t))
+		for k, v := range t {
+			m[k] = v
+		}
+		return appendTerm(b, m)
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		b = append(b, etfMapExt)
+		b = binary.BigEndian.AppendUint32(b, uint32(len(t)))
+		for _, k := range keys {
+			if b, err = appendTerm(b, k); err != nil {
+				return nil, err
+			}
+			if b, err = appendTerm(b, t[k]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported term type %T", v)
+	}
+	return b, nil
+}
+
+func appendAtom(b []byte, atom string) []byte {
+	b = append(b, etfSmallAtomUTF8, byte(len(atom)))
+	return append(b, atom...)
+}
+
+func appendInteger(b []byte, i int64) []byte {
+	switch {
+	case i >= 0 && i <= math.MaxUint8:
+		return append(b, etfSmallIntegerExt, byte(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		b = append(b, etfIntegerExt)
+		return binary.BigEndian.AppendUint32(b, uint32(int32(i)))
+	default:
+		sign := byte(0)
+		u := uint64(i)
+		if i < 0 {
+			sign = 1
+			u = uint64(-i)
+		}
+		b = append(b, etfSmallBigExt, 8, sign)
+		return binary.LittleEndian.AppendUint64(b, u)
+	}
+}
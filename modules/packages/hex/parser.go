@@ -0,0 +1,263 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	SettingKeyPrivate = "hex.key.private"
+	SettingKeyPublic  = "hex.key.public"
+
+	// TarballVersion is the only version of the package tarball format supported by the current clients
+	TarballVersion = "3"
+
+	maxMetadataSize = 1 << 20
+)
+
+var (
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrInvalidTarball       = util.NewInvalidArgumentErrorf("package tarball is invalid")
+	ErrInvalidChecksum      = util.NewInvalidArgumentErrorf("package checksum does not match the content")
+	ErrUnsupportedTarball   = util.NewInvalidArgumentErrorf("package tarball version is not supported")
+	ErrMissingMetadataField = util.NewInvalidArgumentErrorf("package metadata is incomplete")
+)
+
+// Package represents a Hex package
+type Package struct {
+	Name     string
+	Version  string
+	Metadata *Metadata
+}
+
+// Metadata represents the metadata of a Hex package
+type Metadata struct {
+	App               string            `json:"app,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	Licenses          []string          `json:"licenses,omitempty"`
+	Links             map[string]string `json:"links,omitempty"`
+	BuildTools        []string          `json:"build_tools,omitempty"`
+	ElixirRequirement string            `json:"elixir_requirement,omitempty"`
+	Requirements      []*Dependency     `json:"requirements,omitempty"`
+	InnerChecksum     string            `json:"inner_checksum"`
+}
+
+// Dependency represents a requirement of a Hex package
+type Dependency struct {
+	Name        string `json:"name"`
+	App         string `json:"app,omitempty"`
+	Requirement string `json:"requirement"`
+	Optional    bool   `json:"optional,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+}
+
+var (
+	nameMatch = regexp.MustCompile(`\A[a-z][a-z0-9_]{0,127}\z`)
+	// Hex requires versions with exactly three components
+	versionMatch = regexp.MustCompile(`\A\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?\z`)
+)
+
+// ParsePackage parses the outer tarball of a Hex package
+// https://github.com/hexpm/specifications/blob/main/package_tarball.md
+func ParsePackage(r io.Reader) (*Package, error) {
+	var versionContent, checksumContent, metadataContent []byte
+	var innerChecksum []byte
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidTarball
+		}
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch hd.Name {
+		case "VERSION":
+			versionContent, err = io.ReadAll(io.LimitReader(tr, 16))
+		case "CHECKSUM":
+			checksumContent, err = io.ReadAll(io.LimitReader(tr, 128))
+		case "metadata.config":
+			metadataContent, err = io.ReadAll(io.LimitReader(tr, maxMetadataSize))
+		case "contents.tar.gz":
+			// the inner checksum covers the concatenated content of the VERSION, metadata.config and contents.tar.gz files
+			if versionContent == nil || metadataContent == nil {
+				return nil, ErrInvalidTarball
+			}
+			h := sha256.New()
+			h.Write(versionContent)
+			h.Write(metadataContent)
+			if _, err = io.Copy(h, tr); err == nil {
+				innerChecksum = h.Sum(nil)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if innerChecksum == nil {
+		return nil, ErrInvalidTarball
+	}
+	if string(versionContent) != TarballVersion {
+		return nil, ErrUnsupportedTarball
+	}
+	if checksumContent != nil && !strings.EqualFold(strings.TrimSpace(string(checksumContent)), hex.EncodeToString(innerChecksum)) {
+		return nil, ErrInvalidChecksum
+	}
+
+	p, err := parseMetadata(metadataContent)
+	if err != nil {
+		return nil, err
+	}
+	p.Metadata.InnerChecksum = hex.EncodeToString(innerChecksum)
+
+	return p, nil
+}
+
+func parseMetadata(data []byte) (*Package, error) {
+	terms, err := parseTerms(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]any, len(terms))
+	for _, term := range terms {
+		if key, value, ok := keyValue(term); ok {
+			fields[key] = value
+		}
+	}
+
+	name, _ := fields["name"].(string)
+	if !nameMatch.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+
+	v, _ := fields["version"].(string)
+	if _, err := version.NewSemver(v); err != nil || !versionMatch.MatchString(v) {
+		return nil, ErrInvalidVersion
+	}
+
+	m := &Metadata{
+		App:               stringValue(fields["app"]),
+		Description:       stringValue(fields["description"]),
+		Licenses:          stringList(fields["licenses"]),
+		BuildTools:        stringList(fields["build_tools"]),
+		ElixirRequirement: stringValue(fields["elixir"]),
+	}
+
+	if links, ok := fields["links"].([]any); ok {
+		m.Links = make(map[string]string, len(links))
+		for _, link := range links {
+			if name, value, ok := keyValue(link); ok {
+				if url := stringValue(value); validation.IsValidURL(url) {
+					m.Links[name] = url
+				}
+			}
+		}
+	}
+
+	if requirements, ok := fields["requirements"].([]any); ok {
+		for _, requirement := range requirements {
+			dep, err := parseDependency(requirement)
+			if err != nil {
+				return nil, err
+			}
+			m.Requirements = append(m.Requirements, dep)
+		}
+	}
+
+	return &Package{
+		Name:     name,
+		Version:  v,
+		Metadata: m,
+	}, nil
+}
+
+// parseDependency parses a requirement in the current list format [{<<"name">>, ...}, ...]
+// or in the legacy format {<<"name">>, [{<<"app">>, ...}, ...]}
+func parseDependency(term any) (*Dependency, error) {
+	var name string
+	var properties []any
+	switch t := term.(type) {
+	case []any:
+		properties = t
+	case Tuple:
+		var value any
+		var ok bool
+		if name, value, ok = keyValue(t); !ok {
+			return nil, ErrMissingMetadataField
+		}
+		properties, _ = value.([]any)
+	}
+
+	dep := &Dependency{Name: name}
+	for _, property := range properties {
+		key, value, ok := keyValue(property)
+		if !ok {
+			continue
+		}
+		switch key {
+		case "name":
+			dep.Name = stringValue(value)
+		case "app":
+			dep.App = stringValue(value)
+		case "requirement":
+			dep.Requirement = stringValue(value)
+		case "optional":
+			dep.Optional = value == Atom("true")
+		case "repository":
+			dep.Repository = stringValue(value)
+		}
+	}
+
+	if !nameMatch.MatchString(dep.Name) || dep.Requirement == "" {
+		return nil, ErrMissingMetadataField
+	}
+	return dep, nil
+}
+
+func keyValue(term any) (string, any, bool) {
+	t, ok := term.(Tuple)
+	if !ok || len(t) != 2 {
+		return "", nil, false
+	}
+	key, ok := t[0].(string)
+	return key, t[1], ok
+}
+
+func stringValue(term any) string {
+	s, _ := term.(string)
+	return s
+}
+
+func stringList(term any) []string {
+	list, ok := term.([]any)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
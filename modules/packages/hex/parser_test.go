@@ -0,0 +1,154 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `{<<"name">>,<<"gitea_test">>}.
+{<<"version">>,<<"1.0.2">>}.
+{<<"app">>,<<"gitea_test">>}.
+{<<"description">>,<<"Package "
+                     "Description \"quoted\"">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"https://gitea.io/">>},{<<"Invalid">>,<<"no-url">>}]}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"elixir">>,<<"~> 1.15">>}.
+{<<"files">>,[<<"lib">>,<<"lib/gitea_test.ex">>,<<"mix.exs">>]}.
+{<<"requirements">>,
+ [[{<<"name">>,<<"jason">>},
+   {<<"app">>,<<"jason">>},
+   {<<"optional">>,true},
+   {<<"requirement">>,<<"~> 1.4">>},
+   {<<"repository">>,<<"hexpm">>}]]}.
+`
+
+func createArchive(files map[string][]byte, order []string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range order {
+		tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0o600,
+			Size: int64(len(files[name])),
+		})
+		tw.Write(files[name])
+	}
+	tw.Close()
+	return buf.Bytes()
+}
+
+func createPackage(metadata string, checksum func(string) string) []byte {
+	contents := []byte("contents")
+
+	h := sha256.New()
+	h.Write([]byte(TarballVersion))
+	h.Write([]byte(metadata))
+	h.Write(contents)
+	innerChecksum := strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
+
+	return createArchive(map[string][]byte{
+		"VERSION":         []byte(TarballVersion),
+		"CHECKSUM":        []byte(checksum(innerChecksum)),
+		"metadata.config": []byte(metadata),
+		"contents.tar.gz": contents,
+	}, []string{"VERSION", "CHECKSUM", "metadata.config", "contents.tar.gz"})
+}
+
+func TestParsePackage(t *testing.T) {
+	keepChecksum := func(s string) string { return s }
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParsePackage(bytes.NewReader(createPackage(testMetadata, keepChecksum)))
+		require.NoError(t, err)
+		assert.Equal(t, "gitea_test", p.Name)
+		assert.Equal(t, "1.0.2", p.Version)
+		assert.Equal(t, "gitea_test", p.Metadata.App)
+		assert.Equal(t, `Package Description "quoted"`, p.Metadata.Description)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, map[string]string{"GitHub": "https://gitea.io/"}, p.Metadata.Links)
+		assert.Equal(t, []string{"mix"}, p.Metadata.BuildTools)
+		assert.Equal(t, "~> 1.15", p.Metadata.ElixirRequirement)
+		assert.Equal(t, []*Dependency{{Name: "jason", App: "jason", Requirement: "~> 1.4", Optional: true, Repository: "hexpm"}}, p.Metadata.Requirements)
+		assert.Len(t, p.Metadata.InnerChecksum, 64)
+	})
+
+	t.Run("LegacyRequirements", func(t *testing.T) {
+		metadata := `{<<"name">>,<<"gitea_test">>}.
+{<<"version">>,<<"1.0.2">>}.
+{<<"requirements">>,[{<<"jason">>,[{<<"app">>,<<"jason">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.4">>}]}]}.
+`
+		p, err := ParsePackage(bytes.NewReader(createPackage(metadata, keepChecksum)))
+		require.NoError(t, err)
+		assert.Equal(t, []*Dependency{{Name: "jason", App: "jason", Requirement: "~> 1.4"}}, p.Metadata.Requirements)
+	})
+
+	t.Run("InvalidChecksum", func(t *testing.T) {
+		_, err := ParsePackage(bytes.NewReader(createPackage(testMetadata, func(string) string { return strings.Repeat("0", 64) })))
+		assert.ErrorIs(t, err, ErrInvalidChecksum)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		metadata := strings.Replace(testMetadata, "gitea_test", "Gitea-Test", 1)
+		_, err := ParsePackage(bytes.NewReader(createPackage(metadata, keepChecksum)))
+		assert.ErrorIs(t, err, ErrInvalidName)
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		metadata := strings.Replace(testMetadata, "1.0.2", "1.0.2.3", 1)
+		_, err := ParsePackage(bytes.NewReader(createPackage(metadata, keepChecksum)))
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("MissingContents", func(t *testing.T) {
+		data := createArchive(map[string][]byte{
+			"VERSION":         []byte(TarballVersion),
+			"metadata.config": []byte(testMetadata),
+		}, []string{"VERSION", "metadata.config"})
+		_, err := ParsePackage(bytes.NewReader(data))
+		assert.ErrorIs(t, err, ErrInvalidTarball)
+	})
+}
+
+func TestParseTerms(t *testing.T) {
+	terms, err := parseTerms([]byte(`% comment
+{<<"a">>, [1, -2, 3.5, atom, 'quoted atom', "str\n", <<>>, <<"ü"/utf8>>, {}]}.
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		Tuple{"a", []any{int64(1), int64(-2), 3.5, Atom("atom"), Atom("quoted atom"), "str\n", "", "ü", Tuple{}}},
+	}, terms)
+
+	for _, invalid := range []string{
+		`{<<"a">>, 1}`,
+		`{<<"a">>, 1.`,
+		`<<"a>>.`,
+		`#{a => 1}.`,
+	} {
+		_, err := parseTerms([]byte(invalid))
+		assert.Error(t, err, "%s", invalid)
+	}
+}
+
+func TestEncodeTerm(t *testing.T) {
+	b, err := EncodeTerm(map[string]any{
+		"a": "b",
+		"c": []any{1, 300, true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		131, 116, 0, 0, 0, 2,
+		109, 0, 0, 0, 1, 'a', 109, 0, 0, 0, 1, 'b',
+		109, 0, 0, 0, 1, 'c', 108, 0, 0, 0, 3, 97, 1, 98, 0, 0, 1, 44, 119, 4, 't', 'r', 'u', 'e', 106,
+	}, b)
+}
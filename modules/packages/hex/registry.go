@@ -0,0 +1,147 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The registry resources are gzipped protobuf messages wrapped in a signed envelope
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+
+// VersionsPackage is an entry of the versions resource
+type VersionsPackage struct {
+	Name     string
+	Versions []string
+}
+
+// Release is an entry of the package resource
+type Release struct {
+	Version       string
+	InnerChecksum []byte
+	OuterChecksum []byte
+	Dependencies  []*Dependency
+}
+
+// EncodeNames encodes the names resource listing all packages of the repository
+func EncodeNames(repository string, names []string) []byte {
+	var b []byte
+	for _, name := range names {
+		var p []byte
+		p = protowire.AppendTag(p, 1, protowire.BytesType)
+		p = protowire.AppendString(p, name)
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, p)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+// EncodeVersions encodes the versions resource listing the versions of all packages of the repository
+func EncodeVersions(repository string, packages []*VersionsPackage) []byte {
+	var b []byte
+	for _, pkg := range packages {
+		var p []byte
+		p = protowire.AppendTag(p, 1, protowire.BytesType)
+		p = protowire.AppendString(p, pkg.Name)
+		for _, v := range pkg.Versions {
+			p = protowire.AppendTag(p, 2, protowire.BytesType)
+			p = protowire.AppendString(p, v)
+		}
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, p)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+// EncodePackage encodes the package resource listing the releases of a package
+func EncodePackage(repository, name string, releases []*Release) []byte {
+	var b []byte
+	for _, release := range releases {
+		var r []byte
+		r = protowire.AppendTag(r, 1, protowire.BytesType)
+		r = protowire.AppendString(r, release.Version)
+		r = protowire.AppendTag(r, 2, protowire.BytesType)
+		r = protowire.AppendBytes(r, release.InnerChecksum)
+		for _, dep := range release.Dependencies {
+			var d []byte
+			d = protowire.AppendTag(d, 1, protowire.BytesType)
+			d = protowire.AppendString(d, dep.Name)
+			d = protowire.AppendTag(d, 2, protowire.BytesType)
+			d = protowire.AppendString(d, dep.Requirement)
+			if dep.Optional {
+				d = protowire.AppendTag(d, 3, protowire.VarintType)
+				d = protowire.AppendVarint(d, 1)
+			}
+			if dep.App != "" && dep.App != dep.Name {
+				d = protowire.AppendTag(d, 4, protowire.BytesType)
+				d = protowire.AppendString(d, dep.App)
+			}
+			if dep.Repository != "" {
+				d = protowire.AppendTag(d, 5, protowire.BytesType)
+				d = protowire.AppendString(d, dep.Repository)
+			}
+
+			r = protowire.AppendTag(r, 3, protowire.BytesType)
+			r = protowire.AppendBytes(r, d)
+		}
+		r = protowire.AppendTag(r, 5, protowire.BytesType)
+		r = protowire.AppendBytes(r, release.OuterChecksum)
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, r)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendString(b, name)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+// SignAndCompress wraps the payload in a signed message and compresses it.
+// The signature is a RSA PKCS #1 v1.5 signature of the SHA-512 hash of the payload.
+func SignAndCompress(privateKeyPem string, payload []byte) ([]byte, error) {
+	block, _ := pem.Decode([]byte(privateKeyPem))
+	if block == nil {
+		return nil, errors.New("failed to decode private key pem")
+	}
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha512.Sum512(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA512, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var signed []byte
+	signed = protowire.AppendTag(signed, 1, protowire.BytesType)
+	signed = protowire.AppendBytes(signed, payload)
+	signed = protowire.AppendTag(signed, 2, protowire.BytesType)
+	signed = protowire.AppendBytes(signed, signature)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(signed); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// readFields returns the values of a protobuf message grouped by field number
+func readFields(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	fields := make(map[protowire.Number][][]byte)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		var value []byte
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(b)
+			value = protowire.AppendVarint(nil, v)
+		default:
+			t.Fatalf("unexpected wire type %v", typ)
+		}
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+
+		fields[num] = append(fields[num], value)
+	}
+	return fields
+}
+
+func TestEncodePackage(t *testing.T) {
+	b := EncodePackage("repo", "pkg", []*Release{
+		{
+			Version:       "1.0.0",
+			InnerChecksum: []byte{1},
+			OuterChecksum: []byte{2},
+			Dependencies: []*Dependency{
+				{Name: "dep", App: "dep", Requirement: "~> 1.0", Optional: true, Repository: "hexpm"},
+			},
+		},
+	})
+
+	pkg := readFields(t, b)
+	assert.Equal(t, [][]byte{[]byte("pkg")}, pkg[2])
+	assert.Equal(t, [][]byte{[]byte("repo")}, pkg[3])
+	require.Len(t, pkg[1], 1)
+
+	release := readFields(t, pkg[1][0])
+	assert.Equal(t, [][]byte{[]byte("1.0.0")}, release[1])
+	assert.Equal(t, [][]byte{{1}}, release[2])
+	assert.Equal(t, [][]byte{{2}}, release[5])
+	require.Len(t, release[3], 1)
+
+	dep := readFields(t, release[3][0])
+	assert.Equal(t, [][]byte{[]byte("dep")}, dep[1])
+	assert.Equal(t, [][]byte{[]byte("~> 1.0")}, dep[2])
+	assert.Equal(t, [][]byte{{1}}, dep[3])
+	assert.Empty(t, dep[4])
+	assert.Equal(t, [][]byte{[]byte("hexpm")}, dep[5])
+}
+
+func TestEncodeVersions(t *testing.T) {
+	b := EncodeVersions("repo", []*VersionsPackage{{Name: "pkg", Versions: []string{"1.0.0", "1.1.0"}}})
+
+	versions := readFields(t, b)
+	assert.Equal(t, [][]byte{[]byte("repo")}, versions[2])
+	require.Len(t, versions[1], 1)
+
+	pkg := readFields(t, versions[1][0])
+	assert.Equal(t, [][]byte{[]byte("pkg")}, pkg[1])
+	assert.Equal(t, [][]byte{[]byte("1.0.0"), []byte("1.1.0")}, pkg[2])
+}
+
+func TestSignAndCompress(t *testing.T) {
+	priv, pub, err := util.GenerateKeyPair(1024)
+	require.NoError(t, err)
+
+	payload := EncodeNames("repo", []string{"a", "b"})
+
+	b, err := SignAndCompress(priv, payload)
+	require.NoError(t, err)
+
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	require.NoError(t, err)
+	signed, err := io.ReadAll(zr)
+	require.NoError(t, err)
+
+	fields := readFields(t, signed)
+	require.Len(t, fields[1], 1)
+	require.Len(t, fields[2], 1)
+	assert.Equal(t, payload, fields[1][0])
+
+	block, _ := pem.Decode([]byte(pub))
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+
+	hash := sha512.Sum512(payload)
+	assert.NoError(t, rsa.VerifyPKCS1v15(publicKey.(*rsa.PublicKey), crypto.SHA512, hash[:], fields[2][0]))
+}
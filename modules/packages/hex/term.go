@@ -0,0 +1,291 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Atom is an Erlang atom
+type Atom string
+
+// Tuple is an Erlang tuple
+type Tuple []any
+
+// parseTerms parses the Erlang terms of a file in the format read by file:consult/1.
+// Binaries and strings are returned as string, lists as []any and numbers as int64 or float64.
+// Only the subset of the syntax written by the Hex clients is supported.
+func parseTerms(data []byte) ([]any, error) {
+	p := &termParser{data: data}
+
+	var terms []any
+	for {
+		p.skipSpace()
+		if p.eof() {
+			return terms, nil
+		}
+
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		if !p.consume('.') {
+			return nil, p.error("expected '.'")
+		}
+
+		terms = append(terms, term)
+	}
+}
+
+type termParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *termParser) error(msg string) error {
+	return util.NewInvalidArgumentErrorf("invalid metadata: %s at offset %d", msg, p.pos)
+}
+
+func (p *termParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *termParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+func (p *termParser) consume(c byte) bool {
+	if p.peek() == c && !p.eof() {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *termParser) hasPrefix(s string) bool {
+	return strings.HasPrefix(string(p.data[p.pos:]), s)
+}
+
+func (p *termParser) skipSpace() {
+	for !p.eof() {
+		switch c := p.peek(); {
+		case c == '%':
+			for !p.eof() && p.peek() != '\n' {
+				p.pos++
+			}
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *termParser) parseTerm() (any, error) {
+	p.skipSpace()
+
+	switch c := p.peek(); {
+	case c == '{':
+		p.pos++
+		elements, err := p.parseSequence('}')
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(elements), nil
+	case c == '[':
+		p.pos++
+		elements, err := p.parseSequence(']')
+		if err != nil {
+			return nil, err
+		}
+		return elements, nil
+	case c == '<' && p.hasPrefix("<<"):
+		return p.parseBinary()
+	case c == '"':
+		return p.parseStrings()
+	case c == '\'':
+		s, err := p.parseQuoted('\'')
+		if err != nil {
+			return nil, err
+		}
+		return Atom(s), nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for !p.eof() && isAtomChar(p.peek()) {
+			p.pos++
+		}
+		return Atom(p.data[start:p.pos]), nil
+	default:
+		return nil, p.error("unexpected character")
+	}
+}
+
+func isAtomChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '@'
+}
+
+func (p *termParser) parseSequence(end byte) ([]any, error) {
+	elements := make([]any, 0)
+
+	p.skipSpace()
+	if p.consume(end) {
+		return elements, nil
+	}
+
+	for {
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, term)
+
+		p.skipSpace()
+		if p.consume(end) {
+			return elements, nil
+		}
+		if !p.consume(',') {
+			return nil, p.error("expected ','")
+		}
+	}
+}
+
+// parseBinary parses binaries like <<"text">> or <<"text"/utf8>>
+func (p *termParser) parseBinary() (any, error) {
+	p.pos += 2
+
+	p.skipSpace()
+	if p.hasPrefix(">>") {
+		p.pos += 2
+		return "", nil
+	}
+
+	s, err := p.parseStrings()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.hasPrefix("/utf8") {
+		p.pos += 5
+		p.skipSpace()
+	}
+	if !p.hasPrefix(">>") {
+		return nil, p.error("expected '>>'")
+	}
+	p.pos += 2
+
+	return s, nil
+}
+
+// parseStrings parses adjacent string literals which are concatenated by Erlang
+func (p *termParser) parseStrings() (string, error) {
+	var sb strings.Builder
+	for {
+		s, err := p.parseQuoted('"')
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(s)
+
+		p.skipSpace()
+		if p.peek() != '"' {
+			return sb.String(), nil
+		}
+	}
+}
+
+func (p *termParser) parseQuoted(quote byte) (string, error) {
+	if !p.consume(quote) {
+		return "", p.error("expected quote")
+	}
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", p.error("unterminated string")
+		}
+
+		c := p.data[p.pos]
+		p.pos++
+
+		switch c {
+		case quote:
+			if !utf8.ValidString(sb.String()) {
+				return "", p.error("invalid utf8 string")
+			}
+			return sb.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", p.error("unterminated string")
+			}
+			e := p.data[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 's':
+				sb.WriteByte(' ')
+			case 'e':
+				sb.WriteByte(0x1b)
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				start := p.pos - 1
+				for p.pos < len(p.data) && p.pos-start < 3 && p.data[p.pos] >= '0' && p.data[p.pos] <= '7' {
+					p.pos++
+				}
+				v, _ := strconv.ParseUint(string(p.data[start:p.pos]), 8, 8)
+				sb.WriteByte(byte(v))
+			default:
+				sb.WriteByte(e)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+func (p *termParser) parseNumber() (any, error) {
+	start := p.pos
+	p.consume('-')
+	isFloat := false
+	for !p.eof() {
+		c := p.peek()
+		if c >= '0' && c <= '9' {
+			p.pos++
+		} else if (c == '.' || c == 'e' || c == 'E') && p.pos+1 < len(p.data) && p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' {
+			isFloat = true
+			p.pos++
+		} else {
+			break
+		}
+	}
+
+	s := string(p.data[start:p.pos])
+	if isFloat {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, p.error("invalid float")
+		}
+		return f, nil
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, p.error("invalid integer")
+	}
+	return i, nil
+}
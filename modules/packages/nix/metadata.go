@@ -0,0 +1,307 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrInvalidStorePath = util.NewInvalidArgumentErrorf("store path is invalid")
+	ErrInvalidNarInfo   = util.NewInvalidArgumentErrorf("narinfo is invalid")
+	ErrInvalidFilename  = util.NewInvalidArgumentErrorf("nar filename is invalid")
+	ErrInvalidKey       = util.NewInvalidArgumentErrorf("signing key is invalid")
+)
+
+const (
+	SettingKeyPrivate = "nix.key.private"
+	SettingKeyPublic  = "nix.key.public"
+
+	// UploadPackageName and UploadVersion hold the NARs which are not referenced by a narinfo yet
+	UploadPackageName = "_nix"
+	UploadVersion     = "_upload"
+
+	StoreDir = "/nix/store"
+
+	// HashLength is the length of the hash part of a store path
+	HashLength = 32
+
+	CompressionNone  = "none"
+	CompressionXz    = "xz"
+	CompressionZstd  = "zstd"
+	CompressionBzip2 = "bzip2"
+
+	NarInfoExtension = ".narinfo"
+
+	maxNarInfoSize  = 1024 * 1024
+	maxStoreNameLen = 211
+	sha256Prefix    = "sha256:"
+)
+
+var (
+	storeNamePattern = regexp.MustCompile(`\A[0-9A-Za-z+\-_?=][0-9A-Za-z+\-._?=]*\z`)
+
+	compressionExtensions = map[string]string{
+		CompressionNone:  ".nar",
+		CompressionXz:    ".nar.xz",
+		CompressionZstd:  ".nar.zst",
+		CompressionBzip2: ".nar.bz2",
+	}
+)
+
+// Metadata represents the narinfo of a store path
+// https://nixos.org/manual/nix/stable/package-management/binary-cache-substituter
+type Metadata struct {
+	StorePath   string   `json:"store_path"`
+	URL         string   `json:"url"`
+	Compression string   `json:"compression"`
+	FileHash    string   `json:"file_hash"`
+	FileSize    int64    `json:"file_size"`
+	NarHash     string   `json:"nar_hash"`
+	NarSize     int64    `json:"nar_size"`
+	References  []string `json:"references,omitempty"`
+	Deriver     string   `json:"deriver,omitempty"`
+	System      string   `json:"system,omitempty"`
+	CA          string   `json:"ca,omitempty"`
+	// Signatures are the signatures created by the uploader
+	Signatures []string `json:"signatures,omitempty"`
+}
+
+// IsValidHash tests if the string is the hash part of a store path
+func IsValidHash(s string) bool {
+	if len(s) != HashLength {
+		return false
+	}
+	for i := range len(s) {
+		if strings.IndexByte(nix32Alphabet, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SplitStorePathBase splits the base name of a store path into its hash and its name
+func SplitStorePathBase(base string) (string, string, error) {
+	hash, name, ok := strings.Cut(base, "-")
+	if !ok || !IsValidHash(hash) || len(name) > maxStoreNameLen || !storeNamePattern.MatchString(name) {
+		return "", "", ErrInvalidStorePath
+	}
+	return hash, name, nil
+}
+
+// ParseStorePath returns the hash and the name of a store path
+func ParseStorePath(storePath string) (string, string, error) {
+	base, ok := strings.CutPrefix(storePath, StoreDir+"/")
+	if !ok {
+		return "", "", ErrInvalidStorePath
+	}
+	return SplitStorePathBase(base)
+}
+
+// NarFilename returns the filename of a NAR with the given file hash and compression
+func NarFilename(fileHash, compression string) (string, error) {
+	ext, ok := compressionExtensions[compression]
+	if !ok {
+		return "", ErrInvalidNarInfo
+	}
+	digest, ok := strings.CutPrefix(fileHash, sha256Prefix)
+	if !ok {
+		return "", ErrInvalidNarInfo
+	}
+	return digest + ext, nil
+}
+
+// ParseNarFilename returns the sha256 checksum of a NAR filename like <nix32 sha256>.nar.xz
+func ParseNarFilename(filename string) ([]byte, error) {
+	for _, ext := range compressionExtensions {
+		if digest, ok := strings.CutSuffix(filename, ext); ok && !strings.Contains(digest, ".") {
+			sum, err := DecodeString(digest, 32)
+			if err != nil {
+				return nil, ErrInvalidFilename
+			}
+			return sum, nil
+		}
+	}
+	return nil, ErrInvalidFilename
+}
+
+func isValidSHA256Hash(s string) bool {
+	digest, ok := strings.CutPrefix(s, sha256Prefix)
+	if !ok {
+		return false
+	}
+	_, err := DecodeString(digest, 32)
+	return err == nil
+}
+
+// ParseNarInfo parses the narinfo uploaded for a store path
+func ParseNarInfo(r io.Reader) (*Metadata, error) {
+	m := &Metadata{
+		// https://github.com/NixOS/nix/blob/master/src/libstore/nar-info.cc
+		Compression: CompressionBzip2,
+	}
+
+	scanner := bufio.NewScanner(io.LimitReader(r, maxNarInfoSize))
+	scanner.Buffer(nil, maxNarInfoSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			if key, ok = strings.CutSuffix(line, ":"); !ok {
+				return nil, ErrInvalidNarInfo
+			}
+		}
+
+		var err error
+		switch key {
+		case "StorePath":
+			m.StorePath = value
+		case "URL":
+			m.URL = value
+		case "Compression":
+			m.Compression = value
+		case "FileHash":
+			m.FileHash = value
+		case "FileSize":
+			m.FileSize, err = strconv.ParseInt(value, 10, 64)
+		case "NarHash":
+			m.NarHash = value
+		case "NarSize":
+			m.NarSize, err = strconv.ParseInt(value, 10, 64)
+		case "References":
+			m.References = strings.Fields(value)
+		case "Deriver":
+			if value != "unknown-deriver" {
+				m.Deriver = value
+			}
+		case "System":
+			m.System = value
+		case "Sig":
+			m.Signatures = append(m.Signatures, value)
+		case "CA":
+			m.CA = value
+		}
+		if err != nil {
+			return nil, ErrInvalidNarInfo
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if err == bufio.ErrTooLong {
+			return nil, ErrInvalidNarInfo
+		}
+		return nil, err
+	}
+
+	if _, _, err := ParseStorePath(m.StorePath); err != nil {
+		return nil, err
+	}
+	for _, ref := range m.References {
+		if _, _, err := SplitStorePathBase(ref); err != nil {
+			return nil, err
+		}
+	}
+	if m.Deriver != "" {
+		if _, _, err := SplitStorePathBase(m.Deriver); err != nil {
+			return nil, err
+		}
+	}
+	if !isValidSHA256Hash(m.NarHash) || m.NarSize <= 0 || !isValidSHA256Hash(m.FileHash) || m.FileSize <= 0 {
+		return nil, ErrInvalidNarInfo
+	}
+	filename, err := NarFilename(m.FileHash, m.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if m.URL != "nar/"+filename {
+		return nil, ErrInvalidNarInfo
+	}
+
+	return m, nil
+}
+
+// Fingerprint returns the data which is signed for a store path
+func (m *Metadata) Fingerprint() string {
+	refs := make([]string, 0, len(m.References))
+	for _, ref := range m.References {
+		refs = append(refs, StoreDir+"/"+ref)
+	}
+	return fmt.Sprintf("1;%s;%s;%d;%s", m.StorePath, m.NarHash, m.NarSize, strings.Join(refs, ","))
+}
+
+// NarInfo builds the narinfo of the store path with the uploaded and the additional signatures
+func (m *Metadata) NarInfo(signatures ...string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "StorePath: %s\n", m.StorePath)
+	fmt.Fprintf(&buf, "URL: %s\n", m.URL)
+	fmt.Fprintf(&buf, "Compression: %s\n", m.Compression)
+	fmt.Fprintf(&buf, "FileHash: %s\n", m.FileHash)
+	fmt.Fprintf(&buf, "FileSize: %d\n", m.FileSize)
+	fmt.Fprintf(&buf, "NarHash: %s\n", m.NarHash)
+	fmt.Fprintf(&buf, "NarSize: %d\n", m.NarSize)
+	fmt.Fprintf(&buf, "References: %s\n", strings.Join(m.References, " "))
+	if m.Deriver != "" {
+		fmt.Fprintf(&buf, "Deriver: %s\n", m.Deriver)
+	}
+	if m.System != "" {
+		fmt.Fprintf(&buf, "System: %s\n", m.System)
+	}
+	for _, sig := range m.Signatures {
+		fmt.Fprintf(&buf, "Sig: %s\n", sig)
+	}
+	for _, sig := range signatures {
+		fmt.Fprintf(&buf, "Sig: %s\n", sig)
+	}
+	if m.CA != "" {
+		fmt.Fprintf(&buf, "CA: %s\n", m.CA)
+	}
+	return buf.Bytes()
+}
+
+// GenerateSigningKey creates an ed25519 key pair in the format of nix-store --generate-binary-cache-key
+func GenerateSigningKey(name string) (string, string, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return name + ":" + base64.StdEncoding.EncodeToString(priv), name + ":" + base64.StdEncoding.EncodeToString(pub), nil
+}
+
+// SplitKey returns the name and the decoded key of a secret or public key
+func SplitKey(key string) (string, []byte, error) {
+	name, encoded, ok := strings.Cut(key, ":")
+	if !ok || name == "" {
+		return "", nil, ErrInvalidKey
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, ErrInvalidKey
+	}
+	return name, data, nil
+}
+
+// Sign signs the fingerprint with the secret key and returns the signature in the format of the Sig field
+func Sign(secretKey, fingerprint string) (string, error) {
+	name, priv, err := SplitKey(secretKey)
+	if err != nil {
+		return "", err
+	}
+	if len(priv) != ed25519.PrivateKeySize {
+		return "", ErrInvalidKey
+	}
+	return name + ":" + base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(fingerprint))), nil
+}
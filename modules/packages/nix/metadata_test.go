@@ -0,0 +1,159 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	storeHash = "7h7qgvs4kgzsn8a6rb273saxyqh4jxlz"
+	narHash   = "sha256:1impfw8zdgisxkghq9a3q7cn7zb6rs6ljxl8v2xbx6hadp1h1ic8"
+)
+
+func TestNix32(t *testing.T) {
+	sum := sha256.Sum256(nil)
+	assert.Equal(t, "0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73", EncodeToString(sum[:]))
+
+	decoded, err := DecodeString("0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73", 32)
+	require.NoError(t, err)
+	assert.Equal(t, sum[:], decoded)
+
+	for _, data := range [][]byte{{0}, {0xff}, []byte("gitea"), []byte("0123456789abcdefghij")} {
+		decoded, err := DecodeString(EncodeToString(data), len(data))
+		require.NoError(t, err)
+		assert.Equal(t, data, decoded)
+	}
+
+	_, err = DecodeString("0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c7e", 32)
+	assert.Error(t, err)
+	_, err = DecodeString("0mdqa", 32)
+	assert.Error(t, err)
+	// the first character only holds one bit of a 32 byte hash
+	_, err = DecodeString("2mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73", 32)
+	assert.Error(t, err)
+}
+
+func TestParseStorePath(t *testing.T) {
+	hash, name, err := ParseStorePath("/nix/store/" + storeHash + "-hello-2.12.1")
+	require.NoError(t, err)
+	assert.Equal(t, storeHash, hash)
+	assert.Equal(t, "hello-2.12.1", name)
+
+	for _, storePath := range []string{
+		"/nix/store/" + storeHash,
+		"/nix/store/" + storeHash + "-",
+		"/nix/store/" + storeHash + "-.hidden",
+		"/nix/store/" + storeHash + "-a/b",
+		"/nix/store/" + storeHash[1:] + "-hello",
+		"/nix/store/" + strings.Replace(storeHash, "7", "e", 1) + "-hello",
+		"/gnu/store/" + storeHash + "-hello",
+	} {
+		_, _, err := ParseStorePath(storePath)
+		assert.ErrorIs(t, err, ErrInvalidStorePath, storePath)
+	}
+}
+
+func TestParseNarFilename(t *testing.T) {
+	sum := sha256.Sum256([]byte("nar"))
+	digest := EncodeToString(sum[:])
+
+	for _, ext := range []string{".nar", ".nar.xz", ".nar.zst", ".nar.bz2"} {
+		parsed, err := ParseNarFilename(digest + ext)
+		require.NoError(t, err)
+		assert.Equal(t, sum[:], parsed)
+	}
+
+	for _, filename := range []string{digest, digest + ".tar.xz", digest[1:] + ".nar", "x.nar"} {
+		_, err := ParseNarFilename(filename)
+		assert.ErrorIs(t, err, ErrInvalidFilename, filename)
+	}
+}
+
+func TestParseNarInfo(t *testing.T) {
+	sum := sha256.Sum256([]byte("nar"))
+	fileHash := "sha256:" + EncodeToString(sum[:])
+	narinfo := `StorePath: /nix/store/` + storeHash + `-hello-2.12.1
+URL: nar/` + EncodeToString(sum[:]) + `.nar.xz
+Compression: xz
+FileHash: ` + fileHash + `
+FileSize: 50264
+NarHash: ` + narHash + `
+NarSize: 226560
+References: 0000000000000000000000000000000a-glibc-2.39 ` + storeHash + `-hello-2.12.1
+Deriver: 0000000000000000000000000000000b-hello-2.12.1.drv
+Sig: client-1:c2lnbmF0dXJl
+`
+
+	t.Run("Valid", func(t *testing.T) {
+		m, err := ParseNarInfo(strings.NewReader(narinfo))
+		require.NoError(t, err)
+		assert.Equal(t, "/nix/store/"+storeHash+"-hello-2.12.1", m.StorePath)
+		assert.Equal(t, CompressionXz, m.Compression)
+		assert.Equal(t, fileHash, m.FileHash)
+		assert.EqualValues(t, 50264, m.FileSize)
+		assert.Equal(t, narHash, m.NarHash)
+		assert.EqualValues(t, 226560, m.NarSize)
+		assert.Equal(t, []string{"0000000000000000000000000000000a-glibc-2.39", storeHash + "-hello-2.12.1"}, m.References)
+		assert.Equal(t, "0000000000000000000000000000000b-hello-2.12.1.drv", m.Deriver)
+		assert.Equal(t, []string{"client-1:c2lnbmF0dXJl"}, m.Signatures)
+
+		assert.Equal(t, "1;/nix/store/"+storeHash+"-hello-2.12.1;"+narHash+";226560;/nix/store/0000000000000000000000000000000a-glibc-2.39,/nix/store/"+storeHash+"-hello-2.12.1", m.Fingerprint())
+
+		assert.Equal(t, narinfo+"Sig: server-1:abc\n", string(m.NarInfo("server-1:abc")))
+
+		reparsed, err := ParseNarInfo(strings.NewReader(string(m.NarInfo())))
+		require.NoError(t, err)
+		assert.Equal(t, m, reparsed)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, replacement := range [][2]string{
+			{"StorePath: /nix/store/", "StorePath: /tmp/"},
+			{"Compression: xz", "Compression: zstd"},
+			{"Compression: xz", "Compression: lzip"},
+			{"NarSize: 226560", "NarSize: large"},
+			{"NarHash: sha256:", "NarHash: md5:"},
+			{"URL: nar/", "URL: https://example.com/"},
+			{"References: 0000000000000000000000000000000a-glibc-2.39", "References: ../glibc"},
+			{"Deriver: 0", "Deriver: "},
+			{"FileSize: 50264\n", "FileSize\n"},
+		} {
+			_, err := ParseNarInfo(strings.NewReader(strings.Replace(narinfo, replacement[0], replacement[1], 1)))
+			assert.ErrorIs(t, err, util.ErrInvalidArgument, replacement[1])
+		}
+	})
+}
+
+func TestSign(t *testing.T) {
+	secretKey, publicKey, err := GenerateSigningKey("gitea.example.com-user2-1")
+	require.NoError(t, err)
+
+	name, pub, err := SplitKey(publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, "gitea.example.com-user2-1", name)
+	assert.Len(t, pub, ed25519.PublicKeySize)
+
+	sig, err := Sign(secretKey, "1;/nix/store/x;sha256:y;1;")
+	require.NoError(t, err)
+
+	sigName, encoded, ok := strings.Cut(sig, ":")
+	assert.True(t, ok)
+	assert.Equal(t, name, sigName)
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(pub, []byte("1;/nix/store/x;sha256:y;1;"), signature))
+
+	_, err = Sign(publicKey, "fingerprint")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
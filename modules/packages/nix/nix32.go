@@ -0,0 +1,60 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+// nix32Alphabet omits the characters e, o, u and t
+const nix32Alphabet = "0123456789abcdfghijklmnpqrsvwxyz"
+
+// EncodedLen returns the length of the nix32 encoding of n bytes
+func EncodedLen(n int) int {
+	return (n*8-1)/5 + 1
+}
+
+// EncodeToString encodes the bytes with the base32 variant of Nix which processes the bits in reverse order
+func EncodeToString(data []byte) string {
+	l := EncodedLen(len(data))
+
+	var sb strings.Builder
+	sb.Grow(l)
+	for n := l - 1; n >= 0; n-- {
+		b := n * 5
+		i, j := b/8, b%8
+		c := data[i] >> j
+		if i+1 < len(data) {
+			c |= data[i+1] << (8 - j)
+		}
+		sb.WriteByte(nix32Alphabet[c&0x1f])
+	}
+	return sb.String()
+}
+
+// DecodeString decodes a nix32 string of the given number of bytes
+func DecodeString(s string, size int) ([]byte, error) {
+	if len(s) != EncodedLen(size) {
+		return nil, util.NewInvalidArgumentErrorf("nix32 string has an invalid length")
+	}
+
+	data := make([]byte, size)
+	for n := range len(s) {
+		digit := strings.IndexByte(nix32Alphabet, s[len(s)-n-1])
+		if digit < 0 {
+			return nil, util.NewInvalidArgumentErrorf("nix32 string contains an invalid character")
+		}
+		b := n * 5
+		i, j := b/8, b%8
+		data[i] |= byte(digit) << j
+		if carry := byte(digit >> (8 - j)); i+1 < size {
+			data[i+1] |= carry
+		} else if carry != 0 {
+			return nil, util.NewInvalidArgumentErrorf("nix32 string has invalid trailing bits")
+		}
+	}
+	return data, nil
+}
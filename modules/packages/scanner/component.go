@@ -0,0 +1,223 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/modules/packages/cargo"
+	"code.gitea.io/gitea/modules/packages/composer"
+	"code.gitea.io/gitea/modules/packages/maven"
+	"code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/packages/pypi"
+)
+
+// OSV ecosystems, see https://ossf.github.io/osv-schema/#defined-ecosystems
+const (
+	EcosystemCrates    = "crates.io"
+	EcosystemGo        = "Go"
+	EcosystemMaven     = "Maven"
+	EcosystemNpm       = "npm"
+	EcosystemNuGet     = "NuGet"
+	EcosystemPackagist = "Packagist"
+	EcosystemPub       = "Pub"
+	EcosystemPyPI      = "PyPI"
+	EcosystemRubyGems  = "RubyGems"
+	EcosystemAlpine    = "Alpine"
+	EcosystemDebian    = "Debian"
+	EcosystemUbuntu    = "Ubuntu"
+)
+
+// Kind describes where a component was found
+type Kind string
+
+const (
+	// KindPackage is the package itself
+	KindPackage Kind = "package"
+	// KindDependency is a dependency declared in the package metadata
+	KindDependency Kind = "dependency"
+	// KindSystem is a package installed in the operating system of a container image
+	KindSystem Kind = "system"
+)
+
+// Component is a piece of software contained in or required by a package version
+type Component struct {
+	Kind Kind
+	// Ecosystem is the OSV ecosystem, operating system packages carry the release too, e.g. "Debian:12"
+	Ecosystem string
+	Name      string
+	// SourceName is the source package of an operating system package, the advisories of distributions refer to it
+	SourceName string
+	// Version is the version or, for dependencies, the declared version requirement
+	Version string
+	License string
+}
+
+// MatchName returns the name advisories use for the component
+func (c *Component) MatchName() string {
+	if c.SourceName != "" {
+		return c.SourceName
+	}
+	return c.Name
+}
+
+// SplitEcosystem splits an ecosystem like "Debian:12" into the base ecosystem and the release
+func SplitEcosystem(ecosystem string) (string, string) {
+	base, release, _ := strings.Cut(ecosystem, ":")
+	return base, release
+}
+
+// MatchesEcosystem tests if an advisory for the ecosystem applies to a component of the ecosystem.
+// Advisories and components without a release match all releases.
+func MatchesEcosystem(advisoryEcosystem, componentEcosystem string) bool {
+	advisoryBase, advisoryRelease := SplitEcosystem(advisoryEcosystem)
+	componentBase, componentRelease := SplitEcosystem(componentEcosystem)
+	if advisoryBase != componentBase {
+		return false
+	}
+	if advisoryRelease == "" || componentRelease == "" {
+		return true
+	}
+	// Ubuntu advisories use releases like "22.04:LTS"
+	return advisoryRelease == componentRelease || strings.HasPrefix(advisoryRelease, componentRelease+":")
+}
+
+// typeEcosystems maps package types to the OSV ecosystems of their packages
+var typeEcosystems = map[string]string{
+	"cargo":    EcosystemCrates,
+	"composer": EcosystemPackagist,
+	"go":       EcosystemGo,
+	"maven":    EcosystemMaven,
+	"npm":      EcosystemNpm,
+	"nuget":    EcosystemNuGet,
+	"pub":      EcosystemPub,
+	"pypi":     EcosystemPyPI,
+	"rubygems": EcosystemRubyGems,
+}
+
+// ExtractComponents returns the components of a package version described by the metadata stored for the package type.
+// Container images are not handled here because their components are read from the image layers.
+func ExtractComponents(packageType, name, version string, metadata any) []*Component {
+	ecosystem, ok := typeEcosystems[packageType]
+	if !ok {
+		return nil
+	}
+
+	pkg := &Component{
+		Kind:      KindPackage,
+		Ecosystem: ecosystem,
+		Name:      name,
+		Version:   version,
+	}
+	components := []*Component{pkg}
+
+	addDependencies := func(deps map[string]string) {
+		names := make([]string, 0, len(deps))
+		for n := range deps {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			components = append(components, &Component{
+				Kind:      KindDependency,
+				Ecosystem: ecosystem,
+				Name:      n,
+				Version:   deps[n],
+			})
+		}
+	}
+
+	switch m := metadata.(type) {
+	case *npm.Metadata:
+		pkg.License = m.License
+		addDependencies(m.Dependencies)
+		addDependencies(m.OptionalDependencies)
+	case *pypi.Metadata:
+		pkg.License = m.License
+		for _, requirement := range m.RequiresDist {
+			if c := parsePythonRequirement(requirement); c != nil {
+				components = append(components, c)
+			}
+		}
+	case *cargo.Metadata:
+		pkg.License = m.License
+		for _, dep := range m.Dependencies {
+			if dep.Kind == "dev" {
+				continue
+			}
+			n := dep.Name
+			if dep.Package != nil && *dep.Package != "" {
+				// the dependency is renamed
+				n = *dep.Package
+			}
+			components = append(components, &Component{
+				Kind:      KindDependency,
+				Ecosystem: ecosystem,
+				Name:      n,
+				Version:   dep.Req,
+			})
+		}
+	case *composer.Metadata:
+		pkg.License = strings.Join(m.License, " OR ")
+		deps := make(map[string]string, len(m.Require))
+		for n, req := range m.Require {
+			// platform requirements are no packages
+			if n == "php" || strings.HasPrefix(n, "ext-") || strings.HasPrefix(n, "lib-") || !strings.Contains(n, "/") {
+				continue
+			}
+			deps[n] = req
+		}
+		addDependencies(deps)
+	case *maven.Metadata:
+		pkg.Name = m.GroupID + ":" + m.ArtifactID
+		pkg.License = strings.Join(m.Licenses, ", ")
+		for _, dep := range m.Dependencies {
+			components = append(components, &Component{
+				Kind:      KindDependency,
+				Ecosystem: ecosystem,
+				Name:      dep.GroupID + ":" + dep.ArtifactID,
+				Version:   dep.Version,
+			})
+		}
+	}
+
+	return components
+}
+
+// https://packaging.python.org/en/latest/specifications/dependency-specifiers/
+var pythonRequirementPattern = regexp.MustCompile(`\A\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*\(?([^;)]*)\)?\s*(?:;(.*))?\z`)
+
+func parsePythonRequirement(requirement string) *Component {
+	m := pythonRequirementPattern.FindStringSubmatch(requirement)
+	if m == nil {
+		return nil
+	}
+	// requirements of extras are not installed by default
+	if strings.Contains(m[3], "extra") {
+		return nil
+	}
+	return &Component{
+		Kind:      KindDependency,
+		Ecosystem: EcosystemPyPI,
+		Name:      m[1],
+		Version:   strings.TrimSpace(m[2]),
+	}
+}
+
+// NormalizeName normalizes the name of a component so it can be compared with the names used by advisories
+func NormalizeName(ecosystem, name string) string {
+	base, _ := SplitEcosystem(ecosystem)
+	switch base {
+	case EcosystemPyPI:
+		// https://peps.python.org/pep-0503/#normalized-names
+		return strings.ToLower(pythonNormalizePattern.ReplaceAllString(name, "-"))
+	case EcosystemGo:
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+var pythonNormalizePattern = regexp.MustCompile(`[-_.]+`)
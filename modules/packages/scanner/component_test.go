@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/packages/cargo"
+	"code.gitea.io/gitea/modules/packages/composer"
+	"code.gitea.io/gitea/modules/packages/maven"
+	"code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/packages/pypi"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractComponents(t *testing.T) {
+	t.Run("Npm", func(t *testing.T) {
+		components := ExtractComponents("npm", "@scope/pkg", "1.0.0", &npm.Metadata{
+			License:              "MIT",
+			Dependencies:         map[string]string{"lodash": "^4.17.0", "express": "4.18.2"},
+			OptionalDependencies: map[string]string{"fsevents": "~2.3.2"},
+		})
+		require.Len(t, components, 4)
+		assert.Equal(t, &Component{Kind: KindPackage, Ecosystem: EcosystemNpm, Name: "@scope/pkg", Version: "1.0.0", License: "MIT"}, components[0])
+		assert.Equal(t, &Component{Kind: KindDependency, Ecosystem: EcosystemNpm, Name: "express", Version: "4.18.2"}, components[1])
+		assert.Equal(t, &Component{Kind: KindDependency, Ecosystem: EcosystemNpm, Name: "lodash", Version: "^4.17.0"}, components[2])
+		assert.Equal(t, "fsevents", components[3].Name)
+	})
+
+	t.Run("PyPI", func(t *testing.T) {
+		components := ExtractComponents("pypi", "pkg", "1.0", &pypi.Metadata{
+			License:      "BSD",
+			RequiresDist: []string{"requests (>=2.0,<3)", "urllib3>=1.26; python_version >= '3.8'", "pytest; extra == 'test'", "Flask[async]==3.0.0"},
+		})
+		require.Len(t, components, 4)
+		assert.Equal(t, "BSD", components[0].License)
+		assert.Equal(t, &Component{Kind: KindDependency, Ecosystem: EcosystemPyPI, Name: "requests", Version: ">=2.0,<3"}, components[1])
+		assert.Equal(t, &Component{Kind: KindDependency, Ecosystem: EcosystemPyPI, Name: "urllib3", Version: ">=1.26"}, components[2])
+		assert.Equal(t, &Component{Kind: KindDependency, Ecosystem: EcosystemPyPI, Name: "Flask", Version: "==3.0.0"}, components[3])
+	})
+
+	t.Run("Cargo", func(t *testing.T) {
+		renamed := "serde"
+		components := ExtractComponents("cargo", "pkg", "0.1.0", &cargo.Metadata{
+			License: "Apache-2.0",
+			Dependencies: []*cargo.Dependency{
+				{Name: "tokio", Req: "^1.0", Kind: "normal"},
+				{Name: "serde_renamed", Req: "^1", Kind: "normal", Package: &renamed},
+				{Name: "criterion", Req: "^0.5", Kind: "dev"},
+			},
+		})
+		require.Len(t, components, 3)
+		assert.Equal(t, "tokio", components[1].Name)
+		assert.Equal(t, "serde", components[2].Name)
+	})
+
+	t.Run("Composer", func(t *testing.T) {
+		components := ExtractComponents("composer", "vendor/pkg", "1.0.0", &composer.Metadata{
+			License: composer.Licenses{"MIT", "GPL-3.0"},
+			Require: map[string]string{"php": ">=8.1", "ext-json": "*", "guzzlehttp/guzzle": "^7.0"},
+		})
+		require.Len(t, components, 2)
+		assert.Equal(t, "MIT OR GPL-3.0", components[0].License)
+		assert.Equal(t, &Component{Kind: KindDependency, Ecosystem: EcosystemPackagist, Name: "guzzlehttp/guzzle", Version: "^7.0"}, components[1])
+	})
+
+	t.Run("Maven", func(t *testing.T) {
+		components := ExtractComponents("maven", "com.example-app", "1.0", &maven.Metadata{
+			GroupID:      "com.example",
+			ArtifactID:   "app",
+			Licenses:     []string{"Apache License 2.0"},
+			Dependencies: []*maven.Dependency{{GroupID: "org.apache.logging.log4j", ArtifactID: "log4j-core", Version: "2.14.1"}},
+		})
+		require.Len(t, components, 2)
+		assert.Equal(t, "com.example:app", components[0].Name)
+		assert.Equal(t, &Component{Kind: KindDependency, Ecosystem: EcosystemMaven, Name: "org.apache.logging.log4j:log4j-core", Version: "2.14.1"}, components[1])
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		assert.Empty(t, ExtractComponents("generic", "pkg", "1.0", nil))
+	})
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "zope-interface", NormalizeName(EcosystemPyPI, "Zope.Interface"))
+	assert.Equal(t, "lodash", NormalizeName(EcosystemNpm, "Lodash"))
+	assert.Equal(t, "github.com/BurntSushi/toml", NormalizeName(EcosystemGo, "github.com/BurntSushi/toml"))
+}
+
+func TestMatchesEcosystem(t *testing.T) {
+	assert.True(t, MatchesEcosystem("npm", "npm"))
+	assert.False(t, MatchesEcosystem("npm", "PyPI"))
+	assert.True(t, MatchesEcosystem("Debian:12", "Debian:12"))
+	assert.False(t, MatchesEcosystem("Debian:11", "Debian:12"))
+	assert.True(t, MatchesEcosystem("Debian", "Debian:12"))
+	assert.True(t, MatchesEcosystem("Debian:12", "Debian"))
+	assert.True(t, MatchesEcosystem("Ubuntu:22.04:LTS", "Ubuntu:22.04"))
+	assert.False(t, MatchesEcosystem("Ubuntu:22.04:LTS", "Ubuntu:22.10"))
+}
@@ -0,0 +1,272 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const maxDatabaseFileSize = 32 * 1024 * 1024
+
+const (
+	osReleasePath      = "etc/os-release"
+	usrOSReleasePath   = "usr/lib/os-release"
+	dpkgStatusPath     = "var/lib/dpkg/status"
+	dpkgStatusDirPath  = "var/lib/dpkg/status.d"
+	apkInstalledPath   = "lib/apk/db/installed"
+	whiteoutPrefix     = ".wh."
+	whiteoutOpaqueName = ".wh..wh..opq"
+)
+
+func isDatabaseFile(p string) bool {
+	switch p {
+	case osReleasePath, usrOSReleasePath, dpkgStatusPath, apkInstalledPath:
+		return true
+	}
+	return path.Dir(p) == dpkgStatusDirPath && !strings.HasSuffix(p, ".md5sums")
+}
+
+// Image collects the files of container image layers which describe the installed operating system packages
+type Image struct {
+	files map[string][]byte
+}
+
+// NewImage creates an empty image
+func NewImage() *Image {
+	return &Image{files: make(map[string][]byte)}
+}
+
+// IsLayerMediaType tests if the media type describes a filesystem layer
+func IsLayerMediaType(mediaType string) bool {
+	return strings.Contains(mediaType, ".layer.") || strings.Contains(mediaType, ".rootfs.diff.")
+}
+
+// AddLayer reads the files of a layer. Layers must be added from the lowest to the topmost one.
+func (img *Image) AddLayer(r io.Reader, mediaType string) error {
+	switch {
+	case strings.HasSuffix(mediaType, "gzip"):
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gzr.Close()
+		r = gzr
+	case strings.HasSuffix(mediaType, "zstd"):
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		r = zr
+	case strings.HasSuffix(mediaType, "tar"):
+	default:
+		return util.NewInvalidArgumentErrorf("unsupported layer media type %s", mediaType)
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hd, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+hd.Name), "/")
+		dir, base := path.Split(name)
+		dir = strings.TrimSuffix(dir, "/")
+
+		if base == whiteoutOpaqueName {
+			img.remove(dir)
+			continue
+		}
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			img.remove(path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)))
+			continue
+		}
+
+		if hd.Typeflag != tar.TypeReg || !isDatabaseFile(name) {
+			continue
+		}
+		data, err := util.ReadWithLimit(tr, maxDatabaseFileSize)
+		if err != nil {
+			return err
+		}
+		img.files[name] = data
+	}
+}
+
+// remove removes a file or all files of a directory
+func (img *Image) remove(p string) {
+	for name := range img.files {
+		if name == p || p == "" || strings.HasPrefix(name, p+"/") {
+			delete(img.files, name)
+		}
+	}
+}
+
+// Components returns the operating system packages installed in the image
+func (img *Image) Components() []*Component {
+	osRelease, ok := img.files[osReleasePath]
+	if !ok {
+		osRelease = img.files[usrOSReleasePath]
+	}
+	id, versionID := parseOSRelease(osRelease)
+
+	components := make([]*Component, 0, 100)
+
+	if data, ok := img.files[apkInstalledPath]; ok {
+		ecosystem := EcosystemAlpine
+		if id == "alpine" && versionID != "" {
+			// advisories use the branch like "v3.20"
+			parts := strings.SplitN(versionID, ".", 3)
+			if len(parts) >= 2 {
+				ecosystem += ":v" + parts[0] + "." + parts[1]
+			}
+		}
+		components = append(components, parseApkInstalled(ecosystem, data)...)
+	}
+
+	dpkgEcosystem := EcosystemDebian
+	if id == "ubuntu" {
+		dpkgEcosystem = EcosystemUbuntu
+	}
+	if (id == "debian" || id == "ubuntu") && versionID != "" {
+		dpkgEcosystem += ":" + versionID
+	}
+	if data, ok := img.files[dpkgStatusPath]; ok {
+		components = append(components, parseDpkgStatus(dpkgEcosystem, data)...)
+	}
+	names := make([]string, 0, len(img.files))
+	for name := range img.files {
+		if path.Dir(name) == dpkgStatusDirPath {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		components = append(components, parseDpkgStatus(dpkgEcosystem, img.files[name])...)
+	}
+
+	return components
+}
+
+// parseOSRelease returns the ID and VERSION_ID fields of an os-release file
+func parseOSRelease(data []byte) (string, string) {
+	var id, versionID string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		switch strings.TrimSpace(key) {
+		case "ID":
+			id = strings.ToLower(value)
+		case "VERSION_ID":
+			versionID = value
+		}
+	}
+	return id, versionID
+}
+
+// parseParagraphs splits a database into its paragraphs of "Key<sep>Value" lines
+func parseParagraphs(data []byte, sep string) []map[string]string {
+	var paragraphs []map[string]string
+	current := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), maxDatabaseFileSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, current)
+				current = map[string]string{}
+			}
+			continue
+		}
+		// continuation lines of multiline fields
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, sep)
+		if !ok {
+			continue
+		}
+		current[key] = strings.TrimSpace(value)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, current)
+	}
+	return paragraphs
+}
+
+// parseDpkgStatus parses the installed packages of a dpkg status file
+func parseDpkgStatus(ecosystem string, data []byte) []*Component {
+	var components []*Component
+	for _, p := range parseParagraphs(data, ":") {
+		name, version := p["Package"], p["Version"]
+		if name == "" || version == "" {
+			continue
+		}
+		if status, ok := p["Status"]; ok && !strings.HasSuffix(status, " installed") {
+			continue
+		}
+		// "Source: name (version)" is present if the source package differs
+		sourceName, sourceVersion, _ := strings.Cut(p["Source"], " ")
+		if sourceVersion = strings.Trim(sourceVersion, "()"); sourceVersion != "" {
+			version = sourceVersion
+		}
+		if sourceName == name {
+			sourceName = ""
+		}
+		components = append(components, &Component{
+			Kind:       KindSystem,
+			Ecosystem:  ecosystem,
+			Name:       name,
+			SourceName: sourceName,
+			Version:    version,
+		})
+	}
+	return components
+}
+
+// parseApkInstalled parses the installed packages of an apk database
+func parseApkInstalled(ecosystem string, data []byte) []*Component {
+	var components []*Component
+	for _, p := range parseParagraphs(data, ":") {
+		name, version := p["P"], p["V"]
+		if name == "" || version == "" {
+			continue
+		}
+		sourceName := p["o"]
+		if sourceName == name {
+			sourceName = ""
+		}
+		components = append(components, &Component{
+			Kind:       KindSystem,
+			Ecosystem:  ecosystem,
+			Name:       name,
+			SourceName: sourceName,
+			Version:    version,
+			License:    p["L"],
+		})
+	}
+	return components
+}
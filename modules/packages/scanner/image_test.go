@@ -0,0 +1,131 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createLayer(t *testing.T, files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return &buf
+}
+
+const layerMediaType = "application/vnd.oci.image.layer.v1.tar+gzip"
+
+func TestImageDebian(t *testing.T) {
+	img := NewImage()
+
+	require.NoError(t, img.AddLayer(createLayer(t, map[string]string{
+		"etc/os-release": "PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nID=debian\nVERSION_ID=\"12\"\n",
+		"var/lib/dpkg/status": `Package: libssl3
+Status: install ok installed
+Source: openssl
+Version: 3.0.11-1~deb12u2
+Description: Secure Sockets Layer toolkit
+ continuation line
+
+Package: removed
+Status: deinstall ok config-files
+Version: 1.0
+
+Package: zlib1g
+Status: install ok installed
+Source: zlib (1:1.2.13.dfsg-1)
+Version: 1:1.2.13.dfsg-1
+`,
+		"usr/share/doc/readme": "ignored",
+	}), layerMediaType))
+
+	components := img.Components()
+	require.Len(t, components, 2)
+	assert.Equal(t, &Component{Kind: KindSystem, Ecosystem: "Debian:12", Name: "libssl3", SourceName: "openssl", Version: "3.0.11-1~deb12u2"}, components[0])
+	assert.Equal(t, "openssl", components[0].MatchName())
+	assert.Equal(t, "zlib", components[1].SourceName)
+	assert.Equal(t, "1:1.2.13.dfsg-1", components[1].Version)
+
+	// the package database of an upper layer replaces the lower one
+	require.NoError(t, img.AddLayer(createLayer(t, map[string]string{
+		"./var/lib/dpkg/status": "Package: zlib1g\nStatus: install ok installed\nVersion: 1:1.3\n",
+	}), layerMediaType))
+
+	components = img.Components()
+	require.Len(t, components, 1)
+	assert.Equal(t, "1:1.3", components[0].Version)
+
+	// whiteouts remove files of lower layers
+	require.NoError(t, img.AddLayer(createLayer(t, map[string]string{
+		"var/lib/dpkg/.wh.status": "",
+	}), layerMediaType))
+	assert.Empty(t, img.Components())
+}
+
+func TestImageDistroless(t *testing.T) {
+	img := NewImage()
+	require.NoError(t, img.AddLayer(createLayer(t, map[string]string{
+		"usr/lib/os-release":                  "ID=debian\nVERSION_ID=\"12\"\n",
+		"var/lib/dpkg/status.d/base":          "Package: base-files\nVersion: 12.4\n",
+		"var/lib/dpkg/status.d/libc6":         "Package: libc6\nSource: glibc\nVersion: 2.36-9+deb12u3\n",
+		"var/lib/dpkg/status.d/libc6.md5sums": "abc  lib/libc.so.6\n",
+	}), layerMediaType))
+
+	components := img.Components()
+	require.Len(t, components, 2)
+	assert.Equal(t, "base-files", components[0].Name)
+	assert.Equal(t, "glibc", components[1].MatchName())
+	assert.Equal(t, "Debian:12", components[1].Ecosystem)
+}
+
+func TestImageAlpine(t *testing.T) {
+	img := NewImage()
+	require.NoError(t, img.AddLayer(createLayer(t, map[string]string{
+		"etc/os-release": "NAME=\"Alpine Linux\"\nID=alpine\nVERSION_ID=3.20.3\n",
+		"lib/apk/db/installed": `C:Q1abc=
+P:libcrypto3
+V:3.3.2-r0
+L:Apache-2.0
+o:openssl
+
+P:musl
+V:1.2.5-r0
+L:MIT
+o:musl
+`,
+	}), layerMediaType))
+
+	components := img.Components()
+	require.Len(t, components, 2)
+	assert.Equal(t, &Component{Kind: KindSystem, Ecosystem: "Alpine:v3.20", Name: "libcrypto3", SourceName: "openssl", Version: "3.3.2-r0", License: "Apache-2.0"}, components[0])
+	assert.Equal(t, &Component{Kind: KindSystem, Ecosystem: "Alpine:v3.20", Name: "musl", Version: "1.2.5-r0", License: "MIT"}, components[1])
+}
+
+func TestImageInvalidLayer(t *testing.T) {
+	img := NewImage()
+	assert.Error(t, img.AddLayer(bytes.NewReader([]byte("no gzip")), layerMediaType))
+	assert.Error(t, img.AddLayer(bytes.NewReader(nil), "application/vnd.oci.image.config.v1+json"))
+
+	assert.True(t, IsLayerMediaType(layerMediaType))
+	assert.True(t, IsLayerMediaType("application/vnd.docker.image.rootfs.diff.tar.gzip"))
+	assert.False(t, IsLayerMediaType("application/vnd.oci.image.config.v1+json"))
+}
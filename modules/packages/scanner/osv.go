@@ -0,0 +1,202 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var ErrInvalidAdvisory = util.NewInvalidArgumentErrorf("advisory is invalid")
+
+// Advisory is a vulnerability entry in the OSV format, see https://ossf.github.io/osv-schema/
+type Advisory struct {
+	ID         string         `json:"id"`
+	Modified   time.Time      `json:"modified"`
+	Published  time.Time      `json:"published"`
+	Withdrawn  *time.Time     `json:"withdrawn,omitempty"`
+	Aliases    []string       `json:"aliases,omitempty"`
+	Summary    string         `json:"summary,omitempty"`
+	Details    string         `json:"details,omitempty"`
+	Severity   []*Severity    `json:"severity,omitempty"`
+	Affected   []*Affected    `json:"affected,omitempty"`
+	References []*Reference   `json:"references,omitempty"`
+	Database   map[string]any `json:"database_specific,omitempty"`
+}
+
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type Reference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type Affected struct {
+	Package  AffectedPackage `json:"package"`
+	Ranges   []*Range        `json:"ranges,omitempty"`
+	Versions []string        `json:"versions,omitempty"`
+}
+
+type AffectedPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Purl      string `json:"purl,omitempty"`
+}
+
+// Range types
+const (
+	RangeTypeSemver    = "SEMVER"
+	RangeTypeEcosystem = "ECOSYSTEM"
+	RangeTypeGit       = "GIT"
+)
+
+type Range struct {
+	Type   string   `json:"type"`
+	Events []*Event `json:"events"`
+}
+
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// ParseAdvisory parses an advisory in the OSV format
+func ParseAdvisory(data []byte) (*Advisory, error) {
+	a := &Advisory{}
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, errors.Join(ErrInvalidAdvisory, err)
+	}
+	if a.ID == "" {
+		return nil, ErrInvalidAdvisory
+	}
+	return a, nil
+}
+
+// IsWithdrawn tests if the advisory was withdrawn and should not be reported anymore
+func (a *Advisory) IsWithdrawn() bool {
+	return a.Withdrawn != nil && !a.Withdrawn.IsZero()
+}
+
+// SeverityLevel derives a level (critical, high, moderate, low) from the database specific data
+// which most sources fill, an empty string is returned if the level is unknown
+func (a *Advisory) SeverityLevel() string {
+	if level, ok := a.Database["severity"].(string); ok {
+		level = strings.ToLower(level)
+		switch level {
+		case "critical", "high", "moderate", "medium", "low":
+			if level == "medium" {
+				return "moderate"
+			}
+			return level
+		}
+	}
+	return ""
+}
+
+func eventVersion(e *Event) string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	case e.LastAffected != "":
+		return e.LastAffected
+	}
+	return e.Limit
+}
+
+// IsAffected tests if a version of the package is affected.
+// The version which fixes the vulnerability is returned too if it is known.
+// See https://ossf.github.io/osv-schema/#evaluation
+func (a *Affected) IsAffected(version string) (bool, string) {
+	ecosystem := a.Package.Ecosystem
+
+	for _, v := range a.Versions {
+		if v == version {
+			return true, a.fixedVersion(version)
+		}
+	}
+
+	for _, r := range a.Ranges {
+		if r.Type != RangeTypeSemver && r.Type != RangeTypeEcosystem {
+			continue
+		}
+		if affected, fixed := r.isAffected(ecosystem, version); affected {
+			return true, fixed
+		}
+	}
+	return false, ""
+}
+
+func (a *Affected) fixedVersion(version string) string {
+	for _, r := range a.Ranges {
+		if r.Type != RangeTypeSemver && r.Type != RangeTypeEcosystem {
+			continue
+		}
+		for _, e := range r.Events {
+			if e.Fixed != "" && CompareVersions(a.Package.Ecosystem, version, e.Fixed) < 0 {
+				return e.Fixed
+			}
+		}
+	}
+	return ""
+}
+
+func (r *Range) isAffected(ecosystem, version string) (bool, string) {
+	events := make([]*Event, 0, len(r.Events))
+	for _, e := range r.Events {
+		if e.Limit == "" {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		vi, vj := eventVersion(events[i]), eventVersion(events[j])
+		if vi == "0" || vj == "0" {
+			return vi == "0" && vj != "0"
+		}
+		return CompareVersions(ecosystem, vi, vj) < 0
+	})
+
+	affected := false
+	for idx, e := range events {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || CompareVersions(ecosystem, version, e.Introduced) >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if CompareVersions(ecosystem, version, e.Fixed) >= 0 {
+				affected = false
+			} else if affected {
+				return true, e.Fixed
+			}
+		case e.LastAffected != "":
+			if CompareVersions(ecosystem, version, e.LastAffected) > 0 {
+				affected = false
+			} else if affected {
+				return true, nextFixed(events[idx+1:])
+			}
+		}
+	}
+	return affected, ""
+}
+
+func nextFixed(events []*Event) string {
+	for _, e := range events {
+		if e.Fixed != "" {
+			return e.Fixed
+		}
+	}
+	return ""
+}
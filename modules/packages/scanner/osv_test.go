@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const advisoryContent = `{
+  "id": "GHSA-xxxx-yyyy-zzzz",
+  "modified": "2024-01-02T03:04:05Z",
+  "published": "2024-01-01T00:00:00Z",
+  "aliases": ["CVE-2024-0001"],
+  "summary": "Prototype pollution",
+  "affected": [
+    {
+      "package": {"ecosystem": "npm", "name": "lodash"},
+      "ranges": [
+        {
+          "type": "SEMVER",
+          "events": [
+            {"introduced": "4.0.0"},
+            {"fixed": "4.17.21"},
+            {"introduced": "0"},
+            {"fixed": "3.10.2"}
+          ]
+        }
+      ]
+    },
+    {
+      "package": {"ecosystem": "Debian:12", "name": "openssl"},
+      "ranges": [
+        {"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"last_affected": "3.0.11-1~deb12u2"}]}
+      ],
+      "versions": ["3.0.9-1"]
+    }
+  ],
+  "database_specific": {"severity": "HIGH"}
+}`
+
+func TestParseAdvisory(t *testing.T) {
+	a, err := ParseAdvisory([]byte(advisoryContent))
+	require.NoError(t, err)
+	assert.Equal(t, "GHSA-xxxx-yyyy-zzzz", a.ID)
+	assert.Equal(t, []string{"CVE-2024-0001"}, a.Aliases)
+	assert.Equal(t, "high", a.SeverityLevel())
+	assert.False(t, a.IsWithdrawn())
+	require.Len(t, a.Affected, 2)
+
+	_, err = ParseAdvisory([]byte(`{}`))
+	assert.ErrorIs(t, err, ErrInvalidAdvisory)
+
+	_, err = ParseAdvisory([]byte(`invalid`))
+	assert.ErrorIs(t, err, ErrInvalidAdvisory)
+}
+
+func TestAffectedIsAffected(t *testing.T) {
+	a, err := ParseAdvisory([]byte(advisoryContent))
+	require.NoError(t, err)
+
+	cases := []struct {
+		Affected *Affected
+		Version  string
+		Expected bool
+		Fixed    string
+	}{
+		{a.Affected[0], "1.0.0", true, "3.10.2"},
+		{a.Affected[0], "3.10.2", false, ""},
+		{a.Affected[0], "3.99.0", false, ""},
+		{a.Affected[0], "4.0.0", true, "4.17.21"},
+		{a.Affected[0], "4.17.20", true, "4.17.21"},
+		{a.Affected[0], "4.17.21", false, ""},
+		{a.Affected[0], "5.0.0", false, ""},
+		{a.Affected[1], "3.0.9-1", true, ""},
+		{a.Affected[1], "3.0.11-1~deb12u2", true, ""},
+		{a.Affected[1], "3.0.11-1~deb12u3", false, ""},
+	}
+
+	for _, c := range cases {
+		affected, fixed := c.Affected.IsAffected(c.Version)
+		assert.Equal(t, c.Expected, affected, c.Version)
+		assert.Equal(t, c.Fixed, fixed, c.Version)
+	}
+}
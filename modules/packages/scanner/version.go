@@ -0,0 +1,183 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	semverPrereleasePattern = regexp.MustCompile(`-([A-Za-z])`)
+	pythonPrereleasePattern = regexp.MustCompile(`([0-9])[._-]?(a|b|c|rc|alpha|beta|pre|preview)`)
+	pythonDevPattern        = regexp.MustCompile(`([0-9])[._-]?dev`)
+	alpinePrereleasePattern = regexp.MustCompile(`_(alpha|beta|pre|rc)`)
+)
+
+// normalizeVersion rewrites the pre-release markers of an ecosystem to "~" which sorts before everything else
+func normalizeVersion(ecosystem, v string) string {
+	base, _ := SplitEcosystem(ecosystem)
+	switch base {
+	case EcosystemDebian, EcosystemUbuntu:
+		return v
+	case EcosystemAlpine:
+		return alpinePrereleasePattern.ReplaceAllString(v, "~$1")
+	case EcosystemPyPI:
+		v = strings.TrimPrefix(strings.ToLower(v), "v")
+		v, _, _ = strings.Cut(v, "+")
+		// development releases sort before pre-releases
+		v = pythonDevPattern.ReplaceAllString(v, "$1~~dev")
+		return pythonPrereleasePattern.ReplaceAllString(v, "$1~$2")
+	}
+	v = strings.TrimPrefix(v, "v")
+	// build metadata does not take part in the precedence
+	v, _, _ = strings.Cut(v, "+")
+	return semverPrereleasePattern.ReplaceAllString(v, "~$1")
+}
+
+// CompareVersions compares two versions of an ecosystem and returns -1, 0 or 1.
+// The comparison follows the rules of dpkg which handle the version schemes of most ecosystems well
+// once their pre-release markers are normalized.
+func CompareVersions(ecosystem, a, b string) int {
+	a = normalizeVersion(ecosystem, a)
+	b = normalizeVersion(ecosystem, b)
+
+	epochA, a := splitEpoch(a)
+	epochB, b := splitEpoch(b)
+	if epochA != epochB {
+		if epochA < epochB {
+			return -1
+		}
+		return 1
+	}
+	return compareVersionStrings(a, b)
+}
+
+func splitEpoch(v string) (int, string) {
+	epoch, rest, ok := strings.Cut(v, ":")
+	if !ok {
+		return 0, v
+	}
+	e, err := strconv.Atoi(epoch)
+	if err != nil {
+		return 0, v
+	}
+	return e, rest
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+// charOrder is the order of a character in the non-digit parts, "~" sorts before the end of a part
+func charOrder(c byte) int {
+	switch {
+	case c == '~':
+		return -1
+	case isLetter(c):
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+// compareVersionStrings compares alternating non-digit and digit parts like dpkg's verrevcmp
+func compareVersionStrings(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		// non-digit part
+		for i < len(a) && !isDigit(a[i]) || j < len(b) && !isDigit(b[j]) {
+			ac, bc := 0, 0
+			if i < len(a) && !isDigit(a[i]) {
+				ac = charOrder(a[i])
+			}
+			if j < len(b) && !isDigit(b[j]) {
+				bc = charOrder(b[j])
+			}
+			if ac != bc {
+				if ac < bc {
+					return -1
+				}
+				return 1
+			}
+			i++
+			j++
+		}
+
+		// digit part
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		startA, startB := i, j
+		for i < len(a) && isDigit(a[i]) {
+			i++
+		}
+		for j < len(b) && isDigit(b[j]) {
+			j++
+		}
+		numA, numB := a[startA:i], b[startB:j]
+		if len(numA) != len(numB) {
+			if len(numA) < len(numB) {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(numA, numB); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+var requirementVersionPattern = regexp.MustCompile(`[0-9]+(?:\.(?:[0-9]+|[xX*]))*(?:-[0-9A-Za-z.-]+|[a-z]+[0-9]*)?`)
+
+// MinimumVersion returns the lowest version allowed by a version requirement like "^1.2", ">=2.0,<3" or "[1.0,2.0)".
+// An empty string is returned if the requirement has no lower bound.
+func MinimumVersion(requirement string) string {
+	requirement = strings.TrimSpace(requirement)
+	// the first alternative has the lowest versions in practice
+	requirement, _, _ = strings.Cut(requirement, "||")
+	requirement, _, _ = strings.Cut(requirement, "|")
+	requirement = strings.TrimSpace(requirement)
+
+	if requirement == "" || strings.Contains(requirement, "${") {
+		return ""
+	}
+	// Maven version ranges
+	if requirement[0] == '[' || requirement[0] == '(' {
+		requirement = strings.TrimSpace(strings.SplitN(requirement[1:], ",", 2)[0])
+		requirement = strings.TrimRight(requirement, "])")
+	}
+	if strings.HasPrefix(requirement, "<") || strings.HasPrefix(requirement, "!=") {
+		return ""
+	}
+
+	v := requirementVersionPattern.FindString(requirement)
+	if v == "" {
+		return ""
+	}
+
+	core, suffix := v, ""
+	if pos := strings.IndexFunc(v, func(r rune) bool { return r != '.' && (r < '0' || r > '9') && r != 'x' && r != 'X' && r != '*' }); pos != -1 {
+		core, suffix = v[:pos], v[pos:]
+	}
+	parts := strings.Split(core, ".")
+	for i, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			parts[i] = "0"
+		}
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	return strings.Join(parts, ".") + suffix
+}
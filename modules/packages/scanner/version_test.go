@@ -0,0 +1,68 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		Ecosystem string
+		A         string
+		B         string
+		Expected  int
+	}{
+		{EcosystemNpm, "1.2.3", "1.2.3", 0},
+		{EcosystemNpm, "1.2.3", "1.2.10", -1},
+		{EcosystemNpm, "v2.0.0", "1.9.9", 1},
+		{EcosystemNpm, "1.0.0-beta.2", "1.0.0", -1},
+		{EcosystemNpm, "1.0.0-beta.2", "1.0.0-beta.10", -1},
+		{EcosystemNpm, "1.0.0+build.1", "1.0.0", 0},
+		{EcosystemMaven, "1.0-SNAPSHOT", "1.0", -1},
+		{EcosystemPyPI, "2.0rc1", "2.0", -1},
+		{EcosystemPyPI, "2.0.post1", "2.0", 1},
+		{EcosystemPyPI, "2.0.dev1", "2.0a1", -1},
+		{EcosystemDebian, "1:1.0", "2.0", 1},
+		{EcosystemDebian, "1.0~rc1-1", "1.0-1", -1},
+		{EcosystemDebian, "2.36-9+deb12u3", "2.36-9+deb12u4", -1},
+		{EcosystemAlpine, "1.2.3-r4", "1.2.3-r10", -1},
+		{EcosystemAlpine, "1.2.3_rc1-r0", "1.2.3-r0", -1},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.Expected, CompareVersions(c.Ecosystem, c.A, c.B), "%s: %s <=> %s", c.Ecosystem, c.A, c.B)
+		assert.Equal(t, -c.Expected, CompareVersions(c.Ecosystem, c.B, c.A), "%s: %s <=> %s", c.Ecosystem, c.B, c.A)
+	}
+}
+
+func TestMinimumVersion(t *testing.T) {
+	cases := map[string]string{
+		"1.2.3":              "1.2.3",
+		"^1.2":               "1.2.0",
+		"~1.2.3":             "1.2.3",
+		">=1.0.0 <2.0.0":     "1.0.0",
+		"1.x":                "1.0.0",
+		"^7.4|^8.0":          "7.4.0",
+		"^1.0 || ^2.0":       "1.0.0",
+		"=0.4.19":            "0.4.19",
+		">=2.0,<3":           "2.0.0",
+		"==1.4.*":            "1.4.0",
+		"~=2.31.0":           "2.31.0",
+		"[1.0,2.0)":          "1.0.0",
+		"1.0.0-beta.1":       "1.0.0-beta.1",
+		"*":                  "",
+		"latest":             "",
+		"<2.0":               "",
+		"(,1.0]":             "",
+		"${project.version}": "",
+		"":                   "",
+	}
+
+	for requirement, expected := range cases {
+		assert.Equal(t, expected, MinimumVersion(requirement), requirement)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrInvalidName     = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion  = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrInvalidFilename = util.NewInvalidArgumentErrorf("package filename is invalid")
+	ErrInvalidArchive  = util.NewInvalidArgumentErrorf("module archive is invalid")
+	ErrInvalidManifest = util.NewInvalidArgumentErrorf("provider manifest is invalid")
+)
+
+const (
+	KindModule   = "module"
+	KindProvider = "provider"
+
+	SettingKeyPrivate = "terraform.key.private"
+	SettingKeyPublic  = "terraform.key.public"
+
+	// DefaultProtocol is the plugin protocol version of providers uploaded without manifest
+	DefaultProtocol = "5.0"
+
+	SHA256SumsFilename          = "SHA256SUMS"
+	SHA256SumsSignatureFilename = "SHA256SUMS.sig"
+
+	maxReadmeSize   = 1024 * 1024
+	maxManifestSize = 64 * 1024
+)
+
+var (
+	// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#module-addresses
+	moduleNamePattern = regexp.MustCompile(`\A[0-9A-Za-z](?:[0-9A-Za-z-_]{0,62}[0-9A-Za-z])?\z`)
+	// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#provider-addresses
+	providerTypePattern = regexp.MustCompile(`\A[0-9a-z](?:[0-9a-z-]{0,62}[0-9a-z])?\z`)
+	platformPattern     = regexp.MustCompile(`\A[0-9a-z]+\z`)
+	protocolPattern     = regexp.MustCompile(`\A\d+\.\d+\z`)
+)
+
+// Metadata represents the metadata of a Terraform module or provider version
+type Metadata struct {
+	Kind      string   `json:"kind"`
+	Readme    string   `json:"readme,omitempty"`
+	Protocols []string `json:"protocols,omitempty"`
+}
+
+// IsValidModuleName tests if the name and the target system of a module are valid
+func IsValidModuleName(name, system string) bool {
+	return moduleNamePattern.MatchString(name) && moduleNamePattern.MatchString(system)
+}
+
+// ModulePackageName returns the package name of the module, the name and the target system are joined with a slash
+func ModulePackageName(name, system string) string {
+	return name + "/" + system
+}
+
+// ModuleFilename returns the name of the archive of the module version
+func ModuleFilename(name, system, version string) string {
+	return fmt.Sprintf("%s-%s-%s.tar.gz", name, system, version)
+}
+
+// IsValidProviderType tests if the type of a provider is valid
+func IsValidProviderType(providerType string) bool {
+	return providerTypePattern.MatchString(providerType)
+}
+
+// ProviderFilename returns the name of the archive of the provider version for the platform
+func ProviderFilename(providerType, version, os, arch string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_%s_%s.zip", providerType, version, os, arch)
+}
+
+// ProviderManifestFilename returns the name of the manifest of the provider version
+func ProviderManifestFilename(providerType, version string) string {
+	return fmt.Sprintf("terraform-provider-%s_%s_manifest.json", providerType, version)
+}
+
+// Platform is an operating system and architecture a provider is built for
+type Platform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+// ParseProviderFilename parses the platform of a provider archive.
+// The manifest of the provider version has no platform, isManifest is set then.
+func ParseProviderFilename(filename, providerType, version string) (platform *Platform, isManifest bool, err error) {
+	if filename == ProviderManifestFilename(providerType, version) {
+		return nil, true, nil
+	}
+
+	prefix := fmt.Sprintf("terraform-provider-%s_%s_", providerType, version)
+	if !strings.HasPrefix(filename, prefix) || !strings.HasSuffix(filename, ".zip") {
+		return nil, false, ErrInvalidFilename
+	}
+	os, arch, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(filename, prefix), ".zip"), "_")
+	if !ok || !platformPattern.MatchString(os) || !platformPattern.MatchString(arch) {
+		return nil, false, ErrInvalidFilename
+	}
+	return &Platform{OS: os, Arch: arch}, false, nil
+}
+
+// ParseProviderManifest parses the plugin protocol versions from the manifest of a provider
+// https://developer.hashicorp.com/terraform/registry/providers/publishing#terraform-registry-manifest-file
+func ParseProviderManifest(r io.Reader) ([]string, error) {
+	var manifest struct {
+		Version  int `json:"version"`
+		Metadata struct {
+			ProtocolVersions []string `json:"protocol_versions"`
+		} `json:"metadata"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r, maxManifestSize)).Decode(&manifest); err != nil {
+		return nil, ErrInvalidManifest
+	}
+	if manifest.Version != 1 || len(manifest.Metadata.ProtocolVersions) == 0 {
+		return nil, ErrInvalidManifest
+	}
+	for _, protocol := range manifest.Metadata.ProtocolVersions {
+		if !protocolPattern.MatchString(protocol) {
+			return nil, ErrInvalidManifest
+		}
+	}
+	return manifest.Metadata.ProtocolVersions, nil
+}
+
+// ParseModuleArchive validates the tar.gz archive of a module and extracts the readme from the module root
+func ParseModuleArchive(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	defer gzr.Close()
+
+	m := &Metadata{
+		Kind: KindModule,
+	}
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidArchive
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if strings.EqualFold(path.Clean(hd.Name), "readme.md") {
+			data, err := util.ReadWithLimit(tr, maxReadmeSize)
+			if err != nil {
+				return nil, err
+			}
+			m.Readme = string(data)
+		}
+	}
+	return m, nil
+}
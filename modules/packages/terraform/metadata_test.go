@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidModuleName(t *testing.T) {
+	assert.True(t, IsValidModuleName("consul", "aws"))
+	assert.True(t, IsValidModuleName("my-module_1", "azurerm"))
+	assert.False(t, IsValidModuleName("-consul", "aws"))
+	assert.False(t, IsValidModuleName("consul", "aws/x"))
+	assert.False(t, IsValidModuleName("", "aws"))
+	assert.False(t, IsValidModuleName(strings.Repeat("a", 65), "aws"))
+}
+
+func TestIsValidProviderType(t *testing.T) {
+	assert.True(t, IsValidProviderType("random"))
+	assert.True(t, IsValidProviderType("my-provider"))
+	assert.False(t, IsValidProviderType("Random"))
+	assert.False(t, IsValidProviderType("my_provider"))
+}
+
+func TestParseProviderFilename(t *testing.T) {
+	platform, isManifest, err := ParseProviderFilename("terraform-provider-random_1.0.0_linux_amd64.zip", "random", "1.0.0")
+	require.NoError(t, err)
+	assert.False(t, isManifest)
+	assert.Equal(t, &Platform{OS: "linux", Arch: "amd64"}, platform)
+
+	platform, isManifest, err = ParseProviderFilename("terraform-provider-random_1.0.0_manifest.json", "random", "1.0.0")
+	require.NoError(t, err)
+	assert.True(t, isManifest)
+	assert.Nil(t, platform)
+
+	for _, filename := range []string{
+		"terraform-provider-random_1.0.0_linux_amd64.tar.gz",
+		"terraform-provider-random_1.0.1_linux_amd64.zip",
+		"terraform-provider-other_1.0.0_linux_amd64.zip",
+		"terraform-provider-random_1.0.0_linux.zip",
+		"terraform-provider-random_1.0.0_linux_amd64_v2.zip",
+	} {
+		_, _, err = ParseProviderFilename(filename, "random", "1.0.0")
+		assert.ErrorIs(t, err, ErrInvalidFilename, filename)
+	}
+}
+
+func TestParseProviderManifest(t *testing.T) {
+	protocols, err := ParseProviderManifest(strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["5.0","6.0"]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5.0", "6.0"}, protocols)
+
+	for _, content := range []string{
+		`{`,
+		`{"version":2,"metadata":{"protocol_versions":["5.0"]}}`,
+		`{"version":1,"metadata":{"protocol_versions":[]}}`,
+		`{"version":1,"metadata":{"protocol_versions":["five"]}}`,
+	} {
+		_, err = ParseProviderManifest(strings.NewReader(content))
+		assert.ErrorIs(t, err, ErrInvalidManifest, content)
+	}
+}
+
+func TestParseModuleArchive(t *testing.T) {
+	createArchive := func(files map[string]string) io.Reader {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for filename, content := range files {
+			tw.WriteHeader(&tar.Header{
+				Name: filename,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			io.WriteString(tw, content)
+		}
+		tw.Close()
+		zw.Close()
+		return &buf
+	}
+
+	t.Run("InvalidArchive", func(t *testing.T) {
+		_, err := ParseModuleArchive(strings.NewReader("dummy"))
+		assert.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("Readme", func(t *testing.T) {
+		m, err := ParseModuleArchive(createArchive(map[string]string{
+			"main.tf":             "",
+			"./README.md":         "# Module",
+			"modules/x/README.md": "# Submodule",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, KindModule, m.Kind)
+		assert.Equal(t, "# Module", m.Readme)
+	})
+
+	t.Run("NoReadme", func(t *testing.T) {
+		m, err := ParseModuleArchive(createArchive(map[string]string{"main.tf": ""}))
+		require.NoError(t, err)
+		assert.Empty(t, m.Readme)
+	})
+}
@@ -37,6 +37,7 @@ type PushTrigger string
 const (
 	PushTriggerPRMergeToBase    PushTrigger = "pr-merge-to-base"
 	PushTriggerPRUpdateWithBase PushTrigger = "pr-update-with-base"
+	PushTriggerMergeQueue       PushTrigger = "merge-queue" // the merge queue pushes a batch to its temporary branch
 )
 
 // InternalPushingEnvironment returns an os environment to switch off hooks on push
@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Filter is an equality filter `<attribute> eq <value>`, which is the kind of filter identity providers look up resources with
+type Filter struct {
+	Attribute string // lower case
+	Value     string
+}
+
+// ParseFilter parses an equality filter, only string, boolean and number values are supported
+func ParseFilter(s string) (*Filter, error) {
+	s = strings.TrimSpace(s)
+	attribute, rest, ok := strings.Cut(s, " ")
+	if !ok {
+		return nil, util.NewInvalidArgumentErrorf("invalid filter %q", s)
+	}
+	op, value, ok := strings.Cut(strings.TrimSpace(rest), " ")
+	if !ok || !strings.EqualFold(op, "eq") {
+		return nil, util.NewInvalidArgumentErrorf("unsupported filter %q, only the eq operator is supported", s)
+	}
+
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid filter value %s", value)
+		}
+		value = unquoted
+	} else if strings.ContainsAny(value, " ()[]") {
+		return nil, util.NewInvalidArgumentErrorf("unsupported filter %q, logical operators are not supported", s)
+	}
+
+	return &Filter{Attribute: strings.ToLower(attribute), Value: value}, nil
+}
+
+// Path is the attribute path of a patch operation, e.g. `members[value eq "2"]` or `name.givenName`
+type Path struct {
+	Attribute    string  // lower case
+	SubAttribute string  // lower case
+	ValueFilter  *Filter // filter of the values of a multi-valued attribute
+}
+
+// ParsePath parses the attribute path of a patch operation, an empty path addresses the whole resource
+func ParsePath(s string) (*Path, error) {
+	s = strings.TrimSpace(s)
+	// attribute names may be prefixed by the URN of their schema
+	for _, schema := range []string{SchemaUser + ":", SchemaGroup + ":"} {
+		if len(s) > len(schema) && strings.EqualFold(s[:len(schema)], schema) {
+			s = s[len(schema):]
+		}
+	}
+
+	p := &Path{}
+	if start := strings.IndexByte(s, '['); start >= 0 {
+		end := strings.LastIndexByte(s, ']')
+		if end < start {
+			return nil, util.NewInvalidArgumentErrorf("invalid path %q", s)
+		}
+		filter, err := ParseFilter(s[start+1 : end])
+		if err != nil {
+			return nil, err
+		}
+		p.ValueFilter = filter
+		s = s[:start] + s[end+1:]
+	}
+
+	attribute, subAttribute, _ := strings.Cut(strings.ToLower(s), ".")
+	p.Attribute, p.SubAttribute = attribute, subAttribute
+	return p, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter(`userName eq "Alice"`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Filter{Attribute: "username", Value: "Alice"}, f)
+
+	f, err = ParseFilter(`active EQ true`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Filter{Attribute: "active", Value: "true"}, f)
+
+	f, err = ParseFilter(`displayName eq "org/team with spaces"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "org/team with spaces", f.Value)
+
+	for _, s := range []string{
+		`userName`,
+		`userName sw "a"`,
+		`userName eq "a`,
+		`userName eq a and active eq true`,
+	} {
+		_, err = ParseFilter(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	p, err := ParsePath("name.givenName")
+	assert.NoError(t, err)
+	assert.Equal(t, &Path{Attribute: "name", SubAttribute: "givenname"}, p)
+
+	p, err = ParsePath(`emails[type eq "work"].value`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Path{Attribute: "emails", SubAttribute: "value", ValueFilter: &Filter{Attribute: "type", Value: "work"}}, p)
+
+	p, err = ParsePath(`members[value eq "2"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, &Path{Attribute: "members", ValueFilter: &Filter{Attribute: "value", Value: "2"}}, p)
+
+	p, err = ParsePath(SchemaUser + ":active")
+	assert.NoError(t, err)
+	assert.Equal(t, &Path{Attribute: "active"}, p)
+
+	_, err = ParsePath(`members]value eq "2"[`)
+	assert.Error(t, err)
+}
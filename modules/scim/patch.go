@@ -0,0 +1,276 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const (
+	opAdd     = "add"
+	opReplace = "replace"
+	opRemove  = "remove"
+)
+
+// PatchOperation is an operation of a patch request
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// PatchRequest modifies the attributes of a resource
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// forEach calls fn for the attribute path and value of every operation of the request.
+// An operation without path sets the attributes of its value, which is an object.
+func (r *PatchRequest) forEach(fn func(op string, path *Path, value json.RawMessage) error) error {
+	for _, operation := range r.Operations {
+		op := strings.ToLower(operation.Op)
+		if op != opAdd && op != opReplace && op != opRemove {
+			return util.NewInvalidArgumentErrorf("unsupported patch operation %q", operation.Op)
+		}
+
+		if operation.Path != "" {
+			path, err := ParsePath(operation.Path)
+			if err != nil {
+				return err
+			}
+			if err := fn(op, path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return util.NewInvalidArgumentErrorf("the value of a patch operation without path must be an object")
+		}
+		for name, value := range attributes {
+			path, err := ParsePath(name)
+			if err != nil {
+				return err
+			}
+			if err := fn(op, path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyToUser applies the operations of the request to a user, attributes which are not stored are ignored
+func (r *PatchRequest) ApplyToUser(u *User) error {
+	return r.forEach(func(op string, path *Path, value json.RawMessage) error {
+		switch path.Attribute {
+		case "active":
+			if op == opRemove {
+				return util.NewInvalidArgumentErrorf("active can not be removed")
+			}
+			active, err := unmarshalBool(value)
+			if err != nil {
+				return err
+			}
+			u.Active = &active
+		case "username":
+			if op == opRemove {
+				return util.NewInvalidArgumentErrorf("userName can not be removed")
+			}
+			return unmarshalString(value, &u.UserName)
+		case "externalid":
+			if op == opRemove {
+				u.ExternalID = ""
+				return nil
+			}
+			return unmarshalString(value, &u.ExternalID)
+		case "displayname":
+			if op == opRemove {
+				u.DisplayName = ""
+				return nil
+			}
+			return unmarshalString(value, &u.DisplayName)
+		case "name":
+			return patchName(u, op, path, value)
+		case "emails":
+			return patchEmails(u, op, path, value)
+		}
+		return nil
+	})
+}
+
+func patchName(u *User, op string, path *Path, value json.RawMessage) error {
+	if u.Name == nil {
+		u.Name = &Name{}
+	}
+	var field *string
+	switch path.SubAttribute {
+	case "":
+		if op == opRemove {
+			u.Name = nil
+			return nil
+		}
+		return json.Unmarshal(value, u.Name)
+	case "givenname":
+		field = &u.Name.GivenName
+	case "familyname":
+		field = &u.Name.FamilyName
+	case "formatted":
+		field = &u.Name.Formatted
+	default:
+		return nil
+	}
+	if op == opRemove {
+		*field = ""
+		return nil
+	}
+	return unmarshalString(value, field)
+}
+
+func patchEmails(u *User, op string, path *Path, value json.RawMessage) error {
+	if path.ValueFilter == nil && path.SubAttribute == "" {
+		if op == opRemove {
+			u.Emails = nil
+			return nil
+		}
+		var emails []MultiValue
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid emails: %v", err)
+		}
+		if op == opReplace {
+			u.Emails = emails
+		} else {
+			u.Emails = append(u.Emails, emails...)
+		}
+		return nil
+	}
+
+	// e.g. emails[type eq "work"].value, which is how most identity providers change the email address
+	if path.SubAttribute != "" && path.SubAttribute != "value" {
+		return nil
+	}
+	idx := -1
+	for i, email := range u.Emails {
+		if path.ValueFilter == nil || matchesValue(email, path.ValueFilter) {
+			idx = i
+			break
+		}
+	}
+	if op == opRemove {
+		if idx >= 0 {
+			u.Emails = append(u.Emails[:idx], u.Emails[idx+1:]...)
+		}
+		return nil
+	}
+
+	var address string
+	if err := unmarshalString(value, &address); err != nil {
+		return err
+	}
+	if idx < 0 {
+		email := MultiValue{Primary: len(u.Emails) == 0}
+		if path.ValueFilter != nil && path.ValueFilter.Attribute == "type" {
+			email.Type = path.ValueFilter.Value
+		}
+		u.Emails = append(u.Emails, email)
+		idx = len(u.Emails) - 1
+	}
+	u.Emails[idx].Value = address
+	return nil
+}
+
+func matchesValue(v MultiValue, filter *Filter) bool {
+	switch filter.Attribute {
+	case "value":
+		return strings.EqualFold(v.Value, filter.Value)
+	case "type":
+		return strings.EqualFold(v.Type, filter.Value)
+	case "primary":
+		primary, _ := strconv.ParseBool(filter.Value)
+		return v.Primary == primary
+	}
+	return false
+}
+
+// GroupChanges are the changes of a group requested by a patch request
+type GroupChanges struct {
+	DisplayName    string
+	AddMembers     []string
+	RemoveMembers  []string
+	ReplaceMembers bool // whether AddMembers replace the members of the group
+}
+
+// GroupChanges returns the changes of a group requested by the operations of the request
+func (r *PatchRequest) GroupChanges() (*GroupChanges, error) {
+	changes := &GroupChanges{}
+	err := r.forEach(func(op string, path *Path, value json.RawMessage) error {
+		switch path.Attribute {
+		case "displayname":
+			if op == opRemove {
+				return util.NewInvalidArgumentErrorf("displayName can not be removed")
+			}
+			return unmarshalString(value, &changes.DisplayName)
+		case "members":
+			var ids []string
+			if path.ValueFilter != nil {
+				if path.ValueFilter.Attribute != "value" {
+					return util.NewInvalidArgumentErrorf("members can only be filtered by value")
+				}
+				ids = []string{path.ValueFilter.Value}
+			} else if len(value) > 0 && string(value) != "null" {
+				var members []MultiValue
+				if err := json.Unmarshal(value, &members); err != nil {
+					return util.NewInvalidArgumentErrorf("invalid members: %v", err)
+				}
+				for _, member := range members {
+					ids = append(ids, member.Value)
+				}
+			}
+
+			switch op {
+			case opAdd:
+				changes.AddMembers = append(changes.AddMembers, ids...)
+			case opReplace:
+				changes.AddMembers, changes.RemoveMembers, changes.ReplaceMembers = ids, nil, true
+			case opRemove:
+				if path.ValueFilter == nil && len(ids) == 0 {
+					// removing the attribute removes all members
+					changes.AddMembers, changes.RemoveMembers, changes.ReplaceMembers = nil, nil, true
+					return nil
+				}
+				changes.RemoveMembers = append(changes.RemoveMembers, ids...)
+			}
+		}
+		return nil
+	})
+	return changes, err
+}
+
+func unmarshalString(value json.RawMessage, s *string) error {
+	if err := json.Unmarshal(value, s); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid value %s, expected a string", value)
+	}
+	return nil
+}
+
+// unmarshalBool decodes a boolean, some identity providers send booleans as strings like "False"
+func unmarshalBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, util.NewInvalidArgumentErrorf("invalid value %s, expected a boolean", value)
+}
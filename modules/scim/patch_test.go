@@ -0,0 +1,68 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parsePatchRequest(t *testing.T, s string) *PatchRequest {
+	req := &PatchRequest{}
+	require.NoError(t, json.Unmarshal([]byte(s), req))
+	return req
+}
+
+func TestApplyToUser(t *testing.T) {
+	u := &User{
+		UserName: "alice",
+		Emails:   []MultiValue{{Value: "alice@example.com", Type: "work", Primary: true}},
+	}
+
+	req := parsePatchRequest(t, `{"Operations":[
+		{"op":"Replace","path":"active","value":"False"},
+		{"op":"replace","path":"emails[type eq \"work\"].value","value":"alice@example.org"},
+		{"op":"add","value":{"name.givenName":"Alice","name.familyName":"Liddell","externalId":"ext-1"}}
+	]}`)
+	assert.NoError(t, req.ApplyToUser(u))
+	assert.False(t, *u.Active)
+	assert.Equal(t, "alice@example.org", u.PrimaryEmail())
+	assert.Equal(t, "Alice Liddell", u.FullName())
+	assert.Equal(t, "ext-1", u.ExternalID)
+
+	req = parsePatchRequest(t, `{"Operations":[{"op":"remove","path":"userName"}]}`)
+	assert.Error(t, req.ApplyToUser(u))
+
+	req = parsePatchRequest(t, `{"Operations":[{"op":"move","path":"userName"}]}`)
+	assert.Error(t, req.ApplyToUser(u))
+}
+
+func TestGroupChanges(t *testing.T) {
+	req := parsePatchRequest(t, `{"Operations":[
+		{"op":"add","path":"members","value":[{"value":"1"},{"value":"2"}]},
+		{"op":"remove","path":"members[value eq \"3\"]"}
+	]}`)
+	changes, err := req.GroupChanges()
+	assert.NoError(t, err)
+	assert.Equal(t, &GroupChanges{AddMembers: []string{"1", "2"}, RemoveMembers: []string{"3"}}, changes)
+
+	req = parsePatchRequest(t, `{"Operations":[
+		{"op":"replace","value":{"displayName":"org/team","members":[{"value":"4"}]}}
+	]}`)
+	changes, err = req.GroupChanges()
+	assert.NoError(t, err)
+	assert.Equal(t, "org/team", changes.DisplayName)
+	assert.Equal(t, []string{"4"}, changes.AddMembers)
+	assert.True(t, changes.ReplaceMembers)
+
+	req = parsePatchRequest(t, `{"Operations":[{"op":"remove","path":"members"}]}`)
+	changes, err = req.GroupChanges()
+	assert.NoError(t, err)
+	assert.Empty(t, changes.AddMembers)
+	assert.True(t, changes.ReplaceMembers)
+}
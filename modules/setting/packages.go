@@ -31,7 +31,9 @@ var (
 		LimitSizeGeneric     int64
 		LimitSizeGo          int64
 		LimitSizeHelm        int64
+		LimitSizeHex         int64
 		LimitSizeMaven       int64
+		LimitSizeNix         int64
 		LimitSizeNpm         int64
 		LimitSizeNuGet       int64
 		LimitSizePub         int64
@@ -39,12 +41,18 @@ var (
 		LimitSizeRpm         int64
 		LimitSizeRubyGems    int64
 		LimitSizeSwift       int64
+		LimitSizeTerraform   int64
 		LimitSizeVagrant     int64
 
 		DefaultRPMSignEnabled bool
+
+		RemoteAllowedHostList string
+
+		ScannerEnabled bool
 	}{
 		Enabled:              true,
 		LimitTotalOwnerCount: -1,
+		ScannerEnabled:       true,
 	}
 )
 
@@ -78,7 +86,9 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeGeneric = mustBytes(sec, "LIMIT_SIZE_GENERIC")
 	Packages.LimitSizeGo = mustBytes(sec, "LIMIT_SIZE_GO")
 	Packages.LimitSizeHelm = mustBytes(sec, "LIMIT_SIZE_HELM")
+	Packages.LimitSizeHex = mustBytes(sec, "LIMIT_SIZE_HEX")
 	Packages.LimitSizeMaven = mustBytes(sec, "LIMIT_SIZE_MAVEN")
+	Packages.LimitSizeNix = mustBytes(sec, "LIMIT_SIZE_NIX")
 	Packages.LimitSizeNpm = mustBytes(sec, "LIMIT_SIZE_NPM")
 	Packages.LimitSizeNuGet = mustBytes(sec, "LIMIT_SIZE_NUGET")
 	Packages.LimitSizePub = mustBytes(sec, "LIMIT_SIZE_PUB")
@@ -86,8 +96,11 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeRpm = mustBytes(sec, "LIMIT_SIZE_RPM")
 	Packages.LimitSizeRubyGems = mustBytes(sec, "LIMIT_SIZE_RUBYGEMS")
 	Packages.LimitSizeSwift = mustBytes(sec, "LIMIT_SIZE_SWIFT")
+	Packages.LimitSizeTerraform = mustBytes(sec, "LIMIT_SIZE_TERRAFORM")
 	Packages.LimitSizeVagrant = mustBytes(sec, "LIMIT_SIZE_VAGRANT")
 	Packages.DefaultRPMSignEnabled = sec.Key("DEFAULT_RPM_SIGN_ENABLED").MustBool(false)
+	Packages.RemoteAllowedHostList = sec.Key("REMOTE_ALLOWED_HOST_LIST").MustString("")
+	Packages.ScannerEnabled = sec.Key("SCANNER_ENABLED").MustBool(true)
 	return nil
 }
 
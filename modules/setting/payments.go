@@ -0,0 +1,17 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+// Payments settings, organizations need a subscription managed by the payments sidecar if they are enabled
+var Payments = struct {
+	Enabled       bool
+	SidecarURL    string `ini:"SIDECAR_URL"`
+	WebhookSecret string `ini:"WEBHOOK_SECRET"`
+}{
+	SidecarURL: "http://payments:9000",
+}
+
+func loadPaymentsFrom(rootCfg ConfigProvider) {
+	mustMapSetting(rootCfg, "payments", &Payments)
+}
@@ -138,6 +138,10 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	if err := loadActionsFrom(cfg); err != nil {
 		return err
 	}
+	if err := loadTerraformFrom(cfg); err != nil {
+		return err
+	}
+	loadQuotaFrom(cfg)
 	loadUIFrom(cfg)
 	loadAdminFrom(cfg)
 	loadAPIFrom(cfg)
@@ -146,6 +150,7 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	loadI18nFrom(cfg)
 	loadGitFrom(cfg)
 	loadMirrorFrom(cfg)
+	loadPaymentsFrom(cfg)
 	loadMarkupFrom(cfg)
 	loadGlobalLockFrom(cfg)
 	loadOtherFrom(cfg)
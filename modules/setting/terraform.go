@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"fmt"
+)
+
+const defaultTerraformMaxStateSize = 64 * 1024 * 1024
+
+// Terraform state backend settings
+var Terraform = struct {
+	Storage *Storage
+	Enabled bool
+
+	MaxStateSize     int64 `ini:"-"`
+	MaxStateVersions int
+}{
+	Enabled:          true,
+	MaxStateSize:     defaultTerraformMaxStateSize,
+	MaxStateVersions: 100,
+}
+
+func loadTerraformFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("terraform")
+	if sec == nil {
+		Terraform.Storage, err = getStorage(rootCfg, "terraform", "", nil)
+		return err
+	}
+
+	if err = sec.MapTo(&Terraform); err != nil {
+		return fmt.Errorf("failed to map Terraform settings: %v", err)
+	}
+
+	if sec.HasKey("MAX_STATE_SIZE") {
+		Terraform.MaxStateSize = mustBytes(sec, "MAX_STATE_SIZE")
+	}
+
+	Terraform.Storage, err = getStorage(rootCfg, "terraform", "", sec)
+	return err
+}
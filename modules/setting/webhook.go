@@ -5,28 +5,37 @@ package setting
 
 import (
 	"net/url"
+	"time"
 
 	"code.gitea.io/gitea/modules/log"
 )
 
 // Webhook settings
 var Webhook = struct {
-	QueueLength     int
-	DeliverTimeout  int
-	SkipTLSVerify   bool
-	AllowedHostList string
-	Types           []string
-	PagingNum       int
-	ProxyURL        string
-	ProxyURLFixed   *url.URL
-	ProxyHosts      []string
+	QueueLength          int
+	DeliverTimeout       int
+	SkipTLSVerify        bool
+	AllowedHostList      string
+	Types                []string
+	PagingNum            int
+	ProxyURL             string
+	ProxyURLFixed        *url.URL
+	ProxyHosts           []string
+	MaxAttempts          int
+	RetryBackoff         time.Duration
+	RetryMaxBackoff      time.Duration
+	AutoDisableThreshold int
 }{
-	QueueLength:    1000,
-	DeliverTimeout: 5,
-	SkipTLSVerify:  false,
-	PagingNum:      10,
-	ProxyURL:       "",
-	ProxyHosts:     []string{},
+	QueueLength:          1000,
+	DeliverTimeout:       5,
+	SkipTLSVerify:        false,
+	PagingNum:            10,
+	ProxyURL:             "",
+	ProxyHosts:           []string{},
+	MaxAttempts:          3,
+	RetryBackoff:         time.Minute,
+	RetryMaxBackoff:      time.Hour,
+	AutoDisableThreshold: 0,
 }
 
 func loadWebhookFrom(rootCfg ConfigProvider) {
@@ -35,7 +44,7 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 	Webhook.DeliverTimeout = sec.Key("DELIVER_TIMEOUT").MustInt(5)
 	Webhook.SkipTLSVerify = sec.Key("SKIP_TLS_VERIFY").MustBool()
 	Webhook.AllowedHostList = sec.Key("ALLOWED_HOST_LIST").MustString("")
-	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist"}
+	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist", "custom"}
 	Webhook.PagingNum = sec.Key("PAGING_NUM").MustInt(10)
 	Webhook.ProxyURL = sec.Key("PROXY_URL").MustString("")
 	if Webhook.ProxyURL != "" {
@@ -47,4 +56,8 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 		}
 	}
 	Webhook.ProxyHosts = sec.Key("PROXY_HOSTS").Strings(",")
+	Webhook.MaxAttempts = max(sec.Key("MAX_ATTEMPTS").MustInt(3), 1)
+	Webhook.RetryBackoff = sec.Key("RETRY_BACKOFF").MustDuration(time.Minute)
+	Webhook.RetryMaxBackoff = sec.Key("RETRY_MAX_BACKOFF").MustDuration(time.Hour)
+	Webhook.AutoDisableThreshold = sec.Key("AUTO_DISABLE_THRESHOLD").MustInt(0)
 }
@@ -133,6 +133,9 @@ var (
 	Actions ObjectStorage = uninitializedStorage
 	// Actions Artifacts represents actions artifacts storage
 	ActionsArtifacts ObjectStorage = uninitializedStorage
+
+	// TerraformStates represents the storage of the Terraform state versions
+	TerraformStates ObjectStorage = uninitializedStorage
 )
 
 // Init init the storage
@@ -145,6 +148,7 @@ func Init() error {
 		initRepoArchives,
 		initPackages,
 		initActions,
+		initTerraformStates,
 	} {
 		if err := f(); err != nil {
 			return err
@@ -228,3 +232,13 @@ func initActions() (err error) {
 	ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage)
 	return err
 }
+
+func initTerraformStates() (err error) {
+	if !setting.Terraform.Enabled {
+		TerraformStates = discardStorage("Terraform state backend isn't enabled")
+		return nil
+	}
+	log.Info("Initialising Terraform state storage with type: %s", setting.Terraform.Storage.Type)
+	TerraformStates, err = NewStorage(setting.Terraform.Storage.Type, setting.Terraform.Storage)
+	return err
+}
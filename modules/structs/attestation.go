@@ -0,0 +1,52 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// Attestation represents a signed in-toto statement about artifacts produced by an actions run
+type Attestation struct {
+	// ID is the unique identifier of the attestation
+	ID int64 `json:"id"`
+	// Repository is the full name of the repository of the run
+	Repository string `json:"repository"`
+	// RunID is the identifier of the run which produced the artifacts
+	RunID int64 `json:"run_id"`
+	// CommitSHA is the commit the run was triggered for
+	CommitSHA string `json:"commit_sha"`
+	// Workflow is the workflow file of the run
+	Workflow string `json:"workflow"`
+	// PredicateType is the type of the statement, e.g. https://slsa.dev/provenance/v1
+	PredicateType string `json:"predicate_type"`
+	// Envelope is the DSSE envelope carrying the signed statement, which includes the identity of the job added by the server
+	Envelope *AttestationEnvelope `json:"envelope"`
+	// Verified tells whether the signature of the attestation is valid, its signed identity matches the run and the commit
+	// of the attestation and the statement describes the requested digest
+	Verified bool `json:"verified"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AttestationEnvelope represents a DSSE envelope
+type AttestationEnvelope struct {
+	PayloadType string                  `json:"payloadType"`
+	Payload     string                  `json:"payload"`
+	Signatures  []*AttestationSignature `json:"signatures"`
+}
+
+// AttestationSignature represents a signature of a DSSE envelope
+type AttestationSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// CreateAttestationOption options to upload an attestation from an actions job
+type CreateAttestationOption struct {
+	// Statement is the in-toto statement, e.g. a SLSA build provenance or an SBOM.
+	// The identity of the job (repository, workflow ref, run and commit) is added to it as `identity` before it is signed.
+	// required: true
+	Statement map[string]any `json:"statement" binding:"Required"`
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// AuditEvent represents a security relevant change recorded in the audit log
+type AuditEvent struct {
+	// ID is the unique identifier of the event
+	ID int64 `json:"id"`
+	// Action is the kind of the change, e.g. collaborator_add or secret_delete
+	Action string `json:"action"`
+	// ActorID is the identifier of the user who made the change, 0 for changes made by the system
+	ActorID int64 `json:"actor_id"`
+	// ActorName is the name of the user who made the change
+	ActorName string `json:"actor_name"`
+	// OwnerID is the identifier of the user or organization owning the target, 0 for instance wide events
+	OwnerID int64 `json:"owner_id"`
+	// Repository is the full name of the repository of the target
+	Repository string `json:"repository,omitempty"`
+	// TargetType is the type of the changed object, e.g. team or branch_protection
+	TargetType string `json:"target_type"`
+	// TargetID is the identifier of the changed object
+	TargetID int64 `json:"target_id"`
+	// TargetName is the name of the changed object
+	TargetName string `json:"target_name"`
+	// Before is the value of the target before the change
+	Before any `json:"before,omitempty"`
+	// After is the value of the target after the change
+	After any `json:"after,omitempty"`
+	// IPAddress is the address the change was requested from
+	IPAddress string `json:"ip_address"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
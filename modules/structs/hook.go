@@ -23,6 +23,8 @@ type Hook struct {
 	Type string `json:"type"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter"`
+	// Condition expression to determine which events trigger the webhook
+	Condition string `json:"condition"`
 	// The URL of the webhook endpoint (hidden in JSON)
 	URL string `json:"-"`
 	// Configuration settings for the webhook
@@ -33,6 +35,8 @@ type Hook struct {
 	AuthorizationHeader string `json:"authorization_header"`
 	// Whether the webhook is active and will be triggered
 	Active bool `json:"active"`
+	// Number of attempts to deliver an event, 0 uses the default of the instance
+	MaxAttempts int `json:"max_attempts"`
 	// swagger:strfmt date-time
 	// The date and time when the webhook was last updated
 	Updated time.Time `json:"updated_at"`
@@ -51,7 +55,7 @@ type CreateHookOptionConfig map[string]string
 // CreateHookOption options when create a hook
 type CreateHookOption struct {
 	// required: true
-	// enum: dingtalk,discord,gitea,gogs,msteams,slack,telegram,feishu,wechatwork,packagist
+	// enum: dingtalk,discord,gitea,gogs,msteams,slack,telegram,feishu,wechatwork,packagist,custom
 	// The type of the webhook to create
 	Type string `json:"type" binding:"Required"`
 	// required: true
@@ -61,11 +65,15 @@ type CreateHookOption struct {
 	Events []string `json:"events"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter" binding:"GlobPattern"`
+	// Condition expression to determine which events trigger the webhook
+	Condition string `json:"condition" binding:"WebhookCondition"`
 	// Authorization header to include in webhook requests
 	AuthorizationHeader string `json:"authorization_header"`
 	// default: false
 	// Whether the webhook should be active upon creation
 	Active bool `json:"active"`
+	// Number of attempts to deliver an event, 0 uses the default of the instance
+	MaxAttempts int `json:"max_attempts" binding:"Range(0,10)"`
 }
 
 // EditHookOption options when modify one hook
@@ -76,10 +84,14 @@ type EditHookOption struct {
 	Events []string `json:"events"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter" binding:"GlobPattern"`
+	// Condition expression to determine which events trigger the webhook
+	Condition string `json:"condition" binding:"WebhookCondition"`
 	// Authorization header to include in webhook requests
 	AuthorizationHeader string `json:"authorization_header"`
 	// Whether the webhook is active and will be triggered
 	Active *bool `json:"active"`
+	// Number of attempts to deliver an event, 0 uses the default of the instance
+	MaxAttempts *int `json:"max_attempts"`
 }
 
 // Payloader payload is some part of one hook
@@ -150,6 +162,16 @@ var (
 	_ Payloader = &RepositoryPayload{}
 	_ Payloader = &ReleasePayload{}
 	_ Payloader = &PackagePayload{}
+	_ Payloader = &PackageVulnerabilityPayload{}
+	_ Payloader = &MemberPayload{}
+	_ Payloader = &OrganizationPayload{}
+	_ Payloader = &TeamPayload{}
+	_ Payloader = &MembershipPayload{}
+	_ Payloader = &BranchProtectionPayload{}
+	_ Payloader = &DeployKeyPayload{}
+	_ Payloader = &UserPayload{}
+	_ Payloader = &StarPayload{}
+	_ Payloader = &WatchPayload{}
 )
 
 // CreatePayload represents a payload information of create event.
@@ -498,6 +520,10 @@ const (
 	HookRepoCreated HookRepoAction = "created"
 	// HookRepoDeleted deleted
 	HookRepoDeleted HookRepoAction = "deleted"
+	// HookRepoPublicized made public
+	HookRepoPublicized HookRepoAction = "publicized"
+	// HookRepoPrivatized made private
+	HookRepoPrivatized HookRepoAction = "privatized"
 )
 
 // RepositoryPayload payload for repository webhooks
@@ -546,6 +572,292 @@ func (p *PackagePayload) JSONPayload() ([]byte, error) {
 	return json.MarshalIndent(p, "", "  ")
 }
 
+// HookPackageVulnerabilityAction an action that happens to the vulnerabilities of a package
+type HookPackageVulnerabilityAction string
+
+const (
+	// HookPackageVulnerabilityDetected detected
+	HookPackageVulnerabilityDetected HookPackageVulnerabilityAction = "detected"
+)
+
+// PackageVulnerabilityPayload represents a payload of newly detected vulnerabilities of a package version
+type PackageVulnerabilityPayload struct {
+	// The action performed on the vulnerabilities
+	Action HookPackageVulnerabilityAction `json:"action"`
+	// The repository associated with the package
+	Repository *Repository `json:"repository"`
+	// The package version containing the vulnerabilities
+	Package *Package `json:"package"`
+	// The newly detected vulnerabilities
+	Vulnerabilities []*PackageVulnerability `json:"vulnerabilities"`
+	// The organization that owns the package (if applicable)
+	Organization *Organization `json:"organization"`
+	// The user who published the package version
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *PackageVulnerabilityPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookMemberAction an action that happens to a collaborator of a repository
+type HookMemberAction string
+
+const (
+	// HookMemberAdded added
+	HookMemberAdded HookMemberAction = "added"
+	// HookMemberEdited permission changed
+	HookMemberEdited HookMemberAction = "edited"
+	// HookMemberRemoved removed
+	HookMemberRemoved HookMemberAction = "removed"
+)
+
+// MemberPayload represents a payload of a collaborator change of a repository
+type MemberPayload struct {
+	// The action performed on the collaborator
+	Action HookMemberAction `json:"action"`
+	// The repository of the collaborator
+	Repository *Repository `json:"repository"`
+	// The collaborator that was acted upon
+	Member *User `json:"member"`
+	// The permission of the collaborator, empty if removed
+	Permission string `json:"permission,omitempty"`
+	// The previous permission of the collaborator if edited
+	PreviousPermission string `json:"previous_permission,omitempty"`
+	// The user who performed the action
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *MemberPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookOrganizationAction an action that happens to the members of an organization
+type HookOrganizationAction string
+
+const (
+	// HookOrganizationMemberAdded member added
+	HookOrganizationMemberAdded HookOrganizationAction = "member_added"
+	// HookOrganizationMemberRemoved member removed
+	HookOrganizationMemberRemoved HookOrganizationAction = "member_removed"
+)
+
+// OrganizationPayload represents a payload of a membership change of an organization
+type OrganizationPayload struct {
+	// The action performed on the organization
+	Action HookOrganizationAction `json:"action"`
+	// The organization that was acted upon
+	Organization *Organization `json:"organization"`
+	// The user who joined or left the organization
+	Member *User `json:"member"`
+	// The user who performed the action
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *OrganizationPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookTeamAction an action that happens to a team
+type HookTeamAction string
+
+const (
+	// HookTeamCreated created
+	HookTeamCreated HookTeamAction = "created"
+	// HookTeamEdited edited
+	HookTeamEdited HookTeamAction = "edited"
+	// HookTeamDeleted deleted
+	HookTeamDeleted HookTeamAction = "deleted"
+	// HookTeamAddedToRepository repository access granted
+	HookTeamAddedToRepository HookTeamAction = "added_to_repository"
+	// HookTeamRemovedFromRepository repository access revoked
+	HookTeamRemovedFromRepository HookTeamAction = "removed_from_repository"
+)
+
+// TeamPayload represents a payload of a team change
+type TeamPayload struct {
+	// The action performed on the team
+	Action HookTeamAction `json:"action"`
+	// The team that was acted upon
+	Team *Team `json:"team"`
+	// The organization of the team
+	Organization *Organization `json:"organization"`
+	// The repository added to or removed from the team (if applicable)
+	Repository *Repository `json:"repository,omitempty"`
+	// The user who performed the action
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *TeamPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookMembershipAction an action that happens to the members of a team
+type HookMembershipAction string
+
+const (
+	// HookMembershipAdded added
+	HookMembershipAdded HookMembershipAction = "added"
+	// HookMembershipRemoved removed
+	HookMembershipRemoved HookMembershipAction = "removed"
+)
+
+// MembershipPayload represents a payload of a membership change of a team
+type MembershipPayload struct {
+	// The action performed on the membership
+	Action HookMembershipAction `json:"action"`
+	// The team of the membership
+	Team *Team `json:"team"`
+	// The organization of the team
+	Organization *Organization `json:"organization"`
+	// The user who was added to or removed from the team
+	Member *User `json:"member"`
+	// The user who performed the action
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *MembershipPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookBranchProtectionAction an action that happens to a branch protection rule
+type HookBranchProtectionAction string
+
+const (
+	// HookBranchProtectionCreated created
+	HookBranchProtectionCreated HookBranchProtectionAction = "created"
+	// HookBranchProtectionEdited edited
+	HookBranchProtectionEdited HookBranchProtectionAction = "edited"
+	// HookBranchProtectionDeleted deleted
+	HookBranchProtectionDeleted HookBranchProtectionAction = "deleted"
+)
+
+// BranchProtectionPayload represents a payload of a branch protection rule change
+type BranchProtectionPayload struct {
+	// The action performed on the rule
+	Action HookBranchProtectionAction `json:"action"`
+	// The branch protection rule that was acted upon
+	Rule *BranchProtection `json:"rule"`
+	// The repository of the rule
+	Repository *Repository `json:"repository"`
+	// The user who performed the action
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *BranchProtectionPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookDeployKeyAction an action that happens to a deploy key
+type HookDeployKeyAction string
+
+const (
+	// HookDeployKeyCreated created
+	HookDeployKeyCreated HookDeployKeyAction = "created"
+	// HookDeployKeyDeleted deleted
+	HookDeployKeyDeleted HookDeployKeyAction = "deleted"
+)
+
+// DeployKeyPayload represents a payload of a deploy key change
+type DeployKeyPayload struct {
+	// The action performed on the deploy key
+	Action HookDeployKeyAction `json:"action"`
+	// The deploy key that was acted upon
+	Key *DeployKey `json:"key"`
+	// The repository of the deploy key
+	Repository *Repository `json:"repository"`
+	// The user who performed the action
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *DeployKeyPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookUserAction an action that happens to a user account
+type HookUserAction string
+
+const (
+	// HookUserCreated created
+	HookUserCreated HookUserAction = "created"
+	// HookUserSuspended prohibited from signing in
+	HookUserSuspended HookUserAction = "suspended"
+	// HookUserUnsuspended allowed to sign in again
+	HookUserUnsuspended HookUserAction = "unsuspended"
+)
+
+// UserPayload represents a payload of a user account change, only sent to system webhooks
+type UserPayload struct {
+	// The action performed on the user
+	Action HookUserAction `json:"action"`
+	// The user that was acted upon
+	User *User `json:"user"`
+	// The user who performed the action
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *UserPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookStarAction an action that happens to a star of a repository
+type HookStarAction string
+
+const (
+	// HookStarCreated starred
+	HookStarCreated HookStarAction = "created"
+	// HookStarDeleted unstarred
+	HookStarDeleted HookStarAction = "deleted"
+)
+
+// StarPayload represents a payload of a repository being starred or unstarred
+type StarPayload struct {
+	// The action performed on the star
+	Action HookStarAction `json:"action"`
+	// The repository that was starred or unstarred
+	Repository *Repository `json:"repository"`
+	// The user who starred or unstarred the repository
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *StarPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
+// HookWatchAction an action that happens to a watch of a repository
+type HookWatchAction string
+
+const (
+	// HookWatchStarted started watching
+	HookWatchStarted HookWatchAction = "started"
+	// HookWatchStopped stopped watching
+	HookWatchStopped HookWatchAction = "stopped"
+)
+
+// WatchPayload represents a payload of a repository being watched or unwatched
+type WatchPayload struct {
+	// The action performed on the watch
+	Action HookWatchAction `json:"action"`
+	// The repository that was watched or unwatched
+	Repository *Repository `json:"repository"`
+	// The user who watched or unwatched the repository
+	Sender *User `json:"sender"`
+}
+
+// JSONPayload implements Payload
+func (p *WatchPayload) JSONPayload() ([]byte, error) {
+	return json.MarshalIndent(p, "", "  ")
+}
+
 // WorkflowDispatchPayload represents a workflow dispatch payload
 type WorkflowDispatchPayload struct {
 	// The name or path of the workflow file
@@ -47,3 +47,25 @@ type PackageFile struct {
 	// The SHA512 hash of the package file
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageVulnerability represents a known vulnerability of a component of a package version
+type PackageVulnerability struct {
+	// The id of the advisory in the OSV database
+	ID string `json:"id"`
+	// Other ids of the advisory, usually CVE ids
+	Aliases []string `json:"aliases"`
+	// The summary of the advisory
+	Summary string `json:"summary"`
+	// The severity of the advisory (critical, high, moderate, low), empty if unknown
+	Severity string `json:"severity"`
+	// The URL of the advisory
+	URL string `json:"url"`
+	// The OSV ecosystem of the affected component
+	Ecosystem string `json:"ecosystem"`
+	// The name of the affected component
+	Component string `json:"component"`
+	// The version or the version requirement of the affected component
+	ComponentVersion string `json:"component_version"`
+	// The version fixing the vulnerability, empty if unknown
+	FixedVersion string `json:"fixed_version"`
+}
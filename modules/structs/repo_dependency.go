@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// RepoDependency represents a package the default branch of a repository depends on
+type RepoDependency struct {
+	// Ecosystem is the ecosystem of the package as named by OSV, e.g. "npm", "Go" or "crates.io"
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	// Version is the resolved version or, if the manifest only declares a requirement, the requirement
+	Version string `json:"version"`
+	// PackageURL is the package URL (purl) of the dependency
+	PackageURL string `json:"purl"`
+	// Manifest is the path of the manifest or lockfile which declares the dependency
+	Manifest string `json:"manifest"`
+	// Direct tells whether the repository declares the dependency itself
+	Direct bool `json:"direct"`
+	// CommitID is the commit the dependencies were parsed from
+	CommitID   string      `json:"commit_id"`
+	Repository *Repository `json:"repository,omitempty"`
+}
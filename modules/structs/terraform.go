@@ -0,0 +1,24 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// TerraformState represents a Terraform state stored in a repository
+type TerraformState struct {
+	// Name is the name of the state, it is part of the address of the HTTP backend
+	Name string `json:"name"`
+	// Serial is the serial of the current version of the state
+	Serial int64 `json:"serial"`
+	// Lineage is the lineage of the current version of the state
+	Lineage string `json:"lineage"`
+	// Locked tells whether a Terraform operation holds the lock of the state
+	Locked bool `json:"locked"`
+	// LockID is the identifier of the lock held by a Terraform operation
+	LockID string `json:"lock_id,omitempty"`
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -12,6 +12,7 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/glob"
 	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
 
 	"gitea.com/go-chi/binding"
 )
@@ -27,6 +28,8 @@ const (
 	ErrUsername = "UsernameError"
 	// ErrInvalidGroupTeamMap is returned when a group team mapping is invalid
 	ErrInvalidGroupTeamMap = "InvalidGroupTeamMap"
+	// ErrWebhookCondition is returned when a webhook condition is invalid
+	ErrWebhookCondition = "WebhookCondition"
 )
 
 // AddBindingRules adds additional binding rules
@@ -40,6 +43,7 @@ func AddBindingRules() {
 	addGlobOrRegexPatternRule()
 	addUsernamePatternRule()
 	addValidGroupTeamMapRule()
+	addWebhookConditionRule()
 }
 
 func addGitRefNameBindingRule() {
@@ -145,6 +149,21 @@ func globPatternValidator(errs binding.Errors, name string, val any) (bool, bind
 	return true, errs
 }
 
+func addWebhookConditionRule() {
+	binding.AddRule(&binding.Rule{
+		IsMatch: func(rule string) bool {
+			return rule == "WebhookCondition"
+		},
+		IsValid: func(errs binding.Errors, name string, val any) (bool, binding.Errors) {
+			if _, err := webhook_module.ParseCondition(fmt.Sprintf("%v", val)); err != nil {
+				errs.Add([]string{name}, ErrWebhookCondition, err.Error())
+				return false, errs
+			}
+			return true, errs
+		},
+	})
+}
+
 func addRegexPatternRule() {
 	binding.AddRule(&binding.Rule{
 		IsMatch: func(rule string) bool {
@@ -0,0 +1,265 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/glob"
+)
+
+// Condition is a parsed webhook condition which decides whether an event is delivered to a webhook.
+//
+// The syntax is a list of comparisons combined by "and", "or", "not" and parentheses, for example:
+//
+//	branch matches release/* and (label == "security" or not sender == renovate)
+//
+// A comparison is "<field> <operator> <value>" with one of the operators "==", "!=", "matches" (glob pattern)
+// and "contains" (substring). Values containing spaces or parentheses must be quoted.
+type Condition struct {
+	root conditionNode
+}
+
+// ConditionLookup returns the values of a field. Fields with several values (e.g. labels) match
+// if any of the values matches, "!=" matches if none of the values is equal.
+type ConditionLookup func(field string) []string
+
+type conditionNode interface {
+	match(lookup ConditionLookup) bool
+}
+
+type conditionAnd struct{ left, right conditionNode }
+
+func (n *conditionAnd) match(lookup ConditionLookup) bool {
+	return n.left.match(lookup) && n.right.match(lookup)
+}
+
+type conditionOr struct{ left, right conditionNode }
+
+func (n *conditionOr) match(lookup ConditionLookup) bool {
+	return n.left.match(lookup) || n.right.match(lookup)
+}
+
+type conditionNot struct{ node conditionNode }
+
+func (n *conditionNot) match(lookup ConditionLookup) bool {
+	return !n.node.match(lookup)
+}
+
+type conditionCompare struct {
+	field    string
+	operator string
+	value    string
+	glob     glob.Glob
+}
+
+func (n *conditionCompare) match(lookup ConditionLookup) bool {
+	values := lookup(n.field)
+	switch n.operator {
+	case "==":
+		return slices.Contains(values, n.value)
+	case "!=":
+		return !slices.Contains(values, n.value)
+	case "matches":
+		return slices.ContainsFunc(values, n.glob.Match)
+	case "contains":
+		return slices.ContainsFunc(values, func(v string) bool { return strings.Contains(v, n.value) })
+	}
+	return false
+}
+
+// ParseCondition parses a condition expression. An empty expression returns nil, which matches every event.
+func ParseCondition(s string) (*Condition, error) {
+	tokens, err := tokenizeCondition(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := &conditionParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.peek().text)
+	}
+	return &Condition{root: root}, nil
+}
+
+// Match returns true if the event described by the lookup function matches the condition
+func (c *Condition) Match(lookup ConditionLookup) bool {
+	if c == nil {
+		return true
+	}
+	return c.root.match(lookup)
+}
+
+type conditionToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeCondition(s string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, conditionToken{text: string(c)})
+			i++
+		case c == '"' || c == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				sb.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, conditionToken{text: sb.String(), quoted: true})
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\r()\"'", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, conditionToken{text: s[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (p *conditionParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.pos]
+}
+
+// keyword returns true and consumes the next token if it is one of the given unquoted keywords
+func (p *conditionParser) keyword(keywords ...string) bool {
+	if p.done() || p.peek().quoted || !slices.Contains(keywords, strings.ToLower(p.peek().text)) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or", "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &conditionOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and", "&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &conditionAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseNot() (conditionNode, error) {
+	if p.keyword("not", "!") {
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &conditionNot{node: node}, nil
+	}
+	if p.keyword("(") {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword(")") {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return node, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *conditionParser) parseCompare() (conditionNode, error) {
+	if p.done() {
+		return nil, errors.New("unexpected end of condition")
+	}
+	field := p.peek()
+	if field.quoted || !isConditionField(field.text) {
+		return nil, fmt.Errorf("invalid field %q", field.text)
+	}
+	p.pos++
+
+	if p.done() || p.peek().quoted {
+		return nil, fmt.Errorf("missing operator after %q", field.text)
+	}
+	operator := strings.ToLower(p.peek().text)
+	if !slices.Contains([]string{"==", "!=", "matches", "contains"}, operator) {
+		return nil, fmt.Errorf("invalid operator %q", p.peek().text)
+	}
+	p.pos++
+
+	if p.done() || (!p.peek().quoted && (p.peek().text == "(" || p.peek().text == ")")) {
+		return nil, fmt.Errorf("missing value after %q", operator)
+	}
+	node := &conditionCompare{field: field.text, operator: operator, value: p.peek().text}
+	p.pos++
+
+	if operator == "matches" {
+		g, err := glob.Compile(node.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", node.value, err)
+		}
+		node.glob = g
+	}
+	return node, nil
+}
+
+// isConditionField checks that a field is a (dotted) name like "branch" or "pull_request.base.ref"
+func isConditionField(s string) bool {
+	for part := range strings.SplitSeq(s, ".") {
+		if part == "" {
+			return false
+		}
+		for i, c := range part {
+			if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCondition(t *testing.T) {
+	c, err := ParseCondition("")
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	for _, condition := range []string{
+		"branch == main",
+		`label == "security"`,
+		"branch matches release/* and not sender == renovate",
+		"(event == push or event == create) && ref contains v1",
+		"pull_request.base.ref != 'main'",
+	} {
+		_, err := ParseCondition(condition)
+		assert.NoError(t, err, "condition: %s", condition)
+	}
+
+	for _, condition := range []string{
+		"branch",
+		"branch ==",
+		"branch is main",
+		`"branch" == main`,
+		"branch == main and",
+		"(branch == main",
+		"branch == main)",
+		`label == "security`,
+		"branch matches [",
+		"1branch == main",
+		"pull_request..base == main",
+	} {
+		_, err := ParseCondition(condition)
+		assert.Error(t, err, "condition: %s", condition)
+	}
+}
+
+func TestConditionMatch(t *testing.T) {
+	fields := map[string][]string{
+		"event":  {"pull_request"},
+		"branch": {"release/1.0"},
+		"label":  {"bug", "security"},
+		"sender": {"user2"},
+	}
+	lookup := func(field string) []string {
+		return fields[field]
+	}
+
+	cases := []struct {
+		condition string
+		match     bool
+	}{
+		{"", true},
+		{"branch == release/1.0", true},
+		{"branch == main", false},
+		{"branch matches release/*", true},
+		{"branch matches feature/*", false},
+		{"branch contains 1.0", true},
+		{`label == "security"`, true},
+		{`label == "enhancement"`, false},
+		{"label != bug", false},
+		{"label != enhancement", true},
+		{"tag == v1", false},
+		{"tag != v1", true},
+		{"not sender == user2", false},
+		{"event == push or branch matches release/*", true},
+		{"event == pull_request and label == enhancement", false},
+		{"event == push or event == pull_request and label == bug", true},
+		{"(event == push or event == pull_request) and not label == bug", false},
+		{"EVENT == pull_request", false},
+		{"event MATCHES pull_* AND sender == user2", true},
+	}
+	for _, v := range cases {
+		c, err := ParseCondition(v.condition)
+		require.NoError(t, err, "condition: %s", v.condition)
+		assert.Equal(t, v.match, c.Match(lookup), "condition: %s", v.condition)
+	}
+}
@@ -15,6 +15,7 @@ type HookEvent struct {
 	SendEverything bool   `json:"send_everything"`
 	ChooseEvents   bool   `json:"choose_events"`
 	BranchFilter   string `json:"branch_filter"`
+	Condition      string `json:"condition"`
 
 	HookEvents `json:"events"`
 }
@@ -31,7 +31,18 @@ const (
 	HookEventRepository                HookEventType = "repository"
 	HookEventRelease                   HookEventType = "release"
 	HookEventPackage                   HookEventType = "package"
+	HookEventPackageVulnerability      HookEventType = "package_vulnerability"
 	HookEventStatus                    HookEventType = "status"
+	HookEventMember                    HookEventType = "member"
+	HookEventOrganization              HookEventType = "organization"
+	HookEventTeam                      HookEventType = "team"
+	HookEventMembership                HookEventType = "membership"
+	HookEventBranchProtection          HookEventType = "branch_protection"
+	HookEventDeployKey                 HookEventType = "deploy_key"
+	HookEventRepositoryVisibility      HookEventType = "repository_visibility"
+	HookEventUser                      HookEventType = "user"
+	HookEventStar                      HookEventType = "star"
+	HookEventWatch                     HookEventType = "watch"
 	// once a new event added here, please also added to AllEvents() function
 
 	// FIXME: This event should be a group of pull_request_review_xxx events
@@ -67,7 +78,18 @@ func AllEvents() []HookEventType {
 		HookEventRepository,
 		HookEventRelease,
 		HookEventPackage,
+		HookEventPackageVulnerability,
 		HookEventStatus,
+		HookEventMember,
+		HookEventOrganization,
+		HookEventTeam,
+		HookEventMembership,
+		HookEventBranchProtection,
+		HookEventDeployKey,
+		HookEventRepositoryVisibility,
+		HookEventUser,
+		HookEventStar,
+		HookEventWatch,
 		HookEventWorkflowRun,
 		HookEventWorkflowJob,
 	}
@@ -114,6 +136,7 @@ const (
 	MATRIX     HookType = "matrix"
 	WECHATWORK HookType = "wechatwork"
 	PACKAGIST  HookType = "packagist"
+	CUSTOM     HookType = "custom"
 )
 
 // HookStatus is the status of a web hook
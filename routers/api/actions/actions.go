@@ -20,5 +20,10 @@ func Routes(prefix string) *web.Router {
 	path, handler = runner.NewRunnerServiceHandler()
 	m.Post(path+"*", http.StripPrefix(prefix, handler).ServeHTTP)
 
+	// the issuer of the OIDC ID tokens of jobs, cloud providers discover its keys below it
+	m.Get("/.well-known/openid-configuration", oidcWellKnown)
+	m.Get("/.well-known/jwks", oidcKeys)
+	m.Get("/_apis/idtoken", generateIDToken)
+
 	return m
 }
@@ -242,6 +242,9 @@ func (ar artifactRoutes) uploadArtifact(ctx *ArtifactContext) {
 
 	// get upload file size
 	fileRealTotalSize, contentLength := getUploadFileSize(ctx)
+	if !checkArtifactQuota(ctx, contentLength) {
+		return
+	}
 
 	// get artifact retention days
 	expiredDays := setting.Actions.ArtifactRetentionDays
@@ -5,12 +5,14 @@ package actions
 
 import (
 	"crypto/md5"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"code.gitea.io/gitea/models/actions"
+	quota_model "code.gitea.io/gitea/models/quota"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/util"
 )
@@ -54,6 +56,21 @@ func validateRunIDV4(ctx *ArtifactContext, rawRunID string) (*actions.ActionTask
 	return task, runID, true
 }
 
+// checkArtifactQuota rejects uploads which exceed the artifact storage quota of the repository owner
+func checkArtifactQuota(ctx *ArtifactContext, size int64) bool {
+	if err := quota_model.CheckQuota(ctx, ctx.ActionTask.OwnerID, quota_model.CategoryArtifacts, size); err != nil {
+		if errors.Is(err, util.ErrContentTooLarge) {
+			log.Warn("Error uploading artifact: %v", err)
+			ctx.HTTPError(http.StatusRequestEntityTooLarge, err.Error())
+			return false
+		}
+		log.Error("Error checking artifact quota: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error checking artifact quota")
+		return false
+	}
+	return true
+}
+
 func validateArtifactHash(ctx *ArtifactContext, artifactName string) bool {
 	paramHash := ctx.PathParam("artifact_hash")
 	// use artifact name to create upload url
@@ -303,6 +303,9 @@ func (r *artifactV4Routes) uploadArtifact(ctx *ArtifactContext) {
 	comp := ctx.Req.URL.Query().Get("comp")
 	switch comp {
 	case "block", "appendBlock":
+		if !checkArtifactQuota(ctx, ctx.Req.ContentLength) {
+			return
+		}
 		blockid := ctx.Req.URL.Query().Get("blockid")
 		if blockid == "" {
 			// get artifact by name
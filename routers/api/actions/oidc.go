@@ -0,0 +1,97 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/oauth2_provider"
+)
+
+func writeJSON(resp http.ResponseWriter, status int, v any) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(status)
+	if err := json.NewEncoder(resp).Encode(v); err != nil {
+		log.Error("Failed to encode representation as json. Error: %v", err)
+	}
+}
+
+// oidcWellKnown serves the discovery document of the issuer of the OIDC ID tokens of jobs
+func oidcWellKnown(resp http.ResponseWriter, req *http.Request) {
+	if !actions_service.IsIDTokenAvailable() {
+		http.NotFound(resp, req)
+		return
+	}
+	issuer := actions_service.IDTokenIssuer()
+	writeJSON(resp, http.StatusOK, map[string]any{
+		"issuer":                                issuer,
+		"jwks_uri":                              issuer + "/.well-known/jwks",
+		"response_types_supported":              []string{"id_token"},
+		"subject_types_supported":               []string{"public", "pairwise"},
+		"id_token_signing_alg_values_supported": []string{oauth2_provider.DefaultSigningKey.SigningMethod().Alg()},
+		"scopes_supported":                      []string{"openid"},
+		"claims_supported": []string{
+			"sub", "aud", "exp", "iat", "iss", "jti", "nbf",
+			"ref", "ref_type", "sha", "repository", "repository_id", "repository_owner", "repository_owner_id", "repository_visibility",
+			"actor", "actor_id", "workflow", "workflow_ref", "event_name", "head_ref", "base_ref", "environment",
+			"run_id", "run_number", "run_attempt",
+		},
+	})
+}
+
+// oidcKeys serves the JSON Web Key Set verifying the OIDC ID tokens of jobs
+func oidcKeys(resp http.ResponseWriter, req *http.Request) {
+	if !actions_service.IsIDTokenAvailable() {
+		http.NotFound(resp, req)
+		return
+	}
+	jwk, err := oauth2_provider.DefaultSigningKey.ToJWK()
+	if err != nil {
+		log.Error("Error converting signing key to JWK: %v", err)
+		http.Error(resp, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	jwk["use"] = "sig"
+	writeJSON(resp, http.StatusOK, map[string][]map[string]string{"keys": {jwk}})
+}
+
+// generateIDToken creates an OIDC ID token for the job authenticated by the request token of its task
+func generateIDToken(resp http.ResponseWriter, req *http.Request) {
+	taskID, err := actions_service.ParseAuthorizationToken(req)
+	if err != nil || taskID == 0 {
+		http.Error(resp, "Bad authorization header", http.StatusUnauthorized)
+		return
+	}
+	task, err := actions_model.GetTaskByID(req.Context(), taskID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			http.Error(resp, "Task not found", http.StatusUnauthorized)
+		} else {
+			log.Error("GetTaskByID: %v", err)
+			http.Error(resp, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	token, err := actions_service.CreateIDToken(req.Context(), task, req.URL.Query().Get("audience"))
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			http.Error(resp, err.Error(), http.StatusNotFound)
+		case errors.Is(err, util.ErrPermissionDenied):
+			http.Error(resp, err.Error(), http.StatusForbidden)
+		default:
+			log.Error("CreateIDToken: %v", err)
+			http.Error(resp, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+	writeJSON(resp, http.StatusOK, map[string]any{"count": len(token), "value": token})
+}
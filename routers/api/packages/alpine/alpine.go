@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	alpine_module "code.gitea.io/gitea/modules/packages/alpine"
@@ -181,42 +182,66 @@ func UploadPackageFile(ctx *context.Context) {
 	ctx.Status(http.StatusCreated)
 }
 
-func DownloadPackageFile(ctx *context.Context) {
-	branch := ctx.PathParam("branch")
-	repository := ctx.PathParam("repository")
-	architecture := ctx.PathParam("architecture")
-
+// searchPackageFile searches the file of the owner in the architecture or in the 'noarch' architecture
+func searchPackageFile(ctx *context.Context, ownerID int64, branch, repository, architecture, filename string) (*packages_model.PackageFile, error) {
 	opts := &packages_model.PackageFileSearchOptions{
-		OwnerID:      ctx.Package.Owner.ID,
+		OwnerID:      ownerID,
 		PackageType:  packages_model.TypeAlpine,
-		Query:        ctx.PathParam("filename"),
+		Query:        filename,
 		CompositeKey: fmt.Sprintf("%s|%s|%s", branch, repository, architecture),
 	}
 	pfs, _, err := packages_model.SearchFiles(ctx, opts)
 	if err != nil {
-		apiError(ctx, http.StatusInternalServerError, err)
-		return
+		return nil, err
 	}
 	if len(pfs) == 0 {
 		// Try again with architecture 'noarch'
 		if architecture == alpine_module.NoArch {
-			apiError(ctx, http.StatusNotFound, nil)
-			return
+			return nil, nil
 		}
 
 		opts.CompositeKey = fmt.Sprintf("%s|%s|%s", branch, repository, alpine_module.NoArch)
 		if pfs, _, err = packages_model.SearchFiles(ctx, opts); err != nil {
-			apiError(ctx, http.StatusInternalServerError, err)
-			return
+			return nil, err
 		}
 
 		if len(pfs) == 0 {
+			return nil, nil
+		}
+	}
+	return pfs[0], nil
+}
+
+func DownloadPackageFile(ctx *context.Context) {
+	branch := ctx.PathParam("branch")
+	repository := ctx.PathParam("repository")
+	architecture := ctx.PathParam("architecture")
+	filename := ctx.PathParam("filename")
+
+	pf, err := searchPackageFile(ctx, ctx.Package.Owner.ID, branch, repository, architecture, filename)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if pf == nil {
+		served := helper.ServeFromVirtualSourcesFunc(ctx, packages_model.TypeAlpine, func(source *user_model.User) (string, error) {
+			pf, err := searchPackageFile(ctx, source.ID, branch, repository, architecture, filename)
+			if err != nil || pf == nil {
+				return "", err
+			}
+			p, err := packages_service.GetPackageOfFile(ctx, pf)
+			if err != nil {
+				return "", err
+			}
+			return p.Name, nil
+		}, DownloadPackageFile)
+		if !served {
 			apiError(ctx, http.StatusNotFound, nil)
-			return
 		}
+		return
 	}
 
-	s, u, pf, err := packages_service.OpenFileForDownload(ctx, pfs[0], ctx.Req.Method)
+	s, u, pf, err := packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
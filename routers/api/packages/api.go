@@ -24,7 +24,9 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/generic"
 	"code.gitea.io/gitea/routers/api/packages/goproxy"
 	"code.gitea.io/gitea/routers/api/packages/helm"
+	"code.gitea.io/gitea/routers/api/packages/hex"
 	"code.gitea.io/gitea/routers/api/packages/maven"
+	"code.gitea.io/gitea/routers/api/packages/nix"
 	"code.gitea.io/gitea/routers/api/packages/npm"
 	"code.gitea.io/gitea/routers/api/packages/nuget"
 	"code.gitea.io/gitea/routers/api/packages/pub"
@@ -32,6 +34,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/rpm"
 	"code.gitea.io/gitea/routers/api/packages/rubygems"
 	"code.gitea.io/gitea/routers/api/packages/swift"
+	"code.gitea.io/gitea/routers/api/packages/terraform"
 	"code.gitea.io/gitea/routers/api/packages/vagrant"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
@@ -119,6 +122,19 @@ func CommonRoutes() *web.Router {
 		&nuget.Auth{},
 		&conan.Auth{},
 		&chef.Auth{},
+		&hex.Auth{},
+	})
+
+	// The Terraform registry protocols use fixed base paths from the service discovery
+	r.Group("/-/terraform", func() {
+		r.Group("/modules/v1/{username}/{name}/{system}", func() {
+			r.Get("/versions", terraform.EnumerateModuleVersions)
+			r.Get("/{version}/download", terraform.DownloadModuleVersion)
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
+		r.Group("/providers/v1/{username}/{provider}", func() {
+			r.Get("/versions", terraform.EnumerateProviderVersions)
+			r.Get("/{version}/download/{os}/{arch}", terraform.DownloadProviderPackage)
+		}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
 	})
 
 	r.Group("/{username}", func() {
@@ -352,6 +368,20 @@ func CommonRoutes() *web.Router {
 			r.Get("/{filename}", helm.DownloadPackageFile)
 			r.Post("/api/charts", reqPackageAccess(perm.AccessModeWrite), helm.UploadPackage)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/hex", func() {
+			r.Get("/public_key", hex.GetRepositoryKey)
+			r.Get("/names", hex.EnumeratePackageNames)
+			r.Get("/versions", hex.EnumeratePackageVersions)
+			r.Get("/packages/{package}", hex.EnumeratePackageReleases)
+			r.Get("/tarballs/{filename}", hex.DownloadPackageFile)
+			r.Group("/api", func() {
+				r.Post("/publish", reqPackageAccess(perm.AccessModeWrite), hex.UploadPackage)
+				r.Group("/packages/{package}", func() {
+					r.Get("", hex.PackageInfo)
+					r.Delete("/releases/{version}", reqPackageAccess(perm.AccessModeWrite), hex.DeletePackageVersion)
+				})
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/maven", func() {
 			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), maven.UploadPackageFile)
 			r.Get("/*", maven.DownloadPackageFile)
@@ -394,6 +424,19 @@ func CommonRoutes() *web.Router {
 				})
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/nix", func() {
+			r.Methods("HEAD,GET", "/nix-cache-info", nix.CacheInfo)
+			r.Get("/public-key", nix.GetPublicKey)
+			r.Get("/flake-registry.json", nix.FlakeRegistry)
+			r.Group("/nar/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.DownloadNar)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNar)
+			})
+			r.Group("/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.DownloadNarInfo)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNarInfo)
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/npm", func() {
 			r.Group("/@{scope}/{id}", func() {
 				r.Get("", npm.PackageMetadata)
@@ -509,6 +552,21 @@ func CommonRoutes() *web.Router {
 				r.Get("/identifiers", swift.CheckAcceptMediaType(swift.AcceptJSON), swift.LookupPackageIdentifiers)
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/terraform", func() {
+			r.Get("/repository.key", terraform.GetRepositoryKey)
+			r.Group("/modules/{name}/{system}/{version}", func() {
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadModule)
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), terraform.DeleteModule)
+				r.Methods("HEAD,GET", "/{filename}", terraform.DownloadModuleFile)
+			})
+			r.Group("/providers/{provider}/{version}", func() {
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), terraform.DeleteProvider)
+				r.Group("/{filename}", func() {
+					r.Methods("HEAD,GET", "", terraform.DownloadProviderFile)
+					r.Put("", reqPackageAccess(perm.AccessModeWrite), terraform.UploadProviderFile)
+				})
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/vagrant", func() {
 			r.Group("/authenticate", func() {
 				r.Get("", vagrant.CheckAuthenticate)
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	arch_module "code.gitea.io/gitea/modules/packages/arch"
@@ -215,6 +216,12 @@ func GetPackageOrRepositoryFile(ctx *context.Context) {
 		}
 	}
 	if len(pfs) != 1 {
+		// Package files which do not exist locally are served by the virtual sources of the repository
+		if opts.VersionID == 0 && helper.ServeFromVirtualSourcesFunc(ctx, packages_model.TypeArch, func(source *user_model.User) (string, error) {
+			return lookupPackageName(ctx, source.ID, repository, architecture, filename)
+		}, GetPackageOrRepositoryFile) {
+			return
+		}
 		apiError(ctx, http.StatusNotFound, nil)
 		return
 	}
@@ -251,6 +258,29 @@ func GetPackageOrRepositoryFile(ctx *context.Context) {
 	helper.ServePackageFile(ctx, s, u, pf)
 }
 
+// lookupPackageName returns the name of the package the file of the owner belongs to or an empty string if the owner has no such file
+func lookupPackageName(ctx *context.Context, ownerID int64, repository, architecture, filename string) (string, error) {
+	for _, arch := range []string{architecture, arch_module.AnyArch} {
+		pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+			OwnerID:      ownerID,
+			PackageType:  packages_model.TypeArch,
+			Query:        filename,
+			CompositeKey: fmt.Sprintf("%s|%s", repository, arch),
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(pfs) == 1 {
+			p, err := packages_service.GetPackageOfFile(ctx, pfs[0])
+			if err != nil {
+				return "", err
+			}
+			return p.Name, nil
+		}
+	}
+	return "", nil
+}
+
 func DeletePackageVersion(ctx *context.Context) {
 	repository := ctx.PathParam("repository")
 	architecture := ctx.PathParam("architecture")
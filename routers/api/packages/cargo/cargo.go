@@ -24,6 +24,7 @@ import (
 	"code.gitea.io/gitea/services/convert"
 	packages_service "code.gitea.io/gitea/services/packages"
 	cargo_service "code.gitea.io/gitea/services/packages/cargo"
+	"code.gitea.io/gitea/services/packages/remote"
 )
 
 // https://doc.rust-lang.org/cargo/reference/registries.html#web-api
@@ -57,7 +58,9 @@ func EnumeratePackageVersions(ctx *context.Context) {
 	p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeCargo, ctx.PathParam("package"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeCargo, ctx.PathParam("package"), EnumeratePackageVersions) {
+				serveRemotePackageIndex(ctx, ctx.PathParam("package"))
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -70,13 +73,28 @@ func EnumeratePackageVersions(ctx *context.Context) {
 		return
 	}
 	if b == nil {
-		apiError(ctx, http.StatusNotFound, nil)
+		serveRemotePackageIndex(ctx, p.Name)
 		return
 	}
 
 	ctx.PlainTextBytes(http.StatusOK, b.Bytes())
 }
 
+// serveRemotePackageIndex serves the index file of the upstream registry if the owner has a remote for packages which do not exist locally
+func serveRemotePackageIndex(ctx *context.Context, packageName string) {
+	b, err := remote.GetCargoPackageIndex(ctx, ctx.Package.Owner, packageName)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.PlainTextBytes(http.StatusOK, b)
+}
+
 type SearchResult struct {
 	Crates []*SearchResultCrate `json:"crates"`
 	Meta   SearchResultMeta     `json:"meta"`
@@ -179,7 +197,9 @@ func DownloadPackageFile(ctx *context.Context) {
 	)
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeCargo, ctx.PathParam("package"), DownloadPackageFile) {
+				serveRemoteFile(ctx, ctx.PathParam("package"), ctx.PathParam("version"))
+			}
 			return
 		}
 		apiError(ctx, http.StatusInternalServerError, err)
@@ -189,6 +209,27 @@ func DownloadPackageFile(ctx *context.Context) {
 	helper.ServePackageFile(ctx, s, u, pf)
 }
 
+// serveRemoteFile serves a crate of the upstream registry
+func serveRemoteFile(ctx *context.Context, packageName, packageVersion string) {
+	f, err := remote.GetCargoPackageFile(ctx, ctx.Package.Owner, packageName, packageVersion)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	s, u, err := remote.OpenFileForDownload(ctx, f, ctx.Req.Method, nil)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServeRemoteFile(ctx, s, u, f)
+}
+
 // https://doc.rust-lang.org/cargo/reference/registries.html#publish
 func UploadPackage(ctx *context.Context) {
 	defer ctx.Req.Body.Close()
@@ -32,6 +32,7 @@ import (
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	"code.gitea.io/gitea/services/packages/remote"
 
 	"github.com/opencontainers/go-digest"
 )
@@ -507,7 +508,9 @@ func HeadBlob(ctx *context.Context) {
 	blob, err := getBlobFromContext(ctx)
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errBlobUnknown)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeContainer, ctx.PathParam("image"), HeadBlob) {
+				serveRemoteBlob(ctx, false)
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -526,7 +529,9 @@ func GetBlob(ctx *context.Context) {
 	blob, err := getBlobFromContext(ctx)
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errBlobUnknown)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeContainer, ctx.PathParam("image"), GetBlob) {
+				serveRemoteBlob(ctx, true)
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -641,7 +646,9 @@ func HeadManifest(ctx *context.Context) {
 	manifest, err := getManifestFromContext(ctx)
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errManifestUnknown)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeContainer, ctx.PathParam("image"), HeadManifest) {
+				serveRemoteManifest(ctx, false)
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -661,7 +668,9 @@ func GetManifest(ctx *context.Context) {
 	manifest, err := getManifestFromContext(ctx)
 	if err != nil {
 		if errors.Is(err, container_model.ErrContainerBlobNotExist) {
-			apiErrorDefined(ctx, errManifestUnknown)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeContainer, ctx.PathParam("image"), GetManifest) {
+				serveRemoteManifest(ctx, true)
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -719,6 +728,74 @@ func serveBlob(ctx *context.Context, pfd *packages_model.PackageFileDescriptor)
 		Status:        http.StatusOK,
 	}
 
+	writeBlob(ctx, headers, s, u)
+}
+
+// serveRemoteManifest serves the manifest of the upstream registry if the owner has a remote for manifests which do not exist locally
+func serveRemoteManifest(ctx *context.Context, serveContent bool) {
+	reference := ctx.PathParam("reference")
+	if digest.Digest(reference).Validate() != nil && !globalVars().referencePattern.MatchString(reference) {
+		apiErrorDefined(ctx, errManifestUnknown)
+		return
+	}
+
+	f, err := remote.GetContainerManifest(ctx, ctx.Package.Owner, ctx.PathParam("image"), reference)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiErrorDefined(ctx, errManifestUnknown)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	serveRemoteFile(ctx, f, serveContent)
+}
+
+// serveRemoteBlob serves the blob of the upstream registry if the owner has a remote for blobs which do not exist locally
+func serveRemoteBlob(ctx *context.Context, serveContent bool) {
+	f, err := remote.GetContainerBlob(ctx, ctx.Package.Owner, ctx.PathParam("image"), digest.Digest(ctx.PathParam("digest")))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiErrorDefined(ctx, errBlobUnknown)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	serveRemoteFile(ctx, f, serveContent)
+}
+
+func serveRemoteFile(ctx *context.Context, f *remote.File, serveContent bool) {
+	contentType := f.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	headers := &containerHeaders{
+		ContentDigest: f.Digest(),
+		ContentType:   contentType,
+		ContentLength: optional.Some(f.Blob.Size),
+		Status:        http.StatusOK,
+	}
+	if !serveContent {
+		setResponseHeaders(ctx.Resp, headers)
+		return
+	}
+
+	serveDirectReqParams := make(url.Values)
+	serveDirectReqParams.Set("response-content-type", contentType)
+	s, u, err := remote.OpenFileForDownload(ctx, f, ctx.Req.Method, serveDirectReqParams)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeBlob(ctx, headers, s, u)
+}
+
+// writeBlob redirects to the direct serving url if it is set, otherwise the content is copied to the response
+func writeBlob(ctx *context.Context, headers *containerHeaders, s io.ReadSeekCloser, u *url.URL) {
 	if u != nil {
 		headers.Status = http.StatusTemporaryRedirect
 		headers.Location = u.String()
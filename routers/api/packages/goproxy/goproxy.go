@@ -19,6 +19,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	"code.gitea.io/gitea/services/packages/remote"
 )
 
 func apiError(ctx *context.Context, status int, obj any) {
@@ -33,7 +34,9 @@ func EnumeratePackageVersions(ctx *context.Context) {
 		return
 	}
 	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
+		if !helper.ServeFromVirtualSources(ctx, packages_model.TypeGo, ctx.PathParam("name"), EnumeratePackageVersions) {
+			serveRemoteFile(ctx, ctx.PathParam("name")+"/@v/list", true)
+		}
 		return
 	}
 
@@ -52,7 +55,14 @@ func PackageVersionMetadata(ctx *context.Context) {
 	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			if helper.ServeFromVirtualSources(ctx, packages_model.TypeGo, ctx.PathParam("name"), PackageVersionMetadata) {
+				return
+			}
+			if ctx.PathParam("version") == "latest" {
+				serveRemoteFile(ctx, ctx.PathParam("name")+"/@latest", true)
+			} else {
+				serveRemoteFile(ctx, ctx.PathParam("name")+"/@v/"+ctx.PathParam("version")+".info", false)
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -72,7 +82,9 @@ func PackageVersionGoModContent(ctx *context.Context) {
 	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeGo, ctx.PathParam("name"), PackageVersionGoModContent) {
+				serveRemoteFile(ctx, ctx.PathParam("name")+"/@v/"+ctx.PathParam("version")+".mod", false)
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -92,7 +104,9 @@ func DownloadPackageFile(ctx *context.Context) {
 	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeGo, ctx.PathParam("name"), DownloadPackageFile) {
+				serveRemoteFile(ctx, ctx.PathParam("name")+"/@v/"+ctx.PathParam("version")+".zip", false)
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -118,6 +132,30 @@ func DownloadPackageFile(ctx *context.Context) {
 	helper.ServePackageFile(ctx, s, u, pfs[0])
 }
 
+// serveRemoteFile serves the file of the upstream proxy if the owner has a remote for modules which do not exist locally
+func serveRemoteFile(ctx *context.Context, p string, isMetadata bool) {
+	f, err := remote.GetFile(ctx, ctx.Package.Owner, packages_model.TypeGo, &remote.FetchOptions{
+		Path:       p,
+		IsMetadata: isMetadata,
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	s, u, err := remote.OpenFileForDownload(ctx, f, ctx.Req.Method, nil)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServeRemoteFile(ctx, s, u, f)
+}
+
 func resolvePackage(ctx *context.Context, ownerID int64, name, version string) (*packages_model.PackageVersion, error) {
 	var pv *packages_model.PackageVersion
 
@@ -4,15 +4,19 @@
 package helper
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/packages/remote"
 )
 
 // ProcessErrorForUser logs the error and returns a user-error message for the end user.
@@ -59,3 +63,81 @@ func ServePackageFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, pf
 
 	ctx.ServeContent(s, opts)
 }
+
+// ServeRemoteFile the content of a file cached from the upstream registry of a remote
+// If the url is set it will redirect the request, otherwise the content is copied to the response.
+func ServeRemoteFile(ctx *context.Context, s io.ReadSeekCloser, u *url.URL, f *remote.File) {
+	ServePackageFile(ctx, s, u, nil, &context.ServeHeaderOptions{
+		Filename:     f.Filename(),
+		ContentType:  f.ContentType,
+		LastModified: f.FetchedUnix.AsLocalTime(),
+	})
+}
+
+const virtualSourceDataKey = "PackageVirtualSource"
+
+// ServeFromVirtualSources serves a package which does not exist in the owner's registry through the virtual sources of the registry.
+// The first source whose pattern matches the package name and which has the package locally or an enabled remote serves the request.
+// It returns false if no source serves the request, see ServeFromVirtualSourcesFunc.
+func ServeFromVirtualSources(ctx *context.Context, packageType packages_model.Type, packageName string, handler func(*context.Context)) bool {
+	return ServeFromVirtualSourcesFunc(ctx, packageType, func(source *user_model.User) (string, error) {
+		_, err := packages_model.GetPackageByName(ctx, source.ID, packageType, packageName)
+		if err == nil {
+			return packageName, nil
+		} else if !errors.Is(err, util.ErrNotExist) {
+			return "", err
+		}
+		if packages_model.IsRemoteType(packageType) {
+			_, err := remote.GetRemote(ctx, source, packageType)
+			if err == nil {
+				return packageName, nil
+			} else if !errors.Is(err, util.ErrNotExist) {
+				return "", err
+			}
+		}
+		return "", nil
+	}, handler)
+}
+
+// ServeFromVirtualSourcesFunc serves a request which can't be answered by the owner's registry through the virtual sources of the registry.
+// lookup returns the name of the requested package if the source has it or an empty string otherwise.
+// The handler is invoked with the source owner as owner of the registry, sources are not resolved transitively.
+// It returns false if no source serves the request, the caller is responsible for the response then.
+func ServeFromVirtualSourcesFunc(ctx *context.Context, packageType packages_model.Type, lookup func(source *user_model.User) (string, error), handler func(*context.Context)) bool {
+	if ctx.Data[virtualSourceDataKey] != nil {
+		return false
+	}
+
+	pvss, err := packages_model.GetVirtualSourcesByOwnerAndType(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil {
+		log.Error("Error getting virtual sources of owner %d: %v", ctx.Package.Owner.ID, err)
+		return false
+	}
+	for _, pvs := range pvss {
+		source, err := user_model.GetUserByID(ctx, pvs.SourceOwnerID)
+		if err != nil {
+			log.Error("Error getting source owner %d of virtual source %d: %v", pvs.SourceOwnerID, pvs.ID, err)
+			continue
+		}
+		packageName, err := lookup(source)
+		if err != nil {
+			log.Error("Error looking up package in virtual source %d: %v", pvs.ID, err)
+			continue
+		}
+		if packageName == "" || !pvs.Matches(packageName) {
+			continue
+		}
+
+		owner := ctx.Package.Owner
+		ctx.Package.Owner = source
+		ctx.Data[virtualSourceDataKey] = pvs
+		defer func() {
+			ctx.Package.Owner = owner
+			delete(ctx.Data, virtualSourceDataKey)
+		}()
+
+		handler(ctx)
+		return true
+	}
+	return false
+}
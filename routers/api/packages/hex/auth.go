@@ -0,0 +1,55 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"net/http"
+	"strings"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/services/auth"
+)
+
+var _ auth.Method = &Auth{}
+
+type Auth struct{}
+
+func (a *Auth) Name() string {
+	return "hex"
+}
+
+// Verify extracts the user from the API key which the Hex clients send without an authorization scheme
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#authentication
+func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) (*user_model.User, error) {
+	key := req.Header.Get("Authorization")
+	if key == "" || strings.ContainsRune(key, ' ') || !strings.Contains(req.URL.Path, "/hex/") {
+		return nil, nil
+	}
+
+	token, err := auth_model.GetAccessTokenBySHA(req.Context(), key)
+	if err != nil {
+		if !(auth_model.IsErrAccessTokenNotExist(err) || auth_model.IsErrAccessTokenEmpty(err)) {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	u, err := user_model.GetUserByID(req.Context(), token.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	token.UpdatedUnix = timeutil.TimeStampNow()
+	if err := auth_model.UpdateAccessToken(req.Context(), token); err != nil {
+		log.Error("UpdateAccessToken: %v", err)
+	}
+
+	store.GetData()["IsApiToken"] = true
+	store.GetData()["ApiTokenScope"] = token.Scope
+
+	return u, nil
+}
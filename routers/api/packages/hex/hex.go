@@ -0,0 +1,315 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	hex_service "code.gitea.io/gitea/services/packages/hex"
+)
+
+// https://github.com/hexpm/specifications/blob/main/apiary.apib
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	serveAPIResponse(ctx, status, map[string]any{
+		"status":  status,
+		"message": message,
+	})
+}
+
+// serveAPIResponse encodes the response in the Erlang term format the Hex clients request or as JSON
+func serveAPIResponse(ctx *context.Context, status int, obj map[string]any) {
+	if !strings.Contains(ctx.Req.Header.Get("Accept"), hex_module.ContentTypeErlang) {
+		ctx.JSON(status, obj)
+		return
+	}
+
+	b, err := hex_module.EncodeTerm(obj)
+	if err != nil {
+		log.Error("EncodeTerm failed: %v", err)
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", hex_module.ContentTypeErlang)
+	ctx.Resp.WriteHeader(status)
+	_, _ = ctx.Resp.Write(b)
+}
+
+func serveRegistryResource(ctx *context.Context, b []byte) {
+	ctx.ServeContent(bytes.NewReader(b), &context.ServeHeaderOptions{
+		ContentType: "application/octet-stream",
+	})
+}
+
+// GetRepositoryKey serves the public key the registry resources are signed with
+func GetRepositoryKey(ctx *context.Context) {
+	_, pub, err := hex_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.PlainText(http.StatusOK, pub)
+}
+
+// EnumeratePackageNames serves the names resource
+func EnumeratePackageNames(ctx *context.Context) {
+	b, err := hex_service.BuildNames(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	serveRegistryResource(ctx, b)
+}
+
+// EnumeratePackageVersions serves the versions resource
+func EnumeratePackageVersions(ctx *context.Context) {
+	b, err := hex_service.BuildVersions(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	serveRegistryResource(ctx, b)
+}
+
+// EnumeratePackageReleases serves the package resource
+func EnumeratePackageReleases(ctx *context.Context) {
+	p, err := packages_model.GetPackageByName(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, ctx.PathParam("package"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	b, err := hex_service.BuildPackage(ctx, ctx.Package.Owner, p)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if b == nil {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	serveRegistryResource(ctx, b)
+}
+
+// DownloadPackageFile serves the tarball of a release
+func DownloadPackageFile(ctx *context.Context) {
+	packageName, packageVersion, ok := strings.Cut(strings.TrimSuffix(ctx.PathParam("filename"), ".tar"), "-")
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        packageName,
+			Version:     packageVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: tarballFilename(packageName, packageVersion),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+func tarballFilename(name, version string) string {
+	return strings.ToLower(fmt.Sprintf("%s-%s.tar", name, version))
+}
+
+// UploadPackage publishes a release, an existing release is only overwritten if requested
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#publish
+func UploadPackage(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	hp, err := hex_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if ctx.FormBool("replace") {
+		pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, hp.Name, hp.Version)
+		if err == nil {
+			err = packages_service.RemovePackageVersion(ctx, ctx.Doer, pv)
+		}
+		if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeHex,
+				Name:        hp.Name,
+				Version:     hp.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         hp.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: tarballFilename(hp.Name, hp.Version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	serveAPIResponse(ctx, http.StatusCreated, releaseInfo(ctx, pd))
+}
+
+func releaseInfo(ctx *context.Context, pd *packages_model.PackageDescriptor) map[string]any {
+	return map[string]any{
+		"version":  pd.Version.Version,
+		"checksum": pd.Files[0].Blob.HashSHA256,
+		"url":      fmt.Sprintf("%sapi/packages/%s/hex/api/packages/%s/releases/%s", setting.AppURL, pd.Owner.Name, pd.Package.Name, pd.Version.Version),
+		"html_url": pd.VersionHTMLURL(ctx),
+	}
+}
+
+// PackageInfo describes a package and its releases
+func PackageInfo(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, ctx.PathParam("package"))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	releases := make([]any, 0, len(pds))
+	for _, pd := range pds {
+		releases = append(releases, releaseInfo(ctx, pd))
+	}
+
+	// the versions are sorted by creation date descending
+	latest := pds[0]
+	metadata := latest.Metadata.(*hex_module.Metadata)
+
+	serveAPIResponse(ctx, http.StatusOK, map[string]any{
+		"name":       latest.Package.Name,
+		"repository": hex_service.RepositoryName(ctx.Package.Owner),
+		"url":        fmt.Sprintf("%sapi/packages/%s/hex/api/packages/%s", setting.AppURL, latest.Owner.Name, latest.Package.Name),
+		"html_url":   latest.PackageHTMLURL(ctx),
+		"releases":   releases,
+		"meta": map[string]any{
+			"description": metadata.Description,
+			"licenses":    metadata.Licenses,
+			"links":       metadata.Links,
+		},
+	})
+}
+
+// DeletePackageVersion reverts a release
+func DeletePackageVersion(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        ctx.PathParam("package"),
+			Version:     ctx.PathParam("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
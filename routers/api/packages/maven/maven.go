@@ -28,6 +28,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	"code.gitea.io/gitea/services/packages/remote"
 )
 
 const (
@@ -93,7 +94,7 @@ func serveMavenMetadata(ctx *context.Context, params parameters) {
 	pvs = append(pvsLegacy, pvs...)
 
 	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		serveRemoteFile(ctx, params)
 		return
 	}
 
@@ -154,7 +155,7 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 	}
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			serveRemoteFile(ctx, params)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -171,7 +172,7 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			serveRemoteFile(ctx, params)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -228,6 +229,38 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 	helper.ServePackageFile(ctx, s, u, pf, opts)
 }
 
+// serveRemoteFile serves the file of the upstream repository if the owner has a remote for files which do not exist locally.
+// The metadata files and the files of snapshot versions change over time and are refreshed.
+// The virtual sources of the registry take precedence over the remote.
+func serveRemoteFile(ctx *context.Context, params parameters) {
+	if helper.ServeFromVirtualSources(ctx, packages_model.TypeMaven, params.toInternalPackageName(), func(ctx *context.Context) {
+		handlePackageFile(ctx, ctx.Req.Method != http.MethodHead)
+	}) {
+		return
+	}
+
+	f, err := remote.GetFile(ctx, ctx.Package.Owner, packages_model.TypeMaven, &remote.FetchOptions{
+		Path:       ctx.PathParam("*"),
+		IsMetadata: params.IsMeta || strings.HasSuffix(params.Version, "-SNAPSHOT"),
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	s, u, err := remote.OpenFileForDownload(ctx, f, ctx.Req.Method, nil)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServeRemoteFile(ctx, s, u, f)
+}
+
 func mavenPkgNameKey(packageName string) string {
 	return "pkg_maven_" + packageName
 }
@@ -0,0 +1,210 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	nix_module "code.gitea.io/gitea/modules/packages/nix"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
+)
+
+const (
+	// caches with a lower priority number are queried first, cache.nixos.org uses 40
+	cachePriority = 50
+
+	narInfoContentType = "text/x-nix-narinfo"
+)
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.PlainText(status, message)
+}
+
+// CacheInfo describes the binary cache
+// https://nixos.org/manual/nix/stable/store/types/http-binary-cache-store
+func CacheInfo(ctx *context.Context) {
+	ctx.ServeContent(strings.NewReader(fmt.Sprintf("StoreDir: %s\nWantMassQuery: 1\nPriority: %d\n", nix_module.StoreDir, cachePriority)), &context.ServeHeaderOptions{
+		ContentType: "text/x-nix-cache-info",
+		Filename:    "nix-cache-info",
+	})
+}
+
+// GetPublicKey returns the public key the narinfos are signed with in the format of the trusted-public-keys setting
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := nix_service.GetOrCreateSigningKey(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.PlainText(http.StatusOK, pub)
+}
+
+// FlakeRegistry lists the flakes of the repositories of the owner
+func FlakeRegistry(ctx *context.Context) {
+	registry, err := nix_service.BuildFlakeRegistry(ctx, ctx.Doer, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registry)
+}
+
+func storePathHash(ctx *context.Context) (string, bool) {
+	hash, ok := strings.CutSuffix(ctx.PathParam("filename"), nix_module.NarInfoExtension)
+	return hash, ok && nix_module.IsValidHash(hash)
+}
+
+// DownloadNarInfo serves the narinfo of a store path signed with the key of the owner
+func DownloadNarInfo(ctx *context.Context) {
+	hash, ok := storePathHash(ctx)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pd, err := nix_service.GetStorePath(ctx, ctx.Package.Owner.ID, hash)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	narinfo, err := nix_service.BuildNarInfo(ctx, ctx.Package.Owner, pd.Metadata.(*nix_module.Metadata))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader(narinfo), &context.ServeHeaderOptions{
+		ContentType:  narInfoContentType,
+		Filename:     hash + nix_module.NarInfoExtension,
+		LastModified: pd.Version.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// UploadNarInfo creates a store path from its narinfo, the NAR must be uploaded before
+func UploadNarInfo(ctx *context.Context) {
+	hash, ok := storePathHash(ctx)
+	if !ok {
+		apiError(ctx, http.StatusBadRequest, nix_module.ErrInvalidStorePath)
+		return
+	}
+
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	m, err := nix_module.ParseNarInfo(upload)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if storeHash, _, _ := nix_module.ParseStorePath(m.StorePath); storeHash != hash {
+		apiError(ctx, http.StatusBadRequest, nix_module.ErrInvalidStorePath)
+		return
+	}
+
+	if err := nix_service.AddStorePath(ctx, ctx.Doer, ctx.Package.Owner, m); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			apiError(ctx, http.StatusBadRequest, err)
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DownloadNar serves a compressed NAR
+func DownloadNar(ctx *context.Context) {
+	pf, err := nix_service.GetNarFile(ctx, ctx.Package.Owner.ID, ctx.PathParam("filename"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	s, u, _, err := packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf, &context.ServeHeaderOptions{
+		ContentType:  "application/x-nix-nar",
+		Filename:     pf.Name,
+		LastModified: pf.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// UploadNar stores a compressed NAR, the filename is the nix32 encoded sha256 checksum of the content
+func UploadNar(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	if _, err := nix_module.ParseNarFilename(filename); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	if err := nix_service.UploadNar(ctx, ctx.Doer, ctx.Package.Owner, filename, buf); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			apiError(ctx, http.StatusBadRequest, err)
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize):
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
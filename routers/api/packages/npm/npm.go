@@ -25,6 +25,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	"code.gitea.io/gitea/services/packages/remote"
 
 	"github.com/hashicorp/go-version"
 )
@@ -60,7 +61,9 @@ func PackageMetadata(ctx *context.Context) {
 		return
 	}
 	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
+		if !helper.ServeFromVirtualSources(ctx, packages_model.TypeNpm, packageName, PackageMetadata) {
+			serveRemotePackageMetadata(ctx, packageName)
+		}
 		return
 	}
 
@@ -99,7 +102,9 @@ func DownloadPackageFile(ctx *context.Context) {
 	)
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeNpm, packageName, DownloadPackageFile) {
+				serveRemoteFile(ctx, packageName, packageVersion, filename)
+			}
 			return
 		}
 		apiError(ctx, http.StatusInternalServerError, err)
@@ -128,7 +133,9 @@ func DownloadPackageFileByName(ctx *context.Context) {
 		return
 	}
 	if len(pvs) != 1 {
-		apiError(ctx, http.StatusNotFound, nil)
+		if !helper.ServeFromVirtualSources(ctx, packages_model.TypeNpm, packageNameFromParams(ctx), DownloadPackageFileByName) {
+			serveRemoteFile(ctx, packageNameFromParams(ctx), "", filename)
+		}
 		return
 	}
 
@@ -152,6 +159,44 @@ func DownloadPackageFileByName(ctx *context.Context) {
 	helper.ServePackageFile(ctx, s, u, pf)
 }
 
+// serveRemotePackageMetadata serves the metadata of the upstream registry if the owner has a remote for packages which do not exist locally
+func serveRemotePackageMetadata(ctx *context.Context, packageName string) {
+	metadata, err := remote.GetNpmPackageMetadata(ctx, ctx.Package.Owner, packageName, setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/npm")
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/json")
+	ctx.Status(http.StatusOK)
+	_, _ = ctx.Resp.Write(metadata)
+}
+
+// serveRemoteFile serves a tarball of the upstream registry, the version is looked up by the filename if it is empty
+func serveRemoteFile(ctx *context.Context, packageName, packageVersion, filename string) {
+	f, err := remote.GetNpmPackageFile(ctx, ctx.Package.Owner, packageName, packageVersion, filename)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	s, u, err := remote.OpenFileForDownload(ctx, f, ctx.Req.Method, nil)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServeRemoteFile(ctx, s, u, f)
+}
+
 // UploadPackage creates a new package
 func UploadPackage(ctx *context.Context) {
 	npmPackage, err := npm_module.ParsePackage(ctx.Req.Body)
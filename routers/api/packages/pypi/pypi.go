@@ -17,10 +17,12 @@ import (
 	packages_module "code.gitea.io/gitea/modules/packages"
 	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/validation"
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	"code.gitea.io/gitea/services/packages/remote"
 )
 
 // https://peps.python.org/pep-0426/#name
@@ -54,7 +56,9 @@ func PackageMetadata(ctx *context.Context) {
 		return
 	}
 	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
+		if !helper.ServeFromVirtualSources(ctx, packages_model.TypePyPI, packageName, PackageMetadata) {
+			serveRemotePackageMetadata(ctx, packageName)
+		}
 		return
 	}
 
@@ -96,7 +100,9 @@ func DownloadPackageFile(ctx *context.Context) {
 	)
 	if err != nil {
 		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypePyPI, packageName, DownloadPackageFile) {
+				serveRemoteFile(ctx, packageName, filename)
+			}
 			return
 		}
 		apiError(ctx, http.StatusInternalServerError, err)
@@ -106,6 +112,44 @@ func DownloadPackageFile(ctx *context.Context) {
 	helper.ServePackageFile(ctx, s, u, pf)
 }
 
+// serveRemotePackageMetadata serves the simple index of the upstream registry if the owner has a remote for packages which do not exist locally
+func serveRemotePackageMetadata(ctx *context.Context, packageName string) {
+	page, err := remote.GetPyPISimpleIndex(ctx, ctx.Package.Owner, packageName, setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/pypi")
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "text/html; charset=utf-8")
+	ctx.Status(http.StatusOK)
+	_, _ = ctx.Resp.Write(page)
+}
+
+// serveRemoteFile serves a distribution of the upstream registry
+func serveRemoteFile(ctx *context.Context, packageName, filename string) {
+	f, err := remote.GetPyPIPackageFile(ctx, ctx.Package.Owner, packageName, filename)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	s, u, err := remote.OpenFileForDownload(ctx, f, ctx.Req.Method, nil)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServeRemoteFile(ctx, s, u, f)
+}
+
 // UploadPackageFile adds a file to the package. If the package does not exist, it gets created.
 func UploadPackageFile(ctx *context.Context) {
 	file, fileHeader, err := ctx.Req.FormFile("content")
@@ -186,6 +230,7 @@ func UploadPackageFile(ctx *context.Context) {
 				ProjectURL:      homepageURL,
 				License:         ctx.Req.FormValue("license"),
 				RequiresPython:  ctx.Req.FormValue("requires_python"),
+				RequiresDist:    ctx.Req.Form["requires_dist"],
 			},
 		},
 		&packages_service.PackageFileCreationInfo{
@@ -236,7 +236,9 @@ func DownloadPackageFile(ctx *context.Context) {
 	)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
+			if !helper.ServeFromVirtualSources(ctx, packages_model.TypeRpm, name, DownloadPackageFile) {
+				apiError(ctx, http.StatusNotFound, err)
+			}
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
 		}
@@ -0,0 +1,579 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	terraform_service "code.gitea.io/gitea/services/packages/terraform"
+
+	"github.com/hashicorp/go-version"
+)
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.JSON(status, struct {
+		Errors []string `json:"errors"`
+	}{
+		Errors: []string{
+			message,
+		},
+	})
+}
+
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#list-available-versions-for-a-specific-module
+type moduleVersions struct {
+	Modules []*moduleVersionList `json:"modules"`
+}
+
+type moduleVersionList struct {
+	Versions []*moduleVersion `json:"versions"`
+}
+
+type moduleVersion struct {
+	Version string `json:"version"`
+}
+
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+type providerVersions struct {
+	Versions []*providerVersion `json:"versions"`
+}
+
+type providerVersion struct {
+	Version   string                       `json:"version"`
+	Protocols []string                     `json:"protocols"`
+	Platforms []*terraform_module.Platform `json:"platforms"`
+}
+
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+type providerPackage struct {
+	Protocols           []string     `json:"protocols"`
+	OS                  string       `json:"os"`
+	Arch                string       `json:"arch"`
+	Filename            string       `json:"filename"`
+	DownloadURL         string       `json:"download_url"`
+	SHASumsURL          string       `json:"shasums_url"`
+	SHASumsSignatureURL string       `json:"shasums_signature_url"`
+	SHASum              string       `json:"shasum"`
+	SigningKeys         *signingKeys `json:"signing_keys"`
+}
+
+type signingKeys struct {
+	GPGPublicKeys []*gpgPublicKey `json:"gpg_public_keys"`
+}
+
+type gpgPublicKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+func isValidVersion(s string) bool {
+	v, err := version.NewSemver(s)
+	return err == nil && v.String() == s
+}
+
+func modulePackageName(ctx *context.Context) string {
+	return terraform_module.ModulePackageName(ctx.PathParam("name"), ctx.PathParam("system"))
+}
+
+func baseURL(ctx *context.Context) string {
+	return fmt.Sprintf("%sapi/packages/%s/terraform", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name))
+}
+
+// GetRepositoryKey returns the public key used to sign the provider checksums
+func GetRepositoryKey(ctx *context.Context) {
+	pub, _, err := terraform_service.GetPublicKey(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader([]byte(pub)), &context.ServeHeaderOptions{
+		ContentType: "application/pgp-keys",
+		Filename:    "repository.key",
+	})
+}
+
+// EnumerateModuleVersions lists all versions of a module
+func EnumerateModuleVersions(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, modulePackageName(ctx))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	versions := make([]*moduleVersion, 0, len(pvs))
+	for _, pv := range pvs {
+		versions = append(versions, &moduleVersion{Version: pv.Version})
+	}
+
+	ctx.JSON(http.StatusOK, &moduleVersions{
+		Modules: []*moduleVersionList{{Versions: versions}},
+	})
+}
+
+// DownloadModuleVersion points Terraform to the archive of the module version
+func DownloadModuleVersion(ctx *context.Context) {
+	name, system, packageVersion := ctx.PathParam("name"), ctx.PathParam("system"), ctx.PathParam("version")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ModulePackageName(name, system), packageVersion)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("X-Terraform-Get", fmt.Sprintf(
+		"%s/modules/%s/%s/%s/%s",
+		baseURL(ctx),
+		url.PathEscape(name),
+		url.PathEscape(system),
+		url.PathEscape(pv.Version),
+		url.PathEscape(terraform_module.ModuleFilename(name, system, pv.Version)),
+	))
+	ctx.Status(http.StatusNoContent)
+}
+
+// DownloadModuleFile serves the archive of a module version
+func DownloadModuleFile(ctx *context.Context) {
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        modulePackageName(ctx),
+			Version:     ctx.PathParam("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.PathParam("filename"),
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadModule creates a new module version from a tar.gz archive
+func UploadModule(ctx *context.Context) {
+	name, system, packageVersion := ctx.PathParam("name"), ctx.PathParam("system"), ctx.PathParam("version")
+	if !terraform_module.IsValidModuleName(name, system) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	if !isValidVersion(packageVersion) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidVersion)
+		return
+	}
+
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModuleArchive(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        terraform_module.ModulePackageName(name, system),
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: terraform_module.ModuleFilename(name, system, packageVersion),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DeleteModule deletes a module version
+func DeleteModule(ctx *context.Context) {
+	deletePackageVersion(ctx, modulePackageName(ctx))
+}
+
+func deletePackageVersion(ctx *context.Context, name string) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        name,
+			Version:     ctx.PathParam("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func providerProtocols(pd *packages_model.PackageDescriptor) []string {
+	if m, ok := pd.Metadata.(*terraform_module.Metadata); ok && len(m.Protocols) > 0 {
+		return m.Protocols
+	}
+	return []string{terraform_module.DefaultProtocol}
+}
+
+// EnumerateProviderVersions lists all versions of a provider with their supported platforms
+func EnumerateProviderVersions(ctx *context.Context) {
+	providerType := ctx.PathParam("provider")
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, providerType)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		platforms := make([]*terraform_module.Platform, 0, len(pd.Files))
+		for _, pfd := range pd.Files {
+			platform, _, err := terraform_module.ParseProviderFilename(pfd.File.Name, providerType, pd.Version.Version)
+			if err == nil && platform != nil {
+				platforms = append(platforms, platform)
+			}
+		}
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: providerProtocols(pd),
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, &providerVersions{
+		Versions: versions,
+	})
+}
+
+// DownloadProviderPackage describes the provider archive of a platform
+func DownloadProviderPackage(ctx *context.Context) {
+	providerType, packageVersion := ctx.PathParam("provider"), ctx.PathParam("version")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, providerType, packageVersion)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	filename := terraform_module.ProviderFilename(providerType, pv.Version, ctx.PathParam("os"), ctx.PathParam("arch"))
+
+	var pfd *packages_model.PackageFileDescriptor
+	for _, f := range pd.Files {
+		if f.File.Name == filename {
+			pfd = f
+			break
+		}
+	}
+	if pfd == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+
+	pub, keyID, err := terraform_service.GetPublicKey(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versionURL := fmt.Sprintf("%s/providers/%s/%s", baseURL(ctx), url.PathEscape(providerType), url.PathEscape(pv.Version))
+
+	ctx.JSON(http.StatusOK, &providerPackage{
+		Protocols:           providerProtocols(pd),
+		OS:                  ctx.PathParam("os"),
+		Arch:                ctx.PathParam("arch"),
+		Filename:            filename,
+		DownloadURL:         versionURL + "/" + url.PathEscape(filename),
+		SHASumsURL:          versionURL + "/" + terraform_module.SHA256SumsFilename,
+		SHASumsSignatureURL: versionURL + "/" + terraform_module.SHA256SumsSignatureFilename,
+		SHASum:              pfd.Blob.HashSHA256,
+		SigningKeys: &signingKeys{
+			GPGPublicKeys: []*gpgPublicKey{
+				{
+					KeyID:      keyID,
+					ASCIIArmor: pub,
+				},
+			},
+		},
+	})
+}
+
+// DownloadProviderFile serves a provider archive or the signed checksums of a provider version
+func DownloadProviderFile(ctx *context.Context) {
+	providerType, packageVersion, filename := ctx.PathParam("provider"), ctx.PathParam("version"), ctx.PathParam("filename")
+
+	if filename == terraform_module.SHA256SumsFilename || filename == terraform_module.SHA256SumsSignatureFilename {
+		pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, providerType, packageVersion)
+		if err != nil {
+			if errors.Is(err, packages_model.ErrPackageNotExist) {
+				apiError(ctx, http.StatusNotFound, err)
+				return
+			}
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		content := terraform_service.BuildSHA256Sums(pd)
+		if filename == terraform_module.SHA256SumsSignatureFilename {
+			content, err = terraform_service.SignData(ctx, ctx.Package.Owner.ID, bytes.NewReader(content))
+			if err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		ctx.ServeContent(bytes.NewReader(content), &context.ServeHeaderOptions{
+			Filename: filename,
+		})
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        providerType,
+			Version:     packageVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadProviderFile adds the archive of a platform or the manifest to a provider version
+func UploadProviderFile(ctx *context.Context) {
+	providerType, packageVersion, filename := ctx.PathParam("provider"), ctx.PathParam("version"), ctx.PathParam("filename")
+	if !terraform_module.IsValidProviderType(providerType) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	if !isValidVersion(packageVersion) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidVersion)
+		return
+	}
+	_, isManifest, err := terraform_module.ParseProviderFilename(filename, providerType, packageVersion)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata := &terraform_module.Metadata{
+		Kind: terraform_module.KindProvider,
+	}
+	if isManifest {
+		metadata.Protocols, err = terraform_module.ParseProviderManifest(buf)
+	} else {
+		_, err = zip.NewReader(buf, buf.Size())
+		if err != nil {
+			err = terraform_module.ErrInvalidArchive
+		}
+	}
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pv, _, err := packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        providerType,
+				Version:     packageVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  !isManifest,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if isManifest {
+		// the version may already exist without the protocols of the manifest
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		pv.MetadataJSON = string(metadataJSON)
+		if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DeleteProvider deletes a provider version with the archives of all platforms
+func DeleteProvider(ctx *context.Context) {
+	deletePackageVersion(ctx, ctx.PathParam("provider"))
+}
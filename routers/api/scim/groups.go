@@ -0,0 +1,137 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"net/http"
+	"strings"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/scim"
+	scim_service "code.gitea.io/gitea/services/scim"
+)
+
+// withMembers returns whether the members of groups are requested, identity providers exclude them to speed up queries
+func (ctx *Context) withMembers() bool {
+	for excluded := range strings.SplitSeq(ctx.FormString("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(excluded), "members") {
+			return false
+		}
+	}
+	return true
+}
+
+func (ctx *Context) respondGroup(status int, team *organization.Team) {
+	g, err := scim_service.ToGroup(ctx, ctx.Source, team, ctx.withMembers())
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.json(status, g)
+}
+
+func listGroups(ctx *Context) {
+	filter, startIndex, count, ok := ctx.listParams()
+	if !ok {
+		return
+	}
+	teams, total, err := scim_service.FindGroups(ctx, ctx.Source, filter, startIndex, count)
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+
+	resources := make([]any, 0, len(teams))
+	for _, team := range teams {
+		g, err := scim_service.ToGroup(ctx, ctx.Source, team, ctx.withMembers())
+		if err != nil {
+			ctx.serviceError(err)
+			return
+		}
+		resources = append(resources, g)
+	}
+	ctx.json(http.StatusOK, scim.NewListResponse(resources, total, startIndex))
+}
+
+func createGroup(ctx *Context) {
+	var g scim.Group
+	if !ctx.decodeBody(&g) {
+		return
+	}
+	team, err := scim_service.CreateGroup(ctx, ctx.Source, &g)
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.respondGroup(http.StatusCreated, team)
+}
+
+func getGroup(ctx *Context) {
+	id, ok := ctx.pathID()
+	if !ok {
+		return
+	}
+	team, err := scim_service.GetGroup(ctx, ctx.Source, id)
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.respondGroup(http.StatusOK, team)
+}
+
+func replaceGroup(ctx *Context) {
+	id, ok := ctx.pathID()
+	if !ok {
+		return
+	}
+	var g scim.Group
+	if !ctx.decodeBody(&g) {
+		return
+	}
+	team, err := scim_service.GetGroup(ctx, ctx.Source, id)
+	if err == nil {
+		err = scim_service.ReplaceGroup(ctx, ctx.Source, team, &g)
+	}
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.respondGroup(http.StatusOK, team)
+}
+
+func patchGroup(ctx *Context) {
+	id, ok := ctx.pathID()
+	if !ok {
+		return
+	}
+	var req scim.PatchRequest
+	if !ctx.decodeBody(&req) {
+		return
+	}
+	team, err := scim_service.GetGroup(ctx, ctx.Source, id)
+	if err == nil {
+		err = scim_service.PatchGroup(ctx, ctx.Source, team, &req)
+	}
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func deleteGroup(ctx *Context) {
+	id, ok := ctx.pathID()
+	if !ok {
+		return
+	}
+	team, err := scim_service.GetGroup(ctx, ctx.Source, id)
+	if err == nil {
+		err = scim_service.DeleteGroup(ctx, ctx.Source, team)
+	}
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/scim"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	web_types "code.gitea.io/gitea/modules/web/types"
+	"code.gitea.io/gitea/services/context"
+)
+
+// Context is the context of a request of an identity provider, which is authenticated by the SCIM token of a source
+type Context struct {
+	*context.Base
+
+	Source *auth_model.Source
+}
+
+type contextKeyType struct{}
+
+var contextKey = contextKeyType{}
+
+func init() {
+	web.RegisterResponseStatusProvider[*Context](func(req *http.Request) web_types.ResponseStatusProvider {
+		return req.Context().Value(contextKey).(*Context)
+	})
+}
+
+// Routes provides the SCIM 2.0 endpoint, identity providers provision the users of a source and their team memberships through it
+func Routes() *web.Router {
+	m := web.NewRouter()
+	m.Use(contexter())
+
+	m.Get("/ServiceProviderConfig", serviceProviderConfig)
+	m.Group("/Users", func() {
+		m.Combo("").Get(listUsers).Post(createUser)
+		m.Combo("/{id}").Get(getUser).Put(replaceUser).Patch(patchUser).Delete(deleteUser)
+	})
+	m.Group("/Groups", func() {
+		m.Combo("").Get(listGroups).Post(createGroup)
+		m.Combo("/{id}").Get(getGroup).Put(replaceGroup).Patch(patchGroup).Delete(deleteGroup)
+	})
+
+	return m
+}
+
+func contexter() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			base := context.NewBaseContext(resp, req)
+			ctx := &Context{Base: base}
+			ctx.SetContextValue(contextKey, ctx)
+
+			token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+			if !ok {
+				ctx.error(http.StatusUnauthorized, "", "bearer token required")
+				return
+			}
+			source, err := auth_model.GetSourceBySCIMToken(ctx, strings.TrimSpace(token))
+			if err != nil {
+				if !errors.Is(err, util.ErrNotExist) {
+					log.Error("GetSourceBySCIMToken: %v", err)
+				}
+				ctx.error(http.StatusUnauthorized, "", "invalid token")
+				return
+			}
+			if !source.IsActive {
+				ctx.error(http.StatusForbidden, "", "authentication source is not active")
+				return
+			}
+
+			ctx.Source = source
+			next.ServeHTTP(ctx.Resp, ctx.Req)
+		})
+	}
+}
+
+func (ctx *Context) json(status int, v any) {
+	ctx.Resp.Header().Set("Content-Type", scim.ContentType)
+	ctx.Resp.WriteHeader(status)
+	if err := json.NewEncoder(ctx.Resp).Encode(v); err != nil {
+		log.Error("Failed to encode SCIM response: %v", err)
+	}
+}
+
+func (ctx *Context) error(status int, scimType, detail string) {
+	ctx.json(status, &scim.Error{
+		Schemas:  []string{scim.SchemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+	})
+}
+
+// serviceError responds the error of a service function
+func (ctx *Context) serviceError(err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.error(http.StatusBadRequest, scim.ErrorInvalidValue, err.Error())
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.error(http.StatusConflict, scim.ErrorUniqueness, err.Error())
+	case errors.Is(err, util.ErrNotExist):
+		ctx.error(http.StatusNotFound, "", err.Error())
+	case errors.Is(err, util.ErrPermissionDenied):
+		ctx.error(http.StatusForbidden, "", err.Error())
+	default:
+		log.Error("SCIM[%s] %s %s: %v", ctx.Source.Name, ctx.Req.Method, ctx.Req.URL.Path, err)
+		ctx.error(http.StatusInternalServerError, "", "internal server error")
+	}
+}
+
+func (ctx *Context) decodeBody(v any) bool {
+	if err := json.NewDecoder(ctx.Req.Body).Decode(v); err != nil {
+		ctx.error(http.StatusBadRequest, scim.ErrorInvalidSyntax, err.Error())
+		return false
+	}
+	return true
+}
+
+// pathID returns the id of the requested resource, resources of other sources do not exist
+func (ctx *Context) pathID() (int64, bool) {
+	id, err := strconv.ParseInt(ctx.PathParam("id"), 10, 64)
+	if err != nil {
+		ctx.error(http.StatusNotFound, "", "resource not found")
+		return 0, false
+	}
+	return id, true
+}
+
+// listParams returns the filter and the 1-based pagination of a query
+func (ctx *Context) listParams() (filter *scim.Filter, startIndex, count int, ok bool) {
+	if s := ctx.FormString("filter"); s != "" {
+		var err error
+		if filter, err = scim.ParseFilter(s); err != nil {
+			ctx.error(http.StatusBadRequest, scim.ErrorInvalidFilter, err.Error())
+			return nil, 0, 0, false
+		}
+	}
+	startIndex = max(ctx.FormInt("startIndex"), 1)
+	count = setting.API.DefaultPagingNum
+	if ctx.FormString("count") != "" {
+		count = min(max(ctx.FormInt("count"), 0), setting.API.MaxResponseItems)
+	}
+	return filter, startIndex, count, true
+}
+
+func serviceProviderConfig(ctx *Context) {
+	ctx.json(http.StatusOK, &scim.ServiceProviderConfig{
+		Schemas: []string{scim.SchemaServiceProviderConfig},
+		Patch:   scim.Supported{Supported: true},
+		Filter:  scim.FilterSupported{Supported: true, MaxResults: setting.API.MaxResponseItems},
+		AuthenticationSchemes: []scim.AuthenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "Bearer Token",
+				Description: "The SCIM token of the authentication source",
+			},
+		},
+	})
+}
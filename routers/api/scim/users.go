@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/scim"
+	scim_service "code.gitea.io/gitea/services/scim"
+)
+
+func listUsers(ctx *Context) {
+	filter, startIndex, count, ok := ctx.listParams()
+	if !ok {
+		return
+	}
+	users, total, err := scim_service.FindUsers(ctx, ctx.Source, filter, startIndex, count)
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+
+	resources := make([]any, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, scim_service.ToUser(u))
+	}
+	ctx.json(http.StatusOK, scim.NewListResponse(resources, total, startIndex))
+}
+
+func createUser(ctx *Context) {
+	var su scim.User
+	if !ctx.decodeBody(&su) {
+		return
+	}
+	u, err := scim_service.CreateUser(ctx, ctx.Source, &su)
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.Resp.Header().Set("Location", scim_service.ResourceURL("Users", u.ID))
+	ctx.json(http.StatusCreated, scim_service.ToUser(u))
+}
+
+func getUser(ctx *Context) {
+	id, ok := ctx.pathID()
+	if !ok {
+		return
+	}
+	u, err := scim_service.GetUser(ctx, ctx.Source, id)
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.json(http.StatusOK, scim_service.ToUser(u))
+}
+
+func replaceUser(ctx *Context) {
+	id, ok := ctx.pathID()
+	if !ok {
+		return
+	}
+	var su scim.User
+	if !ctx.decodeBody(&su) {
+		return
+	}
+	u, err := scim_service.GetUser(ctx, ctx.Source, id)
+	if err == nil {
+		err = scim_service.UpdateUser(ctx, ctx.Source, u, &su)
+	}
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.json(http.StatusOK, scim_service.ToUser(u))
+}
+
+func patchUser(ctx *Context) {
+	id, ok := ctx.pathID()
+	if !ok {
+		return
+	}
+	var req scim.PatchRequest
+	if !ctx.decodeBody(&req) {
+		return
+	}
+	u, err := scim_service.GetUser(ctx, ctx.Source, id)
+	if err == nil {
+		err = scim_service.PatchUser(ctx, ctx.Source, u, &req)
+	}
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.json(http.StatusOK, scim_service.ToUser(u))
+}
+
+func deleteUser(ctx *Context) {
+	id, ok := ctx.pathID()
+	if !ok {
+		return
+	}
+	u, err := scim_service.GetUser(ctx, ctx.Source, id)
+	if err == nil {
+		err = scim_service.DeleteUser(ctx, ctx.Source, u)
+	}
+	if err != nil {
+		ctx.serviceError(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -70,6 +70,7 @@ import (
 	"net/http"
 	"strings"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/organization"
 	"code.gitea.io/gitea/models/perm"
@@ -94,6 +95,7 @@ import (
 	"code.gitea.io/gitea/routers/api/v1/user"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/services/actions"
+	audit_service "code.gitea.io/gitea/services/audit"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
@@ -123,6 +125,7 @@ func sudo() func(ctx *context.APIContext) {
 					return
 				}
 				log.Trace("Sudo from (%s) to: %s", ctx.Doer.Name, user.Name)
+				audit_service.UserImpersonation(ctx, ctx.Doer, user, ctx.Req)
 				ctx.Doer = user
 			} else {
 				ctx.JSON(http.StatusForbidden, map[string]string{
@@ -315,7 +318,9 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 
 		// use the http method to determine the access level
 		requiredScopeLevel := auth_model.Read
-		if ctx.Req.Method == http.MethodPost || ctx.Req.Method == http.MethodPut || ctx.Req.Method == http.MethodPatch || ctx.Req.Method == http.MethodDelete {
+		// LOCK and UNLOCK are used by the Terraform HTTP state backend
+		switch ctx.Req.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, "LOCK", "UNLOCK":
 			requiredScopeLevel = auth_model.Write
 		}
 
@@ -439,6 +444,35 @@ func reqRepoWriter(unitTypes ...unit.Type) func(ctx *context.APIContext) {
 	}
 }
 
+// reqCommitStatusWriter user should have a permission to write to the code of a repo,
+// the token of an actions job needs the "statuses" permission of its own repo instead
+func reqCommitStatusWriter() func(ctx *context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if ctx.Data["IsActionsToken"] != true {
+			reqRepoWriter(unit.TypeCode)(ctx)
+			return
+		}
+		task, err := actions_model.GetTaskByID(ctx, ctx.Data["ActionsTaskID"].(int64))
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if task.RepoID != ctx.Repo.Repository.ID {
+			ctx.APIError(http.StatusForbidden, "actions token should belong to the repo")
+			return
+		}
+		tokenPerms, err := actions_model.GetTaskTokenPermissions(ctx, task)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if tokenPerms.AccessMode(actions_model.TokenScopeStatuses) < perm.AccessModeWrite {
+			ctx.APIError(http.StatusForbidden, "actions token should have the statuses write permission")
+			return
+		}
+	}
+}
+
 // reqRepoReader user should have specific read permission or be a repo admin or a site admin
 func reqRepoReader(unitType unit.Type) func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
@@ -734,6 +768,13 @@ func mustEnableEditor(ctx *context.APIContext) {
 	}
 }
 
+func mustEnableTerraform(ctx *context.APIContext) {
+	if !setting.Terraform.Enabled {
+		ctx.APIErrorNotFound()
+		return
+	}
+}
+
 func mustEnableAttachments(ctx *context.APIContext) {
 	if !setting.Attachment.Enabled {
 		ctx.APIErrorNotFound()
@@ -1001,6 +1042,7 @@ func Routes() *web.Router {
 				}
 
 				m.Get("/repos", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository), reqExploreSignIn(), user.ListUserRepos)
+				m.Get("/attestations/{subject_digest}", tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository), reqExploreSignIn(), user.ListAttestations)
 				m.Group("/tokens", func() {
 					m.Combo("").Get(user.ListAccessTokens).
 						Post(bind(api.CreateAccessTokenOption{}), reqToken(), user.CreateAccessToken)
@@ -1286,6 +1328,15 @@ func Routes() *web.Router {
 					})
 					m.Get("/artifacts/{artifact_id}/zip", repo.DownloadArtifact)
 				}, reqRepoReader(unit.TypeActions), context.ReferencesGitRepo(true))
+				m.Group("/terraform/states", func() {
+					m.Get("", repo.ListTerraformStates)
+					m.Combo("/{name}").
+						Get(repo.GetTerraformState).
+						Post(mustNotBeArchived, repo.UploadTerraformState).
+						Delete(mustNotBeArchived, repo.DeleteTerraformState)
+					m.Methods("LOCK", "/{name}", repo.LockTerraformState)
+					m.Methods("UNLOCK", "/{name}", repo.UnlockTerraformState)
+				}, mustEnableTerraform, reqToken(), reqRepoWriter(unit.TypeCode))
 				m.Group("/keys", func() {
 					m.Combo("").Get(repo.ListDeployKeys).
 						Post(bind(api.CreateKeyOption{}), repo.CreateDeployKey)
@@ -1383,9 +1434,13 @@ func Routes() *web.Router {
 					})
 					m.Get("/{base}/*", repo.GetPullRequestByBaseHead)
 				}, mustAllowPulls, reqRepoReader(unit.TypeCode), context.ReferencesGitRepo())
+				m.Group("/attestations", func() {
+					m.Post("", reqToken(), bind(api.CreateAttestationOption{}), repo.CreateAttestation)
+					m.Get("/{subject_digest}", reqRepoReader(unit.TypeCode), repo.ListAttestations)
+				})
 				m.Group("/statuses", func() {
 					m.Combo("/{sha}").Get(repo.GetCommitStatuses).
-						Post(reqToken(), reqRepoWriter(unit.TypeCode), bind(api.CreateStatusOption{}), repo.NewCommitStatus)
+						Post(reqToken(), reqCommitStatusWriter(), bind(api.CreateStatusOption{}), repo.NewCommitStatus)
 				}, reqRepoReader(unit.TypeCode))
 				m.Group("/commits", func() {
 					m.Get("", context.ReferencesGitRepo(), repo.GetAllCommits)
@@ -1444,6 +1499,8 @@ func Routes() *web.Router {
 				m.Get("/issue_config", context.ReferencesGitRepo(), repo.GetIssueConfig)
 				m.Get("/issue_config/validate", context.ReferencesGitRepo(), repo.ValidateIssueConfig)
 				m.Get("/languages", reqRepoReader(unit.TypeCode), repo.GetLanguages)
+				m.Get("/dependencies", reqRepoReader(unit.TypeCode), repo.ListDependencies)
+				m.Get("/sbom", reqRepoReader(unit.TypeCode), repo.GetSBOM)
 				m.Get("/licenses", reqRepoReader(unit.TypeCode), repo.GetLicenses)
 				m.Get("/activities/feeds", repo.ListRepoActivityFeeds)
 				m.Get("/new_pin_allowed", repo.AreNewIssuePinsAllowed)
@@ -1664,6 +1721,7 @@ func Routes() *web.Router {
 				m.Delete("", org.DeleteAvatar)
 			}, reqToken(), reqOrgOwnership())
 			m.Get("/activities/feeds", org.ListOrgActivityFeeds)
+			m.Get("/audit_events", reqToken(), reqOrgOwnership(), org.ListAuditEvents)
 
 			m.Group("/blocks", func() {
 				m.Get("", org.ListBlocks)
@@ -1696,6 +1754,7 @@ func Routes() *web.Router {
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryOrganization), orgAssignment(false, true), reqToken(), reqTeamMembership(), checkTokenPublicOnly())
 
 		m.Group("/admin", func() {
+			m.Get("/audit_events", admin.ListAuditEvents)
 			m.Group("/cron", func() {
 				m.Get("", admin.ListCronTasks)
 				m.Post("/{task}", admin.PostCronTask)
@@ -1754,6 +1813,10 @@ func Routes() *web.Router {
 		m.Group("/topics", func() {
 			m.Get("/search", repo.TopicSearch)
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository))
+
+		m.Group("/dependencies", func() {
+			m.Get("/search", repo.DependencySearch)
+		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryRepository))
 	}, sudo())
 
 	return m
@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ListAuditEvents lists the events of the audit log of an organization
+func ListAuditEvents(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/audit_events organization orgListAuditEvents
+	// ---
+	// summary: List the events of the audit log of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: action
+	//   in: query
+	//   description: only list events of this action
+	//   type: string
+	// - name: actor
+	//   in: query
+	//   description: only list events of changes made by this user
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AuditEventList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListAuditEvents(ctx, ctx.Org.Organization.ID)
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// CreateAttestation uploads an attestation from an actions job
+func CreateAttestation(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/attestations repository repoCreateAttestation
+	// ---
+	// summary: Upload an in-toto statement about artifacts produced by the actions job authenticated by the request
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateAttestationOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Attestation"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	if ctx.Data["IsActionsToken"] != true {
+		ctx.APIError(http.StatusForbidden, "only the token of an actions job can upload attestations")
+		return
+	}
+	task, err := actions_model.GetTaskByID(ctx, ctx.Data["ActionsTaskID"].(int64))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if task.RepoID != ctx.Repo.Repository.ID {
+		ctx.APIError(http.StatusForbidden, "actions token should belong to the repo")
+		return
+	}
+
+	form := web.GetForm(ctx).(*api.CreateAttestationOption)
+	content, err := json.Marshal(form.Statement)
+	if err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return
+	}
+	attestation, err := actions_service.CreateAttestation(ctx, task, content)
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.APIError(http.StatusForbidden, err)
+		case errors.Is(err, util.ErrNotExist):
+			ctx.APIError(http.StatusNotFound, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	apiAttestation, err := convert.ToAttestation(ctx.Repo.Repository, attestation, true)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiAttestation)
+}
+
+// ListAttestations lists and verifies the attestations of the artifact with the given digest
+func ListAttestations(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/attestations/{subject_digest} repository repoListAttestations
+	// ---
+	// summary: List the attestations of an artifact produced by the actions runs of a repository, each of them is verified
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: subject_digest
+	//   in: path
+	//   description: digest of the artifact or the container image, e.g. sha256:0123abcd
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AttestationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	digest := actions_module.NormalizeDigest(ctx.PathParam("subject_digest"))
+	attestations, total, err := db.FindAndCount[actions_model.ActionAttestation](ctx, actions_model.FindAttestationsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		Digests:     []string{digest},
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiAttestations := make([]*api.Attestation, 0, len(attestations))
+	for _, attestation := range attestations {
+		_, verifyErr := actions_service.VerifyAttestation(attestation, digest)
+		apiAttestation, err := convert.ToAttestation(ctx.Repo.Repository, attestation, verifyErr == nil)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiAttestations = append(apiAttestations, apiAttestation)
+	}
+
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiAttestations)
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// ListDependencies lists the dependencies of the default branch of a repository
+func ListDependencies(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/dependencies repository repoListDependencies
+	// ---
+	// summary: List the dependencies declared in the manifests and lockfiles of the default branch
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ecosystem
+	//   in: query
+	//   description: ecosystem of the dependencies, e.g. "npm", "Go" or "crates.io"
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: keyword to search in the names of the dependencies
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoDependencyList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	deps, total, err := db.FindAndCount[repo_model.RepoDependency](ctx, &repo_model.FindDependenciesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+		Ecosystem:   ctx.FormTrim("ecosystem"),
+		Keyword:     ctx.FormTrim("q"),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiDeps := make([]*api.RepoDependency, 0, len(deps))
+	for _, d := range deps {
+		apiDeps = append(apiDeps, convert.ToRepoDependency(d, nil))
+	}
+
+	ctx.SetLinkHeader(int(total), utils.GetListOptions(ctx).PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiDeps)
+}
+
+// GetSBOM exports the dependencies of a commit as software bill of materials
+func GetSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/sbom repository repoGetSBOM
+	// ---
+	// summary: Export the dependencies of a commit as software bill of materials
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: ref
+	//   in: query
+	//   description: "The name of the commit/branch/tag. Default to the repository’s default branch."
+	//   type: string
+	// - name: format
+	//   in: query
+	//   description: format of the document, CycloneDX 1.5 or SPDX 2.3
+	//   type: string
+	//   enum: [cyclonedx, spdx]
+	//   default: cyclonedx
+	// responses:
+	//   "200":
+	//     description: the SBOM document
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	refCommit := resolveRefCommit(ctx, ctx.FormTrim("ref"))
+	if ctx.Written() {
+		return
+	}
+
+	format := util.IfZero(ctx.FormTrim("format"), dependency.SBOMFormatCycloneDX)
+	sbom, err := repo_service.GenerateSBOM(ctx, ctx.Repo.Repository, refCommit.Commit, format)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", repo_service.SBOMContentTypes[format])
+	ctx.Resp.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(ctx.Resp).Encode(sbom); err != nil {
+		log.Error("Encode SBOM failed: %v", err)
+	}
+}
+
+// DependencySearch searches the repositories which depend on a package
+func DependencySearch(ctx *context.APIContext) {
+	// swagger:operation GET /dependencies/search repository dependencySearch
+	// ---
+	// summary: Search the repositories which depend on a package
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: name
+	//   in: query
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: ecosystem
+	//   in: query
+	//   description: ecosystem of the package, e.g. "npm", "Go" or "crates.io"
+	//   type: string
+	// - name: version
+	//   in: query
+	//   description: version of the package
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RepoDependencyList"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	opts := &repo_model.FindDependenciesOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		Ecosystem:   ctx.FormTrim("ecosystem"),
+		Name:        ctx.FormTrim("name"),
+		Version:     ctx.FormTrim("version"),
+	}
+	if opts.Name == "" {
+		ctx.APIError(http.StatusUnprocessableEntity, "the name of the package is required")
+		return
+	}
+	if ctx.Doer == nil || !ctx.Doer.IsAdmin {
+		opts.RepoCond = repo_model.AccessibleRepositoryCondition(ctx.Doer, unit.TypeCode)
+	}
+
+	deps, total, err := db.FindAndCount[repo_model.RepoDependency](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if err := repo_model.RepoDependencyList(deps).LoadRepositories(ctx); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRepos := make(map[int64]*api.Repository)
+	apiDeps := make([]*api.RepoDependency, 0, len(deps))
+	for _, d := range deps {
+		if d.Repo == nil {
+			continue
+		}
+		apiRepo, ok := apiRepos[d.RepoID]
+		if !ok {
+			permission, err := access_model.GetUserRepoPermission(ctx, d.Repo, ctx.Doer)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+			apiRepo = convert.ToRepo(ctx, d.Repo, permission)
+			apiRepos[d.RepoID] = apiRepo
+		}
+		apiDeps = append(apiDeps, convert.ToRepoDependency(d, apiRepo))
+	}
+
+	ctx.SetLinkHeader(int(total), opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, apiDeps)
+}
@@ -20,8 +20,10 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	audit_service "code.gitea.io/gitea/services/audit"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
+	notify_service "code.gitea.io/gitea/services/notify"
 )
 
 // appendPrivateInformation appends the owner and key type information to api.PublicKey
@@ -243,8 +245,10 @@ func CreateDeployKey(ctx *context.APIContext) {
 		HandleAddKeyError(ctx, err)
 		return
 	}
+	audit_service.DeployKeyAdd(ctx, ctx.Doer, ctx.Repo.Repository, key)
 
 	key.Content = content
+	notify_service.AddDeployKey(ctx, ctx.Doer, ctx.Repo.Repository, key)
 	apiLink := composeDeployKeysAPILink(ctx.Repo.Owner.Name, ctx.Repo.Repository.Name)
 	ctx.JSON(http.StatusCreated, convert.ToDeployKey(apiLink, key))
 }
@@ -279,7 +283,8 @@ func DeleteDeploykey(ctx *context.APIContext) {
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
-	if err := asymkey_service.DeleteDeployKey(ctx, ctx.Repo.Repository, ctx.PathParamInt64("id")); err != nil {
+	key, err := asymkey_service.DeleteDeployKey(ctx, ctx.Repo.Repository, ctx.PathParamInt64("id"))
+	if err != nil {
 		if asymkey_model.IsErrKeyAccessDenied(err) {
 			ctx.APIError(http.StatusForbidden, "You do not have access to this key")
 		} else {
@@ -287,6 +292,10 @@ func DeleteDeploykey(ctx *context.APIContext) {
 		}
 		return
 	}
+	if key != nil {
+		audit_service.DeployKeyDelete(ctx, ctx.Doer, ctx.Repo.Repository, key)
+		notify_service.DeleteDeployKey(ctx, ctx.Doer, ctx.Repo.Repository, key)
+	}
 
 	ctx.Status(http.StatusNoContent)
 }
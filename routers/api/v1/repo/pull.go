@@ -37,6 +37,7 @@ import (
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/gitdiff"
 	issue_service "code.gitea.io/gitea/services/issue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -886,6 +887,8 @@ func MergePullRequest(ctx *context.APIContext) {
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/empty"
+	//   "202":
+	//     "$ref": "#/responses/empty"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 	//   "405":
@@ -1016,6 +1019,24 @@ func MergePullRequest(ctx *context.APIContext) {
 		}
 	}
 
+	if !form.MergeWhenChecksSucceed && !form.ForceMerge {
+		if enabled, err := mergequeue.IsMergeQueueEnabled(ctx, pr); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		} else if enabled {
+			if err := mergequeue.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, deleteBranchAfterMerge); err != nil {
+				if errors.Is(err, pull_model.ErrAlreadyInMergeQueue) {
+					ctx.APIError(http.StatusConflict, err)
+					return
+				}
+				ctx.APIErrorInternal(err)
+				return
+			}
+			ctx.Status(http.StatusAccepted)
+			return
+		}
+	}
+
 	if err := pull_service.Merge(ctx, pr, ctx.Doer, repo_model.MergeStyle(form.Do), form.HeadCommitID, message, false); err != nil {
 		if pull_service.IsErrInvalidMergeStyle(err) {
 			ctx.APIError(http.StatusMethodNotAllowed, fmt.Errorf("%s is not allowed an allowed merge style for this repository", repo_model.MergeStyle(form.Do)))
@@ -1295,7 +1316,7 @@ func UpdatePullRequest(ctx *context.APIContext) {
 func CancelScheduledAutoMerge(ctx *context.APIContext) {
 	// swagger:operation DELETE /repos/{owner}/{repo}/pulls/{index}/merge repository repoCancelScheduledAutoMerge
 	// ---
-	// summary: Cancel the scheduled auto merge for the given pull request
+	// summary: Cancel the scheduled auto merge for the given pull request, or remove it from the merge queue
 	// produces:
 	// - application/json
 	// parameters:
@@ -1342,7 +1363,7 @@ func CancelScheduledAutoMerge(ctx *context.APIContext) {
 		return
 	}
 	if !exist {
-		ctx.APIErrorNotFound()
+		cancelMergeQueueEntry(ctx, pull)
 		return
 	}
 
@@ -1365,6 +1386,37 @@ func CancelScheduledAutoMerge(ctx *context.APIContext) {
 	}
 }
 
+// cancelMergeQueueEntry removes a pull request from the merge queue of its base branch
+func cancelMergeQueueEntry(ctx *context.APIContext, pull *issues_model.PullRequest) {
+	entry, exist, err := pull_model.GetMergeQueueEntryByPullID(ctx, pull.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if !exist {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	if ctx.Doer.ID != entry.DoerID {
+		allowed, err := access_model.IsUserRepoAdmin(ctx, ctx.Repo.Repository, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		if !allowed {
+			ctx.APIError(http.StatusForbidden, "user has no permission to remove the pull request from the merge queue")
+			return
+		}
+	}
+
+	if err := mergequeue.RemoveFromMergeQueue(ctx, ctx.Doer, pull, mergequeue.RemovedReasonCanceled); err != nil {
+		ctx.APIErrorInternal(err)
+	} else {
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
 // GetPullRequestCommits gets all commits associated with a given PR
 func GetPullRequestCommits(ctx *context.APIContext) {
 	// swagger:operation GET /repos/{owner}/{repo}/pulls/{index}/commits repository repoGetPullRequestCommits
@@ -37,6 +37,7 @@ import (
 	"code.gitea.io/gitea/services/convert"
 	feed_service "code.gitea.io/gitea/services/feed"
 	"code.gitea.io/gitea/services/issue"
+	notify_service "code.gitea.io/gitea/services/notify"
 	repo_service "code.gitea.io/gitea/services/repository"
 )
 
@@ -749,10 +750,13 @@ func updateBasicProperties(ctx *context.APIContext, opts api.EditRepoOption) err
 		}
 	}
 
-	if err := repo_service.UpdateRepository(ctx, repo, visibilityChanged); err != nil {
+	if err := repo_service.UpdateRepository(ctx, ctx.Doer, repo, visibilityChanged); err != nil {
 		ctx.APIErrorInternal(err)
 		return err
 	}
+	if visibilityChanged {
+		notify_service.ChangeRepositoryVisibility(ctx, ctx.Doer, repo)
+	}
 
 	if updateRepoLicense {
 		if err := repo_service.AddRepoToLicenseUpdaterQueue(&repo_service.LicenseUpdaterOptions{
@@ -0,0 +1,267 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	terraform_service "code.gitea.io/gitea/services/terraform"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func init() {
+	// the Terraform HTTP backend uses these methods to lock and unlock a state
+	chi.RegisterMethod("LOCK")
+	chi.RegisterMethod("UNLOCK")
+}
+
+func terraformStateError(ctx *context.APIContext, state *terraform_model.State, err error) {
+	switch {
+	case errors.Is(err, terraform_service.ErrStateLocked):
+		// Terraform shows the lock info of the conflicting operation
+		if state != nil {
+			ctx.JSON(http.StatusLocked, state.GetLockInfo())
+			return
+		}
+		ctx.APIError(http.StatusLocked, err)
+	case errors.Is(err, terraform_service.ErrStateTooLarge):
+		ctx.APIError(http.StatusRequestEntityTooLarge, err)
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusBadRequest, err)
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIErrorNotFound(err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// ListTerraformStates lists the Terraform states of a repository
+func ListTerraformStates(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/states repository repoListTerraformStates
+	// ---
+	// summary: List the Terraform states of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/TerraformStateList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	states, err := terraform_model.GetStatesByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiStates := make([]*api.TerraformState, 0, len(states))
+	for _, state := range states {
+		apiStates = append(apiStates, convert.ToTerraformState(state))
+	}
+	ctx.JSON(http.StatusOK, apiStates)
+}
+
+// GetTerraformState returns the current version of a Terraform state
+func GetTerraformState(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/terraform/states/{name} repository repoGetTerraformState
+	// ---
+	// summary: Get the current version of a Terraform state, this is the address of the Terraform HTTP backend
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the content of the state
+	//   "204":
+	//     description: the state has no content yet
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	_, sv, err := terraform_service.GetLatestStateVersion(ctx, ctx.Repo.Repository, ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, terraform_model.ErrStateVersionNotExist) {
+			ctx.Status(http.StatusNoContent)
+			return
+		}
+		terraformStateError(ctx, nil, err)
+		return
+	}
+
+	f, err := terraform_service.OpenStateVersion(ctx, sv)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	defer f.Close()
+
+	ctx.ServeContent(f, &context.ServeHeaderOptions{
+		ContentType:        "application/json",
+		ContentTypeCharset: "utf-8",
+		ContentLength:      &sv.Size,
+		LastModified:       sv.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// UploadTerraformState stores a new version of a Terraform state
+func UploadTerraformState(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/terraform/states/{name} repository repoUploadTerraformState
+	// ---
+	// summary: Store a new version of a Terraform state
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// - name: ID
+	//   in: query
+	//   description: id of the lock held by the Terraform operation
+	//   type: string
+	// - name: body
+	//   in: body
+	//   schema:
+	//     type: object
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+	//   "423":
+	//     "$ref": "#/responses/error"
+
+	name := ctx.PathParam("name")
+	if _, err := terraform_service.UploadState(ctx, ctx.Repo.Repository, ctx.Doer, name, ctx.FormString("ID"), ctx.Req.Body); err != nil {
+		var state *terraform_model.State
+		if errors.Is(err, terraform_service.ErrStateLocked) {
+			state, _ = terraform_model.GetStateByName(ctx, ctx.Repo.Repository.ID, name)
+		}
+		terraformStateError(ctx, state, err)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+// DeleteTerraformState deletes a Terraform state with all its versions
+func DeleteTerraformState(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/terraform/states/{name} repository repoDeleteTerraformState
+	// ---
+	// summary: Delete a Terraform state with all its versions
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the state
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/error"
+
+	// Terraform expects 200 instead of 204 on success
+	if err := terraform_service.DeleteState(ctx, ctx.Repo.Repository, ctx.PathParam("name")); err != nil {
+		terraformStateError(ctx, nil, err)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+// LockTerraformState locks a Terraform state for an operation, the body is the lock info sent by Terraform
+func LockTerraformState(ctx *context.APIContext) {
+	state, err := terraform_service.LockState(ctx, ctx.Repo.Repository, ctx.Doer, ctx.PathParam("name"), ctx.Req.Body)
+	if err != nil {
+		terraformStateError(ctx, state, err)
+		return
+	}
+	ctx.Status(http.StatusOK)
+}
+
+// UnlockTerraformState unlocks a Terraform state, an empty body removes any lock
+func UnlockTerraformState(ctx *context.APIContext) {
+	lockID, err := terraform_service.ReadLockID(ctx.Req.Body)
+	if err != nil {
+		terraformStateError(ctx, nil, err)
+		return
+	}
+
+	state, err := terraform_service.UnlockState(ctx, ctx.Repo.Repository, ctx.PathParam("name"), lockID)
+	if err != nil {
+		terraformStateError(ctx, state, err)
+		return
+	}
+	if lockID == "" {
+		log.Trace("Terraform state %q of repository %s was force-unlocked by %s", state.Name, ctx.Repo.Repository.FullName(), ctx.Doer.Name)
+	}
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"net/http"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListAuditEvents lists the events of the audit log filtered by the request, ownerID 0 lists the events of all owners.
+// Access rights are checked at the API route level
+func ListAuditEvents(ctx *context.APIContext, ownerID int64) {
+	opts := &audit_model.FindEventsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerID:     ownerID,
+		Action:      audit_model.Action(ctx.FormString("action")),
+	}
+
+	if actorName := ctx.FormTrim("actor"); actorName != "" {
+		actor, err := user_model.GetUserByName(ctx, actorName)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.APIErrorNotFound(err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		opts.ActorID = actor.ID
+	}
+
+	events, total, err := db.FindAndCount[audit_model.Event](ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := make([]*api.AuditEvent, len(events))
+	for i, event := range events {
+		res[i] = convert.ToAuditEvent(event)
+	}
+
+	ctx.SetLinkHeader(int(total), opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
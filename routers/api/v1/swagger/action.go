@@ -46,3 +46,17 @@ type swaggerResponseActionWorkflowList struct {
 	// in:body
 	Body api.ActionWorkflowResponse `json:"body"`
 }
+
+// Attestation
+// swagger:response Attestation
+type swaggerResponseAttestation struct {
+	// in:body
+	Body api.Attestation `json:"body"`
+}
+
+// AttestationList
+// swagger:response AttestationList
+type swaggerResponseAttestationList struct {
+	// in:body
+	Body []api.Attestation `json:"body"`
+}
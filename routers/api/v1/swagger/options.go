@@ -225,4 +225,7 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	LockIssueOption api.LockIssueOption
+
+	// in:body
+	CreateAttestationOption api.CreateAttestationOption
 }
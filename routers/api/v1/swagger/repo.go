@@ -522,3 +522,17 @@ type swaggerMergeUpstreamResponse struct {
 	// in:body
 	Body api.MergeUpstreamResponse `json:"body"`
 }
+
+// RepoDependencyList
+// swagger:response RepoDependencyList
+type swaggerResponseRepoDependencyList struct {
+	// in:body
+	Body []api.RepoDependency `json:"body"`
+}
+
+// TerraformStateList
+// swagger:response TerraformStateList
+type swaggerResponseTerraformStateList struct {
+	// in:body
+	Body []api.TerraformState `json:"body"`
+}
@@ -16,6 +16,7 @@ import (
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/v1/utils"
+	audit_service "code.gitea.io/gitea/services/audit"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
 )
@@ -130,6 +131,8 @@ func CreateAccessToken(ctx *context.APIContext) {
 		ctx.APIErrorInternal(err)
 		return
 	}
+	audit_service.AccessTokenCreate(ctx, ctx.Doer, ctx.ContextUser, t)
+
 	ctx.JSON(http.StatusCreated, &api.AccessToken{
 		Name:           t.Name,
 		Token:          t.Token,
@@ -196,7 +199,11 @@ func DeleteAccessToken(ctx *context.APIContext) {
 		return
 	}
 
-	if err := auth_model.DeleteAccessTokenByID(ctx, tokenID, ctx.ContextUser.ID); err != nil {
+	t, err := auth_model.GetAccessTokenByID(ctx, tokenID, ctx.ContextUser.ID)
+	if err == nil {
+		err = auth_model.DeleteAccessTokenByID(ctx, tokenID, ctx.ContextUser.ID)
+	}
+	if err != nil {
 		if auth_model.IsErrAccessTokenNotExist(err) {
 			ctx.APIErrorNotFound()
 		} else {
@@ -204,6 +211,7 @@ func DeleteAccessToken(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.AccessTokenDelete(ctx, ctx.Doer, ctx.ContextUser, t)
 
 	ctx.Status(http.StatusNoContent)
 }
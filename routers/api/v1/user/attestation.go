@@ -0,0 +1,98 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListAttestations lists and verifies the attestations of the artifact with the given digest across the repositories of a user or an organization
+func ListAttestations(ctx *context.APIContext) {
+	// swagger:operation GET /users/{username}/attestations/{subject_digest} user userListAttestations
+	// ---
+	// summary: List the attestations of an artifact produced by the actions runs of the repositories of a user or an organization, each of them is verified
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user or organization
+	//   type: string
+	//   required: true
+	// - name: subject_digest
+	//   in: path
+	//   description: digest of the artifact or the container image, e.g. sha256:0123abcd
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/AttestationList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	digest := actions_module.NormalizeDigest(ctx.PathParam("subject_digest"))
+	attestations, err := db.Find[actions_model.ActionAttestation](ctx, actions_model.FindAttestationsOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerID:     ctx.ContextUser.ID,
+		Digests:     []string{digest},
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	repos := make(map[int64]*repo_model.Repository)
+	apiAttestations := make([]*api.Attestation, 0, len(attestations))
+	for _, attestation := range attestations {
+		repo, ok := repos[attestation.RepoID]
+		if !ok {
+			if repo, err = repo_model.GetRepositoryByID(ctx, attestation.RepoID); err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+			permission, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+			if !permission.CanRead(unit.TypeCode) {
+				repo = nil
+			}
+			repos[attestation.RepoID] = repo
+		}
+		if repo == nil {
+			continue
+		}
+
+		_, verifyErr := actions_service.VerifyAttestation(attestation, digest)
+		apiAttestation, err := convert.ToAttestation(repo, attestation, verifyErr == nil)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiAttestations = append(apiAttestations, apiAttestation)
+	}
+
+	ctx.JSON(http.StatusOK, apiAttestations)
+}
@@ -15,6 +15,7 @@ import (
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
+	repo_service "code.gitea.io/gitea/services/repository"
 )
 
 // getStarredRepos returns the repos that the user with the specified userID has
@@ -166,7 +167,7 @@ func Star(ctx *context.APIContext) {
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
-	err := repo_model.StarRepo(ctx, ctx.Doer, ctx.Repo.Repository, true)
+	err := repo_service.StarRepository(ctx, ctx.Doer, ctx.Repo.Repository, true)
 	if err != nil {
 		if errors.Is(err, user_model.ErrBlockedUser) {
 			ctx.APIError(http.StatusForbidden, err)
@@ -202,7 +203,7 @@ func Unstar(ctx *context.APIContext) {
 	//   "403":
 	//     "$ref": "#/responses/forbidden"
 
-	err := repo_model.StarRepo(ctx, ctx.Doer, ctx.Repo.Repository, false)
+	err := repo_service.StarRepository(ctx, ctx.Doer, ctx.Repo.Repository, false)
 	if err != nil {
 		ctx.APIErrorInternal(err)
 		return
@@ -14,6 +14,7 @@ import (
 	"code.gitea.io/gitea/routers/api/v1/utils"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/convert"
+	repo_service "code.gitea.io/gitea/services/repository"
 )
 
 // getWatchedRepos returns the repos that the user with the specified userID is watching
@@ -165,7 +166,7 @@ func Watch(ctx *context.APIContext) {
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
-	err := repo_model.WatchRepo(ctx, ctx.Doer, ctx.Repo.Repository, true)
+	err := repo_service.WatchRepository(ctx, ctx.Doer, ctx.Repo.Repository, true)
 	if err != nil {
 		if errors.Is(err, user_model.ErrBlockedUser) {
 			ctx.APIError(http.StatusForbidden, err)
@@ -206,7 +207,7 @@ func Unwatch(ctx *context.APIContext) {
 	//   "404":
 	//     "$ref": "#/responses/notFound"
 
-	err := repo_model.WatchRepo(ctx, ctx.Doer, ctx.Repo.Repository, false)
+	err := repo_service.WatchRepository(ctx, ctx.Doer, ctx.Repo.Repository, false)
 	if err != nil {
 		ctx.APIErrorInternal(err)
 		return
@@ -172,7 +172,18 @@ func updateHookEvents(events []string) webhook_module.HookEvents {
 	hookEvents[webhook_module.HookEventWiki] = util.SliceContainsString(events, string(webhook_module.HookEventWiki), true)
 	hookEvents[webhook_module.HookEventRelease] = util.SliceContainsString(events, string(webhook_module.HookEventRelease), true)
 	hookEvents[webhook_module.HookEventPackage] = util.SliceContainsString(events, string(webhook_module.HookEventPackage), true)
+	hookEvents[webhook_module.HookEventPackageVulnerability] = util.SliceContainsString(events, string(webhook_module.HookEventPackageVulnerability), true)
 	hookEvents[webhook_module.HookEventStatus] = util.SliceContainsString(events, string(webhook_module.HookEventStatus), true)
+	hookEvents[webhook_module.HookEventMember] = util.SliceContainsString(events, string(webhook_module.HookEventMember), true)
+	hookEvents[webhook_module.HookEventOrganization] = util.SliceContainsString(events, string(webhook_module.HookEventOrganization), true)
+	hookEvents[webhook_module.HookEventTeam] = util.SliceContainsString(events, string(webhook_module.HookEventTeam), true)
+	hookEvents[webhook_module.HookEventMembership] = util.SliceContainsString(events, string(webhook_module.HookEventMembership), true)
+	hookEvents[webhook_module.HookEventBranchProtection] = util.SliceContainsString(events, string(webhook_module.HookEventBranchProtection), true)
+	hookEvents[webhook_module.HookEventDeployKey] = util.SliceContainsString(events, string(webhook_module.HookEventDeployKey), true)
+	hookEvents[webhook_module.HookEventRepositoryVisibility] = util.SliceContainsString(events, string(webhook_module.HookEventRepositoryVisibility), true)
+	hookEvents[webhook_module.HookEventUser] = util.SliceContainsString(events, string(webhook_module.HookEventUser), true)
+	hookEvents[webhook_module.HookEventStar] = util.SliceContainsString(events, string(webhook_module.HookEventStar), true)
+	hookEvents[webhook_module.HookEventWatch] = util.SliceContainsString(events, string(webhook_module.HookEventWatch), true)
 	hookEvents[webhook_module.HookEventWorkflowRun] = util.SliceContainsString(events, string(webhook_module.HookEventWorkflowRun), true)
 	hookEvents[webhook_module.HookEventWorkflowJob] = util.SliceContainsString(events, string(webhook_module.HookEventWorkflowJob), true)
 
@@ -223,9 +234,11 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 			ChooseEvents: true,
 			HookEvents:   updateHookEvents(form.Events),
 			BranchFilter: form.BranchFilter,
+			Condition:    form.Condition,
 		},
-		IsActive: form.Active,
-		Type:     form.Type,
+		IsActive:    form.Active,
+		Type:        form.Type,
+		MaxAttempts: form.MaxAttempts,
 	}
 	err := w.SetHeaderAuthorization(form.AuthorizationHeader)
 	if err != nil {
@@ -257,6 +270,27 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		}
 		w.Meta = string(meta)
 	}
+	if w.Type == webhook_module.CUSTOM {
+		payloadTemplate, ok := form.Config["payload_template"]
+		if !ok {
+			ctx.APIError(http.StatusUnprocessableEntity, "Missing config option: payload_template")
+			return nil, false
+		}
+		if _, err := webhook_service.ParseCustomPayloadTemplate(payloadTemplate); err != nil {
+			ctx.APIError(http.StatusUnprocessableEntity, "Invalid payload template: "+err.Error())
+			return nil, false
+		}
+
+		meta, err := json.Marshal(&webhook_service.CustomMeta{
+			PayloadTemplate:    payloadTemplate,
+			PayloadContentType: form.Config["payload_content_type"],
+		})
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return nil, false
+		}
+		w.Meta = string(meta)
+	}
 
 	if err := w.UpdateEvent(); err != nil {
 		ctx.APIErrorInternal(err)
@@ -367,6 +401,26 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 				w.Meta = string(meta)
 			}
 		}
+
+		if w.Type == webhook_module.CUSTOM {
+			meta := webhook_service.GetCustomHook(w)
+			if payloadTemplate, ok := form.Config["payload_template"]; ok {
+				if _, err := webhook_service.ParseCustomPayloadTemplate(payloadTemplate); err != nil {
+					ctx.APIError(http.StatusUnprocessableEntity, "Invalid payload template: "+err.Error())
+					return false
+				}
+				meta.PayloadTemplate = payloadTemplate
+			}
+			if contentType, ok := form.Config["payload_content_type"]; ok {
+				meta.PayloadContentType = contentType
+			}
+			data, err := json.Marshal(meta)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return false
+			}
+			w.Meta = string(data)
+		}
 	}
 
 	// Update events
@@ -375,6 +429,7 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	w.SendEverything = false
 	w.ChooseEvents = true
 	w.BranchFilter = form.BranchFilter
+	w.Condition = form.Condition
 
 	err := w.SetHeaderAuthorization(form.AuthorizationHeader)
 	if err != nil {
@@ -388,9 +443,20 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	}
 
 	if form.Active != nil {
+		if *form.Active && !w.IsActive {
+			w.ConsecutiveFailures = 0
+		}
 		w.IsActive = *form.Active
 	}
 
+	if form.MaxAttempts != nil {
+		if *form.MaxAttempts < 0 || *form.MaxAttempts > 10 {
+			ctx.APIError(http.StatusUnprocessableEntity, "max_attempts must be between 0 and 10")
+			return false
+		}
+		w.MaxAttempts = *form.MaxAttempts
+	}
+
 	if err := webhook.UpdateWebhook(ctx, w); err != nil {
 		ctx.APIErrorInternal(err)
 		return false
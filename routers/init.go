@@ -31,6 +31,7 @@ import (
 	"code.gitea.io/gitea/modules/web/routing"
 	actions_router "code.gitea.io/gitea/routers/api/actions"
 	packages_router "code.gitea.io/gitea/routers/api/packages"
+	scim_router "code.gitea.io/gitea/routers/api/scim"
 	apiv1 "code.gitea.io/gitea/routers/api/v1"
 	"code.gitea.io/gitea/routers/common"
 	"code.gitea.io/gitea/routers/private"
@@ -46,9 +47,11 @@ import (
 	"code.gitea.io/gitea/services/mailer"
 	mailer_incoming "code.gitea.io/gitea/services/mailer/incoming"
 	markup_service "code.gitea.io/gitea/services/markup"
+	"code.gitea.io/gitea/services/mergequeue"
 	repo_migrations "code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	"code.gitea.io/gitea/services/oauth2_provider"
+	packages_scanner_service "code.gitea.io/gitea/services/packages/scanner"
 	pull_service "code.gitea.io/gitea/services/pull"
 	release_service "code.gitea.io/gitea/services/release"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -160,8 +163,10 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(webhook.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(mergequeue.Init)
 	mustInit(task.Init)
 	mustInit(repo_migrations.Init)
+	mustInit(packages_scanner_service.Init)
 	eventsource.GetManager().Init()
 	mustInitCtx(ctx, mailer_incoming.Init)
 
@@ -192,6 +197,9 @@ func NormalRoutes() *web.Router {
 
 	r.Post("/-/fetch-redirect", common.FetchRedirectDelegate)
 
+	// SCIM provisioning of the users of authentication sources by identity providers
+	r.Mount("/scim/v2", scim_router.Routes())
+
 	if setting.Packages.Enabled {
 		// This implements package support for most package managers
 		r.Mount("/api/packages", packages_router.CommonRoutes())
@@ -8,14 +8,17 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	perm_model "code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
+	quota_model "code.gitea.io/gitea/models/quota"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/gitrepo"
@@ -46,6 +49,9 @@ type preReceiveContext struct {
 	protectedTags    []*git_model.ProtectedTag
 	gotProtectedTags bool
 
+	rulesets    []*git_model.Ruleset
+	gotRulesets bool
+
 	env []string
 
 	opts *private.HookOptions
@@ -134,11 +140,46 @@ func HookPreReceive(ctx *gitea_context.PrivateContext) {
 		if ctx.Written() {
 			return
 		}
+
+		preReceiveRulesets(ourCtx, oldCommitID, newCommitID, refFullName)
+		if ctx.Written() {
+			return
+		}
+	}
+
+	preReceiveQuota(ourCtx)
+	if ctx.Written() {
+		return
 	}
 
 	ctx.PlainText(http.StatusOK, "ok")
 }
 
+// preReceiveQuota rejects pushes if the owner of the repository exceeds the storage quota.
+// Pushes which only delete refs are allowed to free up space.
+func preReceiveQuota(ctx *preReceiveContext) {
+	emptyCommitID := ctx.Repo.GetObjectFormat().EmptyObjectID().String()
+	if !slices.ContainsFunc(ctx.opts.NewCommitIDs, func(commitID string) bool { return commitID != emptyCommitID }) {
+		return
+	}
+
+	repo := ctx.Repo.Repository
+	if err := quota_model.CheckQuota(ctx, repo.OwnerID, quota_model.CategoryGit, 0); err != nil {
+		var errQuota quota_model.ErrQuotaExceeded
+		if errors.As(err, &errQuota) {
+			log.Warn("Forbidden: %-v exceeds the storage quota of its owner: %v", repo, err)
+			ctx.JSON(http.StatusForbidden, private.Response{
+				UserMsg: fmt.Sprintf("the owner of the repository exceeds the %s storage quota of %s", errQuota.Category, base.FileSize(errQuota.Limit)),
+			})
+			return
+		}
+		log.Error("Unable to check the storage quota of %-v Error: %v", repo, err)
+		ctx.JSON(http.StatusInternalServerError, private.Response{
+			Err: err.Error(),
+		})
+	}
+}
+
 func preReceiveBranch(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
 	branchName := refFullName.BranchName()
 	ctx.branchName = branchName
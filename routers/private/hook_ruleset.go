@@ -0,0 +1,277 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/private"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// This file contains the enforcement of the organization and repository rulesets for refs passed across in hooks
+
+func preReceiveRulesets(ctx *preReceiveContext, oldCommitID, newCommitID string, refFullName git.RefName) {
+	var target git_model.RulesetTarget
+	var name string
+	switch {
+	case refFullName.IsBranch():
+		target, name = git_model.RulesetTargetBranch, refFullName.BranchName()
+		// the merge queue force-pushes the batches to its temporary branches, they are not subject to the rulesets
+		if ctx.opts.PushTrigger == repo_module.PushTriggerMergeQueue && strings.HasPrefix(name, pull_model.MergeQueueBranchPrefix) {
+			return
+		}
+	case refFullName.IsTag():
+		target, name = git_model.RulesetTargetTag, refFullName.TagName()
+	default:
+		return
+	}
+
+	repo := ctx.Repo.Repository
+	if !ctx.gotRulesets {
+		var err error
+		ctx.rulesets, err = git_model.GetEnabledRulesetsForRepo(ctx, repo)
+		if err != nil {
+			log.Error("Unable to get rulesets for %-v Error: %v", repo, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: err.Error(),
+			})
+			return
+		}
+		ctx.gotRulesets = true
+	}
+
+	for _, rs := range ctx.rulesets {
+		if !rs.MatchRef(target, name, repo.DefaultBranch) {
+			continue
+		}
+
+		bypass, ok := ctx.canBypassRuleset(rs)
+		if !ok {
+			return
+		} else if bypass {
+			log.Trace("User %d bypasses ruleset %q for %s in %-v", ctx.opts.UserID, rs.Name, refFullName, repo)
+			continue
+		}
+
+		violation, err := checkRuleset(ctx, rs, oldCommitID, newCommitID, refFullName)
+		if err != nil {
+			log.Error("Unable to check ruleset %q for %s in %-v: %v", rs.Name, refFullName, repo, err)
+			ctx.JSON(http.StatusInternalServerError, private.Response{
+				Err: fmt.Sprintf("Unable to check ruleset %q: %v", rs.Name, err),
+			})
+			return
+		}
+		if violation == "" {
+			continue
+		}
+
+		if rs.Enforcement == git_model.RulesetEnforcementEvaluate {
+			log.Info("Ruleset %q in evaluate mode would reject the update of %s in %-v by user %d: %s", rs.Name, refFullName, repo, ctx.opts.UserID, violation)
+			continue
+		}
+		log.Warn("Forbidden: Ruleset %q rejects the update of %s in %-v by user %d: %s", rs.Name, refFullName, repo, ctx.opts.UserID, violation)
+		ctx.JSON(http.StatusForbidden, private.Response{
+			UserMsg: fmt.Sprintf("%s %s is protected by ruleset %q: %s", target, name, rs.Name, violation),
+		})
+		return
+	}
+}
+
+// canBypassRuleset returns whether the pusher is a bypass actor of the ruleset, ok is false if an error response has been written
+func (ctx *preReceiveContext) canBypassRuleset(rs *git_model.Ruleset) (bypass, ok bool) {
+	if ctx.opts.DeployKeyID != 0 {
+		return rs.BypassDeployKeys, true
+	}
+	if ctx.opts.UserID == user_model.ActionsUserID {
+		return rs.BypassActions, true
+	}
+	if rs.BypassRepoAdmins {
+		if !ctx.loadPusherAndPermission() {
+			return false, false
+		}
+		if ctx.userPerm.IsAdmin() {
+			return true, true
+		}
+	}
+	return rs.CanUserBypass(ctx, ctx.opts.UserID), true
+}
+
+// checkRuleset returns a description of the first rule of the ruleset violated by the ref update, or an empty string
+func checkRuleset(ctx *preReceiveContext, rs *git_model.Ruleset, oldCommitID, newCommitID string, refFullName git.RefName) (string, error) {
+	repo := ctx.Repo.Repository
+	emptyObjectID := ctx.Repo.GetObjectFormat().EmptyObjectID().String()
+	isCreation := oldCommitID == emptyObjectID
+
+	if newCommitID == emptyObjectID {
+		if rs.BlockDeletion {
+			return "deletion is not allowed", nil
+		}
+		return "", nil
+	}
+	if isCreation && rs.BlockCreation {
+		return "creation is not allowed", nil
+	}
+
+	if !isCreation && rs.BlockForcePush {
+		output, err := gitrepo.RunCmdString(ctx, repo, gitcmd.NewCommand("rev-list", "--max-count=1").
+			AddDynamicArguments(oldCommitID, "^"+newCommitID).
+			WithEnv(ctx.env))
+		if err != nil {
+			return "", err
+		} else if len(output) > 0 {
+			return "force push is not allowed", nil
+		}
+	}
+
+	if rs.RequireLinearHistory {
+		output, err := gitrepo.RunCmdString(ctx, repo, addPushedCommits(gitcmd.NewCommand("rev-list", "--max-count=1", "--min-parents=2"), oldCommitID, newCommitID, isCreation).
+			WithEnv(ctx.env))
+		if err != nil {
+			return "", err
+		} else if sha := strings.TrimSpace(output); sha != "" {
+			return fmt.Sprintf("merge commit %s is not allowed, the history must be linear", sha), nil
+		}
+	}
+
+	if rs.RequireSignedCommits {
+		if err := verifyCommits(oldCommitID, newCommitID, ctx.Repo.GitRepo, ctx.env); err != nil {
+			if !isErrUnverifiedCommit(err) {
+				return "", err
+			}
+			return fmt.Sprintf("commit %s is not signed with a verified signature", err.(*errUnverifiedCommit).sha), nil
+		}
+	}
+
+	messageRegexp, err := rs.GetCommitMessageRegexp()
+	if err != nil {
+		return "", fmt.Errorf("invalid commit message pattern: %w", err)
+	} else if messageRegexp != nil {
+		output, err := gitrepo.RunCmdString(ctx, repo, addPushedCommits(gitcmd.NewCommand("log", "-z", "--format=%H%n%B"), oldCommitID, newCommitID, isCreation).
+			WithEnv(ctx.env))
+		if err != nil {
+			return "", err
+		}
+		for entry := range strings.SplitSeq(output, "\x00") {
+			sha, message, _ := strings.Cut(entry, "\n")
+			if sha != "" && !messageRegexp.MatchString(message) {
+				return fmt.Sprintf("the message of commit %s does not match the pattern %s", sha, rs.CommitMessagePattern), nil
+			}
+		}
+	}
+
+	if globs := rs.GetRestrictedFilePatterns(); len(globs) > 0 && refFullName.IsBranch() {
+		if _, err := pull_service.CheckFileProtection(ctx.Repo.GitRepo, refFullName.BranchName(), oldCommitID, newCommitID, globs, 1, ctx.env); err != nil {
+			if !pull_service.IsErrFilePathProtected(err) {
+				return "", err
+			}
+			return fmt.Sprintf("changing file %s is not allowed", err.(pull_service.ErrFilePathProtected).Path), nil
+		}
+	}
+
+	if rs.MaxFileSize > 0 {
+		path, err := findFileLargerThan(ctx, oldCommitID, newCommitID, isCreation, rs.MaxFileSize)
+		if err != nil {
+			return "", err
+		} else if path != "" {
+			return fmt.Sprintf("file %s is larger than %s", path, base.FileSize(rs.MaxFileSize)), nil
+		}
+	}
+
+	if len(rs.RequiredDeployments) > 0 && refFullName.IsBranch() {
+		commitID, err := getDeploymentCommitID(ctx, newCommitID)
+		if err != nil {
+			return "", err
+		}
+		statuses, err := git_model.GetLatestCommitStatus(ctx, repo.ID, commitID, db.ListOptionsAll)
+		if err != nil {
+			return "", err
+		}
+		for _, environment := range rs.RequiredDeployments {
+			// the commit is deployed by an Actions job to the environment, or by an external system reporting a commit status
+			deployed, err := actions_model.HasSuccessfulDeployment(ctx, repo.ID, environment, commitID)
+			if err != nil {
+				return "", err
+			}
+			if !deployed && !slices.ContainsFunc(statuses, func(status *git_model.CommitStatus) bool {
+				return status.Context == environment && status.State.IsSuccess()
+			}) {
+				return fmt.Sprintf("commit %s must be deployed successfully to %s first", commitID, environment), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// getDeploymentCommitID returns the commit which has to be deployed before the ref update. A pull request merged
+// through Gitea creates its merge commit at merge time, so the head commit of the pull request has to be deployed instead.
+func getDeploymentCommitID(ctx *preReceiveContext, newCommitID string) (string, error) {
+	if ctx.opts.PullRequestID == 0 {
+		return newCommitID, nil
+	}
+	pr, err := issues_model.GetPullRequestByID(ctx, ctx.opts.PullRequestID)
+	if err != nil {
+		return "", err
+	}
+	return ctx.Repo.GitRepo.GetRefCommitID(pr.GetGitHeadRefName())
+}
+
+// addPushedCommits adds the revision range of the commits received by the ref update to the command
+func addPushedCommits(cmd *gitcmd.Command, oldCommitID, newCommitID string, isCreation bool) *gitcmd.Command {
+	if isCreation {
+		// only list the commits received, not those already reachable from the existing refs
+		return cmd.AddDynamicArguments(newCommitID).AddArguments("--not", "--all")
+	}
+	return cmd.AddDynamicArguments(oldCommitID + ".." + newCommitID)
+}
+
+// findFileLargerThan returns the path of a file added by the ref update whose size exceeds maxSize, if any
+func findFileLargerThan(ctx *preReceiveContext, oldCommitID, newCommitID string, isCreation bool, maxSize int64) (string, error) {
+	objects, err := gitrepo.RunCmdString(ctx, ctx.Repo.Repository, addPushedCommits(gitcmd.NewCommand("rev-list", "--objects"), oldCommitID, newCommitID, isCreation).
+		WithEnv(ctx.env))
+	if err != nil {
+		return "", err
+	}
+	if objects == "" {
+		return "", nil
+	}
+
+	output, err := gitrepo.RunCmdString(ctx, ctx.Repo.Repository, gitcmd.NewCommand("cat-file", "--batch-check=%(objecttype) %(objectsize) %(rest)").
+		WithEnv(ctx.env).
+		WithStdin(strings.NewReader(objects)))
+	if err != nil {
+		return "", err
+	}
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 || fields[0] != "blob" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("unexpected cat-file output %q: %w", line, err)
+		}
+		if size > maxSize {
+			return fields[2], nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package private
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	git_model "code.gitea.io/gitea/models/git"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/private"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRulesetRequiredDeployments(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	privateCtx, _ := contexttest.MockPrivateContext(t, "/")
+	privateCtx.Repo = &context.Repository{Repository: repo, GitRepo: gitRepo}
+	ctx := &preReceiveContext{PrivateContext: privateCtx, opts: &private.HookOptions{}}
+
+	const (
+		oldCommitID = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+		// the merge commit is not known before the merge, any commit without a deployment works here
+		mergeCommitID = "90c1019714259b24fb81711d4416ac0f18667dfa"
+		// head commit of the pull request with ID 2
+		headCommitID = "985f0301dba5e7b34be866819cd15ad3d8f508ee"
+	)
+	rs := &git_model.Ruleset{RequiredDeployments: []string{"production"}}
+	refName := git.RefNameFromBranch("master")
+
+	violation, err := checkRuleset(ctx, rs, oldCommitID, headCommitID, refName)
+	require.NoError(t, err)
+	assert.Equal(t, "commit "+headCommitID+" must be deployed successfully to production first", violation)
+
+	require.NoError(t, git_model.NewCommitStatus(t.Context(), git_model.NewCommitStatusOptions{
+		Repo:    repo,
+		Creator: unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2}),
+		SHA:     git.MustIDFromString(headCommitID),
+		CommitStatus: &git_model.CommitStatus{
+			State:   commitstatus.CommitStatusSuccess,
+			Context: "production",
+		},
+	}))
+
+	// a direct push of the deployed commit is allowed
+	violation, err = checkRuleset(ctx, rs, oldCommitID, headCommitID, refName)
+	require.NoError(t, err)
+	assert.Empty(t, violation)
+
+	// a direct push of another commit is rejected
+	violation, err = checkRuleset(ctx, rs, oldCommitID, mergeCommitID, refName)
+	require.NoError(t, err)
+	assert.Equal(t, "commit "+mergeCommitID+" must be deployed successfully to production first", violation)
+
+	// the merge commit of a pull request is allowed if the head of the pull request has been deployed
+	ctx.opts.PullRequestID = 2
+	violation, err = checkRuleset(ctx, rs, oldCommitID, mergeCommitID, refName)
+	require.NoError(t, err)
+	assert.Empty(t, violation)
+}
+
+func TestPreReceiveRulesetsMergeQueueBranch(t *testing.T) {
+	unittest.PrepareTestEnv(t)
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	gitRepo, err := gitrepo.OpenRepository(t.Context(), repo)
+	require.NoError(t, err)
+	defer gitRepo.Close()
+
+	emptyCommitID := git.ObjectFormatFromName(repo.ObjectFormatName).EmptyObjectID().String()
+	const commitID = "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	refName := git.RefNameFromBranch(pull_model.MergeQueueBranchName("master"))
+
+	preReceive := func(pushTrigger repo_module.PushTrigger) *httptest.ResponseRecorder {
+		privateCtx, resp := contexttest.MockPrivateContext(t, "/")
+		privateCtx.Repo = &context.Repository{Repository: repo, GitRepo: gitRepo}
+		ctx := &preReceiveContext{
+			PrivateContext: privateCtx,
+			opts:           &private.HookOptions{UserID: 2, PushTrigger: pushTrigger},
+			gotRulesets:    true,
+			rulesets: []*git_model.Ruleset{{
+				Name:          "all branches",
+				Target:        git_model.RulesetTargetBranch,
+				IncludeRefs:   git_model.RulesetPatternAll,
+				BlockCreation: true,
+			}},
+		}
+		preReceiveRulesets(ctx, emptyCommitID, commitID, refName)
+		return resp
+	}
+
+	// users can't push to the branches of the merge queue
+	assert.Equal(t, http.StatusForbidden, preReceive("").Code)
+	assert.Equal(t, http.StatusForbidden, preReceive(repo_module.PushTriggerPRMergeToBase).Code)
+
+	// the merge queue itself is not subject to the rulesets
+	resp := preReceive(repo_module.PushTriggerMergeQueue)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Body.String())
+}
@@ -115,7 +115,7 @@ func AddLogger(ctx *context.PrivateContext) {
 	var ok bool
 	if flags, ok = opts.Config["flags"].(string); !ok {
 		switch opts.Logger {
-		case "access":
+		case "access", "audit":
 			flags = ""
 		case "router":
 			flags = "date,time"
@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/templates"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplAuditEvents templates.TplName = "admin/audit"
+
+// AuditEvents shows the audit log of the instance
+func AuditEvents(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("audit.title")
+	ctx.Data["PageIsAdminAuditEvents"] = true
+
+	shared_user.AuditEvents(ctx, 0)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplAuditEvents)
+}
@@ -24,6 +24,7 @@ import (
 	"code.gitea.io/gitea/services/auth/source/ldap"
 	"code.gitea.io/gitea/services/auth/source/oauth2"
 	pam_service "code.gitea.io/gitea/services/auth/source/pam"
+	"code.gitea.io/gitea/services/auth/source/saml"
 	"code.gitea.io/gitea/services/auth/source/smtp"
 	"code.gitea.io/gitea/services/auth/source/sspi"
 	"code.gitea.io/gitea/services/context"
@@ -69,6 +70,7 @@ var (
 			{auth.SMTP.String(), auth.SMTP},
 			{auth.OAuth2.String(), auth.OAuth2},
 			{auth.SSPI.String(), auth.SSPI},
+			{auth.SAML.String(), auth.SAML},
 		}
 		if pam.Supported {
 			items = append(items, dropdownItem{auth.Names[auth.PAM], auth.PAM})
@@ -231,6 +233,38 @@ func parseSSPIConfig(ctx *context.Context, form forms.AuthenticationForm) (*sspi
 	}, nil
 }
 
+func parseSAMLConfig(form forms.AuthenticationForm) *saml.Source {
+	return &saml.Source{
+		IdentityProviderEntityID:    strings.TrimSpace(form.SAMLIdentityProviderEntityID),
+		IdentityProviderSSOURL:      strings.TrimSpace(form.SAMLIdentityProviderSSOURL),
+		IdentityProviderCertificate: form.SAMLIdentityProviderCertificate,
+		ServiceProviderCertificate:  strings.TrimSpace(form.SAMLServiceProviderCertificate),
+		ServiceProviderPrivateKey:   strings.TrimSpace(form.SAMLServiceProviderPrivateKey),
+		SignRequests:                form.SAMLSignRequests,
+		NameIDFormat:                form.SAMLNameIDFormat,
+		AllowIdPInitiated:           form.SAMLAllowIdPInitiated,
+		IconURL:                     form.SAMLIconURL,
+		UsernameAttribute:           form.SAMLUsernameAttribute,
+		EmailAttribute:              form.SAMLEmailAttribute,
+		FullNameAttribute:           form.SAMLFullNameAttribute,
+		GroupAttribute:              form.SAMLGroupAttribute,
+		AdminGroup:                  form.SAMLAdminGroup,
+		RestrictedGroup:             form.SAMLRestrictedGroup,
+		GroupTeamMap:                form.SAMLGroupTeamMap,
+		GroupTeamMapRemoval:         form.SAMLGroupTeamMapRemoval,
+	}
+}
+
+// validateSAMLConfig generates the key for signing requests if none is given and validates the config
+func validateSAMLConfig(config *saml.Source) error {
+	if config.SignRequests {
+		if err := config.GenerateServiceProviderKey(setting.Domain); err != nil {
+			return err
+		}
+	}
+	return config.Validate()
+}
+
 // NewAuthSourcePost response for adding an auth source
 func NewAuthSourcePost(ctx *context.Context) {
 	form := *web.GetForm(ctx).(*forms.AuthenticationForm)
@@ -289,6 +323,13 @@ func NewAuthSourcePost(ctx *context.Context) {
 			ctx.RenderWithErr(ctx.Tr("admin.auths.login_source_of_type_exist"), tplAuthNew, form)
 			return
 		}
+	case auth.SAML:
+		samlConfig := parseSAMLConfig(form)
+		if err := validateSAMLConfig(samlConfig); err != nil {
+			ctx.RenderWithErr(ctx.Tr("admin.auths.saml_invalid_config", err.Error()), tplAuthNew, form)
+			return
+		}
+		config = samlConfig
 	default:
 		ctx.HTTPError(http.StatusBadRequest)
 		return
@@ -345,6 +386,14 @@ func EditAuthSource(ctx *context.Context) {
 	ctx.Data["Source"] = source
 	ctx.Data["HasTLS"] = source.HasTLS()
 
+	scimToken, err := auth.GetSCIMToken(ctx, source.ID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("auth.GetSCIMToken", err)
+		return
+	}
+	ctx.Data["SCIMToken"] = scimToken
+	ctx.Data["SCIMEndpoint"] = setting.AppURL + "scim/v2"
+
 	if source.IsOAuth2() {
 		type Named interface {
 			Name() string
@@ -412,6 +461,18 @@ func EditAuthSourcePost(ctx *context.Context) {
 			ctx.RenderWithErr(err.Error(), tplAuthEdit, form)
 			return
 		}
+	case auth.SAML:
+		samlConfig := parseSAMLConfig(form)
+		// the private key is never shown, so it is kept unless a new one is given
+		if existing, ok := source.Cfg.(*saml.Source); ok && samlConfig.ServiceProviderPrivateKey == "" {
+			samlConfig.ServiceProviderCertificate = existing.ServiceProviderCertificate
+			samlConfig.ServiceProviderPrivateKey = existing.ServiceProviderPrivateKey
+		}
+		if err := validateSAMLConfig(samlConfig); err != nil {
+			ctx.RenderWithErr(ctx.Tr("admin.auths.saml_invalid_config", err.Error()), tplAuthEdit, form)
+			return
+		}
+		config = samlConfig
 	default:
 		ctx.HTTPError(http.StatusBadRequest)
 		return
@@ -463,3 +524,62 @@ func DeleteAuthSource(ctx *context.Context) {
 	ctx.Flash.Success(ctx.Tr("admin.auths.deletion_success"))
 	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/auths")
 }
+
+// GenerateSCIMToken generates a new SCIM token of an auth source, the previous token stops working
+func GenerateSCIMToken(ctx *context.Context) {
+	source, err := auth.GetSourceByID(ctx, ctx.PathParamInt64("authid"))
+	if err != nil {
+		ctx.ServerError("auth.GetSourceByID", err)
+		return
+	}
+
+	token, err := auth.GenerateSCIMToken(ctx, source.ID)
+	if err != nil {
+		ctx.ServerError("auth.GenerateSCIMToken", err)
+		return
+	}
+	log.Trace("SCIM token of authentication source %d generated by admin(%s)", source.ID, ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.auths.scim_token_generated"))
+	ctx.Flash.Info(token)
+	ctx.Redirect(setting.AppSubURL + "/-/admin/auths/" + strconv.FormatInt(source.ID, 10))
+}
+
+// DeleteSCIMToken deletes the SCIM token of an auth source, which disables provisioning through it
+func DeleteSCIMToken(ctx *context.Context) {
+	source, err := auth.GetSourceByID(ctx, ctx.PathParamInt64("authid"))
+	if err != nil {
+		ctx.ServerError("auth.GetSourceByID", err)
+		return
+	}
+
+	if err := auth.DeleteSCIMToken(ctx, source.ID); err != nil {
+		ctx.ServerError("auth.DeleteSCIMToken", err)
+		return
+	}
+	log.Trace("SCIM token of authentication source %d deleted by admin(%s)", source.ID, ctx.Doer.Name)
+
+	ctx.Flash.Success(ctx.Tr("admin.auths.scim_token_deleted"))
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/auths/" + strconv.FormatInt(source.ID, 10))
+}
+
+// UpdateSCIMTeamMap changes the teams which can be provisioned through the SCIM token of an auth source
+func UpdateSCIMTeamMap(ctx *context.Context) {
+	source, err := auth.GetSourceByID(ctx, ctx.PathParamInt64("authid"))
+	if err != nil {
+		ctx.ServerError("auth.GetSourceByID", err)
+		return
+	}
+
+	if err := auth.UpdateSCIMTeamMap(ctx, source.ID, strings.TrimSpace(ctx.FormString("scim_team_map"))); err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) {
+			ctx.ServerError("auth.UpdateSCIMTeamMap", err)
+			return
+		}
+		ctx.Flash.Error(ctx.Tr("admin.auths.scim_team_map_invalid", err.Error()))
+	} else {
+		log.Trace("SCIM teams of authentication source %d updated by admin(%s)", source.ID, ctx.Doer.Name)
+		ctx.Flash.Success(ctx.Tr("admin.auths.scim_team_map_updated"))
+	}
+	ctx.Redirect(setting.AppSubURL + "/-/admin/auths/" + strconv.FormatInt(source.ID, 10))
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	quota_model "code.gitea.io/gitea/models/quota"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	tplQuotaGroups    templates.TplName = "admin/quota/list"
+	tplQuotaGroupEdit templates.TplName = "admin/quota/edit"
+)
+
+// QuotaGroups shows all quota groups
+func QuotaGroups(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("quota.groups")
+	ctx.Data["PageIsAdminQuota"] = true
+
+	groups, err := quota_model.GetGroups(ctx)
+	if err != nil {
+		ctx.ServerError("GetGroups", err)
+		return
+	}
+	counts, err := quota_model.CountGroupUsers(ctx)
+	if err != nil {
+		ctx.ServerError("CountGroupUsers", err)
+		return
+	}
+
+	ctx.Data["Groups"] = groups
+	ctx.Data["GroupUserCounts"] = counts
+	ctx.Data["DefaultGroup"] = setting.Quota.DefaultGroup
+	ctx.Data["QuotaCategories"] = quota_model.Categories
+
+	ctx.HTML(http.StatusOK, tplQuotaGroups)
+}
+
+// NewQuotaGroupPost creates a quota group
+func NewQuotaGroupPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.AdminQuotaGroupForm)
+	redirect := setting.AppSubURL + "/-/admin/quota"
+
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		ctx.Flash.Error(ctx.Tr("quota.group.name_empty"))
+		ctx.Redirect(redirect)
+		return
+	}
+
+	g := &quota_model.Group{Name: name}
+	if !applyQuotaGroupForm(ctx, g, form) {
+		ctx.Redirect(redirect)
+		return
+	}
+
+	if err := quota_model.CreateGroup(ctx, g); err != nil {
+		if errors.Is(err, util.ErrAlreadyExist) {
+			ctx.Flash.Error(ctx.Tr("quota.group.name_exists", name))
+			ctx.Redirect(redirect)
+			return
+		}
+		ctx.ServerError("CreateGroup", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("quota.group.create_success", name))
+	ctx.Redirect(redirect + "/" + strconv.FormatInt(g.ID, 10))
+}
+
+func getQuotaGroup(ctx *context.Context) *quota_model.Group {
+	g, err := quota_model.GetGroupByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetGroupByID", err)
+		}
+		return nil
+	}
+	return g
+}
+
+// EditQuotaGroup shows the limits and the members of a quota group
+func EditQuotaGroup(ctx *context.Context) {
+	g := getQuotaGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Data["Title"] = ctx.Tr("quota.groups")
+	ctx.Data["PageIsAdminQuota"] = true
+	ctx.Data["Group"] = g
+	ctx.Data["QuotaCategories"] = quota_model.Categories
+
+	userIDs, err := quota_model.GetGroupUserIDs(ctx, g.ID)
+	if err != nil {
+		ctx.ServerError("GetGroupUserIDs", err)
+		return
+	}
+	users, err := user_model.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		ctx.ServerError("GetUsersByIDs", err)
+		return
+	}
+	usages := make(map[int64]*quota_model.Usage, len(users))
+	for _, u := range users {
+		if usages[u.ID], err = quota_model.GetUsage(ctx, u.ID); err != nil {
+			ctx.ServerError("GetUsage", err)
+			return
+		}
+	}
+	ctx.Data["Users"] = users
+	ctx.Data["Usages"] = usages
+
+	ctx.HTML(http.StatusOK, tplQuotaGroupEdit)
+}
+
+// EditQuotaGroupPost updates the limits of a quota group
+func EditQuotaGroupPost(ctx *context.Context) {
+	g := getQuotaGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.AdminQuotaGroupForm)
+	redirect := setting.AppSubURL + "/-/admin/quota/" + strconv.FormatInt(g.ID, 10)
+
+	if !applyQuotaGroupForm(ctx, g, form) {
+		ctx.Redirect(redirect)
+		return
+	}
+	if err := quota_model.UpdateGroup(ctx, g); err != nil {
+		ctx.ServerError("UpdateGroup", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("quota.group.update_success", g.Name))
+	ctx.Redirect(redirect)
+}
+
+// DeleteQuotaGroup deletes a quota group
+func DeleteQuotaGroup(ctx *context.Context) {
+	g := getQuotaGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := quota_model.DeleteGroup(ctx, g.ID); err != nil {
+		ctx.ServerError("DeleteGroup", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("quota.group.delete_success", g.Name))
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/quota")
+}
+
+// AddQuotaGroupUser assigns a user or an organization to a quota group
+func AddQuotaGroupUser(ctx *context.Context) {
+	g := getQuotaGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	redirect := setting.AppSubURL + "/-/admin/quota/" + strconv.FormatInt(g.ID, 10)
+
+	u, err := user_model.GetUserByName(ctx, strings.TrimSpace(ctx.FormString("name")))
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.Flash.Error(ctx.Tr("form.user_not_exist"))
+			ctx.Redirect(redirect)
+			return
+		}
+		ctx.ServerError("GetUserByName", err)
+		return
+	}
+
+	if err := quota_model.AddGroupUser(ctx, g.ID, u.ID); err != nil {
+		ctx.ServerError("AddGroupUser", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("quota.group.add_user_success", u.Name))
+	ctx.Redirect(redirect)
+}
+
+// RemoveQuotaGroupUser removes a user or an organization from a quota group
+func RemoveQuotaGroupUser(ctx *context.Context) {
+	g := getQuotaGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := quota_model.RemoveGroupUser(ctx, g.ID, ctx.FormInt64("id")); err != nil {
+		ctx.ServerError("RemoveGroupUser", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("quota.group.remove_user_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/-/admin/quota/" + strconv.FormatInt(g.ID, 10))
+}
+
+// applyQuotaGroupForm parses the limits of the form into the group, an invalid limit is reported as flash error
+func applyQuotaGroupForm(ctx *context.Context, g *quota_model.Group, form *forms.AdminQuotaGroupForm) bool {
+	for _, field := range []struct {
+		value string
+		limit *int64
+	}{
+		{form.LimitTotal, &g.LimitTotal},
+		{form.LimitGit, &g.LimitGit},
+		{form.LimitLFS, &g.LimitLFS},
+		{form.LimitPackages, &g.LimitPackages},
+		{form.LimitArtifacts, &g.LimitArtifacts},
+		{form.LimitAttachments, &g.LimitAttachments},
+	} {
+		limit, err := parseQuotaLimit(field.value)
+		if err != nil {
+			ctx.Flash.Error(ctx.Tr("quota.group.invalid_limit", field.value))
+			return false
+		}
+		*field.limit = limit
+	}
+	return true
+}
+
+// parseQuotaLimit parses a human readable size, an empty value or -1 means unlimited
+func parseQuotaLimit(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "-1" {
+		return -1, nil
+	}
+	size, err := humanize.ParseBytes(value)
+	if err != nil {
+		return 0, err
+	}
+	if size > math.MaxInt64 {
+		return 0, util.NewInvalidArgumentErrorf("size %s is too large", value)
+	}
+	return int64(size), nil
+}
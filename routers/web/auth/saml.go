@@ -0,0 +1,271 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"time"
+
+	"code.gitea.io/gitea/models/auth"
+	user_model "code.gitea.io/gitea/models/user"
+	auth_module "code.gitea.io/gitea/modules/auth"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	source_service "code.gitea.io/gitea/services/auth/source"
+	"code.gitea.io/gitea/services/auth/source/saml"
+	"code.gitea.io/gitea/services/context"
+	user_service "code.gitea.io/gitea/services/user"
+)
+
+// samlRequestCookieName is the cookie which binds a pending authentication request to the browser which sent it.
+// Unlike the session cookie, it is sent with the cross-site POST requests of the identity provider.
+const samlRequestCookieName = "saml_request"
+
+func setSAMLRequestCookie(ctx *context.Context, requestID string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     samlRequestCookieName,
+		Value:    requestID,
+		MaxAge:   maxAge,
+		Path:     setting.AppSubURL + "/user/saml/",
+		Domain:   setting.SessionConfig.Domain,
+		Secure:   setting.SessionConfig.Secure,
+		HttpOnly: true,
+	}
+	// browsers only send cookies with cross-site POST requests if they are secure
+	if cookie.Secure {
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(ctx.Resp, cookie)
+}
+
+// consumeSAMLRequest returns where to redirect the user to after signing in with the response to a request.
+// The request must have been sent by the same browser, otherwise a victim could be signed in to the account of an attacker.
+func consumeSAMLRequest(ctx *context.Context, authSourceID int64, inResponseTo string) (string, error) {
+	var requestID string
+	if cookie, err := ctx.Req.Cookie(samlRequestCookieName); err == nil {
+		requestID = cookie.Value
+	}
+	setSAMLRequestCookie(ctx, "", -1)
+	if requestID == "" || subtle.ConstantTimeCompare([]byte(requestID), []byte(inResponseTo)) != 1 {
+		return "", errors.New("the response does not answer a request of this browser")
+	}
+	redirectTo, ok := saml.ConsumeRequest(authSourceID, inResponseTo)
+	if !ok {
+		return "", errors.New("the response does not answer a pending request")
+	}
+	return redirectTo, nil
+}
+
+func getActiveSAMLSource(ctx *context.Context) (*auth.Source, *saml.Source) {
+	authSource, err := saml.GetActiveSource(ctx, ctx.PathParam("provider"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetActiveSource", err)
+		}
+		return nil, nil
+	}
+	return authSource, authSource.Cfg.(*saml.Source)
+}
+
+// SAMLMetadata serves the metadata of Gitea as service provider of a SAML source
+func SAMLMetadata(ctx *context.Context) {
+	_, source := getActiveSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+	metadata, err := source.Metadata()
+	if err != nil {
+		ctx.ServerError("Metadata", err)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = ctx.Resp.Write(metadata)
+}
+
+// SignInSAML redirects the user to the identity provider of a SAML source
+func SignInSAML(ctx *context.Context) {
+	authSource, source := getActiveSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	redirectURL, requestID, err := source.AuthnRequestURL("")
+	if err != nil {
+		ctx.ServerError("AuthnRequestURL", err)
+		return
+	}
+	if err := saml.RememberRequest(authSource.ID, requestID, ctx.FormString("redirect_to")); err != nil {
+		ctx.ServerError("RememberRequest", err)
+		return
+	}
+	setSAMLRequestCookie(ctx, requestID, int(saml.RequestTimeout.Seconds()))
+	ctx.Redirect(redirectURL)
+}
+
+// SignInSAMLCallback handles the responses the identity provider posts to the assertion consumer service
+func SignInSAMLCallback(ctx *context.Context) {
+	authSource, source := getActiveSAMLSource(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	signInError := func(err error) {
+		log.Info("Failed SAML sign in through %s from %s: %v", authSource.Name, ctx.RemoteAddr(), err)
+		ctx.Flash.Error(ctx.Tr("auth.saml.signin.error", err.Error()))
+		ctx.Redirect(setting.AppSubURL + "/user/login")
+	}
+
+	now := time.Now()
+	assertion, err := source.ParseResponse(ctx.Req.PostFormValue("SAMLResponse"), now)
+	if err != nil {
+		signInError(err)
+		return
+	}
+
+	var redirectTo string
+	if assertion.InResponseTo != "" {
+		if redirectTo, err = consumeSAMLRequest(ctx, authSource.ID, assertion.InResponseTo); err != nil {
+			signInError(err)
+			return
+		}
+	} else if source.AllowIdPInitiated {
+		redirectTo = ctx.Req.PostFormValue("RelayState")
+	} else {
+		signInError(errors.New("sign in initiated by the identity provider is not allowed"))
+		return
+	}
+	if !saml.MarkAssertionUsed(authSource.ID, assertion, now) {
+		signInError(errors.New("the assertion has already been used"))
+		return
+	}
+
+	u, err := samlGetOrCreateUser(ctx, authSource, source, assertion)
+	if err != nil {
+		if user_model.IsErrUserAlreadyExist(err) || user_model.IsErrEmailAlreadyUsed(err) || errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrNotExist) {
+			signInError(err)
+			return
+		}
+		ctx.ServerError("samlGetOrCreateUser", err)
+		return
+	}
+	if !u.IsActive || u.ProhibitLogin {
+		log.Info("Failed SAML sign in of %s from %s: user is not allowed to sign in", u.Name, ctx.RemoteAddr())
+		ctx.Data["Title"] = ctx.Tr("auth.prohibit_login")
+		ctx.HTML(http.StatusOK, "user/auth/prohibit_login")
+		return
+	}
+
+	if !authSource.TwoFactorShouldSkip() {
+		hasTwoFactor, err := auth.HasTwoFactorOrWebAuthn(ctx, u.ID)
+		if err != nil {
+			ctx.ServerError("HasTwoFactorOrWebAuthn", err)
+			return
+		}
+		if hasTwoFactor {
+			if err := updateSession(ctx, nil, map[string]any{
+				"twofaUid":      u.ID,
+				"twofaRemember": false,
+			}); err != nil {
+				ctx.ServerError("updateSession", err)
+				return
+			}
+			regs, err := auth.GetWebAuthnCredentialsByUID(ctx, u.ID)
+			if err == nil && len(regs) > 0 {
+				ctx.Redirect(setting.AppSubURL + "/user/webauthn")
+				return
+			}
+			ctx.Redirect(setting.AppSubURL + "/user/two_factor")
+			return
+		}
+	}
+
+	redirect := handleSignInFull(ctx, u, false, false)
+	if ctx.Written() {
+		return
+	}
+	if redirectTo != "" && httplib.IsCurrentGiteaSiteURL(ctx, redirectTo) {
+		ctx.RedirectToCurrentSite(redirectTo)
+		return
+	}
+	ctx.Redirect(redirect)
+}
+
+// samlGetOrCreateUser returns the user of an assertion, which is identified by its NameID.
+// New users are created like by LDAP sources, the attributes and groups of existing users are synchronized.
+func samlGetOrCreateUser(ctx *context.Context, authSource *auth.Source, source *saml.Source, assertion *saml.Assertion) (*user_model.User, error) {
+	groups := source.Groups(assertion)
+	opts := &user_service.UpdateOptions{}
+	if source.AdminGroup != "" {
+		opts.IsAdmin = user_service.UpdateOptionFieldFromSync(groups.Contains(source.AdminGroup))
+	}
+	if source.RestrictedGroup != "" {
+		opts.IsRestricted = optional.Some(groups.Contains(source.RestrictedGroup))
+	}
+
+	u := &user_model.User{
+		LoginType:   auth.SAML,
+		LoginSource: authSource.ID,
+		LoginName:   assertion.NameID,
+	}
+	has, err := user_model.GetUser(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	if has {
+		if fullName := source.FullName(assertion); fullName != "" && fullName != u.FullName {
+			opts.FullName = optional.Some(fullName)
+		}
+		if err := user_service.UpdateUser(ctx, u, opts); err != nil {
+			return nil, err
+		}
+	} else {
+		if setting.Service.AllowOnlyInternalRegistration {
+			return nil, util.NewNotExistErrorf("there is no account for %s", assertion.NameID)
+		}
+		name, err := source.UserName(assertion)
+		if err != nil {
+			return nil, err
+		}
+		email := source.Email(assertion)
+		if name == "" || email == "" {
+			return nil, util.NewInvalidArgumentErrorf("the identity provider did not provide the username and the email address")
+		}
+
+		u = &user_model.User{
+			Name:        name,
+			FullName:    source.FullName(assertion),
+			Email:       email,
+			LoginType:   auth.SAML,
+			LoginSource: authSource.ID,
+			LoginName:   assertion.NameID,
+			IsAdmin:     opts.IsAdmin.ValueOrDefault(user_service.UpdateOptionField[bool]{}).FieldValue,
+		}
+		overwriteDefault := &user_model.CreateUserOverwriteOptions{
+			IsRestricted: optional.Some(opts.IsRestricted.ValueOrDefault(setting.Service.DefaultUserIsRestricted)),
+			IsActive:     optional.Some(true),
+		}
+		if err := user_service.CreateUser(ctx, u, u, &user_model.Meta{}, overwriteDefault); err != nil {
+			return nil, err
+		}
+	}
+
+	if source.GroupTeamMap != "" || source.GroupTeamMapRemoval {
+		groupTeamMapping, err := auth_module.UnmarshalGroupTeamMapping(source.GroupTeamMap)
+		if err != nil {
+			return nil, err
+		}
+		if err := source_service.SyncGroupsToTeams(ctx, u, groups, groupTeamMapping, source.GroupTeamMapRemoval); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
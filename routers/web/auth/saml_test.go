@@ -0,0 +1,51 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/services/auth/source/saml"
+	"code.gitea.io/gitea/services/contexttest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumeSAMLRequest(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	require.NoError(t, saml.RememberRequest(1, "_request", "explore"))
+
+	consume := func(cookie string) (string, error) {
+		ctx, resp := contexttest.MockContext(t, "/user/saml/source/acs")
+		if cookie != "" {
+			ctx.Req.AddCookie(&http.Cookie{Name: samlRequestCookieName, Value: cookie})
+		}
+		redirectTo, err := consumeSAMLRequest(ctx, 1, "_request")
+		// the cookie is removed whether the response is accepted or not
+		assert.Contains(t, resp.Header().Get("Set-Cookie"), samlRequestCookieName+"=;")
+		return redirectTo, err
+	}
+
+	t.Run("NoCookie", func(t *testing.T) {
+		_, err := consume("")
+		assert.ErrorContains(t, err, "does not answer a request of this browser")
+	})
+
+	t.Run("OtherRequest", func(t *testing.T) {
+		_, err := consume("_other")
+		assert.ErrorContains(t, err, "does not answer a request of this browser")
+	})
+
+	t.Run("SameBrowser", func(t *testing.T) {
+		redirectTo, err := consume("_request")
+		require.NoError(t, err)
+		assert.Equal(t, "explore", redirectTo)
+
+		_, err = consume("_request")
+		assert.ErrorContains(t, err, "does not answer a pending request")
+	})
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/payments"
+)
+
+// maxBillingWebhookSize limits the body of webhooks of the payments sidecar, which only carry a subscription status
+const maxBillingWebhookSize = 64 * 1024
+
+// BillingWebhook receives the signed subscription status changes of the payments sidecar
+func BillingWebhook(ctx *context.Context) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Req.Body, maxBillingWebhookSize))
+	if err != nil {
+		ctx.HTTPError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := payments.HandleWebhook(ctx, ctx.Req.Header.Get(payments.WebhookSignatureHeader), body); err != nil {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
+			ctx.HTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, util.ErrInvalidArgument):
+			log.Warn("Rejected payments webhook from %s: %v", ctx.RemoteAddr(), err)
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+		default:
+			ctx.ServerError("HandleWebhook", err)
+		}
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -21,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	"code.gitea.io/gitea/services/payments"
 )
 
 const (
@@ -194,11 +195,11 @@ func CreatePost(ctx *context.Context) {
 }
 
 func isPaywallEnabled() bool {
-	return setting.CfgProvider.Section("payments").Key("ENABLED").MustBool(false)
+	return payments.IsEnabled()
 }
 
 func paymentsBaseURL() string {
-	return setting.CfgProvider.Section("payments").Key("SIDECAR_URL").MustString("http://payments:9000")
+	return payments.BaseURL()
 }
 
 type checkoutResponse struct {
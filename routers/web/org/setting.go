@@ -23,7 +23,6 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/templates"
-	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
@@ -31,6 +30,7 @@ import (
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	org_service "code.gitea.io/gitea/services/org"
+	"code.gitea.io/gitea/services/payments"
 	user_service "code.gitea.io/gitea/services/user"
 )
 
@@ -203,47 +203,13 @@ func SyncSeats(ctx *context.Context) {
 		return
 	}
 
-	memberIDs, err := organization.GetWriteMembersIDs(ctx, org.ID)
-	if err != nil {
-		log.Error("GetWriteMembersIDs org_id=%d: %v", org.ID, err)
-		ctx.Flash.Error("Failed to compute seat count")
-		ctx.Redirect(ctx.Org.OrgLink + "/settings")
-		return
-	}
-	seatCount := len(memberIDs)
-
-	body, err := json.Marshal(map[string]int{"quantity": seatCount})
-	if err != nil {
-		ctx.ServerError("MarshalSyncSeatsPayload", err)
-		return
-	}
-
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/billing/subscription/%s/quantity", paymentsBaseURL(), url.PathEscape(ob.SubscriptionID)), bytes.NewReader(body))
-	if err != nil {
-		ctx.ServerError("SyncSeatsRequest", err)
-		return
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
+	seatCount, err := payments.SyncOrgSeats(ctx, ob, true)
 	if err != nil {
+		log.Error("SyncOrgSeats org_id=%d: %v", org.ID, err)
 		ctx.Flash.Error(fmt.Sprintf("Failed to sync seats: %v", err))
 		ctx.Redirect(ctx.Org.OrgLink + "/settings")
 		return
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= http.StatusBadRequest {
-		ctx.Flash.Error(fmt.Sprintf("Failed to sync seats: %s", resp.Status))
-		ctx.Redirect(ctx.Org.OrgLink + "/settings")
-		return
-	}
-
-	ob.LastSeatCount = seatCount
-	ob.LastSync = timeutil.TimeStampNow()
-	if err := organization.UpsertOrgBilling(ctx, ob); err != nil {
-		log.Warn("UpsertOrgBilling sync seats org_id=%d: %v", org.ID, err)
-	}
 
 	ctx.Flash.Success(fmt.Sprintf("Synced seats to %d", seatCount))
 	ctx.Redirect(ctx.Org.OrgLink + "/settings")
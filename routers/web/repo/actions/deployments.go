@@ -0,0 +1,112 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplDeployments templates.TplName = "repo/actions/deployments"
+
+// Deployments lists the deployments of the jobs of a repository to its environments
+func Deployments(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.deployments")
+	ctx.Data["PageIsActions"] = true
+	ctx.Data["PageIsDeployments"] = true
+
+	page := max(ctx.FormInt("page"), 1)
+	opts := actions_model.FindDeploymentsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.IssuePagingNum,
+		},
+		RepoID:      ctx.Repo.Repository.ID,
+		Environment: ctx.FormTrim("environment"),
+	}
+	deployments, total, err := db.FindAndCount[actions_model.ActionDeployment](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAndCount", err)
+		return
+	}
+
+	// the reviewers of the environments are checked once for each environment
+	canReview := make(map[int64]bool)
+	for _, d := range deployments {
+		if err := d.LoadAttributes(ctx); err != nil {
+			ctx.ServerError("LoadAttributes", err)
+			return
+		}
+		if !d.IsPending() || ctx.Doer == nil {
+			continue
+		}
+		if _, ok := canReview[d.EnvironmentID]; ok {
+			continue
+		}
+		env, err := actions_model.GetEnvironmentByID(ctx, ctx.Repo.Repository.ID, d.EnvironmentID)
+		if err != nil {
+			if !actions_model.IsErrEnvironmentNotExist(err) {
+				ctx.ServerError("GetEnvironmentByID", err)
+				return
+			}
+			canReview[d.EnvironmentID] = false
+			continue
+		}
+		canReview[d.EnvironmentID] = env.IsReviewer(ctx, ctx.Doer.ID)
+	}
+	ctx.Data["Deployments"] = deployments
+	ctx.Data["CanReviewEnvironments"] = canReview
+
+	environments, err := actions_model.GetEnvironments(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetEnvironments", err)
+		return
+	}
+	ctx.Data["Environments"] = environments
+	ctx.Data["CurEnvironment"] = opts.Environment
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplDeployments)
+}
+
+// ReviewDeploymentPost approves or rejects a deployment waiting for a review of the doer
+func ReviewDeploymentPost(ctx *context.Context) {
+	deployment, err := actions_model.GetDeploymentByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetDeploymentByID", err)
+		}
+		return
+	}
+
+	approve := ctx.FormString("action") == "approve"
+	if err := actions_service.ReviewDeployment(ctx, ctx.Doer, deployment, approve, ctx.FormTrim("comment")); err != nil {
+		switch {
+		case errors.Is(err, util.ErrPermissionDenied):
+			ctx.Flash.Error(ctx.Tr("actions.deployments.review.not_allowed"))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("actions.deployments.review.not_waiting"))
+		default:
+			ctx.ServerError("ReviewDeployment", err)
+			return
+		}
+	} else {
+		ctx.Flash.Success(ctx.Tr(fmt.Sprintf("actions.deployments.review.%s", deployment.Status)))
+	}
+	ctx.Redirect(ctx.Repo.RepoLink + "/actions/deployments")
+}
@@ -13,6 +13,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/storage"
@@ -476,6 +478,10 @@ func Rerun(ctx *context_module.Context) {
 	isRunBlocked := run.Status == actions_model.StatusBlocked
 	if jobIndexStr == "" { // rerun all jobs
 		for _, j := range jobs {
+			if j.CallerJobID != 0 {
+				// the jobs of a called workflow are added to the run again when their caller is rerun
+				continue
+			}
 			// if the job has needs, it should be set to "blocked" status to wait for other jobs
 			shouldBlockJob := len(j.Needs) > 0 || isRunBlocked
 			if err := rerunJob(ctx, j, shouldBlockJob); err != nil {
@@ -487,6 +493,14 @@ func Rerun(ctx *context_module.Context) {
 		return
 	}
 
+	// a job of a called workflow is rerun with the whole workflow by its top level caller
+	for job.CallerJobID != 0 {
+		idx := slices.IndexFunc(jobs, func(j *actions_model.ActionRunJob) bool { return j.ID == job.CallerJobID })
+		if idx < 0 {
+			break
+		}
+		job = jobs[idx]
+	}
 	rerunJobs := actions_service.GetAllRerunJobs(job, jobs)
 
 	for _, j := range rerunJobs {
@@ -507,6 +521,11 @@ func rerunJob(ctx *context_module.Context, job *actions_model.ActionRunJob, shou
 		return nil
 	}
 
+	// a job deploying to an environment stays blocked until the job emitter has checked the protection rules,
+	// and a job calling a reusable workflow until the job emitter has added the called jobs
+	emitJob := !shouldBlock && (job.Environment != "" || job.Uses != "")
+	shouldBlock = shouldBlock || emitJob
+
 	job.TaskID = 0
 	job.Status = util.Iif(shouldBlock, actions_model.StatusBlocked, actions_model.StatusWaiting)
 	job.Started = 0
@@ -547,6 +566,9 @@ func rerunJob(ctx *context_module.Context, job *actions_model.ActionRunJob, shou
 	actions_service.CreateCommitStatusForRunJobs(ctx, job.Run, job)
 	notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
 
+	if emitJob {
+		return actions_service.EmitJobsIfReadyByRun(job.RunID)
+	}
 	return nil
 }
 
@@ -623,6 +645,7 @@ func approveRuns(ctx *context_module.Context, runIndexes []int64) {
 	updatedJobs := make([]*actions_model.ActionRunJob, 0)
 	runMap := make(map[int64]*actions_model.ActionRun, len(runIndexes))
 	runJobs := make(map[int64][]*actions_model.ActionRunJob, len(runIndexes))
+	emitRunIDs := make(container.Set[int64])
 
 	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
 		for _, runIndex := range runIndexes {
@@ -643,6 +666,11 @@ func approveRuns(ctx *context_module.Context, runIndexes []int64) {
 			}
 			runJobs[run.ID] = jobs
 			for _, job := range jobs {
+				if job.Environment != "" || job.Uses != "" {
+					// the job emitter checks the protection rules of the environment or adds the jobs of the called workflow after the approval
+					emitRunIDs.Add(run.ID)
+					continue
+				}
 				job.Status, err = actions_service.PrepareToStartJobWithConcurrency(ctx, job)
 				if err != nil {
 					return err
@@ -669,6 +697,12 @@ func approveRuns(ctx *context_module.Context, runIndexes []int64) {
 		actions_service.CreateCommitStatusForRunJobs(ctx, run, runJobs[runID]...)
 	}
 
+	for runID := range emitRunIDs {
+		if err := actions_service.EmitJobsIfReadyByRun(runID); err != nil {
+			log.Error("Check jobs of run %d: %v", runID, err)
+		}
+	}
+
 	if len(updatedJobs) > 0 {
 		job := updatedJobs[0]
 		actions_service.NotifyWorkflowRunStatusUpdateWithReload(ctx, job)
@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+const (
+	tplDependencies templates.TplName = "repo/activity"
+)
+
+var sbomFileExtensions = map[string]string{
+	dependency.SBOMFormatCycloneDX: ".cdx.json",
+	dependency.SBOMFormatSPDX:      ".spdx.json",
+}
+
+// Dependencies renders the page to show the dependencies of the default branch
+func Dependencies(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.activity.navbar.dependencies")
+	ctx.Data["PageIsActivity"] = true
+	ctx.Data["PageIsDependencies"] = true
+
+	status, err := repo_model.GetIndexerStatus(ctx, ctx.Repo.Repository, repo_model.RepoIndexerTypeDependencies)
+	if err != nil {
+		ctx.ServerError("GetIndexerStatus", err)
+		return
+	}
+	ctx.Data["IndexedCommitID"] = status.CommitSha
+
+	ecosystems, err := repo_model.GetDependencyEcosystems(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetDependencyEcosystems", err)
+		return
+	}
+	ctx.Data["Ecosystems"] = ecosystems
+
+	page := max(ctx.FormInt("page"), 1)
+	opts := &repo_model.FindDependenciesOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.RepoSearchPagingNum,
+		},
+		RepoID:    ctx.Repo.Repository.ID,
+		Ecosystem: ctx.FormTrim("ecosystem"),
+		Keyword:   ctx.FormTrim("q"),
+	}
+	deps, total, err := db.FindAndCount[repo_model.RepoDependency](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindDependencies", err)
+		return
+	}
+	ctx.Data["Dependencies"] = deps
+	ctx.Data["Ecosystem"] = opts.Ecosystem
+	ctx.Data["Keyword"] = opts.Keyword
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplDependencies)
+}
+
+// DependenciesSBOM downloads the software bill of materials of the default branch
+func DependenciesSBOM(ctx *context.Context) {
+	commit, err := ctx.Repo.GitRepo.GetBranchCommit(ctx.Repo.Repository.DefaultBranch)
+	if err != nil {
+		ctx.NotFoundOrServerError("GetBranchCommit", func(err error) bool { return errors.Is(err, util.ErrNotExist) }, err)
+		return
+	}
+
+	format := util.IfZero(ctx.FormTrim("format"), dependency.SBOMFormatCycloneDX)
+	sbom, err := repo_service.GenerateSBOM(ctx, ctx.Repo.Repository, commit, format)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.HTTPError(http.StatusBadRequest, err.Error())
+		} else {
+			ctx.ServerError("GenerateSBOM", err)
+		}
+		return
+	}
+
+	data, err := json.MarshalIndent(sbom, "", "  ")
+	if err != nil {
+		ctx.ServerError("MarshalIndent", err)
+		return
+	}
+
+	ctx.ServeContent(bytes.NewReader(data), &context.ServeHeaderOptions{
+		ContentType: repo_service.SBOMContentTypes[format],
+		Filename:    ctx.Repo.Repository.Name + "-" + base.ShortSha(commit.ID.String()) + sbomFileExtensions[format],
+	})
+}
@@ -954,6 +954,15 @@ func preparePullViewReviewAndMerge(ctx *context.Context, issue *issues_model.Iss
 		ctx.Data["IsBlockedByRejection"] = issues_model.MergeBlockedByRejectedReview(ctx, pb, pull)
 		ctx.Data["IsBlockedByOfficialReviewRequests"] = issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pull)
 		ctx.Data["IsBlockedByOutdatedBranch"] = issues_model.MergeBlockedByOutdatedBranch(pb, pull)
+		if pb.BlockOnCodeOwnerReviews && !pull.HasMerged {
+			missingCodeOwnerApprovals, err := pull_service.GetMissingCodeOwnerApprovals(ctx, pb, pull)
+			if err != nil {
+				// the merge itself is still blocked by CheckPullBranchProtections, don't break the page
+				log.Error("GetMissingCodeOwnerApprovals: %v", err)
+			}
+			ctx.Data["MissingCodeOwnerApprovals"] = missingCodeOwnerApprovals
+			ctx.Data["IsBlockedByCodeOwners"] = err != nil || len(missingCodeOwnerApprovals) > 0
+		}
 		ctx.Data["GrantedApprovals"] = issues_model.GetGrantedApprovalsCount(ctx, pb, pull)
 		ctx.Data["RequireSigned"] = pb.RequireSignedCommits
 		ctx.Data["ChangedProtectedFiles"] = pull.ChangedProtectedFiles
@@ -994,6 +1003,27 @@ func preparePullViewReviewAndMerge(ctx *context.Context, issue *issues_model.Iss
 		ctx.ServerError("GetScheduledMergeByPullID", err)
 		return
 	}
+
+	// Check if the pr is waiting in the merge queue
+	mergeQueueEntry, inMergeQueue, err := pull_model.GetMergeQueueEntryByPullID(ctx, pull.ID)
+	if err != nil {
+		ctx.ServerError("GetMergeQueueEntryByPullID", err)
+		return
+	}
+	if inMergeQueue {
+		if err := mergeQueueEntry.LoadDoer(ctx); err != nil {
+			ctx.ServerError("LoadDoer", err)
+			return
+		}
+		position, err := pull_model.GetMergeQueuePosition(ctx, mergeQueueEntry)
+		if err != nil {
+			ctx.ServerError("GetMergeQueuePosition", err)
+			return
+		}
+		ctx.Data["MergeQueueEntry"] = mergeQueueEntry
+		ctx.Data["MergeQueuePosition"] = position
+		ctx.Data["CanCancelMergeQueue"] = ctx.IsSigned && (mergeQueueEntry.DoerID == ctx.Doer.ID || ctx.Repo.IsAdmin())
+	}
 }
 
 func prepareIssueViewContent(ctx *context.Context, issue *issues_model.Issue) {
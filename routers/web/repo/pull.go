@@ -45,6 +45,7 @@ import (
 	"code.gitea.io/gitea/services/context/upload"
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/gitdiff"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -1141,6 +1142,25 @@ func MergePullRequest(ctx *context.Context) {
 		return
 	}
 
+	if !form.MergeWhenChecksSucceed && !form.ForceMerge {
+		if enabled, err := mergequeue.IsMergeQueueEnabled(ctx, pr); err != nil {
+			ctx.ServerError("IsMergeQueueEnabled", err)
+			return
+		} else if enabled {
+			if err := mergequeue.AddToMergeQueue(ctx, ctx.Doer, pr, repo_model.MergeStyle(form.Do), message, deleteBranchAfterMerge); err != nil {
+				if errors.Is(err, pull_model.ErrAlreadyInMergeQueue) {
+					ctx.JSONError(ctx.Tr("repo.pulls.merge_queue_already_queued"))
+					return
+				}
+				ctx.ServerError("AddToMergeQueue", err)
+				return
+			}
+			ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue_newly_added"))
+			ctx.JSONRedirect(issue.Link())
+			return
+		}
+	}
+
 	if form.MergeWhenChecksSucceed {
 		// delete all scheduled auto merges
 		_ = pull_model.DeleteScheduledAutoMerge(ctx, pr.ID)
@@ -1278,6 +1298,35 @@ func CancelAutoMergePullRequest(ctx *context.Context) {
 	ctx.Redirect(fmt.Sprintf("%s/pulls/%d", ctx.Repo.RepoLink, issue.Index))
 }
 
+// CancelMergeQueuePullRequest removes a pull request from the merge queue
+func CancelMergeQueuePullRequest(ctx *context.Context) {
+	issue, ok := getPullInfo(ctx)
+	if !ok {
+		return
+	}
+
+	entry, exist, err := pull_model.GetMergeQueueEntryByPullID(ctx, issue.PullRequest.ID)
+	if err != nil {
+		ctx.ServerError("GetMergeQueueEntryByPullID", err)
+		return
+	} else if !exist {
+		ctx.Flash.Error(ctx.Tr("repo.pulls.merge_queue_not_queued"))
+		ctx.JSONRedirect(issue.Link())
+		return
+	}
+	if entry.DoerID != ctx.Doer.ID && !ctx.Repo.IsAdmin() {
+		ctx.NotFound(nil)
+		return
+	}
+
+	if err := mergequeue.RemoveFromMergeQueue(ctx, ctx.Doer, issue.PullRequest, mergequeue.RemovedReasonCanceled); err != nil {
+		ctx.ServerError("RemoveFromMergeQueue", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.pulls.merge_queue_canceled"))
+	ctx.JSONRedirect(issue.Link())
+}
+
 func stopTimerIfAvailable(ctx *context.Context, user *user_model.User, issue *issues_model.Issue) error {
 	_, err := issues_model.FinishIssueStopwatch(ctx, user, issue)
 	return err
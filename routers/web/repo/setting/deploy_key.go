@@ -12,8 +12,10 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/web"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	audit_service "code.gitea.io/gitea/services/audit"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	notify_service "code.gitea.io/gitea/services/notify"
 )
 
 // DeployKeys render the deploy keys list of a repository page
@@ -92,6 +94,9 @@ func DeployKeysPost(ctx *context.Context) {
 		return
 	}
 
+	audit_service.DeployKeyAdd(ctx, ctx.Doer, ctx.Repo.Repository, key)
+	notify_service.AddDeployKey(ctx, ctx.Doer, ctx.Repo.Repository, key)
+
 	log.Trace("Deploy key added: %d", ctx.Repo.Repository.ID)
 	ctx.Flash.Success(ctx.Tr("repo.settings.add_key_success", key.Name))
 	ctx.Redirect(ctx.Repo.RepoLink + "/settings/keys")
@@ -99,9 +104,13 @@ func DeployKeysPost(ctx *context.Context) {
 
 // DeleteDeployKey response for deleting a deploy key
 func DeleteDeployKey(ctx *context.Context) {
-	if err := asymkey_service.DeleteDeployKey(ctx, ctx.Repo.Repository, ctx.FormInt64("id")); err != nil {
+	if key, err := asymkey_service.DeleteDeployKey(ctx, ctx.Repo.Repository, ctx.FormInt64("id")); err != nil {
 		ctx.Flash.Error("DeleteDeployKey: " + err.Error())
 	} else {
+		if key != nil {
+			audit_service.DeployKeyDelete(ctx, ctx.Doer, ctx.Repo.Repository, key)
+			notify_service.DeleteDeployKey(ctx, ctx.Doer, ctx.Repo.Repository, key)
+		}
 		ctx.Flash.Success(ctx.Tr("repo.settings.deploy_key_deletion_success"))
 	}
 
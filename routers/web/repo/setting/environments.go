@@ -0,0 +1,297 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	secret_model "code.gitea.io/gitea/models/secret"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+	secret_service "code.gitea.io/gitea/services/secrets"
+)
+
+const tplRepoActionsEnvironments templates.TplName = "repo/settings/actions"
+
+func environmentsLink(ctx *context.Context) string {
+	return ctx.Repo.RepoLink + "/settings/actions/environments"
+}
+
+// prepareEnvironmentsContext loads the users and teams which can be chosen as required reviewers
+func prepareEnvironmentsContext(ctx *context.Context) bool {
+	ctx.Data["Title"] = ctx.Tr("actions.environments")
+	ctx.Data["PageType"] = "environments"
+	ctx.Data["PageIsActionsSettingsEnvironments"] = true
+	ctx.Data["EnvironmentsLink"] = environmentsLink(ctx)
+
+	users, err := access_model.GetUsersWithUnitAccess(ctx, ctx.Repo.Repository, perm.AccessModeWrite, unit.TypeActions)
+	if err != nil {
+		ctx.ServerError("GetUsersWithUnitAccess", err)
+		return false
+	}
+	ctx.Data["Users"] = users
+	if ctx.Repo.Owner.IsOrganization() {
+		teams, err := organization.GetTeamsWithAccessToAnyRepoUnit(ctx, ctx.Repo.Owner.ID, ctx.Repo.Repository.ID, perm.AccessModeWrite, unit.TypeActions)
+		if err != nil {
+			ctx.ServerError("GetTeamsWithAccessToAnyRepoUnit", err)
+			return false
+		}
+		ctx.Data["Teams"] = teams
+	}
+	return true
+}
+
+func getEnvironmentByContext(ctx *context.Context) *actions_model.ActionEnvironment {
+	env, err := actions_model.GetEnvironmentByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetEnvironmentByID", err)
+		}
+		return nil
+	}
+	return env
+}
+
+// Environments render the deployment environments of a repository
+func Environments(ctx *context.Context) {
+	if !prepareEnvironmentsContext(ctx) {
+		return
+	}
+
+	envs, err := actions_model.GetEnvironments(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetEnvironments", err)
+		return
+	}
+	ctx.Data["Environments"] = envs
+
+	ctx.HTML(http.StatusOK, tplRepoActionsEnvironments)
+}
+
+// EnvironmentNew render the page to create an environment
+func EnvironmentNew(ctx *context.Context) {
+	if !prepareEnvironmentsContext(ctx) {
+		return
+	}
+
+	setEnvironmentEditContext(ctx, &actions_model.ActionEnvironment{})
+	ctx.HTML(http.StatusOK, tplRepoActionsEnvironments)
+}
+
+// EnvironmentNewPost handles the creation of an environment
+func EnvironmentNewPost(ctx *context.Context) {
+	if !prepareEnvironmentsContext(ctx) {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.EnvironmentForm)
+	env := &actions_model.ActionEnvironment{RepoID: ctx.Repo.Repository.ID, Name: strings.TrimSpace(form.Name)}
+	if !applyEnvironmentForm(ctx, env) {
+		return
+	}
+	if err := actions_model.CreateEnvironment(ctx, env); err != nil {
+		if actions_model.IsErrEnvironmentAlreadyExist(err) {
+			ctx.Data["Err_Name"] = true
+			ctx.RenderWithErr(ctx.Tr("actions.environments.name_exists", env.Name), tplRepoActionsEnvironments, form)
+			return
+		}
+		ctx.ServerError("CreateEnvironment", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.environments.created", env.Name))
+	ctx.Redirect(environmentsLink(ctx))
+}
+
+// EnvironmentEdit render the page to edit the protection rules, the secrets and the variables of an environment
+func EnvironmentEdit(ctx *context.Context) {
+	if !prepareEnvironmentsContext(ctx) {
+		return
+	}
+
+	env := getEnvironmentByContext(ctx)
+	if env == nil {
+		return
+	}
+	setEnvironmentEditContext(ctx, env)
+
+	secrets, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{RepoID: env.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		ctx.ServerError("FindSecrets", err)
+		return
+	}
+	ctx.Data["Secrets"] = secrets
+	variables, err := db.Find[actions_model.ActionVariable](ctx, actions_model.FindVariablesOpts{RepoID: env.RepoID, EnvironmentID: env.ID})
+	if err != nil {
+		ctx.ServerError("FindVariables", err)
+		return
+	}
+	ctx.Data["Variables"] = variables
+
+	ctx.HTML(http.StatusOK, tplRepoActionsEnvironments)
+}
+
+// EnvironmentEditPost handles the update of the protection rules of an environment
+func EnvironmentEditPost(ctx *context.Context) {
+	if !prepareEnvironmentsContext(ctx) {
+		return
+	}
+
+	env := getEnvironmentByContext(ctx)
+	if env == nil {
+		return
+	}
+	if !applyEnvironmentForm(ctx, env) {
+		return
+	}
+	if err := actions_model.UpdateEnvironment(ctx, env); err != nil {
+		ctx.ServerError("UpdateEnvironment", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.environments.updated", env.Name))
+	ctx.Redirect(environmentsLink(ctx) + "/" + ctx.PathParam("id"))
+}
+
+// EnvironmentDeletePost handles the deletion of an environment
+func EnvironmentDeletePost(ctx *context.Context) {
+	if err := actions_service.DeleteEnvironment(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("DeleteEnvironment", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.environments.deleted"))
+	ctx.JSONRedirect(environmentsLink(ctx))
+}
+
+// EnvironmentSecretPost creates or updates a secret of an environment
+func EnvironmentSecretPost(ctx *context.Context) {
+	env := getEnvironmentByContext(ctx)
+	if env == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.AddSecretForm)
+	s, _, err := secret_service.CreateOrUpdateEnvironmentSecret(ctx, ctx.Doer, env.RepoID, env.ID, form.Name, util.ReserveLineBreakForTextarea(form.Data), form.Description)
+	if err != nil {
+		log.Error("CreateOrUpdateEnvironmentSecret failed: %v", err)
+		ctx.JSONError(ctx.Tr("secrets.save_failed"))
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("secrets.save_success", s.Name))
+	ctx.JSONRedirect(environmentsLink(ctx) + "/" + ctx.PathParam("id"))
+}
+
+// EnvironmentSecretDeletePost deletes a secret of an environment
+func EnvironmentSecretDeletePost(ctx *context.Context) {
+	env := getEnvironmentByContext(ctx)
+	if env == nil {
+		return
+	}
+
+	id := ctx.FormInt64("id")
+	if err := secret_service.DeleteEnvironmentSecretByID(ctx, ctx.Doer, env.RepoID, env.ID, id); err != nil {
+		log.Error("DeleteEnvironmentSecretByID(%d) failed: %v", id, err)
+		ctx.JSONError(ctx.Tr("secrets.deletion.failed"))
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("secrets.deletion.success"))
+	ctx.JSONRedirect(environmentsLink(ctx) + "/" + ctx.PathParam("id"))
+}
+
+// EnvironmentVariablePost creates a variable of an environment
+func EnvironmentVariablePost(ctx *context.Context) {
+	env := getEnvironmentByContext(ctx)
+	if env == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.EditVariableForm)
+	v, err := actions_service.CreateEnvironmentVariable(ctx, env.RepoID, env.ID, form.Name, form.Data, form.Description)
+	if err != nil {
+		log.Error("CreateEnvironmentVariable: %v", err)
+		ctx.JSONError(ctx.Tr("actions.variables.creation.failed"))
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.variables.creation.success", v.Name))
+	ctx.JSONRedirect(environmentsLink(ctx) + "/" + ctx.PathParam("id"))
+}
+
+// EnvironmentVariableDeletePost deletes a variable of an environment
+func EnvironmentVariableDeletePost(ctx *context.Context) {
+	env := getEnvironmentByContext(ctx)
+	if env == nil {
+		return
+	}
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		IDs:           []int64{ctx.FormInt64("id")},
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+	})
+	if err == nil {
+		err = actions_service.DeleteVariableByID(ctx, v.ID)
+	}
+	if err != nil {
+		log.Error("Delete environment variable [%d] failed: %v", ctx.FormInt64("id"), err)
+		ctx.JSONError(ctx.Tr("actions.variables.deletion.failed"))
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.variables.deletion.success"))
+	ctx.JSONRedirect(environmentsLink(ctx) + "/" + ctx.PathParam("id"))
+}
+
+func setEnvironmentEditContext(ctx *context.Context, env *actions_model.ActionEnvironment) {
+	ctx.Data["PageIsEditEnvironment"] = true
+	ctx.Data["Environment"] = env
+	ctx.Data["reviewer_users"] = strings.Join(base.Int64sToStrings(env.ReviewerUserIDs), ",")
+	ctx.Data["reviewer_teams"] = strings.Join(base.Int64sToStrings(env.ReviewerTeamIDs), ",")
+}
+
+// applyEnvironmentForm copies the submitted protection rules into the environment, it renders the form again and returns false if it is invalid
+func applyEnvironmentForm(ctx *context.Context, env *actions_model.ActionEnvironment) bool {
+	form := web.GetForm(ctx).(*forms.EnvironmentForm)
+
+	env.ReviewerUserIDs, _ = base.StringsToInt64s(util.SplitTrimSpace(form.ReviewerUsers, ","))
+	env.ReviewerTeamIDs, _ = base.StringsToInt64s(util.SplitTrimSpace(form.ReviewerTeams, ","))
+	env.PreventSelfReview = form.PreventSelfReview
+	env.WaitTimer = form.WaitTimer
+	env.BranchPatterns = strings.TrimSpace(form.BranchPatterns)
+
+	setEnvironmentEditContext(ctx, env)
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplRepoActionsEnvironments)
+		return false
+	}
+	if env.Name == "" {
+		ctx.Data["Err_Name"] = true
+		ctx.RenderWithErr(ctx.Tr("actions.environments.name_required"), tplRepoActionsEnvironments, form)
+		return false
+	}
+	return true
+}
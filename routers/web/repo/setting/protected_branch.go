@@ -25,6 +25,7 @@ import (
 	"code.gitea.io/gitea/routers/web/repo"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
+	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	"code.gitea.io/gitea/services/repository"
 )
@@ -146,7 +147,8 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 			return
 		}
 	}
-	if protectBranch == nil {
+	isNewRule := protectBranch == nil
+	if isNewRule {
 		// No options found, create defaults.
 		protectBranch = &git_model.ProtectedBranch{
 			RepoID:   ctx.Repo.Repository.ID,
@@ -253,6 +255,7 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	}
 	protectBranch.BlockOnRejectedReviews = f.BlockOnRejectedReviews
 	protectBranch.BlockOnOfficialReviewRequests = f.BlockOnOfficialReviewRequests
+	protectBranch.BlockOnCodeOwnerReviews = f.BlockOnCodeOwnerReviews
 	protectBranch.DismissStaleApprovals = f.DismissStaleApprovals
 	protectBranch.IgnoreStaleApprovals = f.IgnoreStaleApprovals
 	protectBranch.RequireSignedCommits = f.RequireSignedCommits
@@ -260,8 +263,10 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 	protectBranch.UnprotectedFilePatterns = f.UnprotectedFilePatterns
 	protectBranch.BlockOnOutdatedBranch = f.BlockOnOutdatedBranch
 	protectBranch.BlockAdminMergeOverride = f.BlockAdminMergeOverride
+	protectBranch.EnableMergeQueue = f.EnableMergeQueue
+	protectBranch.MergeQueueMaxBatchSize = max(f.MergeQueueMaxBatchSize, 0)
 
-	if err = pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
+	if err = pull_service.CreateOrUpdateProtectedBranch(ctx, ctx.Doer, ctx.Repo.Repository, protectBranch, git_model.WhitelistOptions{
 		UserIDs:          whitelistUsers,
 		TeamIDs:          whitelistTeams,
 		ForcePushUserIDs: forcePushAllowlistUsers,
@@ -274,6 +279,11 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		ctx.ServerError("CreateOrUpdateProtectedBranch", err)
 		return
 	}
+	if isNewRule {
+		notify_service.CreateBranchProtection(ctx, ctx.Doer, ctx.Repo.Repository, protectBranch)
+	} else {
+		notify_service.UpdateBranchProtection(ctx, ctx.Doer, ctx.Repo.Repository, protectBranch)
+	}
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.update_protect_branch_success", protectBranch.RuleName))
 	ctx.Redirect(fmt.Sprintf("%s/settings/branches?rule_name=%s", ctx.Repo.RepoLink, protectBranch.RuleName))
@@ -301,11 +311,12 @@ func DeleteProtectedBranchRulePost(ctx *context.Context) {
 		return
 	}
 
-	if err := git_model.DeleteProtectedBranch(ctx, ctx.Repo.Repository, ruleID); err != nil {
+	if err := pull_service.DeleteProtectedBranch(ctx, ctx.Doer, ctx.Repo.Repository, rule); err != nil {
 		ctx.Flash.Error(ctx.Tr("repo.settings.remove_protected_branch_failed", rule.RuleName))
 		ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/branches")
 		return
 	}
+	notify_service.DeleteBranchProtection(ctx, ctx.Doer, ctx.Repo.Repository, rule)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.remove_protected_branch_success", rule.RuleName))
 	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/branches")
@@ -0,0 +1,313 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/base"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/forms"
+)
+
+const (
+	tplRepoRulesets templates.TplName = "repo/settings/rulesets"
+	tplOrgRulesets  templates.TplName = "org/settings/rulesets"
+)
+
+type rulesetsCtx struct {
+	OwnerID          int64
+	RepoID           int64
+	IsRepo           bool
+	IsOrg            bool
+	RulesetsTemplate templates.TplName
+	RedirectLink     string
+}
+
+func getRulesetsCtx(ctx *context.Context) (*rulesetsCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &rulesetsCtx{
+			OwnerID:          0,
+			RepoID:           ctx.Repo.Repository.ID,
+			IsRepo:           true,
+			RulesetsTemplate: tplRepoRulesets,
+			RedirectLink:     ctx.Repo.RepoLink + "/settings/rulesets",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+			ctx.ServerError("RenderUserOrgHeader", err)
+			return nil, nil
+		}
+		return &rulesetsCtx{
+			OwnerID:          ctx.ContextUser.ID,
+			RepoID:           0,
+			IsOrg:            true,
+			RulesetsTemplate: tplOrgRulesets,
+			RedirectLink:     ctx.Org.OrgLink + "/settings/rulesets",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set Rulesets context")
+}
+
+// prepareRulesetsContext loads the rulesets context and the users and teams which can be chosen as bypass actors
+func prepareRulesetsContext(ctx *context.Context) *rulesetsCtx {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.rulesets")
+	ctx.Data["PageIsSettingsRulesets"] = true
+
+	rCtx, err := getRulesetsCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getRulesetsCtx", err)
+		return nil
+	} else if ctx.Written() {
+		return nil
+	}
+	ctx.Data["RulesetsLink"] = rCtx.RedirectLink
+	ctx.Data["IsOrgRulesets"] = rCtx.IsOrg
+
+	if rCtx.IsRepo {
+		users, err := access_model.GetUsersWithUnitAccess(ctx, ctx.Repo.Repository, perm.AccessModeWrite, unit.TypeCode)
+		if err != nil {
+			ctx.ServerError("GetUsersWithUnitAccess", err)
+			return nil
+		}
+		ctx.Data["Users"] = users
+		if ctx.Repo.Owner.IsOrganization() {
+			teams, err := organization.GetTeamsWithAccessToAnyRepoUnit(ctx, ctx.Repo.Owner.ID, ctx.Repo.Repository.ID, perm.AccessModeWrite, unit.TypeCode)
+			if err != nil {
+				ctx.ServerError("GetTeamsWithAccessToAnyRepoUnit", err)
+				return nil
+			}
+			ctx.Data["Teams"] = teams
+		}
+		return rCtx
+	}
+
+	users, _, err := organization.FindOrgMembers(ctx, &organization.FindOrgMembersOpts{
+		Doer:         ctx.Doer,
+		IsDoerMember: true,
+		OrgID:        rCtx.OwnerID,
+	})
+	if err != nil {
+		ctx.ServerError("FindOrgMembers", err)
+		return nil
+	}
+	ctx.Data["Users"] = users
+	teams, err := organization.FindOrgTeams(ctx, rCtx.OwnerID)
+	if err != nil {
+		ctx.ServerError("FindOrgTeams", err)
+		return nil
+	}
+	ctx.Data["Teams"] = teams
+	return rCtx
+}
+
+func getRulesetByContext(ctx *context.Context, rCtx *rulesetsCtx) *git_model.Ruleset {
+	rs, err := git_model.GetRulesetByID(ctx, rCtx.OwnerID, rCtx.RepoID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetRulesetByID", err)
+		}
+		return nil
+	}
+	return rs
+}
+
+// Rulesets render the rulesets of a repository or an organization
+func Rulesets(ctx *context.Context) {
+	rCtx := prepareRulesetsContext(ctx)
+	if rCtx == nil {
+		return
+	}
+
+	rulesets, err := git_model.GetRulesets(ctx, rCtx.OwnerID, rCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetRulesets", err)
+		return
+	}
+	ctx.Data["Rulesets"] = rulesets
+
+	if rCtx.IsRepo && ctx.Repo.Owner.IsOrganization() {
+		// the organization rulesets apply to the repository too, list them read-only
+		orgRulesets, err := git_model.GetRulesets(ctx, ctx.Repo.Owner.ID, 0)
+		if err != nil {
+			ctx.ServerError("GetRulesets", err)
+			return
+		}
+		inherited := make([]*git_model.Ruleset, 0, len(orgRulesets))
+		for _, rs := range orgRulesets {
+			if rs.MatchRepo(ctx.Repo.Repository) {
+				inherited = append(inherited, rs)
+			}
+		}
+		ctx.Data["InheritedRulesets"] = inherited
+	}
+
+	ctx.HTML(http.StatusOK, rCtx.RulesetsTemplate)
+}
+
+// RulesetNew render the page to create a ruleset
+func RulesetNew(ctx *context.Context) {
+	rCtx := prepareRulesetsContext(ctx)
+	if rCtx == nil {
+		return
+	}
+
+	setRulesetEditContext(ctx, &git_model.Ruleset{
+		Target:      git_model.RulesetTargetBranch,
+		Enforcement: git_model.RulesetEnforcementActive,
+	})
+	ctx.HTML(http.StatusOK, rCtx.RulesetsTemplate)
+}
+
+// RulesetNewPost handles the creation of a ruleset
+func RulesetNewPost(ctx *context.Context) {
+	rCtx := prepareRulesetsContext(ctx)
+	if rCtx == nil {
+		return
+	}
+
+	rs := &git_model.Ruleset{OwnerID: rCtx.OwnerID, RepoID: rCtx.RepoID}
+	if !applyRulesetForm(ctx, rCtx, rs) {
+		return
+	}
+	if err := git_model.CreateRuleset(ctx, rs); err != nil {
+		ctx.ServerError("CreateRuleset", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.rulesets.created", rs.Name))
+	ctx.Redirect(rCtx.RedirectLink)
+}
+
+// RulesetEdit render the page to edit a ruleset
+func RulesetEdit(ctx *context.Context) {
+	rCtx := prepareRulesetsContext(ctx)
+	if rCtx == nil {
+		return
+	}
+
+	rs := getRulesetByContext(ctx, rCtx)
+	if rs == nil {
+		return
+	}
+	setRulesetEditContext(ctx, rs)
+	ctx.HTML(http.StatusOK, rCtx.RulesetsTemplate)
+}
+
+// RulesetEditPost handles the update of a ruleset
+func RulesetEditPost(ctx *context.Context) {
+	rCtx := prepareRulesetsContext(ctx)
+	if rCtx == nil {
+		return
+	}
+
+	rs := getRulesetByContext(ctx, rCtx)
+	if rs == nil {
+		return
+	}
+	if !applyRulesetForm(ctx, rCtx, rs) {
+		return
+	}
+	if err := git_model.UpdateRuleset(ctx, rs); err != nil {
+		ctx.ServerError("UpdateRuleset", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.rulesets.updated", rs.Name))
+	ctx.Redirect(rCtx.RedirectLink)
+}
+
+// RulesetDeletePost handles the deletion of a ruleset
+func RulesetDeletePost(ctx *context.Context) {
+	rCtx, err := getRulesetsCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getRulesetsCtx", err)
+		return
+	} else if ctx.Written() {
+		return
+	}
+
+	if err := git_model.DeleteRuleset(ctx, rCtx.OwnerID, rCtx.RepoID, ctx.PathParamInt64("id")); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("DeleteRuleset", err)
+		}
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.rulesets.deleted"))
+	ctx.JSONRedirect(rCtx.RedirectLink)
+}
+
+func setRulesetEditContext(ctx *context.Context, rs *git_model.Ruleset) {
+	ctx.Data["PageIsEditRuleset"] = true
+	ctx.Data["Ruleset"] = rs
+	ctx.Data["bypass_users"] = strings.Join(base.Int64sToStrings(rs.BypassUserIDs), ",")
+	ctx.Data["bypass_teams"] = strings.Join(base.Int64sToStrings(rs.BypassTeamIDs), ",")
+	ctx.Data["required_deployments"] = strings.Join(rs.RequiredDeployments, ",")
+	ctx.Data["max_file_size"] = rs.MaxFileSize / (1024 * 1024)
+}
+
+// applyRulesetForm copies the submitted form into the ruleset, it renders the form again and returns false if it is invalid
+func applyRulesetForm(ctx *context.Context, rCtx *rulesetsCtx, rs *git_model.Ruleset) bool {
+	form := web.GetForm(ctx).(*forms.RulesetForm)
+
+	rs.Name = strings.TrimSpace(form.Name)
+	rs.Target = git_model.RulesetTarget(form.Target)
+	rs.Enforcement = git_model.RulesetEnforcement(form.Enforcement)
+	rs.IncludeRefs = strings.TrimSpace(form.IncludeRefs)
+	rs.ExcludeRefs = strings.TrimSpace(form.ExcludeRefs)
+	if rCtx.IsOrg {
+		rs.RepoPatterns = strings.TrimSpace(form.RepoPatterns)
+	}
+	rs.BypassUserIDs, _ = base.StringsToInt64s(util.SplitTrimSpace(form.BypassUsers, ","))
+	rs.BypassTeamIDs, _ = base.StringsToInt64s(util.SplitTrimSpace(form.BypassTeams, ","))
+	rs.BypassDeployKeys = form.BypassDeployKeys
+	rs.BypassActions = form.BypassActions
+	rs.BypassRepoAdmins = form.BypassRepoAdmins
+	rs.BlockCreation = form.BlockCreation
+	rs.BlockDeletion = form.BlockDeletion
+	rs.BlockForcePush = form.BlockForcePush
+	rs.RequireLinearHistory = form.RequireLinearHistory
+	rs.RequireSignedCommits = form.RequireSignedCommits
+	rs.RequiredDeployments = util.SplitTrimSpace(form.RequiredDeployments, ",")
+	rs.CommitMessagePattern = strings.TrimSpace(form.CommitMessagePattern)
+	rs.RestrictedFilePaths = strings.TrimSpace(form.RestrictedFilePaths)
+	rs.MaxFileSize = form.MaxFileSize * 1024 * 1024
+
+	setRulesetEditContext(ctx, rs)
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, rCtx.RulesetsTemplate)
+		return false
+	}
+	if rs.Target != git_model.RulesetTargetPush && rs.IncludeRefs == "" {
+		ctx.Data["Err_IncludeRefs"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.settings.rulesets.include_refs_required"), rCtx.RulesetsTemplate, form)
+		return false
+	}
+	if _, err := regexp.Compile(rs.CommitMessagePattern); err != nil {
+		ctx.Data["Err_CommitMessagePattern"] = true
+		ctx.RenderWithErr(ctx.Tr("repo.settings.rulesets.commit_message_pattern_invalid", err.Error()), rCtx.RulesetsTemplate, form)
+		return false
+	}
+	return true
+}
@@ -33,6 +33,7 @@ import (
 	"code.gitea.io/gitea/services/forms"
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
+	notify_service "code.gitea.io/gitea/services/notify"
 	repo_service "code.gitea.io/gitea/services/repository"
 	wiki_service "code.gitea.io/gitea/services/wiki"
 
@@ -207,7 +208,7 @@ func handleSettingsPostUpdate(ctx *context.Context) {
 	repo.Website = form.Website
 	repo.IsTemplate = form.Template
 
-	if err := repo_service.UpdateRepository(ctx, repo, false); err != nil {
+	if err := repo_service.UpdateRepository(ctx, ctx.Doer, repo, false); err != nil {
 		ctx.ServerError("UpdateRepository", err)
 		return
 	}
@@ -638,7 +639,7 @@ func handleSettingsPostAdvanced(ctx *context.Context) {
 		return
 	}
 	if repoChanged {
-		if err := repo_service.UpdateRepository(ctx, repo, false); err != nil {
+		if err := repo_service.UpdateRepository(ctx, ctx.Doer, repo, false); err != nil {
 			ctx.ServerError("UpdateRepository", err)
 			return
 		}
@@ -1009,9 +1010,9 @@ func handleSettingsPostVisibility(ctx *context.Context) {
 	}
 
 	if repo.IsPrivate {
-		err = repo_service.MakeRepoPublic(ctx, repo)
+		err = repo_service.MakeRepoPublic(ctx, ctx.Doer, repo)
 	} else {
-		err = repo_service.MakeRepoPrivate(ctx, repo)
+		err = repo_service.MakeRepoPrivate(ctx, ctx.Doer, repo)
 	}
 
 	if err != nil {
@@ -1021,6 +1022,8 @@ func handleSettingsPostVisibility(ctx *context.Context) {
 		return
 	}
 
+	notify_service.ChangeRepositoryVisibility(ctx, ctx.Doer, repo)
+
 	ctx.Flash.Success(ctx.Tr("repo.settings.visibility.success"))
 
 	log.Trace("Repository visibility changed: %s/%s", ctx.Repo.Owner.Name, repo.Name)
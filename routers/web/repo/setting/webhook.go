@@ -184,11 +184,23 @@ func ParseHookEvent(form forms.WebhookForm) *webhook_module.HookEvent {
 			webhook_module.HookEventWiki:                     form.Wiki,
 			webhook_module.HookEventRepository:               form.Repository,
 			webhook_module.HookEventPackage:                  form.Package,
+			webhook_module.HookEventPackageVulnerability:     form.PackageVulnerability,
 			webhook_module.HookEventStatus:                   form.Status,
+			webhook_module.HookEventMember:                   form.Member,
+			webhook_module.HookEventOrganization:             form.Organization,
+			webhook_module.HookEventTeam:                     form.Team,
+			webhook_module.HookEventMembership:               form.Membership,
+			webhook_module.HookEventBranchProtection:         form.BranchProtection,
+			webhook_module.HookEventDeployKey:                form.DeployKey,
+			webhook_module.HookEventRepositoryVisibility:     form.RepositoryVisibility,
+			webhook_module.HookEventUser:                     form.User,
+			webhook_module.HookEventStar:                     form.Star,
+			webhook_module.HookEventWatch:                    form.Watch,
 			webhook_module.HookEventWorkflowRun:              form.WorkflowRun,
 			webhook_module.HookEventWorkflowJob:              form.WorkflowJob,
 		},
 		BranchFilter: form.BranchFilter,
+		Condition:    form.Condition,
 	}
 }
 
@@ -239,6 +251,7 @@ func createWebhook(ctx *context.Context, params webhookParams) {
 		Secret:          params.WebhookForm.Secret,
 		HookEvent:       ParseHookEvent(params.WebhookForm),
 		IsActive:        params.WebhookForm.Active,
+		MaxAttempts:     params.WebhookForm.MaxAttempts,
 		Type:            params.Type,
 		Meta:            string(meta),
 		OwnerID:         orCtx.OwnerID,
@@ -291,7 +304,12 @@ func editWebhook(ctx *context.Context, params webhookParams) {
 	w.ContentType = params.ContentType
 	w.Secret = params.WebhookForm.Secret
 	w.HookEvent = ParseHookEvent(params.WebhookForm)
+	if params.WebhookForm.Active && !w.IsActive {
+		// start counting the failed deliveries again when the webhook gets reactivated
+		w.ConsecutiveFailures = 0
+	}
 	w.IsActive = params.WebhookForm.Active
+	w.MaxAttempts = params.WebhookForm.MaxAttempts
 	w.HTTPMethod = params.HTTPMethod
 	w.Meta = string(meta)
 
@@ -581,6 +599,32 @@ func packagistHookParams(ctx *context.Context) webhookParams {
 	}
 }
 
+// CustomHooksNewPost response for creating custom webhook
+func CustomHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, customHookParams(ctx))
+}
+
+// CustomHooksEditPost response for editing custom webhook
+func CustomHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, customHookParams(ctx))
+}
+
+func customHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewCustomHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.CUSTOM,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		HTTPMethod:  form.HTTPMethod,
+		WebhookForm: form.WebhookForm,
+		Meta: &webhook_service.CustomMeta{
+			PayloadTemplate:    form.PayloadTemplate,
+			PayloadContentType: strings.TrimSpace(form.PayloadContentType),
+		},
+	}
+}
+
 func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 	orCtx, err := getOwnerRepoCtx(ctx)
 	if err != nil {
@@ -619,9 +663,16 @@ func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 		ctx.Data["MatrixHook"] = webhook_service.GetMatrixHook(w)
 	case webhook_module.PACKAGIST:
 		ctx.Data["PackagistHook"] = webhook_service.GetPackagistHook(w)
+	case webhook_module.CUSTOM:
+		ctx.Data["CustomHook"] = webhook_service.GetCustomHook(w)
 	}
 
-	ctx.Data["History"], err = w.History(ctx, 1)
+	if ctx.FormString("deliveries") == "failed" {
+		ctx.Data["ShowFailedDeliveries"] = true
+		ctx.Data["History"], err = w.FailedDeliveries(ctx, 1)
+	} else {
+		ctx.Data["History"], err = w.History(ctx, 1)
+	}
 	if err != nil {
 		ctx.ServerError("History", err)
 	}
@@ -0,0 +1,192 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"code.gitea.io/gitea/models/db"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	terraform_service "code.gitea.io/gitea/services/terraform"
+)
+
+const (
+	tplTerraformStates       templates.TplName = "repo/terraform/list"
+	tplTerraformState        templates.TplName = "repo/terraform/state"
+	tplTerraformStateVersion templates.TplName = "repo/terraform/version"
+)
+
+// MustEnableTerraform checks if the Terraform state backend is enabled
+func MustEnableTerraform(ctx *context.Context) {
+	if !setting.Terraform.Enabled {
+		ctx.NotFound(nil)
+		return
+	}
+}
+
+func prepareTerraformData(ctx *context.Context) {
+	ctx.Data["PageIsTerraform"] = true
+	ctx.Data["TerraformBackendAddress"] = setting.AppURL + "api/v1/repos/" + url.PathEscape(ctx.Repo.Repository.OwnerName) + "/" + url.PathEscape(ctx.Repo.Repository.Name) + "/terraform/states/"
+}
+
+// TerraformStates lists the Terraform states of a repository
+func TerraformStates(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.terraform.states")
+	prepareTerraformData(ctx)
+
+	states, err := terraform_model.GetStatesByRepoID(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.ServerError("GetStatesByRepoID", err)
+		return
+	}
+	ctx.Data["States"] = states
+
+	ctx.HTML(http.StatusOK, tplTerraformStates)
+}
+
+func getTerraformState(ctx *context.Context) *terraform_model.State {
+	state, err := terraform_model.GetStateByName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetStateByName", err)
+		}
+		return nil
+	}
+	ctx.Data["State"] = state
+	return state
+}
+
+// TerraformState shows the versions of a Terraform state
+func TerraformState(ctx *context.Context) {
+	state := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = state.Name
+	prepareTerraformData(ctx)
+
+	if state.IsLocked() {
+		ctx.Data["LockInfo"] = state.GetLockInfo()
+	}
+
+	page := max(ctx.FormInt("page"), 1)
+	opts := db.ListOptions{
+		Page:     page,
+		PageSize: setting.UI.IssuePagingNum,
+	}
+	versions, total, err := terraform_model.FindStateVersions(ctx, state.ID, opts)
+	if err != nil {
+		ctx.ServerError("FindStateVersions", err)
+		return
+	}
+	for _, v := range versions {
+		if err := v.LoadCreator(ctx); err != nil {
+			ctx.ServerError("LoadCreator", err)
+			return
+		}
+	}
+	ctx.Data["Versions"] = versions
+
+	pager := context.NewPagination(int(total), opts.PageSize, opts.Page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+
+	ctx.HTML(http.StatusOK, tplTerraformState)
+}
+
+// TerraformStateUnlockPost removes the lock of a Terraform state, like "terraform force-unlock" does
+func TerraformStateUnlockPost(ctx *context.Context) {
+	state := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if _, err := terraform_service.UnlockState(ctx, ctx.Repo.Repository, state.Name, ""); err != nil {
+		ctx.ServerError("UnlockState", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.terraform.unlocked"))
+	ctx.Redirect(ctx.Repo.RepoLink + "/terraform/" + url.PathEscape(state.Name))
+}
+
+func getTerraformStateVersion(ctx *context.Context, state *terraform_model.State) *terraform_model.StateVersion {
+	version, err := terraform_model.GetStateVersionByID(ctx, state.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetStateVersionByID", err)
+		}
+		return nil
+	}
+	if err := version.LoadCreator(ctx); err != nil {
+		ctx.ServerError("LoadCreator", err)
+		return nil
+	}
+	ctx.Data["Version"] = version
+	return version
+}
+
+// TerraformStateVersion shows the content of a version of a Terraform state
+func TerraformStateVersion(ctx *context.Context) {
+	state := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+	version := getTerraformStateVersion(ctx, state)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = state.Name
+	prepareTerraformData(ctx)
+
+	content, err := terraform_service.ReadStateVersion(ctx, version)
+	if err != nil {
+		ctx.ServerError("ReadStateVersion", err)
+		return
+	}
+	ctx.Data["Content"] = content
+
+	ctx.HTML(http.StatusOK, tplTerraformStateVersion)
+}
+
+// TerraformStateVersionDiff shows the changes of a version of a Terraform state compared to the previous version
+func TerraformStateVersionDiff(ctx *context.Context) {
+	state := getTerraformState(ctx)
+	if ctx.Written() {
+		return
+	}
+	version := getTerraformStateVersion(ctx, state)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = state.Name
+	ctx.Data["PageIsDiff"] = true
+	prepareTerraformData(ctx)
+
+	previous, err := terraform_model.GetPreviousStateVersion(ctx, version)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.ServerError("GetPreviousStateVersion", err)
+		return
+	}
+	ctx.Data["PreviousVersion"] = previous
+
+	diff, err := terraform_service.DiffStateVersions(ctx, previous, version)
+	if err != nil {
+		ctx.ServerError("DiffStateVersions", err)
+		return
+	}
+	ctx.Data["DiffLines"] = diff
+
+	ctx.HTML(http.StatusOK, tplTerraformStateVersion)
+}
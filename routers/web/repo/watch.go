@@ -9,12 +9,13 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/services/context"
+	repo_service "code.gitea.io/gitea/services/repository"
 )
 
 const tplWatchUnwatch templates.TplName = "repo/watch_unwatch"
 
 func ActionWatch(ctx *context.Context) {
-	err := repo_model.WatchRepo(ctx, ctx.Doer, ctx.Repo.Repository, ctx.PathParam("action") == "watch")
+	err := repo_service.WatchRepository(ctx, ctx.Doer, ctx.Repo.Repository, ctx.PathParam("action") == "watch")
 	if err != nil {
 		handleActionError(ctx, err)
 		return
@@ -0,0 +1,120 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	perm_model "code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
+	unit_model "code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/templates"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+)
+
+type tokenPermissionsCtx struct {
+	OwnerID      int64
+	RepoUnit     *repo_model.RepoUnit
+	Template     templates.TplName
+	RedirectLink string
+}
+
+func getTokenPermissionsCtx(ctx *context.Context) (*tokenPermissionsCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		actionsUnit, err := ctx.Repo.Repository.GetUnit(ctx, unit_model.TypeActions)
+		if err != nil {
+			return nil, err
+		}
+		return &tokenPermissionsCtx{
+			OwnerID:      ctx.Repo.Repository.OwnerID,
+			RepoUnit:     actionsUnit,
+			Template:     tplRepoVariables,
+			RedirectLink: ctx.Repo.RepoLink + "/settings/actions/token_permissions",
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
+			return nil, err
+		}
+		return &tokenPermissionsCtx{
+			OwnerID:      ctx.ContextUser.ID,
+			Template:     tplOrgVariables,
+			RedirectLink: ctx.Org.OrgLink + "/settings/actions/token_permissions",
+		}, nil
+	}
+
+	return nil, errors.New("unable to set TokenPermissions context")
+}
+
+// TokenPermissions renders the maximum permissions of the GITEA_TOKEN of the jobs of a repository or an organization
+func TokenPermissions(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("actions.token_permissions")
+	ctx.Data["PageType"] = "token_permissions"
+	ctx.Data["PageIsSharedSettingsTokenPermissions"] = true
+
+	tCtx, err := getTokenPermissionsCtx(ctx)
+	if err != nil {
+		if repo_model.IsErrUnitTypeNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("getTokenPermissionsCtx", err)
+		}
+		return
+	}
+
+	ownerPerms, err := actions_model.GetOwnerMaxTokenPermissions(ctx, tCtx.OwnerID)
+	if err != nil {
+		ctx.ServerError("GetOwnerMaxTokenPermissions", err)
+		return
+	}
+	perms := ownerPerms
+	if tCtx.RepoUnit != nil {
+		perms = actions_model.TokenPermissions(tCtx.RepoUnit.ActionsConfig().MaxTokenPermissions)
+		ctx.Data["OwnerMaxTokenPermissions"] = ownerPerms
+	}
+
+	ctx.Data["TokenPermissionScopes"] = actions_model.TokenPermissionScopes
+	ctx.Data["IsTokenPermissionsLimited"] = perms != nil
+	ctx.Data["MaxTokenPermissions"] = perms
+	ctx.HTML(http.StatusOK, tCtx.Template)
+}
+
+// TokenPermissionsPost updates the maximum permissions of the GITEA_TOKEN of the jobs of a repository or an organization
+func TokenPermissionsPost(ctx *context.Context) {
+	tCtx, err := getTokenPermissionsCtx(ctx)
+	if err != nil {
+		if repo_model.IsErrUnitTypeNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("getTokenPermissionsCtx", err)
+		}
+		return
+	}
+
+	var perms actions_model.TokenPermissions
+	if ctx.FormBool("limit_permissions") {
+		perms = make(actions_model.TokenPermissions, len(actions_model.TokenPermissionScopes))
+		for _, scope := range actions_model.TokenPermissionScopes {
+			perms[scope] = perm_model.ParseAccessMode(ctx.FormString(scope), perm_model.AccessModeNone, perm_model.AccessModeRead, perm_model.AccessModeWrite)
+		}
+	}
+
+	if tCtx.RepoUnit != nil {
+		tCtx.RepoUnit.ActionsConfig().MaxTokenPermissions = perms
+		err = repo_model.UpdateRepoUnit(ctx, tCtx.RepoUnit)
+	} else {
+		err = actions_model.SetOwnerMaxTokenPermissions(ctx, tCtx.OwnerID, perms)
+	}
+	if err != nil {
+		ctx.ServerError("UpdateMaxTokenPermissions", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("actions.token_permissions.update_success"))
+	ctx.Redirect(tCtx.RedirectLink)
+}
@@ -18,6 +18,9 @@ import (
 	"code.gitea.io/gitea/services/forms"
 	cargo_service "code.gitea.io/gitea/services/packages/cargo"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+	virtual_service "code.gitea.io/gitea/services/packages/virtual"
 )
 
 func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
@@ -28,6 +31,41 @@ func SetPackagesContext(ctx *context.Context, owner *user_model.User) {
 	}
 
 	ctx.Data["CleanupRules"] = pcrs
+
+	prs, err := packages_model.GetRemotesByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetRemotesByOwner", err)
+		return
+	}
+
+	ctx.Data["Remotes"] = prs
+
+	pvss, err := packages_model.GetVirtualSourcesByOwner(ctx, owner.ID)
+	if err != nil {
+		ctx.ServerError("GetVirtualSourcesByOwner", err)
+		return
+	}
+
+	sourceOwnerIDs := make([]int64, 0, len(pvss))
+	for _, pvs := range pvss {
+		sourceOwnerIDs = append(sourceOwnerIDs, pvs.SourceOwnerID)
+	}
+	sourceOwners, err := user_model.GetUsersMapByIDs(ctx, sourceOwnerIDs)
+	if err != nil {
+		ctx.ServerError("GetUsersMapByIDs", err)
+		return
+	}
+
+	ctx.Data["VirtualSources"] = pvss
+	ctx.Data["VirtualSourceOwners"] = sourceOwners
+
+	_, nixPublicKey, err := nix_service.GetOrCreateSigningKey(ctx, owner)
+	if err != nil {
+		ctx.ServerError("GetOrCreateSigningKey", err)
+		return
+	}
+
+	ctx.Data["NixPublicKey"] = nixPublicKey
 }
 
 func SetRuleAddContext(ctx *context.Context) {
@@ -230,6 +268,333 @@ func getCleanupRuleByContext(ctx *context.Context, owner *user_model.User) *pack
 	return nil
 }
 
+func SetRemoteAddContext(ctx *context.Context) {
+	setRemoteEditContext(ctx, nil)
+}
+
+func SetRemoteEditContext(ctx *context.Context, owner *user_model.User) {
+	pr := getRemoteByContext(ctx, owner)
+	if pr == nil {
+		return
+	}
+
+	setRemoteEditContext(ctx, pr)
+}
+
+func setRemoteEditContext(ctx *context.Context, pr *packages_model.PackageRemote) {
+	ctx.Data["IsEditRemote"] = pr != nil
+
+	if pr == nil {
+		pr = &packages_model.PackageRemote{Enabled: true}
+	} else {
+		count, err := packages_model.CountRemoteFiles(ctx, pr.ID)
+		if err != nil {
+			ctx.ServerError("CountRemoteFiles", err)
+			return
+		}
+		ctx.Data["CachedFileCount"] = count
+	}
+	ctx.Data["Remote"] = pr
+	ctx.Data["MetadataTTLMinutes"] = pr.MetadataTTL / 60
+	ctx.Data["AvailableTypes"] = packages_model.RemoteTypes
+}
+
+func PerformRemoteAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performRemoteEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformRemoteEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pr := getRemoteByContext(ctx, owner)
+	if pr == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageRemoteForm)
+
+	switch form.Action {
+	case "remove":
+		if err := packages_model.DeleteRemoteByID(ctx, pr.ID); err != nil {
+			ctx.ServerError("DeleteRemoteByID", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.delete"))
+		ctx.Redirect(redirectURL)
+	case "clear":
+		if err := remote_service.ClearCache(ctx, pr); err != nil {
+			ctx.ServerError("ClearCache", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.clear"))
+		ctx.Redirect(fmt.Sprintf("%s/remotes/%d", redirectURL, pr.ID))
+	default:
+		performRemoteEditPost(ctx, owner, pr, redirectURL, template)
+	}
+}
+
+func performRemoteEditPost(ctx *context.Context, owner *user_model.User, pr *packages_model.PackageRemote, redirectURL string, template templates.TplName) {
+	isEditRemote := pr != nil
+
+	if pr == nil {
+		pr = &packages_model.PackageRemote{}
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageRemoteForm)
+
+	urlChanged := pr.URL != form.URL
+
+	pr.Enabled = form.Enabled
+	pr.OwnerID = owner.ID
+	pr.URL = form.URL
+	pr.Username = form.Username
+	pr.MetadataTTL = form.MetadataTTL * 60
+
+	ctx.Data["IsEditRemote"] = isEditRemote
+	ctx.Data["Remote"] = pr
+	ctx.Data["MetadataTTLMinutes"] = form.MetadataTTL
+	ctx.Data["AvailableTypes"] = packages_model.RemoteTypes
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	// an empty password keeps the stored one, it is removed together with the username
+	if form.Password != "" || form.Username == "" {
+		if err := pr.SetPassword(form.Password); err != nil {
+			ctx.ServerError("SetPassword", err)
+			return
+		}
+	}
+
+	if isEditRemote {
+		if err := packages_model.UpdateRemote(ctx, pr); err != nil {
+			ctx.ServerError("UpdateRemote", err)
+			return
+		}
+		// the cached files belong to the old upstream
+		if urlChanged {
+			if err := remote_service.ClearCache(ctx, pr); err != nil {
+				ctx.ServerError("ClearCache", err)
+				return
+			}
+		}
+	} else {
+		pr.Type = packages_model.Type(form.Type)
+
+		if has, err := packages_model.HasOwnerRemoteForPackageType(ctx, owner.ID, pr.Type); err != nil {
+			ctx.ServerError("HasOwnerRemoteForPackageType", err)
+			return
+		} else if has {
+			ctx.Data["Err_Type"] = true
+			ctx.HTML(http.StatusOK, template)
+			return
+		}
+
+		var err error
+		if pr, err = packages_model.InsertRemote(ctx, pr); err != nil {
+			ctx.ServerError("InsertRemote", err)
+			return
+		}
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.remotes.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/remotes/%d", redirectURL, pr.ID))
+}
+
+func getRemoteByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageRemote {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pr, err := packages_model.GetRemoteByID(ctx, id)
+	if err != nil {
+		if err == packages_model.ErrPackageRemoteNotExist {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetRemoteByID", err)
+		}
+		return nil
+	}
+
+	if pr != nil && pr.OwnerID == owner.ID {
+		return pr
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageRemote[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
+
+func SetVirtualSourceAddContext(ctx *context.Context) {
+	setVirtualSourceEditContext(ctx, nil)
+}
+
+func SetVirtualSourceEditContext(ctx *context.Context, owner *user_model.User) {
+	pvs := getVirtualSourceByContext(ctx, owner)
+	if pvs == nil {
+		return
+	}
+
+	setVirtualSourceEditContext(ctx, pvs)
+}
+
+func setVirtualSourceEditContext(ctx *context.Context, pvs *packages_model.PackageVirtualSource) {
+	ctx.Data["IsEditVirtualSource"] = pvs != nil
+
+	if pvs == nil {
+		pvs = &packages_model.PackageVirtualSource{}
+	} else {
+		source, err := user_model.GetUserByID(ctx, pvs.SourceOwnerID)
+		if err != nil {
+			ctx.ServerError("GetUserByID", err)
+			return
+		}
+		ctx.Data["SourceName"] = source.Name
+	}
+	ctx.Data["VirtualSource"] = pvs
+	ctx.Data["AvailableTypes"] = packages_model.VirtualTypes
+}
+
+func PerformVirtualSourceAddPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	performVirtualSourceEditPost(ctx, owner, nil, redirectURL, template)
+}
+
+func PerformVirtualSourceEditPost(ctx *context.Context, owner *user_model.User, redirectURL string, template templates.TplName) {
+	pvs := getVirtualSourceByContext(ctx, owner)
+	if pvs == nil {
+		return
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageVirtualSourceForm)
+
+	if form.Action == "remove" {
+		if err := packages_model.DeleteVirtualSourceByID(ctx, pvs.ID); err != nil {
+			ctx.ServerError("DeleteVirtualSourceByID", err)
+			return
+		}
+		if err := virtual_service.RebuildRepositoryIndex(ctx, owner.ID, pvs.Type); err != nil {
+			ctx.ServerError("RebuildRepositoryIndex", err)
+			return
+		}
+
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.virtual_sources.success.delete"))
+		ctx.Redirect(redirectURL)
+	} else {
+		performVirtualSourceEditPost(ctx, owner, pvs, redirectURL, template)
+	}
+}
+
+func performVirtualSourceEditPost(ctx *context.Context, owner *user_model.User, pvs *packages_model.PackageVirtualSource, redirectURL string, template templates.TplName) {
+	isEditVirtualSource := pvs != nil
+
+	if pvs == nil {
+		pvs = &packages_model.PackageVirtualSource{}
+	}
+
+	form := web.GetForm(ctx).(*forms.PackageVirtualSourceForm)
+
+	pvs.OwnerID = owner.ID
+	pvs.Pattern = form.Pattern
+	pvs.PatternMatcher = nil
+	pvs.Position = form.Position
+
+	ctx.Data["IsEditVirtualSource"] = isEditVirtualSource
+	ctx.Data["VirtualSource"] = pvs
+	ctx.Data["SourceName"] = form.Source
+	ctx.Data["AvailableTypes"] = packages_model.VirtualTypes
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, template)
+		return
+	}
+
+	if err := pvs.CompiledPattern(); err != nil {
+		ctx.Data["Err_Pattern"] = true
+		ctx.RenderWithErr(ctx.Tr("packages.owner.settings.virtual_sources.pattern.invalid"), template, nil)
+		return
+	}
+
+	if isEditVirtualSource {
+		if err := packages_model.UpdateVirtualSource(ctx, pvs); err != nil {
+			ctx.ServerError("UpdateVirtualSource", err)
+			return
+		}
+	} else {
+		pvs.Type = packages_model.Type(form.Type)
+
+		source, err := user_model.GetUserByName(ctx, form.Source)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			ctx.ServerError("GetUserByName", err)
+			return
+		}
+		if source != nil {
+			if ok, err := virtual_service.CanUseAsSource(ctx, ctx.Doer, owner, source); err != nil {
+				ctx.ServerError("CanUseAsSource", err)
+				return
+			} else if !ok {
+				source = nil
+			}
+		}
+		if source == nil {
+			ctx.Data["Err_Source"] = true
+			ctx.RenderWithErr(ctx.Tr("packages.owner.settings.virtual_sources.source.invalid"), template, nil)
+			return
+		}
+		pvs.SourceOwnerID = source.ID
+
+		if has, err := packages_model.HasVirtualSource(ctx, owner.ID, pvs.Type, source.ID); err != nil {
+			ctx.ServerError("HasVirtualSource", err)
+			return
+		} else if has {
+			ctx.Data["Err_Source"] = true
+			ctx.RenderWithErr(ctx.Tr("packages.owner.settings.virtual_sources.source.exists"), template, nil)
+			return
+		}
+
+		if pvs, err = packages_model.InsertVirtualSource(ctx, pvs); err != nil {
+			ctx.ServerError("InsertVirtualSource", err)
+			return
+		}
+	}
+
+	// the packages of the sources are part of the repository index
+	if err := virtual_service.RebuildRepositoryIndex(ctx, owner.ID, pvs.Type); err != nil {
+		ctx.ServerError("RebuildRepositoryIndex", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("packages.owner.settings.virtual_sources.success.update"))
+	ctx.Redirect(fmt.Sprintf("%s/sources/%d", redirectURL, pvs.ID))
+}
+
+func getVirtualSourceByContext(ctx *context.Context, owner *user_model.User) *packages_model.PackageVirtualSource {
+	id := ctx.FormInt64("id")
+	if id == 0 {
+		id = ctx.PathParamInt64("id")
+	}
+
+	pvs, err := packages_model.GetVirtualSourceByID(ctx, id)
+	if err != nil {
+		if err == packages_model.ErrPackageVirtualSourceNotExist {
+			ctx.NotFound(err)
+		} else {
+			ctx.ServerError("GetVirtualSourceByID", err)
+		}
+		return nil
+	}
+
+	if pvs != nil && pvs.OwnerID == owner.ID {
+		return pvs
+	}
+
+	ctx.NotFound(fmt.Errorf("PackageVirtualSource[%v] not associated to owner %v", id, owner))
+
+	return nil
+}
+
 func InitializeCargoIndex(ctx *context.Context, owner *user_model.User) {
 	err := cargo_service.InitializeIndexRepository(ctx, owner, owner)
 	if err != nil {
@@ -249,3 +614,13 @@ func RebuildCargoIndex(ctx *context.Context, owner *user_model.User) {
 		ctx.Flash.Success(ctx.Tr("packages.owner.settings.cargo.rebuild.success"))
 	}
 }
+
+func RegenerateNixSigningKey(ctx *context.Context, owner *user_model.User) {
+	_, err := nix_service.RegenerateSigningKey(ctx, owner)
+	if err != nil {
+		log.Error("RegenerateSigningKey failed: %v", err)
+		ctx.Flash.Error(ctx.Tr("packages.owner.settings.nix.regenerate.error", err))
+	} else {
+		ctx.Flash.Success(ctx.Tr("packages.owner.settings.nix.regenerate.success"))
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+)
+
+// AuditEvents loads the events of the audit log filtered by the request, ownerID 0 loads the events of all owners
+func AuditEvents(ctx *context.Context, ownerID int64) {
+	page := max(ctx.FormInt("page"), 1)
+	opts := &audit_model.FindEventsOptions{
+		ListOptions: db.ListOptions{
+			Page:     page,
+			PageSize: setting.UI.Admin.NoticePagingNum,
+		},
+		OwnerID: ownerID,
+		Action:  audit_model.Action(ctx.FormString("action")),
+	}
+
+	actorName := ctx.FormTrim("actor")
+	if actorName != "" {
+		actor, err := user_model.GetUserByName(ctx, actorName)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			ctx.ServerError("GetUserByName", err)
+			return
+		}
+		opts.ActorID = -1 // an unknown actor matches no events
+		if actor != nil {
+			opts.ActorID = actor.ID
+		}
+	}
+
+	events, total, err := db.FindAndCount[audit_model.Event](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAuditEvents", err)
+		return
+	}
+
+	ctx.Data["AuditEvents"] = events
+	ctx.Data["AuditActions"] = audit_model.Actions
+	ctx.Data["SelectedAction"] = string(opts.Action)
+	ctx.Data["ActorName"] = actorName
+	ctx.Data["Total"] = total
+
+	pager := context.NewPagination(int(total), opts.PageSize, page, 5)
+	pager.AddParamFromRequest(ctx.Req)
+	ctx.Data["Page"] = pager
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package user
+
+import (
+	quota_model "code.gitea.io/gitea/models/quota"
+	"code.gitea.io/gitea/services/context"
+)
+
+// QuotaUsage loads the quota group and the storage usage of the owner
+func QuotaUsage(ctx *context.Context, ownerID int64) {
+	group, err := quota_model.GetGroupForUser(ctx, ownerID)
+	if err != nil {
+		ctx.ServerError("GetGroupForUser", err)
+		return
+	}
+	usage, err := quota_model.GetUsage(ctx, ownerID)
+	if err != nil {
+		ctx.ServerError("GetUsage", err)
+		return
+	}
+
+	ctx.Data["QuotaGroup"] = group
+	ctx.Data["QuotaUsage"] = usage
+	ctx.Data["QuotaCategories"] = quota_model.Categories
+}
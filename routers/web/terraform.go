@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package web
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/context"
+)
+
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+type terraformServicesType struct {
+	ModulesV1   string `json:"modules.v1"`
+	ProvidersV1 string `json:"providers.v1"`
+}
+
+func terraformServices(ctx *context.Context) {
+	baseURL := setting.AppURL + "api/packages/-/terraform/"
+	ctx.JSON(http.StatusOK, terraformServicesType{
+		ModulesV1:   baseURL + "modules/v1/",
+		ProvidersV1: baseURL + "providers/v1/",
+	})
+}
@@ -8,7 +8,9 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"slices"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	org_model "code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
@@ -31,10 +33,13 @@ import (
 	"code.gitea.io/gitea/modules/web"
 	packages_helper "code.gitea.io/gitea/routers/api/packages/helper"
 	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	actions_service "code.gitea.io/gitea/services/actions"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 	packages_service "code.gitea.io/gitea/services/packages"
 	container_service "code.gitea.io/gitea/services/packages/container"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
+	packages_scanner_service "code.gitea.io/gitea/services/packages/scanner"
 )
 
 const (
@@ -293,6 +298,13 @@ func ViewPackageVersion(ctx *context.Context) {
 			}
 		}
 		ctx.Data["ContainerImageMetadata"] = imageMetadata
+	case packages_model.TypeNix:
+		_, pub, err := nix_service.GetOrCreateSigningKey(ctx, pd.Owner)
+		if err != nil {
+			ctx.ServerError("GetOrCreateSigningKey", err)
+			return
+		}
+		ctx.Data["NixPublicKey"] = pub
 	}
 	var pvs []*packages_model.PackageVersion
 	var pvsTotal int64
@@ -328,9 +340,56 @@ func ViewPackageVersion(ctx *context.Context) {
 		hasRepositoryAccess = permission.HasAnyUnitAccess()
 	}
 	ctx.Data["HasRepositoryAccess"] = hasRepositoryAccess
+
+	attestations, err := findVerifiedAttestations(ctx, pd)
+	if err != nil {
+		ctx.ServerError("findVerifiedAttestations", err)
+		return
+	}
+	ctx.Data["Attestations"] = attestations
+
+	scanResults, err := packages_scanner_service.GetScanResults(ctx, pd)
+	if err != nil {
+		ctx.ServerError("GetScanResults", err)
+		return
+	}
+	ctx.Data["ScanResults"] = scanResults
+
 	ctx.HTML(http.StatusOK, tplPackagesView)
 }
 
+// findVerifiedAttestations returns the verified attestations of the actions runs of the package owner which describe any file of the package version,
+// e.g. the build provenance of a release artifact or of a container image
+func findVerifiedAttestations(ctx *context.Context, pd *packages_model.PackageDescriptor) ([]*actions_model.ActionAttestation, error) {
+	if !actions_service.IsAttestationAvailable() || len(pd.Files) == 0 {
+		return nil, nil
+	}
+	digests := make([]string, 0, len(pd.Files))
+	for _, pf := range pd.Files {
+		digests = append(digests, "sha256:"+pf.Blob.HashSHA256)
+	}
+	attestations, err := db.Find[actions_model.ActionAttestation](ctx, actions_model.FindAttestationsOptions{
+		OwnerID: pd.Owner.ID,
+		Digests: digests,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	verified := make([]*actions_model.ActionAttestation, 0, len(attestations))
+	for _, attestation := range attestations {
+		statement, err := actions_service.VerifyAttestation(attestation, "")
+		if err != nil {
+			log.Warn("Attestation %d of package version %d is not verified: %v", attestation.ID, pd.Version.ID, err)
+			continue
+		}
+		if slices.ContainsFunc(statement.Digests(), func(digest string) bool { return slices.Contains(digests, digest) }) {
+			verified = append(verified, attestation)
+		}
+	}
+	return verified, nil
+}
+
 // ListPackageVersions lists all versions of a package
 func ListPackageVersions(ctx *context.Context) {
 	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
@@ -15,6 +15,7 @@ import (
 	"code.gitea.io/gitea/modules/templates"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/web"
+	audit_service "code.gitea.io/gitea/services/audit"
 	"code.gitea.io/gitea/services/context"
 	"code.gitea.io/gitea/services/forms"
 )
@@ -86,6 +87,7 @@ func ApplicationsPost(ctx *context.Context) {
 		ctx.ServerError("NewAccessToken", err)
 		return
 	}
+	audit_service.AccessTokenCreate(ctx, ctx.Doer, ctx.Doer, t)
 
 	ctx.Flash.Success(ctx.Tr("settings.generate_token_success"))
 	ctx.Flash.Info(t.Token)
@@ -95,9 +97,14 @@ func ApplicationsPost(ctx *context.Context) {
 
 // DeleteApplication response for delete user access token
 func DeleteApplication(ctx *context.Context) {
-	if err := auth_model.DeleteAccessTokenByID(ctx, ctx.FormInt64("id"), ctx.Doer.ID); err != nil {
+	t, err := auth_model.GetAccessTokenByID(ctx, ctx.FormInt64("id"), ctx.Doer.ID)
+	if err == nil {
+		err = auth_model.DeleteAccessTokenByID(ctx, t.ID, ctx.Doer.ID)
+	}
+	if err != nil {
 		ctx.Flash.Error("DeleteAccessTokenByID: " + err.Error())
 	} else {
+		audit_service.AccessTokenDelete(ctx, ctx.Doer, ctx.Doer, t)
 		ctx.Flash.Success(ctx.Tr("settings.delete_token_success"))
 	}
 
@@ -17,9 +17,11 @@ import (
 )
 
 const (
-	tplSettingsPackages            templates.TplName = "user/settings/packages"
-	tplSettingsPackagesRuleEdit    templates.TplName = "user/settings/packages_cleanup_rules_edit"
-	tplSettingsPackagesRulePreview templates.TplName = "user/settings/packages_cleanup_rules_preview"
+	tplSettingsPackages                  templates.TplName = "user/settings/packages"
+	tplSettingsPackagesRuleEdit          templates.TplName = "user/settings/packages_cleanup_rules_edit"
+	tplSettingsPackagesRulePreview       templates.TplName = "user/settings/packages_cleanup_rules_preview"
+	tplSettingsPackagesRemoteEdit        templates.TplName = "user/settings/packages_remotes_edit"
+	tplSettingsPackagesVirtualSourceEdit templates.TplName = "user/settings/packages_virtual_sources_edit"
 )
 
 func Packages(ctx *context.Context) {
@@ -88,6 +90,98 @@ func PackagesRulePreview(ctx *context.Context) {
 	ctx.HTML(http.StatusOK, tplSettingsPackagesRulePreview)
 }
 
+func PackagesRemoteAdd(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.SetRemoteAddContext(ctx)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesRemoteEdit)
+}
+
+func PackagesRemoteEdit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.SetRemoteEditContext(ctx, ctx.Doer)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesRemoteEdit)
+}
+
+func PackagesRemoteAddPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.PerformRemoteAddPost(
+		ctx,
+		ctx.Doer,
+		setting.AppSubURL+"/user/settings/packages",
+		tplSettingsPackagesRemoteEdit,
+	)
+}
+
+func PackagesRemoteEditPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.PerformRemoteEditPost(
+		ctx,
+		ctx.Doer,
+		setting.AppSubURL+"/user/settings/packages",
+		tplSettingsPackagesRemoteEdit,
+	)
+}
+
+func PackagesVirtualSourceAdd(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.SetVirtualSourceAddContext(ctx)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesVirtualSourceEdit)
+}
+
+func PackagesVirtualSourceEdit(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.SetVirtualSourceEditContext(ctx, ctx.Doer)
+
+	ctx.HTML(http.StatusOK, tplSettingsPackagesVirtualSourceEdit)
+}
+
+func PackagesVirtualSourceAddPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.PerformVirtualSourceAddPost(
+		ctx,
+		ctx.Doer,
+		setting.AppSubURL+"/user/settings/packages",
+		tplSettingsPackagesVirtualSourceEdit,
+	)
+}
+
+func PackagesVirtualSourceEditPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	shared.PerformVirtualSourceEditPost(
+		ctx,
+		ctx.Doer,
+		setting.AppSubURL+"/user/settings/packages",
+		tplSettingsPackagesVirtualSourceEdit,
+	)
+}
+
 func InitializeCargoIndex(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("packages.title")
 	ctx.Data["PageIsSettingsPackages"] = true
@@ -106,6 +200,15 @@ func RebuildCargoIndex(ctx *context.Context) {
 	ctx.Redirect(setting.AppSubURL + "/user/settings/packages")
 }
 
+func RegenerateNixSigningKey(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("packages.title")
+	ctx.Data["PageIsSettingsPackages"] = true
+
+	shared.RegenerateNixSigningKey(ctx, ctx.Doer)
+
+	ctx.Redirect(setting.AppSubURL + "/user/settings/packages")
+}
+
 func RegenerateChefKeyPair(ctx *context.Context) {
 	priv, pub, err := util.GenerateKeyPair(chef_module.KeyBits)
 	if err != nil {
@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package setting
+
+import (
+	"net/http"
+
+	"code.gitea.io/gitea/modules/templates"
+	shared_user "code.gitea.io/gitea/routers/web/shared/user"
+	"code.gitea.io/gitea/services/context"
+)
+
+const tplSettingsStorage templates.TplName = "user/settings/storage"
+
+// Storage shows the storage used by the user and the quota
+func Storage(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("quota.title")
+	ctx.Data["PageIsSettingsStorage"] = true
+
+	shared_user.QuotaUsage(ctx, ctx.Doer.ID)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplSettingsStorage)
+}
@@ -381,6 +381,13 @@ func registerWebRoutes(m *web.Router) {
 		}
 	}
 
+	quotaEnabled := func(ctx *context.Context) {
+		if !setting.Quota.Enabled {
+			ctx.HTTPError(http.StatusForbidden)
+			return
+		}
+	}
+
 	feedEnabled := func(ctx *context.Context) {
 		if !setting.Other.EnableFeed {
 			ctx.HTTPError(http.StatusNotFound)
@@ -423,6 +430,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Post("/feishu/new", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksNewPost)
 		m.Post("/wechatwork/new", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksNewPost)
 		m.Post("/packagist/new", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksNewPost)
+		m.Post("/custom/new", web.Bind(forms.NewCustomHookForm{}), repo_setting.CustomHooksNewPost)
 	}
 
 	addWebhookEditRoutes := func() {
@@ -437,6 +445,7 @@ func registerWebRoutes(m *web.Router) {
 		m.Post("/feishu/{id}", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksEditPost)
 		m.Post("/wechatwork/{id}", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksEditPost)
 		m.Post("/packagist/{id}", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksEditPost)
+		m.Post("/custom/{id}", web.Bind(forms.NewCustomHookForm{}), repo_setting.CustomHooksEditPost)
 	}
 
 	addSettingsVariablesRoutes := func() {
@@ -456,6 +465,15 @@ func registerWebRoutes(m *web.Router) {
 		})
 	}
 
+	addSettingsRulesetsRoutes := func() {
+		m.Group("/rulesets", func() {
+			m.Get("", repo_setting.Rulesets)
+			m.Combo("/new").Get(repo_setting.RulesetNew).Post(web.Bind(forms.RulesetForm{}), repo_setting.RulesetNewPost)
+			m.Combo("/{id}").Get(repo_setting.RulesetEdit).Post(web.Bind(forms.RulesetForm{}), repo_setting.RulesetEditPost)
+			m.Post("/{id}/delete", repo_setting.RulesetDeletePost)
+		})
+	}
+
 	addSettingsRunnersRoutes := func() {
 		m.Group("/runners", func() {
 			m.Get("", shared_actions.Runners)
@@ -481,6 +499,7 @@ func registerWebRoutes(m *web.Router) {
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		})
 		m.Get("/passkey-endpoints", passkeyEndpoints)
+		m.Get("/terraform.json", packagesEnabled, terraformServices)
 		m.Methods("GET, HEAD", "/*", public.FileHandlerFunc())
 	}, optionsCorsHandler())
 
@@ -489,6 +508,8 @@ func registerWebRoutes(m *web.Router) {
 	m.Get("/-/web-theme/list", misc.WebThemeList)
 	m.Post("/-/web-theme/apply", optSignInIgnoreCsrf, misc.WebThemeApply)
 
+	m.Post("/-/billing/webhook", org.BillingWebhook)
+
 	m.Group("/explore", func() {
 		m.Get("", func(ctx *context.Context) {
 			ctx.Redirect(setting.AppSubURL + "/explore/repos")
@@ -650,10 +671,31 @@ func registerWebRoutes(m *web.Router) {
 					m.Get("/preview", user_setting.PackagesRulePreview)
 				})
 			})
+			m.Group("/remotes", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesRemoteAdd)
+					m.Post("", web.Bind(forms.PackageRemoteForm{}), user_setting.PackagesRemoteAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesRemoteEdit)
+					m.Post("", web.Bind(forms.PackageRemoteForm{}), user_setting.PackagesRemoteEditPost)
+				})
+			})
+			m.Group("/sources", func() {
+				m.Group("/add", func() {
+					m.Get("", user_setting.PackagesVirtualSourceAdd)
+					m.Post("", web.Bind(forms.PackageVirtualSourceForm{}), user_setting.PackagesVirtualSourceAddPost)
+				})
+				m.Group("/{id}", func() {
+					m.Get("", user_setting.PackagesVirtualSourceEdit)
+					m.Post("", web.Bind(forms.PackageVirtualSourceForm{}), user_setting.PackagesVirtualSourceEditPost)
+				})
+			})
 			m.Group("/cargo", func() {
 				m.Post("/initialize", user_setting.InitializeCargoIndex)
 				m.Post("/rebuild", user_setting.RebuildCargoIndex)
 			})
+			m.Post("/nix/regenerate_key", user_setting.RegenerateNixSigningKey)
 			m.Post("/chef/regenerate_keypair", user_setting.RegenerateChefKeyPair)
 		}, packagesEnabled)
 
@@ -683,7 +725,9 @@ func registerWebRoutes(m *web.Router) {
 			m.Get("", user_setting.BlockedUsers)
 			m.Post("", web.Bind(forms.BlockUserForm{}), user_setting.BlockedUsersPost)
 		})
-	}, reqSignIn, ctxDataSet("PageIsUserSettings", true, "EnablePackages", setting.Packages.Enabled, "EnableNotifyMail", setting.Service.EnableNotifyMail))
+
+		m.Get("/storage", quotaEnabled, user_setting.Storage)
+	}, reqSignIn, ctxDataSet("PageIsUserSettings", true, "EnablePackages", setting.Packages.Enabled, "EnableNotifyMail", setting.Service.EnableNotifyMail, "EnableQuota", setting.Quota.Enabled))
 
 	m.Group("/user", func() {
 		m.Get("/activate", auth.Activate)
@@ -701,6 +745,11 @@ func registerWebRoutes(m *web.Router) {
 			m.Get("/{provider}", auth.SignInOAuth)
 			m.Get("/{provider}/callback", auth.SignInOAuthCallback)
 		})
+		m.Group("/saml/{provider}", func() {
+			m.Get("", auth.SignInSAML)
+			m.Get("/metadata", auth.SAMLMetadata)
+			m.Post("/acs", auth.SignInSAMLCallback)
+		})
 	})
 	// ***** END: User *****
 
@@ -792,6 +841,9 @@ func registerWebRoutes(m *web.Router) {
 			m.Combo("/{authid}").Get(admin.EditAuthSource).
 				Post(web.Bind(forms.AuthenticationForm{}), admin.EditAuthSourcePost)
 			m.Post("/{authid}/delete", admin.DeleteAuthSource)
+			m.Post("/{authid}/scim_token", admin.GenerateSCIMToken)
+			m.Post("/{authid}/scim_token/delete", admin.DeleteSCIMToken)
+			m.Post("/{authid}/scim_teams", admin.UpdateSCIMTeamMap)
 		})
 
 		m.Group("/notices", func() {
@@ -800,6 +852,19 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/empty", admin.EmptyNotices)
 		})
 
+		m.Get("/audit", admin.AuditEvents)
+
+		m.Group("/quota", func() {
+			m.Get("", admin.QuotaGroups)
+			m.Post("/new", web.Bind(forms.AdminQuotaGroupForm{}), admin.NewQuotaGroupPost)
+			m.Group("/{id}", func() {
+				m.Combo("").Get(admin.EditQuotaGroup).Post(web.Bind(forms.AdminQuotaGroupForm{}), admin.EditQuotaGroupPost)
+				m.Post("/delete", admin.DeleteQuotaGroup)
+				m.Post("/users/add", admin.AddQuotaGroupUser)
+				m.Post("/users/remove", admin.RemoveQuotaGroupUser)
+			})
+		}, quotaEnabled)
+
 		m.Group("/applications", func() {
 			m.Get("", admin.Applications)
 			m.Post("/oauth2", web.Bind(forms.EditOAuth2ApplicationForm{}), admin.ApplicationsPost)
@@ -815,7 +880,7 @@ func registerWebRoutes(m *web.Router) {
 			addSettingsRunnersRoutes()
 			addSettingsVariablesRoutes()
 		})
-	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled))
+	}, adminReq, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableQuota", setting.Quota.Enabled))
 	// ***** END: Admin *****
 
 	m.Group("", func() {
@@ -950,6 +1015,8 @@ func registerWebRoutes(m *web.Router) {
 					addWebhookEditRoutes()
 				}, webhooksEnabled)
 
+				addSettingsRulesetsRoutes()
+
 				m.Group("/labels", func() {
 					m.Get("", org.RetrieveLabels, org.Labels)
 					m.Post("/new", web.Bind(forms.CreateLabelForm{}), org.NewLabel)
@@ -963,6 +1030,7 @@ func registerWebRoutes(m *web.Router) {
 					addSettingsRunnersRoutes()
 					addSettingsSecretsRoutes()
 					addSettingsVariablesRoutes()
+					m.Combo("/token_permissions").Get(shared_actions.TokenPermissions).Post(shared_actions.TokenPermissionsPost)
 				}, actions.MustEnableActions)
 
 				m.Post("/billing/portal", org.BillingPortal)
@@ -984,17 +1052,41 @@ func registerWebRoutes(m *web.Router) {
 							m.Get("/preview", org.PackagesRulePreview)
 						})
 					})
+					m.Group("/remotes", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesRemoteAdd)
+							m.Post("", web.Bind(forms.PackageRemoteForm{}), org.PackagesRemoteAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesRemoteEdit)
+							m.Post("", web.Bind(forms.PackageRemoteForm{}), org.PackagesRemoteEditPost)
+						})
+					})
+					m.Group("/sources", func() {
+						m.Group("/add", func() {
+							m.Get("", org.PackagesVirtualSourceAdd)
+							m.Post("", web.Bind(forms.PackageVirtualSourceForm{}), org.PackagesVirtualSourceAddPost)
+						})
+						m.Group("/{id}", func() {
+							m.Get("", org.PackagesVirtualSourceEdit)
+							m.Post("", web.Bind(forms.PackageVirtualSourceForm{}), org.PackagesVirtualSourceEditPost)
+						})
+					})
 					m.Group("/cargo", func() {
 						m.Post("/initialize", org.InitializeCargoIndex)
 						m.Post("/rebuild", org.RebuildCargoIndex)
 					})
+					m.Post("/nix/regenerate_key", org.RegenerateNixSigningKey)
 				}, packagesEnabled)
 
 				m.Group("/blocked_users", func() {
 					m.Get("", org.BlockedUsers)
 					m.Post("", web.Bind(forms.BlockUserForm{}), org.BlockedUsersPost)
 				})
-			}, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "PageIsOrgSettings", true))
+
+				m.Get("/audit", org.AuditEvents)
+				m.Get("/storage", quotaEnabled, org.Storage)
+			}, ctxDataSet("EnableOAuth2", setting.OAuth2.Enabled, "EnablePackages", setting.Packages.Enabled, "EnableQuota", setting.Quota.Enabled, "PageIsOrgSettings", true))
 		}, context.OrgAssignment(context.OrgAssignmentOptions{RequireOwner: true}))
 	}, reqSignIn)
 	// end "/org": most org routes
@@ -1108,6 +1200,10 @@ func registerWebRoutes(m *web.Router) {
 			m.Post("/priority", web.Bind(forms.ProtectBranchPriorityForm{}), context.RepoMustNotBeArchived(), repo_setting.UpdateBranchProtectionPriories)
 		})
 
+		m.Group("", func() {
+			addSettingsRulesetsRoutes()
+		}, context.RepoMustNotBeArchived())
+
 		m.Group("/tags", func() {
 			m.Get("", repo_setting.ProtectedTags)
 			m.Post("", web.Bind(forms.ProtectTagForm{}), context.RepoMustNotBeArchived(), repo_setting.NewProtectedTagPost)
@@ -1162,6 +1258,19 @@ func registerWebRoutes(m *web.Router) {
 			addSettingsRunnersRoutes()
 			addSettingsSecretsRoutes()
 			addSettingsVariablesRoutes()
+			m.Combo("/token_permissions").Get(shared_actions.TokenPermissions).Post(shared_actions.TokenPermissionsPost)
+			m.Group("/environments", func() {
+				m.Get("", repo_setting.Environments)
+				m.Combo("/new").Get(repo_setting.EnvironmentNew).Post(web.Bind(forms.EnvironmentForm{}), repo_setting.EnvironmentNewPost)
+				m.Group("/{id}", func() {
+					m.Combo("").Get(repo_setting.EnvironmentEdit).Post(web.Bind(forms.EnvironmentForm{}), repo_setting.EnvironmentEditPost)
+					m.Post("/delete", repo_setting.EnvironmentDeletePost)
+					m.Post("/secrets", web.Bind(forms.AddSecretForm{}), repo_setting.EnvironmentSecretPost)
+					m.Post("/secrets/delete", repo_setting.EnvironmentSecretDeletePost)
+					m.Post("/variables", web.Bind(forms.EditVariableForm{}), repo_setting.EnvironmentVariablePost)
+					m.Post("/variables/delete", repo_setting.EnvironmentVariableDeletePost)
+				})
+			})
 			m.Group("/general", func() {
 				m.Group("/collaborative_owner", func() {
 					m.Post("/add", repo_setting.AddCollaborativeOwner)
@@ -1463,6 +1572,8 @@ func registerWebRoutes(m *web.Router) {
 		m.Post("/run", reqRepoActionsWriter, actions.Run)
 		m.Get("/workflow-dispatch-inputs", reqRepoActionsWriter, actions.WorkflowDispatchInputs)
 		m.Post("/approve-all-checks", reqRepoActionsWriter, actions.ApproveAllChecks)
+		m.Get("/deployments", actions.Deployments)
+		m.Post("/deployments/{id}/review", reqRepoActionsWriter, actions.ReviewDeploymentPost)
 
 		m.Group("/runs/{run}", func() {
 			m.Combo("").
@@ -1489,6 +1600,17 @@ func registerWebRoutes(m *web.Router) {
 	}, optSignIn, context.RepoAssignment, repo.MustBeNotEmpty, reqRepoActionsReader, actions.MustEnableActions)
 	// end "/{username}/{reponame}/actions"
 
+	m.Group("/{username}/{reponame}/terraform", func() {
+		m.Get("", repo.TerraformStates)
+		m.Group("/{name}", func() {
+			m.Get("", repo.TerraformState)
+			m.Post("/unlock", repo.TerraformStateUnlockPost)
+			m.Get("/versions/{id}", repo.TerraformStateVersion)
+			m.Get("/versions/{id}/diff", repo.TerraformStateVersionDiff)
+		})
+	}, reqSignIn, context.RepoAssignment, reqRepoCodeWriter, repo.MustEnableTerraform)
+	// end "/{username}/{reponame}/terraform"
+
 	m.Group("/{username}/{reponame}/wiki", func() {
 		m.Combo("").
 			Get(repo.Wiki).
@@ -1524,6 +1646,10 @@ func registerWebRoutes(m *web.Router) {
 				m.Get("", repo.RecentCommits)
 				m.Get("/data", repo.CodeFrequencyData) // "recent-commits" also uses the same data as "code-frequency"
 			})
+			m.Group("/dependencies", func() {
+				m.Get("", repo.Dependencies)
+				m.Get("/sbom", repo.DependenciesSBOM)
+			})
 		}, reqUnitCodeReader)
 	},
 		optSignIn, context.RepoAssignment, repo.MustBeNotEmpty,
@@ -1545,6 +1671,7 @@ func registerWebRoutes(m *web.Router) {
 			})
 			m.Post("/merge", context.RepoMustNotBeArchived(), web.Bind(forms.MergePullRequestForm{}), repo.MergePullRequest)
 			m.Post("/cancel_auto_merge", context.RepoMustNotBeArchived(), repo.CancelAutoMergePullRequest)
+			m.Post("/cancel_merge_queue", context.RepoMustNotBeArchived(), repo.CancelMergeQueuePullRequest)
 			m.Post("/update", repo.UpdatePullRequest)
 			m.Post("/set_allow_maintainer_edit", web.Bind(forms.UpdateAllowEditsForm{}), repo.SetAllowEdits)
 			m.Post("/cleanup", context.RepoMustNotBeArchived(), repo.CleanUpPullRequest)
@@ -0,0 +1,156 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	perm_model "code.gitea.io/gitea/models/perm"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/oauth2_provider"
+)
+
+// ErrAttestationNotVerified is returned when an attestation fails to verify
+var ErrAttestationNotVerified = errors.New("attestation is not verified")
+
+// IsAttestationAvailable returns whether attestations can be signed, which needs the signing key of the OAuth2 provider
+func IsAttestationAvailable() bool {
+	return setting.OAuth2.Enabled && oauth2_provider.DefaultSigningKey != nil
+}
+
+func attestationSigningKeyID() string {
+	if jwk, err := oauth2_provider.DefaultSigningKey.ToJWK(); err == nil {
+		return jwk["kid"]
+	}
+	return ""
+}
+
+// CreateAttestation signs an in-toto statement uploaded by the job of a running task and stores it as an attestation of its run.
+// The identity of the job (its repository, workflow ref, run and commit) is added to the statement before it is signed,
+// so the signature binds the statement to the job.
+func CreateAttestation(ctx context.Context, task *actions_model.ActionTask, content []byte) (*actions_model.ActionAttestation, error) {
+	if !IsAttestationAvailable() {
+		return nil, util.NewNotExistErrorf("attestations are not available")
+	}
+	if task.Status != actions_model.StatusRunning {
+		return nil, util.NewPermissionDeniedErrorf("task %d is not running", task.ID)
+	}
+	tokenPerms, err := actions_model.GetTaskTokenPermissions(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if tokenPerms.AccessMode(actions_model.TokenScopeAttestations) < perm_model.AccessModeWrite {
+		return nil, util.NewPermissionDeniedErrorf("job %d has no attestations write permission", task.JobID)
+	}
+
+	statement, err := actions_module.ParseInTotoStatement(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+
+	run := task.Job.Run
+	repoName := run.Repo.FullName()
+	payload, err := actions_module.AddAttestationIdentity(content, &actions_module.AttestationIdentity{
+		Repository:  repoName,
+		WorkflowRef: repoName + "/" + run.WorkflowID + "@" + run.Ref,
+		RunID:       run.ID,
+		CommitSHA:   run.CommitSHA,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey := oauth2_provider.DefaultSigningKey
+	sig, err := signingKey.SigningMethod().Sign(actions_module.DSSEPreAuthEncoding(actions_module.InTotoPayloadType, payload), signingKey.SignKey())
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := json.Marshal(&actions_module.DSSEEnvelope{
+		PayloadType: actions_module.InTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []actions_module.DSSESignature{
+			{KeyID: attestationSigningKeyID(), Sig: base64.StdEncoding.EncodeToString(sig)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attestation := &actions_model.ActionAttestation{
+		RepoID:        run.RepoID,
+		OwnerID:       run.OwnerID,
+		RunID:         run.ID,
+		JobID:         task.JobID,
+		CommitSHA:     run.CommitSHA,
+		WorkflowID:    run.WorkflowID,
+		PredicateType: statement.PredicateType,
+		Envelope:      string(envelope),
+	}
+	subjects := make([]*actions_model.ActionAttestationSubject, 0, len(statement.Subject))
+	for _, subject := range statement.Subject {
+		for algorithm, value := range subject.Digest {
+			subjects = append(subjects, &actions_model.ActionAttestationSubject{
+				Digest: actions_module.NormalizeDigest(algorithm + ":" + value),
+				Name:   util.EllipsisDisplayString(subject.Name, 255),
+			})
+		}
+	}
+	if err := actions_model.CreateAttestation(ctx, attestation, subjects); err != nil {
+		return nil, err
+	}
+	return attestation, nil
+}
+
+// VerifyAttestation verifies the signature of an attestation and returns its statement.
+// The signed identity of the statement must match the run and commit of the attestation,
+// and if a digest is given, the statement must describe the artifact with this digest.
+func VerifyAttestation(attestation *actions_model.ActionAttestation, digest string) (*actions_module.InTotoStatement, error) {
+	if !IsAttestationAvailable() {
+		return nil, util.NewNotExistErrorf("attestations are not available")
+	}
+	var envelope actions_module.DSSEEnvelope
+	if err := json.Unmarshal([]byte(attestation.Envelope), &envelope); err != nil {
+		return nil, err
+	}
+	if envelope.PayloadType != actions_module.InTotoPayloadType {
+		return nil, ErrAttestationNotVerified
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, ErrAttestationNotVerified
+	}
+
+	signingKey := oauth2_provider.DefaultSigningKey
+	keyID := attestationSigningKeyID()
+	signingString := actions_module.DSSEPreAuthEncoding(envelope.PayloadType, payload)
+	verified := slices.ContainsFunc(envelope.Signatures, func(s actions_module.DSSESignature) bool {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		return err == nil && s.KeyID == keyID && signingKey.SigningMethod().Verify(signingString, sig, signingKey.VerifyKey()) == nil
+	})
+	if !verified {
+		return nil, ErrAttestationNotVerified
+	}
+
+	statement, err := actions_module.ParseInTotoStatement(payload)
+	if err != nil {
+		return nil, ErrAttestationNotVerified
+	}
+	if statement.Identity == nil || statement.Identity.RunID != attestation.RunID || statement.Identity.CommitSHA != attestation.CommitSHA {
+		return nil, ErrAttestationNotVerified
+	}
+	if digest != "" && !slices.Contains(statement.Digests(), actions_module.NormalizeDigest(digest)) {
+		return nil, ErrAttestationNotVerified
+	}
+	return statement, nil
+}
@@ -57,8 +57,8 @@ func findJobNeedsAndFillJobResults(ctx context.Context, job *actions_model.Actio
 		}
 		jobResults[jobID] = jobResult
 	}
-	jobResults[job.JobID] = &jobparser.JobResult{
-		Needs: job.Needs,
+	jobResults[job.WorkflowJobID()] = &jobparser.JobResult{
+		Needs: job.WorkflowNeeds(),
 	}
 	return jobResults, nil
 }
@@ -95,7 +95,7 @@ func EvaluateJobConcurrencyFillModel(ctx context.Context, run *actions_model.Act
 		return fmt.Errorf("load job %d: %w", actionRunJob.ID, err)
 	}
 
-	actionRunJob.ConcurrencyGroup, actionRunJob.ConcurrencyCancel, err = jobparser.EvaluateConcurrency(&rawConcurrency, actionRunJob.JobID, workflowJob, actionsJobCtx, jobResults, vars, inputs)
+	actionRunJob.ConcurrencyGroup, actionRunJob.ConcurrencyCancel, err = jobparser.EvaluateConcurrency(&rawConcurrency, actionRunJob.WorkflowJobID(), workflowJob, actionsJobCtx, jobResults, vars, inputs)
 	if err != nil {
 		return fmt.Errorf("evaluate concurrency: %w", err)
 	}
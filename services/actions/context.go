@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
@@ -88,7 +89,7 @@ func GenerateGiteaContext(run *actions_model.ActionRun, job *actions_model.Actio
 	}
 
 	if job != nil {
-		gitContext["job"] = job.JobID
+		gitContext["job"] = job.WorkflowJobID()
 		gitContext["run_id"] = strconv.FormatInt(job.RunID, 10)
 		gitContext["run_attempt"] = strconv.FormatInt(job.Attempt, 10)
 	}
@@ -118,6 +119,9 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 		jobIDJobs[job.JobID] = append(jobIDJobs[job.JobID], job)
 	}
 
+	// the needs of a job of a reusable workflow are keyed by the job ids in the called workflow
+	prefix := strings.TrimSuffix(job.JobID, job.WorkflowJobID())
+
 	ret := make(map[string]*TaskNeed, len(needs))
 	for jobID, jobsWithSameID := range jobIDJobs {
 		if !needs.Contains(jobID) {
@@ -125,17 +129,23 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 		}
 		var jobOutputs map[string]string
 		for _, job := range jobsWithSameID {
-			if job.TaskID == 0 || !job.Status.IsDone() {
-				// it shouldn't happen, or the job has been rerun
-				continue
-			}
-			got, err := actions_model.FindTaskOutputByTaskID(ctx, job.TaskID)
-			if err != nil {
-				return nil, fmt.Errorf("FindTaskOutputByTaskID: %w", err)
-			}
-			outputs := make(map[string]string, len(got))
-			for _, v := range got {
-				outputs[v.OutputKey] = v.OutputValue
+			var outputs map[string]string
+			if job.Uses != "" && job.Status.IsDone() {
+				// a job calling a reusable workflow has no task, its outputs are evaluated when the called jobs are done
+				outputs = job.CallOutputs
+			} else {
+				if job.TaskID == 0 || !job.Status.IsDone() {
+					// it shouldn't happen, or the job has been rerun
+					continue
+				}
+				got, err := actions_model.FindTaskOutputByTaskID(ctx, job.TaskID)
+				if err != nil {
+					return nil, fmt.Errorf("FindTaskOutputByTaskID: %w", err)
+				}
+				outputs = make(map[string]string, len(got))
+				for _, v := range got {
+					outputs[v.OutputKey] = v.OutputValue
+				}
 			}
 			if len(jobOutputs) == 0 {
 				jobOutputs = outputs
@@ -143,7 +153,7 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 				jobOutputs = mergeTwoOutputs(outputs, jobOutputs)
 			}
 		}
-		ret[jobID] = &TaskNeed{
+		ret[strings.TrimPrefix(jobID, prefix)] = &TaskNeed{
 			Outputs: jobOutputs,
 			Result:  actions_model.AggregateJobStatus(jobsWithSameID),
 		}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// PrepareToStartJobWithEnvironment checks the protection rules of the deployment environment of a job which is ready to start.
+// It records a deployment for the next attempt of the job and returns:
+//   - StatusWaiting if the job can start now
+//   - StatusBlocked if the deployment waits for a review or for the wait timer of the environment
+//   - StatusFailure if the deployment has been rejected
+func PrepareToStartJobWithEnvironment(ctx context.Context, job *actions_model.ActionRunJob) (actions_model.Status, error) {
+	if job.Environment == "" {
+		return actions_model.StatusWaiting, nil
+	}
+	if err := job.LoadRun(ctx); err != nil {
+		return actions_model.StatusBlocked, err
+	}
+	if job.Run.NeedApproval {
+		return actions_model.StatusBlocked, nil
+	}
+
+	// a runner increases the attempt of the job when it picks it
+	attempt := job.Attempt + 1
+	deployment, err := actions_model.GetDeploymentByJobAttempt(ctx, job.ID, attempt)
+	if err != nil {
+		return actions_model.StatusBlocked, err
+	}
+
+	var env *actions_model.ActionEnvironment
+	if deployment == nil {
+		env, err = actions_model.GetEnvironmentByName(ctx, job.RepoID, job.Environment)
+		if err != nil && !actions_model.IsErrEnvironmentNotExist(err) {
+			return actions_model.StatusBlocked, err
+		}
+
+		// an environment which is not configured has no protection rules, like on GitHub
+		deployment = &actions_model.ActionDeployment{
+			RepoID:        job.RepoID,
+			RunID:         job.RunID,
+			RunJobID:      job.ID,
+			Attempt:       attempt,
+			Environment:   job.Environment,
+			Ref:           job.Run.Ref,
+			CommitSHA:     job.CommitSHA,
+			TriggerUserID: job.Run.TriggerUserID,
+			Status:        actions_model.DeploymentStatusApproved,
+		}
+		if env != nil {
+			deployment.EnvironmentID = env.ID
+			deployment.Environment = env.Name
+			if !env.MatchRef(job.Run.Ref) {
+				deployment.Status = actions_model.DeploymentStatusRejected
+				deployment.ReviewComment = fmt.Sprintf("%s is not allowed to deploy to this environment", git.RefName(job.Run.Ref).ShortName())
+				deployment.ReviewedUnix = timeutil.TimeStampNow()
+			} else if env.RequiresReview() {
+				deployment.Status = actions_model.DeploymentStatusWaiting
+			}
+		}
+		if err := actions_model.CreateDeployment(ctx, deployment); err != nil {
+			return actions_model.StatusBlocked, err
+		}
+	}
+
+	switch deployment.Status {
+	case actions_model.DeploymentStatusRejected:
+		return actions_model.StatusFailure, nil
+	case actions_model.DeploymentStatusWaiting:
+		return actions_model.StatusBlocked, nil
+	}
+
+	if deployment.EnvironmentID == 0 {
+		return actions_model.StatusWaiting, nil
+	}
+	if env == nil {
+		env, err = actions_model.GetEnvironmentByID(ctx, job.RepoID, deployment.EnvironmentID)
+		if err != nil {
+			if actions_model.IsErrEnvironmentNotExist(err) {
+				// the environment has been deleted in the meantime, nothing to wait for
+				return actions_model.StatusWaiting, nil
+			}
+			return actions_model.StatusBlocked, err
+		}
+	}
+	if env.WaitTimer > 0 && deployment.CreatedUnix.AddDuration(env.GetWaitTimer()) > timeutil.TimeStampNow() {
+		return actions_model.StatusBlocked, nil
+	}
+	return actions_model.StatusWaiting, nil
+}
+
+// ReviewDeployment approves or rejects a deployment waiting for a review, the job of the deployment is resolved afterwards
+func ReviewDeployment(ctx context.Context, doer *user_model.User, deployment *actions_model.ActionDeployment, approve bool, comment string) error {
+	if deployment.Status != actions_model.DeploymentStatusWaiting {
+		return util.NewInvalidArgumentErrorf("the deployment has been reviewed already")
+	}
+	if err := deployment.LoadAttributes(ctx); err != nil {
+		return err
+	}
+	if !deployment.IsPending() {
+		return util.NewInvalidArgumentErrorf("the job of the deployment is not waiting anymore")
+	}
+
+	env, err := actions_model.GetEnvironmentByID(ctx, deployment.RepoID, deployment.EnvironmentID)
+	if err != nil {
+		return err
+	}
+	if !env.IsReviewer(ctx, doer.ID) {
+		return util.NewPermissionDeniedErrorf("the user is not a reviewer of the environment")
+	}
+	if env.PreventSelfReview && deployment.TriggerUserID == doer.ID {
+		return util.NewPermissionDeniedErrorf("the user triggered the run and cannot review its deployment")
+	}
+
+	deployment.Status = util.Iif(approve, actions_model.DeploymentStatusApproved, actions_model.DeploymentStatusRejected)
+	deployment.ReviewerID = doer.ID
+	deployment.ReviewComment = comment
+	deployment.ReviewedUnix = timeutil.TimeStampNow()
+	if updated, err := actions_model.UpdateDeploymentReview(ctx, deployment); err != nil {
+		return err
+	} else if !updated {
+		return util.NewInvalidArgumentErrorf("the deployment has been reviewed already")
+	}
+
+	return EmitJobsIfReadyByRun(deployment.RunID)
+}
+
+// DeleteEnvironment deletes a deployment environment of a repository together with its secrets and variables
+func DeleteEnvironment(ctx context.Context, repoID, environmentID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := actions_model.DeleteEnvironment(ctx, repoID, environmentID); err != nil {
+			return err
+		}
+		_, err := db.DeleteByBean(ctx, &secret_model.Secret{RepoID: repoID, EnvironmentID: environmentID})
+		return err
+	})
+}
+
+// ResumeDeploymentsAfterWaitTimer resolves the jobs whose approved deployments wait for the wait timer of their environment
+func ResumeDeploymentsAfterWaitTimer(ctx context.Context) error {
+	runIDs, err := actions_model.GetRunIDsWithApprovedBlockedDeployments(ctx)
+	if err != nil {
+		return fmt.Errorf("GetRunIDsWithApprovedBlockedDeployments: %w", err)
+	}
+	var errs []error
+	for _, runID := range runIDs {
+		if err := EmitJobsIfReadyByRun(runID); err != nil {
+			log.Error("Check jobs of run %d: %v", runID, err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
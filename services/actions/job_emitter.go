@@ -207,11 +207,19 @@ func checkJobsOfRun(ctx context.Context, run *actions_model.ActionRun) (jobs, up
 		return nil, nil, err
 	}
 
+	var callers []*actions_model.ActionRunJob
+	var calledJobsDone bool
 	if err = db.WithTx(ctx, func(ctx context.Context) error {
 		for _, job := range jobs {
 			job.Run = run
 		}
 
+		completedJobs, err := completeReusableWorkflowCalls(ctx, jobs)
+		if err != nil {
+			return err
+		}
+		updatedJobs = append(updatedJobs, completedJobs...)
+
 		updates := newJobStatusResolver(jobs, vars).Resolve(ctx)
 		for _, job := range jobs {
 			if status, ok := updates[job.ID]; ok {
@@ -222,6 +230,16 @@ func checkJobsOfRun(ctx context.Context, run *actions_model.ActionRun) (jobs, up
 					return fmt.Errorf("no affected for updating blocked job %v", job.ID)
 				}
 				updatedJobs = append(updatedJobs, job)
+				if job.Uses != "" && status == actions_model.StatusRunning {
+					callers = append(callers, job)
+				}
+				calledJobsDone = calledJobsDone || (job.CallerJobID != 0 && status.IsDone())
+			}
+		}
+
+		for _, caller := range callers {
+			if err := expandReusableWorkflowJob(ctx, run, caller, vars); err != nil {
+				return fmt.Errorf("expand reusable workflow of job %d: %w", caller.ID, err)
 			}
 		}
 		return nil
@@ -229,6 +247,15 @@ func checkJobsOfRun(ctx context.Context, run *actions_model.ActionRun) (jobs, up
 		return nil, nil, err
 	}
 
+	if len(callers) > 0 || calledJobsDone {
+		// the jobs of the called workflows have been added to the run or have been skipped, their callers have to be checked again
+		js, ujs, err := checkJobsOfRun(ctx, run)
+		if err != nil {
+			return nil, nil, err
+		}
+		return js, append(updatedJobs, ujs...), nil
+	}
+
 	return jobs, updatedJobs, nil
 }
 
@@ -349,6 +376,16 @@ func (r *jobStatusResolver) resolve(ctx context.Context) map[int64]actions_model
 				log.Error("ShouldBlockJobByConcurrency failed, this job will stay blocked: job: %d, err: %v", id, err)
 			}
 		}
+		if newStatus == actions_model.StatusWaiting && actionRunJob.Environment != "" {
+			newStatus, err = PrepareToStartJobWithEnvironment(ctx, actionRunJob)
+			if err != nil {
+				log.Error("PrepareToStartJobWithEnvironment failed, this job will stay blocked: job: %d, err: %v", id, err)
+			}
+		}
+		if newStatus == actions_model.StatusWaiting && actionRunJob.Uses != "" {
+			// a job calling a reusable workflow is not picked by runners, it is running until the called jobs are done
+			newStatus = actions_model.StatusRunning
+		}
 
 		if newStatus != actions_model.StatusBlocked {
 			ret[id] = newStatus
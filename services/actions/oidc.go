@@ -0,0 +1,176 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/perm"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/oauth2_provider"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/nektos/act/pkg/jobparser"
+	"gopkg.in/yaml.v3"
+)
+
+// idTokenExpiration is the lifetime of an OIDC ID token, it only has to be exchanged for the credentials of a cloud provider
+const idTokenExpiration = 5 * time.Minute
+
+// IDTokenClaims are the claims of the OIDC ID token of a job, they follow the claims of GitHub so cloud providers can match them
+// See https://docs.github.com/en/actions/security-for-github-actions/security-hardening-your-deployments/about-security-hardening-with-openid-connect#understanding-the-oidc-token
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Ref                  string `json:"ref"`
+	RefType              string `json:"ref_type"`
+	Sha                  string `json:"sha"`
+	Repository           string `json:"repository"`
+	RepositoryID         string `json:"repository_id"`
+	RepositoryOwner      string `json:"repository_owner"`
+	RepositoryOwnerID    string `json:"repository_owner_id"`
+	RepositoryVisibility string `json:"repository_visibility"`
+	Actor                string `json:"actor"`
+	ActorID              string `json:"actor_id"`
+	Workflow             string `json:"workflow"`
+	WorkflowRef          string `json:"workflow_ref"`
+	EventName            string `json:"event_name"`
+	HeadRef              string `json:"head_ref,omitempty"`
+	BaseRef              string `json:"base_ref,omitempty"`
+	Environment          string `json:"environment,omitempty"`
+	RunID                string `json:"run_id"`
+	RunNumber            string `json:"run_number"`
+	RunAttempt           string `json:"run_attempt"`
+}
+
+// IDTokenIssuer returns the issuer of the OIDC ID tokens of jobs, its discovery document is served below it
+func IDTokenIssuer() string {
+	return setting.AppURL + "api/actions"
+}
+
+// IDTokenRequestURL returns the URL the jobs request their OIDC ID tokens from, the audience is appended as a query parameter by the actions toolkit
+func IDTokenRequestURL() string {
+	return setting.AppURL + "api/actions/_apis/idtoken?api-version=2.0"
+}
+
+// IsIDTokenAvailable returns whether OIDC ID tokens can be signed, which needs an asymmetric signing key of the OAuth2 provider
+func IsIDTokenAvailable() bool {
+	return setting.OAuth2.Enabled && oauth2_provider.DefaultSigningKey != nil && !oauth2_provider.DefaultSigningKey.IsSymmetric()
+}
+
+// CanJobRequestIDToken returns whether a job has been granted `permissions: id-token: write`, by its caller as well for a called workflow.
+// Like the secrets, ID tokens are never issued to the jobs of fork pull requests, except for pull_request_target
+// which runs in the context of the base branch.
+func CanJobRequestIDToken(ctx context.Context, job *actions_model.ActionRunJob) (bool, error) {
+	if err := job.LoadRun(ctx); err != nil {
+		return false, err
+	}
+	if job.Run.IsForkPullRequest && job.Run.TriggerEvent != actions_module.GithubEventPullRequestTarget {
+		return false, nil
+	}
+	return job.TokenPermissions.IDTokenAccessMode() == perm.AccessModeWrite, nil
+}
+
+// CreateIDToken creates a signed OIDC ID token for the job of a running task, which identifies the job to a cloud provider.
+// The audience defaults to the URL of the owner of the repository.
+func CreateIDToken(ctx context.Context, task *actions_model.ActionTask, audience string) (string, error) {
+	if !IsIDTokenAvailable() {
+		return "", util.NewNotExistErrorf("OIDC ID tokens are not available")
+	}
+	if task.Status != actions_model.StatusRunning {
+		return "", util.NewPermissionDeniedErrorf("task %d is not running", task.ID)
+	}
+	if err := task.LoadAttributes(ctx); err != nil {
+		return "", err
+	}
+	if ok, err := CanJobRequestIDToken(ctx, task.Job); err != nil {
+		return "", err
+	} else if !ok {
+		return "", util.NewPermissionDeniedErrorf("job %d has no id-token write permission", task.Job.ID)
+	}
+
+	run := task.Job.Run
+	if err := run.Repo.LoadOwner(ctx); err != nil {
+		return "", err
+	}
+	gitCtx := GenerateGiteaContext(run, task.Job)
+	contextString := func(key string) string {
+		s, _ := gitCtx[key].(string)
+		return s
+	}
+
+	repoName := run.Repo.FullName()
+	var subject string
+	switch {
+	case task.Job.Environment != "":
+		subject = "repo:" + repoName + ":environment:" + task.Job.Environment
+	case run.TriggerEvent == actions_module.GithubEventPullRequest || run.TriggerEvent == actions_module.GithubEventPullRequestTarget:
+		subject = "repo:" + repoName + ":pull_request"
+	default:
+		subject = "repo:" + repoName + ":ref:" + contextString("ref")
+	}
+
+	visibility := "public"
+	if run.Repo.IsPrivate {
+		visibility = "private"
+	} else if !run.Repo.Owner.Visibility.IsPublic() {
+		visibility = "internal"
+	}
+
+	now := time.Now()
+	claims := &IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    IDTokenIssuer(),
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{util.IfZero(audience, run.Repo.Owner.HTMLURL(ctx))},
+			ExpiresAt: jwt.NewNumericDate(now.Add(idTokenExpiration)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.New().String(),
+		},
+		Ref:                  contextString("ref"),
+		RefType:              contextString("ref_type"),
+		Sha:                  contextString("sha"),
+		Repository:           repoName,
+		RepositoryID:         strconv.FormatInt(run.Repo.ID, 10),
+		RepositoryOwner:      run.Repo.OwnerName,
+		RepositoryOwnerID:    strconv.FormatInt(run.Repo.OwnerID, 10),
+		RepositoryVisibility: visibility,
+		Actor:                run.TriggerUser.Name,
+		ActorID:              strconv.FormatInt(run.TriggerUserID, 10),
+		Workflow:             run.WorkflowID,
+		WorkflowRef:          repoName + "/" + run.WorkflowID + "@" + contextString("ref"),
+		EventName:            run.TriggerEvent,
+		HeadRef:              contextString("head_ref"),
+		BaseRef:              contextString("base_ref"),
+		Environment:          task.Job.Environment,
+		RunID:                strconv.FormatInt(run.ID, 10),
+		RunNumber:            strconv.FormatInt(run.Index, 10),
+		RunAttempt:           strconv.FormatInt(task.Job.Attempt, 10),
+	}
+
+	signingKey := oauth2_provider.DefaultSigningKey
+	token := jwt.NewWithClaims(signingKey.SigningMethod(), claims)
+	signingKey.PreProcessToken(token)
+	return token.SignedString(signingKey.SignKey())
+}
+
+// addIDTokenRequestEnv adds the environment variables which the actions toolkit reads to request an OIDC ID token to the workflow payload of a task
+func addIDTokenRequestEnv(payload []byte, requestToken string) ([]byte, error) {
+	var workflow jobparser.SingleWorkflow
+	if err := yaml.Unmarshal(payload, &workflow); err != nil {
+		return nil, err
+	}
+	if workflow.Env == nil {
+		workflow.Env = make(map[string]string, 2)
+	}
+	workflow.Env["ACTIONS_ID_TOKEN_REQUEST_URL"] = IDTokenRequestURL()
+	workflow.Env["ACTIONS_ID_TOKEN_REQUEST_TOKEN"] = requestToken
+	return workflow.Marshal()
+}
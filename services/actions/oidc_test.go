@@ -0,0 +1,68 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/jobparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanJobRequestIDToken(t *testing.T) {
+	getPermissions := func(t *testing.T, permissions string) actions_model.TokenPermissions {
+		workflows, err := jobparser.Parse([]byte(`
+name: deploy
+on: push
+jobs:
+  deploy:
+    runs-on: ubuntu-latest` + permissions + `
+    steps:
+      - run: echo deploy
+`))
+		require.NoError(t, err)
+		require.Len(t, workflows, 1)
+		return getJobTokenPermissions(workflows[0])
+	}
+	const idTokenWrite = `
+    permissions:
+      id-token: write`
+	const contentsWrite = `
+    permissions:
+      contents: write`
+
+	cases := []struct {
+		name              string
+		permissions       string
+		callerPermissions *string
+		run               *actions_model.ActionRun
+		expected          bool
+	}{
+		{"push", idTokenWrite, nil, &actions_model.ActionRun{ID: 1, TriggerEvent: actions_module.GithubEventPush}, true},
+		{"no permission", "", nil, &actions_model.ActionRun{ID: 1, TriggerEvent: actions_module.GithubEventPush}, false},
+		{"pull request", idTokenWrite, nil, &actions_model.ActionRun{ID: 1, TriggerEvent: actions_module.GithubEventPullRequest}, true},
+		{"fork pull request", idTokenWrite, nil, &actions_model.ActionRun{ID: 1, TriggerEvent: actions_module.GithubEventPullRequest, IsForkPullRequest: true}, false},
+		{"fork pull request target", idTokenWrite, nil, &actions_model.ActionRun{ID: 1, TriggerEvent: actions_module.GithubEventPullRequestTarget, IsForkPullRequest: true}, true},
+		{"called by granting caller", idTokenWrite, util.ToPointer(idTokenWrite), &actions_model.ActionRun{ID: 1, TriggerEvent: actions_module.GithubEventPush}, true},
+		{"called by caller without permissions", idTokenWrite, util.ToPointer(""), &actions_model.ActionRun{ID: 1, TriggerEvent: actions_module.GithubEventPush}, false},
+		{"called by caller without id-token", idTokenWrite, util.ToPointer(contentsWrite), &actions_model.ActionRun{ID: 1, TriggerEvent: actions_module.GithubEventPush}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			perms := getPermissions(t, c.permissions)
+			if c.callerPermissions != nil {
+				perms = perms.ClampByCaller(getPermissions(t, *c.callerPermissions))
+			}
+			job := &actions_model.ActionRunJob{RunID: c.run.ID, Run: c.run, TokenPermissions: perms}
+			ok, err := CanJobRequestIDToken(t.Context(), job)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, ok)
+		})
+	}
+}
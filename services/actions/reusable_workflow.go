@@ -0,0 +1,388 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/nektos/act/pkg/exprparser"
+	"github.com/nektos/act/pkg/jobparser"
+	act_model "github.com/nektos/act/pkg/model"
+	"gopkg.in/yaml.v3"
+	"xorm.io/builder"
+)
+
+// maxReusableWorkflowDepth is the maximum number of nested workflows, the top level workflow included
+// See https://docs.github.com/en/actions/sharing-automations/reusing-workflows#nesting-reusable-workflows
+const maxReusableWorkflowDepth = 4
+
+// expandReusableWorkflowJob adds the jobs of the workflow called by a job to its run.
+// The called jobs are blocked until the job emitter resolves them, and the caller is running until they are done.
+// If the workflow cannot be called, the caller fails.
+func expandReusableWorkflowJob(ctx context.Context, run *actions_model.ActionRun, caller *actions_model.ActionRunJob, vars map[string]string) error {
+	calledJobs, outputs, err := prepareReusableWorkflowJobs(ctx, run, caller, vars)
+	if err != nil {
+		if !errors.Is(err, util.ErrInvalidArgument) && !errors.Is(err, util.ErrNotExist) && !errors.Is(err, util.ErrPermissionDenied) {
+			return err
+		}
+		log.Warn("Job %d of run %d cannot call reusable workflow %q: %v", caller.ID, run.ID, caller.Uses, err)
+		caller.Status = actions_model.StatusFailure
+		caller.Started = timeutil.TimeStampNow()
+		caller.Stopped = caller.Started
+		_, err := actions_model.UpdateRunJob(ctx, caller, nil, "status", "started", "stopped")
+		return err
+	}
+
+	if err := deleteCalledJobs(ctx, caller); err != nil {
+		return err
+	}
+	for _, job := range calledJobs {
+		if err := db.Insert(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	caller.Status = actions_model.StatusRunning
+	caller.Started = timeutil.TimeStampNow()
+	caller.CallOutputs = outputs
+	_, err = actions_model.UpdateRunJob(ctx, caller, nil, "status", "started", "call_outputs")
+	return err
+}
+
+// prepareReusableWorkflowJobs reads the workflow called by a job and returns its jobs and the expressions of its outputs
+func prepareReusableWorkflowJobs(ctx context.Context, run *actions_model.ActionRun, caller *actions_model.ActionRunJob, vars map[string]string) ([]*actions_model.ActionRunJob, map[string]string, error) {
+	if strings.Count(caller.JobID, "/") >= maxReusableWorkflowDepth-1 {
+		return nil, nil, util.NewInvalidArgumentErrorf("reusable workflows are nested more than %d levels", maxReusableWorkflowDepth)
+	}
+	wf, err := actions_module.ParseReusableWorkflowUses(caller.Uses)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := readReusableWorkflow(ctx, run, wf)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, err := actions_module.ReadWorkflowCallConfig(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job, err := caller.ParseJob()
+	if err != nil {
+		return nil, nil, err
+	}
+	with, err := evaluateWorkflowCallWith(ctx, run, caller, job, vars)
+	if err != nil {
+		return nil, nil, util.NewInvalidArgumentErrorf("evaluate with: %v", err)
+	}
+	inputs, err := actions_module.GetWorkflowCallInputs(config, with)
+	if err != nil {
+		return nil, nil, err
+	}
+	if content, err = actions_module.SubstituteWorkflowCallInputs(content, inputs); err != nil {
+		return nil, nil, util.NewInvalidArgumentErrorf("substitute inputs: %v", err)
+	}
+
+	giteaCtx := GenerateGiteaContext(run, nil)
+	workflows, err := jobparser.Parse(content, jobparser.WithVars(vars), jobparser.WithGitContext(giteaCtx.ToGitHubContext()), jobparser.WithInputs(inputs))
+	if err != nil {
+		return nil, nil, util.NewInvalidArgumentErrorf("parse workflow: %v", err)
+	}
+	environments, err := actions_module.GetJobEnvironmentsFromContent(content)
+	if err != nil {
+		return nil, nil, util.NewInvalidArgumentErrorf("read environments: %v", err)
+	}
+
+	prefix := caller.JobID + "/"
+	calledJobs := make([]*actions_model.ActionRunJob, 0, len(workflows))
+	for _, v := range workflows {
+		id, job := v.Job()
+		needs := job.Needs()
+		for i := range needs {
+			needs[i] = prefix + needs[i]
+		}
+		if err := v.SetJob(id, job.EraseNeeds()); err != nil {
+			return nil, nil, err
+		}
+		payload, err := v.Marshal()
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshal job %s: %w", id, err)
+		}
+
+		calledJob := &actions_model.ActionRunJob{
+			RunID:             run.ID,
+			RepoID:            run.RepoID,
+			OwnerID:           run.OwnerID,
+			CommitSHA:         run.CommitSHA,
+			IsForkPullRequest: run.IsForkPullRequest,
+			Name:              util.EllipsisDisplayString(caller.Name+" / "+job.Name, 255),
+			WorkflowPayload:   payload,
+			JobID:             prefix + id,
+			Needs:             needs,
+			RunsOn:            job.RunsOn(),
+			Environment:       environments[id],
+			TokenPermissions:  getJobTokenPermissions(v).ClampByCaller(caller.TokenPermissions), // a called workflow can only reduce the permissions of its caller
+			Uses:              job.Uses,
+			CallerJobID:       caller.ID,
+			Status:            actions_model.StatusBlocked,
+		}
+		if job.RawConcurrency != nil {
+			rawConcurrency, err := yaml.Marshal(job.RawConcurrency)
+			if err != nil {
+				return nil, nil, fmt.Errorf("marshal raw concurrency: %w", err)
+			}
+			calledJob.RawConcurrency = string(rawConcurrency)
+		}
+		calledJobs = append(calledJobs, calledJob)
+	}
+
+	outputs := make(map[string]string, len(config.Outputs))
+	for name, output := range config.Outputs {
+		outputs[name] = output.Value
+	}
+	return calledJobs, outputs, nil
+}
+
+// readReusableWorkflow reads a reusable workflow of the repository of the run or of another accessible repository
+func readReusableWorkflow(ctx context.Context, run *actions_model.ActionRun, wf *actions_module.ReusableWorkflow) ([]byte, error) {
+	if err := run.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	repo, ref := run.Repo, run.CommitSHA
+	if !wf.IsLocal() {
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, wf.OwnerName, wf.RepoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, util.NewNotExistErrorf("repository %s/%s does not exist", wf.OwnerName, wf.RepoName)
+			}
+			return nil, err
+		}
+		if err := checkReusableWorkflowAccess(ctx, run.Repo, repo); err != nil {
+			return nil, err
+		}
+		ref = wf.Ref
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetCommit(ref)
+	if err != nil {
+		return nil, util.NewNotExistErrorf("ref %q of %s: %v", ref, repo.FullName(), err)
+	}
+	entry, err := commit.GetTreeEntryByPath(wf.Path)
+	if err != nil {
+		return nil, util.NewNotExistErrorf("workflow %q of %s: %v", wf.Path, repo.FullName(), err)
+	}
+	return actions_module.GetContentFromEntry(entry)
+}
+
+// checkReusableWorkflowAccess checks whether the runs of the caller repository can call the workflows of the repository.
+// Like its actions, the workflows of a repository which is not public can only be called by the private repositories
+// of its collaborative owners, even if they belong to the same owner.
+func checkReusableWorkflowAccess(ctx context.Context, callerRepo, repo *repo_model.Repository) error {
+	if repo.ID == callerRepo.ID {
+		return nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, nil)
+	if err != nil {
+		return err
+	}
+	if perm.CanRead(unit.TypeCode) {
+		return nil
+	}
+	if callerRepo.IsPrivate {
+		actionsUnit, err := repo.GetUnit(ctx, unit.TypeActions)
+		if err != nil && !repo_model.IsErrUnitTypeNotExist(err) {
+			return err
+		}
+		if actionsUnit != nil && actionsUnit.ActionsConfig().IsCollaborativeOwner(callerRepo.OwnerID) {
+			return nil
+		}
+	}
+	return util.NewPermissionDeniedErrorf("the workflows of repository %s are not accessible from %s", repo.FullName(), callerRepo.FullName())
+}
+
+// evaluateWorkflowCallWith evaluates the expressions in the `with` of a job calling a reusable workflow
+func evaluateWorkflowCallWith(ctx context.Context, run *actions_model.ActionRun, caller *actions_model.ActionRunJob, job *jobparser.Job, vars map[string]string) (map[string]any, error) {
+	if len(job.With) == 0 {
+		return map[string]any{}, nil
+	}
+
+	actJob := &act_model.Job{Strategy: &act_model.Strategy{RawMatrix: job.Strategy.RawMatrix}}
+	matrix := make(map[string]any)
+	matrixes, err := actJob.GetMatrixes()
+	if err != nil {
+		return nil, err
+	}
+	if len(matrixes) > 0 {
+		matrix = matrixes[0]
+	}
+	jobResults, err := findJobNeedsAndFillJobResults(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := getInputsFromRun(run)
+	if err != nil {
+		return nil, err
+	}
+
+	giteaCtx := GenerateGiteaContext(run, caller)
+	evaluator := jobparser.NewExpressionEvaluator(jobparser.NewInterpeter(caller.WorkflowJobID(), actJob, matrix, giteaCtx.ToGitHubContext(), jobResults, vars, inputs))
+	var node yaml.Node
+	if err := node.Encode(job.With); err != nil {
+		return nil, err
+	}
+	if err := evaluator.EvaluateYamlNode(&node); err != nil {
+		return nil, err
+	}
+	with := make(map[string]any, len(job.With))
+	if err := node.Decode(&with); err != nil {
+		return nil, err
+	}
+	return with, nil
+}
+
+// deleteCalledJobs deletes the jobs added to a run by an earlier attempt of a job calling a reusable workflow
+func deleteCalledJobs(ctx context.Context, caller *actions_model.ActionRunJob) error {
+	jobs, err := db.Find[actions_model.ActionRunJob](ctx, actions_model.FindRunJobOptions{RunID: caller.RunID})
+	if err != nil {
+		return err
+	}
+	called := map[int64]bool{caller.ID: true}
+	var jobIDs []int64
+	// the called jobs are inserted after their callers, so the nested ones are found in a single pass
+	for _, job := range jobs {
+		if called[job.CallerJobID] {
+			called[job.ID] = true
+			jobIDs = append(jobIDs, job.ID)
+		}
+	}
+	if len(jobIDs) == 0 {
+		return nil
+	}
+
+	var tasks actions_model.TaskList
+	if err := db.GetEngine(ctx).Where("repo_id = ?", caller.RepoID).In("job_id", jobIDs).Find(&tasks); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if err := db.DeleteBeans(ctx,
+			&actions_model.ActionTask{RepoID: task.RepoID, ID: task.ID},
+			&actions_model.ActionTaskStep{RepoID: task.RepoID, TaskID: task.ID},
+			&actions_model.ActionTaskOutput{TaskID: task.ID},
+		); err != nil {
+			return err
+		}
+		removeTaskLog(ctx, task)
+	}
+	_, err = db.GetEngine(ctx).In("id", jobIDs).Delete(&actions_model.ActionRunJob{})
+	return err
+}
+
+// completeReusableWorkflowCalls finishes the running jobs calling reusable workflows whose called jobs are done,
+// and evaluates the outputs of the called workflows for the jobs needing them.
+func completeReusableWorkflowCalls(ctx context.Context, jobs []*actions_model.ActionRunJob) (updatedJobs []*actions_model.ActionRunJob, _ error) {
+	var callers []*actions_model.ActionRunJob
+	for _, job := range jobs {
+		if job.Uses != "" && job.Status == actions_model.StatusRunning {
+			callers = append(callers, job)
+		}
+	}
+	// the nested callers are completed first, so their callers can be completed in the same pass
+	sort.SliceStable(callers, func(i, j int) bool {
+		return strings.Count(callers[i].JobID, "/") > strings.Count(callers[j].JobID, "/")
+	})
+
+	for _, caller := range callers {
+		var calledJobs []*actions_model.ActionRunJob
+		allDone := true
+		for _, job := range jobs {
+			if job.CallerJobID == caller.ID {
+				calledJobs = append(calledJobs, job)
+				allDone = allDone && job.Status.IsDone()
+			}
+		}
+		if !allDone {
+			continue
+		}
+
+		outputs, err := evaluateWorkflowCallOutputs(ctx, caller, calledJobs)
+		if err != nil {
+			return nil, err
+		}
+		// a called workflow whose jobs have all been skipped has succeeded, as a workflow without jobs
+		status := actions_model.AggregateJobStatus(calledJobs)
+		caller.Status = util.Iif(status.In(actions_model.StatusSkipped, actions_model.StatusUnknown), actions_model.StatusSuccess, status)
+		caller.Stopped = timeutil.TimeStampNow()
+		caller.CallOutputs = outputs
+		if n, err := actions_model.UpdateRunJob(ctx, caller, builder.Eq{"status": actions_model.StatusRunning}, "status", "stopped", "call_outputs"); err != nil {
+			return nil, err
+		} else if n != 1 {
+			return nil, fmt.Errorf("no affected for updating running job %v", caller.ID)
+		}
+		updatedJobs = append(updatedJobs, caller)
+	}
+	return updatedJobs, nil
+}
+
+// evaluateWorkflowCallOutputs evaluates the outputs of a called workflow, which refer to the outputs of its jobs
+func evaluateWorkflowCallOutputs(ctx context.Context, caller *actions_model.ActionRunJob, calledJobs []*actions_model.ActionRunJob) (map[string]string, error) {
+	if len(caller.CallOutputs) == 0 {
+		return caller.CallOutputs, nil
+	}
+
+	jobsCtx := make(map[string]*act_model.WorkflowCallResult, len(calledJobs))
+	for _, job := range calledJobs {
+		var outputs map[string]string
+		if job.Uses != "" {
+			outputs = job.CallOutputs
+		} else if job.TaskID != 0 {
+			got, err := actions_model.FindTaskOutputByTaskID(ctx, job.TaskID)
+			if err != nil {
+				return nil, fmt.Errorf("FindTaskOutputByTaskID: %w", err)
+			}
+			outputs = make(map[string]string, len(got))
+			for _, v := range got {
+				outputs[v.OutputKey] = v.OutputValue
+			}
+		}
+		// the outputs of the jobs of a matrix are merged
+		id := job.WorkflowJobID()
+		if result, ok := jobsCtx[id]; ok {
+			if len(outputs) == 0 {
+				outputs = result.Outputs
+			} else if len(result.Outputs) > 0 {
+				outputs = mergeTwoOutputs(outputs, result.Outputs)
+			}
+		}
+		jobsCtx[id] = &act_model.WorkflowCallResult{Outputs: outputs}
+	}
+
+	evaluator := jobparser.NewExpressionEvaluator(exprparser.NewInterpeter(&exprparser.EvaluationEnvironment{Jobs: &jobsCtx}, exprparser.Config{}))
+	outputs := make(map[string]string, len(caller.CallOutputs))
+	for name, value := range caller.CallOutputs {
+		outputs[name] = evaluator.Interpolate(value)
+	}
+	return outputs, nil
+}
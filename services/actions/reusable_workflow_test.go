@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateWorkflowCallOutputs(t *testing.T) {
+	caller := &actions_model.ActionRunJob{
+		ID:    1,
+		JobID: "deploy",
+		Uses:  "./.gitea/workflows/deploy.yml",
+		CallOutputs: map[string]string{
+			"url":     "${{ jobs.release.outputs.url }}",
+			"summary": "released ${{ jobs.release.outputs.version }}",
+			"missing": "${{ jobs.unknown.outputs.value }}",
+		},
+	}
+	calledJobs := []*actions_model.ActionRunJob{
+		{
+			ID:          2,
+			JobID:       "deploy/release",
+			Uses:        "./.gitea/workflows/release.yml",
+			CallerJobID: 1,
+			Status:      actions_model.StatusSuccess,
+			CallOutputs: map[string]string{"url": "https://example.com", "version": "v1.0.0"},
+		},
+	}
+
+	outputs, err := evaluateWorkflowCallOutputs(t.Context(), caller, calledJobs)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"url":     "https://example.com",
+		"summary": "released v1.0.0",
+		"missing": "",
+	}, outputs)
+}
+
+func TestCheckReusableWorkflowAccess(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	publicRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	privateRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	privateCallerRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 16})
+	otherOwnerRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	assert.NoError(t, checkReusableWorkflowAccess(t.Context(), otherOwnerRepo, publicRepo))
+	assert.NoError(t, checkReusableWorkflowAccess(t.Context(), privateRepo, privateRepo))
+
+	// a private repository has to opt in, even for the repositories of its owner
+	assert.ErrorIs(t, checkReusableWorkflowAccess(t.Context(), publicRepo, privateRepo), util.ErrPermissionDenied)
+	assert.ErrorIs(t, checkReusableWorkflowAccess(t.Context(), privateCallerRepo, privateRepo), util.ErrPermissionDenied)
+
+	require.NoError(t, db.Insert(t.Context(), &repo_model.RepoUnit{
+		RepoID: privateRepo.ID,
+		Type:   unit.TypeActions,
+		Config: &repo_model.ActionsConfig{CollaborativeOwnerIDs: []int64{privateRepo.OwnerID}},
+	}))
+	privateRepo = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 2})
+	assert.NoError(t, checkReusableWorkflowAccess(t.Context(), privateCallerRepo, privateRepo))
+	// the workflows of a private repository are never called by a public one
+	assert.ErrorIs(t, checkReusableWorkflowAccess(t.Context(), publicRepo, privateRepo), util.ErrPermissionDenied)
+	assert.ErrorIs(t, checkReusableWorkflowAccess(t.Context(), otherOwnerRepo, privateRepo), util.ErrPermissionDenied)
+}
@@ -9,6 +9,7 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
+	actions_module "code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 
@@ -52,7 +53,13 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 		run.Title = jobs[0].RunName
 	}
 
-	if err = InsertRun(ctx, run, jobs, vars); err != nil {
+	// jobparser drops the "environment" of the jobs, so read it from the workflow content
+	environments, err := actions_module.GetJobEnvironmentsFromContent(content)
+	if err != nil {
+		return fmt.Errorf("GetJobEnvironmentsFromContent: %w", err)
+	}
+
+	if err = InsertRun(ctx, run, jobs, vars, environments); err != nil {
 		return fmt.Errorf("InsertRun: %w", err)
 	}
 
@@ -64,6 +71,9 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 
 	CreateCommitStatusForRunJobs(ctx, run, allJobs...)
 
+	// a job rejected by its environment is done already, the jobs needing it have to be resolved
+	EmitJobsIfReadyByJobs(allJobs)
+
 	notify_service.WorkflowRunStatusUpdate(ctx, run.Repo, run.TriggerUser, run)
 	for _, job := range allJobs {
 		notify_service.WorkflowJobStatusUpdate(ctx, run.Repo, run.TriggerUser, job, nil)
@@ -74,7 +84,8 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 
 // InsertRun inserts a run
 // The title will be cut off at 255 characters if it's longer than 255 characters.
-func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobparser.SingleWorkflow, vars map[string]string) error {
+// The environments map the job ids to the deployment environments they declare.
+func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobparser.SingleWorkflow, vars map[string]string, environments map[string]string) error {
 	return db.WithTx(ctx, func(ctx context.Context) error {
 		index, err := db.GetNextResourceIndex(ctx, "action_run_index", run.RepoID)
 		if err != nil {
@@ -102,7 +113,8 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 		}
 
 		runJobs := make([]*actions_model.ActionRunJob, 0, len(jobs))
-		var hasWaitingJobs bool
+		var hasWaitingJobs, hasReusableWorkflowCalls bool
+		isRunBlocked := run.NeedApproval || run.Status == actions_model.StatusBlocked
 		for _, v := range jobs {
 			id, job := v.Job()
 			needs := job.Needs()
@@ -111,7 +123,9 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 			}
 			payload, _ := v.Marshal()
 
-			shouldBlockJob := len(needs) > 0 || run.NeedApproval || run.Status == actions_model.StatusBlocked
+			// a job calling a reusable workflow is blocked until the job emitter adds the called jobs
+			shouldBlockJob := len(needs) > 0 || run.NeedApproval || run.Status == actions_model.StatusBlocked || job.Uses != ""
+			hasReusableWorkflowCalls = hasReusableWorkflowCalls || job.Uses != ""
 
 			job.Name = util.EllipsisDisplayString(job.Name, 255)
 			runJob := &actions_model.ActionRunJob{
@@ -125,6 +139,8 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 				JobID:             id,
 				Needs:             needs,
 				RunsOn:            job.RunsOn(),
+				Environment:       environments[id],
+				TokenPermissions:  getJobTokenPermissions(v),
 				Status:            util.Iif(shouldBlockJob, actions_model.StatusBlocked, actions_model.StatusWaiting),
 			}
 			// check job concurrency
@@ -153,14 +169,37 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 				}
 			}
 
-			hasWaitingJobs = hasWaitingJobs || runJob.Status == actions_model.StatusWaiting
 			if err := db.Insert(ctx, runJob); err != nil {
 				return err
 			}
 
+			// the protection rules of the environment are checked after inserting the job because its deployment refers to it
+			if runJob.Status == actions_model.StatusWaiting && runJob.Environment != "" {
+				runJob.Run = run
+				runJob.Status, err = PrepareToStartJobWithEnvironment(ctx, runJob)
+				if err != nil {
+					return fmt.Errorf("prepare to start job with environment: %w", err)
+				}
+				if runJob.Status != actions_model.StatusWaiting {
+					if _, err := db.GetEngine(ctx).ID(runJob.ID).Cols("status").Update(runJob); err != nil {
+						return err
+					}
+				}
+			}
+			hasWaitingJobs = hasWaitingJobs || runJob.Status == actions_model.StatusWaiting
+
 			runJobs = append(runJobs, runJob)
 		}
 
+		if hasReusableWorkflowCalls && !isRunBlocked {
+			if _, _, err := checkJobsOfRun(ctx, run); err != nil {
+				return fmt.Errorf("check jobs calling reusable workflows: %w", err)
+			}
+			if runJobs, err = actions_model.GetRunJobsByRunID(ctx, run.ID); err != nil {
+				return err
+			}
+		}
+
 		run.Status = actions_model.AggregateJobStatus(runJobs)
 		if err := actions_model.UpdateRun(ctx, run, "status"); err != nil {
 			return err
@@ -64,9 +64,9 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 			return fmt.Errorf("GetSecretsOfTask: %w", err)
 		}
 
-		vars, err := actions_model.GetVariablesOfRun(ctx, t.Job.Run)
+		vars, err := actions_model.GetVariablesOfJob(ctx, t.Job)
 		if err != nil {
-			return fmt.Errorf("GetVariablesOfRun: %w", err)
+			return fmt.Errorf("GetVariablesOfJob: %w", err)
 		}
 
 		needs, err := findTaskNeeds(ctx, job)
@@ -79,9 +79,26 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 			return fmt.Errorf("generateTaskContext: %w", err)
 		}
 
+		payload := t.Job.WorkflowPayload
+		if IsIDTokenAvailable() {
+			if ok, err := CanJobRequestIDToken(ctx, t.Job); err != nil {
+				return fmt.Errorf("CanJobRequestIDToken: %w", err)
+			} else if ok {
+				requestToken, err := CreateAuthorizationToken(t.ID, t.Job.RunID, t.JobID)
+				if err != nil {
+					return fmt.Errorf("CreateAuthorizationToken: %w", err)
+				}
+				if payload, err = addIDTokenRequestEnv(payload, requestToken); err != nil {
+					return fmt.Errorf("addIDTokenRequestEnv: %w", err)
+				}
+				// the runner masks the secrets in the logs
+				secrets["ACTIONS_ID_TOKEN_REQUEST_TOKEN"] = requestToken
+			}
+		}
+
 		task = &runnerv1.Task{
 			Id:              t.ID,
-			WorkflowPayload: t.Job.WorkflowPayload,
+			WorkflowPayload: payload,
 			Context:         taskContext,
 			Secrets:         secrets,
 			Vars:            vars,
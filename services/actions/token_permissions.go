@@ -0,0 +1,41 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	actions_model "code.gitea.io/gitea/models/actions"
+	perm_model "code.gitea.io/gitea/models/perm"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/log"
+
+	"github.com/nektos/act/pkg/jobparser"
+)
+
+// getJobTokenPermissions returns the permissions of the GITEA_TOKEN and the ID token declared by the job of a single workflow or by the workflow itself.
+// It returns nil if no permissions are declared, invalid permissions grant no access at all.
+func getJobTokenPermissions(workflow *jobparser.SingleWorkflow) actions_model.TokenPermissions {
+	perms, err := actions_module.GetJobPermissions(workflow)
+	if err != nil {
+		log.Warn("Invalid permissions of job: %v", err)
+		return actions_model.TokenPermissions{}
+	}
+	if perms == nil {
+		return nil
+	}
+	tokenPerms := make(actions_model.TokenPermissions, len(actions_model.TokenPermissionScopes))
+	for _, scope := range actions_model.TokenPermissionScopes {
+		switch perms[scope] {
+		case actions_module.PermissionRead:
+			tokenPerms[scope] = perm_model.AccessModeRead
+		case actions_module.PermissionWrite:
+			tokenPerms[scope] = perm_model.AccessModeWrite
+		default:
+			tokenPerms[scope] = perm_model.AccessModeNone
+		}
+	}
+	if perms[actions_module.PermissionIDToken] == actions_module.PermissionWrite {
+		tokenPerms[actions_model.TokenScopeIDToken] = perm_model.AccessModeWrite
+	}
+	return tokenPerms
+}
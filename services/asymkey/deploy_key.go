@@ -47,8 +47,10 @@ func deleteDeployKeyFromDB(ctx context.Context, key *asymkey_model.DeployKey) er
 }
 
 // DeleteDeployKey deletes deploy key from its repository authorized_keys file if needed.
+// It returns the deleted key, nil if the key does not exist.
 // Permissions check should be done outside.
-func DeleteDeployKey(ctx context.Context, repo *repo_model.Repository, id int64) error {
+func DeleteDeployKey(ctx context.Context, repo *repo_model.Repository, id int64) (*asymkey_model.DeployKey, error) {
+	var deleted *asymkey_model.DeployKey
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		key, err := asymkey_model.GetDeployKeyByID(ctx, id)
 		if err != nil {
@@ -62,10 +64,14 @@ func DeleteDeployKey(ctx context.Context, repo *repo_model.Repository, id int64)
 			return fmt.Errorf("deploy key %d does not belong to repository %d", id, repo.ID)
 		}
 
-		return deleteDeployKeyFromDB(ctx, key)
+		if err := deleteDeployKeyFromDB(ctx, key); err != nil {
+			return err
+		}
+		deleted = key
+		return nil
 	}); err != nil {
-		return err
+		return nil, err
 	}
 
-	return RewriteAllPublicKeys(ctx)
+	return deleted, RewriteAllPublicKeys(ctx)
 }
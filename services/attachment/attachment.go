@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"code.gitea.io/gitea/models/db"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
@@ -75,7 +76,16 @@ func uploadAttachment(ctx context.Context, file *UploaderFile, allowedTypes stri
 		return nil, util.ErrorWrap(util.ErrContentTooLarge, "attachment exceeds limit %d", maxFileSize)
 	}
 
-	attach, err := NewAttachment(ctx, attach, io.MultiReader(bytes.NewReader(buf), src), file.size)
+	repo, err := repo_model.GetRepositoryByID(ctx, attach.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	// the size of streamed uploads is unknown, they are rejected once the quota is exceeded
+	if err := quota_model.CheckQuota(ctx, repo.OwnerID, quota_model.CategoryAttachments, max(file.size, 0)); err != nil {
+		return nil, err
+	}
+
+	attach, err = NewAttachment(ctx, attach, io.MultiReader(bytes.NewReader(buf), src), file.size)
 	var maxBytesError *http.MaxBytesError
 	if errors.As(err, &maxBytesError) {
 		return nil, util.ErrorWrap(util.ErrContentTooLarge, "attachment exceeds limit %d", maxFileSize)
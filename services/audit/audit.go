@@ -0,0 +1,118 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// record fills in the actor, the request address and the values of an event, stores it and streams it to the audit logger.
+// Errors are only logged because the recorded change has already been made.
+func record(ctx context.Context, doer *user_model.User, event *audit_model.Event, before, after any) {
+	if doer != nil {
+		event.ActorID = doer.ID
+		event.ActorName = doer.Name
+	}
+	event.IPAddress = remoteAddress(ctx)
+	event.Before = marshalValue(before)
+	event.After = marshalValue(after)
+
+	if err := audit_model.InsertEvent(ctx, event); err != nil {
+		log.Error("InsertEvent [action: %s, target: %s %d]: %v", event.Action, event.TargetType, event.TargetID, err)
+		return
+	}
+
+	if setting.IsAuditLogEnabled() {
+		line, err := json.Marshal(convert.ToAuditEvent(event))
+		if err != nil {
+			log.Error("Marshal audit event %d: %v", event.ID, err)
+			return
+		}
+		log.GetLogger("audit").Log(1, &log.Event{Level: log.INFO}, "%s", line)
+	}
+}
+
+func marshalValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Error("Marshal audit value: %v", err)
+		return ""
+	}
+	return string(data)
+}
+
+// remoteAddress returns the address of the client of the request the change was made by, it is empty outside of requests
+func remoteAddress(ctx context.Context) string {
+	req, _ := ctx.Value(httplib.RequestContextKey).(*http.Request)
+	if req == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		return host
+	}
+	return req.RemoteAddr
+}
+
+func repoEvent(repo *repo_model.Repository, action audit_model.Action, targetType audit_model.TargetType, targetID int64, targetName string) *audit_model.Event {
+	return &audit_model.Event{
+		Action:     action,
+		OwnerID:    repo.OwnerID,
+		RepoID:     repo.ID,
+		RepoName:   repo.FullName(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		TargetName: targetName,
+	}
+}
+
+// UserImpersonation records a site administrator acting as another user
+func UserImpersonation(ctx context.Context, doer, user *user_model.User, req *http.Request) {
+	record(ctx, doer, &audit_model.Event{
+		Action:     audit_model.ActionUserImpersonation,
+		TargetType: audit_model.TargetUser,
+		TargetID:   user.ID,
+		TargetName: user.Name,
+	}, nil, map[string]string{"request": req.Method + " " + req.URL.Path})
+}
+
+func accessTokenValue(token *auth_model.AccessToken) map[string]string {
+	return map[string]string{"name": token.Name, "scope": string(token.Scope)}
+}
+
+// AccessTokenCreate records the creation of an access token of a user
+func AccessTokenCreate(ctx context.Context, doer, owner *user_model.User, token *auth_model.AccessToken) {
+	record(ctx, doer, &audit_model.Event{
+		Action:     audit_model.ActionAccessTokenCreate,
+		OwnerID:    owner.ID,
+		TargetType: audit_model.TargetAccessToken,
+		TargetID:   token.ID,
+		TargetName: token.Name,
+	}, nil, accessTokenValue(token))
+}
+
+// AccessTokenDelete records the deletion of an access token of a user
+func AccessTokenDelete(ctx context.Context, doer, owner *user_model.User, token *auth_model.AccessToken) {
+	record(ctx, doer, &audit_model.Event{
+		Action:     audit_model.ActionAccessTokenDelete,
+		OwnerID:    owner.ID,
+		TargetType: audit_model.TargetAccessToken,
+		TargetID:   token.ID,
+		TargetName: token.Name,
+	}, accessTokenValue(token), nil)
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	audit_model "code.gitea.io/gitea/models/audit"
+	git_model "code.gitea.io/gitea/models/git"
+	"code.gitea.io/gitea/models/perm"
+	repo_model "code.gitea.io/gitea/models/repo"
+	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// RepositoryVisibility records a change of the visibility of a repository
+func RepositoryVisibility(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, wasPrivate bool) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionRepositoryVisibility, audit_model.TargetRepository, repo.ID, repo.FullName()),
+		map[string]bool{"private": wasPrivate}, map[string]bool{"private": repo.IsPrivate})
+}
+
+// RepositoryTransfer records the transfer of a repository, the event is recorded for both the old and the new owner
+func RepositoryTransfer(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, oldOwner *user_model.User) {
+	before := map[string]string{"owner": oldOwner.Name}
+	after := map[string]string{"owner": repo.OwnerName}
+	record(ctx, doer, repoEvent(repo, audit_model.ActionRepositoryTransfer, audit_model.TargetRepository, repo.ID, repo.FullName()), before, after)
+	if oldOwner.ID != repo.OwnerID {
+		event := repoEvent(repo, audit_model.ActionRepositoryTransfer, audit_model.TargetRepository, repo.ID, repo.FullName())
+		event.OwnerID = oldOwner.ID
+		record(ctx, doer, event, before, after)
+	}
+}
+
+// RepositoryDelete records the deletion of a repository
+func RepositoryDelete(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionRepositoryDelete, audit_model.TargetRepository, repo.ID, repo.FullName()), nil, nil)
+}
+
+func accessModeValue(mode perm.AccessMode) map[string]string {
+	return map[string]string{"access_mode": mode.ToString()}
+}
+
+// CollaboratorAdd records the addition of a collaborator to a repository
+func CollaboratorAdd(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionCollaboratorAdd, audit_model.TargetUser, collaborator.ID, collaborator.Name), nil, accessModeValue(mode))
+}
+
+// CollaboratorAccessMode records a change of the access mode of a collaborator of a repository
+func CollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, before, after perm.AccessMode) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionCollaboratorAccessMode, audit_model.TargetUser, collaborator.ID, collaborator.Name), accessModeValue(before), accessModeValue(after))
+}
+
+// CollaboratorRemove records the removal of a collaborator from a repository
+func CollaboratorRemove(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionCollaboratorRemove, audit_model.TargetUser, collaborator.ID, collaborator.Name), nil, nil)
+}
+
+// BranchProtectionCreate records the creation of a branch protection rule
+func BranchProtectionCreate(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionBranchProtectionCreate, audit_model.TargetBranchProtection, rule.ID, rule.RuleName),
+		nil, convert.ToBranchProtection(ctx, rule, repo))
+}
+
+// BranchProtectionUpdate records a change of a branch protection rule, before is the rule as it was before the change
+func BranchProtectionUpdate(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, before *api.BranchProtection, rule *git_model.ProtectedBranch) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionBranchProtectionUpdate, audit_model.TargetBranchProtection, rule.ID, rule.RuleName),
+		before, convert.ToBranchProtection(ctx, rule, repo))
+}
+
+// BranchProtectionDelete records the deletion of a branch protection rule
+func BranchProtectionDelete(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionBranchProtectionDelete, audit_model.TargetBranchProtection, rule.ID, rule.RuleName),
+		convert.ToBranchProtection(ctx, rule, repo), nil)
+}
+
+func deployKeyValue(key *asymkey_model.DeployKey) map[string]any {
+	return map[string]any{"title": key.Name, "fingerprint": key.Fingerprint, "read_only": key.IsReadOnly()}
+}
+
+// DeployKeyAdd records the addition of a deploy key to a repository
+func DeployKeyAdd(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionDeployKeyAdd, audit_model.TargetDeployKey, key.ID, key.Name), nil, deployKeyValue(key))
+}
+
+// DeployKeyDelete records the deletion of a deploy key of a repository
+func DeployKeyDelete(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey) {
+	record(ctx, doer, repoEvent(repo, audit_model.ActionDeployKeyDelete, audit_model.TargetDeployKey, key.ID, key.Name), deployKeyValue(key), nil)
+}
+
+// secretValue describes a secret without its data
+func secretValue(secret *secret_model.Secret) map[string]any {
+	value := map[string]any{"name": secret.Name, "description": secret.Description}
+	if secret.EnvironmentID > 0 {
+		value["environment_id"] = secret.EnvironmentID
+	}
+	return value
+}
+
+func secretEvent(ctx context.Context, secret *secret_model.Secret, action audit_model.Action) *audit_model.Event {
+	if secret.RepoID > 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, secret.RepoID)
+		if err == nil {
+			return repoEvent(repo, action, audit_model.TargetSecret, secret.ID, secret.Name)
+		}
+		log.Error("GetRepositoryByID: %v", err)
+	}
+	return &audit_model.Event{
+		Action:     action,
+		OwnerID:    secret.OwnerID,
+		RepoID:     secret.RepoID,
+		TargetType: audit_model.TargetSecret,
+		TargetID:   secret.ID,
+		TargetName: secret.Name,
+	}
+}
+
+// SecretCreate records the creation of a secret of a user, an organization or a repository
+func SecretCreate(ctx context.Context, doer *user_model.User, secret *secret_model.Secret) {
+	record(ctx, doer, secretEvent(ctx, secret, audit_model.ActionSecretCreate), nil, secretValue(secret))
+}
+
+// SecretUpdate records a change of a secret, the values never contain the data of the secret
+func SecretUpdate(ctx context.Context, doer *user_model.User, before, secret *secret_model.Secret) {
+	record(ctx, doer, secretEvent(ctx, secret, audit_model.ActionSecretUpdate), secretValue(before), secretValue(secret))
+}
+
+// SecretDelete records the deletion of a secret
+func SecretDelete(ctx context.Context, doer *user_model.User, secret *secret_model.Secret) {
+	record(ctx, doer, secretEvent(ctx, secret, audit_model.ActionSecretDelete), secretValue(secret), nil)
+}
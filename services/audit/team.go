@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func teamEvent(team *organization.Team, action audit_model.Action) *audit_model.Event {
+	return &audit_model.Event{
+		Action:     action,
+		OwnerID:    team.OrgID,
+		TargetType: audit_model.TargetTeam,
+		TargetID:   team.ID,
+		TargetName: team.Name,
+	}
+}
+
+// TeamValue returns the permissions of a team as they are recorded, it is used to keep the value of a team before a change
+func TeamValue(ctx context.Context, team *organization.Team) *api.Team {
+	apiTeam, err := convert.ToTeam(ctx, team)
+	if err != nil {
+		log.Error("ToTeam: %v", err)
+	}
+	return apiTeam
+}
+
+// TeamCreate records the creation of a team
+func TeamCreate(ctx context.Context, doer *user_model.User, team *organization.Team) {
+	record(ctx, doer, teamEvent(team, audit_model.ActionTeamCreate), nil, TeamValue(ctx, team))
+}
+
+// TeamUpdate records a change of the permissions of a team, before is the value of the team before the change
+func TeamUpdate(ctx context.Context, doer *user_model.User, before *api.Team, team *organization.Team) {
+	record(ctx, doer, teamEvent(team, audit_model.ActionTeamUpdate), before, TeamValue(ctx, team))
+}
+
+// TeamDelete records the deletion of a team
+func TeamDelete(ctx context.Context, doer *user_model.User, team *organization.Team) {
+	record(ctx, doer, teamEvent(team, audit_model.ActionTeamDelete), TeamValue(ctx, team), nil)
+}
+
+// TeamMemberAdd records the addition of a member to a team
+func TeamMemberAdd(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	record(ctx, doer, teamEvent(team, audit_model.ActionTeamMemberAdd), nil, map[string]string{"member": member.Name})
+}
+
+// TeamMemberRemove records the removal of a member from a team
+func TeamMemberRemove(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	record(ctx, doer, teamEvent(team, audit_model.ActionTeamMemberRemove), map[string]string{"member": member.Name}, nil)
+}
+
+// TeamRepositoryAdd records that a team was given access to a repository
+func TeamRepositoryAdd(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	event := teamEvent(team, audit_model.ActionTeamRepositoryAdd)
+	event.RepoID, event.RepoName = repo.ID, repo.FullName()
+	record(ctx, doer, event, nil, map[string]string{"access_mode": team.AccessMode.ToString()})
+}
+
+// TeamRepositoryRemove records that the access of a team to a repository was removed
+func TeamRepositoryRemove(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	event := teamEvent(team, audit_model.ActionTeamRepositoryRemove)
+	event.RepoID, event.RepoName = repo.ID, repo.FullName()
+	record(ctx, doer, event, map[string]string{"access_mode": team.AccessMode.ToString()}, nil)
+}
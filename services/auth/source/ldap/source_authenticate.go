@@ -94,7 +94,7 @@ func (source *Source) Authenticate(ctx context.Context, user *user_model.User, u
 			IsActive:     optional.Some(true),
 		}
 
-		err := user_model.CreateUser(ctx, user, &user_model.Meta{}, overwriteDefault)
+		err := user_service.CreateUser(ctx, user, user, &user_model.Meta{}, overwriteDefault)
 		if err != nil {
 			return user, err
 		}
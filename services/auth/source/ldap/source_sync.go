@@ -17,6 +17,7 @@ import (
 	"code.gitea.io/gitea/modules/optional"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	source_service "code.gitea.io/gitea/services/auth/source"
+	notify_service "code.gitea.io/gitea/services/notify"
 	user_service "code.gitea.io/gitea/services/user"
 )
 
@@ -128,7 +129,7 @@ func (source *Source) Sync(ctx context.Context, updateExisting bool) error {
 				IsActive:     optional.Some(true),
 			}
 
-			err = user_model.CreateUser(ctx, usr, &user_model.Meta{}, overwriteDefault)
+			err = user_service.CreateUser(ctx, nil, usr, &user_model.Meta{}, overwriteDefault)
 			if err != nil {
 				log.Error("SyncExternalUsers[%s]: Error creating user %s: %v", source.AuthSource.Name, su.Username, err)
 			}
@@ -156,6 +157,7 @@ func (source *Source) Sync(ctx context.Context, updateExisting bool) error {
 				usr.FullName != fullName ||
 				!usr.IsActive {
 				log.Trace("SyncExternalUsers[%s]: Updating user %s", source.AuthSource.Name, usr.Name)
+				wasActive := usr.IsActive
 
 				opts := &user_service.UpdateOptions{
 					FullName: optional.Some(fullName),
@@ -171,6 +173,8 @@ func (source *Source) Sync(ctx context.Context, updateExisting bool) error {
 
 				if err := user_service.UpdateUser(ctx, usr, opts); err != nil {
 					log.Error("SyncExternalUsers[%s]: Error updating user %s: %v", source.AuthSource.Name, usr.Name, err)
+				} else if !wasActive {
+					notify_service.ChangeUserSuspension(ctx, nil, usr, false)
 				}
 
 				if err := user_service.ReplacePrimaryEmailAddress(ctx, usr, su.Mail); err != nil {
@@ -216,12 +220,15 @@ func (source *Source) Sync(ctx context.Context, updateExisting bool) error {
 			}
 
 			log.Trace("SyncExternalUsers[%s]: Deactivating user %s", source.AuthSource.Name, usr.Name)
+			wasActive := usr.IsActive
 
 			opts := &user_service.UpdateOptions{
 				IsActive: optional.Some(false),
 			}
 			if err := user_service.UpdateUser(ctx, usr, opts); err != nil {
 				log.Error("SyncExternalUsers[%s]: Error deactivating user %s: %v", source.AuthSource.Name, usr.Name, err)
+			} else if wasActive {
+				notify_service.ChangeUserSuspension(ctx, nil, usr, true)
 			}
 		}
 	}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"fmt"
+	"time"
+
+	"code.gitea.io/gitea/modules/cache"
+)
+
+// The requests are kept in the cache instead of the session, because the responses of the identity provider
+// are cross-site POST requests, which do not carry the session cookie. The browser which sent a request is
+// recognized by a cookie of its own, see the SAML routes.
+
+// RequestTimeout is how long a user has to sign in at the identity provider
+const RequestTimeout = 10 * time.Minute
+
+func requestCacheKey(sourceID int64, requestID string) string {
+	return fmt.Sprintf("saml_request_%d_%s", sourceID, requestID)
+}
+
+// RememberRequest remembers an authentication request and where to redirect the user to after signing in
+func RememberRequest(sourceID int64, requestID, redirectTo string) error {
+	// the value must not be empty, empty values are treated as missing
+	return cache.GetCache().Put(requestCacheKey(sourceID, requestID), "/"+redirectTo, int64(RequestTimeout.Seconds()))
+}
+
+// ConsumeRequest returns where to redirect the user to for a remembered request, each request can only be answered once
+func ConsumeRequest(sourceID int64, requestID string) (redirectTo string, ok bool) {
+	key := requestCacheKey(sourceID, requestID)
+	v, ok := cache.GetCache().Get(key)
+	if !ok || v == "" {
+		return "", false
+	}
+	_ = cache.GetCache().Delete(key)
+	return v[1:], true
+}
+
+// MarkAssertionUsed returns false if the assertion has been used before, assertions are remembered until they expire
+func MarkAssertionUsed(sourceID int64, a *Assertion, now time.Time) bool {
+	key := fmt.Sprintf("saml_assertion_%d_%s", sourceID, a.ID)
+	if cache.GetCache().IsExist(key) {
+		return false
+	}
+	ttl := max(int64(a.NotOnOrAfter.Add(clockSkew).Sub(now).Seconds()), 1)
+	_ = cache.GetCache().Put(key, "1", ttl)
+	return true
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+)
+
+const bindingHTTPPost = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+type entityDescriptor struct {
+	XMLName         xml.Name        `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string          `xml:"entityID,attr"`
+	SPSSODescriptor spSSODescriptor `xml:"SPSSODescriptor"`
+}
+
+type spSSODescriptor struct {
+	AuthnRequestsSigned        bool                       `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                       `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                     `xml:"protocolSupportEnumeration,attr"`
+	KeyDescriptors             []keyDescriptor            `xml:"KeyDescriptor"`
+	NameIDFormats              []string                   `xml:"NameIDFormat"`
+	AssertionConsumerServices  []assertionConsumerService `xml:"AssertionConsumerService"`
+}
+
+type keyDescriptor struct {
+	Use             string `xml:"use,attr"`
+	X509Certificate string `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo>X509Data>X509Certificate"`
+}
+
+type assertionConsumerService struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+// Metadata returns the metadata of Gitea as service provider, identity providers can be configured with it
+func (source *Source) Metadata() ([]byte, error) {
+	descriptor := &entityDescriptor{
+		EntityID: source.EntityID(),
+		SPSSODescriptor: spSSODescriptor{
+			AuthnRequestsSigned:        source.SignRequests,
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: nsProtocol,
+			AssertionConsumerServices: []assertionConsumerService{{
+				Binding:   bindingHTTPPost,
+				Location:  source.AssertionConsumerServiceURL(),
+				IsDefault: true,
+			}},
+		},
+	}
+	if source.NameIDFormat != "" {
+		descriptor.SPSSODescriptor.NameIDFormats = []string{source.NameIDFormat}
+	}
+	if source.SignRequests {
+		_, cert, err := source.serviceProviderKey()
+		if err != nil {
+			return nil, err
+		}
+		descriptor.SPSSODescriptor.KeyDescriptors = []keyDescriptor{{
+			Use:             "signing",
+			X509Certificate: base64.StdEncoding.EncodeToString(cert.Raw),
+		}}
+	}
+
+	bs, err := xml.MarshalIndent(descriptor, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), bs...), nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthnRequestURL(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://gitea.example.com/")()
+	source := newTestSource(newTestIdentityProvider(t))
+	source.IdentityProviderSSOURL = "https://idp.example.com/sso?tenant=corp"
+	source.SignRequests = true
+	require.NoError(t, source.GenerateServiceProviderKey("gitea.example.com"))
+
+	redirectURL, requestID, err := source.AuthnRequestURL("state")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(redirectURL, "https://idp.example.com/sso?tenant=corp&SAMLRequest="))
+
+	u, err := url.Parse(redirectURL)
+	require.NoError(t, err)
+	query := u.Query()
+	assert.Equal(t, "state", query.Get("RelayState"))
+	assert.Equal(t, dsig.RSASHA256SignatureMethod, query.Get("SigAlg"))
+
+	compressed, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+	require.NoError(t, err)
+	data, err := io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	require.NoError(t, err)
+	request, err := parseXML(data)
+	require.NoError(t, err)
+	assert.True(t, request.Is(nsProtocol, "AuthnRequest"))
+	assert.Equal(t, requestID, request.Attr("ID"))
+	assert.Equal(t, "https://gitea.example.com/user/saml/corp/acs", request.Attr("AssertionConsumerServiceURL"))
+	assert.Equal(t, "https://gitea.example.com/user/saml/corp/metadata", request.Child(nsAssertion, "Issuer").Text())
+
+	// the signature covers the query parameters in their order of the binding
+	signed := u.RawQuery[strings.Index(u.RawQuery, "SAMLRequest="):strings.Index(u.RawQuery, "&Signature=")]
+	sig, err := base64.StdEncoding.DecodeString(query.Get("Signature"))
+	require.NoError(t, err)
+	_, cert, err := source.serviceProviderKey()
+	require.NoError(t, err)
+	hashed := sha256.Sum256([]byte(signed))
+	assert.NoError(t, rsa.VerifyPKCS1v15(cert.PublicKey.(*rsa.PublicKey), crypto.SHA256, hashed[:], sig))
+}
+
+func TestMetadata(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://gitea.example.com/")()
+	source := newTestSource(newTestIdentityProvider(t))
+
+	metadata, err := source.Metadata()
+	require.NoError(t, err)
+	root, err := parseXML(metadata)
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/user/saml/corp/metadata", root.Attr("entityID"))
+	descriptor := root.Child(nsMetadata, "SPSSODescriptor")
+	require.NotNil(t, descriptor)
+	assert.Equal(t, "false", descriptor.Attr("AuthnRequestsSigned"))
+	assert.Empty(t, descriptor.Children(nsMetadata, "KeyDescriptor"))
+	acs := descriptor.Child(nsMetadata, "AssertionConsumerService")
+	require.NotNil(t, acs)
+	assert.Equal(t, bindingHTTPPost, acs.Attr("Binding"))
+	assert.Equal(t, "https://gitea.example.com/user/saml/corp/acs", acs.Attr("Location"))
+
+	source.SignRequests = true
+	require.NoError(t, source.GenerateServiceProviderKey("gitea.example.com"))
+	metadata, err = source.Metadata()
+	require.NoError(t, err)
+	root, err = parseXML(metadata)
+	require.NoError(t, err)
+	descriptor = root.Child(nsMetadata, "SPSSODescriptor")
+	assert.Equal(t, "true", descriptor.Attr("AuthnRequestsSigned"))
+	assert.Len(t, descriptor.Children(nsMetadata, "KeyDescriptor"), 1)
+}
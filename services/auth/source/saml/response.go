@@ -0,0 +1,306 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+
+	"github.com/beevik/etree"
+)
+
+const (
+	statusSuccess            = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	subjectConfirmBearer     = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	nameIDFormatEmailAddress = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+
+	// clockSkew is the tolerated difference between the clocks of the identity provider and Gitea
+	clockSkew = 3 * time.Minute
+)
+
+// Assertion is the validated statement of the identity provider about the signed in user
+type Assertion struct {
+	ID           string
+	InResponseTo string // empty for logins initiated by the identity provider
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	NotOnOrAfter time.Time
+	Attributes   map[string][]string // values by name and by friendly name
+}
+
+// Attribute returns the first value of an attribute
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ParseResponse parses a base64 encoded response of the HTTP-POST binding and validates its signatures and conditions.
+// Whether the response answers a request of Gitea has to be checked by the caller.
+func (source *Source) ParseResponse(encoded string, now time.Time) (*Assertion, error) {
+	data, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAML response encoding: %w", err)
+	}
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+	if !root.Is(nsProtocol, "Response") {
+		return nil, errors.New("document is not a SAML response")
+	}
+	if root.Attr("Version") != "2.0" {
+		return nil, fmt.Errorf("unsupported SAML version %q", root.Attr("Version"))
+	}
+	if destination := root.Attr("Destination"); destination != "" && destination != source.AssertionConsumerServiceURL() {
+		return nil, fmt.Errorf("response is destined for %q", destination)
+	}
+	if err := source.checkIssuer(root); err != nil {
+		return nil, err
+	}
+	if err := checkStatus(root); err != nil {
+		return nil, err
+	}
+
+	// identifiers must be unique, otherwise a signed element could be referenced in place of a forged one
+	ids := container.Set[string]{}
+	duplicated := false
+	root.walk(func(el *xmlElement) {
+		if id := el.Attr("ID"); id != "" && !ids.Add(id) {
+			duplicated = true
+		}
+	})
+	if duplicated {
+		return nil, errors.New("response contains duplicated identifiers")
+	}
+
+	if root.Child(nsAssertion, "EncryptedAssertion") != nil {
+		return nil, errors.New("encrypted assertions are not supported")
+	}
+	if assertions := root.Children(nsAssertion, "Assertion"); len(assertions) != 1 {
+		return nil, fmt.Errorf("response must contain exactly one assertion, found %d", len(assertions))
+	}
+
+	certs, err := source.identityProviderCertificates()
+	if err != nil {
+		return nil, err
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+
+	assertionElements := childElements(doc.Root(), nsAssertion, "Assertion")
+	if len(assertionElements) != 1 {
+		return nil, fmt.Errorf("response must contain exactly one assertion, found %d", len(assertionElements))
+	}
+
+	// from here on, the assertion is only read from the verified copies of the signed elements
+	var assertion *xmlElement
+	signedResponse, err := verifySignature(doc.Root(), certs)
+	if err == nil {
+		assertion = signedResponse.Child(nsAssertion, "Assertion")
+	} else if !errors.Is(err, errNotSigned) {
+		return nil, fmt.Errorf("invalid signature of the response: %w", err)
+	}
+	signedAssertion, err := verifySignature(assertionElements[0], certs)
+	if err == nil {
+		assertion = signedAssertion
+	} else if !errors.Is(err, errNotSigned) {
+		return nil, fmt.Errorf("invalid signature of the assertion: %w", err)
+	}
+	if assertion == nil {
+		return nil, errors.New("neither the response nor the assertion is signed")
+	}
+
+	return source.parseAssertion(assertion, root.Attr("InResponseTo"), now)
+}
+
+func (source *Source) checkIssuer(el *xmlElement) error {
+	issuer := el.Child(nsAssertion, "Issuer")
+	if issuer == nil || source.IdentityProviderEntityID == "" {
+		return nil
+	}
+	if issuer.Text() != source.IdentityProviderEntityID {
+		return fmt.Errorf("unexpected issuer %q", issuer.Text())
+	}
+	return nil
+}
+
+func checkStatus(response *xmlElement) error {
+	status := response.Child(nsProtocol, "Status")
+	if status == nil {
+		return errors.New("response has no status")
+	}
+	code := status.Child(nsProtocol, "StatusCode")
+	if code == nil {
+		return errors.New("response has no status code")
+	}
+	if code.Attr("Value") == statusSuccess {
+		return nil
+	}
+	message := ""
+	if m := status.Child(nsProtocol, "StatusMessage"); m != nil {
+		message = m.Text()
+	}
+	if sub := code.Child(nsProtocol, "StatusCode"); sub != nil {
+		return fmt.Errorf("identity provider responded %s (%s): %s", code.Attr("Value"), sub.Attr("Value"), message)
+	}
+	return fmt.Errorf("identity provider responded %s: %s", code.Attr("Value"), message)
+}
+
+func parseTime(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q", s)
+	}
+	return t, nil
+}
+
+func (source *Source) parseAssertion(el *xmlElement, inResponseTo string, now time.Time) (*Assertion, error) {
+	if el.Child(nsAssertion, "Issuer") == nil {
+		return nil, errors.New("assertion has no issuer")
+	}
+	if err := source.checkIssuer(el); err != nil {
+		return nil, err
+	}
+	a := &Assertion{ID: el.Attr("ID"), InResponseTo: inResponseTo, Attributes: map[string][]string{}}
+
+	subject := el.Child(nsAssertion, "Subject")
+	if subject == nil {
+		return nil, errors.New("assertion has no subject")
+	}
+	nameID := subject.Child(nsAssertion, "NameID")
+	if nameID == nil || nameID.Text() == "" {
+		return nil, errors.New("assertion has no NameID")
+	}
+	a.NameID, a.NameIDFormat = nameID.Text(), nameID.Attr("Format")
+	if err := source.checkSubjectConfirmation(subject, a, now); err != nil {
+		return nil, err
+	}
+
+	conditions := el.Child(nsAssertion, "Conditions")
+	if conditions == nil {
+		return nil, errors.New("assertion has no conditions")
+	}
+	if s := conditions.Attr("NotBefore"); s != "" {
+		notBefore, err := parseTime(s)
+		if err != nil {
+			return nil, err
+		}
+		if now.Add(clockSkew).Before(notBefore) {
+			return nil, errors.New("assertion is not yet valid")
+		}
+	}
+	if s := conditions.Attr("NotOnOrAfter"); s != "" {
+		notOnOrAfter, err := parseTime(s)
+		if err != nil {
+			return nil, err
+		}
+		if !now.Add(-clockSkew).Before(notOnOrAfter) {
+			return nil, errors.New("assertion has expired")
+		}
+		if notOnOrAfter.Before(a.NotOnOrAfter) {
+			a.NotOnOrAfter = notOnOrAfter
+		}
+	}
+	for _, restriction := range conditions.Children(nsAssertion, "AudienceRestriction") {
+		found := false
+		for _, audience := range restriction.Children(nsAssertion, "Audience") {
+			found = found || audience.Text() == source.EntityID()
+		}
+		if !found {
+			return nil, errors.New("assertion is not intended for this service provider")
+		}
+	}
+
+	if statement := el.Child(nsAssertion, "AuthnStatement"); statement != nil {
+		a.SessionIndex = statement.Attr("SessionIndex")
+	}
+	for _, statement := range el.Children(nsAssertion, "AttributeStatement") {
+		for _, attribute := range statement.Children(nsAssertion, "Attribute") {
+			var values []string
+			for _, value := range attribute.Children(nsAssertion, "AttributeValue") {
+				values = append(values, value.Text())
+			}
+			for _, name := range []string{attribute.Attr("Name"), attribute.Attr("FriendlyName")} {
+				if name != "" {
+					a.Attributes[name] = append(a.Attributes[name], values...)
+				}
+			}
+		}
+	}
+	return a, nil
+}
+
+// checkSubjectConfirmation checks that the assertion may be used by the bearer to sign in to this service provider
+func (source *Source) checkSubjectConfirmation(subject *xmlElement, a *Assertion, now time.Time) error {
+	for _, confirmation := range subject.Children(nsAssertion, "SubjectConfirmation") {
+		data := confirmation.Child(nsAssertion, "SubjectConfirmationData")
+		if confirmation.Attr("Method") != subjectConfirmBearer || data == nil {
+			continue
+		}
+		if data.Attr("Recipient") != source.AssertionConsumerServiceURL() {
+			continue
+		}
+		notOnOrAfter, err := parseTime(data.Attr("NotOnOrAfter"))
+		if err != nil || !now.Add(-clockSkew).Before(notOnOrAfter) {
+			continue
+		}
+		if inResponseTo := data.Attr("InResponseTo"); inResponseTo != "" {
+			if a.InResponseTo != "" && a.InResponseTo != inResponseTo {
+				continue
+			}
+			a.InResponseTo = inResponseTo
+		}
+		a.NotOnOrAfter = notOnOrAfter
+		return nil
+	}
+	return errors.New("assertion has no valid bearer subject confirmation for this service provider")
+}
+
+// UserName returns the name of the user, which is the configured attribute or the NameID
+func (source *Source) UserName(a *Assertion) (string, error) {
+	name := a.NameID
+	if source.UsernameAttribute != "" {
+		name = a.Attribute(source.UsernameAttribute)
+	}
+	if name == "" {
+		return "", nil
+	}
+	return user_model.NormalizeUserName(name)
+}
+
+// Email returns the email address of the user, which is the configured attribute or an email address NameID
+func (source *Source) Email(a *Assertion) string {
+	if source.EmailAttribute != "" {
+		return a.Attribute(source.EmailAttribute)
+	}
+	if a.NameIDFormat == nameIDFormatEmailAddress {
+		return a.NameID
+	}
+	return ""
+}
+
+// FullName returns the full name of the user
+func (source *Source) FullName(a *Assertion) string {
+	if source.FullNameAttribute == "" {
+		return ""
+	}
+	return a.Attribute(source.FullNameAttribute)
+}
+
+// Groups returns the groups of the user
+func (source *Source) Groups(a *Assertion) container.Set[string] {
+	if source.GroupAttribute == "" {
+		return nil
+	}
+	return container.SetOf(a.Attributes[source.GroupAttribute]...)
+}
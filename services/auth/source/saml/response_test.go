@@ -0,0 +1,280 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testIdentityProvider struct {
+	key  *rsa.PrivateKey
+	cert string
+}
+
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	s := &Source{}
+	require.NoError(t, s.GenerateServiceProviderKey("idp.example.com"))
+	key, _, err := s.serviceProviderKey()
+	require.NoError(t, err)
+	return &testIdentityProvider{key: key.(*rsa.PrivateKey), cert: s.ServiceProviderCertificate}
+}
+
+// GetKeyPair implements dsig.X509KeyStore
+func (idp *testIdentityProvider) GetKeyPair() (*rsa.PrivateKey, []byte, error) {
+	block, _ := pem.Decode([]byte(idp.cert))
+	return idp.key, block.Bytes, nil
+}
+
+// sign adds an enveloped signature to the element of a document with the ID
+func (idp *testIdentityProvider) sign(t *testing.T, doc, id string) string {
+	d := etree.NewDocument()
+	require.NoError(t, d.ReadFromString(doc))
+	el := d.FindElement("//[@ID='" + id + "']")
+	require.NotNil(t, el)
+
+	nsCtx, err := etreeutils.NSBuildParentContext(el)
+	require.NoError(t, err)
+	detached, err := etreeutils.NSDetatch(nsCtx, el)
+	require.NoError(t, err)
+	signingCtx := dsig.NewDefaultSigningContext(idp)
+	signingCtx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signed, err := signingCtx.SignEnveloped(detached)
+	require.NoError(t, err)
+
+	if parent := el.Parent(); parent != nil {
+		parent.InsertChildAt(el.Index(), signed)
+		parent.RemoveChild(el)
+	} else {
+		d.SetRoot(signed)
+	}
+	signedDoc, err := d.WriteToString()
+	require.NoError(t, err)
+	return signedDoc
+}
+
+const testResponse = `<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_response" Version="2.0" IssueInstant="2026-01-01T12:00:00Z" Destination="https://gitea.example.com/user/saml/corp/acs" InResponseTo="_request">
+  <saml:Issuer>https://idp.example.com</saml:Issuer>
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>
+  <saml:Assertion ID="_assertion" Version="2.0" IssueInstant="2026-01-01T12:00:00Z">
+    <saml:Issuer>https://idp.example.com</saml:Issuer>
+    <saml:Subject>
+      <saml:NameID Format="urn:oasis:names:tc:SAML:2.0:nameid-format:persistent">a1b2c3</saml:NameID>
+      <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+        <saml:SubjectConfirmationData NotOnOrAfter="2026-01-01T12:05:00Z" Recipient="https://gitea.example.com/user/saml/corp/acs" InResponseTo="_request"/>
+      </saml:SubjectConfirmation>
+    </saml:Subject>
+    <saml:Conditions NotBefore="2026-01-01T11:59:00Z" NotOnOrAfter="2026-01-01T12:10:00Z">
+      <saml:AudienceRestriction><saml:Audience>https://gitea.example.com/user/saml/corp/metadata</saml:Audience></saml:AudienceRestriction>
+    </saml:Conditions>
+    <saml:AuthnStatement AuthnInstant="2026-01-01T12:00:00Z" SessionIndex="_session"/>
+    <saml:AttributeStatement>
+      <saml:Attribute Name="urn:oid:0.9.2342.19200300.100.1.1" FriendlyName="uid"><saml:AttributeValue>jdoe</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="email"><saml:AttributeValue>jdoe@example.com</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="displayName"><saml:AttributeValue>Jane Doe</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="groups"><saml:AttributeValue>developers</saml:AttributeValue><saml:AttributeValue>admins</saml:AttributeValue></saml:Attribute>
+    </saml:AttributeStatement>
+  </saml:Assertion>
+</samlp:Response>`
+
+var testNow = time.Date(2026, 1, 1, 12, 1, 0, 0, time.UTC)
+
+func newTestSource(idp *testIdentityProvider) *Source {
+	source := &Source{
+		IdentityProviderEntityID:    "https://idp.example.com",
+		IdentityProviderSSOURL:      "https://idp.example.com/sso",
+		IdentityProviderCertificate: idp.cert,
+		UsernameAttribute:           "uid",
+		EmailAttribute:              "email",
+		FullNameAttribute:           "displayName",
+		GroupAttribute:              "groups",
+	}
+	source.SetAuthSource(&auth.Source{Name: "corp"})
+	return source
+}
+
+func encodeResponse(doc string) string {
+	return base64.StdEncoding.EncodeToString([]byte(doc))
+}
+
+func TestParseResponse(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://gitea.example.com/")()
+	idp := newTestIdentityProvider(t)
+	source := newTestSource(idp)
+
+	t.Run("SignedAssertion", func(t *testing.T) {
+		doc := idp.sign(t, testResponse, "_assertion")
+		a, err := source.ParseResponse(encodeResponse(doc), testNow)
+		require.NoError(t, err)
+		assert.Equal(t, "_assertion", a.ID)
+		assert.Equal(t, "_request", a.InResponseTo)
+		assert.Equal(t, "a1b2c3", a.NameID)
+		assert.Equal(t, "_session", a.SessionIndex)
+		assert.Equal(t, time.Date(2026, 1, 1, 12, 5, 0, 0, time.UTC), a.NotOnOrAfter)
+
+		name, err := source.UserName(a)
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe", name)
+		assert.Equal(t, "jdoe@example.com", source.Email(a))
+		assert.Equal(t, "Jane Doe", source.FullName(a))
+		assert.ElementsMatch(t, []string{"developers", "admins"}, source.Groups(a).Values())
+	})
+
+	t.Run("SignedResponseAndAssertion", func(t *testing.T) {
+		doc := idp.sign(t, idp.sign(t, testResponse, "_assertion"), "_response")
+		_, err := source.ParseResponse(encodeResponse(doc), testNow)
+		require.NoError(t, err)
+	})
+
+	t.Run("SignedResponse", func(t *testing.T) {
+		doc := idp.sign(t, testResponse, "_response")
+		_, err := source.ParseResponse(encodeResponse(doc), testNow)
+		require.NoError(t, err)
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		_, err := source.ParseResponse(encodeResponse(testResponse), testNow)
+		assert.ErrorContains(t, err, "neither the response nor the assertion is signed")
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		doc := idp.sign(t, testResponse, "_assertion")
+		doc = strings.Replace(doc, "a1b2c3", "admin", 1)
+		_, err := source.ParseResponse(encodeResponse(doc), testNow)
+		assert.ErrorContains(t, err, "invalid signature of the assertion")
+	})
+
+	t.Run("OtherKey", func(t *testing.T) {
+		doc := newTestIdentityProvider(t).sign(t, testResponse, "_assertion")
+		_, err := source.ParseResponse(encodeResponse(doc), testNow)
+		assert.ErrorContains(t, err, "not valid for a certificate of the identity provider")
+	})
+
+	t.Run("WrappedAssertion", func(t *testing.T) {
+		// a forged assertion is added next to the signed one, which keeps its valid signature
+		doc := idp.sign(t, testResponse, "_assertion")
+		start := strings.Index(doc, "<saml:Assertion ")
+		end := strings.Index(doc, "</saml:Assertion>") + len("</saml:Assertion>")
+		forged := strings.Replace(doc[start:end], "a1b2c3", "admin", 1)
+		_, err := source.ParseResponse(encodeResponse(doc[:start]+forged+doc[start:]), testNow)
+		assert.ErrorContains(t, err, "duplicated identifiers")
+
+		forged = strings.Replace(forged, `ID="_assertion"`, `ID="_forged"`, 1)
+		_, err = source.ParseResponse(encodeResponse(doc[:start]+forged+doc[start:]), testNow)
+		assert.ErrorContains(t, err, "exactly one assertion")
+	})
+
+	t.Run("WrappedSignedElement", func(t *testing.T) {
+		// the signed assertion is moved to the extensions, a forged one takes its place
+		doc := idp.sign(t, testResponse, "_assertion")
+		start := strings.Index(doc, "<saml:Assertion ")
+		end := strings.Index(doc, "</saml:Assertion>") + len("</saml:Assertion>")
+		signed := doc[start:end]
+		forged := testResponse[strings.Index(testResponse, "<saml:Assertion ") : strings.Index(testResponse, "</saml:Assertion>")+len("</saml:Assertion>")]
+		forged = strings.Replace(forged, "a1b2c3", "admin", 1)
+		forged = strings.Replace(forged, `ID="_assertion"`, `xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_forged"`, 1)
+		wrapped := doc[:start] + "<samlp:Extensions>" + signed + "</samlp:Extensions>" + forged + doc[end:]
+		_, err := source.ParseResponse(encodeResponse(wrapped), testNow)
+		assert.ErrorContains(t, err, "neither the response nor the assertion is signed")
+
+		// the signature of the response doesn't cover a forged assertion which is added afterwards
+		doc = idp.sign(t, testResponse, "_response")
+		start = strings.Index(doc, "<saml:Assertion ")
+		end = strings.Index(doc, "</saml:Assertion>") + len("</saml:Assertion>")
+		wrapped = doc[:start] + "<samlp:Extensions>" + doc[start:end] + "</samlp:Extensions>" + forged + doc[end:]
+		_, err = source.ParseResponse(encodeResponse(wrapped), testNow)
+		assert.ErrorContains(t, err, "invalid signature of the response")
+
+		// the signature of the assertion is copied into the forged assertion
+		doc = idp.sign(t, testResponse, "_assertion")
+		start = strings.Index(doc, "<saml:Assertion ")
+		end = strings.Index(doc, "</saml:Assertion>") + len("</saml:Assertion>")
+		signature := doc[strings.Index(doc, "<ds:Signature") : strings.Index(doc, "</ds:Signature>")+len("</ds:Signature>")]
+		forged = strings.Replace(doc[start:end], "a1b2c3", "admin", 1)
+		forged = strings.Replace(forged, signature, "", 1)
+		forged = strings.Replace(forged, "</saml:Assertion>", signature+"</saml:Assertion>", 1)
+		_, err = source.ParseResponse(encodeResponse(doc[:start]+forged+doc[end:]), testNow)
+		assert.ErrorContains(t, err, "invalid signature of the assertion")
+	})
+
+	t.Run("CommentInjection", func(t *testing.T) {
+		// comments are not covered by the signature, they must not truncate the signed text
+		doc := idp.sign(t, strings.Replace(testResponse, "a1b2c3", "jdoe@example.com.evil.example.com", 1), "_assertion")
+		doc = strings.Replace(doc, "jdoe@example.com.evil.example.com", "jdoe@example.com<!---->.evil.example.com", 1)
+		a, err := source.ParseResponse(encodeResponse(doc), testNow)
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe@example.com.evil.example.com", a.NameID)
+
+		doc = strings.Replace(doc, "<!---->.evil.example.com", "<!---->", 1)
+		_, err = source.ParseResponse(encodeResponse(doc), testNow)
+		assert.ErrorContains(t, err, "invalid signature of the assertion")
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		doc := idp.sign(t, testResponse, "_assertion")
+		_, err := source.ParseResponse(encodeResponse(doc), testNow.Add(time.Hour))
+		assert.ErrorContains(t, err, "no valid bearer subject confirmation")
+	})
+
+	t.Run("NotYetValid", func(t *testing.T) {
+		doc := idp.sign(t, testResponse, "_assertion")
+		_, err := source.ParseResponse(encodeResponse(doc), testNow.Add(-time.Hour))
+		assert.ErrorContains(t, err, "not yet valid")
+	})
+
+	t.Run("OtherAudience", func(t *testing.T) {
+		doc := strings.Replace(testResponse, "/user/saml/corp/metadata", "/user/saml/other/metadata", 1)
+		doc = idp.sign(t, doc, "_assertion")
+		_, err := source.ParseResponse(encodeResponse(doc), testNow)
+		assert.ErrorContains(t, err, "not intended for this service provider")
+	})
+
+	t.Run("OtherIssuer", func(t *testing.T) {
+		doc := idp.sign(t, strings.ReplaceAll(testResponse, "https://idp.example.com<", "https://evil.example.com<"), "_assertion")
+		_, err := source.ParseResponse(encodeResponse(doc), testNow)
+		assert.ErrorContains(t, err, "unexpected issuer")
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		doc := strings.Replace(testResponse, "status:Success", "status:Responder", 1)
+		_, err := source.ParseResponse(encodeResponse(doc), testNow)
+		assert.ErrorContains(t, err, "identity provider responded")
+	})
+
+	t.Run("IdPInitiated", func(t *testing.T) {
+		doc := strings.ReplaceAll(testResponse, ` InResponseTo="_request"`, "")
+		doc = idp.sign(t, doc, "_assertion")
+		a, err := source.ParseResponse(encodeResponse(doc), testNow)
+		require.NoError(t, err)
+		assert.Empty(t, a.InResponseTo)
+	})
+}
+
+func TestIdentityProviderCertificates(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	block, _ := pem.Decode([]byte(idp.cert))
+
+	for _, cert := range []string{idp.cert, base64.StdEncoding.EncodeToString(block.Bytes)} {
+		certs, err := (&Source{IdentityProviderCertificate: cert}).identityProviderCertificates()
+		require.NoError(t, err)
+		assert.Len(t, certs, 1)
+	}
+
+	_, err := (&Source{}).identityProviderCertificates()
+	assert.Error(t, err)
+}
@@ -0,0 +1,242 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Source holds configuration for a SAML 2.0 identity provider, Gitea is the service provider.
+type Source struct {
+	auth.ConfigBase `json:"-"`
+
+	IdentityProviderEntityID    string
+	IdentityProviderSSOURL      string
+	IdentityProviderCertificate string // PEM encoded, several certificates can be given while the identity provider rolls over its key
+
+	ServiceProviderCertificate string // PEM encoded, published in the metadata
+	ServiceProviderPrivateKey  string // PEM encoded
+	SignRequests               bool
+	NameIDFormat               string
+	AllowIdPInitiated          bool
+	IconURL                    string
+
+	UsernameAttribute   string
+	EmailAttribute      string
+	FullNameAttribute   string
+	GroupAttribute      string
+	AdminGroup          string
+	RestrictedGroup     string
+	GroupTeamMap        string
+	GroupTeamMapRemoval bool
+}
+
+// FromDB fills up a SAML Source from serialized format.
+func (source *Source) FromDB(bs []byte) error {
+	return json.UnmarshalHandleDoubleEncode(bs, &source)
+}
+
+// ToDB exports a SAML Source to a serialized format.
+func (source *Source) ToDB() ([]byte, error) {
+	return json.Marshal(source)
+}
+
+func init() {
+	auth.RegisterTypeConfig(auth.SAML, &Source{})
+}
+
+// GetActiveSource returns the active SAML source with the name
+func GetActiveSource(ctx context.Context, name string) (*auth.Source, error) {
+	sources, err := db.Find[auth.Source](ctx, auth.FindSourcesOptions{
+		IsActive:  optional.Some(true),
+		LoginType: auth.SAML,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, source := range sources {
+		if source.Name == name {
+			return source, nil
+		}
+	}
+	return nil, util.NewNotExistErrorf("SAML source %q does not exist", name)
+}
+
+// GetActiveSources returns the active SAML sources, which are offered on the sign in page
+func GetActiveSources(ctx context.Context) ([]*Source, error) {
+	authSources, err := db.Find[auth.Source](ctx, auth.FindSourcesOptions{
+		IsActive:  optional.Some(true),
+		LoginType: auth.SAML,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]*Source, 0, len(authSources))
+	for _, authSource := range authSources {
+		sources = append(sources, authSource.Cfg.(*Source))
+	}
+	return sources, nil
+}
+
+func (source *Source) baseURL() string {
+	return setting.AppURL + "user/saml/" + url.PathEscape(source.AuthSource.Name)
+}
+
+// EntityID returns the entity id of Gitea as service provider of this source, which is the location of its metadata
+func (source *Source) EntityID() string {
+	return source.MetadataURL()
+}
+
+// MetadataURL returns the location of the service provider metadata
+func (source *Source) MetadataURL() string {
+	return source.baseURL() + "/metadata"
+}
+
+// AssertionConsumerServiceURL returns the location the identity provider posts its responses to
+func (source *Source) AssertionConsumerServiceURL() string {
+	return source.baseURL() + "/acs"
+}
+
+// LoginURL returns the location which starts a sign in through this source
+func (source *Source) LoginURL() string {
+	return setting.AppSubURL + "/user/saml/" + url.PathEscape(source.AuthSource.Name)
+}
+
+// DisplayName returns the name shown on the sign in page
+func (source *Source) DisplayName() string {
+	return source.AuthSource.Name
+}
+
+// Validate checks that the source is usable
+func (source *Source) Validate() error {
+	if _, err := url.ParseRequestURI(source.IdentityProviderSSOURL); err != nil {
+		return fmt.Errorf("invalid single sign-on URL of the identity provider: %w", err)
+	}
+	if _, err := source.identityProviderCertificates(); err != nil {
+		return err
+	}
+	if source.SignRequests {
+		if _, _, err := source.serviceProviderKey(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// identityProviderCertificates parses the certificates of the identity provider, which may be given
+// as PEM blocks or like in metadata documents as base64 encoded DER
+func (source *Source) identityProviderCertificates() ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(strings.TrimSpace(source.IdentityProviderCertificate))
+	for len(rest) > 0 {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate of the identity provider: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 && len(rest) > 0 {
+		der, err := decodeBase64(string(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate of the identity provider: %w", err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate of the identity provider: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("the certificate of the identity provider is required")
+	}
+	return certs, nil
+}
+
+func (source *Source) serviceProviderKey() (crypto.Signer, *x509.Certificate, error) {
+	certBlock, _ := pem.Decode([]byte(source.ServiceProviderCertificate))
+	keyBlock, _ := pem.Decode([]byte(source.ServiceProviderPrivateKey))
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, errors.New("the certificate and the private key of the service provider are required to sign requests")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate of the service provider: %w", err)
+	}
+
+	var key any
+	switch keyBlock.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid private key of the service provider: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("the private key of the service provider must be an RSA key")
+	}
+	return rsaKey, cert, nil
+}
+
+// GenerateServiceProviderKey generates a private key and a self-signed certificate for signing requests if there is none yet
+func (source *Source) GenerateServiceProviderKey(commonName string) error {
+	if source.ServiceProviderCertificate != "" || source.ServiceProviderPrivateKey != "" {
+		return nil
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	source.ServiceProviderCertificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	source.ServiceProviderPrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	return nil
+}
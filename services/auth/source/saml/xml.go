@@ -0,0 +1,205 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SAML messages are parsed into a small document tree instead of being unmarshalled, so the namespaces of the elements
+// are checked and the text of an element is read completely, even if it is split by comments.
+
+const (
+	nsXML       = "http://www.w3.org/XML/1998/namespace"
+	nsDSig      = "http://www.w3.org/2000/09/xmldsig#"
+	nsProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	nsAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	nsMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+)
+
+type xmlAttr struct {
+	Prefix string
+	Space  string // namespace URI
+	Local  string
+	Value  string
+}
+
+type xmlElement struct {
+	Prefix string
+	Space  string // namespace URI
+	Local  string
+	Attrs  []xmlAttr
+
+	// namespaces maps the prefixes in scope of the element to their URIs, "" is the default namespace
+	namespaces map[string]string
+	children   []any // *xmlElement or string
+	parent     *xmlElement
+}
+
+// parseXML parses a document, documents with a DTD are rejected because SAML messages never contain one
+func parseXML(data []byte) (*xmlElement, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root, current *xmlElement
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if current == nil && root != nil {
+				return nil, errors.New("xml: multiple root elements")
+			}
+			el, err := newXMLElement(t, current)
+			if err != nil {
+				return nil, err
+			}
+			if current == nil {
+				root = el
+			} else {
+				current.children = append(current.children, el)
+			}
+			current = el
+		case xml.EndElement:
+			if current == nil || t.Name.Space != current.Prefix || t.Name.Local != current.Local {
+				return nil, fmt.Errorf("xml: unexpected end element %s", t.Name.Local)
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, string(t))
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, errors.New("xml: text outside of the root element")
+			}
+		case xml.Directive:
+			return nil, errors.New("xml: DTDs are not allowed")
+		}
+	}
+	if root == nil || current != nil {
+		return nil, errors.New("xml: incomplete document")
+	}
+	return root, nil
+}
+
+func newXMLElement(t xml.StartElement, parent *xmlElement) (*xmlElement, error) {
+	el := &xmlElement{Prefix: t.Name.Space, Local: t.Name.Local, parent: parent, namespaces: map[string]string{}}
+	if parent != nil {
+		el.namespaces = parent.namespaces
+	}
+
+	// the declared namespaces must be known before the names of the element and its attributes can be resolved
+	copied := false
+	for _, attr := range t.Attr {
+		prefix := ""
+		switch {
+		case attr.Name.Space == "xmlns":
+			prefix = attr.Name.Local
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+		default:
+			continue
+		}
+		if !copied {
+			namespaces := make(map[string]string, len(el.namespaces)+1)
+			for k, v := range el.namespaces {
+				namespaces[k] = v
+			}
+			el.namespaces, copied = namespaces, true
+		}
+		el.namespaces[prefix] = attr.Value
+	}
+
+	var ok bool
+	if el.Space, ok = el.lookupNamespace(el.Prefix); !ok {
+		return nil, fmt.Errorf("xml: undeclared namespace prefix %q", el.Prefix)
+	}
+	for _, attr := range t.Attr {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		a := xmlAttr{Prefix: attr.Name.Space, Local: attr.Name.Local, Value: attr.Value}
+		if a.Prefix != "" {
+			if a.Space, ok = el.lookupNamespace(a.Prefix); !ok {
+				return nil, fmt.Errorf("xml: undeclared namespace prefix %q", a.Prefix)
+			}
+		}
+		el.Attrs = append(el.Attrs, a)
+	}
+	return el, nil
+}
+
+func (el *xmlElement) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return nsXML, true
+	}
+	uri, ok := el.namespaces[prefix]
+	return uri, ok || prefix == ""
+}
+
+// Attr returns the value of an attribute without namespace
+func (el *xmlElement) Attr(local string) string {
+	for _, attr := range el.Attrs {
+		if attr.Space == "" && attr.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// Is returns whether the element has the namespace and the local name
+func (el *xmlElement) Is(space, local string) bool {
+	return el.Space == space && el.Local == local
+}
+
+// Children returns the child elements with the namespace and the local name
+func (el *xmlElement) Children(space, local string) []*xmlElement {
+	var children []*xmlElement
+	for _, child := range el.children {
+		if c, ok := child.(*xmlElement); ok && c.Is(space, local) {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// Child returns the first child element with the namespace and the local name
+func (el *xmlElement) Child(space, local string) *xmlElement {
+	for _, child := range el.children {
+		if c, ok := child.(*xmlElement); ok && c.Is(space, local) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Text returns the text content of the element
+func (el *xmlElement) Text() string {
+	var sb strings.Builder
+	for _, child := range el.children {
+		switch c := child.(type) {
+		case string:
+			sb.WriteString(c)
+		case *xmlElement:
+			sb.WriteString(c.Text())
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// walk calls fn for the element and all its descendants
+func (el *xmlElement) walk(fn func(*xmlElement)) {
+	fn(el)
+	for _, child := range el.children {
+		if c, ok := child.(*xmlElement); ok {
+			c.walk(fn)
+		}
+	}
+}
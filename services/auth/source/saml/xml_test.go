@@ -0,0 +1,26 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLElementText(t *testing.T) {
+	root, err := parseXML([]byte(`<e xmlns:a="urn:a"> admin@example.com<!-- comment -->.evil.example.com<a:b>!</a:b> </e>`))
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com.evil.example.com!", root.Text())
+	assert.Equal(t, "!", root.Child("urn:a", "b").Text())
+}
+
+func TestParseXMLRejectsDTD(t *testing.T) {
+	_, err := parseXML([]byte(`<?xml version="1.0"?><!DOCTYPE e [<!ENTITY x "y">]><e>&x;</e>`))
+	assert.Error(t, err)
+
+	_, err = parseXML([]byte(`<a></a><b></b>`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+// The XML signatures are verified by goxmldsig, which returns the canonical form of the signed element.
+// Only this verified copy is read afterwards, so values outside of the signed element can't be passed off as signed.
+
+// errNotSigned is returned for elements without signature
+var errNotSigned = errors.New("element is not signed")
+
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
+
+// childElements returns the child elements with the namespace and the local name
+func childElements(el *etree.Element, space, local string) []*etree.Element {
+	var children []*etree.Element
+	for _, child := range el.ChildElements() {
+		if child.NamespaceURI() == space && child.Tag == local {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// verifySignature verifies the enveloped signature of an element with one of the certificates and returns the signed element
+func verifySignature(el *etree.Element, certs []*x509.Certificate) (*xmlElement, error) {
+	signatures := childElements(el, nsDSig, "Signature")
+	if len(signatures) == 0 {
+		return nil, errNotSigned
+	} else if len(signatures) > 1 {
+		return nil, errors.New("element has multiple signatures")
+	}
+
+	// the signature covers the canonical form of the element, which includes the namespaces declared by its ancestors
+	nsCtx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, err
+	}
+	detached, err := etreeutils.NSDetatch(nsCtx, el)
+	if err != nil {
+		return nil, err
+	}
+	// the certificate in the key info is not trusted, the signature has to be verifiable with a configured certificate
+	for _, signature := range childElements(detached, nsDSig, "Signature") {
+		for _, keyInfo := range childElements(signature, nsDSig, "KeyInfo") {
+			signature.RemoveChild(keyInfo)
+		}
+	}
+
+	err = errors.New("no certificate of the identity provider is configured")
+	for _, cert := range certs {
+		validationCtx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: []*x509.Certificate{cert}})
+		// the certificates are configured by the administrator like trust anchors, their validity period is not checked
+		validationCtx.Clock = dsig.NewFakeClockAt(cert.NotBefore)
+		var signed *etree.Element
+		if signed, err = validationCtx.Validate(detached); err == nil {
+			return toXMLElement(signed)
+		}
+	}
+	return nil, fmt.Errorf("signature is not valid for a certificate of the identity provider: %w", err)
+}
+
+// toXMLElement converts an element of goxmldsig to the document tree the messages are read from
+func toXMLElement(el *etree.Element) (*xmlElement, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(el)
+	data, err := doc.WriteToBytes()
+	if err != nil {
+		return nil, err
+	}
+	return parseXML(data)
+}
@@ -14,6 +14,7 @@ import (
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/util"
+	user_service "code.gitea.io/gitea/services/user"
 )
 
 // Authenticate queries if the provided login/password is authenticates against the SMTP server
@@ -79,7 +80,7 @@ func (source *Source) Authenticate(ctx context.Context, user *user_model.User, u
 		IsActive: optional.Some(true),
 	}
 
-	if err := user_model.CreateUser(ctx, user, &user_model.Meta{}, overwriteDefault); err != nil {
+	if err := user_service.CreateUser(ctx, user, user, &user_model.Meta{}, overwriteDefault); err != nil {
 		return user, err
 	}
 
@@ -24,6 +24,7 @@ import (
 	"code.gitea.io/gitea/modules/process"
 	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/services/automergequeue"
+	"code.gitea.io/gitea/services/mergequeue"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
@@ -260,6 +261,21 @@ func handlePullRequestAutoMerge(pullID int64, sha string) {
 		return
 	}
 
+	// with a merge queue the pull request has to pass the checks again together with the other queued pull requests
+	if enabled, err := mergequeue.IsMergeQueueEnabled(ctx, pr); err != nil {
+		log.Error("IsMergeQueueEnabled: %v", err)
+		return
+	} else if enabled {
+		if err := mergequeue.AddToMergeQueue(ctx, doer, pr, scheduledPRM.MergeStyle, scheduledPRM.Message, scheduledPRM.DeleteBranchAfterMerge); err != nil && !errors.Is(err, pull_model.ErrAlreadyInMergeQueue) {
+			log.Error("AddToMergeQueue: %v", err)
+			return
+		}
+		if err := pull_model.DeleteScheduledAutoMerge(ctx, pr.ID); err != nil {
+			log.Error("DeleteScheduledAutoMerge: %v", err)
+		}
+		return
+	}
+
 	if err := pull_service.Merge(ctx, pr, doer, scheduledPRM.MergeStyle, "", scheduledPRM.Message, true); err != nil {
 		log.Error("pull_service.Merge: %v", err)
 		// FIXME: if merge failed, we should display some error message to the pull request page.
@@ -202,6 +202,7 @@ func Contexter() func(next http.Handler) http.Handler {
 			ctx.Data["DisableMigrations"] = setting.Repository.DisableMigrations
 			ctx.Data["DisableStars"] = setting.Repository.DisableStars
 			ctx.Data["EnableActions"] = setting.Actions.Enabled && !unit.TypeActions.UnitGlobalDisabled()
+			ctx.Data["EnableTerraform"] = setting.Terraform.Enabled
 
 			ctx.Data["ManifestData"] = setting.ManifestData
 			ctx.Data["AllLangs"] = translation.AllLangs()
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
 	"code.gitea.io/gitea/models/perm"
@@ -101,7 +102,10 @@ func determineAccessMode(ctx *Base, pkg *Package, doer *user_model.User) (perm.A
 		return perm.AccessModeNone, nil
 	}
 
-	// TODO: ActionUser permission check
+	if doer != nil && doer.ID == user_model.ActionsUserID {
+		return determineActionsAccessMode(ctx, pkg)
+	}
+
 	accessMode := perm.AccessModeNone
 	if pkg.Owner.IsOrganization() {
 		org := organization.OrgFromUser(pkg.Owner)
@@ -148,6 +152,32 @@ func determineAccessMode(ctx *Base, pkg *Package, doer *user_model.User) (perm.A
 	return accessMode, nil
 }
 
+// determineActionsAccessMode returns the access of the token of an actions job to a package.
+// The packages of the owner of the job's repository can only be written if the job declares "packages: write".
+func determineActionsAccessMode(ctx *Base, pkg *Package) (perm.AccessMode, error) {
+	accessMode := perm.AccessModeNone
+	if pkg.Owner.Visibility == structs.VisibleTypePublic || pkg.Owner.Visibility == structs.VisibleTypeLimited {
+		accessMode = perm.AccessModeRead
+	}
+
+	taskID, ok := ctx.Data["ActionsTaskID"].(int64)
+	if !ok {
+		return accessMode, nil
+	}
+	task, err := actions_model.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return accessMode, err
+	}
+	if task.OwnerID != pkg.Owner.ID {
+		return accessMode, nil
+	}
+	packagesAccessMode, err := actions_model.GetTaskPackagesAccessMode(ctx, task)
+	if err != nil {
+		return accessMode, err
+	}
+	return max(accessMode, packagesAccessMode), nil
+}
+
 // PackageContexter initializes a package context for a request.
 func PackageContexter() func(next http.Handler) http.Handler {
 	renderer := templates.HTMLRenderer()
@@ -16,6 +16,7 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	asymkey_model "code.gitea.io/gitea/models/asymkey"
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
@@ -24,11 +25,13 @@ import (
 	"code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
+	terraform_model "code.gitea.io/gitea/models/terraform"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/actions"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
@@ -189,6 +192,7 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		ApprovalsWhitelistTeams:       approvalsWhitelistTeams,
 		BlockOnRejectedReviews:        bp.BlockOnRejectedReviews,
 		BlockOnOfficialReviewRequests: bp.BlockOnOfficialReviewRequests,
+		BlockOnCodeOwnerReviews:       bp.BlockOnCodeOwnerReviews,
 		BlockOnOutdatedBranch:         bp.BlockOnOutdatedBranch,
 		DismissStaleApprovals:         bp.DismissStaleApprovals,
 		IgnoreStaleApprovals:          bp.IgnoreStaleApprovals,
@@ -196,6 +200,8 @@ func ToBranchProtection(ctx context.Context, bp *git_model.ProtectedBranch, repo
 		ProtectedFilePatterns:         bp.ProtectedFilePatterns,
 		UnprotectedFilePatterns:       bp.UnprotectedFilePatterns,
 		BlockAdminMergeOverride:       bp.BlockAdminMergeOverride,
+		EnableMergeQueue:              bp.EnableMergeQueue,
+		MergeQueueMaxBatchSize:        bp.MergeQueueMaxBatchSize,
 		Created:                       bp.CreatedUnix.AsTime(),
 		Updated:                       bp.UpdatedUnix.AsTime(),
 	}
@@ -483,6 +489,75 @@ func GetActionWorkflow(ctx context.Context, gitrepo *git.Repository, repo *repo_
 	return nil, util.NewNotExistErrorf("workflow %q not found", workflowID)
 }
 
+// ToAttestation convert a actions_model.ActionAttestation to an api.Attestation
+func ToAttestation(repo *repo_model.Repository, attestation *actions_model.ActionAttestation, verified bool) (*api.Attestation, error) {
+	var envelope api.AttestationEnvelope
+	if err := json.Unmarshal([]byte(attestation.Envelope), &envelope); err != nil {
+		return nil, err
+	}
+	return &api.Attestation{
+		ID:            attestation.ID,
+		Repository:    repo.FullName(),
+		RunID:         attestation.RunID,
+		CommitSHA:     attestation.CommitSHA,
+		Workflow:      attestation.WorkflowID,
+		PredicateType: attestation.PredicateType,
+		Envelope:      &envelope,
+		Verified:      verified,
+		CreatedAt:     attestation.Created.AsLocalTime(),
+	}, nil
+}
+
+// ToTerraformState convert a terraform_model.State to an api.TerraformState
+func ToTerraformState(state *terraform_model.State) *api.TerraformState {
+	return &api.TerraformState{
+		Name:      state.Name,
+		Serial:    state.Serial,
+		Lineage:   state.Lineage,
+		Locked:    state.IsLocked(),
+		LockID:    state.LockID,
+		UpdatedAt: state.UpdatedUnix.AsLocalTime(),
+	}
+}
+
+// ToRepoDependency converts a repo_model.RepoDependency to an api.RepoDependency
+func ToRepoDependency(d *repo_model.RepoDependency, apiRepo *api.Repository) *api.RepoDependency {
+	return &api.RepoDependency{
+		Ecosystem:  d.Ecosystem,
+		Name:       d.Name,
+		Version:    d.Version,
+		PackageURL: d.PackageURL(),
+		Manifest:   d.Manifest,
+		Direct:     d.IsDirect,
+		CommitID:   d.CommitID,
+		Repository: apiRepo,
+	}
+}
+
+// ToAuditEvent convert an audit_model.Event to an api.AuditEvent
+func ToAuditEvent(event *audit_model.Event) *api.AuditEvent {
+	apiEvent := &api.AuditEvent{
+		ID:         event.ID,
+		Action:     string(event.Action),
+		ActorID:    event.ActorID,
+		ActorName:  event.ActorName,
+		OwnerID:    event.OwnerID,
+		Repository: event.RepoName,
+		TargetType: string(event.TargetType),
+		TargetID:   event.TargetID,
+		TargetName: event.TargetName,
+		IPAddress:  event.IPAddress,
+		CreatedAt:  event.CreatedUnix.AsLocalTime(),
+	}
+	if event.Before != "" {
+		_ = json.Unmarshal([]byte(event.Before), &apiEvent.Before)
+	}
+	if event.After != "" {
+		_ = json.Unmarshal([]byte(event.After), &apiEvent.After)
+	}
+	return apiEvent
+}
+
 // ToActionArtifact convert a actions_model.ActionArtifact to an api.ActionArtifact
 func ToActionArtifact(repo *repo_model.Repository, art *actions_model.ActionArtifact) (*api.ActionArtifact, error) {
 	url := fmt.Sprintf("%s/actions/artifacts/%d", repo.APIURL(), art.ID)
@@ -51,3 +51,19 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageVulnerability converts packages.PackageVulnerability to api.PackageVulnerability,
+// the component and the advisory must be loaded
+func ToPackageVulnerability(pv *packages.PackageVulnerability) *api.PackageVulnerability {
+	return &api.PackageVulnerability{
+		ID:               pv.Advisory.Identifier,
+		Aliases:          pv.Advisory.Aliases,
+		Summary:          pv.Advisory.Summary,
+		Severity:         pv.Advisory.Severity,
+		URL:              pv.Advisory.Link(),
+		Ecosystem:        pv.Component.Ecosystem,
+		Component:        pv.Component.Name,
+		ComponentVersion: pv.Component.Version,
+		FixedVersion:     pv.FixedVersion,
+	}
+}
@@ -27,6 +27,7 @@ func Init(original context.Context) {
 	initBasicTasks()
 	initExtendedTasks()
 	initActionsTasks()
+	initPaymentsTasks()
 
 	lock.Lock()
 	for _, task := range tasks {
@@ -20,6 +20,7 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerScheduleTasks()
 	registerActionsCleanup()
+	registerResumeDeployments()
 }
 
 func registerStopZombieTasks() {
@@ -74,3 +75,14 @@ func registerActionsCleanup() {
 		return actions_service.Cleanup(ctx)
 	})
 }
+
+// registerResumeDeployments registers a task that runs every minute to start the jobs whose environment wait timer elapsed.
+func registerResumeDeployments() {
+	RegisterTaskFatal("resume_actions_deployments", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.ResumeDeploymentsAfterWaitTimer(ctx)
+	})
+}
@@ -5,6 +5,7 @@ package cron
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"code.gitea.io/gitea/models"
@@ -14,9 +15,11 @@ import (
 	"code.gitea.io/gitea/modules/git/gitcmd"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/services/auth"
+	dependency_update_service "code.gitea.io/gitea/services/dependencyupdate"
 	"code.gitea.io/gitea/services/migrations"
 	mirror_service "code.gitea.io/gitea/services/mirror"
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+	packages_scanner_service "code.gitea.io/gitea/services/packages/scanner"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
 )
@@ -156,6 +159,41 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerScanPackages() {
+	RegisterTaskFatal("scan_packages", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return packages_scanner_service.ScanTask(ctx)
+	})
+}
+
+func registerDependencyUpdates() {
+	type DependencyUpdatesConfig struct {
+		BaseConfig
+		// User is the account which opens the pull requests, it needs write access to the repositories
+		User string
+	}
+	RegisterTaskFatal("dependency_updates", &DependencyUpdatesConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		duConfig := config.(*DependencyUpdatesConfig)
+		if duConfig.User == "" {
+			return errors.New("no user is configured to open the pull requests of the dependency updates")
+		}
+		doer, err := user_model.GetUserByName(ctx, duConfig.User)
+		if err != nil {
+			return err
+		}
+		return dependency_update_service.Update(ctx, doer)
+	})
+}
+
 func registerSyncRepoLicenses() {
 	RegisterTaskFatal("sync_repo_licenses", &BaseConfig{
 		Enabled:    false,
@@ -181,6 +219,10 @@ func initBasicTasks() {
 	registerCleanupHookTaskTable()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
+		if setting.Packages.ScannerEnabled {
+			registerScanPackages()
+		}
 	}
+	registerDependencyUpdates()
 	registerSyncRepoLicenses()
 }
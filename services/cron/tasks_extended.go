@@ -8,6 +8,7 @@ import (
 	"time"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/system"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git/gitcmd"
@@ -171,6 +172,20 @@ func registerDeleteOldSystemNotices() {
 	})
 }
 
+func registerDeleteOldAuditEvents() {
+	RegisterTaskFatal("delete_old_audit_events", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@every 168h",
+		},
+		OlderThan: 365 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		olderThanConfig := config.(*OlderThanConfig)
+		return audit_model.DeleteOldEvents(ctx, olderThanConfig.OlderThan)
+	})
+}
+
 type GCLFSConfig struct {
 	BaseConfig
 	OlderThan                time.Duration
@@ -237,6 +252,7 @@ func initExtendedTasks() {
 	registerDeleteOldActions()
 	registerUpdateGiteaChecker()
 	registerDeleteOldSystemNotices()
+	registerDeleteOldAuditEvents()
 	registerGCLFS()
 	registerRebuildIssueIndexer()
 }
@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cron
+
+import (
+	"context"
+
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/services/payments"
+)
+
+func initPaymentsTasks() {
+	if !payments.IsEnabled() {
+		return
+	}
+	registerSyncBillingSeats()
+}
+
+func registerSyncBillingSeats() {
+	RegisterTaskFatal("sync_billing_seats", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return payments.SyncAllOrgSeats(ctx)
+	})
+}
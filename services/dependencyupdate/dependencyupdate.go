@@ -0,0 +1,254 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependencyupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// BranchPrefix is the prefix of the branches of the pull requests opened by the dependency updater
+const BranchPrefix = "dependency-updates/"
+
+var branchSegmentPattern = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// update is the update of a dependency to a newer version
+type update struct {
+	Name string
+	From string
+	To   string
+	Type string
+}
+
+// change is a pull request which updates one dependency or the dependencies of a group
+type change struct {
+	Branch  string
+	Title   string
+	Updates []*update
+}
+
+// Update checks the dependencies of all repositories with a dependency updater config and
+// opens or rebases the pull requests of the updates which are due
+func Update(ctx context.Context, doer *user_model.User) error {
+	// the repositories without dependencies in the default branch have nothing to update
+	repoIDs, err := repo_model.GetRepoIDsWithDependencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, repoID := range repoIDs {
+		select {
+		case <-ctx.Done():
+			return errors.New("aborted")
+		default:
+		}
+
+		repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+		if err != nil {
+			log.Error("GetRepositoryByID(%d): %v", repoID, err)
+			continue
+		}
+		if err := UpdateRepository(ctx, doer, repo, now); err != nil {
+			log.Error("Updating the dependencies of %s failed: %v", repo.FullName(), err)
+		}
+	}
+	return nil
+}
+
+// UpdateRepository opens or rebases the pull requests of the dependency updates of the repository which are due at the time
+func UpdateRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, now time.Time) error {
+	if repo.IsEmpty || repo.IsArchived || repo.IsMirror {
+		return nil
+	}
+	if !repo.UnitEnabled(ctx, unit.TypePullRequests) {
+		return nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err != nil {
+		return err
+	}
+	if !perm.CanWrite(unit.TypeCode) {
+		log.Trace("Skipping dependency updates of %s: %s has no write access", repo.FullName(), doer.Name)
+		return nil
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetBranchCommit(repo.DefaultBranch)
+	if err != nil {
+		return err
+	}
+	content, err := readFile(commit, dependency.UpdatesConfigPath)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	cfg, err := dependency.ParseUpdatesConfig(content)
+	if err != nil {
+		log.Warn("Skipping dependency updates of %s: %v", repo.FullName(), err)
+		return nil
+	}
+
+	if err := repo.LoadOwner(ctx); err != nil {
+		return err
+	}
+
+	versions := newVersionsCache(repo.Owner)
+	for _, u := range cfg.Updates {
+		if !u.IsDue(now) {
+			continue
+		}
+		if err := updateEntry(ctx, doer, repo, gitRepo, u, versions); err != nil {
+			log.Error("Updating the %s dependencies in %s of %s failed: %v", u.PackageEcosystem, u.Directory, repo.FullName(), err)
+		}
+	}
+	return nil
+}
+
+func readFile(commit *git.Commit, treePath string) ([]byte, error) {
+	entry, err := commit.GetTreeEntryByPath(treePath)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil, util.NewNotExistErrorf("%s does not exist in commit %s", treePath, commit.ID)
+		}
+		return nil, err
+	}
+	if !entry.IsRegular() || entry.Blob().Size() > dependency.MaxManifestSize {
+		return nil, util.NewNotExistErrorf("%s is no regular file or too large", treePath)
+	}
+	return entry.Blob().GetBlobBytes(dependency.MaxManifestSize)
+}
+
+func updateEntry(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, u *dependency.UpdateConfig, versions *versionsCache) error {
+	ecosystem := dependency.GetUpdateEcosystem(u.PackageEcosystem)
+	baseBranch := util.IfZero(u.TargetBranch, repo.DefaultBranch)
+	manifestPath := ecosystem.ManifestPath(u.Directory)
+
+	baseCommit, err := gitRepo.GetBranchCommit(baseBranch)
+	if err != nil {
+		return err
+	}
+	content, err := readFile(baseCommit, manifestPath)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			log.Trace("Skipping dependency updates of %s: %v", repo.FullName(), err)
+			return nil
+		}
+		return err
+	}
+	reqs, err := ecosystem.ReadRequirements(content)
+	if err != nil {
+		log.Warn("Skipping dependency updates of %s: invalid %s: %v", repo.FullName(), manifestPath, err)
+		return nil
+	}
+
+	var updates []*update
+	for _, req := range reqs {
+		available, err := versions.Get(ctx, ecosystem, req.Name)
+		if err != nil {
+			return err
+		}
+		latest := dependency.LatestVersion(ecosystem.Ecosystem, req.Version, available, func(v string) bool {
+			return !u.IsIgnored(req.Name, dependency.GetUpdateType(req.Version, v))
+		})
+		if latest == "" {
+			continue
+		}
+		updates = append(updates, &update{
+			Name: req.Name,
+			From: req.Version,
+			To:   latest,
+			Type: dependency.GetUpdateType(req.Version, latest),
+		})
+	}
+
+	prefix := entryBranchPrefix(ecosystem, u.Directory)
+	changes := groupUpdates(u, prefix, updates)
+
+	return syncPullRequests(ctx, doer, repo, gitRepo, &entry{
+		Config:       u,
+		Ecosystem:    ecosystem,
+		BaseBranch:   baseBranch,
+		BaseCommit:   baseCommit,
+		ManifestPath: manifestPath,
+		Manifest:     content,
+		BranchPrefix: prefix,
+	}, changes)
+}
+
+// entryBranchPrefix returns the prefix of the branches of an update entry, e.g. "dependency-updates/npm/web/"
+func entryBranchPrefix(ecosystem *dependency.UpdateEcosystem, directory string) string {
+	dir := strings.ReplaceAll(strings.Trim(directory, "/"), "/", "-")
+	return BranchPrefix + ecosystem.Name + "/" + util.IfZero(branchSegment(dir), "root") + "/"
+}
+
+// branchSegment replaces the characters of a name which are not valid or confusing in a branch name
+func branchSegment(name string) string {
+	return strings.Trim(branchSegmentPattern.ReplaceAllString(strings.ReplaceAll(name, "..", "."), "-"), ".-")
+}
+
+// groupUpdates returns the pull requests of the updates, the updates of a group are combined
+func groupUpdates(u *dependency.UpdateConfig, prefix string, updates []*update) []*change {
+	var changes []*change
+	groups := make(map[string]*change)
+	for _, up := range updates {
+		group := u.GetGroup(up.Name, up.Type)
+		if group == "" {
+			changes = append(changes, &change{
+				Branch:  prefix + branchSegment(up.Name),
+				Updates: []*update{up},
+			})
+			continue
+		}
+		c, ok := groups[group]
+		if !ok {
+			c = &change{Branch: prefix + "group-" + branchSegment(group)}
+			groups[group] = c
+			changes = append(changes, c)
+		}
+		c.Updates = append(c.Updates, up)
+	}
+
+	dir := ""
+	if u.Directory != "/" {
+		dir = " in " + u.Directory
+	}
+	for group, c := range groups {
+		c.Title = fmt.Sprintf("Update the %s group%s with %d updates", group, dir, len(c.Updates))
+		if len(c.Updates) == 1 {
+			c.Title = fmt.Sprintf("Update the %s group%s with 1 update", group, dir)
+		}
+	}
+	for _, c := range changes {
+		if c.Title == "" {
+			c.Title = fmt.Sprintf("Update %s from %s to %s%s", c.Updates[0].Name, c.Updates[0].From, c.Updates[0].To, dir)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Branch < changes[j].Branch
+	})
+	return changes
+}
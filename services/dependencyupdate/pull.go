@@ -0,0 +1,233 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependencyupdate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	issue_service "code.gitea.io/gitea/services/issue"
+	pull_service "code.gitea.io/gitea/services/pull"
+	files_service "code.gitea.io/gitea/services/repository/files"
+)
+
+// entry is an update entry of the config together with the manifest it updates
+type entry struct {
+	Config       *dependency.UpdateConfig
+	Ecosystem    *dependency.UpdateEcosystem
+	BaseBranch   string
+	BaseCommit   *git.Commit
+	ManifestPath string
+	Manifest     []byte
+	BranchPrefix string
+}
+
+// syncPullRequests opens or rebases the pull requests of the changes and closes the open pull requests of the entry
+// which are not needed anymore because the dependencies got updated otherwise or the updates are ignored now
+func syncPullRequests(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, e *entry, changes []*change) error {
+	prs, err := issues_model.GetUnmergedPullRequestsByHeadBranchPrefix(ctx, repo.ID, e.BranchPrefix)
+	if err != nil {
+		return err
+	}
+	if _, err := prs.LoadIssues(ctx); err != nil {
+		return err
+	}
+	openPulls := make(map[string]*issues_model.PullRequest, len(prs))
+	for _, pr := range prs {
+		if pr.BaseBranch == e.BaseBranch {
+			openPulls[pr.HeadBranch] = pr
+		}
+	}
+
+	openCount := len(openPulls)
+	needed := make(container.Set[string])
+	for _, c := range changes {
+		content := e.Manifest
+		for _, up := range c.Updates {
+			content = e.Ecosystem.UpdateRequirement(content, up.Name, up.From, up.To)
+		}
+		if bytes.Equal(content, e.Manifest) {
+			continue
+		}
+		needed.Add(c.Branch)
+
+		if pr, ok := openPulls[c.Branch]; ok {
+			if err := refreshPullRequest(ctx, doer, repo, gitRepo, e, c, pr, content); err != nil {
+				log.Error("Refreshing pull request %s#%d failed: %v", repo.FullName(), pr.Index, err)
+			}
+			continue
+		}
+
+		pr, err := issues_model.GetLatestPullRequestByHeadInfo(ctx, repo.ID, c.Branch)
+		if err != nil {
+			return err
+		}
+		if pr != nil {
+			if err := pr.LoadIssue(ctx); err != nil {
+				return err
+			}
+			// a pull request of the same updates has been closed without merging it, the updates are not wanted
+			if pr.Issue.IsClosed && !pr.HasMerged && pr.Issue.Title == c.Title {
+				continue
+			}
+		}
+
+		if openCount >= e.Config.GetOpenPullRequestsLimit() {
+			continue
+		}
+		if err := createPullRequest(ctx, doer, repo, e, c, content); err != nil {
+			log.Error("Opening the pull request of branch %s in %s failed: %v", c.Branch, repo.FullName(), err)
+			continue
+		}
+		openCount++
+	}
+
+	for branch, pr := range openPulls {
+		if needed.Contains(branch) {
+			continue
+		}
+		if _, err := issue_service.CreateIssueComment(ctx, doer, repo, pr.Issue, "The dependencies are up to date or the updates are ignored now, closing this pull request.", nil); err != nil {
+			return err
+		}
+		if err := issue_service.CloseIssue(ctx, pr.Issue, doer, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushChange commits the updated manifest on top of the base branch, an existing branch gets replaced
+func pushChange(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, e *entry, c *change, content []byte) error {
+	message := c.Title
+	if len(c.Updates) > 1 {
+		lines := make([]string, 0, len(c.Updates))
+		for _, up := range c.Updates {
+			lines = append(lines, fmt.Sprintf("- Update %s from %s to %s", up.Name, up.From, up.To))
+		}
+		message += "\n\n" + strings.Join(lines, "\n")
+	}
+
+	_, err := files_service.ChangeRepoFiles(ctx, repo, doer, &files_service.ChangeRepoFilesOptions{
+		LastCommitID: e.BaseCommit.ID.String(),
+		OldBranch:    e.BaseBranch,
+		NewBranch:    c.Branch,
+		Message:      message,
+		Files: []*files_service.ChangeRepoFile{
+			{
+				Operation:     "update",
+				TreePath:      e.ManifestPath,
+				ContentReader: bytes.NewReader(content),
+			},
+		},
+		ForcePush: true,
+	})
+	return err
+}
+
+func createPullRequest(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, e *entry, c *change, content []byte) error {
+	if err := pushChange(ctx, doer, repo, e, c, content); err != nil {
+		return err
+	}
+
+	labelIDs, err := issues_model.GetLabelIDsInRepoByNames(ctx, repo.ID, e.Config.Labels)
+	if err != nil {
+		return err
+	}
+
+	return pull_service.NewPullRequest(ctx, &pull_service.NewPullRequestOptions{
+		Repo: repo,
+		Issue: &issues_model.Issue{
+			RepoID:   repo.ID,
+			Repo:     repo,
+			Title:    c.Title,
+			PosterID: doer.ID,
+			Poster:   doer,
+			IsPull:   true,
+			Content:  pullRequestContent(e, c),
+		},
+		LabelIDs: labelIDs,
+		PullRequest: &issues_model.PullRequest{
+			HeadRepoID: repo.ID,
+			BaseRepoID: repo.ID,
+			HeadBranch: c.Branch,
+			BaseBranch: e.BaseBranch,
+			HeadRepo:   repo,
+			BaseRepo:   repo,
+			MergeBase:  e.BaseCommit.ID.String(),
+			Type:       issues_model.PullRequestGitea,
+		},
+	})
+}
+
+// refreshPullRequest rebases the branch of the pull request if the base branch moved on or newer versions are available
+// and updates the title and the description. Branches with commits of other users are not touched anymore.
+func refreshPullRequest(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, gitRepo *git.Repository, e *entry, c *change, pr *issues_model.PullRequest, content []byte) error {
+	headCommit, err := gitRepo.GetBranchCommit(pr.HeadBranch)
+	if err != nil && !git.IsErrNotExist(err) {
+		return err
+	}
+
+	upToDate := false
+	if headCommit != nil {
+		if headCommit.Author == nil || headCommit.Author.Email != doer.GetEmail() {
+			log.Trace("Skipping pull request %s#%d: the branch contains commits of other users", repo.FullName(), pr.Index)
+			return nil
+		}
+		if headCommit.ParentCount() == 1 {
+			parentID, err := headCommit.ParentID(0)
+			if err != nil {
+				return err
+			}
+			if parentID.String() == e.BaseCommit.ID.String() {
+				headContent, err := readFile(headCommit, e.ManifestPath)
+				if err != nil {
+					return err
+				}
+				upToDate = bytes.Equal(headContent, content)
+			}
+		}
+	}
+	if !upToDate {
+		if err := pushChange(ctx, doer, repo, e, c, content); err != nil {
+			return err
+		}
+	}
+
+	if pr.Issue.Title != c.Title {
+		if err := issue_service.ChangeTitle(ctx, pr.Issue, doer, c.Title); err != nil {
+			return err
+		}
+	}
+	if body := pullRequestContent(e, c); pr.Issue.Content != body {
+		if err := issue_service.ChangeContent(ctx, pr.Issue, doer, body, pr.Issue.ContentVersion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pullRequestContent returns the description of the pull request of the change
+func pullRequestContent(e *entry, c *change) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Updates the dependencies of `%s`:\n\n", e.ManifestPath)
+	sb.WriteString("| Package | From | To | Update |\n| --- | --- | --- | --- |\n")
+	for _, up := range c.Updates {
+		fmt.Fprintf(&sb, "| `%s` | %s | %s | %s |\n", up.Name, up.From, up.To, up.Type)
+	}
+	if e.Ecosystem.Lockfile != "" {
+		fmt.Fprintf(&sb, "\nThe lockfile `%s` is not updated, run `%s` on this branch if it is used.\n", e.Ecosystem.Lockfile, e.Ecosystem.LockfileCommand)
+	}
+	fmt.Fprintf(&sb, "\nThis pull request is rebased when `%s` changes or newer versions are published, as long as nobody else pushes to the branch. ", e.BaseBranch)
+	fmt.Fprintf(&sb, "Close it without merging to skip these updates, the updates are configured in `%s`.\n", dependency.UpdatesConfigPath)
+	return sb.String()
+}
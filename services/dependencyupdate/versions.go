@@ -0,0 +1,101 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dependencyupdate
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/packages/remote"
+)
+
+// packageTypes maps the package-ecosystems of the config to the package types of the registries
+var packageTypes = map[string]packages_model.Type{
+	"cargo":    packages_model.TypeCargo,
+	"composer": packages_model.TypeComposer,
+	"gomod":    packages_model.TypeGo,
+	"maven":    packages_model.TypeMaven,
+	"npm":      packages_model.TypeNpm,
+	"pip":      packages_model.TypePyPI,
+}
+
+// pypiNameNormalizer normalizes the names of python packages like the PyPI registry does
+var pypiNameNormalizer = strings.NewReplacer(".", "-", "_", "-")
+
+// versionsCache collects the available versions of the dependencies of a repository once per run
+type versionsCache struct {
+	owner    *user_model.User
+	versions map[string][]string
+}
+
+func newVersionsCache(owner *user_model.User) *versionsCache {
+	return &versionsCache{
+		owner:    owner,
+		versions: make(map[string][]string),
+	}
+}
+
+// Get returns the versions of the dependency published in the registry of the repository owner,
+// the sources of the owner's virtual registry and the upstream of the owner's remote
+func (c *versionsCache) Get(ctx context.Context, ecosystem *dependency.UpdateEcosystem, name string) ([]string, error) {
+	key := ecosystem.Name + "/" + name
+	if versions, ok := c.versions[key]; ok {
+		return versions, nil
+	}
+
+	versions, err := c.load(ctx, packageTypes[ecosystem.Name], name)
+	if err != nil {
+		return nil, err
+	}
+	c.versions[key] = versions
+	return versions, nil
+}
+
+func (c *versionsCache) load(ctx context.Context, packageType packages_model.Type, name string) ([]string, error) {
+	if !setting.Packages.Enabled {
+		return nil, nil
+	}
+	if packageType == packages_model.TypePyPI {
+		name = pypiNameNormalizer.Replace(name)
+	}
+
+	ownerIDs := []int64{c.owner.ID}
+	sources, err := packages_model.GetVirtualSourcesByOwnerAndType(ctx, c.owner.ID, packageType)
+	if err != nil {
+		return nil, err
+	}
+	for _, pvs := range sources {
+		if pvs.Matches(name) {
+			ownerIDs = append(ownerIDs, pvs.SourceOwnerID)
+		}
+	}
+
+	versions := make(container.Set[string])
+	for _, ownerID := range ownerIDs {
+		pvs, err := packages_model.GetVersionsByPackageName(ctx, ownerID, packageType, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, pv := range pvs {
+			versions.Add(pv.Version)
+		}
+	}
+
+	remoteVersions, err := remote.GetVersions(ctx, c.owner, packageType, name)
+	// the owner has no remote or the upstream does not know the package, a failing upstream stops the run
+	// because open pull requests would be closed otherwise
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return nil, err
+	}
+	versions.AddMultiple(remoteVersions...)
+
+	return versions.Values(), nil
+}
@@ -71,3 +71,20 @@ func (f *AdminDashboardForm) Validate(req *http.Request, errs binding.Errors) bi
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// AdminQuotaGroupForm form for creating and editing quota groups, empty limits are unlimited
+type AdminQuotaGroupForm struct {
+	Name             string `binding:"MaxSize(255)"`
+	LimitTotal       string
+	LimitGit         string
+	LimitLFS         string
+	LimitPackages    string
+	LimitArtifacts   string
+	LimitAttachments string
+}
+
+// Validate validates form fields
+func (f *AdminQuotaGroupForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
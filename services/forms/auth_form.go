@@ -15,7 +15,7 @@ import (
 // AuthenticationForm form for authentication
 type AuthenticationForm struct {
 	ID              int64
-	Type            int    `binding:"Range(2,7)"`
+	Type            int    `binding:"Range(2,8)"`
 	Name            string `binding:"Required;MaxSize(30)"`
 	TwoFactorPolicy string
 	IsActive        bool
@@ -94,6 +94,25 @@ type AuthenticationForm struct {
 	SSPIStripDomainNames     bool
 	SSPISeparatorReplacement string `binding:"AlphaDashDot;MaxSize(5)"`
 	SSPIDefaultLanguage      string
+
+	// SAML
+	SAMLIdentityProviderEntityID    string
+	SAMLIdentityProviderSSOURL      string `form:"saml_identity_provider_sso_url"`
+	SAMLIdentityProviderCertificate string
+	SAMLServiceProviderCertificate  string
+	SAMLServiceProviderPrivateKey   string
+	SAMLSignRequests                bool
+	SAMLNameIDFormat                string
+	SAMLAllowIdPInitiated           bool `form:"saml_allow_idp_initiated"`
+	SAMLIconURL                     string
+	SAMLUsernameAttribute           string
+	SAMLEmailAttribute              string
+	SAMLFullNameAttribute           string
+	SAMLGroupAttribute              string
+	SAMLAdminGroup                  string
+	SAMLRestrictedGroup             string
+	SAMLGroupTeamMap                string `binding:"ValidGroupTeamMap"`
+	SAMLGroupTeamMapRemoval         bool
 }
 
 // Validate validates fields
@@ -15,7 +15,7 @@ import (
 type PackageCleanupRuleForm struct {
 	ID            int64
 	Enabled       bool
-	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,vagrant)"`
+	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,hex,maven,nix,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant)"`
 	KeepCount     int    `binding:"In(0,1,5,10,25,50,100)"`
 	KeepPattern   string `binding:"RegexPattern"`
 	RemoveDays    int    `binding:"In(0,7,14,30,60,90,180)"`
@@ -28,3 +28,33 @@ func (f *PackageCleanupRuleForm) Validate(req *http.Request, errs binding.Errors
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+type PackageRemoteForm struct {
+	ID          int64
+	Enabled     bool
+	Type        string `binding:"Required;In(cargo,container,go,maven,npm,pypi)"`
+	URL         string `binding:"Required;ValidUrl"`
+	Username    string `binding:"MaxSize(255)"`
+	Password    string
+	MetadataTTL int64  `binding:"Range(0,10080)"` // in minutes
+	Action      string `binding:"Required;In(save,remove,clear)"`
+}
+
+func (f *PackageRemoteForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+type PackageVirtualSourceForm struct {
+	ID       int64
+	Type     string `binding:"Required;In(alpine,arch,cargo,container,debian,go,maven,npm,pypi,rpm)"`
+	Source   string `binding:"Required;MaxSize(255)"` // name of the source owner
+	Pattern  string `binding:"MaxSize(255)"`
+	Position int    `binding:"Range(0,1000)"`
+	Action   string `binding:"Required;In(save,remove)"`
+}
+
+func (f *PackageVirtualSourceForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
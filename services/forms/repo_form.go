@@ -184,6 +184,7 @@ type ProtectBranchForm struct {
 	ApprovalsWhitelistTeams       string
 	BlockOnRejectedReviews        bool
 	BlockOnOfficialReviewRequests bool
+	BlockOnCodeOwnerReviews       bool
 	BlockOnOutdatedBranch         bool
 	DismissStaleApprovals         bool
 	IgnoreStaleApprovals          bool
@@ -191,6 +192,8 @@ type ProtectBranchForm struct {
 	ProtectedFilePatterns         string
 	UnprotectedFilePatterns       string
 	BlockAdminMergeOverride       bool
+	EnableMergeQueue              bool
+	MergeQueueMaxBatchSize        int64
 }
 
 // Validate validates the fields
@@ -203,6 +206,22 @@ type ProtectBranchPriorityForm struct {
 	IDs []int64
 }
 
+// EnvironmentForm form for creating or editing a deployment environment of Actions
+type EnvironmentForm struct {
+	Name              string `binding:"Required;MaxSize(255)"`
+	ReviewerUsers     string
+	ReviewerTeams     string
+	PreventSelfReview bool
+	WaitTimer         int64 `binding:"Range(0,43200)"` // in minutes
+	BranchPatterns    string
+}
+
+// Validate validates the fields
+func (f *EnvironmentForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // WebhookForm form for changing web hook
 type WebhookForm struct {
 	Events                   string
@@ -227,11 +246,24 @@ type WebhookForm struct {
 	Repository               bool
 	Release                  bool
 	Package                  bool
+	PackageVulnerability     bool
 	Status                   bool
+	Member                   bool
+	Organization             bool
+	Team                     bool
+	Membership               bool
+	BranchProtection         bool
+	DeployKey                bool
+	RepositoryVisibility     bool
+	User                     bool
+	Star                     bool
+	Watch                    bool
 	WorkflowRun              bool
 	WorkflowJob              bool
 	Active                   bool
+	MaxAttempts              int    `binding:"Range(0,10)"`
 	BranchFilter             string `binding:"GlobPattern"`
+	Condition                string `binding:"WebhookCondition"`
 	AuthorizationHeader      string
 	Secret                   string
 }
@@ -405,6 +437,28 @@ func (f *NewPackagistHookForm) Validate(req *http.Request, errs binding.Errors)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// NewCustomHookForm form for creating custom hook
+type NewCustomHookForm struct {
+	PayloadURL         string `binding:"Required;ValidUrl"`
+	HTTPMethod         string `binding:"Required;In(POST,PUT)"`
+	PayloadContentType string
+	PayloadTemplate    string `binding:"Required"`
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewCustomHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	if _, err := webhook.ParseCustomPayloadTemplate(f.PayloadTemplate); err != nil {
+		errs = append(errs, binding.Error{
+			FieldNames:     []string{"PayloadTemplate"},
+			Classification: "",
+			Message:        ctx.Locale.TrString("repo.settings.custom_payload_template_invalid", err.Error()),
+		})
+	}
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // .___
 // |   | ______ ________ __   ____
 // |   |/  ___//  ___/  |  \_/ __ \
@@ -24,3 +24,33 @@ func (f *ProtectTagForm) Validate(req *http.Request, errs binding.Errors) bindin
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// RulesetForm form for creating or editing an organization or repository ruleset
+type RulesetForm struct {
+	Name                 string `binding:"Required;MaxSize(255)"`
+	Target               string `binding:"Required;In(branch,tag,push)"`
+	Enforcement          int    `binding:"Range(0,2)"`
+	IncludeRefs          string
+	ExcludeRefs          string
+	RepoPatterns         string
+	BypassUsers          string
+	BypassTeams          string
+	BypassDeployKeys     bool
+	BypassActions        bool
+	BypassRepoAdmins     bool
+	BlockCreation        bool
+	BlockDeletion        bool
+	BlockForcePush       bool
+	RequireLinearHistory bool
+	RequireSignedCommits bool
+	RequiredDeployments  string
+	CommitMessagePattern string
+	RestrictedFilePaths  string
+	MaxFileSize          int64 `binding:"Range(0,1048576)"` // in MiB
+}
+
+// Validate validates the fields
+func (f *RulesetForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -5,6 +5,7 @@ package indexer
 
 import (
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	dependencies_indexer "code.gitea.io/gitea/modules/indexer/dependencies"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
 	notify_service "code.gitea.io/gitea/services/notify"
@@ -16,5 +17,8 @@ func Init() error {
 
 	issue_indexer.InitIssueIndexer(false)
 	code_indexer.Init()
+	if err := dependencies_indexer.Init(); err != nil {
+		return err
+	}
 	return stats_indexer.Init()
 }
@@ -10,6 +10,7 @@ import (
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	code_indexer "code.gitea.io/gitea/modules/indexer/code"
+	dependencies_indexer "code.gitea.io/gitea/modules/indexer/dependencies"
 	issue_indexer "code.gitea.io/gitea/modules/indexer/issues"
 	stats_indexer "code.gitea.io/gitea/modules/indexer/stats"
 	"code.gitea.io/gitea/modules/log"
@@ -82,6 +83,11 @@ func (r *indexerNotifier) MigrateRepository(ctx context.Context, doer, u *user_m
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if !repo.IsEmpty {
+		if err := dependencies_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("dependencies_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
@@ -95,6 +101,11 @@ func (r *indexerNotifier) PushCommits(ctx context.Context, pusher *user_model.Us
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if opts.RefFullName.BranchName() == repo.DefaultBranch {
+		if err := dependencies_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("dependencies_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) SyncPushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits) {
@@ -108,6 +119,11 @@ func (r *indexerNotifier) SyncPushCommits(ctx context.Context, pusher *user_mode
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if opts.RefFullName.BranchName() == repo.DefaultBranch {
+		if err := dependencies_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("dependencies_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
@@ -117,6 +133,11 @@ func (r *indexerNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_mo
 	if err := stats_indexer.UpdateRepoIndexer(repo); err != nil {
 		log.Error("stats_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
 	}
+	if !repo.IsEmpty {
+		if err := dependencies_indexer.UpdateRepoIndexer(repo); err != nil {
+			log.Error("dependencies_indexer.UpdateRepoIndexer(%d) failed: %v", repo.ID, err)
+		}
+	}
 }
 
 func (r *indexerNotifier) IssueChangeContent(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldContent string) {
@@ -49,25 +49,18 @@ func IsCodeOwnerFile(f string) bool {
 	return slices.Contains(codeOwnerFiles, f)
 }
 
-func PullRequestCodeOwnersReview(ctx context.Context, pr *issues_model.PullRequest) ([]*ReviewRequestNotifier, error) {
-	if err := pr.LoadIssue(ctx); err != nil {
-		return nil, err
-	}
-	issue := pr.Issue
-	if pr.IsWorkInProgress(ctx) {
-		return nil, nil
-	}
-	if err := pr.LoadHeadRepo(ctx); err != nil {
-		return nil, err
-	}
+// CodeOwnersMatch is a CODEOWNERS rule together with the files changed by a pull request it owns
+type CodeOwnersMatch struct {
+	Rule  *issues_model.CodeOwnerRule
+	Files []string
+}
+
+// GetPullRequestCodeOwners returns the CODEOWNERS rules of the base repository which own at least one of the files
+// changed by the pull request, in the order they are defined
+func GetPullRequestCodeOwners(ctx context.Context, pr *issues_model.PullRequest) ([]*CodeOwnersMatch, error) {
 	if err := pr.LoadBaseRepo(ctx); err != nil {
 		return nil, err
 	}
-	pr.Issue.Repo = pr.BaseRepo
-
-	if pr.BaseRepo.IsFork {
-		return nil, nil
-	}
 
 	repo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
 	if err != nil {
@@ -111,19 +104,57 @@ func PullRequestCodeOwnersReview(ctx context.Context, pr *issues_model.PullReque
 		return nil, err
 	}
 
-	uniqUsers := make(map[int64]*user_model.User)
-	uniqTeams := make(map[string]*org_model.Team)
+	matches := make([]*CodeOwnersMatch, 0, len(rules))
 	for _, rule := range rules {
+		var files []string
 		for _, f := range changedFiles {
-			if (rule.Rule.MatchString(f) && !rule.Negative) || (!rule.Rule.MatchString(f) && rule.Negative) {
-				for _, u := range rule.Users {
-					uniqUsers[u.ID] = u
-				}
-				for _, t := range rule.Teams {
-					uniqTeams[fmt.Sprintf("%d/%d", t.OrgID, t.ID)] = t
-				}
+			if rule.MatchFile(f) {
+				files = append(files, f)
 			}
 		}
+		if len(files) > 0 {
+			matches = append(matches, &CodeOwnersMatch{Rule: rule, Files: files})
+		}
+	}
+	return matches, nil
+}
+
+func PullRequestCodeOwnersReview(ctx context.Context, pr *issues_model.PullRequest) ([]*ReviewRequestNotifier, error) {
+	if err := pr.LoadIssue(ctx); err != nil {
+		return nil, err
+	}
+	issue := pr.Issue
+	if pr.IsWorkInProgress(ctx) {
+		return nil, nil
+	}
+	if err := pr.LoadHeadRepo(ctx); err != nil {
+		return nil, err
+	}
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	pr.Issue.Repo = pr.BaseRepo
+
+	if pr.BaseRepo.IsFork {
+		return nil, nil
+	}
+
+	matches, err := GetPullRequestCodeOwners(ctx, pr)
+	if err != nil {
+		return nil, err
+	} else if len(matches) == 0 {
+		return nil, nil
+	}
+
+	uniqUsers := make(map[int64]*user_model.User)
+	uniqTeams := make(map[string]*org_model.Team)
+	for _, match := range matches {
+		for _, u := range match.Rule.Users {
+			uniqUsers[u.ID] = u
+		}
+		for _, t := range match.Rule.Teams {
+			uniqTeams[fmt.Sprintf("%d/%d", t.OrgID, t.ID)] = t
+		}
 	}
 
 	notifiers := make([]*ReviewRequestNotifier, 0, len(uniqUsers)+len(uniqTeams))
@@ -24,6 +24,7 @@ import (
 	git_model "code.gitea.io/gitea/models/git"
 	perm_model "code.gitea.io/gitea/models/perm"
 	access_model "code.gitea.io/gitea/models/perm/access"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	user_model "code.gitea.io/gitea/models/user"
@@ -33,6 +34,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/context"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -327,6 +329,16 @@ func UploadHandler(ctx *context.Context) {
 		return
 	}
 
+	if err := quota_model.CheckQuota(ctx, repository.OwnerID, quota_model.CategoryLFS, p.Size); err != nil {
+		if errors.Is(err, util.ErrContentTooLarge) {
+			writeStatusMessage(ctx, http.StatusRequestEntityTooLarge, err.Error())
+		} else {
+			log.Error("Unable to check the LFS quota of %s/%s. Error: %v", rc.User, rc.Repo, err)
+			writeStatus(ctx, http.StatusInternalServerError)
+		}
+		return
+	}
+
 	contentStore := lfs_module.NewContentStore()
 	exists, err := contentStore.Exists(p)
 	if err != nil {
@@ -0,0 +1,130 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/translation"
+	sender_service "code.gitea.io/gitea/services/mailer/sender"
+)
+
+const mailWebhookDisabled templates.TplName = "webhook/disabled"
+
+// SendWebhookDisabledMail notifies the owners of a webhook which has been deactivated because its deliveries failed repeatedly
+func SendWebhookDisabledMail(ctx context.Context, w *webhook_model.Webhook, failures int) error {
+	if setting.MailService == nil {
+		// No mail service configured
+		return nil
+	}
+
+	target, link, recipients, err := getWebhookOwners(ctx, w)
+	if err != nil {
+		return err
+	}
+
+	host := w.URL
+	if u, err := url.Parse(w.URL); err == nil && u.Host != "" {
+		// the path of the URL may contain credentials
+		host = u.Host
+	}
+
+	langMap := make(map[string][]*user_model.User)
+	for _, user := range recipients {
+		if !user.IsActive {
+			// don't send emails to inactive users
+			continue
+		}
+		langMap[user.Language] = append(langMap[user.Language], user)
+	}
+
+	for lang, tos := range langMap {
+		locale := translation.NewLocale(lang)
+		subject := locale.TrString("mail.webhook.disabled.subject", target)
+
+		data := map[string]any{
+			"locale":   locale,
+			"Subject":  subject,
+			"Target":   target,
+			"Host":     host,
+			"Failures": failures,
+			"Link":     link,
+			"Language": locale.Language(),
+		}
+
+		var content bytes.Buffer
+		if err := LoadedTemplates().BodyTemplates.ExecuteTemplate(&content, string(mailWebhookDisabled), data); err != nil {
+			return err
+		}
+
+		for _, to := range tos {
+			msg := sender_service.NewMessage(to.EmailTo(), subject, content.String())
+			msg.Info = fmt.Sprintf("UID: %d, webhook %d deactivated", to.ID, w.ID)
+
+			SendAsync(msg)
+		}
+	}
+
+	return nil
+}
+
+// getWebhookOwners returns the name of the repository or user the webhook belongs to, the link to its settings and the users who manage it
+func getWebhookOwners(ctx context.Context, w *webhook_model.Webhook) (string, string, []*user_model.User, error) {
+	if w.RepoID > 0 {
+		repo, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+		if err != nil {
+			return "", "", nil, err
+		}
+		if err := repo.LoadOwner(ctx); err != nil {
+			return "", "", nil, err
+		}
+		users, err := getOwnerAdmins(ctx, repo.Owner)
+		return repo.FullName(), fmt.Sprintf("%s/settings/hooks/%d", repo.HTMLURL(ctx), w.ID), users, err
+	}
+
+	if w.OwnerID > 0 {
+		owner, err := user_model.GetUserByID(ctx, w.OwnerID)
+		if err != nil {
+			return "", "", nil, err
+		}
+		link := fmt.Sprintf("%suser/settings/hooks/%d", setting.AppURL, w.ID)
+		if owner.IsOrganization() {
+			link = fmt.Sprintf("%sorg/%s/settings/hooks/%d", setting.AppURL, url.PathEscape(owner.Name), w.ID)
+		}
+		users, err := getOwnerAdmins(ctx, owner)
+		return owner.Name, link, users, err
+	}
+
+	// system and default webhooks are managed by the site administrators
+	admins, _, err := user_model.SearchUsers(ctx, user_model.SearchUserOptions{
+		ListOptions: db.ListOptionsAll,
+		Types:       []user_model.UserType{user_model.UserTypeIndividual},
+		IsAdmin:     optional.Some(true),
+	})
+	return setting.AppName, fmt.Sprintf("%s-/admin/hooks/%d", setting.AppURL, w.ID), admins, err
+}
+
+// getOwnerAdmins returns the user or the members of the owners team of an organization
+func getOwnerAdmins(ctx context.Context, owner *user_model.User) ([]*user_model.User, error) {
+	if !owner.IsOrganization() {
+		return []*user_model.User{owner}, nil
+	}
+
+	team, err := organization.GetOwnerTeam(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+	return organization.GetTeamMembers(ctx, &organization.SearchMembersOptions{TeamID: team.ID})
+}
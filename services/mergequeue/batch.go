@@ -0,0 +1,176 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/git/gitcmd"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/setting"
+	pull_service "code.gitea.io/gitea/services/pull"
+)
+
+// trackingBranch is the branch of the temporary repository the head of the merged pull request is fetched into
+const trackingBranch = "tracking"
+
+type batchResult struct {
+	batched      []*queuedPull
+	conflicted   []*queuedPull
+	baseCommitID string
+	headCommitID string
+}
+
+type batchRepo struct {
+	path string
+	env  []string
+}
+
+func (b *batchRepo) run(ctx context.Context, cmd *gitcmd.Command) (string, error) {
+	stdout, stderr, err := cmd.WithDir(b.path).WithEnv(b.env).RunStdString(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w\n%s\n%s", err, stdout, stderr)
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// buildBatch merges the pull requests one after another on top of the base branch in a temporary repository
+// and force-pushes the result to the queue branch. Pull requests which do not merge cleanly on top of the
+// previous ones are left out of the batch.
+func buildBatch(ctx context.Context, repo *repo_model.Repository, baseBranch string, candidates []*queuedPull) (*batchResult, error) {
+	baseGitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer baseGitRepo.Close()
+
+	tmpBasePath, cleanup, err := repo_module.CreateTemporaryPath("merge-queue")
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	if err := git.Clone(ctx, repo.RepoPath(), tmpBasePath, git.CloneRepoOptions{
+		Shared: true,
+		Quiet:  true,
+		Branch: baseBranch,
+	}); err != nil {
+		return nil, fmt.Errorf("unable to clone %s to temporary repo: %w", repo.FullName(), err)
+	}
+
+	doer := queueDoer(candidates)
+	tmpRepo := &batchRepo{path: tmpBasePath, env: repo_module.PushingEnvironment(doer, repo)}
+
+	result := &batchResult{}
+	if result.baseCommitID, err = tmpRepo.run(ctx, gitcmd.NewCommand("rev-parse", "HEAD")); err != nil {
+		return nil, err
+	}
+
+	tip := result.baseCommitID
+	for _, q := range candidates {
+		if _, err := tmpRepo.run(ctx, gitcmd.NewCommand("fetch", "--no-tags", "origin").
+			AddDynamicArguments("+"+q.pr.GetGitHeadRefName()+":"+git.BranchPrefix+trackingBranch)); err != nil {
+			return nil, fmt.Errorf("unable to fetch head of %v: %w", q.pr, err)
+		}
+
+		message := q.entry.Message
+		if message == "" {
+			if message, _, err = pull_service.GetDefaultMergeMessage(ctx, baseGitRepo, q.pr, q.entry.MergeStyle); err != nil {
+				return nil, err
+			}
+		}
+
+		// commits of the batch are created by the user who queued the pull request
+		tmpRepo.env = repo_module.PushingEnvironment(q.entry.Doer, repo)
+		if err := mergeIntoBatch(ctx, tmpRepo, baseBranch, tip, q.entry.MergeStyle, message); err != nil {
+			log.Debug("Queued %-v cannot be merged on top of merge queue batch: %v", q.pr, err)
+			if err := resetBatch(ctx, tmpRepo, baseBranch, tip); err != nil {
+				return nil, err
+			}
+			result.conflicted = append(result.conflicted, q)
+			continue
+		}
+
+		newTip, err := tmpRepo.run(ctx, gitcmd.NewCommand("rev-parse", "HEAD"))
+		if err != nil {
+			return nil, err
+		}
+		if setting.LFS.StartServer {
+			if err := pull_service.LFSPush(ctx, tmpBasePath, newTip, tip, q.pr); err != nil {
+				return nil, err
+			}
+		}
+		tip = newTip
+		q.entry.MergeCommitID = tip
+		result.batched = append(result.batched, q)
+	}
+
+	if len(result.batched) == 0 {
+		return result, nil
+	}
+	result.headCommitID = tip
+
+	// the queue branch is pushed with the hooks enabled, so the CI runs on the combined result
+	tmpRepo.env = append(repo_module.PushingEnvironment(doer, repo), repo_module.EnvPushTrigger+"="+string(repo_module.PushTriggerMergeQueue))
+	if _, err := tmpRepo.run(ctx, gitcmd.NewCommand("push", "--force", "origin").
+		AddDynamicArguments(tip+":"+git.BranchPrefix+pull_model.MergeQueueBranchName(baseBranch))); err != nil {
+		return nil, fmt.Errorf("unable to push merge queue branch: %w", err)
+	}
+	return result, nil
+}
+
+// mergeIntoBatch merges the tracking branch on top of the batch with the given merge style.
+// The base branch of the temporary repository is checked out and points to tip.
+func mergeIntoBatch(ctx context.Context, tmpRepo *batchRepo, baseBranch, tip string, style repo_model.MergeStyle, message string) error {
+	switch style {
+	case repo_model.MergeStyleMerge:
+		_, err := tmpRepo.run(ctx, gitcmd.NewCommand("merge", "--no-ff", "--no-gpg-sign").
+			AddOptionFormat("--message=%s", message).AddDynamicArguments(trackingBranch))
+		return err
+	case repo_model.MergeStyleSquash:
+		if _, err := tmpRepo.run(ctx, gitcmd.NewCommand("merge", "--squash").AddDynamicArguments(trackingBranch)); err != nil {
+			return err
+		}
+		_, err := tmpRepo.run(ctx, gitcmd.NewCommand("commit", "--no-gpg-sign").AddOptionFormat("--message=%s", message))
+		return err
+	case repo_model.MergeStyleRebase, repo_model.MergeStyleRebaseMerge:
+		if _, err := tmpRepo.run(ctx, gitcmd.NewCommand("rebase", "--no-gpg-sign").AddDynamicArguments(tip, trackingBranch)); err != nil {
+			return err
+		}
+		if _, err := tmpRepo.run(ctx, gitcmd.NewCommand("checkout").AddDynamicArguments(baseBranch)); err != nil {
+			return err
+		}
+		if style == repo_model.MergeStyleRebase {
+			_, err := tmpRepo.run(ctx, gitcmd.NewCommand("merge", "--ff-only").AddDynamicArguments(trackingBranch))
+			return err
+		}
+		_, err := tmpRepo.run(ctx, gitcmd.NewCommand("merge", "--no-ff", "--no-gpg-sign").
+			AddOptionFormat("--message=%s", message).AddDynamicArguments(trackingBranch))
+		return err
+	case repo_model.MergeStyleFastForwardOnly:
+		_, err := tmpRepo.run(ctx, gitcmd.NewCommand("merge", "--ff-only").AddDynamicArguments(trackingBranch))
+		return err
+	}
+	return pull_service.ErrInvalidMergeStyle{Style: style}
+}
+
+// resetBatch drops a failed merge and restores the base branch of the temporary repository to tip
+func resetBatch(ctx context.Context, tmpRepo *batchRepo, baseBranch, tip string) error {
+	// only one of them can be in progress, the others fail and are ignored
+	_, _ = tmpRepo.run(ctx, gitcmd.NewCommand("rebase", "--abort"))
+	_, _ = tmpRepo.run(ctx, gitcmd.NewCommand("merge", "--abort"))
+
+	if _, err := tmpRepo.run(ctx, gitcmd.NewCommand("checkout", "--force").AddDynamicArguments(baseBranch)); err != nil {
+		return err
+	}
+	_, err := tmpRepo.run(ctx, gitcmd.NewCommand("reset", "--hard").AddDynamicArguments(tip))
+	return err
+}
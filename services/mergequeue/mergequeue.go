@@ -0,0 +1,152 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/queue"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// Reasons stored in the content of CommentTypePRRemovedFromMergeQueue comments
+const (
+	RemovedReasonCanceled     = ""
+	RemovedReasonChecksFailed = "checks_failed"
+	RemovedReasonConflict     = "conflict"
+	RemovedReasonRejected     = "rejected"
+	RemovedReasonHeadUpdated  = "head_updated"
+	RemovedReasonClosed       = "closed"
+)
+
+var mergeQueue *queue.WorkerPoolQueue[string]
+
+// Init runs the task queue that tests and merges the batches of the merge queues
+func Init() error {
+	notify_service.RegisterNotifier(NewNotifier())
+
+	mergeQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "pr_merge_queue", handler)
+	if mergeQueue == nil {
+		return errors.New("unable to create pr_merge_queue queue")
+	}
+	go graceful.GetManager().RunWithCancel(mergeQueue)
+	return nil
+}
+
+func queueItem(repoID int64, baseBranch string) string {
+	return fmt.Sprintf("%d:%s", repoID, baseBranch)
+}
+
+func parseQueueItem(item string) (repoID int64, baseBranch string, err error) {
+	id, branch, ok := strings.Cut(item, ":")
+	if !ok || branch == "" {
+		return 0, "", fmt.Errorf("invalid merge queue item %q", item)
+	}
+	repoID, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid merge queue item %q: %w", item, err)
+	}
+	return repoID, branch, nil
+}
+
+// handle passed repository branches and process their merge queues
+func handler(items ...string) []string {
+	for _, item := range items {
+		repoID, baseBranch, err := parseQueueItem(item)
+		if err != nil {
+			log.Error("could not parse data from pr_merge_queue queue: %v", err)
+			continue
+		}
+		processMergeQueue(repoID, baseBranch)
+	}
+	return nil
+}
+
+// StartMergeQueueCheck schedules processing of the merge queue of a base branch
+func StartMergeQueueCheck(repoID int64, baseBranch string) {
+	if err := mergeQueue.Push(queueItem(repoID, baseBranch)); err != nil && !errors.Is(err, queue.ErrAlreadyInQueue) {
+		log.Error("Error adding %d:%s to the merge queue: %v", repoID, baseBranch, err)
+	}
+}
+
+// IsMergeQueueEnabled returns whether merges into the base branch of the pull request go through the merge queue
+func IsMergeQueueEnabled(ctx context.Context, pr *issues_model.PullRequest) (bool, error) {
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		return false, err
+	}
+	return pb != nil && pb.EnableMergeQueue, nil
+}
+
+// AddToMergeQueue appends the pull request to the merge queue of its base branch
+func AddToMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, style repo_model.MergeStyle, message string, deleteBranchAfterMerge bool) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+
+	err := db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := pull_model.AddToMergeQueue(ctx, doer, pr.BaseRepoID, pr.BaseBranch, pr.ID, style, message, deleteBranchAfterMerge); err != nil {
+			return err
+		}
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRAddedToMergeQueue, pr, doer, "")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Trace("Pull request [%d] added to the merge queue of %s with style [%s]", pr.ID, pr.BaseBranch, style)
+	StartMergeQueueCheck(pr.BaseRepoID, pr.BaseBranch)
+	return nil
+}
+
+// RemoveFromMergeQueue removes the pull request from the merge queue, the reason is recorded in the timeline.
+// If the pull request was part of the batch under test, the batch is rebuilt without it.
+func RemoveFromMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, reason string) error {
+	entry, err := dequeuePull(ctx, doer, pr, reason)
+	if err != nil {
+		return err
+	}
+
+	if entry.Status == pull_model.MergeQueueStatusTesting {
+		StartMergeQueueCheck(entry.RepoID, entry.BaseBranch)
+	}
+	return nil
+}
+
+// dequeuePull removes the pull request from the merge queue without scheduling the processing of the queue,
+// it is used while the queue is processed and the base branch is locked
+func dequeuePull(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, reason string) (*pull_model.MergeQueueEntry, error) {
+	entry, exist, err := pull_model.GetMergeQueueEntryByPullID(ctx, pr.ID)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, db.ErrNotExist{Resource: "merge_queue", ID: pr.ID}
+	}
+
+	return entry, db.WithTx(ctx, func(ctx context.Context) error {
+		if err := pull_model.RemoveFromMergeQueue(ctx, pr.ID); err != nil {
+			return err
+		}
+		if entry.Status == pull_model.MergeQueueStatusTesting {
+			if err := pull_model.ResetMergeQueueBatch(ctx, entry.RepoID, entry.BaseBranch); err != nil {
+				return err
+			}
+		}
+		_, err := issues_model.CreateMergeQueueComment(ctx, issues_model.CommentTypePRRemovedFromMergeQueue, pr, doer, reason)
+		return err
+	})
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueueItem(t *testing.T) {
+	repoID, branch, err := parseQueueItem(queueItem(12, "release/v1.2:rc"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 12, repoID)
+	assert.Equal(t, "release/v1.2:rc", branch)
+
+	for _, item := range []string{"", "12", "12:", "abc:main"} {
+		_, _, err = parseQueueItem(item)
+		assert.Error(t, err, "item %q", item)
+	}
+}
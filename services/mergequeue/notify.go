@@ -0,0 +1,83 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/repository"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+type mergeQueueNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &mergeQueueNotifier{}
+
+// NewNotifier create a new mergeQueueNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &mergeQueueNotifier{}
+}
+
+func (n *mergeQueueNotifier) CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, commit *repository.PushCommit, sender *user_model.User, status *git_model.CommitStatus) {
+	// a status of the batch under test may complete its required checks
+	entries, err := pull_model.GetMergeQueueEntriesByBatchHead(ctx, repo.ID, commit.Sha1)
+	if err != nil {
+		log.Error("GetMergeQueueEntriesByBatchHead[repo_id: %d, sha: %s]: %v", repo.ID, commit.Sha1, err)
+		return
+	}
+	if len(entries) > 0 {
+		StartMergeQueueCheck(repo.ID, entries[0].BaseBranch)
+	}
+}
+
+func (n *mergeQueueNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool) {
+	if !issue.IsPull || !closeOrReopen {
+		return
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		log.Error("LoadPullRequest: %v", err)
+		return
+	}
+	removeFromMergeQueue(ctx, doer, issue.PullRequest, RemovedReasonClosed)
+}
+
+func (n *mergeQueueNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	// the queued head is no longer the one which was approved and tested
+	removeFromMergeQueue(ctx, doer, pr, RemovedReasonHeadUpdated)
+}
+
+func (n *mergeQueueNotifier) PullRequestChangeTargetBranch(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, oldBranch string) {
+	removeFromMergeQueue(ctx, doer, pr, RemovedReasonCanceled)
+}
+
+func (n *mergeQueueNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	dropMergedPull(ctx, pr)
+}
+
+func (n *mergeQueueNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	dropMergedPull(ctx, pr)
+}
+
+func removeFromMergeQueue(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest, reason string) {
+	if err := RemoveFromMergeQueue(ctx, doer, pr, reason); err != nil && !db.IsErrNotExist(err) {
+		log.Error("RemoveFromMergeQueue %-v: %v", pr, err)
+	}
+}
+
+// dropMergedPull removes a merged pull request from the queue. The batch is not reset: the pull request is part
+// of the base branch now and a moved base branch is detected when the batch is checked.
+func dropMergedPull(ctx context.Context, pr *issues_model.PullRequest) {
+	if err := pull_model.RemoveFromMergeQueue(ctx, pr.ID); err != nil && !db.IsErrNotExist(err) {
+		log.Error("RemoveFromMergeQueue %-v: %v", pr, err)
+	}
+}
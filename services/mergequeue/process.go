@@ -0,0 +1,291 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/process"
+	pull_service "code.gitea.io/gitea/services/pull"
+	repo_service "code.gitea.io/gitea/services/repository"
+)
+
+// queuedPull is a merge queue entry together with its loaded pull request
+type queuedPull struct {
+	entry *pull_model.MergeQueueEntry
+	pr    *issues_model.PullRequest
+}
+
+func getMergeQueueLockKey(repoID int64, baseBranch string) string {
+	return fmt.Sprintf("merge_queue_%d_%s", repoID, baseBranch)
+}
+
+// processMergeQueue checks the batch under test of a base branch, merges it if its checks passed
+// and starts testing the next batch
+func processMergeQueue(repoID int64, baseBranch string) {
+	ctx, _, finished := process.GetManager().AddContext(graceful.GetManager().HammerContext(),
+		fmt.Sprintf("Handle merge queue of Repo[%d] branch [%s]", repoID, baseBranch))
+	defer finished()
+
+	releaser, err := globallock.Lock(ctx, getMergeQueueLockKey(repoID, baseBranch))
+	if err != nil {
+		log.Error("lock.Lock(): %v", err)
+		return
+	}
+	defer releaser()
+
+	repo, err := repo_model.GetRepositoryByID(ctx, repoID)
+	if err != nil {
+		log.Error("GetRepositoryByID[%d]: %v", repoID, err)
+		return
+	}
+
+	pb, err := git_model.GetFirstMatchProtectedBranchRule(ctx, repoID, baseBranch)
+	if err != nil {
+		log.Error("GetFirstMatchProtectedBranchRule[%d:%s]: %v", repoID, baseBranch, err)
+		return
+	}
+	if pb == nil || !pb.EnableMergeQueue {
+		log.Debug("Merge queue of %-v branch [%s] is disabled, keep the queued pull requests", repo, baseBranch)
+		return
+	}
+
+	queued, err := loadQueuedPulls(ctx, repoID, baseBranch)
+	if err != nil {
+		log.Error("loadQueuedPulls[%d:%s]: %v", repoID, baseBranch, err)
+		return
+	}
+
+	batchSize := pb.GetMergeQueueMaxBatchSize()
+	if testing := filterQueuedPulls(queued, pull_model.MergeQueueStatusTesting); len(testing) > 0 {
+		done, nextBatchSize, err := checkBatch(ctx, repo, pb, testing)
+		if err != nil {
+			log.Error("checkBatch of %-v branch [%s]: %v", repo, baseBranch, err)
+			return
+		}
+		if !done {
+			return
+		}
+		if nextBatchSize > 0 {
+			batchSize = nextBatchSize
+		}
+		if queued, err = loadQueuedPulls(ctx, repoID, baseBranch); err != nil {
+			log.Error("loadQueuedPulls[%d:%s]: %v", repoID, baseBranch, err)
+			return
+		}
+	}
+
+	waiting := filterQueuedPulls(queued, pull_model.MergeQueueStatusWaiting)
+	if len(waiting) == 0 {
+		return
+	}
+	if err := startBatch(ctx, repo, baseBranch, waiting[:min(batchSize, len(waiting))]); err != nil {
+		log.Error("startBatch of %-v branch [%s]: %v", repo, baseBranch, err)
+	}
+}
+
+// loadQueuedPulls loads the queue of a base branch, entries whose pull request can no longer be merged are dropped
+func loadQueuedPulls(ctx context.Context, repoID int64, baseBranch string) ([]*queuedPull, error) {
+	entries, err := pull_model.GetMergeQueueEntries(ctx, repoID, baseBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	queued := make([]*queuedPull, 0, len(entries))
+	for _, entry := range entries {
+		pr, err := issues_model.GetPullRequestByID(ctx, entry.PullID)
+		if err != nil && !issues_model.IsErrPullRequestNotExist(err) {
+			return nil, err
+		}
+		if pr == nil || pr.HasMerged {
+			if err := pull_model.RemoveFromMergeQueue(ctx, entry.PullID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := entry.LoadDoer(ctx); err != nil {
+			return nil, err
+		}
+		queued = append(queued, &queuedPull{entry: entry, pr: pr})
+	}
+	return queued, nil
+}
+
+func filterQueuedPulls(queued []*queuedPull, status pull_model.MergeQueueStatus) []*queuedPull {
+	filtered := make([]*queuedPull, 0, len(queued))
+	for _, q := range queued {
+		if q.entry.Status == status {
+			filtered = append(filtered, q)
+		}
+	}
+	return filtered
+}
+
+// checkBatch looks at the commit status of the batch under test. If the required checks passed, the batch is merged.
+// done is false while the checks are still running. If the checks failed for a batch of several pull requests,
+// nextBatchSize asks to retest the first half of it alone, so the failing pull request gets isolated.
+func checkBatch(ctx context.Context, repo *repo_model.Repository, pb *git_model.ProtectedBranch, batch []*queuedPull) (done bool, nextBatchSize int, err error) {
+	baseBranch := batch[0].entry.BaseBranch
+	headCommitID := batch[0].entry.BatchHeadCommitID
+
+	baseCommitID, err := gitrepo.GetBranchCommitID(ctx, repo, baseBranch)
+	if err != nil {
+		return false, 0, err
+	}
+	if baseCommitID != batch[0].entry.BatchBaseCommitID {
+		log.Debug("Base branch [%s] of %-v moved while testing merge queue batch %s, rebuilding it", baseBranch, repo, headCommitID)
+		return true, 0, pull_model.ResetMergeQueueBatch(ctx, repo.ID, baseBranch)
+	}
+
+	state := commitstatus.CommitStatusSuccess
+	if pb.EnableStatusCheck {
+		commitStatuses, err := git_model.GetLatestCommitStatus(ctx, repo.ID, headCommitID, db.ListOptionsAll)
+		if err != nil {
+			return false, 0, err
+		}
+		state = pull_service.MergeRequiredContextsCommitStatus(commitStatuses, pb.StatusCheckContexts)
+	}
+
+	switch {
+	case state.IsSuccess():
+		return true, 0, mergeBatch(ctx, repo, batch)
+	case state.IsPending():
+		return false, 0, nil
+	}
+
+	log.Debug("Required checks of merge queue batch %s of %-v branch [%s] failed", headCommitID, repo, baseBranch)
+	if len(batch) == 1 {
+		_, err := dequeuePull(ctx, batch[0].entry.Doer, batch[0].pr, RemovedReasonChecksFailed)
+		return true, 0, err
+	}
+	return true, len(batch) / 2, pull_model.ResetMergeQueueBatch(ctx, repo.ID, baseBranch)
+}
+
+// mergeBatch fast-forwards the base branch to the queue branch, one pull request after the other
+func mergeBatch(ctx context.Context, repo *repo_model.Repository, batch []*queuedPull) error {
+	for _, q := range batch {
+		if err := pull_service.MergeQueuedCommit(ctx, q.pr, q.entry.Doer, q.entry.MergeCommitID); err != nil {
+			if git.IsErrPushOutOfDate(err) {
+				log.Debug("Base branch of %-v moved while merging merge queue batch, rebuilding it", q.pr)
+				return pull_model.ResetMergeQueueBatch(ctx, repo.ID, q.entry.BaseBranch)
+			}
+			if git.IsErrPushRejected(err) {
+				log.Info("Merge of queued %-v was rejected: %v", q.pr, err)
+				_, err := dequeuePull(ctx, q.entry.Doer, q.pr, RemovedReasonRejected)
+				return err
+			}
+			return err
+		}
+
+		if err := pull_model.RemoveFromMergeQueue(ctx, q.pr.ID); err != nil && !db.IsErrNotExist(err) {
+			return err
+		}
+
+		deleteBranchAfterMerge, err := pull_service.ShouldDeleteBranchAfterMerge(ctx, &q.entry.DeleteBranchAfterMerge, repo, q.pr)
+		if err != nil {
+			log.Error("ShouldDeleteBranchAfterMerge: %v", err)
+		} else if deleteBranchAfterMerge {
+			if err = repo_service.DeleteBranchAfterMerge(ctx, q.entry.Doer, q.pr.ID, nil); err != nil {
+				log.Error("DeleteBranchAfterMerge: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// startBatch merges the waiting pull requests on top of the base branch and pushes the result to the queue branch,
+// where the CI runs on the combined result
+func startBatch(ctx context.Context, repo *repo_model.Repository, baseBranch string, waiting []*queuedPull) error {
+	candidates := make([]*queuedPull, 0, len(waiting))
+	for _, q := range waiting {
+		err := checkQueuedPullMergeable(ctx, q)
+		switch {
+		case err == nil, errors.Is(err, pull_service.ErrIsChecking):
+			// a running conflict check does not block the batch, conflicts are detected when building it
+			candidates = append(candidates, q)
+		case errors.Is(err, pull_service.ErrIsClosed):
+			if _, err := dequeuePull(ctx, q.entry.Doer, q.pr, RemovedReasonClosed); err != nil {
+				return err
+			}
+		case isNotMergeableError(err):
+			log.Debug("Queued %-v is not mergeable: %v", q.pr, err)
+			if _, err := dequeuePull(ctx, q.entry.Doer, q.pr, RemovedReasonRejected); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	result, err := buildBatch(ctx, repo, baseBranch, candidates)
+	if err != nil {
+		return err
+	}
+	for _, q := range result.conflicted {
+		if _, err := dequeuePull(ctx, q.entry.Doer, q.pr, RemovedReasonConflict); err != nil {
+			return err
+		}
+	}
+	if len(result.batched) == 0 {
+		return nil
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for _, q := range result.batched {
+			q.entry.Status = pull_model.MergeQueueStatusTesting
+			q.entry.BatchBaseCommitID = result.baseCommitID
+			q.entry.BatchHeadCommitID = result.headCommitID
+			if err := pull_model.UpdateMergeQueueEntryBatch(ctx, q.entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func checkQueuedPullMergeable(ctx context.Context, q *queuedPull) error {
+	if err := q.pr.LoadBaseRepo(ctx); err != nil {
+		return err
+	}
+	if q.entry.Doer.IsGhost() {
+		return pull_service.ErrNoPermissionToMerge
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, q.pr.BaseRepo, q.entry.Doer)
+	if err != nil {
+		return err
+	}
+	return pull_service.CheckPullMergeable(ctx, q.entry.Doer, &perm, q.pr, pull_service.MergeCheckTypeGeneral, false)
+}
+
+func isNotMergeableError(err error) bool {
+	return errors.Is(err, pull_service.ErrHasMerged) ||
+		errors.Is(err, pull_service.ErrNoPermissionToMerge) ||
+		errors.Is(err, pull_service.ErrNotReadyToMerge) ||
+		errors.Is(err, pull_service.ErrIsWorkInProgress) ||
+		errors.Is(err, pull_service.ErrNotMergeableState) ||
+		errors.Is(err, pull_service.ErrDependenciesLeft)
+}
+
+// queueDoer returns the user who pushes the queue branch, which is the user who queued the first pull request of the batch
+func queueDoer(batch []*queuedPull) *user_model.User {
+	return batch[0].entry.Doer
+}
@@ -7,9 +7,12 @@ import (
 	"context"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -28,6 +31,20 @@ type Notifier interface {
 	RenameRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, oldRepoName string)
 	TransferRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, oldOwnerName string)
 	RepoPendingTransfer(ctx context.Context, doer, newOwner *user_model.User, repo *repo_model.Repository)
+	ChangeRepositoryVisibility(ctx context.Context, doer *user_model.User, repo *repo_model.Repository)
+	StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool)
+	WatchRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, watch bool)
+
+	AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode)
+	ChangeCollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, oldMode, mode perm.AccessMode)
+	RemoveCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User)
+
+	CreateBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch)
+	UpdateBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch)
+	DeleteBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch)
+
+	AddDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey)
+	DeleteDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey)
 
 	NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User)
 	IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool)
@@ -74,6 +91,21 @@ type Notifier interface {
 
 	PackageCreate(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
 	PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor)
+	PackageVulnerabilitiesDetected(ctx context.Context, pd *packages_model.PackageDescriptor, vulnerabilities []*packages_model.PackageVulnerability)
+
+	AddOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User)
+	RemoveOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User)
+
+	CreateTeam(ctx context.Context, doer *user_model.User, team *organization.Team)
+	UpdateTeam(ctx context.Context, doer *user_model.User, team *organization.Team)
+	DeleteTeam(ctx context.Context, doer *user_model.User, team *organization.Team)
+	AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User)
+	RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User)
+	AddTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository)
+	RemoveTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository)
+
+	CreateUser(ctx context.Context, doer, u *user_model.User)
+	ChangeUserSuspension(ctx context.Context, doer, u *user_model.User, suspended bool)
 
 	ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository)
 
@@ -7,9 +7,12 @@ import (
 	"context"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -386,6 +389,167 @@ func PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_mode
 	}
 }
 
+// PackageVulnerabilitiesDetected notifies newly detected vulnerabilities of a package version to notifiers
+func PackageVulnerabilitiesDetected(ctx context.Context, pd *packages_model.PackageDescriptor, vulnerabilities []*packages_model.PackageVulnerability) {
+	for _, notifier := range notifiers {
+		notifier.PackageVulnerabilitiesDetected(ctx, pd, vulnerabilities)
+	}
+}
+
+// ChangeRepositoryVisibility notifies a visibility change of a repository to notifiers
+func ChangeRepositoryVisibility(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) {
+	for _, notifier := range notifiers {
+		notifier.ChangeRepositoryVisibility(ctx, doer, repo)
+	}
+}
+
+// StarRepository notifies a repository being starred or unstarred to notifiers
+func StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) {
+	for _, notifier := range notifiers {
+		notifier.StarRepository(ctx, doer, repo, star)
+	}
+}
+
+// WatchRepository notifies a repository being watched or unwatched to notifiers
+func WatchRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, watch bool) {
+	for _, notifier := range notifiers {
+		notifier.WatchRepository(ctx, doer, repo, watch)
+	}
+}
+
+// AddCollaborator notifies a new collaborator of a repository to notifiers
+func AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+	for _, notifier := range notifiers {
+		notifier.AddCollaborator(ctx, doer, repo, collaborator, mode)
+	}
+}
+
+// ChangeCollaboratorAccessMode notifies a permission change of a collaborator to notifiers
+func ChangeCollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, oldMode, mode perm.AccessMode) {
+	for _, notifier := range notifiers {
+		notifier.ChangeCollaboratorAccessMode(ctx, doer, repo, collaborator, oldMode, mode)
+	}
+}
+
+// RemoveCollaborator notifies removal of a collaborator to notifiers
+func RemoveCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.RemoveCollaborator(ctx, doer, repo, collaborator)
+	}
+}
+
+// CreateBranchProtection notifies creation of a branch protection rule to notifiers
+func CreateBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	for _, notifier := range notifiers {
+		notifier.CreateBranchProtection(ctx, doer, repo, rule)
+	}
+}
+
+// UpdateBranchProtection notifies update of a branch protection rule to notifiers
+func UpdateBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	for _, notifier := range notifiers {
+		notifier.UpdateBranchProtection(ctx, doer, repo, rule)
+	}
+}
+
+// DeleteBranchProtection notifies deletion of a branch protection rule to notifiers
+func DeleteBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	for _, notifier := range notifiers {
+		notifier.DeleteBranchProtection(ctx, doer, repo, rule)
+	}
+}
+
+// AddDeployKey notifies a new deploy key of a repository to notifiers
+func AddDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey) {
+	for _, notifier := range notifiers {
+		notifier.AddDeployKey(ctx, doer, repo, key)
+	}
+}
+
+// DeleteDeployKey notifies deletion of a deploy key to notifiers
+func DeleteDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey) {
+	for _, notifier := range notifiers {
+		notifier.DeleteDeployKey(ctx, doer, repo, key)
+	}
+}
+
+// AddOrgMember notifies a new member of an organization to notifiers
+func AddOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.AddOrgMember(ctx, doer, org, member)
+	}
+}
+
+// RemoveOrgMember notifies removal of a member of an organization to notifiers
+func RemoveOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.RemoveOrgMember(ctx, doer, org, member)
+	}
+}
+
+// CreateTeam notifies creation of a team to notifiers
+func CreateTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+	for _, notifier := range notifiers {
+		notifier.CreateTeam(ctx, doer, team)
+	}
+}
+
+// UpdateTeam notifies update of a team to notifiers
+func UpdateTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+	for _, notifier := range notifiers {
+		notifier.UpdateTeam(ctx, doer, team)
+	}
+}
+
+// DeleteTeam notifies deletion of a team to notifiers
+func DeleteTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+	for _, notifier := range notifiers {
+		notifier.DeleteTeam(ctx, doer, team)
+	}
+}
+
+// AddTeamMember notifies a new member of a team to notifiers
+func AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.AddTeamMember(ctx, doer, team, member)
+	}
+}
+
+// RemoveTeamMember notifies removal of a member of a team to notifiers
+func RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.RemoveTeamMember(ctx, doer, team, member)
+	}
+}
+
+// AddTeamRepository notifies a repository added to a team to notifiers
+func AddTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	for _, notifier := range notifiers {
+		notifier.AddTeamRepository(ctx, doer, team, repo)
+	}
+}
+
+// RemoveTeamRepository notifies a repository removed from a team to notifiers
+func RemoveTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	for _, notifier := range notifiers {
+		notifier.RemoveTeamRepository(ctx, doer, team, repo)
+	}
+}
+
+// CreateUser notifies creation of a user to notifiers
+func CreateUser(ctx context.Context, doer, u *user_model.User) {
+	for _, notifier := range notifiers {
+		notifier.CreateUser(ctx, doer, u)
+	}
+}
+
+// ChangeUserSuspension notifies a user being suspended or unsuspended to notifiers
+func ChangeUserSuspension(ctx context.Context, doer, u *user_model.User, suspended bool) {
+	for _, notifier := range notifiers {
+		notifier.ChangeUserSuspension(ctx, doer, u, suspended)
+	}
+}
+
 // ChangeDefaultBranch notifies change default branch to notifiers
 func ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 	for _, notifier := range notifiers {
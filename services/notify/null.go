@@ -7,9 +7,12 @@ import (
 	"context"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/git"
@@ -207,6 +210,98 @@ func (*NullNotifier) PackageCreate(ctx context.Context, doer *user_model.User, p
 func (*NullNotifier) PackageDelete(ctx context.Context, doer *user_model.User, pd *packages_model.PackageDescriptor) {
 }
 
+// PackageVulnerabilitiesDetected places a place holder function
+func (*NullNotifier) PackageVulnerabilitiesDetected(ctx context.Context, pd *packages_model.PackageDescriptor, vulnerabilities []*packages_model.PackageVulnerability) {
+}
+
+// ChangeRepositoryVisibility places a place holder function
+func (*NullNotifier) ChangeRepositoryVisibility(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) {
+}
+
+// StarRepository places a place holder function
+func (*NullNotifier) StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) {
+}
+
+// WatchRepository places a place holder function
+func (*NullNotifier) WatchRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, watch bool) {
+}
+
+// AddCollaborator places a place holder function
+func (*NullNotifier) AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+}
+
+// ChangeCollaboratorAccessMode places a place holder function
+func (*NullNotifier) ChangeCollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, oldMode, mode perm.AccessMode) {
+}
+
+// RemoveCollaborator places a place holder function
+func (*NullNotifier) RemoveCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) {
+}
+
+// CreateBranchProtection places a place holder function
+func (*NullNotifier) CreateBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+}
+
+// UpdateBranchProtection places a place holder function
+func (*NullNotifier) UpdateBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+}
+
+// DeleteBranchProtection places a place holder function
+func (*NullNotifier) DeleteBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+}
+
+// AddDeployKey places a place holder function
+func (*NullNotifier) AddDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey) {
+}
+
+// DeleteDeployKey places a place holder function
+func (*NullNotifier) DeleteDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey) {
+}
+
+// AddOrgMember places a place holder function
+func (*NullNotifier) AddOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+}
+
+// RemoveOrgMember places a place holder function
+func (*NullNotifier) RemoveOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+}
+
+// CreateTeam places a place holder function
+func (*NullNotifier) CreateTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+}
+
+// UpdateTeam places a place holder function
+func (*NullNotifier) UpdateTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+}
+
+// DeleteTeam places a place holder function
+func (*NullNotifier) DeleteTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+}
+
+// AddTeamMember places a place holder function
+func (*NullNotifier) AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+}
+
+// RemoveTeamMember places a place holder function
+func (*NullNotifier) RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+}
+
+// AddTeamRepository places a place holder function
+func (*NullNotifier) AddTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+}
+
+// RemoveTeamRepository places a place holder function
+func (*NullNotifier) RemoveTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+}
+
+// CreateUser places a place holder function
+func (*NullNotifier) CreateUser(ctx context.Context, doer, u *user_model.User) {
+}
+
+// ChangeUserSuspension places a place holder function
+func (*NullNotifier) ChangeUserSuspension(ctx context.Context, doer, u *user_model.User, suspended bool) {
+}
+
 // ChangeDefaultBranch places a place holder function
 func (*NullNotifier) ChangeDefaultBranch(ctx context.Context, repo *repo_model.Repository) {
 }
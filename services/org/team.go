@@ -19,6 +19,8 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
+	audit_service "code.gitea.io/gitea/services/audit"
+	notify_service "code.gitea.io/gitea/services/notify"
 	repo_service "code.gitea.io/gitea/services/repository"
 
 	"xorm.io/builder"
@@ -26,7 +28,7 @@ import (
 
 // NewTeam creates a record of new team.
 // It's caller's responsibility to assign organization ID.
-func NewTeam(ctx context.Context, t *organization.Team) (err error) {
+func NewTeam(ctx context.Context, doer *user_model.User, t *organization.Team) (err error) {
 	if len(t.Name) == 0 {
 		return util.NewInvalidArgumentErrorf("empty team name")
 	}
@@ -55,7 +57,7 @@ func NewTeam(ctx context.Context, t *organization.Team) (err error) {
 		return organization.ErrTeamAlreadyExist{OrgID: t.OrgID, Name: t.LowerName}
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err = db.Insert(ctx, t); err != nil {
 			return err
 		}
@@ -81,11 +83,16 @@ func NewTeam(ctx context.Context, t *organization.Team) (err error) {
 		// Update organization number of teams.
 		_, err = db.Exec(ctx, "UPDATE `user` SET num_teams=num_teams+1 WHERE id = ?", t.OrgID)
 		return err
-	})
+	}); err != nil {
+		return err
+	}
+	audit_service.TeamCreate(ctx, doer, t)
+	notify_service.CreateTeam(ctx, doer, t)
+	return nil
 }
 
 // UpdateTeam updates information of team.
-func UpdateTeam(ctx context.Context, t *organization.Team, authChanged, includeAllChanged bool) (err error) {
+func UpdateTeam(ctx context.Context, doer *user_model.User, t *organization.Team, authChanged, includeAllChanged bool) (err error) {
 	if len(t.Name) == 0 {
 		return util.NewInvalidArgumentErrorf("empty team name")
 	}
@@ -94,7 +101,13 @@ func UpdateTeam(ctx context.Context, t *organization.Team, authChanged, includeA
 		t.Description = t.Description[:255]
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	oldTeam, err := organization.GetTeamByID(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	before := audit_service.TeamValue(ctx, oldTeam)
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		t.LowerName = strings.ToLower(t.Name)
 		has, err := db.Exist[organization.Team](ctx, builder.Eq{
 			"org_id":     t.OrgID,
@@ -154,16 +167,22 @@ func UpdateTeam(ctx context.Context, t *organization.Team, authChanged, includeA
 		}
 
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	audit_service.TeamUpdate(ctx, doer, before, t)
+	notify_service.UpdateTeam(ctx, doer, t)
+	return nil
 }
 
 // DeleteTeam deletes given team.
 // It's caller's responsibility to assign organization ID.
-func DeleteTeam(ctx context.Context, t *organization.Team) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+func DeleteTeam(ctx context.Context, doer *user_model.User, t *organization.Team) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		if err := t.LoadMembers(ctx); err != nil {
 			return err
 		}
+		audit_service.TeamDelete(ctx, doer, t)
 
 		// update branch protections
 		{
@@ -196,7 +215,7 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 		}
 
 		for _, tm := range t.Members {
-			if err := removeInvalidOrgUser(ctx, t.OrgID, tm); err != nil {
+			if err := removeInvalidOrgUser(ctx, doer, t.OrgID, tm); err != nil {
 				return err
 			}
 		}
@@ -204,12 +223,16 @@ func DeleteTeam(ctx context.Context, t *organization.Team) error {
 		// Update organization number of teams.
 		_, err := db.Exec(ctx, "UPDATE `user` SET num_teams=num_teams-1 WHERE id=?", t.OrgID)
 		return err
-	})
+	}); err != nil {
+		return err
+	}
+	notify_service.DeleteTeam(ctx, doer, t)
+	return nil
 }
 
 // AddTeamMember adds new membership of given team to given organization,
 // the user will have membership to given organization automatically when needed.
-func AddTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
+func AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
 	if user_model.IsUserBlockedBy(ctx, user, team.OrgID) {
 		return user_model.ErrBlockedUser
 	}
@@ -219,9 +242,20 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		return err
 	}
 
+	isOrgMember, err := organization.IsOrganizationMember(ctx, team.OrgID, user.ID)
+	if err != nil {
+		return err
+	}
 	if err := organization.AddOrgUser(ctx, team.OrgID, user.ID); err != nil {
 		return err
 	}
+	if !isOrgMember {
+		org, err := organization.GetOrgByID(ctx, team.OrgID)
+		if err != nil {
+			return err
+		}
+		notify_service.AddOrgMember(ctx, doer, org, user)
+	}
 
 	err = db.WithTx(ctx, func(ctx context.Context) error {
 		// check in transaction
@@ -245,9 +279,11 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 		team.NumMembers++
 		return nil
 	})
-	if err != nil {
+	if err != nil || isAlreadyMember {
 		return err
 	}
+	audit_service.TeamMemberAdd(ctx, doer, team, user)
+	notify_service.AddTeamMember(ctx, doer, team, user)
 
 	// this behaviour may spend much time so run it in a goroutine
 	// FIXME: Update watch repos batchly
@@ -272,7 +308,7 @@ func AddTeamMember(ctx context.Context, team *organization.Team, user *user_mode
 	return nil
 }
 
-func removeTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
+func removeTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
 	e := db.GetEngine(ctx)
 	isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, user.ID)
 	if err != nil || !isMember {
@@ -323,10 +359,12 @@ func removeTeamMember(ctx context.Context, team *organization.Team, user *user_m
 		}
 	}
 
-	return removeInvalidOrgUser(ctx, team.OrgID, user)
+	audit_service.TeamMemberRemove(ctx, doer, team, user)
+
+	return removeInvalidOrgUser(ctx, doer, team.OrgID, user)
 }
 
-func removeInvalidOrgUser(ctx context.Context, orgID int64, user *user_model.User) error {
+func removeInvalidOrgUser(ctx context.Context, doer *user_model.User, orgID int64, user *user_model.User) error {
 	// Check if the user is a member of any team in the organization.
 	if count, err := db.GetEngine(ctx).Count(&organization.TeamUser{
 		UID:   user.ID,
@@ -339,14 +377,34 @@ func removeInvalidOrgUser(ctx context.Context, orgID int64, user *user_model.Use
 			return err
 		}
 
-		return RemoveOrgUser(ctx, org, user)
+		return removeOrgUser(ctx, doer, org, user)
 	}
 	return nil
 }
 
 // RemoveTeamMember removes member from given team of given organization.
-func RemoveTeamMember(ctx context.Context, team *organization.Team, user *user_model.User) error {
-	return db.WithTx(ctx, func(ctx context.Context) error {
-		return removeTeamMember(ctx, team, user)
-	})
+func RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, user *user_model.User) error {
+	isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, user.ID)
+	if err != nil || !isMember {
+		return err
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		return removeTeamMember(ctx, doer, team, user)
+	}); err != nil {
+		return err
+	}
+	notify_service.RemoveTeamMember(ctx, doer, team, user)
+
+	// the user leaves the organization with its last team
+	if isOrgMember, err := organization.IsOrganizationMember(ctx, team.OrgID, user.ID); err != nil {
+		return err
+	} else if !isOrgMember {
+		org, err := organization.GetOrgByID(ctx, team.OrgID)
+		if err != nil {
+			return err
+		}
+		notify_service.RemoveOrgMember(ctx, doer, org, user)
+	}
+	return nil
 }
@@ -72,8 +72,27 @@ func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, err
 	return priv, pub, nil
 }
 
-// BuildAllRepositoryFiles (re)builds all repository files for every available branches, repositories and architectures
+// BuildAllRepositoryFiles (re)builds all repository files for every available branches, repositories and architectures.
+// The repositories of the virtual registries including the owner's packages are rebuilt too.
 func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	ownerIDs, err := packages_service.GetRepositoryIndexOwnerIDs(ctx, ownerID, packages_model.TypeAlpine)
+	if err != nil {
+		return err
+	}
+	for _, ownerID := range ownerIDs {
+		if err := buildAllRepositoryFiles(ctx, ownerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	rs, err := packages_service.GetRepositorySources(ctx, ownerID, packages_model.TypeAlpine)
+	if err != nil {
+		return err
+	}
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -92,22 +111,22 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 	}
 
 	// 2. (Re)Build repository files for existing packages
-	branches, err := alpine_model.GetBranches(ctx, ownerID)
+	branches, err := alpine_model.GetBranches(ctx, rs.OwnerIDs)
 	if err != nil {
 		return err
 	}
 	for _, branch := range branches {
-		repositories, err := alpine_model.GetRepositories(ctx, ownerID, branch)
+		repositories, err := alpine_model.GetRepositories(ctx, rs.OwnerIDs, branch)
 		if err != nil {
 			return err
 		}
 		for _, repository := range repositories {
-			architectures, err := alpine_model.GetArchitectures(ctx, ownerID, repository)
+			architectures, err := alpine_model.GetArchitectures(ctx, rs.OwnerIDs, repository)
 			if err != nil {
 				return err
 			}
 			for _, architecture := range architectures {
-				if err := buildPackagesIndex(ctx, ownerID, pv, branch, repository, architecture); err != nil {
+				if err := buildPackagesIndex(ctx, rs, pv, branch, repository, architecture); err != nil {
 					return fmt.Errorf("failed to build repository files [%s/%s/%s]: %w", branch, repository, architecture, err)
 				}
 			}
@@ -117,8 +136,27 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 	return nil
 }
 
-// BuildSpecificRepositoryFiles builds index files for the repository
+// BuildSpecificRepositoryFiles builds index files for the repository.
+// The repositories of the virtual registries including the owner's packages are rebuilt too.
 func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, branch, repository, architecture string) error {
+	ownerIDs, err := packages_service.GetRepositoryIndexOwnerIDs(ctx, ownerID, packages_model.TypeAlpine)
+	if err != nil {
+		return err
+	}
+	for _, ownerID := range ownerIDs {
+		if err := buildSpecificRepositoryFiles(ctx, ownerID, branch, repository, architecture); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildSpecificRepositoryFiles(ctx context.Context, ownerID int64, branch, repository, architecture string) error {
+	rs, err := packages_service.GetRepositorySources(ctx, ownerID, packages_model.TypeAlpine)
+	if err != nil {
+		return err
+	}
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -127,7 +165,7 @@ func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, branch, re
 	architectures := container.SetOf(architecture)
 	if architecture == alpine_module.NoArch {
 		// Update all other architectures too when updating the noarch index
-		additionalArchitectures, err := alpine_model.GetArchitectures(ctx, ownerID, repository)
+		additionalArchitectures, err := alpine_model.GetArchitectures(ctx, rs.OwnerIDs, repository)
 		if err != nil {
 			return err
 		}
@@ -135,7 +173,7 @@ func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, branch, re
 	}
 
 	for architecture := range architectures {
-		if err := buildPackagesIndex(ctx, ownerID, pv, branch, repository, architecture); err != nil {
+		if err := buildPackagesIndex(ctx, rs, pv, branch, repository, architecture); err != nil {
 			return err
 		}
 	}
@@ -152,9 +190,9 @@ type packageData struct {
 
 type packageCache = map[*packages_model.PackageFile]*packageData
 
-func searchPackageFiles(ctx context.Context, ownerID int64, branch, repository, architecture string) ([]*packages_model.PackageFile, error) {
+func searchPackageFiles(ctx context.Context, rs *packages_service.RepositorySources, branch, repository, architecture string) ([]*packages_model.PackageFile, error) {
 	pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
-		OwnerID:     ownerID,
+		OwnerIDs:    rs.OwnerIDs,
 		PackageType: packages_model.TypeAlpine,
 		Query:       "%.apk",
 		Properties: map[string]string{
@@ -166,18 +204,18 @@ func searchPackageFiles(ctx context.Context, ownerID int64, branch, repository,
 	if err != nil {
 		return nil, err
 	}
-	return pfs, nil
+	return rs.FilterFiles(ctx, pfs)
 }
 
 // https://wiki.alpinelinux.org/wiki/Apk_spec#APKINDEX_Format
-func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *packages_model.PackageVersion, branch, repository, architecture string) error {
-	pfs, err := searchPackageFiles(ctx, ownerID, branch, repository, architecture)
+func buildPackagesIndex(ctx context.Context, rs *packages_service.RepositorySources, repoVersion *packages_model.PackageVersion, branch, repository, architecture string) error {
+	pfs, err := searchPackageFiles(ctx, rs, branch, repository, architecture)
 	if err != nil {
 		return err
 	}
 	if architecture != alpine_module.NoArch {
 		// Add all noarch packages too
-		noarchFiles, err := searchPackageFiles(ctx, ownerID, branch, repository, alpine_module.NoArch)
+		noarchFiles, err := searchPackageFiles(ctx, rs, branch, repository, alpine_module.NoArch)
 		if err != nil {
 			return err
 		}
@@ -283,7 +321,7 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 		return err
 	}
 
-	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	priv, _, err := GetOrCreateKeyPair(ctx, rs.OwnerID)
 	if err != nil {
 		return err
 	}
@@ -303,7 +341,7 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 		return err
 	}
 
-	owner, err := user_model.GetUserByID(ctx, ownerID)
+	owner, err := user_model.GetUserByID(ctx, rs.OwnerID)
 	if err != nil {
 		return err
 	}
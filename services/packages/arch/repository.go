@@ -130,8 +130,27 @@ func SignData(ctx context.Context, ownerID int64, r io.Reader) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-// BuildAllRepositoryFiles (re)builds all repository files for every available repositories and architectures
+// BuildAllRepositoryFiles (re)builds all repository files for every available repositories and architectures.
+// The repositories of the virtual registries including the owner's packages are rebuilt too.
 func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	ownerIDs, err := packages_service.GetRepositoryIndexOwnerIDs(ctx, ownerID, packages_model.TypeArch)
+	if err != nil {
+		return err
+	}
+	for _, ownerID := range ownerIDs {
+		if err := buildAllRepositoryFiles(ctx, ownerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	rs, err := packages_service.GetRepositorySources(ctx, ownerID, packages_model.TypeArch)
+	if err != nil {
+		return err
+	}
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -150,17 +169,17 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 	}
 
 	// 2. (Re)Build repository files for existing packages
-	repositories, err := arch_model.GetRepositories(ctx, ownerID)
+	repositories, err := arch_model.GetRepositories(ctx, rs.OwnerIDs)
 	if err != nil {
 		return err
 	}
 	for _, repository := range repositories {
-		architectures, err := arch_model.GetArchitectures(ctx, ownerID, repository)
+		architectures, err := arch_model.GetArchitectures(ctx, rs.OwnerIDs, repository)
 		if err != nil {
 			return err
 		}
 		for _, architecture := range architectures {
-			if err := buildPackagesIndex(ctx, ownerID, pv, repository, architecture); err != nil {
+			if err := buildPackagesIndex(ctx, rs, pv, repository, architecture); err != nil {
 				return fmt.Errorf("failed to build repository files [%s/%s]: %w", repository, architecture, err)
 			}
 		}
@@ -169,8 +188,28 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 	return nil
 }
 
-// BuildSpecificRepositoryFiles builds index files for the repository
+// BuildSpecificRepositoryFiles builds index files for the repository.
+// The repositories of the virtual registries including the owner's packages are rebuilt too,
+// they are not guarded by the registry lock of their owner.
 func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, repository, architecture string) error {
+	ownerIDs, err := packages_service.GetRepositoryIndexOwnerIDs(ctx, ownerID, packages_model.TypeArch)
+	if err != nil {
+		return err
+	}
+	for _, ownerID := range ownerIDs {
+		if err := buildSpecificRepositoryFiles(ctx, ownerID, repository, architecture); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildSpecificRepositoryFiles(ctx context.Context, ownerID int64, repository, architecture string) error {
+	rs, err := packages_service.GetRepositorySources(ctx, ownerID, packages_model.TypeArch)
+	if err != nil {
+		return err
+	}
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -179,7 +218,7 @@ func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, repository
 	architectures := container.SetOf(architecture)
 	if architecture == arch_module.AnyArch {
 		// Update all other architectures too when updating the any index
-		additionalArchitectures, err := arch_model.GetArchitectures(ctx, ownerID, repository)
+		additionalArchitectures, err := arch_model.GetArchitectures(ctx, rs.OwnerIDs, repository)
 		if err != nil {
 			return err
 		}
@@ -187,16 +226,16 @@ func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, repository
 	}
 
 	for architecture := range architectures {
-		if err := buildPackagesIndex(ctx, ownerID, pv, repository, architecture); err != nil {
+		if err := buildPackagesIndex(ctx, rs, pv, repository, architecture); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func searchPackageFiles(ctx context.Context, ownerID int64, repository, architecture string) ([]*packages_model.PackageFile, error) {
+func searchPackageFiles(ctx context.Context, rs *packages_service.RepositorySources, repository, architecture string) ([]*packages_model.PackageFile, error) {
 	pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
-		OwnerID:     ownerID,
+		OwnerIDs:    rs.OwnerIDs,
 		PackageType: packages_model.TypeArch,
 		Query:       "%.pkg.tar.%",
 		Properties: map[string]string{
@@ -207,17 +246,17 @@ func searchPackageFiles(ctx context.Context, ownerID int64, repository, architec
 	if err != nil {
 		return nil, err
 	}
-	return pfs, nil
+	return rs.FilterFiles(ctx, pfs)
 }
 
-func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *packages_model.PackageVersion, repository, architecture string) error {
-	pfs, err := searchPackageFiles(ctx, ownerID, repository, architecture)
+func buildPackagesIndex(ctx context.Context, rs *packages_service.RepositorySources, repoVersion *packages_model.PackageVersion, repository, architecture string) error {
+	pfs, err := searchPackageFiles(ctx, rs, repository, architecture)
 	if err != nil {
 		return err
 	}
 	if architecture != arch_module.AnyArch {
 		// Add all any packages too
-		anyarchFiles, err := searchPackageFiles(ctx, ownerID, repository, arch_module.AnyArch)
+		anyarchFiles, err := searchPackageFiles(ctx, rs, repository, arch_module.AnyArch)
 		if err != nil {
 			return err
 		}
@@ -236,7 +275,15 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 		return packages_service.DeletePackageFile(ctx, pf)
 	}
 
-	vpfs := make(map[int64]*entryOptions)
+	// the packages of the owner take precedence over packages with the same name of the virtual sources
+	ownerPriority := make(map[int64]int, len(rs.OwnerIDs))
+	for i, ownerID := range rs.OwnerIDs {
+		ownerPriority[ownerID] = i
+	}
+
+	cache := make(map[int64]*packages_model.Package)
+
+	vpfs := make(map[string]*entryOptions)
 	for _, pf := range pfs {
 		current := &entryOptions{
 			File: pf,
@@ -245,16 +292,25 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 		if err != nil {
 			return err
 		}
+		current.Package = cache[current.Version.PackageID]
+		if current.Package == nil {
+			current.Package, err = packages_model.GetPackageByID(ctx, current.Version.PackageID)
+			if err != nil {
+				return err
+			}
+			cache[current.Package.ID] = current.Package
+		}
 
 		// here we compare the versions but not using SearchLatestVersions because we shouldn't allow "downgrading" to a older version by "latest" one.
 		// https://wiki.archlinux.org/title/Downgrading_packages : randomly downgrading can mess up dependencies:
 		// If a downgrade involves a soname change, all dependencies may need downgrading or rebuilding too.
-		if old, ok := vpfs[current.Version.PackageID]; ok {
-			if compareVersions(old.Version.Version, current.Version.Version) == -1 {
-				vpfs[current.Version.PackageID] = current
+		if old, ok := vpfs[current.Package.LowerName]; ok {
+			oldPriority, currentPriority := ownerPriority[old.Package.OwnerID], ownerPriority[current.Package.OwnerID]
+			if currentPriority < oldPriority || (currentPriority == oldPriority && compareVersions(old.Version.Version, current.Version.Version) == -1) {
+				vpfs[current.Package.LowerName] = current
 			}
 		} else {
-			vpfs[current.Version.PackageID] = current
+			vpfs[current.Package.LowerName] = current
 		}
 	}
 
@@ -264,20 +320,10 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 	gw := gzip.NewWriter(indexContent)
 	tw := tar.NewWriter(gw)
 
-	cache := make(map[int64]*packages_model.Package)
-
 	for _, opts := range vpfs {
 		if err := json.Unmarshal([]byte(opts.Version.MetadataJSON), &opts.VersionMetadata); err != nil {
 			return err
 		}
-		opts.Package = cache[opts.Version.PackageID]
-		if opts.Package == nil {
-			opts.Package, err = packages_model.GetPackageByID(ctx, opts.Version.PackageID)
-			if err != nil {
-				return err
-			}
-			cache[opts.Package.ID] = opts.Package
-		}
 		opts.Blob, err = packages_model.GetBlobByID(ctx, opts.File.BlobID)
 		if err != nil {
 			return err
@@ -314,7 +360,7 @@ func buildPackagesIndex(ctx context.Context, ownerID int64, repoVersion *package
 	tw.Close()
 	gw.Close()
 
-	signature, err := SignData(ctx, ownerID, indexContent)
+	signature, err := SignData(ctx, rs.OwnerID, indexContent)
 	if err != nil {
 		return err
 	}
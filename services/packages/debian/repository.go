@@ -96,8 +96,27 @@ func generateKeypair() (string, string, error) {
 	return priv.String(), pub.String(), nil
 }
 
-// BuildAllRepositoryFiles (re)builds all repository files for every available distributions, components and architectures
+// BuildAllRepositoryFiles (re)builds all repository files for every available distributions, components and architectures.
+// The repositories of the virtual registries including the owner's packages are rebuilt too.
 func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	ownerIDs, err := packages_service.GetRepositoryIndexOwnerIDs(ctx, ownerID, packages_model.TypeDebian)
+	if err != nil {
+		return err
+	}
+	for _, ownerID := range ownerIDs {
+		if err := buildAllRepositoryFiles(ctx, ownerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	rs, err := packages_service.GetRepositorySources(ctx, ownerID, packages_model.TypeDebian)
+	if err != nil {
+		return err
+	}
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -116,23 +135,23 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 	}
 
 	// 2. (Re)Build repository files for existing packages
-	distributions, err := debian_model.GetDistributions(ctx, ownerID)
+	distributions, err := debian_model.GetDistributions(ctx, rs.OwnerIDs)
 	if err != nil {
 		return err
 	}
 	for _, distribution := range distributions {
-		components, err := debian_model.GetComponents(ctx, ownerID, distribution)
+		components, err := debian_model.GetComponents(ctx, rs.OwnerIDs, distribution)
 		if err != nil {
 			return err
 		}
-		architectures, err := debian_model.GetArchitectures(ctx, ownerID, distribution)
+		architectures, err := debian_model.GetArchitectures(ctx, rs.OwnerIDs, distribution)
 		if err != nil {
 			return err
 		}
 
 		for _, component := range components {
 			for _, architecture := range architectures {
-				if err := buildRepositoryFiles(ctx, ownerID, pv, distribution, component, architecture); err != nil {
+				if err := buildRepositoryFiles(ctx, rs, pv, distribution, component, architecture); err != nil {
 					return fmt.Errorf("failed to build repository files [%s/%s/%s]: %w", distribution, component, architecture, err)
 				}
 			}
@@ -142,28 +161,44 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 	return nil
 }
 
-// BuildSpecificRepositoryFiles builds index files for the repository
+// BuildSpecificRepositoryFiles builds index files for the repository.
+// The repositories of the virtual registries including the owner's packages are rebuilt too.
 func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, distribution, component, architecture string) error {
-	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
+	ownerIDs, err := packages_service.GetRepositoryIndexOwnerIDs(ctx, ownerID, packages_model.TypeDebian)
 	if err != nil {
 		return err
 	}
 
-	return buildRepositoryFiles(ctx, ownerID, pv, distribution, component, architecture)
+	for _, ownerID := range ownerIDs {
+		rs, err := packages_service.GetRepositorySources(ctx, ownerID, packages_model.TypeDebian)
+		if err != nil {
+			return err
+		}
+
+		pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
+		if err != nil {
+			return err
+		}
+
+		if err := buildRepositoryFiles(ctx, rs, pv, distribution, component, architecture); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func buildRepositoryFiles(ctx context.Context, ownerID int64, repoVersion *packages_model.PackageVersion, distribution, component, architecture string) error {
-	if err := buildPackagesIndices(ctx, ownerID, repoVersion, distribution, component, architecture); err != nil {
+func buildRepositoryFiles(ctx context.Context, rs *packages_service.RepositorySources, repoVersion *packages_model.PackageVersion, distribution, component, architecture string) error {
+	if err := buildPackagesIndices(ctx, rs, repoVersion, distribution, component, architecture); err != nil {
 		return err
 	}
 
-	return buildReleaseFiles(ctx, ownerID, repoVersion, distribution)
+	return buildReleaseFiles(ctx, rs, repoVersion, distribution)
 }
 
 // https://wiki.debian.org/DebianRepository/Format#A.22Packages.22_Indices
-func buildPackagesIndices(ctx context.Context, ownerID int64, repoVersion *packages_model.PackageVersion, distribution, component, architecture string) error {
+func buildPackagesIndices(ctx context.Context, rs *packages_service.RepositorySources, repoVersion *packages_model.PackageVersion, distribution, component, architecture string) error {
 	opts := &debian_model.PackageSearchOptions{
-		OwnerID:      ownerID,
+		OwnerIDs:     rs.OwnerIDs,
 		Distribution: distribution,
 		Component:    component,
 		Architecture: architecture,
@@ -211,6 +246,12 @@ func buildPackagesIndices(ctx context.Context, ownerID int64, repoVersion *packa
 		return err
 	}
 	for _, pfd := range pfds {
+		if included, err := rs.IncludesFile(ctx, pfd.File); err != nil {
+			return err
+		} else if !included {
+			continue
+		}
+
 		if addSeparator {
 			fmt.Fprintln(w)
 		}
@@ -265,7 +306,7 @@ func buildPackagesIndices(ctx context.Context, ownerID int64, repoVersion *packa
 }
 
 // https://wiki.debian.org/DebianRepository/Format#A.22Release.22_files
-func buildReleaseFiles(ctx context.Context, ownerID int64, repoVersion *packages_model.PackageVersion, distribution string) error {
+func buildReleaseFiles(ctx context.Context, rs *packages_service.RepositorySources, repoVersion *packages_model.PackageVersion, distribution string) error {
 	pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
 		VersionID: repoVersion.ID,
 		Properties: map[string]string{
@@ -295,21 +336,21 @@ func buildReleaseFiles(ctx context.Context, ownerID int64, repoVersion *packages
 		return nil
 	}
 
-	components, err := debian_model.GetComponents(ctx, ownerID, distribution)
+	components, err := debian_model.GetComponents(ctx, rs.OwnerIDs, distribution)
 	if err != nil {
 		return err
 	}
 
 	sort.Strings(components)
 
-	architectures, err := debian_model.GetArchitectures(ctx, ownerID, distribution)
+	architectures, err := debian_model.GetArchitectures(ctx, rs.OwnerIDs, distribution)
 	if err != nil {
 		return err
 	}
 
 	sort.Strings(architectures)
 
-	priv, _, err := GetOrCreateKeyPair(ctx, ownerID)
+	priv, _, err := GetOrCreateKeyPair(ctx, rs.OwnerID)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,192 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/hashicorp/go-version"
+)
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+
+// GetOrCreateKeyPair gets or creates the RSA key pair used to sign the registry resources of the owner
+func GetOrCreateKeyPair(ctx context.Context, ownerID int64) (string, string, error) {
+	priv, err := user_model.GetSetting(ctx, ownerID, hex_module.SettingKeyPrivate)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	pub, err := user_model.GetSetting(ctx, ownerID, hex_module.SettingKeyPublic)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		priv, pub, err = util.GenerateKeyPair(4096)
+		if err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, hex_module.SettingKeyPrivate, priv); err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, ownerID, hex_module.SettingKeyPublic, pub); err != nil {
+			return "", "", err
+		}
+	}
+
+	return priv, pub, nil
+}
+
+// RepositoryName returns the name of the repository of the owner.
+// Clients verify that the resources belong to the repository they have been configured with.
+func RepositoryName(owner *user_model.User) string {
+	return owner.LowerName
+}
+
+// BuildNames creates the signed names resource which lists all packages of the owner
+func BuildNames(ctx context.Context, owner *user_model.User) ([]byte, error) {
+	packages, err := getPackageVersions(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		names = append(names, pkg.Name)
+	}
+
+	return signAndCompress(ctx, owner, hex_module.EncodeNames(RepositoryName(owner), names))
+}
+
+// BuildVersions creates the signed versions resource which lists the versions of all packages of the owner
+func BuildVersions(ctx context.Context, owner *user_model.User) ([]byte, error) {
+	packages, err := getPackageVersions(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return signAndCompress(ctx, owner, hex_module.EncodeVersions(RepositoryName(owner), packages))
+}
+
+func getPackageVersions(ctx context.Context, owner *user_model.User) ([]*hex_module.VersionsPackage, error) {
+	ps, err := packages_model.GetPackagesByType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, fmt.Errorf("GetPackagesByType: %w", err)
+	}
+
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, owner.ID, packages_model.TypeHex)
+	if err != nil {
+		return nil, fmt.Errorf("GetVersionsByPackageType: %w", err)
+	}
+
+	versions := make(map[int64][]string)
+	for _, pv := range pvs {
+		versions[pv.PackageID] = append(versions[pv.PackageID], pv.Version)
+	}
+
+	packages := make([]*hex_module.VersionsPackage, 0, len(ps))
+	for _, p := range ps {
+		if len(versions[p.ID]) == 0 {
+			continue
+		}
+		packages = append(packages, &hex_module.VersionsPackage{
+			Name:     p.Name,
+			Versions: sortVersions(versions[p.ID]),
+		})
+	}
+	slices.SortFunc(packages, func(a, b *hex_module.VersionsPackage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return packages, nil
+}
+
+// BuildPackage creates the signed package resource which lists the releases of the package.
+// It returns nil if the package has no versions.
+func BuildPackage(ctx context.Context, owner *user_model.User, p *packages_model.Package) ([]byte, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypeHex, p.Name)
+	if err != nil {
+		return nil, fmt.Errorf("GetVersionsByPackageName[%s]: %w", p.Name, err)
+	}
+	if len(pvs) == 0 {
+		return nil, nil
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, fmt.Errorf("GetPackageDescriptors[%s]: %w", p.Name, err)
+	}
+
+	releases := make([]*hex_module.Release, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*hex_module.Metadata)
+
+		innerChecksum, err := hex.DecodeString(metadata.InnerChecksum)
+		if err != nil {
+			return nil, err
+		}
+		outerChecksum, err := hex.DecodeString(pd.Files[0].Blob.HashSHA256)
+		if err != nil {
+			return nil, err
+		}
+
+		dependencies := make([]*hex_module.Dependency, 0, len(metadata.Requirements))
+		for _, req := range metadata.Requirements {
+			dep := *req
+			// dependencies without repository are resolved in the repository of the package
+			if dep.Repository == RepositoryName(owner) {
+				dep.Repository = ""
+			}
+			dependencies = append(dependencies, &dep)
+		}
+
+		releases = append(releases, &hex_module.Release{
+			Version:       pd.Version.Version,
+			InnerChecksum: innerChecksum,
+			OuterChecksum: outerChecksum,
+			Dependencies:  dependencies,
+		})
+	}
+	slices.SortFunc(releases, func(a, b *hex_module.Release) int {
+		return compareVersions(a.Version, b.Version)
+	})
+
+	return signAndCompress(ctx, owner, hex_module.EncodePackage(RepositoryName(owner), p.Name, releases))
+}
+
+func signAndCompress(ctx context.Context, owner *user_model.User, payload []byte) ([]byte, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex_module.SignAndCompress(priv, payload)
+}
+
+func sortVersions(versions []string) []string {
+	slices.SortFunc(versions, compareVersions)
+	return versions
+}
+
+func compareVersions(a, b string) int {
+	va, errA := version.NewSemver(a)
+	vb, errB := version.NewSemver(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	return va.Compare(vb)
+}
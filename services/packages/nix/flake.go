@@ -0,0 +1,143 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/cache"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+)
+
+const (
+	flakeFilename = "flake.nix"
+
+	// flakeRegistryMaxRepositories limits the repositories checked for a flake on each registry request,
+	// the most recently updated ones are checked first
+	flakeRegistryMaxRepositories = 1000
+)
+
+// FlakeRegistry is a flake registry in the format of https://nix.dev/manual/nix/latest/command-ref/new-cli/nix3-registry
+type FlakeRegistry struct {
+	Version int           `json:"version"`
+	Flakes  []*FlakeEntry `json:"flakes"`
+}
+
+type FlakeEntry struct {
+	From *FlakeRef `json:"from"`
+	To   *FlakeRef `json:"to"`
+}
+
+type FlakeRef struct {
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// BuildFlakeRegistry lists the repositories of the owner the doer can read which contain a flake in their default branch.
+// The flakes are registered under the name of their repository.
+func BuildFlakeRegistry(ctx context.Context, doer, owner *user_model.User) (*FlakeRegistry, error) {
+	repos, _, err := repo_model.SearchRepository(ctx, repo_model.SearchRepoOptions{
+		ListOptions: db.ListOptions{Page: 1, PageSize: flakeRegistryMaxRepositories},
+		Actor:       doer,
+		OwnerID:     owner.ID,
+		Private:     doer != nil,
+		OrderBy:     db.SearchOrderByRecentUpdated,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &FlakeRegistry{
+		Version: 2,
+		Flakes:  make([]*FlakeEntry, 0, len(repos)),
+	}
+	for _, repo := range repos {
+		if repo.IsEmpty || repo.IsBeingCreated() {
+			continue
+		}
+
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if !perm.CanRead(unit.TypeCode) {
+			continue
+		}
+
+		has, err := hasFlakeCached(ctx, repo)
+		if err != nil {
+			log.Error("Checking %s for a flake failed: %v", repo.FullName(), err)
+			continue
+		}
+		if !has {
+			continue
+		}
+
+		registry.Flakes = append(registry.Flakes, &FlakeEntry{
+			From: &FlakeRef{Type: "indirect", ID: repo.LowerName},
+			To:   &FlakeRef{Type: "git", URL: repo.CloneLinkGeneral(ctx).HTTPS},
+		})
+	}
+	slices.SortFunc(registry.Flakes, func(a, b *FlakeEntry) int {
+		return strings.Compare(a.From.ID, b.From.ID)
+	})
+	return registry, nil
+}
+
+// hasFlakeCached caches whether the default branch of the repository contains a flake by the commit of the branch,
+// so the repository is only opened again after a push to the branch
+func hasFlakeCached(ctx context.Context, repo *repo_model.Repository) (bool, error) {
+	branch, err := git_model.GetBranch(ctx, repo.ID, repo.DefaultBranch)
+	if err != nil {
+		if git_model.IsErrBranchNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	has, err := cache.GetString("nix_flake_"+branch.CommitID, func() (string, error) {
+		has, err := hasFlake(ctx, repo, branch.CommitID)
+		return strconv.FormatBool(has), err
+	})
+	if err != nil {
+		return false, err
+	}
+	return has == "true", nil
+}
+
+func hasFlake(ctx context.Context, repo *repo_model.Repository, commitID string) (bool, error) {
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return false, err
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetCommit(commitID)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	entry, err := commit.GetTreeEntryByPath(flakeFilename)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return entry.IsRegular(), nil
+}
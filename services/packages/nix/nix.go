@@ -0,0 +1,347 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	nix_module "code.gitea.io/gitea/modules/packages/nix"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+var (
+	ErrNarNotUploaded = util.NewInvalidArgumentErrorf("the nar of the narinfo has not been uploaded")
+	ErrHashMismatch   = util.NewInvalidArgumentErrorf("the nar does not match the hash of its filename")
+)
+
+// GetOrCreateSigningKey gets or creates the key used to sign the narinfos of the owner
+func GetOrCreateSigningKey(ctx context.Context, owner *user_model.User) (string, string, error) {
+	priv, err := user_model.GetSetting(ctx, owner.ID, nix_module.SettingKeyPrivate)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	pub, err := user_model.GetSetting(ctx, owner.ID, nix_module.SettingKeyPublic)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		return createSigningKey(ctx, owner, 1)
+	}
+
+	return priv, pub, nil
+}
+
+// RegenerateSigningKey replaces the signing key of the owner. The generation in the key name is increased
+// because Nix identifies trusted keys by their name.
+func RegenerateSigningKey(ctx context.Context, owner *user_model.User) (string, error) {
+	_, pub, err := GetOrCreateSigningKey(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+
+	generation := 1
+	if name, _, err := nix_module.SplitKey(pub); err == nil {
+		if pos := strings.LastIndexByte(name, '-'); pos != -1 {
+			generation, _ = strconv.Atoi(name[pos+1:])
+		}
+	}
+
+	_, pub, err = createSigningKey(ctx, owner, generation+1)
+	return pub, err
+}
+
+func createSigningKey(ctx context.Context, owner *user_model.User, generation int) (string, string, error) {
+	priv, pub, err := nix_module.GenerateSigningKey(fmt.Sprintf("%s-%s-%d", setting.Domain, owner.LowerName, generation))
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := user_model.SetUserSetting(ctx, owner.ID, nix_module.SettingKeyPrivate, priv); err != nil {
+		return "", "", err
+	}
+
+	if err := user_model.SetUserSetting(ctx, owner.ID, nix_module.SettingKeyPublic, pub); err != nil {
+		return "", "", err
+	}
+
+	return priv, pub, nil
+}
+
+// BuildNarInfo creates the narinfo of a store path signed with the key of the owner
+func BuildNarInfo(ctx context.Context, owner *user_model.User, m *nix_module.Metadata) ([]byte, error) {
+	priv, _, err := GetOrCreateSigningKey(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := nix_module.Sign(priv, m.Fingerprint())
+	if err != nil {
+		return nil, err
+	}
+
+	return m.NarInfo(sig), nil
+}
+
+// GetStorePath returns the package version of the store path with the hash
+func GetStorePath(ctx context.Context, ownerID int64, hash string) (*packages_model.PackageDescriptor, error) {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: ownerID,
+		Type:    packages_model.TypeNix,
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      hash,
+		},
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	return packages_model.GetPackageDescriptor(ctx, pvs[0])
+}
+
+// GetNarFile returns the file of a NAR. NARs can be downloaded before their narinfo is uploaded.
+func GetNarFile(ctx context.Context, ownerID int64, filename string) (*packages_model.PackageFile, error) {
+	pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+		OwnerID:     ownerID,
+		PackageType: packages_model.TypeNix,
+		Query:       filename,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pf := range pfs {
+		if pf.LowerName == strings.ToLower(filename) {
+			return pf, nil
+		}
+	}
+
+	pv, err := packages_model.GetInternalVersionByNameAndVersion(ctx, ownerID, packages_model.TypeNix, nix_module.UploadPackageName, nix_module.UploadVersion)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			return nil, packages_model.ErrPackageFileNotExist
+		}
+		return nil, err
+	}
+	return packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
+
+// UploadNar stores a NAR until the narinfo referencing it is uploaded
+func UploadNar(ctx context.Context, doer, owner *user_model.User, filename string, hsr packages_module.HashedSizeReader) error {
+	sum, err := nix_module.ParseNarFilename(filename)
+	if err != nil {
+		return err
+	}
+	if _, _, hashSHA256, _ := hsr.Sums(); !bytes.Equal(sum, hashSHA256) {
+		return ErrHashMismatch
+	}
+
+	pv, err := packages_service.GetOrCreateInternalPackageVersion(ctx, owner.ID, packages_model.TypeNix, nix_module.UploadPackageName, nix_module.UploadVersion)
+	if err != nil {
+		return err
+	}
+
+	pb := packages_service.NewPackageBlob(hsr)
+	exists := false
+	contentStore := packages_module.NewContentStore()
+
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		if err := packages_service.CheckSizeQuotaExceeded(ctx, doer, owner, packages_model.TypeNix, hsr.Size()); err != nil {
+			return err
+		}
+
+		pb, exists, err = packages_model.GetOrInsertBlob(ctx, pb)
+		if err != nil {
+			log.Error("Error inserting package blob: %v", err)
+			return err
+		}
+		if !exists {
+			if err := contentStore.Save(packages_module.BlobHash256Key(pb.HashSHA256), hsr, hsr.Size()); err != nil {
+				log.Error("Error saving package blob in content store: %v", err)
+				return err
+			}
+		}
+
+		pf := &packages_model.PackageFile{
+			VersionID:    pv.ID,
+			BlobID:       pb.ID,
+			Name:         filename,
+			LowerName:    strings.ToLower(filename),
+			CompositeKey: packages_model.EmptyFileKey,
+		}
+		if _, err := packages_model.TryInsertFile(ctx, pf); err != nil && !errors.Is(err, packages_model.ErrDuplicatePackageFile) {
+			log.Error("Error inserting package file: %v", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		if !exists {
+			if err := contentStore.Delete(packages_module.BlobHash256Key(pb.HashSHA256)); err != nil {
+				log.Error("Error deleting package blob from content store: %v", err)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// AddStorePath creates the package version of a store path from its narinfo and moves the uploaded NAR to it.
+// Uploading a store path which exists already is a no-op because the store path hash identifies its content.
+func AddStorePath(ctx context.Context, doer, owner *user_model.User, m *nix_module.Metadata) error {
+	hash, name, err := nix_module.ParseStorePath(m.StorePath)
+	if err != nil {
+		return err
+	}
+	filename := strings.TrimPrefix(m.URL, "nar/")
+
+	releaser, err := globallock.Lock(ctx, fmt.Sprintf("pkg_%d_nix_%s", owner.ID, hash))
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	nar, err := GetNarFile(ctx, owner.ID, filename)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			return ErrNarNotUploaded
+		}
+		return err
+	}
+	pb, err := packages_model.GetBlobByID(ctx, nar.BlobID)
+	if err != nil {
+		return err
+	}
+	if pb.Size != m.FileSize {
+		return nix_module.ErrInvalidNarInfo
+	}
+
+	created := false
+	pv, err := db.WithTx2(ctx, func(ctx context.Context) (*packages_model.PackageVersion, error) {
+		p, err := packages_model.TryInsertPackage(ctx, &packages_model.Package{
+			OwnerID:   owner.ID,
+			Type:      packages_model.TypeNix,
+			Name:      name,
+			LowerName: strings.ToLower(name),
+		})
+		if err != nil && !errors.Is(err, packages_model.ErrDuplicatePackage) {
+			log.Error("Error inserting package: %v", err)
+			return nil, err
+		}
+
+		metadataJSON, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+
+		pv, err := packages_model.GetOrInsertVersion(ctx, &packages_model.PackageVersion{
+			PackageID:    p.ID,
+			CreatorID:    doer.ID,
+			Version:      hash,
+			LowerVersion: hash,
+			MetadataJSON: string(metadataJSON),
+		})
+		if err != nil {
+			if errors.Is(err, packages_model.ErrDuplicatePackageVersion) {
+				return pv, nil
+			}
+			log.Error("Error inserting package version: %v", err)
+			return nil, err
+		}
+
+		if err := packages_service.CheckCountQuotaExceeded(ctx, doer, owner); err != nil {
+			return nil, err
+		}
+
+		if _, err := packages_model.TryInsertFile(ctx, &packages_model.PackageFile{
+			VersionID:    pv.ID,
+			BlobID:       pb.ID,
+			Name:         filename,
+			LowerName:    strings.ToLower(filename),
+			CompositeKey: packages_model.EmptyFileKey,
+			IsLead:       true,
+		}); err != nil {
+			log.Error("Error inserting package file: %v", err)
+			return nil, err
+		}
+
+		// the NAR was uploaded for this store path, it is not referenced by another store path
+		if nar.VersionID != pv.ID {
+			if uploadVersion, err := packages_model.GetVersionByID(ctx, nar.VersionID); err != nil {
+				return nil, err
+			} else if uploadVersion.IsInternal {
+				if err := packages_service.DeletePackageFile(ctx, nar); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		created = true
+		return pv, nil
+	})
+	if err != nil || !created {
+		return err
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return err
+	}
+	notify_service.PackageCreate(ctx, doer, pd)
+
+	return nil
+}
+
+// Cleanup removes the NARs which have not been referenced by a narinfo in time
+func Cleanup(ctx context.Context, olderThan time.Duration) error {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		Type: packages_model.TypeNix,
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      nix_module.UploadVersion,
+		},
+		IsInternal: optional.Some(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pv := range pvs {
+		pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+			VersionID: pv.ID,
+			OlderThan: olderThan,
+		})
+		if err != nil {
+			return err
+		}
+		for _, pf := range pfs {
+			if err := packages_service.DeletePackageFile(ctx, pf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
+	quota_model "code.gitea.io/gitea/models/quota"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
@@ -23,6 +24,7 @@ import (
 	packages_module "code.gitea.io/gitea/modules/packages"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
 
@@ -378,8 +380,12 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
 		typeSpecificSize = setting.Packages.LimitSizeHelm
+	case packages_model.TypeHex:
+		typeSpecificSize = setting.Packages.LimitSizeHex
 	case packages_model.TypeMaven:
 		typeSpecificSize = setting.Packages.LimitSizeMaven
+	case packages_model.TypeNix:
+		typeSpecificSize = setting.Packages.LimitSizeNix
 	case packages_model.TypeNpm:
 		typeSpecificSize = setting.Packages.LimitSizeNpm
 	case packages_model.TypeNuGet:
@@ -394,6 +400,8 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		typeSpecificSize = setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
 		typeSpecificSize = setting.Packages.LimitSizeSwift
+	case packages_model.TypeTerraform:
+		typeSpecificSize = setting.Packages.LimitSizeTerraform
 	case packages_model.TypeVagrant:
 		typeSpecificSize = setting.Packages.LimitSizeVagrant
 	}
@@ -414,6 +422,14 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		}
 	}
 
+	if err := quota_model.CheckQuota(ctx, owner.ID, quota_model.CategoryPackages, uploadSize); err != nil {
+		if errors.Is(err, util.ErrContentTooLarge) {
+			return ErrQuotaTotalSize
+		}
+		log.Error("CheckQuota failed: %v", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -533,6 +549,10 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 		return err
 	}
 
+	if err := packages_model.DeleteScanResultsByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+
 	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
 	if err != nil {
 		return err
@@ -654,5 +674,11 @@ func RemoveAllPackages(ctx context.Context, userID int64) (int, error) {
 			count++
 		}
 	}
+	if err := packages_model.DeleteRemotesByOwner(ctx, userID); err != nil {
+		return count, fmt.Errorf("unable to delete package remotes of %d. Error: %w", userID, err)
+	}
+	if err := packages_model.DeleteVirtualSourcesByOwner(ctx, userID); err != nil {
+		return count, fmt.Errorf("unable to delete package virtual sources of %d. Error: %w", userID, err)
+	}
 	return count, nil
 }
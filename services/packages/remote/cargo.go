@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+	cargo_service "code.gitea.io/gitea/services/packages/cargo"
+)
+
+// https://doc.rust-lang.org/cargo/reference/registry-index.html#sparse-protocol
+
+func getCargoIndexFile(ctx context.Context, pr *packages_model.PackageRemote, packageName string) (*File, error) {
+	return GetRemoteFile(ctx, pr, &FetchOptions{
+		Path:       cargo_service.BuildPackagePath(strings.ToLower(packageName)),
+		IsMetadata: true,
+	})
+}
+
+// GetCargoPackageIndex returns the index file of the package of the upstream sparse index.
+// The entries contain no locations, the crates are downloaded through the registry with GetCargoPackageFile.
+func GetCargoPackageIndex(ctx context.Context, owner *user_model.User, packageName string) ([]byte, error) {
+	pr, err := GetRemote(ctx, owner, packages_model.TypeCargo)
+	if err != nil {
+		return nil, err
+	}
+	f, err := getCargoIndexFile(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+	return ReadFile(f)
+}
+
+// cargoDownloadURL returns the location of a crate, the markers of the dl template are replaced
+// https://doc.rust-lang.org/cargo/reference/registry-index.html#index-configuration
+func cargoDownloadURL(dl, name, version, checksum string) string {
+	markers := []string{"{crate}", "{version}", "{prefix}", "{lowerprefix}", "{sha256-checksum}"}
+	hasMarker := false
+	for _, marker := range markers {
+		hasMarker = hasMarker || strings.Contains(dl, marker)
+	}
+	if !hasMarker {
+		return fmt.Sprintf("%s/%s/%s/download", strings.TrimSuffix(dl, "/"), name, version)
+	}
+
+	prefix := cargo_service.BuildPackagePath(name)
+	prefix = prefix[:strings.LastIndex(prefix, "/")]
+	return strings.NewReplacer(
+		"{crate}", name,
+		"{version}", version,
+		"{prefix}", prefix,
+		"{lowerprefix}", strings.ToLower(prefix),
+		"{sha256-checksum}", checksum,
+	).Replace(dl)
+}
+
+// GetCargoPackageFile returns the crate of the upstream registry, it is verified against the checksum of the index
+func GetCargoPackageFile(ctx context.Context, owner *user_model.User, packageName, packageVersion string) (*File, error) {
+	pr, err := GetRemote(ctx, owner, packages_model.TypeCargo)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := getCargoIndexFile(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	var entry *cargo_service.IndexVersionEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		var e cargo_service.IndexVersionEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		if e.Version == packageVersion {
+			entry = &e
+			break
+		}
+	}
+	if entry == nil {
+		return nil, util.NewNotExistErrorf("%s %s does not exist in the upstream registry", packageName, packageVersion)
+	}
+
+	f, err = GetRemoteFile(ctx, pr, &FetchOptions{
+		Path:       cargo_service.ConfigFileName,
+		IsMetadata: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err = ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	var config cargo_service.Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid config of the upstream registry: %w", err)
+	}
+	if config.DownloadURL == "" {
+		return nil, errors.New("the upstream registry has no download location")
+	}
+
+	return GetRemoteFile(ctx, pr, &FetchOptions{
+		Path: cargoDownloadURL(config.DownloadURL, entry.Name, entry.Version, entry.FileChecksum),
+		Verify: func(pb *packages_model.PackageBlob) error {
+			if !strings.EqualFold(entry.FileChecksum, pb.HashSHA256) {
+				return util.NewInvalidArgumentErrorf("checksum mismatch")
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,256 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+var httpClient = sync.OnceValue(func() *http.Client {
+	allowedHostListValue := setting.Packages.RemoteAllowedHostList
+	if allowedHostListValue == "" {
+		allowedHostListValue = hostmatcher.MatchBuiltinExternal
+	}
+	allowList := hostmatcher.ParseHostMatchList("packages.REMOTE_ALLOWED_HOST_LIST", allowedHostListValue)
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 proxy.Proxy(),
+			DialContext:           hostmatcher.NewDialContext("package remote", allowList, nil, setting.Proxy.ProxyURLFixed),
+			ResponseHeaderTimeout: time.Minute,
+		},
+	}
+})
+
+// doRequest requests the path from the upstream of the remote. Registries which require a bearer token,
+// like container registries, are answered by requesting a token from the realm of the authentication challenge.
+func doRequest(ctx context.Context, pr *packages_model.PackageRemote, p, accept string) (*http.Response, error) {
+	u := resolveURL(pr, p)
+	sendCredentials := isUpstreamHost(pr, u)
+
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+	token := ""
+	if sendCredentials {
+		token = tokens.get(pr.ID, u)
+		if err := authorize(req, pr, token); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && sendCredentials {
+		challenge := parseBearerChallenge(resp.Header.Get("WWW-Authenticate"))
+		resp.Body.Close()
+		if challenge == nil {
+			return nil, fmt.Errorf("upstream registry responded %s for %s", resp.Status, p)
+		}
+
+		if token, err = requestToken(ctx, pr, challenge); err != nil {
+			return nil, err
+		}
+		tokens.put(pr.ID, u, token)
+
+		if req, err = newRequest(); err != nil {
+			return nil, err
+		}
+		if err := authorize(req, pr, token); err != nil {
+			return nil, err
+		}
+		if resp, err = httpClient().Do(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := checkStatus(resp, p); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+func isUpstreamHost(pr *packages_model.PackageRemote, u string) bool {
+	upstream, err := url.Parse(pr.URL)
+	if err != nil {
+		return false
+	}
+	target, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(upstream.Host, target.Host)
+}
+
+func authorize(req *http.Request, pr *packages_model.PackageRemote, token string) error {
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	if pr.Username == "" {
+		return nil
+	}
+	password, err := pr.Password()
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(pr.Username, password)
+	return nil
+}
+
+// parseBearerChallenge returns the parameters of a bearer authentication challenge or nil if it is no such challenge.
+// https://distribution.github.io/distribution/spec/auth/token/
+func parseBearerChallenge(header string) map[string]string {
+	scheme, params, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil
+	}
+
+	challenge := map[string]string{}
+	for params != "" {
+		var key, value string
+		key, params, _ = strings.Cut(strings.TrimLeft(params, " ,"), "=")
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+		if key != "" {
+			challenge[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+	if challenge["realm"] == "" {
+		return nil
+	}
+	return challenge
+}
+
+func requestToken(ctx context.Context, pr *packages_model.PackageRemote, challenge map[string]string) (string, error) {
+	realm, err := url.Parse(challenge["realm"])
+	if err != nil {
+		return "", err
+	}
+	q := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if challenge[key] != "" {
+			q.Set(key, challenge[key])
+		}
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	// anonymous tokens are requested if the remote has no credentials
+	if err := authorize(req, pr, ""); err != nil {
+		return "", err
+	}
+
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting a token of the upstream registry failed: %s", resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", errors.New("the upstream registry returned no token")
+}
+
+// tokenLifetime is how long a bearer token is reused, registries issue tokens valid for at least a minute
+const tokenLifetime = 50 * time.Second
+
+type tokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]cachedToken
+}
+
+type cachedToken struct {
+	token   string
+	expires time.Time
+}
+
+var tokens = &tokenCache{tokens: map[string]cachedToken{}}
+
+// tokenKey returns the key a token is cached by, tokens are scoped to a repository which is the parent of the requested file
+func tokenKey(remoteID int64, u string) string {
+	if i := strings.LastIndex(u, "/"); i >= 0 {
+		u = u[:i]
+	}
+	if i := strings.LastIndex(u, "/"); i >= 0 {
+		u = u[:i]
+	}
+	return fmt.Sprintf("%d_%s", remoteID, u)
+}
+
+func (c *tokenCache) get(remoteID int64, u string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := tokenKey(remoteID, u)
+	t, ok := c.tokens[key]
+	if !ok || time.Now().After(t.expires) {
+		delete(c.tokens, key)
+		return ""
+	}
+	return t.token
+}
+
+func (c *tokenCache) put(remoteID int64, u, token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, t := range c.tokens {
+		if now.After(t.expires) {
+			delete(c.tokens, key)
+		}
+	}
+	c.tokens[tokenKey(remoteID, u)] = cachedToken{token: token, expires: now.Add(tokenLifetime)}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/opencontainers/go-digest"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pull
+
+var containerManifestMediaTypes = strings.Join([]string{
+	oci.MediaTypeImageIndex,
+	oci.MediaTypeImageManifest,
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+// dockerHubHosts are the hosts of Docker Hub, which keeps the official images in the "library" namespace
+var dockerHubHosts = []string{"registry-1.docker.io", "index.docker.io", "docker.io", "registry.hub.docker.com"}
+
+func containerUpstreamImage(pr *packages_model.PackageRemote, image string) string {
+	if strings.Contains(image, "/") {
+		return image
+	}
+	u, err := url.Parse(pr.URL)
+	if err != nil {
+		return image
+	}
+	for _, host := range dockerHubHosts {
+		if strings.EqualFold(u.Host, host) {
+			return "library/" + image
+		}
+	}
+	return image
+}
+
+func verifyContainerDigest(d digest.Digest) func(pb *packages_model.PackageBlob) error {
+	return func(pb *packages_model.PackageBlob) error {
+		if d.Encoded() != pb.HashSHA256 {
+			return util.NewInvalidArgumentErrorf("digest mismatch")
+		}
+		return nil
+	}
+}
+
+// GetContainerManifest returns the manifest of the image of the upstream registry.
+// Manifests referenced by a tag are refreshed after the TTL of the remote, manifests referenced by a digest never change.
+func GetContainerManifest(ctx context.Context, owner *user_model.User, image, reference string) (*File, error) {
+	pr, err := GetRemote(ctx, owner, packages_model.TypeContainer)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestsPath := "v2/" + containerUpstreamImage(pr, image) + "/manifests/"
+	opts := &FetchOptions{
+		Path:   manifestsPath + reference,
+		Accept: containerManifestMediaTypes,
+	}
+	if d := digest.Digest(reference); d.Validate() == nil {
+		if d.Algorithm() != digest.SHA256 {
+			return nil, util.NewNotExistErrorf("unsupported digest algorithm %s", d.Algorithm())
+		}
+		opts.Verify = verifyContainerDigest(d)
+	} else {
+		opts.IsMetadata = true
+	}
+	f, err := GetRemoteFile(ctx, pr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IsMetadata {
+		// clients request the manifest by its digest next, which is answered from the cache instead of the upstream
+		digestPath := manifestsPath + f.Digest()
+		if _, err := packages_model.GetRemoteFileByPath(ctx, pr.ID, digestPath); errors.Is(err, packages_model.ErrPackageRemoteFileNotExist) {
+			err = packages_model.SaveRemoteFile(ctx, &packages_model.PackageRemoteFile{
+				RemoteID:    pr.ID,
+				Path:        digestPath,
+				BlobID:      f.BlobID,
+				ContentType: f.ContentType,
+				FetchedUnix: f.FetchedUnix,
+			})
+			if err != nil {
+				log.Error("Error caching manifest %s of package remote %d: %v", digestPath, pr.ID, err)
+			}
+		}
+	}
+	return f, nil
+}
+
+// GetContainerBlob returns the blob of the image of the upstream registry
+func GetContainerBlob(ctx context.Context, owner *user_model.User, image string, d digest.Digest) (*File, error) {
+	if d.Validate() != nil || d.Algorithm() != digest.SHA256 {
+		return nil, util.NewNotExistErrorf("unsupported digest %s", d)
+	}
+
+	pr, err := GetRemote(ctx, owner, packages_model.TypeContainer)
+	if err != nil {
+		return nil, err
+	}
+	return GetRemoteFile(ctx, pr, &FetchOptions{
+		Path:   "v2/" + containerUpstreamImage(pr, image) + "/blobs/" + d.String(),
+		Verify: verifyContainerDigest(d),
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// https://github.com/npm/registry/blob/main/docs/REGISTRY-API.md#getpackage
+
+type npmDistribution struct {
+	Integrity string `json:"integrity"`
+	Shasum    string `json:"shasum"`
+	Tarball   string `json:"tarball"`
+}
+
+func getNpmPackageDocument(ctx context.Context, pr *packages_model.PackageRemote, packageName string) (map[string]json.RawMessage, error) {
+	f, err := GetRemoteFile(ctx, pr, &FetchOptions{
+		Path:       url.PathEscape(packageName),
+		IsMetadata: true,
+		Accept:     "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid package document of %s: %w", packageName, err)
+	}
+	return doc, nil
+}
+
+// GetNpmPackageMetadata returns the package document of the upstream registry.
+// The tarballs are rewritten to point to the registry at registryURL, which serves them with GetNpmPackageFile.
+func GetNpmPackageMetadata(ctx context.Context, owner *user_model.User, packageName, registryURL string) ([]byte, error) {
+	pr, err := GetRemote(ctx, owner, packages_model.TypeNpm)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := getNpmPackageDocument(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(doc["versions"], &versions); err != nil {
+		return nil, fmt.Errorf("invalid package document of %s: %w", packageName, err)
+	}
+	for version, metadata := range versions {
+		var dist map[string]any
+		if err := json.Unmarshal(metadata["dist"], &dist); err != nil {
+			return nil, fmt.Errorf("invalid package document of %s: %w", packageName, err)
+		}
+		tarball, _ := dist["tarball"].(string)
+		if tarball == "" {
+			continue
+		}
+		dist["tarball"] = fmt.Sprintf("%s/%s/-/%s/%s", registryURL, packageName, url.PathEscape(version), path.Base(tarball))
+		if metadata["dist"], err = json.Marshal(dist); err != nil {
+			return nil, err
+		}
+	}
+	if doc["versions"], err = json.Marshal(versions); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// GetNpmPackageFile returns the tarball of the upstream registry with the filename.
+// If the version is empty, the version is looked up by the filename.
+func GetNpmPackageFile(ctx context.Context, owner *user_model.User, packageName, packageVersion, filename string) (*File, error) {
+	pr, err := GetRemote(ctx, owner, packages_model.TypeNpm)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := getNpmPackageDocument(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions map[string]struct {
+		Dist npmDistribution `json:"dist"`
+	}
+	if err := json.Unmarshal(doc["versions"], &versions); err != nil {
+		return nil, fmt.Errorf("invalid package document of %s: %w", packageName, err)
+	}
+
+	var dist *npmDistribution
+	for version, metadata := range versions {
+		if packageVersion != "" && version != packageVersion {
+			continue
+		}
+		if path.Base(metadata.Dist.Tarball) == filename {
+			dist = &metadata.Dist
+			break
+		}
+	}
+	if dist == nil {
+		return nil, util.NewNotExistErrorf("%s does not exist in the upstream registry", filename)
+	}
+
+	return GetRemoteFile(ctx, pr, &FetchOptions{
+		Path: dist.Tarball,
+		Verify: func(pb *packages_model.PackageBlob) error {
+			return verifyNpmDistribution(dist, pb)
+		},
+	})
+}
+
+func verifyNpmDistribution(dist *npmDistribution, pb *packages_model.PackageBlob) error {
+	// https://w3c.github.io/webappsec-subresource-integrity/#integrity-metadata-description
+	for _, integrity := range strings.Fields(dist.Integrity) {
+		algorithm, digest, _ := strings.Cut(integrity, "-")
+		if algorithm != "sha512" {
+			continue
+		}
+		expected, err := base64.StdEncoding.DecodeString(digest)
+		if err != nil || len(expected) != sha512.Size {
+			return util.NewInvalidArgumentErrorf("invalid integrity %q", integrity)
+		}
+		if hex.EncodeToString(expected) != pb.HashSHA512 {
+			return util.NewInvalidArgumentErrorf("integrity mismatch")
+		}
+		return nil
+	}
+	if dist.Shasum != "" && !strings.EqualFold(dist.Shasum, pb.HashSHA1) {
+		return util.NewInvalidArgumentErrorf("shasum mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// https://peps.python.org/pep-0503/
+
+var (
+	pypiNameNormalizer = regexp.MustCompile(`[-_.]+`)
+	pypiAnchorPattern  = regexp.MustCompile(`(?is)<a\s([^>]*)>(.*?)</a>`)
+	pypiHrefPattern    = regexp.MustCompile(`(?is)\bhref\s*=\s*"([^"]*)"`)
+	// the metadata files of PEP 658 are not mirrored, clients download the distributions instead
+	pypiMetadataAttributePattern = regexp.MustCompile(`(?is)\s+data-(?:dist-info|core)-metadata\s*=\s*"[^"]*"`)
+)
+
+type pypiLink struct {
+	URL      string // absolute, without fragment
+	Fragment string
+	Filename string
+}
+
+func normalizePyPIName(name string) string {
+	return strings.ToLower(pypiNameNormalizer.ReplaceAllString(name, "-"))
+}
+
+// getPyPISimpleIndex returns the simple index page of the upstream registry and its location
+func getPyPISimpleIndex(ctx context.Context, pr *packages_model.PackageRemote, packageName string) (*url.URL, []byte, error) {
+	f, err := GetRemoteFile(ctx, pr, &FetchOptions{
+		Path:       normalizePyPIName(packageName) + "/",
+		IsMetadata: true,
+		Accept:     "text/html",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := ReadFile(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	pageURL, err := url.Parse(resolveURL(pr, f.Path))
+	if err != nil {
+		return nil, nil, err
+	}
+	return pageURL, data, nil
+}
+
+// parsePyPILink parses the attributes of an anchor, the link is resolved relative to the index page
+func parsePyPILink(pageURL *url.URL, attributes string) *pypiLink {
+	m := pypiHrefPattern.FindStringSubmatch(attributes)
+	if m == nil {
+		return nil
+	}
+	u, err := pageURL.Parse(html.UnescapeString(m[1]))
+	if err != nil {
+		return nil
+	}
+	link := &pypiLink{Fragment: u.Fragment, Filename: path.Base(u.Path)}
+	u.Fragment = ""
+	link.URL = u.String()
+	return link
+}
+
+// pypiVersionFromFilename returns the version of a distribution filename, it is only used to build download links
+func pypiVersionFromFilename(filename string) string {
+	if strings.HasSuffix(filename, ".whl") {
+		// {distribution}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}.whl
+		if parts := strings.Split(filename, "-"); len(parts) >= 5 {
+			return parts[1]
+		}
+	}
+	for _, ext := range []string{".tar.gz", ".tar.bz2", ".zip", ".egg"} {
+		if base, ok := strings.CutSuffix(filename, ext); ok {
+			if i := strings.LastIndex(base, "-"); i >= 0 {
+				return base[i+1:]
+			}
+		}
+	}
+	return "0"
+}
+
+// GetPyPISimpleIndex returns the simple index page of the upstream registry.
+// The links are rewritten to point to the registry at registryURL, which serves them with GetPyPIPackageFile.
+func GetPyPISimpleIndex(ctx context.Context, owner *user_model.User, packageName, registryURL string) ([]byte, error) {
+	pr, err := GetRemote(ctx, owner, packages_model.TypePyPI)
+	if err != nil {
+		return nil, err
+	}
+	pageURL, data, err := getPyPISimpleIndex(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	name := normalizePyPIName(packageName)
+	page := pypiAnchorPattern.ReplaceAllStringFunc(string(data), func(anchor string) string {
+		m := pypiAnchorPattern.FindStringSubmatch(anchor)
+		link := parsePyPILink(pageURL, m[1])
+		if link == nil {
+			return anchor
+		}
+		href := fmt.Sprintf("%s/files/%s/%s/%s", registryURL, name, url.PathEscape(pypiVersionFromFilename(link.Filename)), url.PathEscape(link.Filename))
+		if link.Fragment != "" {
+			href += "#" + link.Fragment
+		}
+		attributes := pypiMetadataAttributePattern.ReplaceAllString(m[1], "")
+		attributes = pypiHrefPattern.ReplaceAllLiteralString(attributes, `href="`+html.EscapeString(href)+`"`)
+		return "<a " + attributes + ">" + m[2] + "</a>"
+	})
+	return []byte(page), nil
+}
+
+// GetPyPIPackageFile returns the distribution of the upstream registry with the filename
+func GetPyPIPackageFile(ctx context.Context, owner *user_model.User, packageName, filename string) (*File, error) {
+	pr, err := GetRemote(ctx, owner, packages_model.TypePyPI)
+	if err != nil {
+		return nil, err
+	}
+	pageURL, data, err := getPyPISimpleIndex(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var link *pypiLink
+	for _, m := range pypiAnchorPattern.FindAllStringSubmatch(string(data), -1) {
+		if l := parsePyPILink(pageURL, m[1]); l != nil && l.Filename == filename {
+			link = l
+			break
+		}
+	}
+	if link == nil {
+		return nil, util.NewNotExistErrorf("%s does not exist in the upstream registry", filename)
+	}
+
+	return GetRemoteFile(ctx, pr, &FetchOptions{
+		Path: link.URL,
+		Verify: func(pb *packages_model.PackageBlob) error {
+			// https://peps.python.org/pep-0503/#specification, the fragment is <hashname>=<hashvalue>
+			algorithm, digest, _ := strings.Cut(link.Fragment, "=")
+			var expected string
+			switch algorithm {
+			case "sha256":
+				expected = pb.HashSHA256
+			case "sha512":
+				expected = pb.HashSHA512
+			case "sha1":
+				expected = pb.HashSHA1
+			case "md5":
+				expected = pb.HashMD5
+			default:
+				return nil
+			}
+			if !strings.EqualFold(digest, expected) {
+				return util.NewInvalidArgumentErrorf("%s mismatch", algorithm)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// maxMetadataSize is the maximum size of metadata documents which are parsed to answer requests
+const maxMetadataSize = 64 * 1024 * 1024
+
+// File is a file of an upstream registry which has been cached
+type File struct {
+	*packages_model.PackageRemoteFile
+	Blob *packages_model.PackageBlob
+}
+
+// Filename returns the name of the file, which is the last element of its path
+func (f *File) Filename() string {
+	p := f.Path
+	if u, err := url.Parse(p); err == nil && u.IsAbs() {
+		p = u.Path
+	}
+	return path.Base(p)
+}
+
+// Digest returns the OCI digest of the file
+func (f *File) Digest() string {
+	return "sha256:" + f.Blob.HashSHA256
+}
+
+// FetchOptions describe which file to fetch from the upstream registry
+type FetchOptions struct {
+	// Path is relative to the upstream URL. Absolute URLs are used for files the upstream metadata links to,
+	// the credentials of the remote are only sent if they point to the upstream host.
+	Path string
+	// IsMetadata marks files which change over time and are refreshed after the TTL of the remote,
+	// other files are cached forever
+	IsMetadata bool
+	// Accept is sent as header of the upstream request
+	Accept string
+	// Verify checks the fetched content, the file is not cached if an error is returned
+	Verify func(pb *packages_model.PackageBlob) error
+}
+
+// GetRemote returns the enabled remote of the owner's registry of the package type
+func GetRemote(ctx context.Context, owner *user_model.User, packageType packages_model.Type) (*packages_model.PackageRemote, error) {
+	return packages_model.GetEnabledRemoteByOwnerAndType(ctx, owner.ID, packageType)
+}
+
+// GetFile returns the file of the owner's remote of the package type, see GetRemoteFile
+func GetFile(ctx context.Context, owner *user_model.User, packageType packages_model.Type, opts *FetchOptions) (*File, error) {
+	pr, err := GetRemote(ctx, owner, packageType)
+	if err != nil {
+		return nil, err
+	}
+	return GetRemoteFile(ctx, pr, opts)
+}
+
+// GetRemoteFile returns the cached file or fetches it from the upstream of the remote.
+// Cached metadata older than the TTL is refreshed, if the upstream is unavailable the outdated copy is returned.
+func GetRemoteFile(ctx context.Context, pr *packages_model.PackageRemote, opts *FetchOptions) (*File, error) {
+	cached, err := getCachedFile(ctx, pr, opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && !isExpired(pr, cached) {
+		return cached, nil
+	}
+
+	releaser, err := globallock.Lock(ctx, fmt.Sprintf("package_remote_%d_%s", pr.ID, packages_model.RemoteFilePathHash(opts.Path)))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	// the file may have been fetched by another request while waiting for the lock
+	cached, err = getCachedFile(ctx, pr, opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && !isExpired(pr, cached) {
+		return cached, nil
+	}
+
+	f, err := fetchFile(ctx, pr, opts)
+	if err != nil {
+		if cached != nil && !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Serving outdated %s of package remote %d, the upstream failed: %v", opts.Path, pr.ID, err)
+			return cached, nil
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func getCachedFile(ctx context.Context, pr *packages_model.PackageRemote, p string) (*File, error) {
+	prf, err := packages_model.GetRemoteFileByPath(ctx, pr.ID, p)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageRemoteFileNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	pb, err := packages_model.GetBlobByID(ctx, prf.BlobID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &File{PackageRemoteFile: prf, Blob: pb}, nil
+}
+
+func isExpired(pr *packages_model.PackageRemote, f *File) bool {
+	return f.IsMetadata && f.FetchedUnix.AsTime().Add(pr.GetMetadataTTL()).Before(time.Now())
+}
+
+func fetchFile(ctx context.Context, pr *packages_model.PackageRemote, opts *FetchOptions) (*File, error) {
+	resp, err := doRequest(ctx, pr, opts.Path, opts.Accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	buf, err := packages_module.CreateHashedBufferFromReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	pb := packages_service.NewPackageBlob(buf)
+	if opts.Verify != nil {
+		if err := opts.Verify(pb); err != nil {
+			return nil, fmt.Errorf("verifying %s failed: %w", opts.Path, err)
+		}
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	prf := &packages_model.PackageRemoteFile{
+		RemoteID:    pr.ID,
+		Path:        opts.Path,
+		ContentType: resp.Header.Get("Content-Type"),
+		IsMetadata:  opts.IsMetadata,
+		FetchedUnix: timeutil.TimeStampNow(),
+	}
+
+	blobCreated := false
+	err = db.WithTx(ctx, func(ctx context.Context) error {
+		var exists bool
+		pb, exists, err = packages_model.GetOrInsertBlob(ctx, pb)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			blobCreated = true
+			if err := packages_module.NewContentStore().Save(packages_module.BlobHash256Key(pb.HashSHA256), buf, buf.Size()); err != nil {
+				return err
+			}
+		}
+		prf.BlobID = pb.ID
+		return packages_model.SaveRemoteFile(ctx, prf)
+	})
+	if err != nil {
+		if blobCreated {
+			if err := packages_module.NewContentStore().Delete(packages_module.BlobHash256Key(pb.HashSHA256)); err != nil {
+				log.Error("Error deleting package blob from content store: %v", err)
+			}
+		}
+		return nil, err
+	}
+
+	return &File{PackageRemoteFile: prf, Blob: pb}, nil
+}
+
+// OpenFile returns the content of the file
+func OpenFile(f *File) (io.ReadSeekCloser, error) {
+	return packages_service.OpenBlobStream(f.Blob)
+}
+
+// OpenFileForDownload returns the content of the file or the direct serving url if supported by the storage
+func OpenFileForDownload(ctx context.Context, f *File, method string, serveDirectReqParams url.Values) (io.ReadSeekCloser, *url.URL, error) {
+	pf := &packages_model.PackageFile{
+		Name:        f.Filename(),
+		CreatedUnix: f.FetchedUnix,
+	}
+	s, u, _, err := packages_service.OpenBlobForDownload(ctx, pf, f.Blob, method, serveDirectReqParams)
+	return s, u, err
+}
+
+// ReadFile returns the content of a metadata file
+func ReadFile(f *File) ([]byte, error) {
+	if f.Blob.Size > maxMetadataSize {
+		return nil, util.NewInvalidArgumentErrorf("%s is too large", f.Path)
+	}
+	s, err := OpenFile(f)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return io.ReadAll(s)
+}
+
+// ClearCache removes all cached files of the remote, the blobs are removed by the cleanup task
+func ClearCache(ctx context.Context, pr *packages_model.PackageRemote) error {
+	return packages_model.DeleteRemoteFilesByRemoteID(ctx, pr.ID)
+}
+
+func isAbsoluteURL(p string) bool {
+	return strings.HasPrefix(p, "https://") || strings.HasPrefix(p, "http://")
+}
+
+// resolveURL returns the location of the path at the upstream
+func resolveURL(pr *packages_model.PackageRemote, p string) string {
+	if isAbsoluteURL(p) {
+		return p
+	}
+	return strings.TrimSuffix(pr.URL, "/") + "/" + strings.TrimPrefix(p, "/")
+}
+
+func checkStatus(resp *http.Response, p string) error {
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return util.NewNotExistErrorf("%s does not exist in the upstream registry", p)
+	default:
+		return fmt.Errorf("upstream registry responded %s for %s", resp.Status, p)
+	}
+}
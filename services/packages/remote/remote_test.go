@@ -0,0 +1,408 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func insertRemote(t *testing.T, owner *user_model.User, packageType packages_model.Type, upstreamURL string) *packages_model.PackageRemote {
+	pr, err := packages_model.InsertRemote(t.Context(), &packages_model.PackageRemote{
+		Enabled: true,
+		OwnerID: owner.ID,
+		Type:    packageType,
+		URL:     upstreamURL,
+	})
+	require.NoError(t, err)
+	return pr
+}
+
+func expireRemoteFiles(t *testing.T, pr *packages_model.PackageRemote) {
+	_, err := db.GetEngine(t.Context()).Where("remote_id = ?", pr.ID).Cols("fetched_unix").Update(&packages_model.PackageRemoteFile{FetchedUnix: 1})
+	require.NoError(t, err)
+}
+
+func TestGetRemoteFile(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	var hits atomic.Int32
+	var failing atomic.Bool
+	content := "content"
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		switch r.URL.Path {
+		case "/file", "/metadata":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(content))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	pr := insertRemote(t, owner, packages_model.TypeGo, upstream.URL)
+
+	read := func(t *testing.T, opts *FetchOptions) string {
+		f, err := GetRemoteFile(t.Context(), pr, opts)
+		require.NoError(t, err)
+		data, err := ReadFile(f)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("NotExist", func(t *testing.T) {
+		_, err := GetRemoteFile(t.Context(), pr, &FetchOptions{Path: "missing"})
+		assert.ErrorIs(t, err, util.ErrNotExist)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		hits.Store(0)
+
+		assert.Equal(t, "content", read(t, &FetchOptions{Path: "file"}))
+		assert.Equal(t, "content", read(t, &FetchOptions{Path: "file"}))
+		assert.EqualValues(t, 1, hits.Load())
+
+		prf, err := packages_model.GetRemoteFileByPath(t.Context(), pr.ID, "file")
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain", prf.ContentType)
+
+		// files are never refreshed
+		expireRemoteFiles(t, pr)
+		assert.Equal(t, "content", read(t, &FetchOptions{Path: "file"}))
+		assert.EqualValues(t, 1, hits.Load())
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+		hits.Store(0)
+
+		opts := &FetchOptions{Path: "metadata", IsMetadata: true}
+		assert.Equal(t, "content", read(t, opts))
+		assert.Equal(t, "content", read(t, opts))
+		assert.EqualValues(t, 1, hits.Load())
+
+		content = "changed"
+		defer func() { content = "content" }()
+
+		expireRemoteFiles(t, pr)
+		assert.Equal(t, "changed", read(t, opts))
+		assert.EqualValues(t, 2, hits.Load())
+
+		// the outdated copy is served if the upstream fails
+		failing.Store(true)
+		defer failing.Store(false)
+
+		expireRemoteFiles(t, pr)
+		assert.Equal(t, "changed", read(t, opts))
+		assert.EqualValues(t, 3, hits.Load())
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		_, err := GetRemoteFile(t.Context(), pr, &FetchOptions{
+			Path: "file?verify",
+			Verify: func(pb *packages_model.PackageBlob) error {
+				return util.NewInvalidArgumentErrorf("mismatch")
+			},
+		})
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+		_, err = packages_model.GetRemoteFileByPath(t.Context(), pr.ID, "file?verify")
+		assert.ErrorIs(t, err, packages_model.ErrPackageRemoteFileNotExist)
+	})
+
+	t.Run("ClearCache", func(t *testing.T) {
+		count, err := packages_model.CountRemoteFiles(t.Context(), pr.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+
+		require.NoError(t, ClearCache(t.Context(), pr))
+
+		count, err = packages_model.CountRemoteFiles(t.Context(), pr.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		pr.Enabled = false
+		require.NoError(t, packages_model.UpdateRemote(t.Context(), pr))
+		defer func() {
+			pr.Enabled = true
+			require.NoError(t, packages_model.UpdateRemote(t.Context(), pr))
+		}()
+
+		_, err := GetFile(t.Context(), owner, packages_model.TypeGo, &FetchOptions{Path: "file"})
+		assert.ErrorIs(t, err, packages_model.ErrPackageRemoteNotExist)
+	})
+}
+
+func TestBearerAuthentication(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	var tokenRequests atomic.Int32
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			tokenRequests.Add(1)
+			username, password, _ := r.BasicAuth()
+			assert.Equal(t, "user", username)
+			assert.Equal(t, "secret", password)
+			assert.Equal(t, "registry", r.URL.Query().Get("service"))
+			assert.Equal(t, "repository:library/alpine:pull", r.URL.Query().Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "abc"})
+		case r.Header.Get("Authorization") != "Bearer abc":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:library/alpine:pull"`, upstream.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			_, _ = w.Write([]byte("blob"))
+		}
+	}))
+	defer upstream.Close()
+
+	pr := insertRemote(t, owner, packages_model.TypeContainer, upstream.URL)
+	pr.Username = "user"
+	require.NoError(t, pr.SetPassword("secret"))
+	require.NoError(t, packages_model.UpdateRemote(t.Context(), pr))
+
+	for _, p := range []string{"v2/library/alpine/blobs/a", "v2/library/alpine/blobs/b"} {
+		f, err := GetRemoteFile(t.Context(), pr, &FetchOptions{Path: p})
+		require.NoError(t, err)
+		data, err := ReadFile(f)
+		require.NoError(t, err)
+		assert.Equal(t, "blob", string(data))
+	}
+	// the token is reused for the files of the repository
+	assert.EqualValues(t, 1, tokenRequests.Load())
+}
+
+func TestNpm(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	tarball := "tarball content"
+	sum := sha512.Sum512([]byte(tarball))
+	integrity := "sha512-" + base64.StdEncoding.EncodeToString(sum[:])
+
+	var upstream *httptest.Server
+	upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/@scope/test":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name": "@scope/test",
+				"versions": map[string]any{
+					"1.0.0": map[string]any{
+						"name": "@scope/test",
+						"dist": map[string]any{
+							"integrity": integrity,
+							"tarball":   upstream.URL + "/@scope/test/-/test-1.0.0.tgz",
+						},
+					},
+					"2.0.0": map[string]any{
+						"name": "@scope/test",
+						"dist": map[string]any{
+							"integrity": "sha512-" + base64.StdEncoding.EncodeToString(make([]byte, sha512.Size)),
+							"tarball":   upstream.URL + "/@scope/test/-/test-2.0.0.tgz",
+						},
+					},
+				},
+			})
+		case "/@scope/test/-/test-1.0.0.tgz", "/@scope/test/-/test-2.0.0.tgz":
+			_, _ = w.Write([]byte(tarball))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	insertRemote(t, owner, packages_model.TypeNpm, upstream.URL)
+
+	t.Run("Metadata", func(t *testing.T) {
+		data, err := GetNpmPackageMetadata(t.Context(), owner, "@scope/test", "https://gitea.example/api/packages/user2/npm")
+		require.NoError(t, err)
+
+		var doc struct {
+			Versions map[string]struct {
+				Dist npmDistribution `json:"dist"`
+			} `json:"versions"`
+		}
+		require.NoError(t, json.Unmarshal(data, &doc))
+		assert.Equal(t, "https://gitea.example/api/packages/user2/npm/@scope/test/-/1.0.0/test-1.0.0.tgz", doc.Versions["1.0.0"].Dist.Tarball)
+		assert.Equal(t, integrity, doc.Versions["1.0.0"].Dist.Integrity)
+
+		_, err = GetNpmPackageMetadata(t.Context(), owner, "unknown", "")
+		assert.ErrorIs(t, err, util.ErrNotExist)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		f, err := GetNpmPackageFile(t.Context(), owner, "@scope/test", "", "test-1.0.0.tgz")
+		require.NoError(t, err)
+		assert.Equal(t, "test-1.0.0.tgz", f.Filename())
+
+		_, err = GetNpmPackageFile(t.Context(), owner, "@scope/test", "1.0.0", "test-2.0.0.tgz")
+		assert.ErrorIs(t, err, util.ErrNotExist)
+
+		_, err = GetNpmPackageFile(t.Context(), owner, "@scope/test", "2.0.0", "test-2.0.0.tgz")
+		assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+}
+
+func TestPyPI(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	content := "wheel content"
+	sum := sha256.Sum256([]byte(content))
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/simple/test-package/":
+			_, _ = fmt.Fprintf(w, `<html><body>
+<a href="../../files/test_package-1.0-py3-none-any.whl#sha256=%s" data-dist-info-metadata="sha256=abc">test_package-1.0-py3-none-any.whl</a>
+<a href="https://files.example/test-package-1.0.tar.gz#sha256=%s">test-package-1.0.tar.gz</a>
+</body></html>`, hex.EncodeToString(sum[:]), strings.Repeat("0", 64))
+		case "/files/test_package-1.0-py3-none-any.whl":
+			_, _ = w.Write([]byte(content))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	insertRemote(t, owner, packages_model.TypePyPI, upstream.URL+"/simple/")
+
+	t.Run("SimpleIndex", func(t *testing.T) {
+		data, err := GetPyPISimpleIndex(t.Context(), owner, "Test_Package", "https://gitea.example/api/packages/user2/pypi")
+		require.NoError(t, err)
+		page := string(data)
+		assert.Contains(t, page, `<a href="https://gitea.example/api/packages/user2/pypi/files/test-package/1.0/test_package-1.0-py3-none-any.whl#sha256=`+hex.EncodeToString(sum[:])+`">`)
+		assert.Contains(t, page, `<a href="https://gitea.example/api/packages/user2/pypi/files/test-package/1.0/test-package-1.0.tar.gz#sha256=`)
+		assert.NotContains(t, page, "data-dist-info-metadata")
+	})
+
+	t.Run("File", func(t *testing.T) {
+		f, err := GetPyPIPackageFile(t.Context(), owner, "test-package", "test_package-1.0-py3-none-any.whl")
+		require.NoError(t, err)
+		data, err := ReadFile(f)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+
+		_, err = GetPyPIPackageFile(t.Context(), owner, "test-package", "unknown.whl")
+		assert.ErrorIs(t, err, util.ErrNotExist)
+	})
+}
+
+func TestParseBearerChallenge(t *testing.T) {
+	assert.Nil(t, parseBearerChallenge(""))
+	assert.Nil(t, parseBearerChallenge(`Basic realm="Registry"`))
+	assert.Nil(t, parseBearerChallenge(`Bearer service="registry"`))
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/alpine:pull,push",
+	}, parseBearerChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull,push"`))
+	assert.Equal(t, map[string]string{
+		"realm":   "https://ghcr.io/token",
+		"service": "ghcr.io",
+	}, parseBearerChallenge(`bearer realm="https://ghcr.io/token", service=ghcr.io`))
+}
+
+func TestPyPIVersionFromFilename(t *testing.T) {
+	cases := map[string]string{
+		"test_package-1.0.2-py3-none-any.whl":   "1.0.2",
+		"test_package-1.0.2-1-py3-none-any.whl": "1.0.2",
+		"test-package-1.0.2.tar.gz":             "1.0.2",
+		"test_package-2.0rc1.zip":               "2.0rc1",
+		"test_package-1.0-py2.7.egg":            "py2.7",
+		"invalid":                               "0",
+	}
+	for filename, version := range cases {
+		assert.Equal(t, version, pypiVersionFromFilename(filename), filename)
+	}
+}
+
+func TestCargoDownloadURL(t *testing.T) {
+	assert.Equal(t, "https://crates.example/api/v1/crates/serde/1.0.0/download", cargoDownloadURL("https://crates.example/api/v1/crates", "serde", "1.0.0", "abc"))
+	assert.Equal(t, "https://static.crates.io/crates/serde/serde-1.0.0.crate", cargoDownloadURL("https://static.crates.io/crates/{crate}/{crate}-{version}.crate", "serde", "1.0.0", "abc"))
+	assert.Equal(t, "https://crates.example/Se/rd/Serde/abc", cargoDownloadURL("https://crates.example/{prefix}/{crate}/{sha256-checksum}", "Serde", "1.0.0", "abc"))
+	assert.Equal(t, "https://crates.example/se/rd", cargoDownloadURL("https://crates.example/{lowerprefix}", "SErde", "1.0.0", "abc"))
+	assert.Equal(t, "https://crates.example/1/a", cargoDownloadURL("https://crates.example/{prefix}/{crate}", "a", "1.0.0", "abc"))
+}
+
+func TestGetVersions(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/se/rd/serde":
+			_, _ = w.Write([]byte(`{"name":"serde","vers":"1.0.0","yanked":false}` + "\n" + `{"name":"serde","vers":"1.0.1","yanked":true}` + "\n"))
+		case "/github.com/!burnt!sushi/toml/@v/list":
+			_, _ = w.Write([]byte("v1.2.0\nv1.3.0\n"))
+		case "/org/slf4j/slf4j-api/maven-metadata.xml":
+			_, _ = w.Write([]byte(`<metadata><versioning><versions><version>2.0.9</version><version>2.0.12</version></versions></versioning></metadata>`))
+		case "/lodash":
+			_, _ = w.Write([]byte(`{"versions":{"4.17.20":{},"4.17.21":{"deprecated":false},"4.17.22":{"deprecated":"broken"}}}`))
+		case "/django/":
+			_, _ = w.Write([]byte(`<a href="Django-4.2.7.tar.gz">x</a><a href="Django-4.2.7-py3-none-any.whl">x</a><a href="Django-4.2.8.tar.gz" data-yanked="">x</a>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	for _, packageType := range []packages_model.Type{packages_model.TypeCargo, packages_model.TypeGo, packages_model.TypeMaven, packages_model.TypeNpm, packages_model.TypePyPI} {
+		insertRemote(t, owner, packageType, upstream.URL)
+	}
+
+	cases := []struct {
+		Type     packages_model.Type
+		Name     string
+		Expected []string
+	}{
+		{packages_model.TypeCargo, "serde", []string{"1.0.0"}},
+		{packages_model.TypeGo, "github.com/BurntSushi/toml", []string{"v1.2.0", "v1.3.0"}},
+		{packages_model.TypeMaven, "org.slf4j:slf4j-api", []string{"2.0.9", "2.0.12"}},
+		{packages_model.TypeNpm, "lodash", []string{"4.17.20", "4.17.21"}},
+		{packages_model.TypePyPI, "Django", []string{"4.2.7"}},
+	}
+	for _, c := range cases {
+		versions, err := GetVersions(t.Context(), owner, c.Type, c.Name)
+		require.NoError(t, err, c.Type)
+		assert.ElementsMatch(t, c.Expected, versions, c.Type)
+	}
+
+	_, err := GetVersions(t.Context(), owner, packages_model.TypeNpm, "unknown")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	_, err = GetVersions(t.Context(), owner, packages_model.TypeComposer, "vendor/package")
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
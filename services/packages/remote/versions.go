@@ -0,0 +1,160 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+	cargo_service "code.gitea.io/gitea/services/packages/cargo"
+
+	"golang.org/x/mod/module"
+)
+
+// GetVersions returns the versions of the package the upstream of the owner's remote of the package type publishes.
+// An ErrNotExist error is returned if the owner has no remote or the upstream does not know the package.
+func GetVersions(ctx context.Context, owner *user_model.User, packageType packages_model.Type, packageName string) ([]string, error) {
+	pr, err := GetRemote(ctx, owner, packageType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch packageType {
+	case packages_model.TypeCargo:
+		return getCargoVersions(ctx, pr, packageName)
+	case packages_model.TypeGo:
+		return getGoVersions(ctx, pr, packageName)
+	case packages_model.TypeMaven:
+		return getMavenVersions(ctx, pr, packageName)
+	case packages_model.TypeNpm:
+		return getNpmVersions(ctx, pr, packageName)
+	case packages_model.TypePyPI:
+		return getPyPIVersions(ctx, pr, packageName)
+	}
+	return nil, util.NewInvalidArgumentErrorf("the versions of %s packages can not be listed", packageType)
+}
+
+func getCargoVersions(ctx context.Context, pr *packages_model.PackageRemote, packageName string) ([]string, error) {
+	f, err := getCargoIndexFile(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		var e cargo_service.IndexVersionEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Yanked {
+			continue
+		}
+		versions = append(versions, e.Version)
+	}
+	return versions, nil
+}
+
+// https://go.dev/ref/mod#goproxy-protocol
+func getGoVersions(ctx context.Context, pr *packages_model.PackageRemote, modulePath string) ([]string, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid module path %s: %v", modulePath, err)
+	}
+	f, err := GetRemoteFile(ctx, pr, &FetchOptions{
+		Path:       escaped + "/@v/list",
+		IsMetadata: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// https://maven.apache.org/repositories/metadata.html
+func getMavenVersions(ctx context.Context, pr *packages_model.PackageRemote, packageName string) ([]string, error) {
+	groupID, artifactID, ok := strings.Cut(packageName, ":")
+	if !ok {
+		return nil, util.NewInvalidArgumentErrorf("invalid maven package name %s", packageName)
+	}
+	f, err := GetRemoteFile(ctx, pr, &FetchOptions{
+		Path:       strings.ReplaceAll(groupID, ".", "/") + "/" + artifactID + "/maven-metadata.xml",
+		IsMetadata: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := ReadFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata struct {
+		Versions []string `xml:"versioning>versions>version"`
+	}
+	if err := xml.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid maven metadata of %s: %w", packageName, err)
+	}
+	return metadata.Versions, nil
+}
+
+func getNpmVersions(ctx context.Context, pr *packages_model.PackageRemote, packageName string) ([]string, error) {
+	doc, err := getNpmPackageDocument(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions map[string]struct {
+		Deprecated any `json:"deprecated"`
+	}
+	if err := json.Unmarshal(doc["versions"], &versions); err != nil {
+		return nil, fmt.Errorf("invalid package document of %s: %w", packageName, err)
+	}
+	result := make([]string, 0, len(versions))
+	for version, metadata := range versions {
+		if message, _ := metadata.Deprecated.(string); message != "" {
+			continue
+		}
+		result = append(result, version)
+	}
+	return result, nil
+}
+
+func getPyPIVersions(ctx context.Context, pr *packages_model.PackageRemote, packageName string) ([]string, error) {
+	pageURL, data, err := getPyPISimpleIndex(ctx, pr, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var versions []string
+	for _, m := range pypiAnchorPattern.FindAllStringSubmatch(string(data), -1) {
+		link := parsePyPILink(pageURL, m[1])
+		// yanked files are only installed if they are requested explicitly
+		if link == nil || strings.Contains(m[1], "data-yanked") {
+			continue
+		}
+		version := pypiVersionFromFilename(link.Filename)
+		if version == "0" || seen[version] {
+			continue
+		}
+		seen[version] = true
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
@@ -96,8 +96,27 @@ func generateKeypair() (string, string, error) {
 	return priv.String(), pub.String(), nil
 }
 
-// BuildAllRepositoryFiles (re)builds all repository files for every available group
+// BuildAllRepositoryFiles (re)builds all repository files for every available group.
+// The repositories of the virtual registries including the owner's packages are rebuilt too.
 func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	ownerIDs, err := packages_service.GetRepositoryIndexOwnerIDs(ctx, ownerID, packages_model.TypeRpm)
+	if err != nil {
+		return err
+	}
+	for _, ownerID := range ownerIDs {
+		if err := buildAllRepositoryFiles(ctx, ownerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
+	rs, err := packages_service.GetRepositorySources(ctx, ownerID, packages_model.TypeRpm)
+	if err != nil {
+		return err
+	}
+
 	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
 	if err != nil {
 		return err
@@ -116,12 +135,12 @@ func BuildAllRepositoryFiles(ctx context.Context, ownerID int64) error {
 	}
 
 	// 2. (Re)Build repository files for existing packages
-	groups, err := rpm_model.GetGroups(ctx, ownerID)
+	groups, err := rpm_model.GetGroups(ctx, rs.OwnerIDs)
 	if err != nil {
 		return err
 	}
 	for _, group := range groups {
-		if err := BuildSpecificRepositoryFiles(ctx, ownerID, group); err != nil {
+		if err := buildSpecificRepositoryFiles(ctx, rs, group); err != nil {
 			return fmt.Errorf("failed to build repository files [%s]: %w", group, err)
 		}
 	}
@@ -158,15 +177,33 @@ type packageData struct {
 
 type packageCache = map[*packages_model.PackageFile]*packageData
 
-// BuildSpecificRepositoryFiles builds metadata files for the repository
+// BuildSpecificRepositoryFiles builds metadata files for the repository.
+// The repositories of the virtual registries including the owner's packages are rebuilt too.
 func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, group string) error {
-	pv, err := GetOrCreateRepositoryVersion(ctx, ownerID)
+	ownerIDs, err := packages_service.GetRepositoryIndexOwnerIDs(ctx, ownerID, packages_model.TypeRpm)
+	if err != nil {
+		return err
+	}
+	for _, ownerID := range ownerIDs {
+		rs, err := packages_service.GetRepositorySources(ctx, ownerID, packages_model.TypeRpm)
+		if err != nil {
+			return err
+		}
+		if err := buildSpecificRepositoryFiles(ctx, rs, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildSpecificRepositoryFiles(ctx context.Context, rs *packages_service.RepositorySources, group string) error {
+	pv, err := GetOrCreateRepositoryVersion(ctx, rs.OwnerID)
 	if err != nil {
 		return err
 	}
 
 	pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
-		OwnerID:      ownerID,
+		OwnerIDs:     rs.OwnerIDs,
 		PackageType:  packages_model.TypeRpm,
 		Query:        "%.rpm",
 		CompositeKey: group,
@@ -174,6 +211,9 @@ func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, group stri
 	if err != nil {
 		return err
 	}
+	if pfs, err = rs.FilterFiles(ctx, pfs); err != nil {
+		return err
+	}
 
 	// Delete the repository files if there are no packages
 	if len(pfs) == 0 {
@@ -244,7 +284,7 @@ func BuildSpecificRepositoryFiles(ctx context.Context, ownerID int64, group stri
 	return buildRepomd(
 		ctx,
 		pv,
-		ownerID,
+		rs.OwnerID,
 		[]*repoData{
 			primary,
 			filelists,
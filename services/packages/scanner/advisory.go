@@ -0,0 +1,106 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	scanner_module "code.gitea.io/gitea/modules/packages/scanner"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+const maxAdvisorySize = 16 * 1024 * 1024
+
+// ImportAdvisory stores an advisory in the OSV format, a withdrawn advisory is removed.
+// The package versions are matched against the changed advisories by the next scan task.
+func ImportAdvisory(ctx context.Context, data []byte) error {
+	a, err := scanner_module.ParseAdvisory(data)
+	if err != nil {
+		return err
+	}
+
+	if a.IsWithdrawn() {
+		return packages_model.DeleteAdvisoryByIdentifier(ctx, a.ID)
+	}
+
+	references := make([]string, 0, len(a.References))
+	for _, r := range a.References {
+		references = append(references, r.URL)
+	}
+
+	pa := &packages_model.PackageAdvisory{
+		Identifier:    a.ID,
+		Aliases:       a.Aliases,
+		Summary:       a.Summary,
+		Details:       a.Details,
+		Severity:      a.SeverityLevel(),
+		References:    references,
+		PublishedUnix: timeutil.TimeStamp(a.Published.Unix()),
+		ModifiedUnix:  timeutil.TimeStamp(a.Modified.Unix()),
+	}
+
+	affected := make([]*packages_model.PackageAdvisoryAffected, 0, len(a.Affected))
+	for _, aff := range a.Affected {
+		if aff.Package.Name == "" || aff.Package.Ecosystem == "" {
+			continue
+		}
+		base, _ := scanner_module.SplitEcosystem(aff.Package.Ecosystem)
+		affected = append(affected, &packages_model.PackageAdvisoryAffected{
+			Ecosystem: base,
+			LowerName: scanner_module.NormalizeName(base, aff.Package.Name),
+			Affected:  aff,
+		})
+	}
+
+	return packages_model.SaveAdvisory(ctx, pa, affected)
+}
+
+// ImportAdvisoryArchive stores all advisories of a zip archive like the ones provided by osv.dev
+// and returns the number of imported advisories
+func ImportAdvisoryArchive(ctx context.Context, r io.ReaderAt, size int64) (int, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, f := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".json") {
+			continue
+		}
+		if f.UncompressedSize64 > maxAdvisorySize {
+			return count, util.NewInvalidArgumentErrorf("advisory %s is too large", f.Name)
+		}
+
+		data, err := readZipFile(f)
+		if err != nil {
+			return count, err
+		}
+		if err := ImportAdvisory(ctx, data); err != nil {
+			return count, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(io.LimitReader(rc, maxAdvisorySize))
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	scanner_module "code.gitea.io/gitea/modules/packages/scanner"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+type vulnerabilityKey struct {
+	ComponentID int64
+	AdvisoryID  int64
+}
+
+// matchVersion matches the components of a package version against the imported advisories.
+// Vulnerabilities of advisories which don't apply anymore are removed, the newly found vulnerabilities are returned.
+func matchVersion(ctx context.Context, versionID int64) ([]*packages_model.PackageVulnerability, error) {
+	components, err := packages_model.GetComponentsByVersionID(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	namesByEcosystem := make(map[string]container.Set[string])
+	for _, c := range components {
+		base, _ := scanner_module.SplitEcosystem(c.Ecosystem)
+		if namesByEcosystem[base] == nil {
+			namesByEcosystem[base] = make(container.Set[string])
+		}
+		namesByEcosystem[base].Add(c.LowerName)
+	}
+
+	affectedByName := make(map[string][]*packages_model.PackageAdvisoryAffected)
+	for ecosystem, names := range namesByEcosystem {
+		affected, err := packages_model.FindAffectedByNames(ctx, ecosystem, names.Values())
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range affected {
+			key := ecosystem + "/" + a.LowerName
+			affectedByName[key] = append(affectedByName[key], a)
+		}
+	}
+
+	found := make(map[vulnerabilityKey]string)
+	for _, c := range components {
+		version := c.Version
+		if c.Kind == scanner_module.KindDependency {
+			// only the requirement is known, check the lowest version it allows
+			version = scanner_module.MinimumVersion(version)
+		}
+		if version == "" {
+			continue
+		}
+
+		base, _ := scanner_module.SplitEcosystem(c.Ecosystem)
+		for _, a := range affectedByName[base+"/"+c.LowerName] {
+			if a.Affected == nil || !scanner_module.MatchesEcosystem(a.Affected.Package.Ecosystem, c.Ecosystem) {
+				continue
+			}
+			key := vulnerabilityKey{ComponentID: c.ID, AdvisoryID: a.AdvisoryID}
+			if _, ok := found[key]; ok {
+				continue
+			}
+			if affected, fixed := a.Affected.IsAffected(version); affected {
+				found[key] = fixed
+			}
+		}
+	}
+
+	var added []*packages_model.PackageVulnerability
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		existing, err := packages_model.GetVulnerabilitiesByVersionID(ctx, versionID)
+		if err != nil {
+			return err
+		}
+
+		var removed []int64
+		for _, v := range existing {
+			key := vulnerabilityKey{ComponentID: v.ComponentID, AdvisoryID: v.AdvisoryID}
+			if _, ok := found[key]; ok {
+				delete(found, key)
+			} else {
+				removed = append(removed, v.ID)
+			}
+		}
+		if err := packages_model.DeleteVulnerabilitiesByIDs(ctx, removed); err != nil {
+			return err
+		}
+
+		for key, fixed := range found {
+			added = append(added, &packages_model.PackageVulnerability{
+				VersionID:    versionID,
+				ComponentID:  key.ComponentID,
+				AdvisoryID:   key.AdvisoryID,
+				FixedVersion: fixed,
+			})
+		}
+		slices.SortFunc(added, func(a, b *packages_model.PackageVulnerability) int {
+			return cmp.Or(cmp.Compare(a.ComponentID, b.ComponentID), cmp.Compare(a.AdvisoryID, b.AdvisoryID))
+		})
+		return packages_model.InsertVulnerabilities(ctx, added)
+	}); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// matchAndNotify matches the package version against the advisories and notifies about new vulnerabilities
+func matchAndNotify(ctx context.Context, pd *packages_model.PackageDescriptor) error {
+	added, err := matchVersion(ctx, pd.Version.ID)
+	if err != nil {
+		return err
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	if err := packages_model.LoadVulnerabilityAttributes(ctx, added); err != nil {
+		return err
+	}
+
+	log.Trace("Detected %d new vulnerabilities in package version %d", len(added), pd.Version.ID)
+	notify_service.PackageVulnerabilitiesDetected(ctx, pd, added)
+	return nil
+}
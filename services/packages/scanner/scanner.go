@@ -0,0 +1,260 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/graceful"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	scanner_module "code.gitea.io/gitea/modules/packages/scanner"
+	"code.gitea.io/gitea/modules/queue"
+	"code.gitea.io/gitea/modules/setting"
+	notify_service "code.gitea.io/gitea/services/notify"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// ScannableTypes are the package types whose components can be extracted
+var ScannableTypes = []packages_model.Type{
+	packages_model.TypeCargo,
+	packages_model.TypeComposer,
+	packages_model.TypeContainer,
+	packages_model.TypeGo,
+	packages_model.TypeMaven,
+	packages_model.TypeNpm,
+	packages_model.TypeNuGet,
+	packages_model.TypePub,
+	packages_model.TypePyPI,
+	packages_model.TypeRubyGems,
+}
+
+var scannerQueue *queue.WorkerPoolQueue[int64]
+
+// Init starts the queue which scans uploaded package versions
+func Init() error {
+	if !setting.Packages.Enabled || !setting.Packages.ScannerEnabled {
+		return nil
+	}
+
+	scannerQueue = queue.CreateUniqueQueue(graceful.GetManager().ShutdownContext(), "package_scanner", handler)
+	if scannerQueue == nil {
+		return errors.New("unable to create package_scanner queue")
+	}
+	go graceful.GetManager().RunWithCancel(scannerQueue)
+
+	notify_service.RegisterNotifier(&scannerNotifier{})
+	return nil
+}
+
+func handler(items ...int64) []int64 {
+	ctx := graceful.GetManager().ShutdownContext()
+
+	for _, versionID := range items {
+		pv, err := packages_model.GetVersionByID(ctx, versionID)
+		if err != nil {
+			if !errors.Is(err, packages_model.ErrPackageNotExist) {
+				log.Error("GetVersionByID [%d]: %v", versionID, err)
+			}
+			continue
+		}
+		if err := ScanPackageVersion(ctx, pv); err != nil {
+			log.Error("ScanPackageVersion [%d]: %v", versionID, err)
+		}
+	}
+	return nil
+}
+
+type scannerNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &scannerNotifier{}
+
+func (*scannerNotifier) PackageCreate(_ context.Context, _ *user_model.User, pd *packages_model.PackageDescriptor) {
+	if !slices.Contains(ScannableTypes, pd.Package.Type) {
+		return
+	}
+	if err := scannerQueue.Push(pd.Version.ID); err != nil {
+		log.Error("Unable to push package version %d to the scanner queue: %v", pd.Version.ID, err)
+	}
+}
+
+// ScanPackageVersion extracts the components of the package version and reports its known vulnerabilities
+func ScanPackageVersion(ctx context.Context, pv *packages_model.PackageVersion) error {
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return err
+	}
+
+	components, err := extractComponents(ctx, pd)
+	if err != nil {
+		return err
+	}
+
+	pcs := make([]*packages_model.PackageComponent, 0, len(components))
+	for _, c := range components {
+		pcs = append(pcs, &packages_model.PackageComponent{
+			Kind:       c.Kind,
+			Ecosystem:  c.Ecosystem,
+			Name:       c.Name,
+			SourceName: c.SourceName,
+			LowerName:  scanner_module.NormalizeName(c.Ecosystem, c.MatchName()),
+			Version:    c.Version,
+			License:    c.License,
+		})
+	}
+	if err := packages_model.ReplaceComponents(ctx, pv.ID, pcs); err != nil {
+		return err
+	}
+
+	return matchAndNotify(ctx, pd)
+}
+
+func extractComponents(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*scanner_module.Component, error) {
+	if pd.Package.Type == packages_model.TypeContainer {
+		return extractImageComponents(ctx, pd)
+	}
+	return scanner_module.ExtractComponents(string(pd.Package.Type), pd.Package.Name, pd.Version.Version, pd.Metadata), nil
+}
+
+// extractImageComponents reads the operating system packages from the layers of an image manifest
+func extractImageComponents(ctx context.Context, pd *packages_model.PackageDescriptor) ([]*scanner_module.Component, error) {
+	var manifestFile *packages_model.PackageFileDescriptor
+	for _, pfd := range pd.Files {
+		if pfd.File.IsLead {
+			manifestFile = pfd
+			break
+		}
+	}
+	if manifestFile == nil || !container_module.IsMediaTypeImageManifest(manifestFile.Properties.GetByName(container_module.PropertyMediaType)) {
+		// image indexes have no layers, the referenced manifests are scanned on their own
+		return nil, nil
+	}
+
+	var manifest struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := readBlobJSON(manifestFile.Blob, &manifest); err != nil {
+		return nil, err
+	}
+
+	img := scanner_module.NewImage()
+	for _, layer := range manifest.Layers {
+		if !scanner_module.IsLayerMediaType(layer.MediaType) {
+			continue
+		}
+		idx := slices.IndexFunc(pd.Files, func(pfd *packages_model.PackageFileDescriptor) bool {
+			return pfd.Properties.GetByName(container_module.PropertyDigest) == layer.Digest
+		})
+		if idx == -1 {
+			return nil, fmt.Errorf("layer %s of the manifest does not exist", layer.Digest)
+		}
+		if err := addImageLayer(ctx, img, pd.Files[idx].Blob, layer.MediaType); err != nil {
+			return nil, fmt.Errorf("unable to read layer %s: %w", layer.Digest, err)
+		}
+	}
+	return img.Components(), nil
+}
+
+func readBlobJSON(pb *packages_model.PackageBlob, v any) error {
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	return json.NewDecoder(s).Decode(v)
+}
+
+func addImageLayer(ctx context.Context, img *scanner_module.Image, pb *packages_model.PackageBlob, mediaType string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	return img.AddLayer(s, mediaType)
+}
+
+// ScanResults are the scan results of a package version
+type ScanResults struct {
+	Scanned         bool
+	ComponentCount  int
+	Licenses        []string
+	Vulnerabilities []*packages_model.PackageVulnerability
+}
+
+// GetScanResults gets the scan results of a package version.
+// The results of a container image index contain the results of the images it references.
+func GetScanResults(ctx context.Context, pd *packages_model.PackageDescriptor) (*ScanResults, error) {
+	results := &ScanResults{}
+	if !setting.Packages.ScannerEnabled || !slices.Contains(ScannableTypes, pd.Package.Type) {
+		return results, nil
+	}
+
+	versionIDs := []int64{pd.Version.ID}
+	if m, ok := pd.Metadata.(*container_module.Metadata); ok {
+		for _, manifest := range m.Manifests {
+			pv, err := packages_model.GetVersionByNameAndVersion(ctx, pd.Owner.ID, pd.Package.Type, pd.Package.Name, manifest.Digest)
+			if err != nil {
+				if errors.Is(err, packages_model.ErrPackageNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			versionIDs = append(versionIDs, pv.ID)
+		}
+	}
+
+	licenses := make(container.Set[string])
+	for _, versionID := range versionIDs {
+		scan, err := packages_model.GetScanByVersionID(ctx, versionID)
+		if err != nil {
+			return nil, err
+		}
+		if scan == nil {
+			continue
+		}
+		results.Scanned = true
+
+		components, err := packages_model.GetComponentsByVersionID(ctx, versionID)
+		if err != nil {
+			return nil, err
+		}
+		results.ComponentCount += len(components)
+		for _, c := range components {
+			if c.License != "" {
+				licenses.Add(c.License)
+			}
+		}
+
+		vulnerabilities, err := packages_model.GetVulnerabilitiesByVersionID(ctx, versionID)
+		if err != nil {
+			return nil, err
+		}
+		results.Vulnerabilities = append(results.Vulnerabilities, vulnerabilities...)
+	}
+	results.Licenses = licenses.Values()
+	slices.Sort(results.Licenses)
+
+	if err := packages_model.LoadVulnerabilityAttributes(ctx, results.Vulnerabilities); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/log"
+)
+
+const scanBatchSize = 100
+
+// ScanTask scans the package versions uploaded before the scanner was enabled
+// and matches all scanned versions against the current advisories
+func ScanTask(ctx context.Context) error {
+	for {
+		ids, err := packages_model.GetUnscannedVersionIDs(ctx, ScannableTypes, scanBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		for _, id := range ids {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("aborted package scan: %w", ctx.Err())
+			default:
+			}
+
+			pv, err := packages_model.GetVersionByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := ScanPackageVersion(ctx, pv); err != nil {
+				// record the failed scan, otherwise the version would be picked again
+				log.Error("ScanPackageVersion [%d]: %v", id, err)
+				if err := packages_model.ReplaceComponents(ctx, id, nil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return packages_model.IterateScannedVersionIDs(ctx, func(ctx context.Context, versionID int64) error {
+		pv, err := packages_model.GetVersionByID(ctx, versionID)
+		if err != nil {
+			log.Error("GetVersionByID [%d]: %v", versionID, err)
+			return nil
+		}
+		pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+		if err != nil {
+			return err
+		}
+		return matchAndNotify(ctx, pd)
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+)
+
+// RepositorySources are the owners whose packages the repository index of an owner is built from.
+// The index of a registry with virtual sources includes the packages of the sources matching their patterns.
+type RepositorySources struct {
+	OwnerID  int64
+	OwnerIDs []int64
+	sources  map[int64]*packages_model.PackageVirtualSource
+}
+
+// GetRepositorySources returns the sources of the repository index of the owner's registry of the package type
+func GetRepositorySources(ctx context.Context, ownerID int64, packageType packages_model.Type) (*RepositorySources, error) {
+	pvss, err := packages_model.GetVirtualSourcesByOwnerAndType(ctx, ownerID, packageType)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &RepositorySources{
+		OwnerID:  ownerID,
+		OwnerIDs: []int64{ownerID},
+		sources:  make(map[int64]*packages_model.PackageVirtualSource, len(pvss)),
+	}
+	for _, pvs := range pvss {
+		rs.OwnerIDs = append(rs.OwnerIDs, pvs.SourceOwnerID)
+		rs.sources[pvs.SourceOwnerID] = pvs
+	}
+	return rs, nil
+}
+
+// Includes tests if the package is part of the repository index
+func (rs *RepositorySources) Includes(p *packages_model.Package) bool {
+	if p.OwnerID == rs.OwnerID {
+		return true
+	}
+	pvs, ok := rs.sources[p.OwnerID]
+	return ok && pvs.Matches(p.Name)
+}
+
+// IncludesFile tests if the package of the file is part of the repository index
+func (rs *RepositorySources) IncludesFile(ctx context.Context, pf *packages_model.PackageFile) (bool, error) {
+	if len(rs.sources) == 0 {
+		return true, nil
+	}
+
+	p, err := GetPackageOfFile(ctx, pf)
+	if err != nil {
+		return false, err
+	}
+	return rs.Includes(p), nil
+}
+
+// FilterFiles returns the files of the packages which are part of the repository index
+func (rs *RepositorySources) FilterFiles(ctx context.Context, pfs []*packages_model.PackageFile) ([]*packages_model.PackageFile, error) {
+	if len(rs.sources) == 0 {
+		return pfs, nil
+	}
+
+	filtered := make([]*packages_model.PackageFile, 0, len(pfs))
+	for _, pf := range pfs {
+		included, err := rs.IncludesFile(ctx, pf)
+		if err != nil {
+			return nil, err
+		}
+		if included {
+			filtered = append(filtered, pf)
+		}
+	}
+	return filtered, nil
+}
+
+// GetRepositoryIndexOwnerIDs returns the owner and the owners whose repository index includes the packages of the owner.
+// The indexes of all of them need to be rebuilt if the packages of the owner change.
+func GetRepositoryIndexOwnerIDs(ctx context.Context, ownerID int64, packageType packages_model.Type) ([]int64, error) {
+	virtualOwnerIDs, err := packages_model.GetVirtualOwnerIDsBySource(ctx, ownerID, packageType)
+	if err != nil {
+		return nil, err
+	}
+	return append([]int64{ownerID}, virtualOwnerIDs...), nil
+}
+
+// GetPackageOfFile returns the package the file belongs to
+func GetPackageOfFile(ctx context.Context, pf *packages_model.PackageFile) (*packages_model.Package, error) {
+	pv, err := packages_model.GetVersionByID(ctx, pf.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	return packages_model.GetPackageByID(ctx, pv.PackageID)
+}
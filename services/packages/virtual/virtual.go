@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package virtual
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	alpine_service "code.gitea.io/gitea/services/packages/alpine"
+	arch_service "code.gitea.io/gitea/services/packages/arch"
+	debian_service "code.gitea.io/gitea/services/packages/debian"
+	rpm_service "code.gitea.io/gitea/services/packages/rpm"
+)
+
+// CanUseAsSource tests if the doer may add the registries of the source owner as virtual source.
+// The readers of the virtual registry can read all packages of the source through it,
+// so only users who administer the source owner may add it.
+func CanUseAsSource(ctx context.Context, doer, owner, source *user_model.User) (bool, error) {
+	if source.ID == owner.ID || source.IsGhost() {
+		return false, nil
+	}
+	if doer.IsAdmin || doer.ID == source.ID {
+		return true, nil
+	}
+	if source.IsOrganization() {
+		return organization.OrgFromUser(source).IsOwnedBy(ctx, doer.ID)
+	}
+	return false, nil
+}
+
+// RebuildRepositoryIndex rebuilds the repository index of the owner's registry of the package type after its sources changed
+func RebuildRepositoryIndex(ctx context.Context, ownerID int64, packageType packages_model.Type) error {
+	switch packageType {
+	case packages_model.TypeAlpine:
+		return alpine_service.BuildAllRepositoryFiles(ctx, ownerID)
+	case packages_model.TypeArch:
+		release, err := arch_service.AquireRegistryLock(ctx, ownerID)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return arch_service.BuildAllRepositoryFiles(ctx, ownerID)
+	case packages_model.TypeDebian:
+		return debian_service.BuildAllRepositoryFiles(ctx, ownerID)
+	case packages_model.TypeRpm:
+		return rpm_service.BuildAllRepositoryFiles(ctx, ownerID)
+	}
+	return nil
+}
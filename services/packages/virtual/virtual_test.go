@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package virtual
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanUseAsSource(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	user4 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+	user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+	org3 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+
+	cases := []struct {
+		Doer     *user_model.User
+		Owner    *user_model.User
+		Source   *user_model.User
+		Expected bool
+	}{
+		{admin, user4, user5, true},
+		{user2, user4, user2, true},
+		{user2, user2, user2, false},
+		{user2, user4, user5, false},
+		{user2, user4, org3, true},
+		{user4, user5, org3, false},
+	}
+	for _, c := range cases {
+		ok, err := CanUseAsSource(t.Context(), c.Doer, c.Owner, c.Source)
+		require.NoError(t, err)
+		assert.Equal(t, c.Expected, ok, "doer %s, owner %s, source %s", c.Doer.Name, c.Owner.Name, c.Source.Name)
+	}
+}
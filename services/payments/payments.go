@@ -0,0 +1,55 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package payments
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+)
+
+// IsEnabled returns whether organizations need a subscription, which is managed by the payments sidecar
+func IsEnabled() bool {
+	return setting.Payments.Enabled
+}
+
+// BaseURL returns the location of the payments sidecar
+func BaseURL() string {
+	return setting.Payments.SidecarURL
+}
+
+// webhookSecret returns the secret the payments sidecar signs its webhooks with, webhooks are rejected if it is empty
+func webhookSecret() string {
+	return setting.Payments.WebhookSecret
+}
+
+// UpdateSubscriptionQuantity sets the number of billed seats of a subscription
+func UpdateSubscriptionQuantity(ctx context.Context, subscriptionID string, quantity int) error {
+	body, err := json.Marshal(map[string]int{"quantity": quantity})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/billing/subscription/%s/quantity", BaseURL(), url.PathEscape(subscriptionID)), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package payments
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockPaymentsConfig(sidecarURL string) func() {
+	resetEnabled := test.MockVariableValue(&setting.Payments.Enabled, true)
+	resetSidecarURL := test.MockVariableValue(&setting.Payments.SidecarURL, sidecarURL)
+	resetWebhookSecret := test.MockVariableValue(&setting.Payments.WebhookSecret, "secret")
+	return func() {
+		resetWebhookSecret()
+		resetSidecarURL()
+		resetEnabled()
+	}
+}
+
+func TestSyncAllOrgSeats(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	quantities := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Quantity int `json:"quantity"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		quantities[r.URL.Path] = body.Quantity
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer mockPaymentsConfig(server.URL)()
+
+	memberIDs, err := organization.GetWriteMembersIDs(t.Context(), 3)
+	require.NoError(t, err)
+	require.NotEmpty(t, memberIDs)
+
+	require.NoError(t, db.Insert(t.Context(), &organization.OrgBilling{OrgID: 3, SubscriptionID: "sub_3", LastSeatCount: 1}))
+	require.NoError(t, db.Insert(t.Context(), &organization.OrgBilling{OrgID: 6, SubscriptionID: "sub_6", SubscriptionStatus: organization.SubscriptionStatusCanceled}))
+
+	require.NoError(t, SyncAllOrgSeats(t.Context()))
+	assert.Equal(t, map[string]int{"/billing/subscription/sub_3/quantity": len(memberIDs)}, quantities)
+	ob, err := organization.GetOrgBilling(t.Context(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, len(memberIDs), ob.LastSeatCount)
+	assert.NotZero(t, ob.LastSync)
+
+	// unchanged seat counts are not pushed again
+	clear(quantities)
+	require.NoError(t, SyncAllOrgSeats(t.Context()))
+	assert.Empty(t, quantities)
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	now := time.Unix(1767268800, 0)
+	body := []byte(`{"type":"customer.subscription.updated"}`)
+	header := SignWebhook("secret", body, now)
+
+	assert.NoError(t, VerifyWebhookSignature("secret", header, body, now))
+	assert.NoError(t, VerifyWebhookSignature("secret", header, body, now.Add(time.Minute)))
+	assert.NoError(t, VerifyWebhookSignature("secret", "v1=0000,"+header, body, now))
+
+	assert.ErrorIs(t, VerifyWebhookSignature("other", header, body, now), util.ErrInvalidArgument)
+	assert.ErrorIs(t, VerifyWebhookSignature("secret", header, []byte(`{}`), now), util.ErrInvalidArgument)
+	assert.ErrorIs(t, VerifyWebhookSignature("secret", header, body, now.Add(time.Hour)), util.ErrInvalidArgument)
+	assert.ErrorIs(t, VerifyWebhookSignature("secret", "", body, now), util.ErrInvalidArgument)
+}
+
+func TestHandleWebhook(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer mockPaymentsConfig("http://payments.invalid")()
+	require.NoError(t, db.Insert(t.Context(), &organization.OrgBilling{OrgID: 3, SubscriptionID: "sub_3", SubscriptionStatus: "active"}))
+
+	send := func(event WebhookEvent) error {
+		body, err := json.Marshal(event)
+		require.NoError(t, err)
+		return HandleWebhook(t.Context(), SignWebhook("secret", body, time.Now()), body)
+	}
+
+	require.NoError(t, send(WebhookEvent{Type: "customer.subscription.updated", SubscriptionID: "sub_3", Status: organization.SubscriptionStatusPastDue}))
+	readOnly, err := organization.IsOrgReadOnly(t.Context(), 3)
+	require.NoError(t, err)
+	assert.True(t, readOnly)
+
+	require.NoError(t, send(WebhookEvent{Type: "customer.subscription.updated", SubscriptionID: "sub_3", Status: "active"}))
+	readOnly, err = organization.IsOrgReadOnly(t.Context(), 3)
+	require.NoError(t, err)
+	assert.False(t, readOnly)
+
+	require.NoError(t, send(WebhookEvent{Type: "customer.subscription.deleted", SubscriptionID: "sub_3", Status: organization.SubscriptionStatusCanceled}))
+	ob, err := organization.GetOrgBilling(t.Context(), 3)
+	require.NoError(t, err)
+	assert.True(t, ob.IsReadOnly)
+	assert.Equal(t, organization.SubscriptionStatusCanceled, ob.SubscriptionStatus)
+
+	assert.ErrorIs(t, send(WebhookEvent{SubscriptionID: "sub_unknown", Status: "active"}), util.ErrNotExist)
+	assert.ErrorIs(t, HandleWebhook(t.Context(), "t=1,v1=00", []byte(`{}`)), util.ErrInvalidArgument)
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package payments
+
+import (
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// SyncOrgSeats recomputes the billable seats of an org, which are the members with write access,
+// and pushes them to the payments sidecar. Unchanged seat counts are only pushed if force is set.
+func SyncOrgSeats(ctx context.Context, ob *organization.OrgBilling, force bool) (int, error) {
+	memberIDs, err := organization.GetWriteMembersIDs(ctx, ob.OrgID)
+	if err != nil {
+		return 0, fmt.Errorf("GetWriteMembersIDs: %w", err)
+	}
+	seatCount := len(memberIDs)
+	if !force && seatCount == ob.LastSeatCount && ob.LastSync > 0 {
+		return seatCount, nil
+	}
+
+	if err := UpdateSubscriptionQuantity(ctx, ob.SubscriptionID, seatCount); err != nil {
+		return 0, fmt.Errorf("UpdateSubscriptionQuantity: %w", err)
+	}
+	if err := organization.UpdateOrgBillingSeats(ctx, ob.OrgID, seatCount); err != nil {
+		return 0, fmt.Errorf("UpdateOrgBillingSeats: %w", err)
+	}
+	log.Info("Synced seats of org_id=%d sub_id=%s from %d to %d", ob.OrgID, ob.SubscriptionID, ob.LastSeatCount, seatCount)
+	ob.LastSeatCount = seatCount
+	ob.LastSync = timeutil.TimeStampNow()
+	return seatCount, nil
+}
+
+// SyncAllOrgSeats pushes the changed seat counts of all subscribed orgs to the payments sidecar
+func SyncAllOrgSeats(ctx context.Context) error {
+	obs, err := organization.FindSubscribedOrgBillings(ctx)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, ob := range obs {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if _, err := SyncOrgSeats(ctx, ob, false); err != nil {
+			log.Warn("SyncOrgSeats org_id=%d sub_id=%s: %v", ob.OrgID, ob.SubscriptionID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to sync the seats of %d of %d organizations", failed, len(obs))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// WebhookSignatureHeader is the header of the webhook signature, its value is "t=<unix time>,v1=<hex HMAC-SHA256>"
+// and the HMAC is computed over "<unix time>.<body>" with the webhook secret
+const WebhookSignatureHeader = "X-Payments-Signature"
+
+// webhookTolerance is how old a webhook may be, older ones are rejected as replayed
+const webhookTolerance = 5 * time.Minute
+
+// WebhookEvent is sent by the payments sidecar when the status of a subscription changes
+type WebhookEvent struct {
+	Type           string `json:"type"`
+	SubscriptionID string `json:"subscription_id"`
+	CustomerID     string `json:"customer_id"`
+	Status         string `json:"status"`
+}
+
+// IsWebhookEnabled returns whether the payments sidecar can send webhooks
+func IsWebhookEnabled() bool {
+	return IsEnabled() && webhookSecret() != ""
+}
+
+// SignWebhook returns the signature header value of a webhook body
+func SignWebhook(secret string, body []byte, now time.Time) string {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + computeWebhookSignature(secret, timestamp, body)
+}
+
+func computeWebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(timestamp))
+	_, _ = mac.Write([]byte{'.'})
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks the signature header of a webhook body
+func VerifyWebhookSignature(secret, header string, body []byte, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return util.NewInvalidArgumentErrorf("malformed webhook signature")
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > webhookTolerance || age < -webhookTolerance {
+		return util.NewInvalidArgumentErrorf("webhook timestamp is outside of the tolerance")
+	}
+
+	expected := []byte(computeWebhookSignature(secret, timestamp, body))
+	for _, signature := range signatures {
+		if hmac.Equal(expected, []byte(signature)) {
+			return nil
+		}
+	}
+	return util.NewInvalidArgumentErrorf("webhook signature does not match")
+}
+
+// HandleWebhook verifies a webhook of the payments sidecar and applies the new subscription status to its org,
+// orgs are read-only while their subscription is canceled, past due or unpaid
+func HandleWebhook(ctx context.Context, header string, body []byte) error {
+	if !IsWebhookEnabled() {
+		return util.NewNotExistErrorf("payments webhooks are not enabled")
+	}
+	if err := VerifyWebhookSignature(webhookSecret(), header, body, time.Now()); err != nil {
+		return err
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return util.NewInvalidArgumentErrorf("invalid webhook payload: %v", err)
+	}
+	if event.SubscriptionID == "" || event.Status == "" {
+		return util.NewInvalidArgumentErrorf("webhook payload has no subscription or status")
+	}
+
+	ob, err := organization.GetOrgBillingBySubscriptionID(ctx, event.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	if ob == nil {
+		return util.NewNotExistErrorf("no organization has the subscription %s", event.SubscriptionID)
+	}
+	if ob.SubscriptionStatus == event.Status {
+		return nil
+	}
+
+	if err := organization.UpdateOrgBillingStatus(ctx, ob.OrgID, event.Status); err != nil {
+		return err
+	}
+	readOnly := organization.IsSubscriptionStatusReadOnly(event.Status)
+	log.Info("Subscription %s of org_id=%d changed from %q to %q (%s), read-only: %v", event.SubscriptionID, ob.OrgID, ob.SubscriptionStatus, event.Status, event.Type, readOnly)
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
+	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	"code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -31,6 +32,7 @@ import (
 	"code.gitea.io/gitea/modules/timeutil"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
 	"code.gitea.io/gitea/services/automergequeue"
+	issue_service "code.gitea.io/gitea/services/issue"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
 
@@ -237,6 +239,103 @@ func isSignedIfRequired(ctx context.Context, pr *issues_model.PullRequest, doer
 	return sign, err
 }
 
+// MissingCodeOwnerApproval is a CODEOWNERS rule matched by the changed files of a pull request
+// which has not been approved by any of its owners yet
+type MissingCodeOwnerApproval struct {
+	Pattern string
+	Paths   []string
+	Users   []*user_model.User
+	Teams   []*organization.Team
+}
+
+// GetMissingCodeOwnerApprovals returns the owner groups of the files changed by the pull request
+// which still need to approve it before it can be merged into the protected branch.
+// Rules without any owner able to approve are ignored.
+func GetMissingCodeOwnerApprovals(ctx context.Context, pb *git_model.ProtectedBranch, pr *issues_model.PullRequest) ([]*MissingCodeOwnerApproval, error) {
+	if pb == nil || !pb.BlockOnCodeOwnerReviews {
+		return nil, nil
+	}
+
+	matches, err := issue_service.GetPullRequestCodeOwners(ctx, pr)
+	if err != nil {
+		return nil, err
+	} else if len(matches) == 0 {
+		return nil, nil
+	}
+
+	latestReviews, _, err := issues_model.GetReviewsByIssueID(ctx, pr.IssueID)
+	if err != nil {
+		return nil, err
+	}
+	approverIDs := make([]int64, 0, len(latestReviews))
+	for _, review := range latestReviews {
+		if review.ReviewerTeamID != 0 || review.Type != issues_model.ReviewTypeApprove || review.Dismissed {
+			continue
+		}
+		if pb.IgnoreStaleApprovals && review.Stale {
+			continue
+		}
+		approverIDs = append(approverIDs, review.ReviewerID)
+	}
+
+	missing := make([]*MissingCodeOwnerApproval, 0, len(matches))
+	for _, match := range matches {
+		if !hasCodeOwnerApprover(match.Rule) {
+			// nobody could ever approve the rule, requiring it would block the pull request forever
+			continue
+		}
+		approved, err := isApprovedByCodeOwner(ctx, match.Rule, approverIDs)
+		if err != nil {
+			return nil, err
+		}
+		if !approved {
+			missing = append(missing, &MissingCodeOwnerApproval{
+				Pattern: match.Rule.Pattern,
+				Paths:   match.Files,
+				Users:   match.Rule.Users,
+				Teams:   match.Rule.Teams,
+			})
+		}
+	}
+	return missing, nil
+}
+
+// hasCodeOwnerApprover returns whether at least one owner of the rule is able to approve a pull request,
+// organizations, inactive or suspended users and teams without members can't
+func hasCodeOwnerApprover(rule *issues_model.CodeOwnerRule) bool {
+	for _, u := range rule.Users {
+		if u.IsIndividual() && u.IsActive && !u.ProhibitLogin {
+			return true
+		}
+	}
+	for _, t := range rule.Teams {
+		if t.NumMembers > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// isApprovedByCodeOwner returns whether one of the approvers is an owner of the rule, directly or through one of its teams
+func isApprovedByCodeOwner(ctx context.Context, rule *issues_model.CodeOwnerRule, approverIDs []int64) (bool, error) {
+	for _, approverID := range approverIDs {
+		for _, u := range rule.Users {
+			if u.ID == approverID {
+				return true, nil
+			}
+		}
+		for _, t := range rule.Teams {
+			isMember, err := organization.IsTeamMember(ctx, t.OrgID, t.ID, approverID)
+			if err != nil {
+				return false, err
+			} else if isMember {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // markPullRequestAsMergeable checks if pull request is possible to leaving checking status,
 // and set to be either conflict or mergeable.
 func markPullRequestAsMergeable(ctx context.Context, pr *issues_model.PullRequest) {
@@ -270,8 +270,13 @@ func Merge(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.U
 		return err
 	}
 
+	return afterMergePushed(ctx, pr, doer, wasAutoMerged)
+}
+
+// afterMergePushed notifies about a pull request whose merge has been pushed to the base branch and resolves its cross references
+func afterMergePushed(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, wasAutoMerged bool) error {
 	// reload pull request because it has been updated by post receive hook
-	pr, err = issues_model.GetPullRequestByID(ctx, pr.ID)
+	pr, err := issues_model.GetPullRequestByID(ctx, pr.ID)
 	if err != nil {
 		return err
 	}
@@ -598,6 +603,13 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 	if issues_model.MergeBlockedByOfficialReviewRequests(ctx, pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "There are official review requests")
 	}
+	missingCodeOwnerApprovals, err := GetMissingCodeOwnerApprovals(ctx, pb, pr)
+	if err != nil {
+		return fmt.Errorf("GetMissingCodeOwnerApprovals: %w", err)
+	}
+	if len(missingCodeOwnerApprovals) > 0 {
+		return util.ErrorWrap(ErrNotReadyToMerge, "Code owners have not approved all changed files")
+	}
 
 	if issues_model.MergeBlockedByOutdatedBranch(pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "The head branch is behind the base branch")
@@ -0,0 +1,57 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/log"
+	repo_module "code.gitea.io/gitea/modules/repository"
+)
+
+// MergeQueuedCommit fast-forwards the base branch of the pull request to commitID, a commit of the merge queue
+// branch which contains the pull request, and handles the pull request as merged.
+// The push runs through the pre-receive hook, so the branch protection is enforced like for any other merge.
+func MergeQueuedCommit(ctx context.Context, pr *issues_model.PullRequest, doer *user_model.User, commitID string) error {
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return fmt.Errorf("unable to load base repo: %w", err)
+	}
+
+	releaser, err := globallock.Lock(ctx, getPullWorkingLockKey(pr.ID))
+	if err != nil {
+		log.Error("lock.Lock(): %v", err)
+		return fmt.Errorf("lock.Lock: %w", err)
+	}
+	defer releaser()
+	defer func() {
+		// TODO: DUPLICATE-PR-TASK: search and see another TODO comment for more details
+		go AddTestPullRequestTask(TestPullRequestOptions{
+			RepoID: pr.BaseRepo.ID,
+			Doer:   doer,
+			Branch: pr.BaseBranch,
+		})
+	}()
+
+	env := repo_module.FullPushingEnvironment(doer, doer, pr.BaseRepo, pr.BaseRepo.Name, pr.ID, pr.Index)
+	env = append(env, repo_module.EnvPushTrigger+"="+string(repo_module.PushTriggerPRMergeToBase))
+
+	// The queue branch lives in the base repository, so the commit can be pushed from the repository into itself.
+	// Without force, the push fails if the base branch has moved since the batch was built.
+	if err := gitrepo.Push(ctx, pr.BaseRepo, git.PushOptions{
+		Remote: pr.BaseRepo.RepoPath(),
+		Branch: commitID + ":" + git.BranchPrefix + pr.BaseBranch,
+		Env:    env,
+	}); err != nil {
+		return err
+	}
+	releaser()
+
+	return afterMergePushed(ctx, pr, doer, true)
+}
@@ -8,15 +8,36 @@ import (
 
 	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	audit_service "code.gitea.io/gitea/services/audit"
+	"code.gitea.io/gitea/services/convert"
 )
 
-func CreateOrUpdateProtectedBranch(ctx context.Context, repo *repo_model.Repository,
+func CreateOrUpdateProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
 	protectBranch *git_model.ProtectedBranch, whitelistOptions git_model.WhitelistOptions,
 ) error {
+	// the rule is read again because the callers have already changed it
+	var before *api.BranchProtection
+	if protectBranch.ID != 0 {
+		rule, err := git_model.GetProtectedBranchRuleByID(ctx, repo.ID, protectBranch.ID)
+		if err != nil {
+			return err
+		}
+		if rule != nil {
+			before = convert.ToBranchProtection(ctx, rule, repo)
+		}
+	}
+
 	err := git_model.UpdateProtectBranch(ctx, repo, protectBranch, whitelistOptions)
 	if err != nil {
 		return err
 	}
+	if before == nil {
+		audit_service.BranchProtectionCreate(ctx, doer, repo, protectBranch)
+	} else {
+		audit_service.BranchProtectionUpdate(ctx, doer, repo, before, protectBranch)
+	}
 
 	isPlainRule := !git_model.IsRuleNameSpecial(protectBranch.RuleName)
 	var isBranchExist bool
@@ -45,3 +66,12 @@ func CreateOrUpdateProtectedBranch(ctx context.Context, repo *repo_model.Reposit
 
 	return nil
 }
+
+// DeleteProtectedBranch deletes a branch protection rule of a repository
+func DeleteProtectedBranch(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) error {
+	if err := git_model.DeleteProtectedBranch(ctx, repo, rule.ID); err != nil {
+		return err
+	}
+	audit_service.BranchProtectionDelete(ctx, doer, repo, rule)
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pull
+
+import (
+	"testing"
+
+	audit_model "code.gitea.io/gitea/models/audit"
+	git_model "code.gitea.io/gitea/models/git"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtectedBranchAuditEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	rule := &git_model.ProtectedBranch{RepoID: repo.ID, RuleName: "release/*"}
+	require.NoError(t, CreateOrUpdateProtectedBranch(t.Context(), doer, repo, rule, git_model.WhitelistOptions{}))
+	event := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionBranchProtectionCreate, TargetID: rule.ID})
+	assert.Equal(t, doer.ID, event.ActorID)
+	assert.Empty(t, event.Before)
+
+	rule.RequiredApprovals = 2
+	require.NoError(t, CreateOrUpdateProtectedBranch(t.Context(), doer, repo, rule, git_model.WhitelistOptions{}))
+	event = unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionBranchProtectionUpdate, TargetID: rule.ID})
+	assert.Contains(t, event.Before, `"required_approvals":0`)
+	assert.Contains(t, event.After, `"required_approvals":2`)
+
+	require.NoError(t, DeleteProtectedBranch(t.Context(), doer, repo, rule))
+	unittest.AssertNotExistsBean(t, &git_model.ProtectedBranch{ID: rule.ID})
+	unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionBranchProtectionDelete, TargetID: rule.ID})
+}
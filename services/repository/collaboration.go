@@ -14,11 +14,13 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	user_model "code.gitea.io/gitea/models/user"
+	audit_service "code.gitea.io/gitea/services/audit"
+	notify_service "code.gitea.io/gitea/services/notify"
 
 	"xorm.io/builder"
 )
 
-func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u *user_model.User, mode perm.AccessMode) error {
+func AddOrUpdateCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, u *user_model.User, mode perm.AccessMode) error {
 	// only allow valid access modes, read, write and admin
 	if mode < perm.AccessModeRead || mode > perm.AccessModeAdmin {
 		return perm.ErrInvalidAccessMode
@@ -32,7 +34,8 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 		return user_model.ErrBlockedUser
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	oldMode := perm.AccessModeNone
+	err := db.WithTx(ctx, func(ctx context.Context) error {
 		collaboration, has, err := db.Get[repo_model.Collaboration](ctx, builder.Eq{
 			"repo_id": repo.ID,
 			"user_id": u.ID,
@@ -40,6 +43,7 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 		if err != nil {
 			return err
 		} else if has {
+			oldMode = collaboration.Mode
 			if collaboration.Mode == mode {
 				return nil
 			}
@@ -62,21 +66,59 @@ func AddOrUpdateCollaborator(ctx context.Context, repo *repo_model.Repository, u
 
 		return access_model.RecalculateUserAccess(ctx, repo, u.ID)
 	})
+	if err != nil {
+		return err
+	}
+
+	if oldMode == perm.AccessModeNone {
+		audit_service.CollaboratorAdd(ctx, doer, repo, u, mode)
+		notify_service.AddCollaborator(ctx, doer, repo, u, mode)
+	} else if oldMode != mode {
+		audit_service.CollaboratorAccessMode(ctx, doer, repo, u, oldMode, mode)
+		notify_service.ChangeCollaboratorAccessMode(ctx, doer, repo, u, oldMode, mode)
+	}
+	return nil
+}
+
+// ChangeCollaborationAccessMode changes the access mode of an existing collaborator of a repository
+func ChangeCollaborationAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) error {
+	// Discard invalid input
+	if mode <= perm.AccessModeNone || mode > perm.AccessModeOwner {
+		return nil
+	}
+
+	collaboration, has, err := db.Get[repo_model.Collaboration](ctx, builder.Eq{
+		"repo_id": repo.ID,
+		"user_id": collaborator.ID,
+	})
+	if err != nil || !has || collaboration.Mode == mode {
+		return err
+	}
+
+	if err := repo_model.ChangeCollaborationAccessMode(ctx, repo, collaborator.ID, mode); err != nil {
+		return err
+	}
+	audit_service.CollaboratorAccessMode(ctx, doer, repo, collaborator, collaboration.Mode, mode)
+	notify_service.ChangeCollaboratorAccessMode(ctx, doer, repo, collaborator, collaboration.Mode, mode)
+	return nil
 }
 
 // DeleteCollaboration removes collaboration relation between the user and repository.
-func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, collaborator *user_model.User) (err error) {
+func DeleteCollaboration(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) (err error) {
 	collaboration := &repo_model.Collaboration{
 		RepoID: repo.ID,
 		UserID: collaborator.ID,
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	removed := false
+	err = db.WithTx(ctx, func(ctx context.Context) error {
 		if has, err := db.GetEngine(ctx).Delete(collaboration); err != nil {
 			return err
 		} else if has == 0 {
 			return nil
 		}
+		removed = true
+		audit_service.CollaboratorRemove(ctx, doer, repo, collaborator)
 
 		if err := repo.LoadOwner(ctx); err != nil {
 			return err
@@ -97,6 +139,12 @@ func DeleteCollaboration(ctx context.Context, repo *repo_model.Repository, colla
 		// Unassign a user from any issue (s)he has been assigned to in the repository
 		return ReconsiderRepoIssuesAssignee(ctx, repo, collaborator)
 	})
+	if err != nil || !removed {
+		return err
+	}
+
+	notify_service.RemoveCollaborator(ctx, doer, repo, collaborator)
+	return nil
 }
 
 func ReconsiderRepoIssuesAssignee(ctx context.Context, repo *repo_model.Repository, user *user_model.User) error {
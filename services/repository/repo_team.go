@@ -13,20 +13,28 @@ import (
 	"code.gitea.io/gitea/models/organization"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/setting"
+	audit_service "code.gitea.io/gitea/services/audit"
+	notify_service "code.gitea.io/gitea/services/notify"
 )
 
 // TeamAddRepository adds new repository to team of organization.
-func TeamAddRepository(ctx context.Context, t *organization.Team, repo *repo_model.Repository) (err error) {
+func TeamAddRepository(ctx context.Context, doer *user_model.User, t *organization.Team, repo *repo_model.Repository) (err error) {
 	if repo.OwnerID != t.OrgID {
 		return errors.New("repository does not belong to organization")
 	} else if organization.HasTeamRepo(ctx, t.OrgID, t.ID, repo.ID) {
 		return nil
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		return addRepositoryToTeam(ctx, t, repo)
-	})
+	}); err != nil {
+		return err
+	}
+	audit_service.TeamRepositoryAdd(ctx, doer, t, repo)
+	notify_service.AddTeamRepository(ctx, doer, t, repo)
+	return nil
 }
 
 func addRepositoryToTeam(ctx context.Context, t *organization.Team, repo *repo_model.Repository) (err error) {
@@ -145,7 +153,7 @@ func removeAllRepositoriesFromTeam(ctx context.Context, t *organization.Team) (e
 
 // RemoveRepositoryFromTeam removes repository from team of organization.
 // If the team shall include all repositories the request is ignored.
-func RemoveRepositoryFromTeam(ctx context.Context, t *organization.Team, repoID int64) error {
+func RemoveRepositoryFromTeam(ctx context.Context, doer *user_model.User, t *organization.Team, repoID int64) error {
 	if !HasRepository(ctx, t, repoID) {
 		return nil
 	}
@@ -159,9 +167,14 @@ func RemoveRepositoryFromTeam(ctx context.Context, t *organization.Team, repoID
 		return err
 	}
 
-	return db.WithTx(ctx, func(ctx context.Context) error {
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
 		return removeRepositoryFromTeam(ctx, t, repo, true)
-	})
+	}); err != nil {
+		return err
+	}
+	audit_service.TeamRepositoryRemove(ctx, doer, t, repo)
+	notify_service.RemoveTeamRepository(ctx, doer, t, repo)
+	return nil
 }
 
 // removeRepositoryFromTeam removes a repository from a team and recalculates access
@@ -29,6 +29,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
+	audit_service "code.gitea.io/gitea/services/audit"
 	notify_service "code.gitea.io/gitea/services/notify"
 	pull_service "code.gitea.io/gitea/services/pull"
 )
@@ -70,7 +71,11 @@ func DeleteRepository(ctx context.Context, doer *user_model.User, repo *repo_mod
 		notify_service.DeleteRepository(ctx, doer, repo)
 	}
 
-	return DeleteRepositoryDirectly(ctx, repo.ID)
+	if err := DeleteRepositoryDirectly(ctx, repo.ID); err != nil {
+		return err
+	}
+	audit_service.RepositoryDelete(ctx, doer, repo)
+	return nil
 }
 
 // PushCreateRepo creates a repository when a new repository is pushed to an appropriate namespace
@@ -116,16 +121,30 @@ func Init(ctx context.Context) error {
 }
 
 // UpdateRepository updates a repository
-func UpdateRepository(ctx context.Context, repo *repo_model.Repository, visibilityChanged bool) (err error) {
-	return db.WithTx(ctx, func(ctx context.Context) error {
+func UpdateRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, visibilityChanged bool) (err error) {
+	if err = db.WithTx(ctx, func(ctx context.Context) error {
 		if err = updateRepository(ctx, repo, visibilityChanged); err != nil {
 			return fmt.Errorf("updateRepository: %w", err)
 		}
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	if visibilityChanged {
+		audit_service.RepositoryVisibility(ctx, doer, repo, !repo.IsPrivate)
+	}
+	return nil
 }
 
-func MakeRepoPublic(ctx context.Context, repo *repo_model.Repository) (err error) {
+func MakeRepoPublic(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) error {
+	if err := makeRepoPublic(ctx, repo); err != nil {
+		return err
+	}
+	audit_service.RepositoryVisibility(ctx, doer, repo, true)
+	return nil
+}
+
+func makeRepoPublic(ctx context.Context, repo *repo_model.Repository) (err error) {
 	return db.WithTx(ctx, func(ctx context.Context) error {
 		repo.IsPrivate = false
 		if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "is_private"); err != nil {
@@ -154,7 +173,7 @@ func MakeRepoPublic(ctx context.Context, repo *repo_model.Repository) (err error
 
 		if repo.Owner.Visibility != structs.VisibleTypePrivate {
 			for i := range forkRepos {
-				if err = MakeRepoPublic(ctx, forkRepos[i]); err != nil {
+				if err = makeRepoPublic(ctx, forkRepos[i]); err != nil {
 					return fmt.Errorf("MakeRepoPublic[%d]: %w", forkRepos[i].ID, err)
 				}
 			}
@@ -168,7 +187,15 @@ func MakeRepoPublic(ctx context.Context, repo *repo_model.Repository) (err error
 	})
 }
 
-func MakeRepoPrivate(ctx context.Context, repo *repo_model.Repository) (err error) {
+func MakeRepoPrivate(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) error {
+	if err := makeRepoPrivate(ctx, repo); err != nil {
+		return err
+	}
+	audit_service.RepositoryVisibility(ctx, doer, repo, false)
+	return nil
+}
+
+func makeRepoPrivate(ctx context.Context, repo *repo_model.Repository) (err error) {
 	return db.WithTx(ctx, func(ctx context.Context) error {
 		repo.IsPrivate = true
 		if err := repo_model.UpdateRepositoryColsNoAutoTime(ctx, repo, "is_private"); err != nil {
@@ -207,7 +234,7 @@ func MakeRepoPrivate(ctx context.Context, repo *repo_model.Repository) (err erro
 			return fmt.Errorf("getRepositoriesByForkID: %w", err)
 		}
 		for i := range forkRepos {
-			if err = MakeRepoPrivate(ctx, forkRepos[i]); err != nil {
+			if err = makeRepoPrivate(ctx, forkRepos[i]); err != nil {
 				return fmt.Errorf("MakeRepoPrivate[%d]: %w", forkRepos[i].ID, err)
 			}
 		}
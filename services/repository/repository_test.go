@@ -7,12 +7,15 @@ import (
 	"testing"
 
 	activities_model "code.gitea.io/gitea/models/activities"
+	audit_model "code.gitea.io/gitea/models/audit"
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
 	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLinkedRepository(t *testing.T) {
@@ -62,6 +65,19 @@ func TestUpdateRepositoryVisibilityChanged(t *testing.T) {
 	assert.True(t, act.IsPrivate)
 }
 
+func TestMakeRepoPrivate(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	require.NoError(t, MakeRepoPrivate(t.Context(), doer, repo))
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}).IsPrivate)
+	event := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionRepositoryVisibility, RepoID: repo.ID})
+	assert.Equal(t, doer.ID, event.ActorID)
+	assert.JSONEq(t, `{"private":false}`, event.Before)
+	assert.JSONEq(t, `{"private":true}`, event.After)
+}
+
 func TestRepository_HasWiki(t *testing.T) {
 	unittest.PrepareTestEnv(t)
 	repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
@@ -0,0 +1,47 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/dependency"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// SBOMContentTypes are the media types of the SBOM formats
+var SBOMContentTypes = map[string]string{
+	dependency.SBOMFormatCycloneDX: "application/vnd.cyclonedx+json",
+	dependency.SBOMFormatSPDX:      "application/spdx+json",
+}
+
+// GenerateSBOM creates a software bill of materials of the dependencies declared in the commit
+func GenerateSBOM(ctx context.Context, repo *repo_model.Repository, commit *git.Commit, format string) (any, error) {
+	if _, ok := SBOMContentTypes[format]; !ok {
+		return nil, util.NewInvalidArgumentErrorf("unsupported SBOM format %q", format)
+	}
+
+	deps, err := dependency.GetCommitDependencies(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	commitID := commit.ID.String()
+	subject := &dependency.SBOMSubject{
+		Name:        repo.FullName(),
+		Version:     commitID,
+		URL:         repo.HTMLURL(ctx) + "/commit/" + commitID,
+		Created:     time.Now(),
+		ToolName:    "Gitea",
+		ToolVersion: setting.AppVer,
+	}
+	if format == dependency.SBOMFormatSPDX {
+		return dependency.NewSPDX(subject, deps), nil
+	}
+	return dependency.NewCycloneDX(subject, deps), nil
+}
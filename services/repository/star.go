@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repository
+
+import (
+	"context"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	notify_service "code.gitea.io/gitea/services/notify"
+)
+
+// StarRepository stars or unstars a repository and notifies when the state changed
+func StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) error {
+	isStaring := repo_model.IsStaring(ctx, doer.ID, repo.ID)
+	if err := repo_model.StarRepo(ctx, doer, repo, star); err != nil {
+		return err
+	}
+	if isStaring != star {
+		notify_service.StarRepository(ctx, doer, repo, star)
+	}
+	return nil
+}
+
+// WatchRepository watches or unwatches a repository and notifies when the state changed
+func WatchRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, watch bool) error {
+	isWatching := repo_model.IsWatching(ctx, doer.ID, repo.ID)
+	if err := repo_model.WatchRepo(ctx, doer, repo, watch); err != nil {
+		return err
+	}
+	if isWatching != watch {
+		notify_service.WatchRepository(ctx, doer, repo, watch)
+	}
+	return nil
+}
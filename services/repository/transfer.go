@@ -22,6 +22,7 @@ import (
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
+	audit_service "code.gitea.io/gitea/services/audit"
 	notify_service "code.gitea.io/gitea/services/notify"
 )
 
@@ -330,6 +331,8 @@ func transferOwnership(ctx context.Context, doer *user_model.User, newOwnerName
 		}
 	}
 
+	audit_service.RepositoryTransfer(ctx, doer, newRepo, oldOwner)
+
 	return committer.Commit()
 }
 
@@ -451,7 +454,7 @@ func StartRepositoryTransfer(ctx context.Context, doer, newOwner *user_model.Use
 			return err
 		}
 		if !hasAccess {
-			if err := AddOrUpdateCollaborator(ctx, repo, newOwner, perm.AccessModeRead); err != nil {
+			if err := AddOrUpdateCollaborator(ctx, doer, repo, newOwner, perm.AccessModeRead); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,358 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/scim"
+	"code.gitea.io/gitea/modules/util"
+	org_service "code.gitea.io/gitea/services/org"
+
+	"xorm.io/builder"
+)
+
+// Groups are the teams of the organizations, a group is named "<organization>/<team>".
+// A source can only provision the teams mapped on its SCIM token, other teams don't exist for it.
+// Only the memberships of the users provisioned through a source are managed by it, other team members are kept.
+
+// teamMapping holds the lowercase names of the teams a source can provision by lowercase organization name
+type teamMapping map[string]container.Set[string]
+
+func loadTeamMapping(ctx context.Context, source *auth_model.Source) (teamMapping, error) {
+	token, err := auth_model.GetSCIMToken(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := token.TeamMapping()
+	if err != nil {
+		return nil, err
+	}
+	mapping := make(teamMapping, len(raw))
+	for orgName, teamNames := range raw {
+		teams := mapping[strings.ToLower(orgName)]
+		if teams == nil {
+			teams = container.Set[string]{}
+			mapping[strings.ToLower(orgName)] = teams
+		}
+		for _, teamName := range teamNames {
+			teams.Add(strings.ToLower(teamName))
+		}
+	}
+	return mapping, nil
+}
+
+func (m teamMapping) contains(orgName, teamName string) bool {
+	return m[strings.ToLower(orgName)].Contains(strings.ToLower(teamName))
+}
+
+func (m teamMapping) containsTeam(ctx context.Context, team *organization.Team) (bool, error) {
+	name, err := groupName(ctx, team)
+	if err != nil {
+		return false, err
+	}
+	orgName, teamName, _ := strings.Cut(name, "/")
+	return m.contains(orgName, teamName), nil
+}
+
+func groupName(ctx context.Context, team *organization.Team) (string, error) {
+	org, err := organization.GetOrgByID(ctx, team.OrgID)
+	if err != nil {
+		return "", err
+	}
+	return org.Name + "/" + team.Name, nil
+}
+
+// getTeamByGroupName returns the mapped team of a group name, teams must be created before they are provisioned
+func getTeamByGroupName(ctx context.Context, mapping teamMapping, name string) (*organization.Team, error) {
+	orgName, teamName, ok := strings.Cut(name, "/")
+	if !ok {
+		return nil, util.NewInvalidArgumentErrorf("group name %q is not of the form <organization>/<team>", name)
+	}
+	if !mapping.contains(orgName, teamName) {
+		return nil, util.NewNotExistErrorf("group %s is not mapped to this source", name)
+	}
+	org, err := organization.GetOrgByName(ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+	return org.GetTeam(ctx, teamName)
+}
+
+// sourceMembers returns the members of a team which are provisioned through the source
+func sourceMembers(ctx context.Context, source *auth_model.Source, team *organization.Team) ([]*user_model.User, error) {
+	members := make([]*user_model.User, 0, 10)
+	return members, db.GetEngine(ctx).Where(userConds(source)).
+		In("id", builder.Select("uid").From("team_user").Where(builder.Eq{"team_id": team.ID})).
+		OrderBy("id").Find(&members)
+}
+
+// ToGroup converts a team to its SCIM resource, the members are only the users provisioned through the source
+func ToGroup(ctx context.Context, source *auth_model.Source, team *organization.Team, withMembers bool) (*scim.Group, error) {
+	name, err := groupName(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+	g := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          strconv.FormatInt(team.ID, 10),
+		DisplayName: name,
+		Meta: &scim.Meta{
+			ResourceType: "Group",
+			Location:     ResourceURL("Groups", team.ID),
+		},
+	}
+	if !withMembers {
+		return g, nil
+	}
+
+	members, err := sourceMembers(ctx, source, team)
+	if err != nil {
+		return nil, err
+	}
+	g.Members = make([]scim.MultiValue, 0, len(members))
+	for _, member := range members {
+		g.Members = append(g.Members, scim.MultiValue{
+			Value:   strconv.FormatInt(member.ID, 10),
+			Display: member.Name,
+			Ref:     ResourceURL("Users", member.ID),
+		})
+	}
+	return g, nil
+}
+
+// FindGroups returns the mapped teams matching the filter, startIndex is 1-based
+func FindGroups(ctx context.Context, source *auth_model.Source, filter *scim.Filter, startIndex, count int) ([]*organization.Team, int64, error) {
+	if filter != nil {
+		var team *organization.Team
+		var err error
+		switch filter.Attribute {
+		case "displayname":
+			var mapping teamMapping
+			if mapping, err = loadTeamMapping(ctx, source); err == nil {
+				team, err = getTeamByGroupName(ctx, mapping, filter.Value)
+			}
+		case "id":
+			id, _ := strconv.ParseInt(filter.Value, 10, 64)
+			team, err = GetGroup(ctx, source, id)
+		default:
+			return nil, 0, util.NewInvalidArgumentErrorf("groups can not be filtered by %s", filter.Attribute)
+		}
+		if err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				return nil, 0, nil
+			}
+			return nil, 0, err
+		}
+		if startIndex > 1 {
+			return nil, 1, nil
+		}
+		return []*organization.Team{team}, 1, nil
+	}
+
+	teams, err := mappedTeams(ctx, source)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := int64(len(teams))
+	teams = teams[min(startIndex-1, len(teams)):]
+	return teams[:min(count, len(teams))], total, nil
+}
+
+// mappedTeams returns the existing teams mapped to the source ordered by id
+func mappedTeams(ctx context.Context, source *auth_model.Source) ([]*organization.Team, error) {
+	mapping, err := loadTeamMapping(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	teams := make([]*organization.Team, 0, 10)
+	for orgName, teamNames := range mapping {
+		org, err := organization.GetOrgByName(ctx, orgName)
+		if err != nil {
+			if organization.IsErrOrgNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for teamName := range teamNames {
+			team, err := org.GetTeam(ctx, teamName)
+			if err != nil {
+				if organization.IsErrTeamNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			teams = append(teams, team)
+		}
+	}
+	slices.SortFunc(teams, func(a, b *organization.Team) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return teams, nil
+}
+
+// GetGroup returns a team mapped to the source by its id
+func GetGroup(ctx context.Context, source *auth_model.Source, id int64) (*organization.Team, error) {
+	team, err := organization.GetTeamByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := loadTeamMapping(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := mapping.containsTeam(ctx, team); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, organization.ErrTeamNotExist{TeamID: id}
+	}
+	return team, nil
+}
+
+// CreateGroup provisions the members of an existing team
+func CreateGroup(ctx context.Context, source *auth_model.Source, g *scim.Group) (*organization.Team, error) {
+	mapping, err := loadTeamMapping(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	team, err := getTeamByGroupName(ctx, mapping, g.DisplayName)
+	if err != nil {
+		if organization.IsErrOrgNotExist(err) || organization.IsErrTeamNotExist(err) {
+			return nil, util.NewInvalidArgumentErrorf("team %s must be created before it can be provisioned", g.DisplayName)
+		}
+		return nil, err
+	}
+	return team, ReplaceGroup(ctx, source, team, g)
+}
+
+// ReplaceGroup replaces the name and the members of a team
+func ReplaceGroup(ctx context.Context, source *auth_model.Source, team *organization.Team, g *scim.Group) error {
+	changes := &scim.GroupChanges{DisplayName: g.DisplayName, ReplaceMembers: true}
+	for _, member := range g.Members {
+		changes.AddMembers = append(changes.AddMembers, member.Value)
+	}
+	return applyGroupChanges(ctx, source, team, changes)
+}
+
+// PatchGroup applies a patch request to a team
+func PatchGroup(ctx context.Context, source *auth_model.Source, team *organization.Team, req *scim.PatchRequest) error {
+	changes, err := req.GroupChanges()
+	if err != nil {
+		return err
+	}
+	return applyGroupChanges(ctx, source, team, changes)
+}
+
+// DeleteGroup removes the users provisioned through the source from a team, the team itself is kept
+func DeleteGroup(ctx context.Context, source *auth_model.Source, team *organization.Team) error {
+	return applyGroupChanges(ctx, source, team, &scim.GroupChanges{ReplaceMembers: true})
+}
+
+func applyGroupChanges(ctx context.Context, source *auth_model.Source, team *organization.Team, changes *scim.GroupChanges) error {
+	// the owners of an organization are never managed by an identity provider
+	if team.IsOwnerTeam() && (changes.ReplaceMembers || len(changes.AddMembers) > 0 || len(changes.RemoveMembers) > 0) {
+		return util.NewPermissionDeniedErrorf("the members of the owners team can not be provisioned")
+	}
+
+	if changes.DisplayName != "" {
+		if err := renameTeam(ctx, source, team, changes.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	add := container.SetOf(changes.AddMembers...)
+	remove := container.SetOf(changes.RemoveMembers...)
+	if changes.ReplaceMembers {
+		for _, id := range changes.RemoveMembers {
+			add.Remove(id)
+		}
+		members, err := sourceMembers(ctx, source, team)
+		if err != nil {
+			return err
+		}
+		remove = container.Set[string]{}
+		for _, member := range members {
+			if id := strconv.FormatInt(member.ID, 10); !add.Contains(id) {
+				remove.Add(id)
+			}
+		}
+	}
+
+	for id := range add {
+		u, err := getMember(ctx, source, id)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return util.NewInvalidArgumentErrorf("member %s is not a user provisioned through this source", id)
+			}
+			return err
+		}
+		if err := org_service.AddTeamMember(ctx, nil, team, u); err != nil {
+			return err
+		}
+	}
+	for id := range remove {
+		u, err := getMember(ctx, source, id)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return err
+		}
+		isMember, err := organization.IsTeamMember(ctx, team.OrgID, team.ID, u.ID)
+		if err != nil {
+			return err
+		}
+		if isMember {
+			if err := org_service.RemoveTeamMember(ctx, nil, team, u); err != nil {
+				return err
+			}
+		}
+	}
+	log.Trace("SCIM[%s]: Updated members of team %d: %d added, %d removed", source.Name, team.ID, len(add), len(remove))
+	return nil
+}
+
+func getMember(ctx context.Context, source *auth_model.Source, id string) (*user_model.User, error) {
+	uid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid member %q", id)
+	}
+	return GetUser(ctx, source, uid)
+}
+
+// renameTeam renames a team, teams can not be moved to other organizations and must stay mapped to the source
+func renameTeam(ctx context.Context, source *auth_model.Source, team *organization.Team, name string) error {
+	current, err := groupName(ctx, team)
+	if err != nil || current == name {
+		return err
+	}
+	orgName, teamName, ok := strings.Cut(name, "/")
+	currentOrgName, _, _ := strings.Cut(current, "/")
+	if !ok || !strings.EqualFold(orgName, currentOrgName) {
+		return util.NewInvalidArgumentErrorf("team %s can not be moved to %s", current, name)
+	}
+	if team.IsOwnerTeam() {
+		return util.NewInvalidArgumentErrorf("the owners team can not be renamed")
+	}
+	mapping, err := loadTeamMapping(ctx, source)
+	if err != nil {
+		return err
+	}
+	if !mapping.contains(orgName, teamName) {
+		return util.NewInvalidArgumentErrorf("team %s is not mapped to this source", name)
+	}
+	team.Name = teamName
+	return org_service.UpdateTeam(ctx, nil, team, false, false)
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"strconv"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/organization"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/scim"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/auth/source/pam"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMappedGroups(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	source := &auth_model.Source{Type: auth_model.PAM, Name: "scim-source", IsActive: true, Cfg: &pam.Source{ServiceName: "gitea"}}
+	require.NoError(t, auth_model.CreateSource(t.Context(), source))
+	_, err := auth_model.GenerateSCIMToken(t.Context(), source.ID)
+	require.NoError(t, err)
+	require.NoError(t, auth_model.UpdateSCIMTeamMap(t.Context(), source.ID, `{"org3": ["Owners", "team1"]}`))
+
+	u, err := CreateUser(t.Context(), source, &scim.User{
+		UserName: "scim-user",
+		Emails:   []scim.MultiValue{{Value: "scim-user@example.com", Primary: true}},
+	})
+	require.NoError(t, err)
+	members := []scim.MultiValue{{Value: strconv.FormatInt(u.ID, 10)}}
+
+	// only the mapped teams are listed
+	teams, total, err := FindGroups(t.Context(), source, nil, 1, 10)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, total)
+	require.Len(t, teams, 2)
+	assert.EqualValues(t, 1, teams[0].ID)
+	assert.EqualValues(t, 2, teams[1].ID)
+
+	// other teams don't exist for the source
+	_, err = GetGroup(t.Context(), source, 11)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	teams, _, err = FindGroups(t.Context(), source, &scim.Filter{Attribute: "displayname", Value: "org26/team11"}, 1, 10)
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+	_, err = CreateGroup(t.Context(), source, &scim.Group{DisplayName: "org26/team11", Members: members})
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	// the members of a mapped team are provisioned
+	team, err := GetGroup(t.Context(), source, 2)
+	require.NoError(t, err)
+	require.NoError(t, ReplaceGroup(t.Context(), source, team, &scim.Group{DisplayName: "org3/team1", Members: members}))
+	isMember, err := organization.IsTeamMember(t.Context(), team.OrgID, team.ID, u.ID)
+	require.NoError(t, err)
+	assert.True(t, isMember)
+
+	// a mapped team can't be renamed to a team which is not mapped
+	err = ReplaceGroup(t.Context(), source, team, &scim.Group{DisplayName: "org3/team-renamed", Members: members})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	// the members of the owners team are never provisioned
+	owners, err := GetGroup(t.Context(), source, 1)
+	require.NoError(t, err)
+	err = ReplaceGroup(t.Context(), source, owners, &scim.Group{DisplayName: "org3/Owners", Members: members})
+	assert.ErrorIs(t, err, util.ErrPermissionDenied)
+	isMember, err = organization.IsTeamMember(t.Context(), owners.OrgID, owners.ID, u.ID)
+	require.NoError(t, err)
+	assert.False(t, isMember)
+}
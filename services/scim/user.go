@@ -0,0 +1,205 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package scim
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/scim"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+	user_service "code.gitea.io/gitea/services/user"
+
+	"xorm.io/builder"
+)
+
+// ResourceURL returns the location of a resource of the SCIM endpoint
+func ResourceURL(resourceType string, id int64) string {
+	return setting.AppURL + "scim/v2/" + resourceType + "/" + strconv.FormatInt(id, 10)
+}
+
+// ToUser converts a user to its SCIM resource
+func ToUser(u *user_model.User) *scim.User {
+	created, updated := u.CreatedUnix.AsTime(), u.UpdatedUnix.AsTime()
+	su := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          strconv.FormatInt(u.ID, 10),
+		ExternalID:  u.LoginName,
+		UserName:    u.Name,
+		DisplayName: u.FullName,
+		Active:      util.ToPointer(u.IsActive),
+		Meta: &scim.Meta{
+			ResourceType: "User",
+			Created:      &created,
+			LastModified: &updated,
+			Location:     ResourceURL("Users", u.ID),
+		},
+	}
+	if u.FullName != "" {
+		su.Name = &scim.Name{Formatted: u.FullName}
+	}
+	if u.Email != "" {
+		su.Emails = []scim.MultiValue{{Value: u.Email, Type: "work", Primary: true}}
+	}
+	return su
+}
+
+func userConds(source *auth_model.Source) builder.Cond {
+	return builder.Eq{"login_source": source.ID, "type": user_model.UserTypeIndividual}
+}
+
+// FindUsers returns the users provisioned through a source matching the filter, startIndex is 1-based
+func FindUsers(ctx context.Context, source *auth_model.Source, filter *scim.Filter, startIndex, count int) ([]*user_model.User, int64, error) {
+	cond := userConds(source)
+	if filter != nil {
+		switch filter.Attribute {
+		case "username":
+			cond = cond.And(builder.Eq{"lower_name": strings.ToLower(filter.Value)})
+		case "externalid":
+			cond = cond.And(builder.Eq{"login_name": filter.Value})
+		case "emails", "emails.value":
+			cond = cond.And(builder.Eq{"email": strings.ToLower(filter.Value)})
+		case "id":
+			id, _ := strconv.ParseInt(filter.Value, 10, 64)
+			cond = cond.And(builder.Eq{"id": id})
+		default:
+			return nil, 0, util.NewInvalidArgumentErrorf("users can not be filtered by %s", filter.Attribute)
+		}
+	}
+
+	users := make([]*user_model.User, 0, count)
+	total, err := db.GetEngine(ctx).Where(cond).OrderBy("id").Limit(count, startIndex-1).FindAndCount(&users)
+	return users, total, err
+}
+
+// GetUser returns a user provisioned through a source
+func GetUser(ctx context.Context, source *auth_model.Source, id int64) (*user_model.User, error) {
+	u := &user_model.User{}
+	has, err := db.GetEngine(ctx).Where(userConds(source)).And("id = ?", id).Get(u)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, user_model.ErrUserNotExist{UID: id}
+	}
+	return u, nil
+}
+
+// CreateUser creates a user which signs in through the source.
+// The external id of the resource is the login name of the user, which is how the source identifies the user.
+func CreateUser(ctx context.Context, source *auth_model.Source, su *scim.User) (*user_model.User, error) {
+	if su.UserName == "" {
+		return nil, util.NewInvalidArgumentErrorf("userName is required")
+	}
+	email := su.PrimaryEmail()
+	if email == "" {
+		return nil, util.NewInvalidArgumentErrorf("an email address is required")
+	}
+
+	loginName := su.ExternalID
+	if loginName == "" {
+		loginName = su.UserName
+	}
+	u := &user_model.User{
+		Name:        su.UserName,
+		FullName:    su.FullName(),
+		Email:       email,
+		LoginType:   source.Type,
+		LoginSource: source.ID,
+		LoginName:   loginName,
+	}
+	overwriteDefault := &user_model.CreateUserOverwriteOptions{
+		IsActive: optional.Some(su.Active == nil || *su.Active),
+	}
+	if err := user_service.CreateUser(ctx, nil, u, &user_model.Meta{}, overwriteDefault); err != nil {
+		return nil, err
+	}
+	log.Trace("SCIM[%s]: Created user %s", source.Name, u.Name)
+	return u, nil
+}
+
+// UpdateUser replaces the attributes of a user with the ones of the resource
+func UpdateUser(ctx context.Context, source *auth_model.Source, u *user_model.User, su *scim.User) error {
+	if su.UserName == "" {
+		return util.NewInvalidArgumentErrorf("userName is required")
+	}
+
+	if su.UserName != u.Name {
+		if err := user_service.RenameUser(ctx, u, su.UserName, nil); err != nil {
+			return err
+		}
+	}
+
+	if su.ExternalID != "" && su.ExternalID != u.LoginName {
+		if err := user_service.UpdateAuth(ctx, nil, u, &user_service.UpdateAuthOptions{LoginName: optional.Some(su.ExternalID)}); err != nil {
+			return err
+		}
+	}
+
+	wasActive := u.IsActive
+	opts := &user_service.UpdateOptions{
+		FullName: optional.Some(su.FullName()),
+	}
+	if su.Active != nil {
+		opts.IsActive = optional.Some(*su.Active)
+	}
+	if err := user_service.UpdateUser(ctx, u, opts); err != nil {
+		return err
+	}
+	if wasActive != u.IsActive {
+		notify_service.ChangeUserSuspension(ctx, nil, u, !u.IsActive)
+	}
+
+	if email := su.PrimaryEmail(); email != "" && !strings.EqualFold(email, u.Email) {
+		if err := user_service.ReplacePrimaryEmailAddress(ctx, u, email); err != nil {
+			return err
+		}
+	}
+	log.Trace("SCIM[%s]: Updated user %s", source.Name, u.Name)
+	return nil
+}
+
+// PatchUser applies a patch request to a user
+func PatchUser(ctx context.Context, source *auth_model.Source, u *user_model.User, req *scim.PatchRequest) error {
+	su := ToUser(u)
+	// the full name is only changed if the patch sets one of the name attributes
+	su.DisplayName, su.Name = "", nil
+	if err := req.ApplyToUser(su); err != nil {
+		return err
+	}
+	if su.FullName() == "" {
+		su.DisplayName = u.FullName
+	}
+	return UpdateUser(ctx, source, u, su)
+}
+
+// DeleteUser deletes a user provisioned through a source.
+// Users who still own repositories, packages or belong to organizations are deactivated instead, so that their data is kept.
+func DeleteUser(ctx context.Context, source *auth_model.Source, u *user_model.User) error {
+	err := user_service.DeleteUser(ctx, u, false)
+	if repo_model.IsErrUserOwnRepos(err) || organization.IsErrUserHasOrgs(err) || packages_model.IsErrUserOwnPackages(err) {
+		log.Trace("SCIM[%s]: Deactivating user %s instead of deleting: %v", source.Name, u.Name, err)
+		if err := user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{IsActive: optional.Some(false)}); err != nil {
+			return err
+		}
+		notify_service.ChangeUserSuspension(ctx, nil, u, true)
+		return nil
+	}
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return err
+	}
+	log.Trace("SCIM[%s]: Deleted user %s", source.Name, u.Name)
+	return nil
+}
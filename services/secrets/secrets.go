@@ -8,9 +8,11 @@ import (
 
 	"code.gitea.io/gitea/models/db"
 	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	audit_service "code.gitea.io/gitea/services/audit"
 )
 
-func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data, description string) (*secret_model.Secret, bool, error) {
+func CreateOrUpdateSecret(ctx context.Context, doer *user_model.User, ownerID, repoID int64, name, data, description string) (*secret_model.Secret, bool, error) {
 	if err := ValidateName(name); err != nil {
 		return nil, false, err
 	}
@@ -29,17 +31,21 @@ func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data
 		if err != nil {
 			return nil, false, err
 		}
+		audit_service.SecretCreate(ctx, doer, s)
 		return s, true, nil
 	}
 
 	if err := secret_model.UpdateSecret(ctx, s[0].ID, data, description); err != nil {
 		return nil, false, err
 	}
+	before := *s[0]
+	s[0].Description = description
+	audit_service.SecretUpdate(ctx, doer, &before, s[0])
 
 	return s[0], false, nil
 }
 
-func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) error {
+func DeleteSecretByID(ctx context.Context, doer *user_model.User, ownerID, repoID, secretID int64) error {
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
 		OwnerID:  ownerID,
 		RepoID:   repoID,
@@ -52,10 +58,10 @@ func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) erro
 		return secret_model.ErrSecretNotFound{}
 	}
 
-	return deleteSecret(ctx, s[0])
+	return deleteSecret(ctx, doer, s[0])
 }
 
-func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string) error {
+func DeleteSecretByName(ctx context.Context, doer *user_model.User, ownerID, repoID int64, name string) error {
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
 		OwnerID: ownerID,
 		RepoID:  repoID,
@@ -68,12 +74,64 @@ func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string)
 		return secret_model.ErrSecretNotFound{}
 	}
 
-	return deleteSecret(ctx, s[0])
+	return deleteSecret(ctx, doer, s[0])
 }
 
-func deleteSecret(ctx context.Context, s *secret_model.Secret) error {
+func deleteSecret(ctx context.Context, doer *user_model.User, s *secret_model.Secret) error {
 	if _, err := db.DeleteByID[secret_model.Secret](ctx, s.ID); err != nil {
 		return err
 	}
+	audit_service.SecretDelete(ctx, doer, s)
 	return nil
 }
+
+// CreateOrUpdateEnvironmentSecret creates or updates a secret of a deployment environment of a repository
+func CreateOrUpdateEnvironmentSecret(ctx context.Context, doer *user_model.User, repoID, environmentID int64, name, data, description string) (*secret_model.Secret, bool, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, false, err
+	}
+
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		Name:          name,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(s) == 0 {
+		s, err := secret_model.InsertEncryptedEnvironmentSecret(ctx, repoID, environmentID, name, data, description)
+		if err != nil {
+			return nil, false, err
+		}
+		audit_service.SecretCreate(ctx, doer, s)
+		return s, true, nil
+	}
+
+	if err := secret_model.UpdateSecret(ctx, s[0].ID, data, description); err != nil {
+		return nil, false, err
+	}
+	before := *s[0]
+	s[0].Description = description
+	audit_service.SecretUpdate(ctx, doer, &before, s[0])
+
+	return s[0], false, nil
+}
+
+// DeleteEnvironmentSecretByID deletes a secret of a deployment environment of a repository
+func DeleteEnvironmentSecretByID(ctx context.Context, doer *user_model.User, repoID, environmentID, secretID int64) error {
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        repoID,
+		EnvironmentID: environmentID,
+		SecretID:      secretID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(s) != 1 {
+		return secret_model.ErrSecretNotFound{}
+	}
+
+	return deleteSecret(ctx, doer, s[0])
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	terraform_model "code.gitea.io/gitea/models/terraform"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// DiffLineType is the type of a line in the diff of two state versions
+type DiffLineType int
+
+const (
+	DiffLineSame DiffLineType = iota
+	DiffLineAdd
+	DiffLineDelete
+)
+
+// DiffLine is a line in the diff of two state versions
+type DiffLine struct {
+	Type    DiffLineType
+	Content string
+}
+
+// ReadStateVersion reads the content of the version
+func ReadStateVersion(ctx context.Context, sv *terraform_model.StateVersion) (string, error) {
+	if sv == nil {
+		return "", nil
+	}
+	f, err := OpenStateVersion(ctx, sv)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// DiffStateVersions compares two versions line by line. The old version may be nil.
+func DiffStateVersions(ctx context.Context, oldVersion, newVersion *terraform_model.StateVersion) ([]*DiffLine, error) {
+	oldContent, err := ReadStateVersion(ctx, oldVersion)
+	if err != nil {
+		return nil, err
+	}
+	newContent, err := ReadStateVersion(ctx, newVersion)
+	if err != nil {
+		return nil, err
+	}
+	return DiffContent(oldContent, newContent), nil
+}
+
+// DiffContent compares two contents line by line
+func DiffContent(oldContent, newContent string) []*DiffLine {
+	dmp := diffmatchpatch.New()
+	oldChars, newChars, lines := dmp.DiffLinesToChars(oldContent, newContent)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(oldChars, newChars, false), lines)
+
+	result := make([]*DiffLine, 0, len(diffs))
+	for _, d := range diffs {
+		t := DiffLineSame
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			t = DiffLineAdd
+		case diffmatchpatch.DiffDelete:
+			t = DiffLineDelete
+		}
+		for line := range strings.SplitSeq(strings.TrimSuffix(d.Text, "\n"), "\n") {
+			result = append(result, &DiffLine{Type: t, Content: line})
+		}
+	}
+	return result
+}
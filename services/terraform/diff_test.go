@@ -0,0 +1,33 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffContent(t *testing.T) {
+	lines := DiffContent("{\n  \"serial\": 1,\n  \"lineage\": \"a\"\n}\n", "{\n  \"serial\": 2,\n  \"lineage\": \"a\"\n}\n")
+	assert.Equal(t, []*DiffLine{
+		{Type: DiffLineSame, Content: "{"},
+		{Type: DiffLineDelete, Content: "  \"serial\": 1,"},
+		{Type: DiffLineAdd, Content: "  \"serial\": 2,"},
+		{Type: DiffLineSame, Content: "  \"lineage\": \"a\""},
+		{Type: DiffLineSame, Content: "}"},
+	}, lines)
+
+	lines = DiffContent("", "{}\n")
+	assert.Equal(t, []*DiffLine{{Type: DiffLineAdd, Content: "{}"}}, lines)
+}
+
+func TestIsValidName(t *testing.T) {
+	assert.True(t, IsValidName("default"))
+	assert.True(t, IsValidName("prod.eu-west_1"))
+	assert.False(t, IsValidName(""))
+	assert.False(t, IsValidName(".hidden"))
+	assert.False(t, IsValidName("a..b"))
+	assert.False(t, IsValidName("a/b"))
+}
@@ -0,0 +1,291 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package terraform
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrInvalidName     = util.NewInvalidArgumentErrorf("terraform state name is invalid")
+	ErrInvalidState    = util.NewInvalidArgumentErrorf("terraform state is invalid")
+	ErrInvalidLockInfo = util.NewInvalidArgumentErrorf("terraform lock info is invalid")
+	ErrStateTooLarge   = errors.New("terraform state is too large")
+	ErrStateLocked     = errors.New("terraform state is locked")
+)
+
+const maxLockInfoSize = 64 * 1024
+
+var namePattern = regexp.MustCompile(`\A[0-9A-Za-z][0-9A-Za-z._-]{0,99}\z`)
+
+// IsValidName tests if the name of a state is valid
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name) && !strings.Contains(name, "..")
+}
+
+func getStateLockKey(repoID int64, name string) string {
+	return fmt.Sprintf("terraform_state_%d_%s", repoID, strings.ToLower(name))
+}
+
+// stateHeader contains the fields of a state file Gitea is interested in
+type stateHeader struct {
+	Version int64  `json:"version"`
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// UploadState stores a new version of the state.
+// If the state is locked, the lock id must match the current lock.
+func UploadState(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, name, lockID string, r io.Reader) (*terraform_model.StateVersion, error) {
+	if !IsValidName(name) {
+		return nil, ErrInvalidName
+	}
+
+	data, err := util.ReadWithLimit(r, int(setting.Terraform.MaxStateSize)+1)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > setting.Terraform.MaxStateSize {
+		return nil, ErrStateTooLarge
+	}
+
+	var header stateHeader
+	if err := json.Unmarshal(data, &header); err != nil || header.Version == 0 {
+		return nil, ErrInvalidState
+	}
+
+	releaser, err := globallock.Lock(ctx, getStateLockKey(repo.ID, name))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	hash := sha256.Sum256(data)
+
+	var sv *terraform_model.StateVersion
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		s, err := terraform_model.GetOrInsertState(ctx, repo.ID, name)
+		if err != nil {
+			return err
+		}
+		if s.IsLocked() && s.LockID != lockID {
+			return ErrStateLocked
+		}
+
+		sv = &terraform_model.StateVersion{
+			RepoID:     repo.ID,
+			StateID:    s.ID,
+			Serial:     header.Serial,
+			Lineage:    header.Lineage,
+			Size:       int64(len(data)),
+			HashSHA256: hex.EncodeToString(hash[:]),
+			CreatorID:  doer.ID,
+		}
+		if err := terraform_model.InsertStateVersion(ctx, sv); err != nil {
+			return err
+		}
+
+		s.Serial = header.Serial
+		s.Lineage = header.Lineage
+		if err := terraform_model.UpdateStateCols(ctx, s, "serial", "lineage"); err != nil {
+			return err
+		}
+
+		_, err = storage.TerraformStates.Save(sv.RelativePath(), bytes.NewReader(data), sv.Size)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if setting.Terraform.MaxStateVersions > 0 {
+		if err := removeExpiredStateVersions(ctx, sv.StateID, setting.Terraform.MaxStateVersions); err != nil {
+			log.Error("Error removing expired versions of Terraform state %d: %v", sv.StateID, err)
+		}
+	}
+
+	return sv, nil
+}
+
+func removeExpiredStateVersions(ctx context.Context, stateID int64, keep int) error {
+	versions, err := terraform_model.GetExpiredStateVersions(ctx, stateID, keep)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(versions))
+	for _, v := range versions {
+		ids = append(ids, v.ID)
+	}
+	if err := terraform_model.DeleteStateVersionsByIDs(ctx, ids); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		system_model.RemoveStorageWithNotice(ctx, storage.TerraformStates, "Delete expired Terraform state version", v.RelativePath())
+	}
+	return nil
+}
+
+// OpenStateVersion opens the content of the version
+func OpenStateVersion(ctx context.Context, sv *terraform_model.StateVersion) (storage.Object, error) {
+	return storage.TerraformStates.Open(sv.RelativePath())
+}
+
+// GetLatestStateVersion gets the state and its current version
+func GetLatestStateVersion(ctx context.Context, repo *repo_model.Repository, name string) (*terraform_model.State, *terraform_model.StateVersion, error) {
+	s, err := terraform_model.GetStateByName(ctx, repo.ID, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	sv, err := terraform_model.GetLatestStateVersion(ctx, s.ID)
+	if err != nil {
+		return s, nil, err
+	}
+	return s, sv, nil
+}
+
+// LockState locks the state for a Terraform operation.
+// If the state is already locked by another operation, the state is returned together with ErrStateLocked.
+func LockState(ctx context.Context, repo *repo_model.Repository, doer *user_model.User, name string, r io.Reader) (*terraform_model.State, error) {
+	if !IsValidName(name) {
+		return nil, ErrInvalidName
+	}
+
+	data, err := util.ReadWithLimit(r, maxLockInfoSize)
+	if err != nil {
+		return nil, err
+	}
+	info := &terraform_model.LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil || info.ID == "" {
+		return nil, ErrInvalidLockInfo
+	}
+
+	releaser, err := globallock.Lock(ctx, getStateLockKey(repo.ID, name))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	s, err := terraform_model.GetOrInsertState(ctx, repo.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsLocked() {
+		if s.LockID == info.ID {
+			return s, nil
+		}
+		return s, ErrStateLocked
+	}
+
+	s.LockID = info.ID
+	s.LockInfo = string(data)
+	s.LockerID = doer.ID
+	s.LockedUnix = timeutil.TimeStampNow()
+	if err := terraform_model.UpdateStateCols(ctx, s, "lock_id", "lock_info", "locker_id", "locked_unix"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UnlockState removes the lock of the state.
+// An empty lock id removes any lock, this is what "terraform force-unlock" does.
+// If the state is locked by another operation, the state is returned together with ErrStateLocked.
+func UnlockState(ctx context.Context, repo *repo_model.Repository, name, lockID string) (*terraform_model.State, error) {
+	releaser, err := globallock.Lock(ctx, getStateLockKey(repo.ID, name))
+	if err != nil {
+		return nil, err
+	}
+	defer releaser()
+
+	s, err := terraform_model.GetStateByName(ctx, repo.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if !s.IsLocked() {
+		return s, nil
+	}
+	if lockID != "" && s.LockID != lockID {
+		return s, ErrStateLocked
+	}
+
+	s.LockID = ""
+	s.LockInfo = ""
+	s.LockerID = 0
+	s.LockedUnix = 0
+	if err := terraform_model.UpdateStateCols(ctx, s, "lock_id", "lock_info", "locker_id", "locked_unix"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ReadLockID reads the lock id from the lock info sent with an unlock request
+func ReadLockID(r io.Reader) (string, error) {
+	data, err := util.ReadWithLimit(r, maxLockInfoSize)
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return "", nil
+	}
+	info := &terraform_model.LockInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return "", ErrInvalidLockInfo
+	}
+	return info.ID, nil
+}
+
+// DeleteState deletes the state and all its versions. A locked state can't be deleted.
+func DeleteState(ctx context.Context, repo *repo_model.Repository, name string) error {
+	releaser, err := globallock.Lock(ctx, getStateLockKey(repo.ID, name))
+	if err != nil {
+		return err
+	}
+	defer releaser()
+
+	s, err := terraform_model.GetStateByName(ctx, repo.ID, name)
+	if err != nil {
+		return err
+	}
+	if s.IsLocked() {
+		return ErrStateLocked
+	}
+
+	versions, err := terraform_model.GetStateVersionsByStateID(ctx, s.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := terraform_model.DeleteStateByID(ctx, s.ID); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		system_model.RemoveStorageWithNotice(ctx, storage.TerraformStates, "Delete Terraform state version", v.RelativePath())
+	}
+	return nil
+}
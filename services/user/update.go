@@ -13,6 +13,7 @@ import (
 	"code.gitea.io/gitea/modules/optional"
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/structs"
+	notify_service "code.gitea.io/gitea/services/notify"
 )
 
 type UpdateOptionField[T any] struct {
@@ -193,7 +194,9 @@ type UpdateAuthOptions struct {
 	ProhibitLogin      optional.Option[bool]
 }
 
-func UpdateAuth(ctx context.Context, u *user_model.User, opts *UpdateAuthOptions) error {
+func UpdateAuth(ctx context.Context, doer, u *user_model.User, opts *UpdateAuthOptions) error {
+	wasProhibitLogin := u.ProhibitLogin
+
 	if opts.LoginSource.Has() {
 		source, err := auth_model.GetSourceByID(ctx, opts.LoginSource.Value())
 		if err != nil {
@@ -239,6 +242,10 @@ func UpdateAuth(ctx context.Context, u *user_model.User, opts *UpdateAuthOptions
 		return err
 	}
 
+	if wasProhibitLogin != u.ProhibitLogin {
+		notify_service.ChangeUserSuspension(ctx, doer, u, u.ProhibitLogin)
+	}
+
 	if deleteAuthTokens {
 		return auth_model.DeleteAuthTokensByUserID(ctx, u.ID)
 	}
@@ -24,12 +24,31 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/agit"
 	asymkey_service "code.gitea.io/gitea/services/asymkey"
+	notify_service "code.gitea.io/gitea/services/notify"
 	org_service "code.gitea.io/gitea/services/org"
 	"code.gitea.io/gitea/services/packages"
 	container_service "code.gitea.io/gitea/services/packages/container"
 	repo_service "code.gitea.io/gitea/services/repository"
 )
 
+// CreateUser creates a user and notifies about it, doer is nil if the account is provisioned without a user acting, e.g. by a sync
+func CreateUser(ctx context.Context, doer, u *user_model.User, meta *user_model.Meta, overwriteDefault ...*user_model.CreateUserOverwriteOptions) error {
+	if err := user_model.CreateUser(ctx, u, meta, overwriteDefault...); err != nil {
+		return err
+	}
+	notify_service.CreateUser(ctx, doer, u)
+	return nil
+}
+
+// AdminCreateUser creates a user on behalf of an admin and notifies about it
+func AdminCreateUser(ctx context.Context, doer, u *user_model.User, meta *user_model.Meta, overwriteDefault ...*user_model.CreateUserOverwriteOptions) error {
+	if err := user_model.AdminCreateUser(ctx, u, meta, overwriteDefault...); err != nil {
+		return err
+	}
+	notify_service.CreateUser(ctx, doer, u)
+	return nil
+}
+
 // RenameUser renames a user
 func RenameUser(ctx context.Context, u *user_model.User, newUserName string, doer *user_model.User) error {
 	if newUserName == u.Name {
@@ -186,7 +205,7 @@ func DeleteUser(ctx context.Context, u *user_model.User, purge bool) error {
 				break
 			}
 			for _, org := range orgs {
-				if err := org_service.RemoveOrgUser(ctx, org, u); err != nil {
+				if err := org_service.RemoveOrgUser(ctx, nil, org, u); err != nil {
 					if organization.IsErrLastOrgOwner(err) {
 						err = org_service.DeleteOrganization(ctx, org, true)
 						if err != nil {
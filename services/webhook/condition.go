@@ -0,0 +1,118 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// checkCondition evaluates the condition of a webhook against the event payload
+func checkCondition(condition string, event webhook_module.HookEventType, p api.Payloader, payload []byte) bool {
+	if condition == "" {
+		return true
+	}
+
+	c, err := webhook_module.ParseCondition(condition)
+	if err != nil {
+		// should not really happen as Condition is validated
+		log.Debug("checkCondition failed to parse condition %q, err: %s", condition, err)
+		return false
+	}
+
+	var data any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		log.Error("checkCondition failed to unmarshal payload for %s: %v", event, err)
+		return false
+	}
+
+	return c.Match(func(field string) []string {
+		return lookupConditionField(field, event, p, data)
+	})
+}
+
+// lookupConditionField returns the values of a condition field, unknown fields are resolved as dotted path in the payload
+func lookupConditionField(field string, event webhook_module.HookEventType, p api.Payloader, data any) []string {
+	ref := getPayloadRef(p)
+	switch field {
+	case "event":
+		return []string{string(event)}
+	case "ref":
+		if ref != "" {
+			return []string{ref.String()}
+		}
+		return nil
+	case "branch":
+		if ref.IsBranch() {
+			return []string{ref.BranchName()}
+		}
+		// pull request events are filtered by their target branch
+		return lookupPayloadPath(data, "pull_request.base.ref")
+	case "tag":
+		if ref.IsTag() {
+			return []string{ref.TagName()}
+		}
+		return nil
+	case "label":
+		return append(lookupPayloadPath(data, "issue.labels.name"), lookupPayloadPath(data, "pull_request.labels.name")...)
+	case "sender":
+		return lookupPayloadPath(data, "sender.login")
+	case "repo":
+		return lookupPayloadPath(data, "repository.full_name")
+	}
+	return lookupPayloadPath(data, field)
+}
+
+// lookupPayloadPath returns the scalar values at a dotted path of the unmarshalled payload, arrays are expanded
+func lookupPayloadPath(data any, path string) []string {
+	values := []any{data}
+	for key := range strings.SplitSeq(path, ".") {
+		next := make([]any, 0, len(values))
+		for _, v := range values {
+			switch vv := v.(type) {
+			case map[string]any:
+				if child, ok := vv[key]; ok {
+					next = append(next, child)
+				}
+			case []any:
+				for _, item := range vv {
+					if m, ok := item.(map[string]any); ok {
+						if child, ok := m[key]; ok {
+							next = append(next, child)
+						}
+					}
+				}
+			}
+		}
+		values = next
+	}
+
+	result := make([]string, 0, len(values))
+	var add func(v any)
+	add = func(v any) {
+		switch vv := v.(type) {
+		case nil, map[string]any:
+		case []any:
+			for _, item := range vv {
+				add(item)
+			}
+		case string:
+			result = append(result, vv)
+		case float64:
+			result = append(result, strconv.FormatFloat(vv, 'f', -1, 64))
+		default:
+			result = append(result, fmt.Sprint(vv))
+		}
+	}
+	for _, v := range values {
+		add(v)
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"testing"
+
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCondition(t *testing.T) {
+	push := pushTestPayload()
+	pr := pullRequestTestPayload()
+	pr.PullRequest.Base = &api.PRBranchInfo{Ref: "release/1.0"}
+	pr.PullRequest.Labels = []*api.Label{{Name: "bug"}, {Name: "security"}}
+
+	cases := []struct {
+		condition string
+		event     webhook_module.HookEventType
+		payload   api.Payloader
+		match     bool
+	}{
+		{"", webhook_module.HookEventPush, push, true},
+		{"branch == test", webhook_module.HookEventPush, push, true},
+		{"branch matches release/*", webhook_module.HookEventPush, push, false},
+		{"ref == refs/heads/test", webhook_module.HookEventPush, push, true},
+		{"tag == test", webhook_module.HookEventPush, push, false},
+		{"event == push and repo == test/repo", webhook_module.HookEventPush, push, true},
+		{"sender == user1", webhook_module.HookEventPush, push, true},
+		{"commits.message contains message", webhook_module.HookEventPush, push, true},
+		{"total_commits == 2", webhook_module.HookEventPush, push, true},
+
+		{"branch matches release/*", webhook_module.HookEventPullRequest, pr, true},
+		{`label == "security"`, webhook_module.HookEventPullRequest, pr, true},
+		{"label == enhancement", webhook_module.HookEventPullRequest, pr, false},
+		{"action == opened and pull_request.number == 12", webhook_module.HookEventPullRequest, pr, true},
+		{"pull_request.mergeable == true", webhook_module.HookEventPullRequest, pr, true},
+		{"pull_request.unknown == true", webhook_module.HookEventPullRequest, pr, false},
+	}
+	for _, v := range cases {
+		payload, err := v.payload.JSONPayload()
+		require.NoError(t, err)
+		assert.Equal(t, v.match, checkCondition(v.condition, v.event, v.payload, payload), "condition: %s", v.condition)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// CustomMeta contains the metadata for the custom webhook
+type CustomMeta struct {
+	PayloadTemplate    string `json:"payload_template"`
+	PayloadContentType string `json:"payload_content_type"`
+}
+
+// GetCustomHook returns custom metadata
+func GetCustomHook(w *webhook_model.Webhook) *CustomMeta {
+	s := &CustomMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetCustomHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// ParseCustomPayloadTemplate parses the payload template of a custom webhook
+func ParseCustomPayloadTemplate(text string) (*template.Template, error) {
+	return parseCustomPayloadTemplate(text, "")
+}
+
+func parseCustomPayloadTemplate(text string, event webhook_module.HookEventType) (*template.Template, error) {
+	return template.New("payload").Funcs(template.FuncMap{
+		"event": func() string {
+			return string(event)
+		},
+		"toJSON": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(text)
+}
+
+// maxCustomPayloadSize limits the size of the payload rendered by the template of a custom webhook
+const maxCustomPayloadSize = 1 << 20
+
+// payloadBuffer aborts the execution of a payload template once the rendered payload exceeds its limit
+type payloadBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *payloadBuffer) Write(p []byte) (int, error) {
+	if b.buf.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("payload exceeds %d bytes: %w", b.limit, util.ErrContentTooLarge)
+	}
+	return b.buf.Write(p)
+}
+
+// renderCustomPayload executes the payload template with the unmarshalled event payload
+func renderCustomPayload(meta *CustomMeta, t *webhook_model.HookTask) ([]byte, error) {
+	tmpl, err := parseCustomPayloadTemplate(meta.PayloadTemplate, t.EventType)
+	if err != nil {
+		return nil, fmt.Errorf("parse payload template: %w", err)
+	}
+
+	var data any
+	if err := json.Unmarshal([]byte(t.PayloadContent), &data); err != nil {
+		return nil, fmt.Errorf("could not unmarshal payload: %w", err)
+	}
+
+	buf := &payloadBuffer{limit: maxCustomPayloadSize}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("execute payload template: %w", err)
+	}
+	return buf.buf.Bytes(), nil
+}
+
+func newCustomRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &CustomMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newCustomRequest meta json: %w", err)
+	}
+
+	body, err := renderCustomPayload(meta, t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	method := w.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	req, err := http.NewRequest(method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contentType := meta.PayloadContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return req, body, addDefaultHeaders(req, []byte(w.Secret), w, t, body)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.CUSTOM, newCustomRequest)
+}
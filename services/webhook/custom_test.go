@@ -0,0 +1,96 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_GetCustomHook(t *testing.T) {
+	w := &webhook_model.Webhook{
+		Meta: `{"payload_template": "{{.ref}}", "payload_content_type": "text/plain"}`,
+	}
+	customHook := GetCustomHook(w)
+	assert.Equal(t, CustomMeta{
+		PayloadTemplate:    "{{.ref}}",
+		PayloadContentType: "text/plain",
+	}, *customHook)
+}
+
+func TestParseCustomPayloadTemplate(t *testing.T) {
+	_, err := ParseCustomPayloadTemplate(`{"text": {{toJSON .repository.full_name}}, "event": "{{event}}"}`)
+	assert.NoError(t, err)
+
+	_, err = ParseCustomPayloadTemplate("{{.ref")
+	assert.Error(t, err)
+
+	_, err = ParseCustomPayloadTemplate("{{unknown .ref}}")
+	assert.Error(t, err)
+}
+
+func TestCustomJSONPayload(t *testing.T) {
+	p := pushTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.CUSTOM,
+		URL:        "https://example.com/hook",
+		Meta:       `{"payload_template":"{\"text\": {{toJSON .repository.full_name}}, \"event\": \"{{event}}\", \"commits\": [{{range $i, $c := .commits}}{{if $i}}, {{end}}{{toJSON $c.id}}{{end}}]}"}`,
+		HTTPMethod: "PUT",
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newCustomRequest(t.Context(), hook, task)
+	require.NoError(t, err)
+	require.NotNil(t, req)
+
+	expected := `{"text": "test/repo", "event": "push", "commits": ["2020558fe2e34debb818a514715839cabd25e778", "2020558fe2e34debb818a514715839cabd25e778"]}`
+	assert.Equal(t, expected, string(reqBody))
+	assert.Equal(t, "PUT", req.Method)
+	assert.Equal(t, "https://example.com/hook", req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "push", req.Header.Get("X-Gitea-Event"))
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(body))
+
+	hook.Meta = `{"payload_template":"{{index .commits 5}}"}`
+	_, _, err = newCustomRequest(t.Context(), hook, task)
+	assert.Error(t, err)
+}
+
+func TestCustomPayloadSizeLimit(t *testing.T) {
+	task := &webhook_model.HookTask{
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: `{"ref": "` + strings.Repeat("a", maxCustomPayloadSize/4) + `"}`,
+	}
+
+	payload, err := renderCustomPayload(&CustomMeta{PayloadTemplate: "{{.ref}}{{.ref}}{{.ref}}{{.ref}}"}, task)
+	require.NoError(t, err)
+	assert.Len(t, payload, maxCustomPayloadSize)
+
+	_, err = renderCustomPayload(&CustomMeta{PayloadTemplate: "{{.ref}}{{.ref}}{{.ref}}{{.ref}}!"}, task)
+	assert.ErrorIs(t, err, util.ErrContentTooLarge)
+
+	// the execution is aborted instead of rendering the whole output
+	_, err = renderCustomPayload(&CustomMeta{PayloadTemplate: "{{range 1000000}}{{$.ref}}{{end}}"}, task)
+	assert.ErrorIs(t, err, util.ErrContentTooLarge)
+}
@@ -216,6 +216,7 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 	}
 
 	// All code from this point will update the hook task
+	attempted := false
 	defer func() {
 		t.Delivered = timeutil.TimeStampNanoNow()
 		if t.IsSucceed {
@@ -226,10 +227,18 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 			log.Trace("Hook delivery failed: %s", t.UUID)
 		}
 
+		if attempted && !t.IsSucceed {
+			retryHookTask(ctx, w, t)
+		}
+
 		if err := webhook_model.UpdateHookTask(ctx, t); err != nil {
 			log.Error("UpdateHookTask [%d]: %v", t.ID, err)
 		}
 
+		if attempted {
+			updateConsecutiveFailures(ctx, w, t)
+		}
+
 		// Update webhook last delivery status.
 		if t.IsSucceed {
 			w.LastStatus = webhook_module.HookStatusSucceed
@@ -251,6 +260,7 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		return nil
 	}
 
+	attempted = true
 	resp, err := webhookHTTPClient.Do(req.WithContext(ctx))
 	if err != nil {
 		t.ResponseInfo.Body = fmt.Sprintf("Delivery: %v", err)
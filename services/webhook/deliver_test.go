@@ -16,6 +16,8 @@ import (
 	webhook_model "code.gitea.io/gitea/models/webhook"
 	"code.gitea.io/gitea/modules/hostmatcher"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 
@@ -344,3 +346,73 @@ func TestWebhookDeliverSpecificTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhookDeliverRetry(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Webhook.AutoDisableThreshold, 1)()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(s.Close)
+
+	hook := &webhook_model.Webhook{
+		RepoID:      3,
+		URL:         s.URL + "/webhook",
+		ContentType: webhook_model.ContentTypeJSON,
+		IsActive:    true,
+		Type:        webhook_module.GITEA,
+		MaxAttempts: 2,
+	}
+	assert.NoError(t, webhook_model.CreateWebhook(t.Context(), hook))
+
+	hookTask, err := webhook_model.CreateHookTask(t.Context(), &webhook_model.HookTask{
+		HookID:         hook.ID,
+		PayloadContent: `{"data": 42}`,
+		EventType:      webhook_module.HookEventPush,
+		PayloadVersion: 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, hookTask.Attempt)
+
+	assert.NoError(t, Deliver(t.Context(), hookTask))
+	assert.False(t, hookTask.IsSucceed)
+	assert.False(t, hookTask.IsExhausted)
+	assert.Equal(t, http.StatusServiceUnavailable, hookTask.ResponseInfo.Status)
+
+	retry := unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{HookID: hook.ID, Attempt: 2})
+	assert.False(t, retry.IsDelivered)
+	assert.NotEqual(t, hookTask.UUID, retry.UUID)
+	assert.Equal(t, hookTask.PayloadContent, retry.PayloadContent)
+	assert.Greater(t, retry.ScheduledUnix, timeutil.TimeStampNow())
+
+	// the first attempt keeps its own request and response
+	first := unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{ID: hookTask.ID})
+	assert.True(t, first.IsDelivered)
+	assert.NotNil(t, first.ResponseInfo)
+
+	assert.NoError(t, Deliver(t.Context(), retry))
+	assert.False(t, retry.IsSucceed)
+	assert.True(t, retry.IsExhausted)
+	unittest.AssertNotExistsBean(t, &webhook_model.HookTask{HookID: hook.ID, Attempt: 3})
+
+	failed, err := hook.FailedDeliveries(t.Context(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, failed, 1)
+	assert.Equal(t, retry.ID, failed[0].ID)
+
+	hook = unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+	assert.False(t, hook.IsActive)
+	assert.Equal(t, 1, hook.ConsecutiveFailures)
+}
+
+func TestRetryDelay(t *testing.T) {
+	defer test.MockVariableValue(&setting.Webhook.RetryBackoff, time.Minute)()
+	defer test.MockVariableValue(&setting.Webhook.RetryMaxBackoff, 10*time.Minute)()
+
+	assert.Equal(t, time.Minute, retryDelay(2))
+	assert.Equal(t, 2*time.Minute, retryDelay(3))
+	assert.Equal(t, 8*time.Minute, retryDelay(5))
+	assert.Equal(t, 10*time.Minute, retryDelay(6))
+	assert.Equal(t, 10*time.Minute, retryDelay(20))
+}
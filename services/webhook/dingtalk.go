@@ -152,6 +152,12 @@ func (dc dingtalkConvertor) Repository(p *api.RepositoryPayload) (DingtalkPayloa
 				Content: title,
 			},
 		}, nil
+	case api.HookRepoPublicized:
+		title := fmt.Sprintf("[%s] Repository made public", p.Repository.FullName)
+		return createDingtalkPayload(title, title, "view repository", p.Repository.HTMLURL), nil
+	case api.HookRepoPrivatized:
+		title := fmt.Sprintf("[%s] Repository made private", p.Repository.FullName)
+		return createDingtalkPayload(title, title, "view repository", p.Repository.HTMLURL), nil
 	}
 
 	return DingtalkPayload{}, nil
@@ -170,6 +176,66 @@ func (dc dingtalkConvertor) Package(p *api.PackagePayload) (DingtalkPayload, err
 	return createDingtalkPayload(text, text, "view package", p.Package.HTMLURL), nil
 }
 
+func (dc dingtalkConvertor) PackageVulnerability(p *api.PackageVulnerabilityPayload) (DingtalkPayload, error) {
+	text, _ := getPackageVulnerabilityPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view package", p.Package.HTMLURL), nil
+}
+
+func (dc dingtalkConvertor) Member(p *api.MemberPayload) (DingtalkPayload, error) {
+	text, _, link := getMemberPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view collaborators", link), nil
+}
+
+func (dc dingtalkConvertor) Organization(p *api.OrganizationPayload) (DingtalkPayload, error) {
+	text, _, link := getOrganizationPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view organization", link), nil
+}
+
+func (dc dingtalkConvertor) Team(p *api.TeamPayload) (DingtalkPayload, error) {
+	text, _, link := getTeamPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view team", link), nil
+}
+
+func (dc dingtalkConvertor) Membership(p *api.MembershipPayload) (DingtalkPayload, error) {
+	text, _, link := getMembershipPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view team", link), nil
+}
+
+func (dc dingtalkConvertor) BranchProtection(p *api.BranchProtectionPayload) (DingtalkPayload, error) {
+	text, _, link := getBranchProtectionPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view branch protection", link), nil
+}
+
+func (dc dingtalkConvertor) DeployKey(p *api.DeployKeyPayload) (DingtalkPayload, error) {
+	text, _, link := getDeployKeyPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view deploy keys", link), nil
+}
+
+func (dc dingtalkConvertor) User(p *api.UserPayload) (DingtalkPayload, error) {
+	text, _, link := getUserPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view user", link), nil
+}
+
+func (dc dingtalkConvertor) Star(p *api.StarPayload) (DingtalkPayload, error) {
+	text, _, link := getStarPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view repository", link), nil
+}
+
+func (dc dingtalkConvertor) Watch(p *api.WatchPayload) (DingtalkPayload, error) {
+	text, _, link := getWatchPayloadInfo(p, noneLinkFormatter, true)
+
+	return createDingtalkPayload(text, text, "view repository", link), nil
+}
+
 func (dc dingtalkConvertor) Status(p *api.CommitStatusPayload) (DingtalkPayload, error) {
 	text, _ := getStatusPayloadInfo(p, noneLinkFormatter, true)
 
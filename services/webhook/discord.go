@@ -241,6 +241,14 @@ func (d discordConvertor) Repository(p *api.RepositoryPayload) (DiscordPayload,
 	case api.HookRepoDeleted:
 		title = fmt.Sprintf("[%s] Repository deleted", p.Repository.FullName)
 		color = redColor
+	case api.HookRepoPublicized:
+		title = fmt.Sprintf("[%s] Repository made public", p.Repository.FullName)
+		url = p.Repository.HTMLURL
+		color = orangeColor
+	case api.HookRepoPrivatized:
+		title = fmt.Sprintf("[%s] Repository made private", p.Repository.FullName)
+		url = p.Repository.HTMLURL
+		color = greenColor
 	}
 
 	return d.createPayload(p.Sender, title, "", url, color), nil
@@ -272,6 +280,66 @@ func (d discordConvertor) Package(p *api.PackagePayload) (DiscordPayload, error)
 	return d.createPayload(p.Sender, text, "", p.Package.HTMLURL, color), nil
 }
 
+func (d discordConvertor) PackageVulnerability(p *api.PackageVulnerabilityPayload) (DiscordPayload, error) {
+	text, color := getPackageVulnerabilityPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", p.Package.HTMLURL, color), nil
+}
+
+func (d discordConvertor) Member(p *api.MemberPayload) (DiscordPayload, error) {
+	text, color, link := getMemberPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Organization(p *api.OrganizationPayload) (DiscordPayload, error) {
+	text, color, link := getOrganizationPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Team(p *api.TeamPayload) (DiscordPayload, error) {
+	text, color, link := getTeamPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Membership(p *api.MembershipPayload) (DiscordPayload, error) {
+	text, color, link := getMembershipPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) BranchProtection(p *api.BranchProtectionPayload) (DiscordPayload, error) {
+	text, color, link := getBranchProtectionPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) DeployKey(p *api.DeployKeyPayload) (DiscordPayload, error) {
+	text, color, link := getDeployKeyPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) User(p *api.UserPayload) (DiscordPayload, error) {
+	text, color, link := getUserPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Star(p *api.StarPayload) (DiscordPayload, error) {
+	text, color, link := getStarPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
+func (d discordConvertor) Watch(p *api.WatchPayload) (DiscordPayload, error) {
+	text, color, link := getWatchPayloadInfo(p, noneLinkFormatter, false)
+
+	return d.createPayload(p.Sender, text, "", link, color), nil
+}
+
 func (d discordConvertor) Status(p *api.CommitStatusPayload) (DiscordPayload, error) {
 	text, color := getStatusPayloadInfo(p, noneLinkFormatter, false)
 
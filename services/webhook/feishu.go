@@ -147,6 +147,12 @@ func (fc feishuConvertor) Repository(p *api.RepositoryPayload) (FeishuPayload, e
 	case api.HookRepoDeleted:
 		text = fmt.Sprintf("[%s] Repository deleted", p.Repository.FullName)
 		return newFeishuTextPayload(text), nil
+	case api.HookRepoPublicized:
+		text = fmt.Sprintf("[%s] Repository made public", p.Repository.FullName)
+		return newFeishuTextPayload(text), nil
+	case api.HookRepoPrivatized:
+		text = fmt.Sprintf("[%s] Repository made private", p.Repository.FullName)
+		return newFeishuTextPayload(text), nil
 	}
 
 	return FeishuPayload{}, nil
@@ -172,6 +178,66 @@ func (fc feishuConvertor) Package(p *api.PackagePayload) (FeishuPayload, error)
 	return newFeishuTextPayload(text), nil
 }
 
+func (fc feishuConvertor) PackageVulnerability(p *api.PackageVulnerabilityPayload) (FeishuPayload, error) {
+	text, _ := getPackageVulnerabilityPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) Member(p *api.MemberPayload) (FeishuPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) Organization(p *api.OrganizationPayload) (FeishuPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) Team(p *api.TeamPayload) (FeishuPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) Membership(p *api.MembershipPayload) (FeishuPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) BranchProtection(p *api.BranchProtectionPayload) (FeishuPayload, error) {
+	text, _, _ := getBranchProtectionPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) DeployKey(p *api.DeployKeyPayload) (FeishuPayload, error) {
+	text, _, _ := getDeployKeyPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) User(p *api.UserPayload) (FeishuPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) Star(p *api.StarPayload) (FeishuPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
+func (fc feishuConvertor) Watch(p *api.WatchPayload) (FeishuPayload, error) {
+	text, _, _ := getWatchPayloadInfo(p, noneLinkFormatter, true)
+
+	return newFeishuTextPayload(text), nil
+}
+
 func (fc feishuConvertor) Status(p *api.CommitStatusPayload) (FeishuPayload, error) {
 	text, _ := getStatusPayloadInfo(p, noneLinkFormatter, true)
 
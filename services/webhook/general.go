@@ -311,6 +311,223 @@ func getPackagePayloadInfo(p *api.PackagePayload, linkFormatter linkFormatter, w
 	return text, color
 }
 
+func getPackageVulnerabilityPayloadInfo(p *api.PackageVulnerabilityPayload, linkFormatter linkFormatter, withSender bool) (text string, color int) {
+	refLink := linkFormatter(p.Package.HTMLURL, p.Package.Name+":"+p.Package.Version)
+
+	ids := make([]string, 0, len(p.Vulnerabilities))
+	for _, v := range p.Vulnerabilities {
+		ids = append(ids, v.ID)
+	}
+	text = fmt.Sprintf("Vulnerabilities detected in package %s: %s", refLink, strings.Join(ids, ", "))
+	color = redColor
+	if withSender {
+		text += " published by " + linkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName)
+	}
+
+	return text, color
+}
+
+func getUserLink(u *api.User, linkFormatter linkFormatter) string {
+	return linkFormatter(setting.AppURL+url.PathEscape(u.UserName), u.UserName)
+}
+
+func getMemberPayloadInfo(p *api.MemberPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+	memberLink := getUserLink(p.Member, linkFormatter)
+
+	switch p.Action {
+	case api.HookMemberAdded:
+		text = fmt.Sprintf("[%s] Collaborator %s added with %s permission", repoLink, memberLink, p.Permission)
+		color = greenColor
+	case api.HookMemberEdited:
+		text = fmt.Sprintf("[%s] Permission of collaborator %s changed from %s to %s", repoLink, memberLink, p.PreviousPermission, p.Permission)
+		color = yellowColor
+	case api.HookMemberRemoved:
+		text = fmt.Sprintf("[%s] Collaborator %s removed", repoLink, memberLink)
+		color = redColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, p.Repository.HTMLURL + "/settings/collaboration"
+}
+
+func getOrganizationPayloadInfo(p *api.OrganizationPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = setting.AppURL + url.PathEscape(p.Organization.UserName)
+	orgLink := linkFormatter(link, p.Organization.UserName)
+	memberLink := getUserLink(p.Member, linkFormatter)
+
+	switch p.Action {
+	case api.HookOrganizationMemberAdded:
+		text = fmt.Sprintf("[%s] Member %s added", orgLink, memberLink)
+		color = greenColor
+	case api.HookOrganizationMemberRemoved:
+		text = fmt.Sprintf("[%s] Member %s removed", orgLink, memberLink)
+		color = redColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, link
+}
+
+func getTeamPayloadInfo(p *api.TeamPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = setting.AppURL + "org/" + url.PathEscape(p.Organization.UserName) + "/teams/" + url.PathEscape(strings.ToLower(p.Team.Name))
+	orgLink := linkFormatter(setting.AppURL+url.PathEscape(p.Organization.UserName), p.Organization.UserName)
+	teamLink := linkFormatter(link, p.Team.Name)
+
+	switch p.Action {
+	case api.HookTeamCreated:
+		text = fmt.Sprintf("[%s] Team %s created with %s permission", orgLink, teamLink, p.Team.Permission)
+		color = greenColor
+	case api.HookTeamEdited:
+		text = fmt.Sprintf("[%s] Team %s edited, permission is %s", orgLink, teamLink, p.Team.Permission)
+		color = yellowColor
+	case api.HookTeamDeleted:
+		text = fmt.Sprintf("[%s] Team %s deleted", orgLink, p.Team.Name)
+		color = redColor
+		link = setting.AppURL + "org/" + url.PathEscape(p.Organization.UserName) + "/teams"
+	case api.HookTeamAddedToRepository:
+		repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+		text = fmt.Sprintf("[%s] Team %s granted %s permission on repository %s", orgLink, teamLink, p.Team.Permission, repoLink)
+		color = greenColor
+	case api.HookTeamRemovedFromRepository:
+		repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+		text = fmt.Sprintf("[%s] Team %s removed from repository %s", orgLink, teamLink, repoLink)
+		color = redColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, link
+}
+
+func getMembershipPayloadInfo(p *api.MembershipPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = setting.AppURL + "org/" + url.PathEscape(p.Organization.UserName) + "/teams/" + url.PathEscape(strings.ToLower(p.Team.Name))
+	orgLink := linkFormatter(setting.AppURL+url.PathEscape(p.Organization.UserName), p.Organization.UserName)
+	teamLink := linkFormatter(link, p.Team.Name)
+	memberLink := getUserLink(p.Member, linkFormatter)
+
+	switch p.Action {
+	case api.HookMembershipAdded:
+		text = fmt.Sprintf("[%s] Member %s added to team %s", orgLink, memberLink, teamLink)
+		color = greenColor
+	case api.HookMembershipRemoved:
+		text = fmt.Sprintf("[%s] Member %s removed from team %s", orgLink, memberLink, teamLink)
+		color = redColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, link
+}
+
+func getBranchProtectionPayloadInfo(p *api.BranchProtectionPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = p.Repository.HTMLURL + "/settings/branches"
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+
+	switch p.Action {
+	case api.HookBranchProtectionCreated:
+		text = fmt.Sprintf("[%s] Branch protection rule '%s' created", repoLink, p.Rule.RuleName)
+		color = greenColor
+	case api.HookBranchProtectionEdited:
+		text = fmt.Sprintf("[%s] Branch protection rule '%s' edited", repoLink, p.Rule.RuleName)
+		color = yellowColor
+	case api.HookBranchProtectionDeleted:
+		text = fmt.Sprintf("[%s] Branch protection rule '%s' deleted", repoLink, p.Rule.RuleName)
+		color = redColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, link
+}
+
+func getDeployKeyPayloadInfo(p *api.DeployKeyPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = p.Repository.HTMLURL + "/settings/keys"
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+
+	switch p.Action {
+	case api.HookDeployKeyCreated:
+		text = fmt.Sprintf("[%s] Deploy key '%s' added", repoLink, p.Key.Title)
+		if !p.Key.ReadOnly {
+			text += " with write access"
+		}
+		color = greenColor
+	case api.HookDeployKeyDeleted:
+		text = fmt.Sprintf("[%s] Deploy key '%s' removed", repoLink, p.Key.Title)
+		color = redColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, link
+}
+
+func getUserPayloadInfo(p *api.UserPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	link = setting.AppURL + url.PathEscape(p.User.UserName)
+	userLink := linkFormatter(link, p.User.UserName)
+
+	switch p.Action {
+	case api.HookUserCreated:
+		text = "User created: " + userLink
+		color = greenColor
+	case api.HookUserSuspended:
+		text = "User suspended: " + userLink
+		color = redColor
+	case api.HookUserUnsuspended:
+		text = "User unsuspended: " + userLink
+		color = yellowColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, link
+}
+
+func getStarPayloadInfo(p *api.StarPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+
+	switch p.Action {
+	case api.HookStarCreated:
+		text = fmt.Sprintf("[%s] Repository starred", repoLink)
+		color = yellowColor
+	case api.HookStarDeleted:
+		text = fmt.Sprintf("[%s] Repository unstarred", repoLink)
+		color = greyColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, p.Repository.HTMLURL
+}
+
+func getWatchPayloadInfo(p *api.WatchPayload, linkFormatter linkFormatter, withSender bool) (text string, color int, link string) {
+	repoLink := linkFormatter(p.Repository.HTMLURL, p.Repository.FullName)
+
+	switch p.Action {
+	case api.HookWatchStarted:
+		text = fmt.Sprintf("[%s] Repository watched", repoLink)
+		color = yellowColor
+	case api.HookWatchStopped:
+		text = fmt.Sprintf("[%s] Repository unwatched", repoLink)
+		color = greyColor
+	}
+	if withSender {
+		text += " by " + getUserLink(p.Sender, linkFormatter)
+	}
+
+	return text, color, p.Repository.HTMLURL
+}
+
 func getStatusPayloadInfo(p *api.CommitStatusPayload, linkFormatter linkFormatter, withSender bool) (text string, color int) {
 	refLink := linkFormatter(p.TargetURL, fmt.Sprintf("%s [%s]", p.Context, base.ShortSha(p.SHA)))
 
@@ -403,6 +620,11 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		config["icon_url"] = s.IconURL
 		config["color"] = s.Color
 	}
+	if w.Type == webhook_module.CUSTOM {
+		s := GetCustomHook(w)
+		config["payload_template"] = s.PayloadTemplate
+		config["payload_content_type"] = s.PayloadContentType
+	}
 
 	authorizationHeader, err := w.HeaderAuthorization()
 	if err != nil {
@@ -414,11 +636,13 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		Type:                w.Type,
 		URL:                 fmt.Sprintf("%s/settings/hooks/%d", repoLink, w.ID),
 		Active:              w.IsActive,
+		MaxAttempts:         w.MaxAttempts,
 		Config:              config,
 		Events:              w.EventsArray(),
 		AuthorizationHeader: authorizationHeader,
 		Updated:             w.UpdatedUnix.AsTime(),
 		Created:             w.CreatedUnix.AsTime(),
 		BranchFilter:        w.BranchFilter,
+		Condition:           w.Condition,
 	}, nil
 }
@@ -341,6 +341,50 @@ func packageTestPayload() *api.PackagePayload {
 	}
 }
 
+func memberTestPayload() *api.MemberPayload {
+	return &api.MemberPayload{
+		Action:     api.HookMemberAdded,
+		Permission: "admin",
+		Sender: &api.User{
+			UserName:  "user1",
+			AvatarURL: "http://localhost:3000/user1/avatar",
+		},
+		Member: &api.User{
+			UserName:  "user2",
+			AvatarURL: "http://localhost:3000/user2/avatar",
+		},
+		Repository: &api.Repository{
+			HTMLURL:  "http://localhost:3000/test/repo",
+			Name:     "repo",
+			FullName: "test/repo",
+		},
+	}
+}
+
+func teamTestPayload() *api.TeamPayload {
+	return &api.TeamPayload{
+		Action: api.HookTeamCreated,
+		Sender: &api.User{
+			UserName:  "user1",
+			AvatarURL: "http://localhost:3000/user1/avatar",
+		},
+		Team: &api.Team{
+			Name:       "Owners",
+			Permission: "owner",
+		},
+		Organization: &api.Organization{
+			Name:      "org1",
+			UserName:  "org1",
+			AvatarURL: "http://localhost:3000/org1/avatar",
+		},
+		Repository: &api.Repository{
+			HTMLURL:  "http://localhost:3000/org1/repo",
+			Name:     "repo",
+			FullName: "org1/repo",
+		},
+	}
+}
+
 func TestGetIssuesPayloadInfo(t *testing.T) {
 	p := issueTestPayload()
 
@@ -647,3 +691,85 @@ func TestGetIssueCommentPayloadInfo(t *testing.T) {
 		assert.Equal(t, c.color, color, "case %d", i)
 	}
 }
+
+func TestGetMemberPayloadInfo(t *testing.T) {
+	p := memberTestPayload()
+
+	cases := []struct {
+		action             api.HookMemberAction
+		previousPermission string
+		text               string
+		color              int
+	}{
+		{
+			api.HookMemberAdded,
+			"",
+			"[test/repo] Collaborator user2 added with admin permission by user1",
+			greenColor,
+		},
+		{
+			api.HookMemberEdited,
+			"write",
+			"[test/repo] Permission of collaborator user2 changed from write to admin by user1",
+			yellowColor,
+		},
+		{
+			api.HookMemberRemoved,
+			"",
+			"[test/repo] Collaborator user2 removed by user1",
+			redColor,
+		},
+	}
+
+	for i, c := range cases {
+		p.Action = c.action
+		p.PreviousPermission = c.previousPermission
+		text, color, link := getMemberPayloadInfo(p, noneLinkFormatter, true)
+		assert.Equal(t, c.text, text, "case %d", i)
+		assert.Equal(t, c.color, color, "case %d", i)
+		assert.Equal(t, "http://localhost:3000/test/repo/settings/collaboration", link, "case %d", i)
+	}
+}
+
+func TestGetTeamPayloadInfo(t *testing.T) {
+	p := teamTestPayload()
+
+	cases := []struct {
+		action api.HookTeamAction
+		text   string
+		color  int
+	}{
+		{
+			api.HookTeamCreated,
+			"[org1] Team Owners created with owner permission by user1",
+			greenColor,
+		},
+		{
+			api.HookTeamEdited,
+			"[org1] Team Owners edited, permission is owner by user1",
+			yellowColor,
+		},
+		{
+			api.HookTeamDeleted,
+			"[org1] Team Owners deleted by user1",
+			redColor,
+		},
+		{
+			api.HookTeamAddedToRepository,
+			"[org1] Team Owners granted owner permission on repository org1/repo by user1",
+			greenColor,
+		},
+		{
+			api.HookTeamRemovedFromRepository,
+			"[org1] Team Owners removed from repository org1/repo by user1",
+			redColor,
+		},
+	}
+
+	for i, c := range cases {
+		p.Action = c.action
+		text, color, _ := getTeamPayloadInfo(p, noneLinkFormatter, true)
+		assert.Equal(t, c.text, text, "case %d", i)
+		assert.Equal(t, c.color, color, "case %d", i)
+	}
+}
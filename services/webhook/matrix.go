@@ -226,6 +226,10 @@ func (m matrixConvertor) Repository(p *api.RepositoryPayload) (MatrixPayload, er
 		text = fmt.Sprintf("[%s] Repository created by %s", repoLink, senderLink)
 	case api.HookRepoDeleted:
 		text = fmt.Sprintf("[%s] Repository deleted by %s", repoLink, senderLink)
+	case api.HookRepoPublicized:
+		text = fmt.Sprintf("[%s] Repository made public by %s", repoLink, senderLink)
+	case api.HookRepoPrivatized:
+		text = fmt.Sprintf("[%s] Repository made private by %s", repoLink, senderLink)
 	}
 	return m.newPayload(text)
 }
@@ -245,6 +249,66 @@ func (m matrixConvertor) Package(p *api.PackagePayload) (MatrixPayload, error) {
 	return m.newPayload(text)
 }
 
+func (m matrixConvertor) PackageVulnerability(p *api.PackageVulnerabilityPayload) (MatrixPayload, error) {
+	text, _ := getPackageVulnerabilityPayloadInfo(p, htmlLinkFormatter, false)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Member(p *api.MemberPayload) (MatrixPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Organization(p *api.OrganizationPayload) (MatrixPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Team(p *api.TeamPayload) (MatrixPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Membership(p *api.MembershipPayload) (MatrixPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) BranchProtection(p *api.BranchProtectionPayload) (MatrixPayload, error) {
+	text, _, _ := getBranchProtectionPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) DeployKey(p *api.DeployKeyPayload) (MatrixPayload, error) {
+	text, _, _ := getDeployKeyPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) User(p *api.UserPayload) (MatrixPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Star(p *api.StarPayload) (MatrixPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
+func (m matrixConvertor) Watch(p *api.WatchPayload) (MatrixPayload, error) {
+	text, _, _ := getWatchPayloadInfo(p, htmlLinkFormatter, true)
+
+	return m.newPayload(text)
+}
+
 func (m matrixConvertor) Status(p *api.CommitStatusPayload) (MatrixPayload, error) {
 	refLink := htmlLinkFormatter(p.TargetURL, fmt.Sprintf("%s [%s]", p.Context, base.ShortSha(p.SHA)))
 	text := fmt.Sprintf("Commit Status changed: %s - %s", refLink, p.Description)
@@ -247,6 +247,14 @@ func (m msteamsConvertor) Repository(p *api.RepositoryPayload) (MSTeamsPayload,
 	case api.HookRepoDeleted:
 		title = fmt.Sprintf("[%s] Repository deleted", p.Repository.FullName)
 		color = yellowColor
+	case api.HookRepoPublicized:
+		title = fmt.Sprintf("[%s] Repository made public", p.Repository.FullName)
+		url = p.Repository.HTMLURL
+		color = orangeColor
+	case api.HookRepoPrivatized:
+		title = fmt.Sprintf("[%s] Repository made private", p.Repository.FullName)
+		url = p.Repository.HTMLURL
+		color = greenColor
 	}
 
 	return createMSTeamsPayload(
@@ -304,6 +312,146 @@ func (m msteamsConvertor) Package(p *api.PackagePayload) (MSTeamsPayload, error)
 	), nil
 }
 
+func (m msteamsConvertor) PackageVulnerability(p *api.PackageVulnerabilityPayload) (MSTeamsPayload, error) {
+	title, color := getPackageVulnerabilityPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		p.Package.HTMLURL,
+		color,
+		&MSTeamsFact{"Package:", p.Package.Name},
+	), nil
+}
+
+func (m msteamsConvertor) Member(p *api.MemberPayload) (MSTeamsPayload, error) {
+	title, color, link := getMemberPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (m msteamsConvertor) Organization(p *api.OrganizationPayload) (MSTeamsPayload, error) {
+	title, color, link := getOrganizationPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		nil,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (m msteamsConvertor) Team(p *api.TeamPayload) (MSTeamsPayload, error) {
+	title, color, link := getTeamPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (m msteamsConvertor) Membership(p *api.MembershipPayload) (MSTeamsPayload, error) {
+	title, color, link := getMembershipPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		nil,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (m msteamsConvertor) BranchProtection(p *api.BranchProtectionPayload) (MSTeamsPayload, error) {
+	title, color, link := getBranchProtectionPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (m msteamsConvertor) DeployKey(p *api.DeployKeyPayload) (MSTeamsPayload, error) {
+	title, color, link := getDeployKeyPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (m msteamsConvertor) User(p *api.UserPayload) (MSTeamsPayload, error) {
+	title, color, link := getUserPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		nil,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (m msteamsConvertor) Star(p *api.StarPayload) (MSTeamsPayload, error) {
+	title, color, link := getStarPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
+func (m msteamsConvertor) Watch(p *api.WatchPayload) (MSTeamsPayload, error) {
+	title, color, link := getWatchPayloadInfo(p, noneLinkFormatter, false)
+
+	return createMSTeamsPayload(
+		p.Repository,
+		p.Sender,
+		title,
+		"",
+		link,
+		color,
+		nil,
+	), nil
+}
+
 func (m msteamsConvertor) Status(p *api.CommitStatusPayload) (MSTeamsPayload, error) {
 	title, color := getStatusPayloadInfo(p, noneLinkFormatter, false)
 
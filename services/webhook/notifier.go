@@ -7,6 +7,7 @@ import (
 	"context"
 
 	actions_model "code.gitea.io/gitea/models/actions"
+	asymkey_model "code.gitea.io/gitea/models/asymkey"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
@@ -982,6 +983,39 @@ func notifyPackage(ctx context.Context, sender *user_model.User, pd *packages_mo
 	}
 }
 
+func (m *webhookNotifier) PackageVulnerabilitiesDetected(ctx context.Context, pd *packages_model.PackageDescriptor, vulnerabilities []*packages_model.PackageVulnerability) {
+	source := EventSource{
+		Repository: pd.Repository,
+		Owner:      pd.Owner,
+	}
+
+	apiPackage, err := convert.ToPackage(ctx, pd, pd.Creator)
+	if err != nil {
+		log.Error("Error converting package: %v", err)
+		return
+	}
+
+	var org *api.Organization
+	if pd.Owner.IsOrganization() {
+		org = convert.ToOrganization(ctx, organization.OrgFromUser(pd.Owner))
+	}
+
+	apiVulnerabilities := make([]*api.PackageVulnerability, 0, len(vulnerabilities))
+	for _, v := range vulnerabilities {
+		apiVulnerabilities = append(apiVulnerabilities, convert.ToPackageVulnerability(v))
+	}
+
+	if err := PrepareWebhooks(ctx, source, webhook_module.HookEventPackageVulnerability, &api.PackageVulnerabilityPayload{
+		Action:          api.HookPackageVulnerabilityDetected,
+		Package:         apiPackage,
+		Vulnerabilities: apiVulnerabilities,
+		Organization:    org,
+		Sender:          convert.ToUser(ctx, pd.Creator, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+}
+
 func (*webhookNotifier) WorkflowJobStatusUpdate(ctx context.Context, repo *repo_model.Repository, sender *user_model.User, job *actions_model.ActionRunJob, task *actions_model.ActionTask) {
 	source := EventSource{
 		Repository: repo,
@@ -1055,3 +1089,258 @@ func (*webhookNotifier) WorkflowRunStatusUpdate(ctx context.Context, repo *repo_
 		log.Error("PrepareWebhooks: %v", err)
 	}
 }
+
+// toSender converts the user who performed an action, actions without a user (e.g. a sync) are sent by the ghost user
+func toSender(ctx context.Context, doer *user_model.User) *api.User {
+	if doer == nil {
+		doer = user_model.NewGhostUser()
+	}
+	return convert.ToUser(ctx, doer, nil)
+}
+
+func (m *webhookNotifier) ChangeRepositoryVisibility(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) {
+	action := api.HookRepoPublicized
+	if repo.IsPrivate {
+		action = api.HookRepoPrivatized
+	}
+
+	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventRepositoryVisibility, &api.RepositoryPayload{
+		Action:       action,
+		Repository:   convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
+		Organization: convert.ToUser(ctx, repo.MustOwner(ctx), nil),
+		Sender:       toSender(ctx, doer),
+	}); err != nil {
+		log.Error("PrepareWebhooks [repo_id: %d]: %v", repo.ID, err)
+	}
+}
+
+func (m *webhookNotifier) StarRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, star bool) {
+	action := api.HookStarCreated
+	if !star {
+		action = api.HookStarDeleted
+	}
+
+	permission, _ := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventStar, &api.StarPayload{
+		Action:     action,
+		Repository: convert.ToRepo(ctx, repo, permission),
+		Sender:     convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks [repo_id: %d]: %v", repo.ID, err)
+	}
+}
+
+func (m *webhookNotifier) WatchRepository(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, watch bool) {
+	action := api.HookWatchStarted
+	if !watch {
+		action = api.HookWatchStopped
+	}
+
+	permission, _ := access_model.GetUserRepoPermission(ctx, repo, doer)
+	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventWatch, &api.WatchPayload{
+		Action:     action,
+		Repository: convert.ToRepo(ctx, repo, permission),
+		Sender:     convert.ToUser(ctx, doer, nil),
+	}); err != nil {
+		log.Error("PrepareWebhooks [repo_id: %d]: %v", repo.ID, err)
+	}
+}
+
+func (m *webhookNotifier) AddCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, mode perm.AccessMode) {
+	notifyMember(ctx, doer, repo, &api.MemberPayload{
+		Action:     api.HookMemberAdded,
+		Member:     convert.ToUser(ctx, collaborator, nil),
+		Permission: mode.ToString(),
+	})
+}
+
+func (m *webhookNotifier) ChangeCollaboratorAccessMode(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User, oldMode, mode perm.AccessMode) {
+	notifyMember(ctx, doer, repo, &api.MemberPayload{
+		Action:             api.HookMemberEdited,
+		Member:             convert.ToUser(ctx, collaborator, nil),
+		Permission:         mode.ToString(),
+		PreviousPermission: oldMode.ToString(),
+	})
+}
+
+func (m *webhookNotifier) RemoveCollaborator(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, collaborator *user_model.User) {
+	notifyMember(ctx, doer, repo, &api.MemberPayload{
+		Action: api.HookMemberRemoved,
+		Member: convert.ToUser(ctx, collaborator, nil),
+	})
+}
+
+func notifyMember(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, payload *api.MemberPayload) {
+	payload.Repository = convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner})
+	payload.Sender = toSender(ctx, doer)
+
+	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventMember, payload); err != nil {
+		log.Error("PrepareWebhooks [repo_id: %d]: %v", repo.ID, err)
+	}
+}
+
+func (m *webhookNotifier) CreateBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	notifyBranchProtection(ctx, doer, repo, rule, api.HookBranchProtectionCreated)
+}
+
+func (m *webhookNotifier) UpdateBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	notifyBranchProtection(ctx, doer, repo, rule, api.HookBranchProtectionEdited)
+}
+
+func (m *webhookNotifier) DeleteBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch) {
+	notifyBranchProtection(ctx, doer, repo, rule, api.HookBranchProtectionDeleted)
+}
+
+func notifyBranchProtection(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, rule *git_model.ProtectedBranch, action api.HookBranchProtectionAction) {
+	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventBranchProtection, &api.BranchProtectionPayload{
+		Action:     action,
+		Rule:       convert.ToBranchProtection(ctx, rule, repo),
+		Repository: convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
+		Sender:     toSender(ctx, doer),
+	}); err != nil {
+		log.Error("PrepareWebhooks [repo_id: %d]: %v", repo.ID, err)
+	}
+}
+
+func (m *webhookNotifier) AddDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey) {
+	notifyDeployKey(ctx, doer, repo, key, api.HookDeployKeyCreated)
+}
+
+func (m *webhookNotifier) DeleteDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey) {
+	notifyDeployKey(ctx, doer, repo, key, api.HookDeployKeyDeleted)
+}
+
+func notifyDeployKey(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, key *asymkey_model.DeployKey, action api.HookDeployKeyAction) {
+	if err := PrepareWebhooks(ctx, EventSource{Repository: repo}, webhook_module.HookEventDeployKey, &api.DeployKeyPayload{
+		Action:     action,
+		Key:        convert.ToDeployKey(repo.APIURL()+"/keys/", key),
+		Repository: convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner}),
+		Sender:     toSender(ctx, doer),
+	}); err != nil {
+		log.Error("PrepareWebhooks [repo_id: %d]: %v", repo.ID, err)
+	}
+}
+
+func (m *webhookNotifier) AddOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+	notifyOrganization(ctx, doer, org, member, api.HookOrganizationMemberAdded)
+}
+
+func (m *webhookNotifier) RemoveOrgMember(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User) {
+	notifyOrganization(ctx, doer, org, member, api.HookOrganizationMemberRemoved)
+}
+
+func notifyOrganization(ctx context.Context, doer *user_model.User, org *organization.Organization, member *user_model.User, action api.HookOrganizationAction) {
+	if err := PrepareWebhooks(ctx, EventSource{Owner: org.AsUser()}, webhook_module.HookEventOrganization, &api.OrganizationPayload{
+		Action:       action,
+		Organization: convert.ToOrganization(ctx, org),
+		Member:       convert.ToUser(ctx, member, nil),
+		Sender:       toSender(ctx, doer),
+	}); err != nil {
+		log.Error("PrepareWebhooks [org_id: %d]: %v", org.ID, err)
+	}
+}
+
+func (m *webhookNotifier) CreateTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+	notifyTeam(ctx, doer, team, nil, api.HookTeamCreated)
+}
+
+func (m *webhookNotifier) UpdateTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+	notifyTeam(ctx, doer, team, nil, api.HookTeamEdited)
+}
+
+func (m *webhookNotifier) DeleteTeam(ctx context.Context, doer *user_model.User, team *organization.Team) {
+	notifyTeam(ctx, doer, team, nil, api.HookTeamDeleted)
+}
+
+func (m *webhookNotifier) AddTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	notifyTeam(ctx, doer, team, repo, api.HookTeamAddedToRepository)
+}
+
+func (m *webhookNotifier) RemoveTeamRepository(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository) {
+	notifyTeam(ctx, doer, team, repo, api.HookTeamRemovedFromRepository)
+}
+
+func notifyTeam(ctx context.Context, doer *user_model.User, team *organization.Team, repo *repo_model.Repository, action api.HookTeamAction) {
+	org, err := organization.GetOrgByID(ctx, team.OrgID)
+	if err != nil {
+		log.Error("GetOrgByID: %v", err)
+		return
+	}
+
+	apiTeam, err := convert.ToTeam(ctx, team)
+	if err != nil {
+		log.Error("ToTeam: %v", err)
+		return
+	}
+
+	source := EventSource{Owner: org.AsUser()}
+	payload := &api.TeamPayload{
+		Action:       action,
+		Team:         apiTeam,
+		Organization: convert.ToOrganization(ctx, org),
+		Sender:       toSender(ctx, doer),
+	}
+	if repo != nil {
+		source.Repository = repo
+		payload.Repository = convert.ToRepo(ctx, repo, access_model.Permission{AccessMode: perm.AccessModeOwner})
+	}
+
+	if err := PrepareWebhooks(ctx, source, webhook_module.HookEventTeam, payload); err != nil {
+		log.Error("PrepareWebhooks [team_id: %d]: %v", team.ID, err)
+	}
+}
+
+func (m *webhookNotifier) AddTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	notifyMembership(ctx, doer, team, member, api.HookMembershipAdded)
+}
+
+func (m *webhookNotifier) RemoveTeamMember(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User) {
+	notifyMembership(ctx, doer, team, member, api.HookMembershipRemoved)
+}
+
+func notifyMembership(ctx context.Context, doer *user_model.User, team *organization.Team, member *user_model.User, action api.HookMembershipAction) {
+	org, err := organization.GetOrgByID(ctx, team.OrgID)
+	if err != nil {
+		log.Error("GetOrgByID: %v", err)
+		return
+	}
+
+	apiTeam, err := convert.ToTeam(ctx, team)
+	if err != nil {
+		log.Error("ToTeam: %v", err)
+		return
+	}
+
+	if err := PrepareWebhooks(ctx, EventSource{Owner: org.AsUser()}, webhook_module.HookEventMembership, &api.MembershipPayload{
+		Action:       action,
+		Team:         apiTeam,
+		Organization: convert.ToOrganization(ctx, org),
+		Member:       convert.ToUser(ctx, member, nil),
+		Sender:       toSender(ctx, doer),
+	}); err != nil {
+		log.Error("PrepareWebhooks [team_id: %d]: %v", team.ID, err)
+	}
+}
+
+func (m *webhookNotifier) CreateUser(ctx context.Context, doer, u *user_model.User) {
+	notifyUser(ctx, doer, u, api.HookUserCreated)
+}
+
+func (m *webhookNotifier) ChangeUserSuspension(ctx context.Context, doer, u *user_model.User, suspended bool) {
+	action := api.HookUserSuspended
+	if !suspended {
+		action = api.HookUserUnsuspended
+	}
+	notifyUser(ctx, doer, u, action)
+}
+
+func notifyUser(ctx context.Context, doer, u *user_model.User, action api.HookUserAction) {
+	// user events have no repository or owner, so they are only sent to system webhooks which are managed by admins
+	if err := PrepareWebhooks(ctx, EventSource{}, webhook_module.HookEventUser, &api.UserPayload{
+		Action: action,
+		User:   convert.ToUser(ctx, u, u),
+		Sender: toSender(ctx, doer),
+	}); err != nil {
+		log.Error("PrepareWebhooks [user_id: %d]: %v", u.ID, err)
+	}
+}
@@ -110,6 +110,46 @@ func (pc packagistConvertor) Package(_ *api.PackagePayload) (PackagistPayload, e
 	return PackagistPayload{}, nil
 }
 
+func (pc packagistConvertor) PackageVulnerability(_ *api.PackageVulnerabilityPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Member(_ *api.MemberPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Organization(_ *api.OrganizationPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Team(_ *api.TeamPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Membership(_ *api.MembershipPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) BranchProtection(_ *api.BranchProtectionPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) DeployKey(_ *api.DeployKeyPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) User(_ *api.UserPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Star(_ *api.StarPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
+func (pc packagistConvertor) Watch(_ *api.WatchPayload) (PackagistPayload, error) {
+	return PackagistPayload{}, nil
+}
+
 func (pc packagistConvertor) Status(_ *api.CommitStatusPayload) (PackagistPayload, error) {
 	return PackagistPayload{}, nil
 }
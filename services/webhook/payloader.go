@@ -28,6 +28,16 @@ type payloadConvertor[T any] interface {
 	Release(*api.ReleasePayload) (T, error)
 	Wiki(*api.WikiPayload) (T, error)
 	Package(*api.PackagePayload) (T, error)
+	PackageVulnerability(*api.PackageVulnerabilityPayload) (T, error)
+	Member(*api.MemberPayload) (T, error)
+	Organization(*api.OrganizationPayload) (T, error)
+	Team(*api.TeamPayload) (T, error)
+	Membership(*api.MembershipPayload) (T, error)
+	BranchProtection(*api.BranchProtectionPayload) (T, error)
+	DeployKey(*api.DeployKeyPayload) (T, error)
+	User(*api.UserPayload) (T, error)
+	Star(*api.StarPayload) (T, error)
+	Watch(*api.WatchPayload) (T, error)
 	Status(*api.CommitStatusPayload) (T, error)
 	WorkflowRun(*api.WorkflowRunPayload) (T, error)
 	WorkflowJob(*api.WorkflowJobPayload) (T, error)
@@ -72,7 +82,7 @@ func newPayload[T any](rc payloadConvertor[T], data []byte, event webhook_module
 		return convertUnmarshalledJSON(func(p *api.PullRequestPayload) (T, error) {
 			return rc.Review(p, event)
 		}, data)
-	case webhook_module.HookEventRepository:
+	case webhook_module.HookEventRepository, webhook_module.HookEventRepositoryVisibility:
 		return convertUnmarshalledJSON(rc.Repository, data)
 	case webhook_module.HookEventRelease:
 		return convertUnmarshalledJSON(rc.Release, data)
@@ -80,8 +90,28 @@ func newPayload[T any](rc payloadConvertor[T], data []byte, event webhook_module
 		return convertUnmarshalledJSON(rc.Wiki, data)
 	case webhook_module.HookEventPackage:
 		return convertUnmarshalledJSON(rc.Package, data)
+	case webhook_module.HookEventPackageVulnerability:
+		return convertUnmarshalledJSON(rc.PackageVulnerability, data)
 	case webhook_module.HookEventStatus:
 		return convertUnmarshalledJSON(rc.Status, data)
+	case webhook_module.HookEventMember:
+		return convertUnmarshalledJSON(rc.Member, data)
+	case webhook_module.HookEventOrganization:
+		return convertUnmarshalledJSON(rc.Organization, data)
+	case webhook_module.HookEventTeam:
+		return convertUnmarshalledJSON(rc.Team, data)
+	case webhook_module.HookEventMembership:
+		return convertUnmarshalledJSON(rc.Membership, data)
+	case webhook_module.HookEventBranchProtection:
+		return convertUnmarshalledJSON(rc.BranchProtection, data)
+	case webhook_module.HookEventDeployKey:
+		return convertUnmarshalledJSON(rc.DeployKey, data)
+	case webhook_module.HookEventUser:
+		return convertUnmarshalledJSON(rc.User, data)
+	case webhook_module.HookEventStar:
+		return convertUnmarshalledJSON(rc.Star, data)
+	case webhook_module.HookEventWatch:
+		return convertUnmarshalledJSON(rc.Watch, data)
 	case webhook_module.HookEventWorkflowRun:
 		return convertUnmarshalledJSON(rc.WorkflowRun, data)
 	case webhook_module.HookEventWorkflowJob:
@@ -0,0 +1,105 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/services/mailer"
+)
+
+// retryDelay returns the delay before the given attempt, it doubles with every attempt up to RETRY_MAX_BACKOFF
+func retryDelay(attempt int) time.Duration {
+	delay := setting.Webhook.RetryBackoff
+	for i := 2; i < attempt && delay < setting.Webhook.RetryMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, setting.Webhook.RetryMaxBackoff)
+}
+
+// retryHookTask creates the next attempt of a failed task or marks the task as exhausted if there are no attempts left
+func retryHookTask(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) {
+	if t.Attempt >= w.DeliveryAttempts() {
+		t.IsExhausted = true
+		return
+	}
+
+	scheduled := timeutil.TimeStamp(time.Now().Add(retryDelay(t.Attempt + 1)).Unix())
+	retry, err := webhook_model.CreateRetryHookTask(ctx, t, scheduled)
+	if err != nil {
+		log.Error("CreateRetryHookTask[%d]: %v", t.ID, err)
+		t.IsExhausted = true
+		return
+	}
+
+	log.Trace("Hook delivery %s will be retried at %v (attempt %d)", t.UUID, scheduled.AsTime(), retry.Attempt)
+	scheduleHookTask(retry)
+}
+
+// scheduleHookTask enqueues the task once it is due
+func scheduleHookTask(t *webhook_model.HookTask) {
+	delay := time.Until(t.ScheduledUnix.AsTime())
+	if delay <= 0 {
+		if err := enqueueHookTask(t.ID); err != nil {
+			log.Error("Unable to push HookTask[%d] to the Webhook Sending queue: %v", t.ID, err)
+		}
+		return
+	}
+
+	// pending timers get lost on shutdown, the undelivered tasks are enqueued again on startup
+	time.AfterFunc(delay, func() {
+		if err := enqueueHookTask(t.ID); err != nil {
+			log.Error("Unable to push HookTask[%d] to the Webhook Sending queue: %v", t.ID, err)
+		}
+	})
+}
+
+// updateConsecutiveFailures keeps track of the failed deliveries of the webhook
+// and deactivates it once AUTO_DISABLE_THRESHOLD deliveries failed in a row
+func updateConsecutiveFailures(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) {
+	if t.IsSucceed {
+		if w.ConsecutiveFailures > 0 {
+			if err := webhook_model.ResetWebhookConsecutiveFailures(ctx, w.ID); err != nil {
+				log.Error("ResetWebhookConsecutiveFailures[%d]: %v", w.ID, err)
+			}
+			w.ConsecutiveFailures = 0
+		}
+		return
+	}
+	if !t.IsExhausted {
+		return
+	}
+
+	failures, err := webhook_model.IncreaseWebhookConsecutiveFailures(ctx, w.ID)
+	if err != nil {
+		log.Error("IncreaseWebhookConsecutiveFailures[%d]: %v", w.ID, err)
+		return
+	}
+	w.ConsecutiveFailures = failures
+
+	if setting.Webhook.AutoDisableThreshold <= 0 || failures < setting.Webhook.AutoDisableThreshold {
+		return
+	}
+
+	disabled, err := webhook_model.DisableWebhook(ctx, w.ID)
+	if err != nil {
+		log.Error("DisableWebhook[%d]: %v", w.ID, err)
+		return
+	}
+	if !disabled {
+		return
+	}
+	w.IsActive = false
+
+	log.Warn("Webhook[%d] has been deactivated after %d consecutive failed deliveries", w.ID, failures)
+
+	if err := mailer.SendWebhookDisabledMail(ctx, w, failures); err != nil {
+		log.Error("SendWebhookDisabledMail[%d]: %v", w.ID, err)
+	}
+}
@@ -167,6 +167,66 @@ func (s slackConvertor) Package(p *api.PackagePayload) (SlackPayload, error) {
 	return s.createPayload(text, nil), nil
 }
 
+func (s slackConvertor) PackageVulnerability(p *api.PackageVulnerabilityPayload) (SlackPayload, error) {
+	text, _ := getPackageVulnerabilityPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Member(p *api.MemberPayload) (SlackPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Organization(p *api.OrganizationPayload) (SlackPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Team(p *api.TeamPayload) (SlackPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Membership(p *api.MembershipPayload) (SlackPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) BranchProtection(p *api.BranchProtectionPayload) (SlackPayload, error) {
+	text, _, _ := getBranchProtectionPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) DeployKey(p *api.DeployKeyPayload) (SlackPayload, error) {
+	text, _, _ := getDeployKeyPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) User(p *api.UserPayload) (SlackPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Star(p *api.StarPayload) (SlackPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
+func (s slackConvertor) Watch(p *api.WatchPayload) (SlackPayload, error) {
+	text, _, _ := getWatchPayloadInfo(p, SlackLinkFormatter, true)
+
+	return s.createPayload(text, nil), nil
+}
+
 func (s slackConvertor) Status(p *api.CommitStatusPayload) (SlackPayload, error) {
 	text, _ := getStatusPayloadInfo(p, SlackLinkFormatter, true)
 
@@ -277,6 +337,10 @@ func (s slackConvertor) Repository(p *api.RepositoryPayload) (SlackPayload, erro
 		text = fmt.Sprintf("[%s] Repository created by %s", repoLink, senderLink)
 	case api.HookRepoDeleted:
 		text = fmt.Sprintf("[%s] Repository deleted by %s", repoLink, senderLink)
+	case api.HookRepoPublicized:
+		text = fmt.Sprintf("[%s] Repository made public by %s", repoLink, senderLink)
+	case api.HookRepoPrivatized:
+		text = fmt.Sprintf("[%s] Repository made private by %s", repoLink, senderLink)
 	}
 
 	return s.createPayload(text, nil), nil
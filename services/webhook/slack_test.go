@@ -125,6 +125,15 @@ func TestSlackPayload(t *testing.T) {
 		assert.Equal(t, "Package created: <http://localhost:3000/user1/-/packages/container/GiteaContainer/latest|GiteaContainer:latest> by <https://try.gitea.io/user1|user1>", pl.Text)
 	})
 
+	t.Run("Member", func(t *testing.T) {
+		p := memberTestPayload()
+
+		pl, err := sc.Member(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[<http://localhost:3000/test/repo|test/repo>] Collaborator <https://try.gitea.io/user2|user2> added with admin permission by <https://try.gitea.io/user1|user1>", pl.Text)
+	})
+
 	t.Run("Wiki", func(t *testing.T) {
 		p := wikiTestPayload()
 
@@ -150,6 +150,12 @@ func (t telegramConvertor) Repository(p *api.RepositoryPayload) (TelegramPayload
 	case api.HookRepoDeleted:
 		title = fmt.Sprintf("[%s] Repository deleted", html.EscapeString(p.Repository.FullName))
 		return createTelegramPayloadHTML(title), nil
+	case api.HookRepoPublicized:
+		title = fmt.Sprintf(`[%s] Repository made public`, htmlLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName))
+		return createTelegramPayloadHTML(title), nil
+	case api.HookRepoPrivatized:
+		title = fmt.Sprintf(`[%s] Repository made private`, htmlLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName))
+		return createTelegramPayloadHTML(title), nil
 	}
 	return TelegramPayload{}, nil
 }
@@ -174,6 +180,66 @@ func (t telegramConvertor) Package(p *api.PackagePayload) (TelegramPayload, erro
 	return createTelegramPayloadHTML(text), nil
 }
 
+func (t telegramConvertor) PackageVulnerability(p *api.PackageVulnerabilityPayload) (TelegramPayload, error) {
+	text, _ := getPackageVulnerabilityPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) Member(p *api.MemberPayload) (TelegramPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) Organization(p *api.OrganizationPayload) (TelegramPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) Team(p *api.TeamPayload) (TelegramPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) Membership(p *api.MembershipPayload) (TelegramPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) BranchProtection(p *api.BranchProtectionPayload) (TelegramPayload, error) {
+	text, _, _ := getBranchProtectionPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) DeployKey(p *api.DeployKeyPayload) (TelegramPayload, error) {
+	text, _, _ := getDeployKeyPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) User(p *api.UserPayload) (TelegramPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) Star(p *api.StarPayload) (TelegramPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
+func (t telegramConvertor) Watch(p *api.WatchPayload) (TelegramPayload, error) {
+	text, _, _ := getWatchPayloadInfo(p, htmlLinkFormatter, true)
+
+	return createTelegramPayloadHTML(text), nil
+}
+
 func (t telegramConvertor) Status(p *api.CommitStatusPayload) (TelegramPayload, error) {
 	text, _ := getStatusPayloadInfo(p, htmlLinkFormatter, true)
 
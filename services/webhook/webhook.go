@@ -21,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 )
@@ -95,6 +96,12 @@ func handler(items ...int64) []int64 {
 			continue
 		}
 
+		if task.ScheduledUnix > timeutil.TimeStampNow() {
+			// A retry which is not due yet, e.g. enqueued again on startup
+			scheduleHookTask(task)
+			continue
+		}
+
 		if err := Deliver(ctx, task); err != nil {
 			log.Error("Unable to deliver webhook task[%d]: %v", task.ID, err)
 		}
@@ -104,6 +111,10 @@ func handler(items ...int64) []int64 {
 }
 
 func enqueueHookTask(taskID int64) error {
+	if hookQueue == nil {
+		// not initialized outside the web server (e.g. doctor or admin commands), the task is queued on the next start
+		return nil
+	}
 	err := hookQueue.Push(taskID)
 	if err != nil && err != queue.ErrAlreadyInQueue {
 		return err
@@ -163,6 +174,10 @@ func PrepareWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook
 		return fmt.Errorf("JSONPayload for %s: %w", event, err)
 	}
 
+	if !checkCondition(w.Condition, event, p, payload) {
+		return nil
+	}
+
 	task, err := webhook_model.CreateHookTask(ctx, &webhook_model.HookTask{
 		HookID:         w.ID,
 		PayloadContent: string(payload),
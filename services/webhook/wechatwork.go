@@ -150,6 +150,12 @@ func (wc wechatworkConvertor) Repository(p *api.RepositoryPayload) (WechatworkPa
 	case api.HookRepoDeleted:
 		title = fmt.Sprintf("[%s] Repository deleted", p.Repository.FullName)
 		return newWechatworkMarkdownPayload(title), nil
+	case api.HookRepoPublicized:
+		title = fmt.Sprintf("[%s] Repository made public", p.Repository.FullName)
+		return newWechatworkMarkdownPayload(title), nil
+	case api.HookRepoPrivatized:
+		title = fmt.Sprintf("[%s] Repository made private", p.Repository.FullName)
+		return newWechatworkMarkdownPayload(title), nil
 	}
 
 	return WechatworkPayload{}, nil
@@ -175,6 +181,66 @@ func (wc wechatworkConvertor) Package(p *api.PackagePayload) (WechatworkPayload,
 	return newWechatworkMarkdownPayload(text), nil
 }
 
+func (wc wechatworkConvertor) PackageVulnerability(p *api.PackageVulnerabilityPayload) (WechatworkPayload, error) {
+	text, _ := getPackageVulnerabilityPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Member(p *api.MemberPayload) (WechatworkPayload, error) {
+	text, _, _ := getMemberPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Organization(p *api.OrganizationPayload) (WechatworkPayload, error) {
+	text, _, _ := getOrganizationPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Team(p *api.TeamPayload) (WechatworkPayload, error) {
+	text, _, _ := getTeamPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Membership(p *api.MembershipPayload) (WechatworkPayload, error) {
+	text, _, _ := getMembershipPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) BranchProtection(p *api.BranchProtectionPayload) (WechatworkPayload, error) {
+	text, _, _ := getBranchProtectionPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) DeployKey(p *api.DeployKeyPayload) (WechatworkPayload, error) {
+	text, _, _ := getDeployKeyPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) User(p *api.UserPayload) (WechatworkPayload, error) {
+	text, _, _ := getUserPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Star(p *api.StarPayload) (WechatworkPayload, error) {
+	text, _, _ := getStarPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
+func (wc wechatworkConvertor) Watch(p *api.WatchPayload) (WechatworkPayload, error) {
+	text, _, _ := getWatchPayloadInfo(p, noneLinkFormatter, true)
+
+	return newWechatworkMarkdownPayload(text), nil
+}
+
 func (wc wechatworkConvertor) Status(p *api.CommitStatusPayload) (WechatworkPayload, error) {
 	text, _ := getStatusPayloadInfo(p, noneLinkFormatter, true)
 
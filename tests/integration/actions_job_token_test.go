@@ -7,11 +7,13 @@ import (
 	"encoding/base64"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	auth_model "code.gitea.io/gitea/models/auth"
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/perm"
 	"code.gitea.io/gitea/models/unittest"
 	"code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/util"
@@ -115,3 +117,40 @@ func TestActionsJobTokenAccessLFS(t *testing.T) {
 		}))
 	})
 }
+
+func TestActionsJobTokenPackageAccess(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+		require.NoError(t, task.GenerateToken())
+		task.Status = actions_model.StatusRunning
+		task.OwnerID = 5
+		err := actions_model.UpdateTask(t.Context(), task, "token_hash", "token_salt", "token_last_eight", "status", "owner_id")
+		require.NoError(t, err)
+
+		uploadPackage := func(t *testing.T, version string, expectedStatus int) {
+			req := NewRequestWithBody(t, "PUT", "/api/packages/user5/generic/test-package/"+version+"/file.bin", strings.NewReader("content")).
+				AddTokenAuth(task.Token)
+			MakeRequest(t, req, expectedStatus)
+		}
+
+		job := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionRunJob{ID: task.JobID})
+		require.Nil(t, job.TokenPermissions)
+		t.Run("Undeclared Permissions", func(t *testing.T) {
+			uploadPackage(t, "1.0.0", http.StatusUnauthorized)
+		})
+
+		job.TokenPermissions = actions_model.TokenPermissions{actions_model.TokenScopePackages: perm.AccessModeWrite}
+		_, err = actions_model.UpdateRunJob(t.Context(), job, nil, "token_permissions")
+		require.NoError(t, err)
+		t.Run("Declared Write Permission", func(t *testing.T) {
+			uploadPackage(t, "1.0.0", http.StatusCreated)
+		})
+
+		job.TokenPermissions = actions_model.TokenPermissions{actions_model.TokenScopePackages: perm.AccessModeRead}
+		_, err = actions_model.UpdateRunJob(t.Context(), job, nil, "token_permissions")
+		require.NoError(t, err)
+		t.Run("Declared Read Permission", func(t *testing.T) {
+			uploadPackage(t, "1.0.1", http.StatusUnauthorized)
+		})
+	})
+}
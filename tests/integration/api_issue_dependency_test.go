@@ -67,7 +67,7 @@ func TestAPICreateIssueDependencyCrossRepoPermission(t *testing.T) {
 	})
 
 	// add user40 as a collaborator to dependency repository with read permission
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), dependencyRepo, user40, perm.AccessModeRead))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, dependencyRepo, user40, perm.AccessModeRead))
 
 	// try again after getting read permission to dependency repository
 	req = NewRequestWithJSON(t, "POST", url, dependencyMeta).
@@ -79,7 +79,7 @@ func TestAPICreateIssueDependencyCrossRepoPermission(t *testing.T) {
 	})
 
 	// add user40 as a collaborator to target repository with write permission
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), targetRepo, user40, perm.AccessModeWrite))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, targetRepo, user40, perm.AccessModeWrite))
 
 	req = NewRequestWithJSON(t, "POST", url, dependencyMeta).
 		AddTokenAuth(writerToken)
@@ -128,7 +128,7 @@ func TestAPIDeleteIssueDependencyCrossRepoPermission(t *testing.T) {
 	})
 
 	// add user40 as a collaborator to dependency repository with read permission
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), dependencyRepo, user40, perm.AccessModeRead))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, dependencyRepo, user40, perm.AccessModeRead))
 
 	// try again after getting read permission to dependency repository
 	req = NewRequestWithJSON(t, "DELETE", url, dependencyMeta).
@@ -140,7 +140,7 @@ func TestAPIDeleteIssueDependencyCrossRepoPermission(t *testing.T) {
 	})
 
 	// add user40 as a collaborator to target repository with write permission
-	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), targetRepo, user40, perm.AccessModeWrite))
+	assert.NoError(t, repo_service.AddOrUpdateCollaborator(t.Context(), nil, targetRepo, user40, perm.AccessModeWrite))
 
 	req = NewRequestWithJSON(t, "DELETE", url, dependencyMeta).
 		AddTokenAuth(writerToken)
@@ -0,0 +1,317 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestPackageHex(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "gitea_test"
+	packageVersion := "1.0.2"
+	packageDescription := "Test Description"
+
+	createPackage := func(version string) ([]byte, string) {
+		metadata := fmt.Sprintf(`{<<"name">>,<<"%s">>}.
+{<<"version">>,<<"%s">>}.
+{<<"app">>,<<"%s">>}.
+{<<"description">>,<<"%s">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"https://gitea.io/">>}]}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"requirements">>,[[{<<"name">>,<<"jason">>},{<<"app">>,<<"jason">>},{<<"optional">>,false},{<<"requirement">>,<<"~> 1.4">>},{<<"repository">>,<<"hexpm">>}]]}.
+`, packageName, version, packageName, packageDescription)
+		contents := []byte("contents " + version)
+
+		h := sha256.New()
+		h.Write([]byte(hex_module.TarballVersion))
+		h.Write([]byte(metadata))
+		h.Write(contents)
+		innerChecksum := hex.EncodeToString(h.Sum(nil))
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, f := range []struct {
+			Name    string
+			Content []byte
+		}{
+			{"VERSION", []byte(hex_module.TarballVersion)},
+			{"CHECKSUM", []byte(innerChecksum)},
+			{"metadata.config", []byte(metadata)},
+			{"contents.tar.gz", contents},
+		} {
+			tw.WriteHeader(&tar.Header{
+				Name: f.Name,
+				Mode: 0o600,
+				Size: int64(len(f.Content)),
+			})
+			tw.Write(f.Content)
+		}
+		tw.Close()
+
+		return buf.Bytes(), innerChecksum
+	}
+
+	content, innerChecksum := createPackage(packageVersion)
+	outerChecksum := sha256.Sum256(content)
+
+	root := fmt.Sprintf("/api/packages/%s/hex", user.Name)
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := root + "/api/publish"
+
+		req := NewRequestWithBody(t, "POST", url, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader([]byte("invalid"))).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		// Hex clients send the API key without authorization scheme
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content))
+		req.Header.Set("Authorization", token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var result struct {
+			Version  string `json:"version"`
+			Checksum string `json:"checksum"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, packageVersion, result.Version)
+		assert.Equal(t, hex.EncodeToString(outerChecksum[:]), result.Checksum)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		assert.NoError(t, err)
+		assert.IsType(t, &hex_module.Metadata{}, pd.Metadata)
+		metadata := pd.Metadata.(*hex_module.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+		assert.Equal(t, packageDescription, metadata.Description)
+		assert.Equal(t, innerChecksum, metadata.InnerChecksum)
+		assert.Len(t, pd.Files, 1)
+		assert.Equal(t, fmt.Sprintf("%s-%s.tar", packageName, packageVersion), pd.Files[0].File.Name)
+		assert.True(t, pd.Files[0].File.IsLead)
+
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequestWithBody(t, "POST", url+"?replace=true", bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		content, _ := createPackage("1.1.0")
+		req = NewRequestWithBody(t, "POST", url, bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	readFields := func(t *testing.T, b []byte) map[protowire.Number][][]byte {
+		fields := make(map[protowire.Number][][]byte)
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+
+			var value []byte
+			switch typ {
+			case protowire.BytesType:
+				value, n = protowire.ConsumeBytes(b)
+			case protowire.VarintType:
+				var v uint64
+				v, n = protowire.ConsumeVarint(b)
+				value = protowire.AppendVarint(nil, v)
+			default:
+				t.Fatalf("unexpected wire type %v", typ)
+			}
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+
+			fields[num] = append(fields[num], value)
+		}
+		return fields
+	}
+
+	readResource := func(t *testing.T, url string) map[protowire.Number][][]byte {
+		req := NewRequest(t, "GET", root+"/public_key")
+		resp := MakeRequest(t, req, http.StatusOK)
+		block, _ := pem.Decode(resp.Body.Bytes())
+		require.NotNil(t, block)
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+
+		req = NewRequest(t, "GET", url)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		zr, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		signed, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		fields := readFields(t, signed)
+		require.Len(t, fields[1], 1)
+		require.Len(t, fields[2], 1)
+
+		hash := sha512.Sum512(fields[1][0])
+		require.NoError(t, rsa.VerifyPKCS1v15(publicKey.(*rsa.PublicKey), crypto.SHA512, hash[:], fields[2][0]))
+
+		payload := readFields(t, fields[1][0])
+		return payload
+	}
+
+	t.Run("Names", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		names := readResource(t, root+"/names")
+		assert.Equal(t, [][]byte{[]byte(user.LowerName)}, names[2])
+		require.Len(t, names[1], 1)
+		assert.Equal(t, [][]byte{[]byte(packageName)}, readFields(t, names[1][0])[1])
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		versions := readResource(t, root+"/versions")
+		assert.Equal(t, [][]byte{[]byte(user.LowerName)}, versions[2])
+		require.Len(t, versions[1], 1)
+
+		pkg := readFields(t, versions[1][0])
+		assert.Equal(t, [][]byte{[]byte(packageName)}, pkg[1])
+		assert.Equal(t, [][]byte{[]byte(packageVersion), []byte("1.1.0")}, pkg[2])
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/packages/unknown")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		pkg := readResource(t, root+"/packages/"+packageName)
+		assert.Equal(t, [][]byte{[]byte(packageName)}, pkg[2])
+		assert.Equal(t, [][]byte{[]byte(user.LowerName)}, pkg[3])
+		require.Len(t, pkg[1], 2)
+
+		release := readFields(t, pkg[1][0])
+		assert.Equal(t, [][]byte{[]byte(packageVersion)}, release[1])
+		expectedInner, _ := hex.DecodeString(innerChecksum)
+		assert.Equal(t, [][]byte{expectedInner}, release[2])
+		assert.Equal(t, [][]byte{outerChecksum[:]}, release[5])
+		require.Len(t, release[3], 1)
+
+		dep := readFields(t, release[3][0])
+		assert.Equal(t, [][]byte{[]byte("jason")}, dep[1])
+		assert.Equal(t, [][]byte{[]byte("~> 1.4")}, dep[2])
+		assert.Equal(t, [][]byte{[]byte("hexpm")}, dep[5])
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-%s.tar", root, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-0.0.1.tar", root, packageName))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("PackageInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/api/packages/"+packageName)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			Name       string `json:"name"`
+			Repository string `json:"repository"`
+			Releases   []struct {
+				Version string `json:"version"`
+			} `json:"releases"`
+			Meta struct {
+				Description string            `json:"description"`
+				Licenses    []string          `json:"licenses"`
+				Links       map[string]string `json:"links"`
+			} `json:"meta"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, packageName, result.Name)
+		assert.Equal(t, user.LowerName, result.Repository)
+		assert.Len(t, result.Releases, 2)
+		assert.Equal(t, packageDescription, result.Meta.Description)
+		assert.Equal(t, []string{"MIT"}, result.Meta.Licenses)
+		assert.Equal(t, map[string]string{"GitHub": "https://gitea.io/"}, result.Meta.Links)
+
+		req = NewRequest(t, "GET", root+"/api/packages/"+packageName).
+			SetHeader("Accept", hex_module.ContentTypeErlang)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, hex_module.ContentTypeErlang, resp.Header().Get("Content-Type"))
+		assert.Equal(t, byte(131), resp.Body.Bytes()[0])
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/hex/%s/%s", user.Name, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "mix hex.repo add "+user.LowerName)
+		assert.Contains(t, resp.Body.String(), packageDescription)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := fmt.Sprintf("%s/api/packages/%s/releases/%s", root, packageName, packageVersion)
+
+		req := NewRequest(t, "DELETE", url)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequest(t, "DELETE", url).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", url).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		versions := readResource(t, root+"/versions")
+		require.Len(t, versions[1], 1)
+		assert.Equal(t, [][]byte{[]byte("1.1.0")}, readFields(t, versions[1][0])[2])
+	})
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	nix_module "code.gitea.io/gitea/modules/packages/nix"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageNix(t *testing.T) {
+	onGiteaRun(t, testPackageNix)
+}
+
+func testPackageNix(t *testing.T, _ *neturl.URL) {
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	storeHash := "0c7x8w5d1y3b7wq1y2x0w2z6v7r9h5ng"
+	storeName := "hello-2.12.1"
+	storePath := nix_module.StoreDir + "/" + storeHash + "-" + storeName
+
+	narContent := []byte("compressed nar content")
+	sum := sha256.Sum256(narContent)
+	narFileHash := nix_module.EncodeToString(sum[:])
+	narFilename := narFileHash + ".nar.zst"
+
+	narInfo := fmt.Sprintf(`StorePath: %s
+URL: nar/%s
+Compression: zstd
+FileHash: sha256:%s
+FileSize: %d
+NarHash: sha256:1b8m03r63zqhnjf7l5wnldhh7c134ap5vpj0850ymkq1iyzicy5s
+NarSize: 226560
+References: %s-%s 9df65igwjmf2wbw0gbrrgair6piqjgmi-glibc-2.40
+Deriver: q3j4z1ybqs3r0yiiqz1a5xfy8jj3jdqi-hello-2.12.1.drv
+System: x86_64-linux
+`, storePath, narFilename, narFileHash, len(narContent), storeHash, storeName)
+
+	root := fmt.Sprintf("/api/packages/%s/nix", user.Name)
+
+	t.Run("CacheInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/nix-cache-info")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		assert.Equal(t, "StoreDir: /nix/store\nWantMassQuery: 1\nPriority: 50\n", resp.Body.String())
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		narURL := root + "/nar/" + narFilename
+		narInfoURL := fmt.Sprintf("%s/%s.narinfo", root, storeHash)
+
+		req := NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narURL, bytes.NewReader(narContent))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "PUT", root+"/nar/"+strings.Repeat("0", 52)+".nar.zst", bytes.NewReader(narContent)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narURL, bytes.NewReader(narContent)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", narInfoURL)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/%s.narinfo", root, strings.Repeat("0", 32)), strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeNix)
+		assert.NoError(t, err)
+		assert.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		assert.NoError(t, err)
+		assert.IsType(t, &nix_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, storeName, pd.Package.Name)
+		assert.Equal(t, storeHash, pd.Version.Version)
+		assert.Equal(t, storePath, pd.Metadata.(*nix_module.Metadata).StorePath)
+		assert.Len(t, pd.Files, 1)
+		assert.Equal(t, narFilename, pd.Files[0].File.Name)
+		assert.True(t, pd.Files[0].File.IsLead)
+
+		uploadVersion, err := packages.GetInternalVersionByNameAndVersion(t.Context(), user.ID, packages.TypeNix, nix_module.UploadPackageName, nix_module.UploadVersion)
+		assert.NoError(t, err)
+		pfs, err := packages.GetFilesByVersionID(t.Context(), uploadVersion.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, pfs)
+
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	verifySignature := func(t *testing.T) {
+		req := NewRequest(t, "GET", root+"/public-key")
+		resp := MakeRequest(t, req, http.StatusOK)
+		keyName, publicKey, err := nix_module.SplitKey(resp.Body.String())
+		require.NoError(t, err)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/%s.narinfo", root, storeHash))
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		m, err := nix_module.ParseNarInfo(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, storePath, m.StorePath)
+		assert.Equal(t, "nar/"+narFilename, m.URL)
+		assert.Equal(t, "x86_64-linux", m.System)
+		require.Len(t, m.Signatures, 1)
+
+		sigName, sig, err := nix_module.SplitKey(m.Signatures[0])
+		require.NoError(t, err)
+		assert.Equal(t, keyName, sigName)
+		assert.True(t, ed25519.Verify(publicKey, []byte(m.Fingerprint()), sig))
+	}
+
+	t.Run("DownloadNarInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		verifySignature(t)
+	})
+
+	t.Run("DownloadNar", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/nar/"+narFilename)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, narContent, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/nar/"+strings.Repeat("0", 52)+".nar.zst")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		_, publicKey, err := nix_service.GetOrCreateSigningKey(t.Context(), user)
+		assert.NoError(t, err)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/nix/%s/%s", user.Name, storeName, storeHash))
+		resp := MakeRequest(t, req, http.StatusOK)
+		keyName, _, _ := strings.Cut(publicKey, ":")
+		assert.Contains(t, resp.Body.String(), "extra-trusted-public-keys = "+keyName)
+		assert.Contains(t, resp.Body.String(), storePath)
+	})
+
+	t.Run("RegenerateSigningKey", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		_, oldKey, err := nix_service.GetOrCreateSigningKey(t.Context(), user)
+		assert.NoError(t, err)
+
+		session := loginUser(t, user.Name)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings/packages"), http.StatusOK)
+		oldKeyName, _, _ := strings.Cut(oldKey, ":")
+		assert.Contains(t, resp.Body.String(), oldKeyName)
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/packages/nix/regenerate_key", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		_, newKey, err := nix_service.GetOrCreateSigningKey(t.Context(), user)
+		assert.NoError(t, err)
+		assert.NotEqual(t, oldKey, newKey)
+		newKeyName, _, _ := strings.Cut(newKey, ":")
+		assert.True(t, strings.HasSuffix(newKeyName, "-2"))
+
+		verifySignature(t)
+	})
+
+	t.Run("FlakeRegistry", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		testCreateFileInBranch(t, user, repo, createFileInBranchOptions{OldBranch: repo.DefaultBranch}, map[string]string{
+			"flake.nix": "{ outputs = { self }: { }; }\n",
+		})
+
+		req := NewRequest(t, "GET", root+"/flake-registry.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var registry nix_service.FlakeRegistry
+		DecodeJSON(t, resp, &registry)
+
+		assert.Equal(t, 2, registry.Version)
+		assert.Len(t, registry.Flakes, 1)
+		assert.Equal(t, "indirect", registry.Flakes[0].From.Type)
+		assert.Equal(t, repo.LowerName, registry.Flakes[0].From.ID)
+		assert.Equal(t, "git", registry.Flakes[0].To.Type)
+		assert.Equal(t, repo.CloneLinkGeneral(t.Context()).HTTPS, registry.Flakes[0].To.URL)
+	})
+}
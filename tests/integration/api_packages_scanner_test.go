@@ -0,0 +1,134 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+	packages_scanner_service "code.gitea.io/gitea/services/packages/scanner"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageScanner(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	advisory := func(withdrawn string) []byte {
+		return []byte(`{
+			"schema_version": "1.6.0",
+			"id": "GHSA-35jh-r3h4-6jhm",
+			"modified": "2024-01-01T00:00:00Z",
+			"published": "2021-02-15T00:00:00Z",` + withdrawn + `
+			"aliases": ["CVE-2021-23337"],
+			"summary": "Command Injection in lodash",
+			"affected": [{
+				"package": {"ecosystem": "npm", "name": "lodash"},
+				"ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "4.17.21"}]}]
+			}],
+			"database_specific": {"severity": "HIGH"}
+		}`)
+	}
+
+	require.NoError(t, packages_scanner_service.ImportAdvisory(t.Context(), advisory("")))
+
+	hook := &webhook_model.Webhook{
+		OwnerID:     user.ID,
+		URL:         "http://localhost/hook",
+		ContentType: webhook_model.ContentTypeJSON,
+		HookEvent: &webhook_module.HookEvent{
+			ChooseEvents: true,
+			HookEvents: webhook_module.HookEvents{
+				webhook_module.HookEventPackageVulnerability: true,
+			},
+		},
+		IsActive: true,
+		Type:     webhook_module.GITEA,
+	}
+	require.NoError(t, hook.UpdateEvent())
+	require.NoError(t, webhook_model.CreateWebhook(t.Context(), hook))
+
+	packageName := "scanned-package"
+	packageVersion := "1.0.0"
+
+	upload := `{
+		"_id": "` + packageName + `",
+		"name": "` + packageName + `",
+		"dist-tags": {"latest": "` + packageVersion + `"},
+		"versions": {
+			"` + packageVersion + `": {
+				"name": "` + packageName + `",
+				"version": "` + packageVersion + `",
+				"license": "MIT",
+				"dependencies": {"lodash": "^4.17.0", "left-pad": "1.3.0"},
+				"dist": {
+					"integrity": "sha512-yA4FJsVhetynGfOC1jFf79BuS+jrHbm0fhh+aHzCQkOaOBXKf9oBnC4a6DnLLnEsHQDRLYd00cwj8sCXpC+wIg==",
+					"shasum": "aaa7eaf852a948b0aa05afeda35b1badca155d90"
+				}
+			}
+		},
+		"_attachments": {
+			"` + packageName + `-` + packageVersion + `.tgz": {
+				"data": "H4sIAAAAAAAA/ytITM5OTE/VL4DQelnF+XkMVAYGBgZmJiYK2MRBwNDcSIHB2NTMwNDQzMwAqA7IMDUxA9LUdgg2UFpcklgEdAql5kD8ogCnhwio5lJQUMpLzE1VslJQcihOzi9I1S9JLS7RhSYIJR2QgrLUouLM/DyQGkM9Az1D3YIiqExKanFyUWZBCVQ2BKhVwQVJDKwosbQkI78IJO/tZ+LsbRykxFXLNdA+HwWjYBSMgpENACgAbtAACAAA"
+			}
+		}
+	}`
+
+	req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/npm/%s", user.Name, packageName), strings.NewReader(upload)).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusCreated)
+
+	pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeNpm, packageName, packageVersion)
+	require.NoError(t, err)
+
+	var vulnerabilities []*packages_model.PackageVulnerability
+	assert.Eventually(t, func() bool {
+		vulnerabilities, err = packages_model.GetVulnerabilitiesByVersionID(t.Context(), pv.ID)
+		return err == nil && len(vulnerabilities) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+	require.Len(t, vulnerabilities, 1)
+	assert.Equal(t, "4.17.21", vulnerabilities[0].FixedVersion)
+
+	components, err := packages_model.GetComponentsByVersionID(t.Context(), pv.ID)
+	require.NoError(t, err)
+	assert.Len(t, components, 3)
+
+	unittest.AssertExistsAndLoadBean(t, &webhook_model.HookTask{HookID: hook.ID, EventType: webhook_module.HookEventPackageVulnerability})
+
+	t.Run("View", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/npm/%s/%s", user.Name, packageName, packageVersion)), http.StatusOK)
+		body := resp.Body.String()
+		assert.Contains(t, body, "GHSA-35jh-r3h4-6jhm")
+		assert.Contains(t, body, "CVE-2021-23337")
+	})
+
+	t.Run("Withdraw", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		require.NoError(t, packages_scanner_service.ImportAdvisory(t.Context(), advisory(`
+			"withdrawn": "2024-02-01T00:00:00Z",`)))
+		require.NoError(t, packages_scanner_service.ScanTask(t.Context()))
+
+		vulnerabilities, err := packages_model.GetVulnerabilitiesByVersionID(t.Context(), pv.ID)
+		require.NoError(t, err)
+		assert.Empty(t, vulnerabilities)
+	})
+}
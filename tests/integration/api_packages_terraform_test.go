@@ -0,0 +1,312 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	terraform_module "code.gitea.io/gitea/modules/packages/terraform"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	root := fmt.Sprintf("/api/packages/%s/terraform", user.Name)
+
+	t.Run("Discovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/.well-known/terraform.json"), http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, setting.AppURL+"api/packages/-/terraform/modules/v1/", result["modules.v1"])
+		assert.Equal(t, setting.AppURL+"api/packages/-/terraform/providers/v1/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "consul"
+		moduleSystem := "aws"
+		moduleVersion := "1.2.0"
+		readme := "# Consul Module"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for name, content := range map[string]string{"main.tf": "", "README.md": readme} {
+			tw.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		moduleURL := fmt.Sprintf("%s/modules/%s/%s", root, moduleName, moduleSystem)
+		protocolURL := fmt.Sprintf("/api/packages/-/terraform/modules/v1/%s/%s/%s", user.Name, moduleName, moduleSystem)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", moduleURL+"/"+moduleVersion, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", moduleURL+"/v1.2.0", bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", moduleURL+"/"+moduleVersion, strings.NewReader("dummy")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", moduleURL+"/"+moduleVersion, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			require.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+			require.NoError(t, err)
+			assert.Equal(t, moduleName+"/"+moduleSystem, pd.Package.Name)
+			assert.Equal(t, moduleVersion, pd.Version.Version)
+			assert.IsType(t, &terraform_module.Metadata{}, pd.Metadata)
+			assert.Equal(t, terraform_module.KindModule, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, readme, pd.Metadata.(*terraform_module.Metadata).Readme)
+			require.Len(t, pd.Files, 1)
+			assert.Equal(t, "consul-aws-1.2.0.tar.gz", pd.Files[0].File.Name)
+
+			resp := MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/terraform/%s/%s", user.Name, url.PathEscape(pd.Package.Name), moduleVersion)), http.StatusOK)
+			assert.Contains(t, resp.Body.String(), "/"+user.Name+"/consul/aws\"")
+
+			req = NewRequestWithBody(t, "PUT", moduleURL+"/"+moduleVersion, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("Versions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", protocolURL+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.JSONEq(t, `{"modules":[{"versions":[{"version":"1.2.0"}]}]}`, resp.Body.String())
+
+			req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/-/terraform/modules/v1/%s/%s/azure/versions", user.Name, moduleName))
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", protocolURL+"/1.0.0/download")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", protocolURL+"/"+moduleVersion+"/download")
+			resp := MakeRequest(t, req, http.StatusNoContent)
+
+			location := resp.Header().Get("X-Terraform-Get")
+			assert.Equal(t, fmt.Sprintf("%sapi/packages/%s/terraform/modules/consul/aws/1.2.0/consul-aws-1.2.0.tar.gz", setting.AppURL, user.Name), location)
+
+			req = NewRequest(t, "GET", strings.TrimPrefix(location, setting.AppURL[:len(setting.AppURL)-1]))
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "DELETE", moduleURL+"/"+moduleVersion)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "DELETE", moduleURL+"/"+moduleVersion).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+
+			req = NewRequest(t, "GET", protocolURL+"/versions")
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "random"
+		providerVersion := "2.0.0"
+
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create("terraform-provider-random_v2.0.0")
+		w.Write([]byte("binary"))
+		zw.Close()
+		content := buf.Bytes()
+		hash := sha256.Sum256(content)
+
+		providerURL := fmt.Sprintf("%s/providers/%s/%s", root, providerType, providerVersion)
+		protocolURL := fmt.Sprintf("/api/packages/-/terraform/providers/v1/%s/%s", user.Name, providerType)
+
+		linuxFilename := terraform_module.ProviderFilename(providerType, providerVersion, "linux", "amd64")
+		darwinFilename := terraform_module.ProviderFilename(providerType, providerVersion, "darwin", "arm64")
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", providerURL+"/"+linuxFilename, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", providerURL+"/terraform-provider-random_2.0.0_linux_amd64.tar.gz", bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", providerURL+"/"+linuxFilename, strings.NewReader("dummy")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			for _, filename := range []string{linuxFilename, darwinFilename} {
+				req = NewRequestWithBody(t, "PUT", providerURL+"/"+filename, bytes.NewReader(content)).
+					AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusCreated)
+			}
+
+			req = NewRequestWithBody(t, "PUT", providerURL+"/"+linuxFilename, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+
+			req = NewRequestWithBody(t, "PUT", providerURL+"/"+terraform_module.ProviderManifestFilename(providerType, providerVersion), strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeTerraform, providerType, providerVersion)
+			require.NoError(t, err)
+
+			pd, err := packages.GetPackageDescriptor(t.Context(), pv)
+			require.NoError(t, err)
+			assert.Equal(t, terraform_module.KindProvider, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, []string{"6.0"}, pd.Metadata.(*terraform_module.Metadata).Protocols)
+			assert.Len(t, pd.Files, 3)
+
+			MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages/terraform/%s/%s", user.Name, providerType, providerVersion)), http.StatusOK)
+		})
+
+		t.Run("Versions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", protocolURL+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Versions []struct {
+					Version   string                       `json:"version"`
+					Protocols []string                     `json:"protocols"`
+					Platforms []*terraform_module.Platform `json:"platforms"`
+				} `json:"versions"`
+			}
+			DecodeJSON(t, resp, &result)
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"6.0"}, result.Versions[0].Protocols)
+			assert.ElementsMatch(t, []*terraform_module.Platform{{OS: "linux", Arch: "amd64"}, {OS: "darwin", Arch: "arm64"}}, result.Versions[0].Platforms)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", protocolURL+"/"+providerVersion+"/download/windows/amd64")
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", protocolURL+"/"+providerVersion+"/download/linux/amd64")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Protocols           []string `json:"protocols"`
+				OS                  string   `json:"os"`
+				Arch                string   `json:"arch"`
+				Filename            string   `json:"filename"`
+				DownloadURL         string   `json:"download_url"`
+				SHASumsURL          string   `json:"shasums_url"`
+				SHASumsSignatureURL string   `json:"shasums_signature_url"`
+				SHASum              string   `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+			assert.Equal(t, []string{"6.0"}, result.Protocols)
+			assert.Equal(t, "linux", result.OS)
+			assert.Equal(t, "amd64", result.Arch)
+			assert.Equal(t, linuxFilename, result.Filename)
+			assert.Equal(t, hex.EncodeToString(hash[:]), result.SHASum)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.SigningKeys.GPGPublicKeys[0].ASCIIArmor))
+			require.NoError(t, err)
+			require.Len(t, keyring, 1)
+			assert.Equal(t, keyring[0].PrimaryKey.KeyIdString(), result.SigningKeys.GPGPublicKeys[0].KeyID)
+
+			req = NewRequest(t, "GET", root+"/repository.key")
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, result.SigningKeys.GPGPublicKeys[0].ASCIIArmor, resp.Body.String())
+
+			localPath := func(u string) string {
+				return strings.TrimPrefix(u, setting.AppURL[:len(setting.AppURL)-1])
+			}
+
+			resp = MakeRequest(t, NewRequest(t, "GET", localPath(result.DownloadURL)), http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+
+			resp = MakeRequest(t, NewRequest(t, "GET", localPath(result.SHASumsURL)), http.StatusOK)
+			sums := resp.Body.String()
+			assert.Contains(t, sums, fmt.Sprintf("%x  %s\n", hash, linuxFilename))
+			assert.Contains(t, sums, fmt.Sprintf("%x  %s\n", hash, darwinFilename))
+
+			resp = MakeRequest(t, NewRequest(t, "GET", localPath(result.SHASumsSignatureURL)), http.StatusOK)
+			_, err = openpgp.CheckDetachedSignature(keyring, strings.NewReader(sums), resp.Body, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "DELETE", providerURL).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+
+			req = NewRequest(t, "GET", protocolURL+"/versions")
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+}
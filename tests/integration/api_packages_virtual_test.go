@@ -0,0 +1,167 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/blakesmith/ar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageVirtual(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	source := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+
+	session := loginUser(t, source.Name)
+
+	addSource := func(t *testing.T, session *TestSession, packageType packages.Type, sourceName, pattern string, expectedStatus int) {
+		req := NewRequestWithValues(t, "POST", fmt.Sprintf("/org/%s/settings/packages/sources/add", owner.Name), map[string]string{
+			"_csrf":    GetUserCSRFToken(t, session),
+			"type":     string(packageType),
+			"source":   sourceName,
+			"pattern":  pattern,
+			"position": "0",
+			"action":   "save",
+		})
+		session.MakeRequest(t, req, expectedStatus)
+	}
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		data := "H4sIAAAAAAAA/ytITM5OTE/VL4DQelnF+XkMVAYGBgZmJiYK2MRBwNDcSIHB2NTMwNDQzMwAqA7IMDUxA9LUdgg2UFpcklgEdAql5kD8ogCnhwio5lJQUMpLzE1VslJQcihOzi9I1S9JLS7RhSYIJR2QgrLUouLM/DyQGkM9Az1D3YIiqExKanFyUWZBCVQ2BKhVwQVJDKwosbQkI78IJO/tZ+LsbRykxFXLNdA+HwWjYBSMgpENACgAbtAACAAA"
+
+		upload := func(packageName string) {
+			body := `{
+				"_id": "` + packageName + `",
+				"name": "` + packageName + `",
+				"dist-tags": {"latest": "1.0.0"},
+				"versions": {
+					"1.0.0": {
+						"name": "` + packageName + `",
+						"version": "1.0.0",
+						"dist": {
+							"integrity": "sha512-yA4FJsVhetynGfOC1jFf79BuS+jrHbm0fhh+aHzCQkOaOBXKf9oBnC4a6DnLLnEsHQDRLYd00cwj8sCXpC+wIg==",
+							"shasum": "aaa7eaf852a948b0aa05afeda35b1badca155d90"
+						}
+					}
+				},
+				"_attachments": {
+					"` + packageName + `-1.0.0.tgz": {"data": "` + data + `"}
+				}
+			}`
+			req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/npm/%s", source.Name, url.QueryEscape(packageName)), strings.NewReader(body)).
+				AddBasicAuth(source.Name)
+			MakeRequest(t, req, http.StatusCreated)
+		}
+		upload("@corp/test-package")
+		upload("test-package")
+
+		// user4 is no administrator of the source
+		addSource(t, loginUser(t, "user4"), packages.TypeNpm, source.Name, "@corp/*", http.StatusNotFound)
+		addSource(t, session, packages.TypeNpm, "user5", "@corp/*", http.StatusOK)
+		addSource(t, session, packages.TypeNpm, source.Name, "@corp/*", http.StatusSeeOther)
+		addSource(t, session, packages.TypeNpm, source.Name, "", http.StatusOK)
+
+		pvss, err := packages.GetVirtualSourcesByOwnerAndType(t.Context(), owner.ID, packages.TypeNpm)
+		require.NoError(t, err)
+		require.Len(t, pvss, 1)
+		assert.Equal(t, source.ID, pvss[0].SourceOwnerID)
+
+		settingsURL := fmt.Sprintf("/org/%s/settings/packages", owner.Name)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", settingsURL), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf("%s/sources/%d", settingsURL, pvss[0].ID))
+		session.MakeRequest(t, NewRequest(t, "GET", settingsURL+"/sources/add"), http.StatusOK)
+		session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/sources/%d", settingsURL, pvss[0].ID)), http.StatusOK)
+
+		root := fmt.Sprintf("/api/packages/%s/npm", owner.Name)
+
+		req := NewRequest(t, "GET", root+"/"+url.QueryEscape("@corp/test-package"))
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `"name":"@corp/test-package"`)
+
+		req = NewRequest(t, "GET", root+"/@corp/test-package/-/1.0.0/test-package-1.0.0.tgz")
+		MakeRequest(t, req, http.StatusOK)
+
+		// the package name does not match the pattern of the source
+		req = NewRequest(t, "GET", root+"/test-package")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Debian", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		var cbuf bytes.Buffer
+		zw := gzip.NewWriter(&cbuf)
+		tw := tar.NewWriter(zw)
+		control := "Package: gitea\nVersion: 1.0.3\nArchitecture: amd64\nDescription: Package Description\n"
+		tw.WriteHeader(&tar.Header{
+			Name: "control",
+			Mode: 0o600,
+			Size: int64(len(control)),
+		})
+		io.WriteString(tw, control)
+		tw.Close()
+		zw.Close()
+
+		var buf bytes.Buffer
+		aw := ar.NewWriter(&buf)
+		aw.WriteGlobalHeader()
+		aw.WriteHeader(&ar.Header{
+			Name: "control.tar.gz",
+			Mode: 0o600,
+			Size: int64(cbuf.Len()),
+		})
+		aw.Write(cbuf.Bytes())
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/debian/pool/test/main/upload", source.Name), &buf).
+			AddBasicAuth(source.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		root := fmt.Sprintf("/api/packages/%s/debian", owner.Name)
+		packagesURL := root + "/dists/test/main/binary-amd64/Packages"
+
+		MakeRequest(t, NewRequest(t, "GET", packagesURL), http.StatusNotFound)
+
+		addSource(t, session, packages.TypeDebian, source.Name, "", http.StatusSeeOther)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", packagesURL), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "Package: gitea\n")
+		assert.Contains(t, resp.Body.String(), "Filename: pool/test/main/gitea_1.0.3_amd64.deb\n")
+
+		MakeRequest(t, NewRequest(t, "GET", root+"/pool/test/main/gitea_1.0.3_amd64.deb"), http.StatusOK)
+
+		pvss, err := packages.GetVirtualSourcesByOwnerAndType(t.Context(), owner.ID, packages.TypeDebian)
+		require.NoError(t, err)
+		require.Len(t, pvss, 1)
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/org/%s/settings/packages/sources/%d", owner.Name, pvss[0].ID), map[string]string{
+			"_csrf":  GetUserCSRFToken(t, session),
+			"type":   string(packages.TypeDebian),
+			"source": source.Name,
+			"action": "remove",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		MakeRequest(t, NewRequest(t, "GET", packagesURL), http.StatusNotFound)
+		MakeRequest(t, NewRequest(t, "GET", root+"/pool/test/main/gitea_1.0.3_amd64.deb"), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/dependency"
+	api "code.gitea.io/gitea/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRepoDependencies(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		resp := testCreateFileInBranch(t, user2, repo1, createFileInBranchOptions{OldBranch: "master"}, map[string]string{
+			"go.mod": `module example.com/app
+
+go 1.22
+
+require (
+	github.com/google/uuid v1.6.0
+	golang.org/x/text v0.14.0 // indirect
+)
+`,
+			"web/package-lock.json": `{
+  "name": "web",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "web", "dependencies": {"lodash": "^4.17.0"}},
+    "node_modules/lodash": {"version": "4.17.20"}
+  }
+}`,
+		})
+		commitID := resp.Commit.SHA
+
+		// let gitea parse the dependencies
+		assert.Eventually(t, func() bool {
+			status, err := repo_model.GetIndexerStatus(t.Context(), repo1, repo_model.RepoIndexerTypeDependencies)
+			return err == nil && status.CommitSha == commitID
+		}, 10*time.Second, 100*time.Millisecond)
+
+		t.Run("List", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/dependencies")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var deps []*api.RepoDependency
+			DecodeJSON(t, resp, &deps)
+			require.Len(t, deps, 3)
+			assert.Equal(t, "3", resp.Header().Get("X-Total-Count"))
+
+			assert.Equal(t, "Go", deps[0].Ecosystem)
+			assert.Equal(t, "github.com/google/uuid", deps[0].Name)
+			assert.Equal(t, "pkg:golang/github.com/google/uuid@v1.6.0", deps[0].PackageURL)
+			assert.True(t, deps[0].Direct)
+			assert.Equal(t, commitID, deps[0].CommitID)
+			assert.Equal(t, "golang.org/x/text", deps[1].Name)
+			assert.False(t, deps[1].Direct)
+			assert.Equal(t, "lodash", deps[2].Name)
+			assert.Equal(t, "web/package-lock.json", deps[2].Manifest)
+
+			req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/dependencies?ecosystem=npm")
+			resp = MakeRequest(t, req, http.StatusOK)
+			DecodeJSON(t, resp, &deps)
+			require.Len(t, deps, 1)
+			assert.Equal(t, "lodash", deps[0].Name)
+		})
+
+		t.Run("Search", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/api/v1/dependencies/search")
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+			req = NewRequest(t, "GET", "/api/v1/dependencies/search?ecosystem=npm&name=Lodash&version=4.17.20")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var deps []*api.RepoDependency
+			DecodeJSON(t, resp, &deps)
+			require.Len(t, deps, 1)
+			require.NotNil(t, deps[0].Repository)
+			assert.Equal(t, "user2/repo1", deps[0].Repository.FullName)
+
+			req = NewRequest(t, "GET", "/api/v1/dependencies/search?name=lodash&version=4.17.21")
+			resp = MakeRequest(t, req, http.StatusOK)
+			DecodeJSON(t, resp, &deps)
+			assert.Empty(t, deps)
+		})
+
+		t.Run("SBOM", func(t *testing.T) {
+			token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadRepository)
+
+			req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/sbom?ref="+commitID).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, "application/vnd.cyclonedx+json", resp.Header().Get("Content-Type"))
+
+			var bom dependency.CycloneDXBOM
+			DecodeJSON(t, resp, &bom)
+			assert.Equal(t, "CycloneDX", bom.BOMFormat)
+			assert.Equal(t, commitID, bom.Metadata.Component.Version)
+			assert.Len(t, bom.Components, 3)
+
+			req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/sbom?format=spdx").AddTokenAuth(token)
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, "application/spdx+json", resp.Header().Get("Content-Type"))
+
+			var doc dependency.SPDXDocument
+			DecodeJSON(t, resp, &doc)
+			assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+			assert.Len(t, doc.Packages, 4)
+
+			req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/sbom?format=unknown").AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+		})
+
+		t.Run("Web", func(t *testing.T) {
+			req := NewRequest(t, "GET", "/user2/repo1/activity/dependencies?q=uuid")
+			resp := MakeRequest(t, req, http.StatusOK)
+			htmlDoc := NewHTMLParser(t, resp.Body)
+			assert.Equal(t, 1, htmlDoc.Find(".dependency-list .flex-item").Length())
+
+			req = NewRequest(t, "GET", "/user2/repo1/activity/dependencies/sbom?format=spdx")
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, "application/spdx+json", resp.Header().Get("Content-Type"))
+			assert.Contains(t, resp.Header().Get("Content-Disposition"), "repo1-"+commitID[:10]+".spdx.json")
+		})
+	})
+}
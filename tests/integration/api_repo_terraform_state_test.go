@@ -0,0 +1,217 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	auth_model "code.gitea.io/gitea/models/auth"
+	repo_model "code.gitea.io/gitea/models/repo"
+	terraform_model "code.gitea.io/gitea/models/terraform"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRepoTerraformState(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWriteRepository)
+	readToken := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadRepository)
+
+	root := fmt.Sprintf("/api/v1/repos/%s/%s/terraform/states", user.Name, repo.Name)
+	stateURL := root + "/default"
+
+	lockInfo := func(id string) string {
+		info, _ := json.Marshal(&terraform_model.LockInfo{ID: id, Operation: "OperationTypeApply", Who: "user@host", Version: "1.9.0"})
+		return string(info)
+	}
+	stateContent := func(serial int) string {
+		return fmt.Sprintf("{\n  \"version\": 4,\n  \"terraform_version\": \"1.9.0\",\n  \"serial\": %d,\n  \"lineage\": \"0f3b1c2d\",\n  \"outputs\": {}\n}\n", serial)
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", stateURL).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", stateURL)
+		MakeRequest(t, req, http.StatusUnauthorized)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("lock-1"))).AddBasicAuth(user.Name, readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader("invalid")).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("lock-1"))).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("lock-2"))).AddBasicAuth(user.Name, token)
+		resp := MakeRequest(t, req, http.StatusLocked)
+
+		var info terraform_model.LockInfo
+		DecodeJSON(t, resp, &info)
+		assert.Equal(t, "lock-1", info.ID)
+		assert.Equal(t, "user@host", info.Who)
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "GET", stateURL, nil).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequestWithBody(t, "POST", stateURL, strings.NewReader(stateContent(1))).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusLocked)
+
+		req = NewRequestWithBody(t, "POST", stateURL+"?ID=lock-1", strings.NewReader("no state")).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		for serial := 1; serial <= 2; serial++ {
+			req = NewRequestWithBody(t, "POST", stateURL+"?ID=lock-1", strings.NewReader(stateContent(serial))).AddBasicAuth(user.Name, token)
+			MakeRequest(t, req, http.StatusOK)
+		}
+
+		req = NewRequest(t, "GET", stateURL).AddBasicAuth(user.Name, token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, stateContent(2), resp.Body.String())
+	})
+
+	t.Run("Unlock", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "UNLOCK", stateURL, strings.NewReader(lockInfo("lock-2"))).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusLocked)
+
+		req = NewRequestWithBody(t, "UNLOCK", stateURL, strings.NewReader(lockInfo("lock-1"))).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithBody(t, "POST", stateURL, strings.NewReader(stateContent(3))).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusOK)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root).AddTokenAuth(readToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var states []*api.TerraformState
+		DecodeJSON(t, resp, &states)
+		require.Len(t, states, 1)
+		assert.Equal(t, "default", states[0].Name)
+		assert.EqualValues(t, 3, states[0].Serial)
+		assert.Equal(t, "0f3b1c2d", states[0].Lineage)
+		assert.False(t, states[0].Locked)
+	})
+
+	t.Run("UI", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		state := unittest.AssertExistsAndLoadBean(t, &terraform_model.State{RepoID: repo.ID, LowerName: "default"})
+		versions, err := terraform_model.GetStateVersionsByStateID(t.Context(), state.ID)
+		require.NoError(t, err)
+		require.Len(t, versions, 3)
+
+		link := fmt.Sprintf("/%s/%s/terraform", user.Name, repo.Name)
+
+		MakeRequest(t, NewRequest(t, "GET", link), http.StatusSeeOther)
+
+		session := loginUser(t, user.Name)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), link+"/default")
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", link+"/default"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), fmt.Sprintf("%s/default/versions/%d/diff", link, versions[2].ID))
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/default/versions/%d", link, versions[0].ID)), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "&#34;serial&#34;: 1")
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("%s/default/versions/%d/diff", link, versions[2].ID)), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `<span class="gd">-   &#34;serial&#34;: 2,</span>`)
+		assert.Contains(t, resp.Body.String(), `<span class="gi">+   &#34;serial&#34;: 3,</span>`)
+
+		session.MakeRequest(t, NewRequest(t, "GET", link+"/unknown"), http.StatusNotFound)
+
+		req := NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("lock-3"))).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithValues(t, "POST", link+"/default/unlock", map[string]string{
+			"_csrf": GetUserCSRFToken(t, session),
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		state = unittest.AssertExistsAndLoadBean(t, &terraform_model.State{ID: state.ID})
+		assert.False(t, state.IsLocked())
+
+		// a reader of the code can't access the states
+		session = loginUser(t, "user4")
+		session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusNotFound)
+	})
+
+	t.Run("ActionsToken", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		task := unittest.AssertExistsAndLoadBean(t, &actions_model.ActionTask{ID: 47})
+		require.NoError(t, task.GenerateToken())
+		task.Status = actions_model.StatusRunning
+		require.NoError(t, actions_model.UpdateTask(t.Context(), task, "token_hash", "token_salt", "token_last_eight", "status"))
+
+		taskRepo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: task.RepoID})
+		taskStateURL := fmt.Sprintf("/api/v1/repos/%s/%s/terraform/states/default", taskRepo.OwnerName, taskRepo.Name)
+
+		req := NewRequestWithBody(t, "LOCK", taskStateURL, strings.NewReader(lockInfo("job"))).AddBasicAuth("gitea-actions", task.Token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithBody(t, "POST", taskStateURL+"?ID=job", strings.NewReader(stateContent(1))).AddBasicAuth("gitea-actions", task.Token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequestWithBody(t, "UNLOCK", taskStateURL, strings.NewReader(lockInfo("job"))).AddBasicAuth("gitea-actions", task.Token)
+		MakeRequest(t, req, http.StatusOK)
+
+		// the token of the job can't access the states of other repositories
+		req = NewRequest(t, "GET", stateURL).AddBasicAuth("gitea-actions", task.Token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "LOCK", stateURL, strings.NewReader(lockInfo("lock-4"))).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "DELETE", stateURL).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusLocked)
+
+		// "terraform force-unlock" sends no lock info
+		req = NewRequestWithBody(t, "UNLOCK", stateURL, strings.NewReader("")).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "DELETE", stateURL).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", stateURL).AddBasicAuth(user.Name, token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		unittest.AssertNotExistsBean(t, &terraform_model.StateVersion{RepoID: repo.ID})
+	})
+}
@@ -451,9 +451,9 @@ func TestLDAPGroupTeamSyncAddMember(t *testing.T) {
 			isMember, err := organization.IsTeamMember(t.Context(), usersOrgs[0].ID, team.ID, user.ID)
 			assert.NoError(t, err)
 			assert.True(t, isMember, "Membership should be added to the right team")
-			err = org_service.RemoveTeamMember(t.Context(), team, user)
+			err = org_service.RemoveTeamMember(t.Context(), nil, team, user)
 			assert.NoError(t, err)
-			err = org_service.RemoveOrgUser(t.Context(), usersOrgs[0], user)
+			err = org_service.RemoveOrgUser(t.Context(), nil, usersOrgs[0], user)
 			assert.NoError(t, err)
 		} else {
 			// assert members of LDAP group "cn=admin_staff" keep initial team membership since mapped team does not exist
@@ -486,7 +486,7 @@ func TestLDAPGroupTeamSyncRemoveMember(t *testing.T) {
 	})
 	err = organization.AddOrgUser(t.Context(), org.ID, user.ID)
 	assert.NoError(t, err)
-	err = org_service.AddTeamMember(t.Context(), team, user)
+	err = org_service.AddTeamMember(t.Context(), nil, team, user)
 	assert.NoError(t, err)
 	isMember, err := organization.IsOrganizationMember(t.Context(), org.ID, user.ID)
 	assert.NoError(t, err)
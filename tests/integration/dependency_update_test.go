@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	issues_model "code.gitea.io/gitea/models/issues"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	dependency_update_service "code.gitea.io/gitea/services/dependencyupdate"
+	issue_service "code.gitea.io/gitea/services/issue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyUpdates(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo1 := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+		addVersions := func(t *testing.T, name string, versions ...string) {
+			p, err := packages_model.TryInsertPackage(t.Context(), &packages_model.Package{
+				OwnerID:   user2.ID,
+				Type:      packages_model.TypeNpm,
+				Name:      name,
+				LowerName: strings.ToLower(name),
+			})
+			if !errors.Is(err, packages_model.ErrDuplicatePackage) {
+				require.NoError(t, err)
+			}
+			for _, version := range versions {
+				_, err := packages_model.GetOrInsertVersion(t.Context(), &packages_model.PackageVersion{
+					PackageID:    p.ID,
+					CreatorID:    user2.ID,
+					Version:      version,
+					LowerVersion: version,
+				})
+				require.NoError(t, err)
+			}
+		}
+
+		testCreateFileInBranch(t, user2, repo1, createFileInBranchOptions{OldBranch: "master"}, map[string]string{
+			"package.json": `{
+  "dependencies": {
+    "lodash": "^4.17.20",
+    "left-pad": "1.0.0"
+  }
+}`,
+			".gitea/dependency-updates.yml": `updates:
+  - package-ecosystem: npm
+    labels: [label1]
+    ignore:
+      - dependency-name: left-pad
+`,
+		})
+		addVersions(t, "lodash", "4.17.20", "4.17.21", "5.0.0-rc.1")
+		addVersions(t, "left-pad", "1.1.0")
+
+		const branch = "dependency-updates/npm/root/lodash"
+
+		require.NoError(t, dependency_update_service.UpdateRepository(t.Context(), user2, repo1, time.Now()))
+
+		pr, err := issues_model.GetLatestPullRequestByHeadInfo(t.Context(), repo1.ID, branch)
+		require.NoError(t, err)
+		require.NotNil(t, pr)
+		require.NoError(t, pr.LoadIssue(t.Context()))
+		assert.Equal(t, "Update lodash from 4.17.20 to 4.17.21", pr.Issue.Title)
+		assert.Equal(t, "master", pr.BaseBranch)
+		assert.Contains(t, pr.Issue.Content, "package-lock.json")
+		require.NoError(t, pr.Issue.LoadLabels(t.Context()))
+		require.Len(t, pr.Issue.Labels, 1)
+		assert.Equal(t, "label1", pr.Issue.Labels[0].Name)
+
+		req := NewRequest(t, "GET", "/user2/repo1/raw/branch/"+branch+"/package.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `"lodash": "^4.17.21"`)
+		assert.Contains(t, resp.Body.String(), `"left-pad": "1.0.0"`)
+
+		prs, err := issues_model.GetUnmergedPullRequestsByHeadBranchPrefix(t.Context(), repo1.ID, dependency_update_service.BranchPrefix)
+		require.NoError(t, err)
+		assert.Len(t, prs, 1)
+
+		t.Run("Rebase", func(t *testing.T) {
+			addVersions(t, "lodash", "4.18.0")
+
+			require.NoError(t, dependency_update_service.UpdateRepository(t.Context(), user2, repo1, time.Now()))
+
+			updated := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: pr.ID})
+			require.NoError(t, updated.LoadIssue(t.Context()))
+			assert.Equal(t, "Update lodash from 4.17.20 to 4.18.0", updated.Issue.Title)
+			assert.False(t, updated.Issue.IsClosed)
+
+			req := NewRequest(t, "GET", "/user2/repo1/raw/branch/"+branch+"/package.json")
+			resp := MakeRequest(t, req, http.StatusOK)
+			assert.Contains(t, resp.Body.String(), `"lodash": "^4.18.0"`)
+		})
+
+		t.Run("Dismissed", func(t *testing.T) {
+			issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: pr.IssueID})
+			require.NoError(t, issue_service.CloseIssue(t.Context(), issue, user2, ""))
+
+			require.NoError(t, dependency_update_service.UpdateRepository(t.Context(), user2, repo1, time.Now()))
+
+			prs, err := issues_model.GetUnmergedPullRequestsByHeadBranchPrefix(t.Context(), repo1.ID, dependency_update_service.BranchPrefix)
+			require.NoError(t, err)
+			assert.Empty(t, prs)
+		})
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	git_model "code.gitea.io/gitea/models/git"
+	issues_model "code.gitea.io/gitea/models/issues"
+	pull_model "code.gitea.io/gitea/models/pull"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/commitstatus"
+	"code.gitea.io/gitea/modules/gitrepo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/mergequeue"
+	commitstatus_service "code.gitea.io/gitea/services/repository/commitstatus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullMergeQueueBisectFailedBatch(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		session := loginUser(t, "user2")
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{OwnerName: "user2", Name: "repo1"})
+
+		masterCommitID, err := gitrepo.GetBranchCommitID(t.Context(), repo, "master")
+		require.NoError(t, err)
+		defer testResetRepo(t, repo, "master", masterCommitID)
+
+		// the queue is disabled at first, so both pull requests are queued before the first batch is built
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/branch_protections", &api.CreateBranchProtectionOption{
+			RuleName:            "master",
+			EnableStatusCheck:   true,
+			StatusCheckContexts: []string{"ci"},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// the batches are merge commits which are force-pushed to the branch of the queue, the ruleset must not reject them
+		require.NoError(t, git_model.CreateRuleset(t.Context(), &git_model.Ruleset{
+			RepoID:               repo.ID,
+			Name:                 "linear branches",
+			Target:               git_model.RulesetTargetBranch,
+			Enforcement:          git_model.RulesetEnforcementActive,
+			IncludeRefs:          git_model.RulesetPatternAll,
+			ExcludeRefs:          git_model.RulesetPatternDefaultBranch,
+			BlockForcePush:       true,
+			RequireLinearHistory: true,
+		}))
+
+		createQueuedPull := func(branch string) *issues_model.PullRequest {
+			testCreateFile(t, session, "user2", "repo1", "master", branch, branch+".txt", "merge queue test\n")
+			testPullCreate(t, session, "user2", "repo1", true, "master", branch, "Merge queue "+branch)
+			pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo.ID, HeadBranch: branch})
+
+			headCommitID, err := gitrepo.GetBranchCommitID(t.Context(), repo, branch)
+			require.NoError(t, err)
+			setMergeQueueTestStatus(t, repo, user2, headCommitID, commitstatus.CommitStatusSuccess)
+
+			require.NoError(t, mergequeue.AddToMergeQueue(t.Context(), user2, pr, repo_model.MergeStyleMerge, "", false))
+			return pr
+		}
+		prA := createQueuedPull("queue-a")
+		prB := createQueuedPull("queue-b")
+
+		req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/branch_protections/master", &api.EditBranchProtectionOption{
+			EnableMergeQueue:       util.ToPointer(true),
+			MergeQueueMaxBatchSize: util.ToPointer[int64](2),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+		mergequeue.StartMergeQueueCheck(repo.ID, "master")
+
+		// 1. both pull requests are tested together, the batch fails
+		batch := waitForMergeQueueBatch(t, repo, "")
+		require.Len(t, batch, 2)
+		assert.Equal(t, prA.ID, batch[0].PullID)
+		assert.Equal(t, prB.ID, batch[1].PullID)
+		setMergeQueueTestStatus(t, repo, user2, batch[0].BatchHeadCommitID, commitstatus.CommitStatusFailure)
+
+		// 2. the failed batch is bisected, the first pull request is tested alone and fails again
+		batch = waitForMergeQueueBatch(t, repo, batch[0].BatchHeadCommitID)
+		require.Len(t, batch, 1)
+		assert.Equal(t, prA.ID, batch[0].PullID)
+		setMergeQueueTestStatus(t, repo, user2, batch[0].BatchHeadCommitID, commitstatus.CommitStatusFailure)
+
+		// 3. the failing pull request is dequeued and the other one is tested alone
+		batch = waitForMergeQueueBatch(t, repo, batch[0].BatchHeadCommitID)
+		require.Len(t, batch, 1)
+		assert.Equal(t, prB.ID, batch[0].PullID)
+		unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: prA.ID})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{
+			IssueID: prA.IssueID,
+			Type:    issues_model.CommentTypePRRemovedFromMergeQueue,
+			Content: mergequeue.RemovedReasonChecksFailed,
+		})
+
+		// 4. the batch passes and the base branch is fast-forwarded to the tested commit
+		mergeCommitID := batch[0].MergeCommitID
+		setMergeQueueTestStatus(t, repo, user2, batch[0].BatchHeadCommitID, commitstatus.CommitStatusSuccess)
+		assert.Eventually(t, func() bool {
+			prB = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prB.ID})
+			return prB.HasMerged
+		}, 10*time.Second, 100*time.Millisecond)
+		assert.Equal(t, mergeCommitID, prB.MergedCommitID)
+		unittest.AssertNotExistsBean(t, &pull_model.MergeQueueEntry{PullID: prB.ID})
+
+		newMasterCommitID, err := gitrepo.GetBranchCommitID(t.Context(), repo, "master")
+		require.NoError(t, err)
+		assert.Equal(t, mergeCommitID, newMasterCommitID)
+
+		prA = unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{ID: prA.ID})
+		assert.False(t, prA.HasMerged)
+	})
+}
+
+// waitForMergeQueueBatch waits until a batch other than the previous one is under test and returns its entries
+func waitForMergeQueueBatch(t *testing.T, repo *repo_model.Repository, previousHeadCommitID string) []*pull_model.MergeQueueEntry {
+	var batch []*pull_model.MergeQueueEntry
+	require.Eventually(t, func() bool {
+		entries, err := pull_model.GetMergeQueueEntries(t.Context(), repo.ID, "master")
+		require.NoError(t, err)
+		batch = batch[:0]
+		for _, entry := range entries {
+			if entry.Status == pull_model.MergeQueueStatusTesting && entry.BatchHeadCommitID != previousHeadCommitID {
+				batch = append(batch, entry)
+			}
+		}
+		return len(batch) > 0
+	}, 10*time.Second, 100*time.Millisecond)
+	return batch
+}
+
+func setMergeQueueTestStatus(t *testing.T, repo *repo_model.Repository, doer *user_model.User, commitID string, state commitstatus.CommitStatusState) {
+	require.NoError(t, commitstatus_service.CreateCommitStatus(t.Context(), repo, doer, commitID, &git_model.CommitStatus{
+		State:   state,
+		Context: "ci",
+	}))
+}
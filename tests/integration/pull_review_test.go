@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"code.gitea.io/gitea/models/db"
+	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unittest"
@@ -19,11 +20,13 @@ import (
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/test"
 	issue_service "code.gitea.io/gitea/services/issue"
+	pull_service "code.gitea.io/gitea/services/pull"
 	repo_service "code.gitea.io/gitea/services/repository"
 	files_service "code.gitea.io/gitea/services/repository/files"
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPullView_ReviewerMissed(t *testing.T) {
@@ -295,3 +298,70 @@ func testIssueClose(t *testing.T, session *TestSession, owner, repo, issueNumber
 	req = NewRequestWithValues(t, "POST", closeURL, options)
 	return session.MakeRequest(t, req, http.StatusOK)
 }
+
+func TestPullMissingCodeOwnerApprovals(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, u *url.URL) {
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		user5 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+		repo, err := repo_service.CreateRepositoryDirectly(t.Context(), user2, user2, repo_service.CreateRepoOptions{
+			Name:             "test_codeowner_approvals",
+			Readme:           "Default",
+			AutoInit:         true,
+			ObjectFormatName: git.Sha1ObjectFormat.Name(),
+			DefaultBranch:    "master",
+		}, true)
+		require.NoError(t, err)
+
+		// neither an organization nor a team without members can approve a pull request
+		_, err = files_service.ChangeRepoFiles(t.Context(), repo, user2, &files_service.ChangeRepoFilesOptions{
+			OldBranch: repo.DefaultBranch,
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation:     "create",
+					TreePath:      "CODEOWNERS",
+					ContentReader: strings.NewReader("README.md @user5\nREADME.md @org3\nREADME.md @org26/team11\n"),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = files_service.ChangeRepoFiles(t.Context(), repo, user2, &files_service.ChangeRepoFilesOptions{
+			NewBranch: "codeowner-approvals",
+			Files: []*files_service.ChangeRepoFile{
+				{
+					Operation:     "update",
+					TreePath:      "README.md",
+					ContentReader: strings.NewReader("# This is a new project\n"),
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		session := loginUser(t, "user2")
+		testPullCreate(t, session, "user2", repo.Name, false, repo.DefaultBranch, "codeowner-approvals", "Test Pull Request")
+		pr := unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: repo.ID, HeadBranch: "codeowner-approvals"})
+		require.NoError(t, pr.LoadIssue(t.Context()))
+
+		pb := &git_model.ProtectedBranch{RepoID: repo.ID, RuleName: repo.DefaultBranch, BlockOnCodeOwnerReviews: true}
+		missing, err := pull_service.GetMissingCodeOwnerApprovals(t.Context(), pb, pr)
+		require.NoError(t, err)
+		require.Len(t, missing, 1)
+		assert.Equal(t, "README.md", missing[0].Pattern)
+		assert.Equal(t, []string{"README.md"}, missing[0].Paths)
+		require.Len(t, missing[0].Users, 1)
+		assert.Equal(t, user5.ID, missing[0].Users[0].ID)
+
+		_, err = issues_model.CreateReview(t.Context(), issues_model.CreateReviewOptions{
+			Type:     issues_model.ReviewTypeApprove,
+			Issue:    pr.Issue,
+			Reviewer: user5,
+			Official: true,
+		})
+		require.NoError(t, err)
+
+		missing, err = pull_service.GetMissingCodeOwnerApprovals(t.Context(), pb, pr)
+		require.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+}
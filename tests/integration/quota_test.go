@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	quota_model "code.gitea.io/gitea/models/quota"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/lfs"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuota(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Quota.Enabled, true)()
+	defer test.MockVariableValue(&setting.LFS.StartServer, true)()
+
+	createLFSTestRepository(t, "quota-repo")
+
+	admin := loginUser(t, "user1")
+	session := loginUser(t, "user2")
+
+	t.Run("Admin", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithValues(t, "POST", "/-/admin/quota/new", map[string]string{
+			"_csrf":       GetUserCSRFToken(t, admin),
+			"name":        "small",
+			"limit_lfs":   "4 B",
+			"limit_git":   "",
+			"limit_total": "-1",
+		})
+		admin.MakeRequest(t, req, http.StatusSeeOther)
+
+		g := unittest.AssertExistsAndLoadBean(t, &quota_model.Group{Name: "small"})
+		assert.EqualValues(t, 4, g.LimitLFS)
+		assert.EqualValues(t, -1, g.LimitGit)
+		assert.EqualValues(t, -1, g.LimitTotal)
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/-/admin/quota/%d", g.ID), map[string]string{
+			"_csrf":          GetUserCSRFToken(t, admin),
+			"limit_lfs":      "4",
+			"limit_packages": "1 KiB",
+		})
+		admin.MakeRequest(t, req, http.StatusSeeOther)
+
+		g = unittest.AssertExistsAndLoadBean(t, &quota_model.Group{ID: g.ID})
+		assert.EqualValues(t, 1024, g.LimitPackages)
+
+		req = NewRequestWithValues(t, "POST", fmt.Sprintf("/-/admin/quota/%d/users/add", g.ID), map[string]string{
+			"_csrf": GetUserCSRFToken(t, admin),
+			"name":  "user2",
+		})
+		admin.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertExistsAndLoadBean(t, &quota_model.GroupUser{GroupID: g.ID, UserID: 2})
+
+		resp := admin.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/-/admin/quota/%d", g.ID)), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "user2")
+		admin.MakeRequest(t, NewRequest(t, "GET", "/-/admin/quota"), http.StatusOK)
+
+		session.MakeRequest(t, NewRequest(t, "GET", "/-/admin/quota"), http.StatusForbidden)
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings/storage"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "small")
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/org/org3/settings/storage"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "The storage is not limited.")
+	})
+
+	t.Run("LFS", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		upload := func(content string, expectedStatus int) {
+			p := lfs.Pointer{Oid: fmt.Sprintf("%x", sha256.Sum256([]byte(content))), Size: int64(len(content))}
+			req := NewRequestWithBody(t, "PUT", path.Join("/user2/quota-repo.git/info/lfs/objects/", p.Oid, strconv.FormatInt(p.Size, 10)), strings.NewReader(content))
+			session.MakeRequest(t, req, expectedStatus)
+		}
+
+		// the repository of user2 with lfs objects already exceeds the small limit
+		upload("git", http.StatusRequestEntityTooLarge)
+
+		u, err := quota_model.GetUsage(t.Context(), 2)
+		require.NoError(t, err)
+		g := unittest.AssertExistsAndLoadBean(t, &quota_model.Group{Name: "small"})
+		g.LimitLFS = u.LFS + 8
+		require.NoError(t, quota_model.UpdateGroup(t.Context(), g))
+
+		// the uploaded objects count without a push in between
+		upload("gitea", http.StatusOK)
+		upload("git", http.StatusOK)
+		upload("a", http.StatusRequestEntityTooLarge)
+	})
+
+	t.Run("Packages", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := "/api/packages/user2/generic/quota/1.0.0/"
+
+		req := NewRequestWithBody(t, "PUT", url+"small.bin", strings.NewReader(strings.Repeat("a", 1000))).AddBasicAuth("user2")
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithBody(t, "PUT", url+"large.bin", strings.NewReader(strings.Repeat("a", 100))).AddBasicAuth("user2")
+		MakeRequest(t, req, http.StatusForbidden)
+
+		defer test.MockVariableValue(&setting.Quota.Enabled, false)()
+
+		req = NewRequestWithBody(t, "PUT", url+"large.bin", strings.NewReader(strings.Repeat("a", 100))).AddBasicAuth("user2")
+		MakeRequest(t, req, http.StatusCreated)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -27,8 +28,10 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 	"code.gitea.io/gitea/services/actions"
+	user_service "code.gitea.io/gitea/services/user"
 	"code.gitea.io/gitea/tests"
 
 	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
@@ -899,6 +902,107 @@ func Test_WebhookPackage(t *testing.T) {
 	})
 }
 
+func Test_WebhookMember(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		var payloads []api.MemberPayload
+		var triggeredEvent string
+		provider := newMockWebhookProvider(func(r *http.Request) {
+			content, _ := io.ReadAll(r.Body)
+			var payload api.MemberPayload
+			err := json.Unmarshal(content, &payload)
+			assert.NoError(t, err)
+			payloads = append(payloads, payload)
+			triggeredEvent = "member"
+		}, http.StatusOK)
+		defer provider.Close()
+
+		// 1. create a new webhook with special webhook for repo1
+		session := loginUser(t, "user2")
+
+		testAPICreateWebhookForRepo(t, session, "user2", "repo1", provider.URL(), "member")
+
+		// 2. trigger the webhook
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeAll)
+		req := NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/repo1/collaborators/user4", api.AddCollaboratorOption{
+			Permission: util.ToPointer("admin"),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", "/api/v1/repos/user2/repo1/collaborators/user4").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		// 3. validate the webhook is triggered
+		assert.Equal(t, "member", triggeredEvent)
+		require.Len(t, payloads, 2)
+		assert.EqualValues(t, "added", payloads[0].Action)
+		assert.Equal(t, "admin", payloads[0].Permission)
+		assert.Equal(t, "user4", payloads[0].Member.UserName)
+		assert.Equal(t, "user2/repo1", payloads[0].Repository.FullName)
+		assert.Equal(t, "user2", payloads[0].Sender.UserName)
+		assert.EqualValues(t, "removed", payloads[1].Action)
+		assert.Equal(t, "user4", payloads[1].Member.UserName)
+	})
+}
+
+func Test_WebhookUser(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		var payloads []api.UserPayload
+		provider := newMockWebhookProvider(func(r *http.Request) {
+			content, _ := io.ReadAll(r.Body)
+			var payload api.UserPayload
+			err := json.Unmarshal(content, &payload)
+			assert.NoError(t, err)
+			payloads = append(payloads, payload)
+		}, http.StatusOK)
+		defer provider.Close()
+
+		// 1. create a system webhook for user events
+		w := &webhook.Webhook{
+			URL:             provider.URL(),
+			HTTPMethod:      http.MethodPost,
+			ContentType:     webhook.ContentTypeJSON,
+			IsActive:        true,
+			IsSystemWebhook: true,
+			Type:            webhook_module.GITEA,
+			HookEvent: &webhook_module.HookEvent{
+				ChooseEvents: true,
+				HookEvents:   webhook_module.HookEvents{webhook_module.HookEventUser: true},
+			},
+		}
+		require.NoError(t, w.UpdateEvent())
+		require.NoError(t, webhook.CreateWebhook(t.Context(), w))
+
+		// 2. create a user like a provisioning source does, without going through the admin routes
+		u := &user_model.User{
+			Name:     "webhook-user",
+			Email:    "webhook-user@example.com",
+			Passwd:   "password",
+			IsActive: true,
+		}
+		require.NoError(t, user_service.CreateUser(t.Context(), nil, u, &user_model.Meta{}))
+
+		// 3. suspend and deactivate the user with a single admin edit
+		token := getTokenForLoggedInUser(t, loginUser(t, "user1"), auth_model.AccessTokenScopeWriteAdmin)
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/admin/users/webhook-user", api.EditUserOption{
+			LoginName:     "webhook-user",
+			ProhibitLogin: util.ToPointer(true),
+			Active:        util.ToPointer(false),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		// 4. validate the webhook is triggered once per change
+		require.Eventually(t, func() bool {
+			return len(payloads) == 2
+		}, 5*time.Second, 100*time.Millisecond)
+		assert.EqualValues(t, "created", payloads[0].Action)
+		assert.Equal(t, "webhook-user", payloads[0].User.UserName)
+		assert.Equal(t, user_model.GhostUserName, payloads[0].Sender.UserName)
+		assert.EqualValues(t, "suspended", payloads[1].Action)
+		assert.Equal(t, "webhook-user", payloads[1].User.UserName)
+		assert.Equal(t, "user1", payloads[1].Sender.UserName)
+	})
+}
+
 func Test_WebhookStatus(t *testing.T) {
 	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
 		var payloads []api.CommitStatusPayload
@@ -1743,3 +1847,134 @@ jobs:
 		assert.Equal(t, "user2/repo1", webhookData.payloads[i].Repo.FullName)
 	}
 }
+
+func Test_WebhookRetry(t *testing.T) {
+	defer test.MockVariableValue(&setting.Webhook.RetryBackoff, time.Millisecond)()
+	defer test.MockVariableValue(&setting.Webhook.AutoDisableThreshold, 1)()
+
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		var deliveries atomic.Int32
+		provider := newMockWebhookProvider(func(r *http.Request) {
+			deliveries.Add(1)
+		}, http.StatusInternalServerError)
+		defer provider.Close()
+
+		session := loginUser(t, "user2")
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeAll)
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+			Type: "gitea",
+			Config: api.CreateHookOptionConfig{
+				"content_type": "json",
+				"url":          provider.URL(),
+			},
+			Events:      []string{"create"},
+			Active:      true,
+			MaxAttempts: 2,
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var hook api.Hook
+		DecodeJSON(t, resp, &hook)
+		assert.Equal(t, 2, hook.MaxAttempts)
+
+		testAPICreateBranch(t, session, "user2", "repo1", "master", "retry-branch", http.StatusCreated)
+
+		assert.Eventually(t, func() bool {
+			w := unittest.AssertExistsAndLoadBean(t, &webhook.Webhook{ID: hook.ID})
+			return !w.IsActive
+		}, 10*time.Second, 100*time.Millisecond)
+		assert.EqualValues(t, 2, deliveries.Load())
+
+		tasks, err := webhook.HookTasks(t.Context(), hook.ID, 1)
+		assert.NoError(t, err)
+		require.Len(t, tasks, 2)
+		assert.Equal(t, 2, tasks[0].Attempt)
+		assert.True(t, tasks[0].IsExhausted)
+		assert.Equal(t, 1, tasks[1].Attempt)
+		assert.False(t, tasks[1].IsExhausted)
+		assert.Equal(t, http.StatusInternalServerError, tasks[1].ResponseInfo.Status)
+
+		link := fmt.Sprintf("/user2/repo1/settings/hooks/%d", hook.ID)
+		resp = session.MakeRequest(t, NewRequest(t, "GET", link+"?deliveries=failed"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), tasks[0].UUID)
+		assert.NotContains(t, resp.Body.String(), tasks[1].UUID)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, "2", htmlDoc.Find(`input[name="max_attempts"]`).AttrOr("value", ""))
+		assert.Equal(t, 1, htmlDoc.Find(".ui.warning.message").Length())
+	})
+}
+
+func Test_WebhookCustomPayload(t *testing.T) {
+	onGiteaRun(t, func(t *testing.T, giteaURL *url.URL) {
+		var bodies []string
+		var contentType string
+		provider := newMockWebhookProvider(func(r *http.Request) {
+			content, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(content))
+			contentType = r.Header.Get("Content-Type")
+		}, http.StatusOK)
+		defer provider.Close()
+
+		session := loginUser(t, "user2")
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeAll)
+
+		// an invalid template or condition is rejected
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+			Type: "custom",
+			Config: api.CreateHookOptionConfig{
+				"content_type":     "json",
+				"url":              provider.URL(),
+				"payload_template": "{{.ref",
+			},
+			Events: []string{"create"},
+			Active: true,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+			Type: "custom",
+			Config: api.CreateHookOptionConfig{
+				"content_type":     "json",
+				"url":              provider.URL(),
+				"payload_template": "{{.ref}}",
+			},
+			Events:    []string{"create"},
+			Active:    true,
+			Condition: "branch matches",
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/hooks", api.CreateHookOption{
+			Type: "custom",
+			Config: api.CreateHookOptionConfig{
+				"content_type":         "json",
+				"url":                  provider.URL(),
+				"payload_template":     `{{event}} {{.ref}} by {{.sender.login}}`,
+				"payload_content_type": "text/plain",
+			},
+			Events:    []string{"create"},
+			Active:    true,
+			Condition: "branch matches release/* and repo == user2/repo1",
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var hook api.Hook
+		DecodeJSON(t, resp, &hook)
+		assert.Equal(t, "branch matches release/* and repo == user2/repo1", hook.Condition)
+		assert.Equal(t, "text/plain", hook.Config["payload_content_type"])
+
+		testAPICreateBranch(t, session, "user2", "repo1", "master", "feature/custom", http.StatusCreated)
+		testAPICreateBranch(t, session, "user2", "repo1", "master", "release/1.0", http.StatusCreated)
+
+		assert.Equal(t, []string{"create release/1.0 by user2"}, bodies)
+		assert.Equal(t, "text/plain", contentType)
+
+		tasks, err := webhook.HookTasks(t.Context(), hook.ID, 1)
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/user2/repo1/settings/hooks/%d", hook.ID)), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, "{{event}} {{.ref}} by {{.sender.login}}", htmlDoc.Find(`textarea[name="payload_template"]`).Text())
+		assert.Equal(t, "branch matches release/* and repo == user2/repo1", htmlDoc.Find(`input[name="condition"]`).AttrOr("value", ""))
+	})
+}
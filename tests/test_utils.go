@@ -224,6 +224,9 @@ func PrepareCleanPackageData(t testing.TB) {
 		&packages_model.PackageProperty{},
 		&packages_model.PackageBlobUpload{},
 		&packages_model.PackageCleanupRule{},
+		&packages_model.PackageRemote{},
+		&packages_model.PackageRemoteFile{},
+		&packages_model.PackageVirtualSource{},
 	))
 	assert.NoError(t, storage.Clean(storage.Packages))
 }
@@ -142,9 +142,9 @@ function initAdminAuthentication() {
   // New authentication
   if (isNewPage) {
     const onAuthTypeChange = function () {
-      hideElem('.ldap, .dldap, .smtp, .pam, .oauth2, .has-tls, .search-page-size, .sspi');
+      hideElem('.ldap, .dldap, .smtp, .pam, .oauth2, .has-tls, .search-page-size, .sspi, .saml');
 
-      for (const input of document.querySelectorAll<HTMLInputElement>('.ldap input[required], .binddnrequired input[required], .dldap input[required], .smtp input[required], .pam input[required], .oauth2 input[required], .has-tls input[required], .sspi input[required]')) {
+      for (const input of document.querySelectorAll<HTMLInputElement>('.ldap input[required], .binddnrequired input[required], .dldap input[required], .smtp input[required], .pam input[required], .oauth2 input[required], .has-tls input[required], .sspi input[required], .saml input[required], .saml textarea[required]')) {
         input.removeAttribute('required');
       }
 
@@ -191,6 +191,12 @@ function initAdminAuthentication() {
             input.setAttribute('required', 'required');
           }
           break;
+        case '8': // SAML
+          showElem('.saml');
+          for (const input of document.querySelectorAll<HTMLInputElement>('.saml div.required input, .saml div.required textarea')) {
+            input.setAttribute('required', 'required');
+          }
+          break;
       }
       if (authType === '2' || authType === '5') {
         onSecurityProtocolChange();